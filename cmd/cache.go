@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/leonardomso/gone/internal/checker"
+	"github.com/leonardomso/gone/internal/output"
+	"github.com/leonardomso/gone/internal/parser"
+	"github.com/leonardomso/gone/internal/scanner"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultWarmConcurrency keeps cache warming gentle on the hosts being checked.
+const defaultWarmConcurrency = 10
+
+// Cache command flag variables.
+var (
+	warmConcurrency int
+	warmTimeout     int
+	warmRetries     int
+	warmFileTypes   []string
+	warmNoConfig    bool
+)
+
+// cacheCmd groups commands that manage the persistent result cache.
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the persistent result cache",
+	Long: `Manage the persistent result cache used by 'gone check --cache'.
+
+Results are stored per URL and reused until they are older than the
+cache TTL (24h by default). Network errors, 5xx and 429 responses are
+never cached, so they are always checked again.
+
+Config file (.gonerc.yaml):
+  cache:
+    enabled: true               # Use the cache for every check
+    path: .gone-cache.json      # Defaults to the user cache directory
+    ttl: 24h                    # How long results stay fresh`,
+}
+
+// cacheWarmCmd checks every unique URL only to populate the cache.
+var cacheWarmCmd = &cobra.Command{
+	Use:   "warm [path|report.json]",
+	Short: "Check all URLs to populate the cache",
+	Long: `Check every unique URL to populate the persistent cache, without
+printing a report. Intended for nightly jobs, so that daytime checks
+with --cache are nearly instant.
+
+The argument is a directory to scan (default ".") or a JSON report
+written by 'gone check --output=report.json'. Every URL is checked again,
+even if it is already cached. Runs with low concurrency by default and
+always exits 0 once the cache is written: dead links are not failures here.
+
+Examples:
+  gone cache warm                    # Warm from the current directory
+  gone cache warm ./docs             # Warm from a specific directory
+  gone cache warm report.json        # Warm from the URLs in a JSON report
+  gone cache warm --concurrency=4    # Be even gentler on remote hosts`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runCacheWarm,
+}
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheWarmCmd)
+
+	cacheWarmCmd.Flags().IntVarP(&warmConcurrency, "concurrency", "c", defaultWarmConcurrency,
+		"Number of concurrent workers")
+	cacheWarmCmd.Flags().IntVarP(&warmTimeout, "timeout", "t", int(checker.DefaultTimeout.Seconds()),
+		"Timeout per request in seconds")
+	cacheWarmCmd.Flags().IntVarP(&warmRetries, "retries", "r", checker.DefaultMaxRetries,
+		"Number of retries for failed requests")
+	cacheWarmCmd.Flags().StringSliceVarP(&warmFileTypes, "types", "T", []string{"md"},
+		"File types to scan: md, json, yaml, toml, xml, html, ini, env, properties, docker, code, txt")
+	cacheWarmCmd.Flags().BoolVar(&warmNoConfig, "no-config", false,
+		"Skip loading .gonerc.yaml config file")
+}
+
+// runCacheWarm is the entry point for the cache warm command.
+func runCacheWarm(_ *cobra.Command, args []string) {
+	loadedCfg, err := LoadConfig(warmNoConfig)
+	exitOnError(err, "Config error")
+
+	links, err := warmLinks(getPathArg(args), loadedCfg)
+	exitOnError(err, "")
+
+	resultCache, err := loadedCfg.OpenCache()
+	exitOnError(err, "Error opening cache")
+
+	uniqueURLs := CountUniqueURLs(links)
+	fmt.Printf("Warming cache with %d unique URL(s)...\n", uniqueURLs)
+
+	// Config check settings apply, but concurrency stays low unless set on the CLI
+	opts := loadedCfg.BuildCheckerOptions(warmConcurrency, warmTimeout, warmRetries)
+	opts = opts.WithCache(resultCache.WriteOnly(opts))
+	checker.New(opts).CheckAll(links)
+
+	exitOnError(resultCache.Save(), "Error saving cache")
+	fmt.Printf("Cache now holds %d URL(s): %s\n", resultCache.Len(), resultCache.Path())
+}
+
+// warmLinks collects the links to warm from a JSON report or a directory scan.
+// Ignore rules from config apply in both cases.
+func warmLinks(path string, cfg *LoadedConfig) ([]checker.Link, error) {
+	var parserLinks []parser.Link
+
+	info, err := os.Stat(path)
+	switch {
+	case err != nil:
+		return nil, err
+	case !info.IsDir() && strings.EqualFold(filepath.Ext(path), ".json"):
+		data, err := os.ReadFile(path) //nolint:gosec // Path comes from the user
+		if err != nil {
+			return nil, err
+		}
+		links, err := output.ReadJSONLinks(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		for _, l := range links {
+			parserLinks = append(parserLinks, parser.Link{URL: l.URL, FilePath: l.FilePath, Line: l.Line})
+		}
+	default:
+		if err := validateFileTypes(cfg.GetTypes(warmFileTypes, []string{"md"})); err != nil {
+			return nil, err
+		}
+		files, err := scanner.FindFilesWithOptions(cfg.BuildScanOptions(path, warmFileTypes, []string{"md"}))
+		if err != nil {
+			return nil, fmt.Errorf("scanning directory: %w", err)
+		}
+		parserLinks, _, err = parser.ExtractLinksWithBudget(files, false, cfg.BuildParseBudget(0))
+		if err != nil {
+			return nil, fmt.Errorf("parsing files: %w", err)
+		}
+	}
+
+	urlFilter, err := CreateFilterWithConfig(cfg.Config(), nil, nil, nil, false)
+	if err != nil {
+		return nil, fmt.Errorf("creating filter: %w", err)
+	}
+	return FilterParserLinks(parserLinks, urlFilter), nil
+}
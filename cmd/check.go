@@ -1,41 +1,158 @@
 package cmd
 
 import (
+	"cmp"
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"net/http"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
+	"text/template"
+	"time"
 
+	"github.com/leonardomso/gone/internal/cache"
 	"github.com/leonardomso/gone/internal/checker"
 	"github.com/leonardomso/gone/internal/filter"
+	"github.com/leonardomso/gone/internal/lint"
 	"github.com/leonardomso/gone/internal/output"
 	"github.com/leonardomso/gone/internal/parser"
+	"github.com/leonardomso/gone/internal/parser/markdown"
+	"github.com/leonardomso/gone/internal/relative"
 	"github.com/leonardomso/gone/internal/scanner"
+	"github.com/leonardomso/gone/internal/source"
 	"github.com/leonardomso/gone/internal/stats"
+	"github.com/leonardomso/gone/internal/term"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 // Flag variables for the check command.
 var (
-	outputFormat string
-	outputFile   string
-	concurrency  int
-	timeout      int
-	retries      int
-	showAlive    bool
-	showWarnings bool
-	showDead     bool
-	showAll      bool
-	showStats    bool
+	outputFormat  string
+	templatePath  string
+	outputFiles   []string
+	metricsFile   string
+	baselinePath  string
+	concurrency   int
+	timeout       int
+	retries       int
+	maxRedirects  int
+	warnHops      int
+	redirectMode  string
+	showAlive     bool
+	showWarnings  bool
+	showDead      bool
+	showAll       bool
+	showStats     bool
+	uniqueDead    bool
+	byDomain      bool
+	expandDups    bool
+	fromURL       string
+	fromManifest  string
+	gitURL        string
+	loginPattern  []string
+	priorityPath  []string
+	offlineMode   bool
+	probeHosts    bool
+	inspectPages  bool
+	checkAnchors  bool
+	detectSoft404 bool
+	checkInternal bool
+	insecureTLS   bool
+	acceptStatus  []int
+	noResolvers   []string
+	parseTimeout  time.Duration
+	lintLinks     bool
+	recordPath    string
+	useCache      bool
+	resume        bool
+	useHTTP3      bool
+	enableCookies bool
+	uaProfile     string
+	ipFamily      string
+	dnsServer     string
+	maxDuration   time.Duration
+	maxRequests   int
+	staleAfter    string
+	replayPath    string
+	noColor       bool
+	noProgress    bool
+	themeName     string
+	groupBy       string
+	sortBy        string
+	topResults    int
+	failOn        string
+	reportAll     bool
+	junitSuitePer string
+	junitPassed   bool
 
 	// File type flags.
 	fileTypes  []string
 	strictMode bool
 
+	// Fix suggestion flags.
+	suggestArchive bool
+	suggestHTTPS   bool
+
+	// reportTemplate is the parsed --template, for --format=template.
+	reportTemplate *template.Template
+
+	// resultGrouping is the effective --group-by.
+	resultGrouping = output.GroupByStatus
+
+	// resultOrder and resultLimit are the effective --sort and --top.
+	resultOrder output.SortKey
+	resultLimit int
+
+	// junitSuites and junitAll are the effective --junit-suite-per and
+	// --junit-include-passed.
+	junitSuites = output.GroupByFile
+	junitAll    bool
+
+	// failureLevel is the effective --fail-on.
+	failureLevel = failOnDead
+
+	// priorityFiles holds the scanned files matching --priority-paths.
+	priorityFiles map[string]bool
+
+	// skippedFiles holds files skipped for exceeding the parse budget, for the report.
+	skippedFiles []parser.SkippedFile
+
+	// lintFindings holds lint issues found when --lint is enabled, for the report.
+	lintFindings []lint.Finding
+
+	// parseErrors holds files that failed to parse in strict mode; any error fails the run.
+	parseErrors []parser.ParseError
+
+	// policyViolations holds links matching deny rules; any violation fails the run.
+	policyViolations []filter.IgnoreReason
+
+	// repoDir is the temporary clone of a remote repository being checked.
+	repoDir string
+
+	// resultStream writes results as they're checked with --format=ndjson.
+	resultStream *output.NDJSONWriter
+
+	// fixedLinks holds the baseline's dead links that no longer are (--baseline).
+	fixedLinks []checker.Result
+
+	// inventory holds every result for the Markdown report's Full Inventory
+	// section (--report-all), including those --baseline leaves out.
+	inventory []checker.Result
+
+	// internalFindings holds relative links to missing files or anchors; any finding fails the run.
+	internalFindings []relative.Finding
+
 	// Ignore flags.
 	ignoreDomains  []string
 	ignorePatterns []string
 	ignoreRegex    []string
+	skipBadges     bool
 	showIgnored    bool
 	noConfig       bool
 )
@@ -46,41 +163,122 @@ var checkCmd = &cobra.Command{
 	Short: "Scan files for dead links",
 	Long: `Scan a directory for files and check all HTTP/HTTPS links.
 
-If no path is provided, scans the current directory.
+If no path is provided, scans the current directory. A git repository URL
+is shallow-cloned into a temporary directory and scanned instead.
 By default, scans only markdown files (.md).
-Use --types to scan additional file types.
+Use --types to scan additional file types. A single file is checked
+as its own type, so 'gone check sitemap.xml' checks every page of a sitemap.
+Zip and tar archives (.zip, .tar, .tar.gz, .tgz) are scanned like
+directories without extracting them, and their files are reported as
+docs.zip!/guide/intro.md.
 
 By default, shows warnings (redirects, blocked) and dead links.
 Use flags to filter what's displayed.
 
+Results that 'gone fix' could repair (working redirects, rewrite rules,
+normalization, broken anchors) carry the replacement URL as a suggestion in
+every output format. With --suggest-archive, dead links get their latest
+Wayback Machine snapshot as a suggestion, and with --suggest-https, working
+http:// links their https:// version, as 'gone fix --archive-dead' and
+'--upgrade-https' would apply; both are looked up once the check is done.
+
 Exit codes:
-  0 - All links are alive or only have warnings
-  1 - Dead links or errors found
+  0 - No failures (what fails the run is set by --fail-on)
+  1 - Dead links, links matching deny rules, broken internal links (--check-internal),
+      or malformed files (--strict)
+  2 - Only warnings such as redirects and blocked links (--fail-on=warnings)
+  3 - Invalid flags or config, or an error that stopped the run
+
+With --fail-on=none, dead links, broken internal links, and warnings don't
+fail the run; links matching deny rules and malformed files (--strict) still do.
 
 Examples:
   gone check                         # Scan current directory (markdown only)
   gone check ./docs                  # Scan specific directory  
   gone check --types=md,json,yaml    # Scan markdown, JSON, and YAML files
   gone check --types=toml,xml        # Scan TOML and XML files
+  gone check --types=md,code         # Also check URLs in source code comments
+  gone check sitemap.xml             # Check every page listed in a sitemap
+  gone check docs.zip --types=md,html  # Check the pages of a documentation bundle
+  gone check https://github.com/org/awesome-list    # Clone a repository and check it
   gone check --types=json --strict   # Fail on malformed JSON files
+  gone check --from-url=https://example.com/links.txt  # Check a remote link list
+  gone check --from-manifest=package.json              # Check homepage, repository, bugs URLs
+  gone check --from-manifest=.                         # Check the URLs of every manifest in a directory
   gone check --format=json           # Output JSON to stdout
   gone check --format=yaml           # Output YAML to stdout
+  gone check --format=github         # Annotate dead links in a GitHub Actions run
+  gone check --format=ndjson         # Stream results as JSON Lines while checking
+  gone check --output=links.csv      # Write results as CSV for a spreadsheet
+  gone check --format=tap            # Output TAP for a test harness
+  gone check --format=template --template=slack.tmpl  # Render a custom report, e.g. a Slack message
+  gone check --metrics-file=/var/lib/node_exporter/gone.prom  # Export metrics for dashboards
+  gone check --baseline=links.json   # Fail only on links broken since links.json was written
+  gone check --fail-on=warnings      # Also fail (with exit code 2) on redirects and blocked links
+  gone check --theme=ansi            # Color text output with the terminal's own palette
+  gone check --group-by=domain       # List results per domain to spot a dead host
+  gone check --all --sort=latency --top=20  # List the 20 slowest links
   gone check --output=report.json    # Write JSON report to file
   gone check --output=report.md      # Write Markdown report to file
+  gone check -o links.md --report-all  # Also list every link by file, as a link inventory
   gone check --output=report.junit.xml  # Write JUnit XML for CI/CD
+  gone check -o report.junit.xml --junit-include-passed --junit-suite-per=domain  # Every link, a suite per domain
+  gone check --output=links.shield.json # Write shields.io endpoint badge JSON
+  gone check --output=report.html    # Write a standalone HTML report
+  gone check -o report.json -o report.md -o report.junit.xml  # Write several formats from one run
   gone check --all                   # Show all results including alive
   gone check --dead                  # Show only dead links and errors
+  gone check --unique                # List each dead URL once with all its locations
+  gone check --by-domain             # Break results down by destination domain
+  gone check --format=json --expand-duplicates  # Full check data on every duplicate occurrence
   gone check --concurrency=100       # Use 100 concurrent workers
+  gone check --redirect-warn-hops=2  # Flag redirect chains longer than 2 hops
+  gone check --redirect-policy=lenient  # Don't warn about http→https or trailing-slash redirects
+  gone check --probe-hosts --stats   # Probe each host first, show probe results
+  gone check --inspect-pages         # Hint at working pages marked noindex
+  gone check --check-anchors         # Verify #fragments exist on their target pages
+  gone check --detect-soft-404       # Flag 200 pages that say "not found"
+  gone check --check-internal        # Verify relative links and heading anchors between files
+  gone check --accept-status=403,999 # Report these status codes as alive
+  gone check --insecure              # Report certificate problems as warnings
+  gone check --disable-resolver=github  # Check GitHub links as pages, not through the API
+  gone check --lint                  # Flag link text that shows a different URL
+  gone check --http3                 # Try HTTP/3 first; blocked/dead links show the protocol
+  gone check --enable-cookies        # Replay cookies along redirect chains
+  gone check --ua-profile=firefox    # Send Firefox's headers instead of gone's own
+  gone check --ip-family=ipv4        # Connect over IPv4 only (for runners with broken IPv6)
+  gone check --dns-server=1.1.1.1    # Resolve hosts with 1.1.1.1 instead of the system resolver
+  gone check --max-duration=5m       # Stop checking after 5 minutes; the rest is reported as skipped
+  gone check --max-requests=2000     # Send at most 2000 requests; the rest is reported as skipped
+  gone check --stale-after=5y        # Hint at pages not modified in 5 years
+  gone check --login-pattern=/auth/  # Treat redirects to /auth/ as login walls
+  gone check --resume                # Pick up an interrupted run where it stopped
   gone check --stats                 # Show performance statistics
+  gone check --offline               # Validate files and URL syntax without network access
+  gone check --parse-timeout=10s     # Skip files that take longer than 10s to parse
+  gone check --priority-paths="README.md,docs/**"  # Check the most visible docs first
+  gone check --cache                 # Skip URLs checked recently (see 'gone cache warm')
+  gone check --record=cassette.json  # Record HTTP responses for a reproducible bug report
+  gone check --replay=cassette.json  # Re-run a recorded check without network access
 
 Note: --format and --output are mutually exclusive.
 
-Supported file types: md (includes .mdx, .markdown), json, yaml (includes .yml), toml, xml
+Offline mode (--offline) scans and parses files, applies ignore rules and
+detects duplicates without making any requests. Malformed files (as with
+--strict) and links matching deny rules always fail it with exit code 1;
+malformed URLs and broken internal links (--check-internal) do unless
+--fail-on=none.
+
+Supported file types: md (includes .mdx, .markdown), json, yaml (includes .yml), toml, xml, html (includes .htm),
+ini (includes .conf), env, properties, docker (dockerfile and compose files), and code: URLs in the comments
+of go, py, js, ts, rs, and java files (also selectable one by one). txt is an opt-in fallback that
+extracts every URL from .txt files and extensionless CHANGELOG, LICENSE, NOTICE, and similar files
 
 Ignore patterns:
   gone check --ignore-domain=localhost,example.com
   gone check --ignore-pattern="*.local/*"
   gone check --ignore-regex=".*\\.test$"
+  gone check --skip-badges           # Skip shields.io and CI status badges
   gone check --show-ignored          # Show which URLs were ignored
 
 Config file (.gonerc.yaml):
@@ -88,64 +286,242 @@ Config file (.gonerc.yaml):
   scan:
     include: ["docs/**"]        # Only scan matching paths
     exclude: ["vendor/**"]      # Skip matching paths
+    max_file_size: 5000000      # Skip files larger than this (bytes)
+    parse_timeout: 10s          # Skip files that take longer to parse
+    priority_paths: [README.md] # Parse and check first, exempt from the budget
   check:
     concurrency: 100            # Concurrent workers
     timeout: 30                 # Request timeout (seconds)
     retries: 2                  # Retry attempts
     strict: false               # Fail on malformed files
+    max_redirects: 5            # Redirect hops to follow
+    domain_max_redirects:       # Per-domain redirect limits
+      github.com: 10
+    status_codes:               # Override status code classification
+      alive: [999]
+      dead: [403]
+    headers:                    # Sent with every request
+      X-Requested-By: gone
+    domain_headers:             # Sent only to these domains ($VARS are expanded)
+      jira.example.com:
+        Authorization: "Bearer ${JIRA_TOKEN}"
+    disable_resolvers: [youtube] # Check these as pages, not through their API
+    content_rules:              # Pages that answer 200 with a "Not Found" body
+      - pattern: "https://app.example.com/*"
+        must_contain: "<h1>"
+    force_get_domains:          # Hosts that misbehave on HEAD
+      - api.example.com
+    redirect_warn_hops: 2       # Warn on chains longer than this
+    redirect_policy: lenient    # http→https and trailing-slash redirects are alive
+    probe_hosts: true           # Pre-flight probe of every unique host
+    inspect_pages: true         # Fetch pages to detect noindex stubs
+    check_anchors: true         # Verify #fragments exist on target pages
+    detect_soft_404: true       # Flag 200 pages that say "not found"
+    check_internal: true        # Verify relative links and heading anchors
+    insecure: true              # Certificate problems are warnings, not dead links
+    lint: true                  # Flag link text showing a different URL
+    http3: true                 # Try HTTP/3 first, fall back to TCP
+    enable_cookies: true        # Replay cookies along redirect chains
+    ua_profile: corp            # default, chrome, firefox, curl, or a ua_profiles key
+    ua_profiles:
+      corp:
+        User-Agent: corp-bot/1.0
+    ip_family: ipv4             # Connect over IPv4 only
+    dns_server: 1.1.1.1         # Resolve hosts with this DNS server
+    max_duration: 5m            # Report links not checked within 5 minutes as skipped
+    max_requests: 2000          # Report links left without requests as skipped
+    stale_after: 5y             # Hint at pages not modified in 5 years
+    fail_on: warnings           # dead (default), warnings, or none
+    login_patterns:             # Extra login/SSO pages (added to the built-ins)
+      - sso.example.com
   output:
     showStats: true             # Show performance stats
+    format: template            # Render the template below instead of text
+    template: slack.tmpl        # Go text/template for --format=template
+    reportAll: true             # List every link in Markdown reports
+    junitSuitePer: domain       # A JUnit test suite per file (default) or domain
+    junitIncludePassed: true    # Every link is a JUnit test case
+  cache:
+    enabled: true               # Same as --cache
+    path: .gone-cache.json      # Defaults to the user cache directory
+    ttl: 24h                    # How long results stay fresh
   ignore:
     domains: [localhost, example.com]
     patterns: ["*.local/*"]
-    regex: [".*\\.test$"]`,
+    regex: [".*\\.test$"]
+  deny:                         # Links that must never appear (fail the run)
+    domains: [intranet.corp]
+    patterns: ["*tracker.example/*"]
+    regex: ["^http://"]`,
 	Args: cobra.MaximumNArgs(1),
 	Run:  runCheck,
 }
 
 func init() {
 	rootCmd.AddCommand(checkCmd)
+	addCheckFlags(checkCmd.Flags())
+}
 
+// addCheckFlags registers the flags of the check command on flags. gone config
+// show registers them too, to show the configuration a check would run with.
+func addCheckFlags(flags *pflag.FlagSet) {
 	// Output options
-	checkCmd.Flags().StringVarP(&outputFormat, "format", "f", "",
-		"Output format for stdout: json, yaml, xml, junit, markdown")
-	checkCmd.Flags().StringVarP(&outputFile, "output", "o", "",
-		"Write report to file (format inferred from extension: .json, .yaml, .xml, .junit.xml, .md)")
+	flags.StringVarP(&outputFormat, "format", "f", "",
+		"Output format for stdout: json, yaml, xml, junit, markdown, shield-json, html, github, csv, ndjson, tap, "+
+			"prometheus, template")
+	flags.StringVar(&templatePath, "template", "",
+		"Go text/template file to render the report with, for --format=template")
+	flags.StringSliceVarP(&outputFiles, "output", "o", nil,
+		"Write report to file (format inferred from extension: "+
+			".json, .yaml, .xml, .junit.xml, .md, .shield.json, .html, .csv, .ndjson, .tap, .prom; can be repeated)")
+	flags.StringVar(&metricsFile, "metrics-file", "",
+		"Also write Prometheus metrics to this file, e.g. for node_exporter's textfile collector")
+	flags.StringVar(&baselinePath, "baseline", "",
+		"Compare with a JSON report from an earlier run: report only newly broken and fixed links, "+
+			"and fail only on newly broken ones")
+	flags.StringVar(&failOn, "fail-on", "",
+		"What fails the run: dead (default; exit code 1), warnings (also exit code 2 if there are only warnings), "+
+			"or none")
+	flags.BoolVar(&noColor, "no-color", false,
+		"Disable colors and hyperlinks in text output (also set by the NO_COLOR environment variable)")
+	flags.BoolVar(&noProgress, "no-progress", false,
+		"Don't show a progress line on stderr while checking (it's only shown when stderr is a terminal)")
+	flags.StringVar(&themeName, "theme", "",
+		"Color theme for text output: default, ansi (the terminal's own 16 colors), mono")
 
 	// File type options
-	checkCmd.Flags().StringSliceVarP(&fileTypes, "types", "T", []string{"md"},
-		"File types to scan: md (includes .mdx, .markdown), json, yaml, toml, xml")
-	checkCmd.Flags().BoolVar(&strictMode, "strict", false,
-		"Fail on malformed files instead of skipping them")
+	flags.StringSliceVarP(&fileTypes, "types", "T", []string{"md"},
+		"File types to scan: md (includes .mdx, .markdown), json, yaml, toml, xml, html, ini, env, properties, "+
+			"docker, code (comments in source files), txt (plain text, LICENSE, CHANGELOG, ...)")
+	flags.BoolVar(&strictMode, "strict", false,
+		"Report malformed files and fail the run instead of skipping them")
+	flags.StringVar(&fromURL, "from-url", "",
+		"Check the URLs listed one per line in a remote text file instead of scanning a directory")
+	flags.StringVar(&fromManifest, "from-manifest", "",
+		"Check the project URLs in a package manifest (package.json, pyproject.toml, Cargo.toml, "+
+			"composer.json, go.mod), or in every manifest of a directory, instead of scanning a directory")
+	flags.StringVar(&gitURL, "git-url", "",
+		"Shallow-clone a git repository into a temporary directory and scan it (or the path argument inside it)")
+	flags.StringSliceVar(&priorityPath, "priority-paths", nil,
+		"Glob patterns for files to parse and check first, exempt from the parse budget (e.g. \"README.md,docs/**\")")
+	flags.DurationVar(&parseTimeout, "parse-timeout", 0,
+		"Skip (and report) any file that takes longer than this to parse, e.g. 10s (0 disables)")
 
 	// Filter flags
-	checkCmd.Flags().BoolVarP(&showAll, "all", "a", false, "Show all results (alive, warnings, dead)")
-	checkCmd.Flags().BoolVar(&showAlive, "alive", false, "Show only alive links")
-	checkCmd.Flags().BoolVarP(&showWarnings, "warnings", "w", false, "Show only warnings (redirects, blocked)")
-	checkCmd.Flags().BoolVarP(&showDead, "dead", "d", false, "Show only dead links and errors")
+	flags.BoolVarP(&showAll, "all", "a", false, "Show all results (alive, warnings, dead)")
+	flags.BoolVar(&showAlive, "alive", false, "Show only alive links")
+	flags.BoolVarP(&showWarnings, "warnings", "w", false, "Show only warnings (redirects, blocked)")
+	flags.BoolVarP(&showDead, "dead", "d", false, "Show only dead links and errors")
+	flags.BoolVar(&uniqueDead, "unique", false,
+		"List each dead URL once with its occurrence count and locations")
+	flags.StringVar(&groupBy, "group-by", "",
+		"Group results in text, Markdown, and HTML output by status (default), file, or domain")
+	flags.StringVar(&sortBy, "sort", "",
+		"Order results in every format by url, status (dead first), file, or latency (slowest first)")
+	flags.IntVar(&topResults, "top", 0,
+		"List only the first N results, after --sort (0 lists all)")
+	flags.BoolVar(&reportAll, "report-all", false,
+		"Add a Full Inventory section to Markdown reports, listing every link by file with its status")
+	flags.StringVar(&junitSuitePer, "junit-suite-per", "",
+		"Group JUnit test cases into a test suite per file (default) or domain")
+	flags.BoolVar(&junitPassed, "junit-include-passed", false,
+		"Add links that didn't fail to JUnit reports as passing (or skipped) test cases, whatever the --show flags")
+	flags.BoolVar(&byDomain, "by-domain", false,
+		"Add a per-domain breakdown: status counts, matching ignore/deny rules, and time spent")
+	flags.BoolVar(&expandDups, "expand-duplicates", false,
+		"Copy the full check data (status, redirect chain, error, duration) into every duplicate result")
 
 	// Performance options
-	checkCmd.Flags().IntVarP(&concurrency, "concurrency", "c", checker.DefaultConcurrency,
+	flags.IntVarP(&concurrency, "concurrency", "c", checker.DefaultConcurrency,
 		"Number of concurrent workers")
-	checkCmd.Flags().IntVarP(&timeout, "timeout", "t", int(checker.DefaultTimeout.Seconds()),
+	flags.IntVarP(&timeout, "timeout", "t", int(checker.DefaultTimeout.Seconds()),
 		"Timeout per request in seconds")
-	checkCmd.Flags().IntVarP(&retries, "retries", "r", checker.DefaultMaxRetries,
+	flags.IntVarP(&retries, "retries", "r", checker.DefaultMaxRetries,
 		"Number of retries for failed requests")
+	flags.IntVar(&maxRedirects, "max-redirects", checker.DefaultMaxRedirects,
+		"Maximum number of redirects to follow")
+	flags.IntSliceVar(&acceptStatus, "accept-status", nil,
+		"Status codes to report as alive, e.g. 999 for LinkedIn (can be repeated or comma-separated)")
+	flags.StringSliceVar(&noResolvers, "disable-resolver", nil,
+		"Check links on these hosts as regular pages instead of through their API: github, npm, pypi, youtube")
+	flags.StringVar(&redirectMode, "redirect-policy", "",
+		"Which working redirects are warnings: strict (all), ignore-https-upgrade, or lenient "+
+			"(not http→https or trailing-slash changes)")
+	flags.IntVar(&warnHops, "redirect-warn-hops", 0,
+		"Report redirects with more hops than this as long-redirect warnings (0 disables)")
+	flags.IntVar(&warnHops, "max-acceptable-hops", 0, "Same as --redirect-warn-hops")
+	flags.BoolVar(&probeHosts, "probe-hosts", false,
+		"Probe each unique host once before checking to skip dead hosts and defer rate-limited ones")
+	flags.BoolVar(&inspectPages, "inspect-pages", false,
+		"Fetch working pages and hint at targets marked noindex (often deprecation stubs)")
+	flags.BoolVar(&checkAnchors, "check-anchors", false,
+		"Fetch working pages linked with a #fragment and report fragments that match no heading or anchor")
+	flags.BoolVar(&detectSoft404, "detect-soft-404", false,
+		"Fetch working pages and report those that look like \"not found\" or parked-domain pages as suspect")
+	flags.BoolVar(&checkInternal, "check-internal", false,
+		"Resolve relative markdown links against the file system and report missing files and heading anchors")
+	flags.BoolVar(&insecureTLS, "insecure", false,
+		"Skip TLS certificate verification; certificate problems are reported as warnings instead of dead links")
+	flags.BoolVar(&lintLinks, "lint", false,
+		"Flag links whose visible text is a different URL than the target")
+	flags.BoolVar(&useHTTP3, "http3", false,
+		"Try HTTP/3 (QUIC) first, falling back to HTTP/2 or HTTP/1.1 per host")
+	flags.StringVar(&uaProfile, "ua-profile", "",
+		"Send requests with this header profile: default, chrome, firefox, curl, or one from check.ua_profiles")
+	flags.BoolVar(&enableCookies, "enable-cookies", false,
+		"Keep cookies set by responses and send them with later requests to the same host")
+	flags.StringVar(&ipFamily, "ip-family", "",
+		"Connect over this IP family only: auto, ipv4, or ipv6 (default auto)")
+	flags.StringVar(&dnsServer, "dns-server", "",
+		"Resolve host names with this DNS server (IP with optional port) instead of the system resolver")
+	flags.DurationVar(&maxDuration, "max-duration", 0,
+		"Stop checking after this long (e.g. 5m); links not checked in time are reported as skipped")
+	flags.IntVar(&maxRequests, "max-requests", 0,
+		"Send at most this many HTTP requests; links left without requests are reported as skipped")
+	flags.StringSliceVar(&loginPattern, "login-pattern", nil,
+		"Extra login page patterns (host or path fragment) for login-required detection (can be repeated)")
+	flags.StringVar(&staleAfter, "stale-after", "",
+		"Hint at working links whose Last-Modified is older than this (e.g. 5y, 18w, 90d)")
 
 	// Stats flag
-	checkCmd.Flags().BoolVar(&showStats, "stats", false,
+	flags.BoolVar(&showStats, "stats", false,
 		"Show detailed performance statistics")
 
+	// Offline mode
+	flags.BoolVar(&offlineMode, "offline", false,
+		"Validate files and URL syntax without network access (implies --strict)")
+
+	// Persistent cache
+	flags.BoolVar(&useCache, "cache", false,
+		"Reuse fresh results from the persistent cache and store new ones (see 'gone cache warm')")
+
+	flags.BoolVar(&resume, "resume", false,
+		"Journal results as they complete and, after an interruption, check only the URLs still pending")
+
+	// Fix suggestions
+	flags.BoolVar(&suggestArchive, "suggest-archive", false,
+		"Suggest the latest Wayback Machine snapshot of dead links as their fix")
+	flags.BoolVar(&suggestHTTPS, "suggest-https", false,
+		"Suggest moving working http:// links to https:// when it serves the same content")
+
+	// Record and replay
+	flags.StringVar(&recordPath, "record", "",
+		"Record every HTTP response to a cassette file for later --replay")
+	flags.StringVar(&replayPath, "replay", "",
+		"Answer requests from a cassette recorded with --record instead of the network")
+
 	// Ignore options
-	checkCmd.Flags().StringSliceVar(&ignoreDomains, "ignore-domain", nil,
+	flags.StringSliceVar(&ignoreDomains, "ignore-domain", nil,
 		"Domains to ignore, includes subdomains (can be repeated or comma-separated)")
-	checkCmd.Flags().StringSliceVar(&ignorePatterns, "ignore-pattern", nil,
+	flags.StringSliceVar(&ignorePatterns, "ignore-pattern", nil,
 		"Glob patterns to ignore (can be repeated)")
-	checkCmd.Flags().StringSliceVar(&ignoreRegex, "ignore-regex", nil,
+	flags.StringSliceVar(&ignoreRegex, "ignore-regex", nil,
 		"Regex patterns to ignore (can be repeated)")
-	checkCmd.Flags().BoolVar(&showIgnored, "show-ignored", false,
+	flags.BoolVar(&skipBadges, "skip-badges", false,
+		"Skip badge images (shields.io, CI status badges), which are often rate limited")
+	flags.BoolVar(&showIgnored, "show-ignored", false,
 		"Show which URLs were ignored and why")
-	checkCmd.Flags().BoolVar(&noConfig, "no-config", false,
+	flags.BoolVar(&noConfig, "no-config", false,
 		"Skip loading .gonerc.yaml config file")
 }
 
@@ -155,40 +531,217 @@ func runCheck(_ *cobra.Command, args []string) {
 	perf := stats.New()
 	exitOnError(validateCheckFlags(), "Invalid flags")
 
-	// Load configuration
-	loadedCfg, err := LoadConfig(noConfig)
+	// Load configuration, and apply the flags to it
+	fileCfg, err := LoadConfig(noConfig)
 	exitOnError(err, "Config error")
-
-	path := getPathArg(args)
+	loadedCfg, err := fileCfg.WithCheckFlags()
+	exitOnError(err, "Invalid flags")
+	theme, _ := term.ThemeByName(loadedCfg.GetTheme(themeName))
+	console = console.WithTheme(theme).WithColor(!noColor)
+	resultGrouping, _ = output.ParseGroupBy(loadedCfg.GetGroupBy(groupBy))
+	resultOrder, _ = output.ParseSortKey(loadedCfg.GetSort(sortBy))
+	resultLimit = loadedCfg.GetTop(topResults)
+	failureLevel = cmp.Or(loadedCfg.GetFailOn(failOn), failOnDead)
+	junitSuites, _ = output.ParseJUnitSuitePer(loadedCfg.GetJUnitSuitePer(junitSuitePer))
+	junitAll = loadedCfg.GetJUnitIncludePassed(junitPassed)
 
 	// Determine effective output format (CLI overrides config)
 	effectiveFormat := loadedCfg.GetOutputFormat(outputFormat)
-	useStructuredOutput := effectiveFormat != ""
+	useStructuredOutput := effectiveFormat != "" && !offlineMode
+	reportTemplate, err = loadTemplate(effectiveFormat, loadedCfg)
+	exitOnError(err, "Template error")
+
+	var baseline *output.Baseline
+	if baselinePath != "" {
+		baseline, err = output.LoadBaseline(baselinePath)
+		exitOnError(err, "Error loading baseline")
+	}
 
-	// Phase 1: Scan for files
-	files := scanFilesWithConfig(path, loadedCfg, perf, useStructuredOutput)
+	path := getPathArg(args)
+	root := path
+	if gitURL != "" || source.IsRepoURL(path) {
+		// Resumable sessions are keyed by the repository, not its clone
+		root = cmp.Or(gitURL, path)
+		path = cloneRepo(path, useStructuredOutput)
+		defer removeRepo()
+	}
 
-	// Phase 2: Parse links from files
-	links, urlFilter, done := parseAndFilterLinksWithConfig(files, loadedCfg, perf, useStructuredOutput)
+	var (
+		files     []string
+		links     []checker.Link
+		urlFilter *filter.Filter
+		done      bool
+	)
+	if fromURL != "" || fromManifest != "" {
+		// Phases 1-2: Load an external link list instead of scanning
+		files, links, urlFilter, done = loadExternalLinks(loadedCfg, perf, useStructuredOutput)
+	} else {
+		// Phase 1: Scan for files
+		files = scanFilesWithConfig(path, loadedCfg, perf, useStructuredOutput)
+
+		if loadedCfg.GetCheckInternal(checkInternal) {
+			internalFindings = relative.Check(path, markdownLinks(files, markdown.ExtractInternalLinks))
+		}
+
+		// Phase 2: Parse links from files
+		links, urlFilter, done = parseAndFilterLinksWithConfig(files, loadedCfg, perf, useStructuredOutput)
+	}
 	if done {
+		writeMetricsFile(loadedCfg, files, nil, checker.Summary{}, urlFilter, perf)
+		exitOnFailures(false, false)
+		return
+	}
+
+	if loadedCfg.GetLint(lintLinks) {
+		lintFindings = lint.Run(links)
+	}
+
+	if offlineMode {
+		runOfflineCheck(links, urlFilter, perf, loadedCfg.GetShowStats(showStats))
 		return
 	}
 
 	// Phase 3: Check URLs
-	results, summary := checkLinksWithConfig(links, loadedCfg, perf)
+	switch {
+	case fromURL != "":
+		root = fromURL
+	case fromManifest != "":
+		root = fromManifest
+	}
+	results, summary := checkLinksWithConfig(root, links, loadedCfg, perf)
+	var snapshots, upgrades map[string]string
+	lookupTimeout := time.Duration(loadedCfg.GetTimeout(timeout, int(checker.DefaultTimeout.Seconds()))) * time.Second
+	if suggestArchive {
+		snapshots = lookupSnapshots(os.Stderr, results, lookupTimeout)
+	}
+	if suggestHTTPS {
+		upgrades = lookupUpgrades(os.Stderr, results, lookupTimeout)
+	}
+	exitOnError(loadedCfg.AddFixSuggestions(results, snapshots, upgrades), "Invalid rewrite rules")
+
+	// With a baseline, only changes are reported, and only new dead links fail the run
+	reported, failed := results, summary.HasDeadLinks()
+	if loadedCfg.GetReportAll(reportAll) {
+		inventory = results
+	}
+	if baseline != nil {
+		reported, fixedLinks = baseline.Compare(results)
+		failed = len(reported) > 0
+	}
 
 	// Phase 4: Output results
 	effectiveShowStats := loadedCfg.GetShowStats(showStats)
 	routeOutputWithConfig(
-		files, results, summary, urlFilter, perf,
+		files, reported, summary, urlFilter, perf,
 		useStructuredOutput, effectiveFormat, effectiveShowStats,
 	)
+	writeMetricsFile(loadedCfg, files, results, summary, urlFilter, perf)
+
+	// The baseline only covers dead links, so every warning counts
+	exitOnFailures(failed, summary.WarningsCount() > 0)
+}
+
+// cloneRepo shallow-clones the repository named by --git-url or the path
+// argument, and returns the directory to scan: the clone, or the path
+// argument inside it when --git-url is set.
+func cloneRepo(path string, useStructuredOutput bool) string {
+	repoURL, subdir := gitURL, path
+	if repoURL == "" {
+		repoURL, subdir = path, "."
+	}
+	if !useStructuredOutput {
+		fmt.Printf("Cloning %s\n", repoURL)
+	}
+
+	dir, err := source.Clone(context.Background(), repoURL)
+	exitOnError(err, "Error cloning repository")
+	repoDir = dir
+
+	if !filepath.IsLocal(subdir) && subdir != "." {
+		exitOnError(fmt.Errorf("%s is outside the repository", subdir), "Invalid path")
+	}
+	return filepath.Join(dir, subdir)
+}
 
-	if summary.HasDeadLinks() {
-		os.Exit(1)
+// removeRepo removes the clone of a remote repository, if any.
+func removeRepo() {
+	if repoDir != "" {
+		_ = os.RemoveAll(repoDir)
+		repoDir = ""
 	}
 }
 
+// repoRelative returns a path inside the clone of a remote repository
+// relative to the clone, so results name the repository's own files.
+func repoRelative(path string) string {
+	if repoDir == "" {
+		return path
+	}
+	if rel, err := filepath.Rel(repoDir, path); err == nil && filepath.IsLocal(rel) {
+		return rel
+	}
+	return path
+}
+
+// Exit codes, documented in the check command's help.
+const (
+	exitDead     = 1 // Dead links, deny rule matches, broken internal links, or parse errors
+	exitWarnings = 2 // Only warnings, with --fail-on=warnings
+	exitInternal = 3 // Invalid flags or config, or an error that stopped the run
+)
+
+// Values of --fail-on.
+const (
+	failOnDead     = "dead"
+	failOnWarnings = "warnings"
+	failOnNone     = "none"
+)
+
+// failOnNames lists all valid --fail-on values.
+var failOnNames = []string{failOnDead, failOnWarnings, failOnNone}
+
+// exit removes the clone of a remote repository, if any, and exits with code.
+func exit(code int) {
+	removeRepo()
+	os.Exit(code)
+}
+
+// exitOnFailures exits with exitDead if any link matched a deny rule or, in
+// strict mode, any file failed to parse. These are policies of their own, so
+// --fail-on doesn't relax them. Unless --fail-on=none, it also exits with
+// exitDead if dead is set or any relative link is broken (--check-internal),
+// and with --fail-on=warnings, with exitWarnings if warnings is set.
+func exitOnFailures(dead, warnings bool) {
+	if len(policyViolations) > 0 || len(parseErrors) > 0 {
+		exit(exitDead)
+	}
+	if failureLevel == failOnNone {
+		return
+	}
+	if dead || len(internalFindings) > 0 {
+		exit(exitDead)
+	}
+	if warnings && failureLevel == failOnWarnings {
+		exit(exitWarnings)
+	}
+}
+
+// loadTemplate parses the effective template file when the format is
+// template, and returns nil otherwise.
+func loadTemplate(format string, cfg *LoadedConfig) (*template.Template, error) {
+	if output.Format(format) != output.FormatTemplate {
+		if templatePath != "" {
+			return nil, errors.New("--template needs --format=template")
+		}
+		return nil, nil
+	}
+	path := cfg.GetTemplate(templatePath)
+	if path == "" {
+		return nil, errors.New("--format=template needs a template file (--template or output.template)")
+	}
+	return output.ParseTemplate(path)
+}
+
 // exitOnError prints an error message and exits if err is not nil.
 func exitOnError(err error, message string) {
 	if err != nil {
@@ -197,7 +750,7 @@ func exitOnError(err error, message string) {
 		} else {
 			fmt.Fprintf(os.Stderr, "%v\n", err)
 		}
-		os.Exit(1)
+		exit(exitInternal)
 	}
 }
 
@@ -209,12 +762,32 @@ func getPathArg(args []string) string {
 	return "."
 }
 
+// fileTypesForPath returns the CLI file types and their default for scanning
+// path. A single file, such as sitemap.xml, is checked as its own type
+// unless --types differs from the default.
+func fileTypesForPath(path string) (types, defaults []string) {
+	defaults = []string{"md"}
+	if !slices.Equal(fileTypes, defaults) {
+		return fileTypes, defaults
+	}
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return fileTypes, defaults
+	}
+	if t := parser.FileTypeForFile(path); t != "" {
+		// No default, so the file's type overrides the config's types
+		return []string{t}, nil
+	}
+	return fileTypes, defaults
+}
+
 // scanFilesWithConfig scans for files using config and CLI values.
 func scanFilesWithConfig(path string, cfg *LoadedConfig, perf *stats.Stats, useStructuredOutput bool) []string {
 	perf.StartScan()
 
 	// Get effective file types (CLI overrides config)
-	effectiveTypes := cfg.GetTypes(fileTypes, []string{"md"})
+	cliTypes, cliDefault := fileTypesForPath(path)
+	effectiveTypes := cfg.GetTypes(cliTypes, cliDefault)
 
 	// Validate file types
 	if err := validateFileTypes(effectiveTypes); err != nil {
@@ -222,15 +795,21 @@ func scanFilesWithConfig(path string, cfg *LoadedConfig, perf *stats.Stats, useS
 	}
 
 	// Build scan options from config
-	scanOpts := cfg.BuildScanOptions(path, fileTypes, []string{"md"})
+	scanOpts := cfg.BuildScanOptions(path, cliTypes, cliDefault)
 
 	files, err := scanner.FindFilesWithOptions(scanOpts)
 	exitOnError(err, "Error scanning directory")
+
+	files, priorityFiles, err = scanner.Prioritize(files, path, cfg.Config().Scan.PriorityPaths)
+	exitOnError(err, "Invalid --priority-paths")
 	perf.EndScan(len(files))
 
 	if !useStructuredOutput {
 		typeStr := strings.Join(effectiveTypes, ", ")
 		fmt.Printf("Found %d file(s) of type(s): %s\n", len(files), typeStr)
+		if len(priorityFiles) > 0 {
+			fmt.Printf("Prioritizing %d file(s) matching priority paths\n", len(priorityFiles))
+		}
 	}
 	return files
 }
@@ -252,6 +831,46 @@ func validateFileTypes(types []string) error {
 	return nil
 }
 
+// loadExternalLinks reads links from --from-url or --from-manifest and applies filters.
+// The list URL or manifest path stands in for the scanned files in reports.
+//
+//nolint:gocritic // Named returns would make this function harder to read
+func loadExternalLinks(
+	cfg *LoadedConfig, perf *stats.Stats, useStructuredOutput bool,
+) ([]string, []checker.Link, *filter.Filter, bool) {
+	perf.StartScan()
+	perf.EndScan(1)
+	perf.StartParse()
+
+	var (
+		origin      string
+		parserLinks []parser.Link
+		err         error
+	)
+	seconds := cfg.GetTimeout(timeout, int(checker.DefaultTimeout.Seconds()))
+	client := &http.Client{Timeout: time.Duration(seconds) * time.Second}
+	if fromURL != "" {
+		origin = fromURL
+		parserLinks, err = source.FromURL(context.Background(), client, fromURL)
+	} else {
+		origin = fromManifest
+		if offlineMode {
+			// Go modules fall back to their module path instead of the proxy
+			client = nil
+		}
+		parserLinks, err = source.FromManifest(context.Background(), client, fromManifest)
+	}
+	exitOnError(err, "Error loading links")
+
+	if !useStructuredOutput {
+		fmt.Printf("Loaded %d link(s) from %s\n", len(parserLinks), origin)
+	}
+
+	files := []string{origin}
+	links, urlFilter, done := filterLinksWithConfig(files, parserLinks, cfg, perf, useStructuredOutput)
+	return files, links, urlFilter, done
+}
+
 // parseAndFilterLinksWithConfig extracts links from files and applies filters using config.
 // Returns the links, filter, and whether processing should stop (done=true).
 func parseAndFilterLinksWithConfig(
@@ -260,10 +879,50 @@ func parseAndFilterLinksWithConfig(
 	perf.StartParse()
 
 	// Get effective strict mode
-	effectiveStrict := cfg.GetStrict(strictMode)
+	// Offline mode always fails on malformed files
+	effectiveStrict := cfg.GetStrict(strictMode) || offlineMode
 
-	parserLinks, err := parser.ExtractLinksFromMultipleFilesWithRegistry(files, effectiveStrict)
-	exitOnError(err, "Error parsing files")
+	budget := cfg.BuildParseBudget(parseTimeout)
+	budget.Exempt = priorityFiles
+
+	// Strict mode reports every malformed file and fails the run after
+	// checking the links of the others, so one broken file hides nothing
+	ex := parser.Extract(files, budget)
+
+	// Files in a remote repository's clone are named relative to it
+	for i := range ex.Skipped {
+		ex.Skipped[i].FilePath = repoRelative(ex.Skipped[i].FilePath)
+	}
+	for i := range ex.Errors {
+		ex.Errors[i].FilePath = repoRelative(ex.Errors[i].FilePath)
+	}
+	if effectiveStrict {
+		parseErrors = ex.Errors
+	}
+
+	// Links are checked in order, so links from priority files go first
+	parserLinks := PrioritizeLinks(ex.Links, priorityFiles)
+	for i := range parserLinks {
+		parserLinks[i].FilePath = repoRelative(parserLinks[i].FilePath)
+	}
+
+	skippedFiles = ex.Skipped
+	if !useStructuredOutput {
+		printSkippedFiles(ex.Skipped)
+		printParseErrors(parseErrors)
+	}
+
+	return filterLinksWithConfig(files, parserLinks, cfg, perf, useStructuredOutput)
+}
+
+// filterLinksWithConfig applies deny rules and ignore filters to extracted links.
+// Returns the links, filter, and whether processing should stop (done=true).
+func filterLinksWithConfig(
+	files []string, parserLinks []parser.Link, cfg *LoadedConfig, perf *stats.Stats, useStructuredOutput bool,
+) ([]checker.Link, *filter.Filter, bool) {
+	denyFilter, err := CreateDenyFilter(cfg.Config())
+	exitOnError(err, "Error creating deny filter")
+	policyViolations = FindPolicyViolations(parserLinks, denyFilter)
 
 	if len(parserLinks) == 0 {
 		perf.EndParse(0, 0, 0, 0)
@@ -273,7 +932,7 @@ func parseAndFilterLinksWithConfig(
 	}
 
 	// Create filter using config + CLI overrides
-	urlFilter, err := CreateFilterWithConfig(cfg.Config(), ignoreDomains, ignorePatterns, ignoreRegex)
+	urlFilter, err := CreateFilterWithConfig(cfg.Config(), nil, nil, nil, false)
 	exitOnError(err, "Error creating filter")
 
 	links := FilterParserLinks(parserLinks, urlFilter)
@@ -283,7 +942,7 @@ func parseAndFilterLinksWithConfig(
 
 	perf.EndParse(len(parserLinks), uniqueURLs, duplicates, ignoredCount)
 
-	if !useStructuredOutput {
+	if !useStructuredOutput && !offlineMode {
 		printProgressMessage(len(parserLinks), len(links), uniqueURLs, duplicates, ignoredCount)
 	}
 
@@ -304,22 +963,177 @@ func getIgnoredCount(urlFilter *filter.Filter) int {
 	return 0
 }
 
+// statsHooks returns checker hooks that count requests, DNS lookups, and
+// retries into perf, and sample the request timing of checked links.
+func statsHooks(perf *stats.Stats) checker.Hooks {
+	return checker.Hooks{
+		OnRequest: func(checker.RequestEvent) { perf.RecordRequest() },
+		OnDNS:     func(e checker.DNSEvent) { perf.RecordDNS(e.Duration, e.Cached) },
+		OnRetry:   func(e checker.RetryEvent) { perf.RecordRetry(e.Attempt, e.Delay) },
+		OnResult: func(r checker.Result) {
+			if r.Status == checker.StatusDuplicate || r.Timing.IsZero() {
+				return
+			}
+			t := r.Timing
+			perf.RecordTiming(t.DNS, t.Connect, t.TLS, t.TTFB, t.Total)
+		},
+	}
+}
+
+// newProgress returns a progress line for checking total links, or nil if
+// --no-progress or --quiet is set or stderr isn't a terminal. Results streamed
+// to the same terminal, or logs (--verbose), would be drawn over, so there's
+// no progress line then either.
+func newProgress(total int) *term.Progress {
+	if noProgress || quiet || logging() || !term.IsTerminal(os.Stderr) ||
+		(resultStream != nil && term.IsTerminal(os.Stdout)) {
+		return nil
+	}
+	return term.NewProgress(os.Stderr, total)
+}
+
+// streamResults returns an OnResult hook that calls next and then writes the
+// result to stdout as a JSON line as soon as it's checked (--format=ndjson),
+// if the --show flags select it. The rest of the report follows once the
+// check is done.
+func streamResults(next func(checker.Result), cfg *LoadedConfig) func(checker.Result) {
+	f, err := cfg.BuildSuggestionFixer()
+	exitOnError(err, "Invalid rewrite rules")
+
+	resultStream = output.NewNDJSONWriter(os.Stdout)
+	return func(r checker.Result) {
+		next(r)
+		if len(filterResults([]checker.Result{r})) == 0 {
+			return
+		}
+		if s, ok := f.Suggest(r); ok {
+			r.Suggestion = &s
+		}
+		if err := resultStream.WriteResult(r); err != nil {
+			slog.Warn("streaming result", "error", err)
+		}
+	}
+}
+
 // checkLinksWithConfig checks all links using config values and returns results with summary.
+// With --resume, results are journaled per root so an interrupted run can pick up where it stopped.
 func checkLinksWithConfig(
-	links []checker.Link, cfg *LoadedConfig, perf *stats.Stats,
+	root string, links []checker.Link, cfg *LoadedConfig, perf *stats.Stats,
 ) ([]checker.Result, checker.Summary) {
 	perf.StartCheck()
 
-	opts := cfg.BuildCheckerOptions(concurrency, timeout, retries)
+	// cfg has the flags applied; the CLI values only tell an explicit 0 from unset
+	opts := cfg.BuildCheckerOptions(concurrency, timeout, retries).
+		WithExpandDuplicates(expandDups)
+
+	hooks := statsHooks(perf)
+	// A baseline comparison needs every result, and sorting needs them all
+	// before the first is listed, so nothing is streamed then
+	if cfg.GetOutputFormat(outputFormat) == string(output.FormatNDJSON) && baselinePath == "" &&
+		resultOrder == "" && resultLimit == 0 {
+		hooks.OnResult = streamResults(hooks.OnResult, cfg)
+	}
+	progress := newProgress(len(links))
+	if progress != nil {
+		next := hooks.OnResult
+		hooks.OnResult = func(r checker.Result) {
+			next(r)
+			progress.Record(r)
+		}
+	}
+	opts = opts.WithHooks(hooks)
+
+	var cassette *checker.Cassette
+	switch {
+	case replayPath != "":
+		var err error
+		cassette, err = checker.LoadCassette(replayPath)
+		exitOnError(err, "Error loading cassette")
+		opts = opts.WithReplay(cassette)
+	case recordPath != "":
+		cassette = checker.NewCassette()
+		opts = opts.WithRecord(cassette)
+	}
+
+	var resultCache *cache.Cache
+	if cfg.GetCache(useCache) {
+		var err error
+		resultCache, err = cfg.OpenCache()
+		exitOnError(err, "Error opening cache")
+		opts = opts.WithCache(resultCache.For(opts))
+	}
+
+	var session *cache.Session
+	if resume {
+		var err error
+		session, err = cfg.OpenSession(root, links, opts)
+		exitOnError(err, "Error opening session")
+		if n := session.Resumed(); n > 0 {
+			fmt.Fprintf(os.Stderr, "Resuming run started %s: %d of %d URL(s) already checked\n",
+				session.StartedAt().Format(time.DateTime), n, session.Queued())
+		}
+		opts = opts.WithCache(session)
+	}
 
 	c := checker.New(opts)
+	if progress != nil {
+		progress.Start()
+	}
 	results := c.CheckAll(links)
+	if progress != nil {
+		progress.Stop()
+	}
 	summary := checker.Summarize(results)
 
+	// The run completed, so there is nothing left to resume, unless the
+	// budget left links unchecked; skipped links aren't journaled
+	switch {
+	case session != nil && summary.Skipped > 0:
+		if err := session.Close(); err != nil {
+			slog.Warn("closing session", "error", err)
+		}
+		fmt.Fprintf(os.Stderr, "Budget exhausted: %d link(s) skipped; run again with --resume to check them\n",
+			summary.Skipped)
+	case session != nil:
+		if err := session.Finish(); err != nil {
+			slog.Warn("finishing session", "error", err)
+		}
+	case summary.Skipped > 0:
+		fmt.Fprintf(os.Stderr, "Budget exhausted: %d link(s) skipped\n", summary.Skipped)
+	}
+
+	// A cache that can't be written only costs speed on the next run
+	if resultCache != nil {
+		if err := resultCache.Save(); err != nil {
+			slog.Warn("saving cache", "error", err)
+		}
+	}
+
+	if recordPath != "" {
+		exitOnError(cassette.Save(recordPath), "Error saving cassette")
+	}
+
+	if report, ok := c.ProbeReport(); ok {
+		perf.RecordProbe(report.Duration, len(report.Hosts), report.Unreachable(), report.RateLimited())
+	}
+
 	perf.EndCheck()
 	return results, summary
 }
 
+// writeMetricsFile writes Prometheus metrics for the run to --metrics-file, if set.
+func writeMetricsFile(
+	cfg *LoadedConfig, files []string, results []checker.Result, summary checker.Summary,
+	urlFilter *filter.Filter, perf *stats.Stats,
+) {
+	path := cfg.GetMetricsFile(metricsFile)
+	if path == "" {
+		return
+	}
+	report := buildReportWithStatsV2(files, results, summary, urlFilter, perf, false, output.FormatPrometheus)
+	exitOnError(output.WriteMetricsFile(report, path), "Error writing metrics")
+}
+
 // routeOutputWithConfig handles output based on format flags and config.
 func routeOutputWithConfig(
 	files []string, results []checker.Result, summary checker.Summary,
@@ -329,7 +1143,7 @@ func routeOutputWithConfig(
 	switch {
 	case useStructuredOutput:
 		handleStructuredOutputWithStatsV2(files, results, summary, urlFilter, perf, effectiveFormat, effectiveShowStats)
-	case outputFile != "":
+	case len(outputFiles) > 0:
 		handleFileOutputWithStatsV2(files, results, summary, urlFilter, perf, effectiveShowStats)
 	default:
 		outputText(results, summary, urlFilter)
@@ -342,10 +1156,91 @@ func routeOutputWithConfig(
 // validateCheckFlags checks for invalid flag combinations.
 func validateCheckFlags() error {
 	// Validate mutually exclusive flags
-	if outputFormat != "" && outputFile != "" {
+	if outputFormat != "" && len(outputFiles) > 0 {
 		return fmt.Errorf("--format and --output are mutually exclusive; " +
 			"use --format for stdout output, or --output for file output")
 	}
+	if offlineMode && (outputFormat != "" || len(outputFiles) > 0) {
+		return fmt.Errorf("--offline only supports text output; remove --format and --output")
+	}
+	if offlineMode && metricsFile != "" {
+		return fmt.Errorf("--offline doesn't check links, so it has no metrics; remove --metrics-file")
+	}
+	if offlineMode && baselinePath != "" {
+		return fmt.Errorf("--offline doesn't check links, so it can't be compared with a baseline; remove --baseline")
+	}
+	if recordPath != "" && replayPath != "" {
+		return fmt.Errorf("--record and --replay are mutually exclusive")
+	}
+	if fromURL != "" && fromManifest != "" {
+		return fmt.Errorf("--from-url and --from-manifest are mutually exclusive")
+	}
+	if gitURL != "" && (fromURL != "" || fromManifest != "") {
+		return fmt.Errorf("--git-url can't be combined with --from-url or --from-manifest")
+	}
+	if fromURL != "" && offlineMode {
+		return fmt.Errorf("--from-url needs network access; it can't be combined with --offline")
+	}
+
+	for _, code := range acceptStatus {
+		if code < 100 || code > 999 {
+			return fmt.Errorf("--accept-status: invalid status code %d", code)
+		}
+	}
+
+	if _, ok := checker.ParseRedirectPolicy(redirectMode); redirectMode != "" && !ok {
+		return fmt.Errorf("invalid --redirect-policy %q; valid policies: %s",
+			redirectMode, strings.Join(checker.RedirectPolicyNames(), ", "))
+	}
+
+	if _, ok := term.ThemeByName(themeName); !ok {
+		return fmt.Errorf("invalid --theme %q; valid themes: %s", themeName, strings.Join(term.ThemeNames(), ", "))
+	}
+
+	if _, ok := output.ParseGroupBy(groupBy); !ok {
+		return fmt.Errorf("invalid --group-by %q; valid groupings: %s",
+			groupBy, strings.Join(output.GroupByNames(), ", "))
+	}
+
+	if _, ok := output.ParseSortKey(sortBy); !ok {
+		return fmt.Errorf("invalid --sort %q; valid keys: %s", sortBy, strings.Join(output.SortKeyNames(), ", "))
+	}
+	if topResults < 0 {
+		return fmt.Errorf("--top must be >= 0, got %d", topResults)
+	}
+
+	if _, ok := output.ParseJUnitSuitePer(junitSuitePer); !ok {
+		return fmt.Errorf("invalid --junit-suite-per %q; valid groupings: file, domain", junitSuitePer)
+	}
+
+	if failOn != "" && !slices.Contains(failOnNames, failOn) {
+		return fmt.Errorf("invalid --fail-on %q; valid values: %s", failOn, strings.Join(failOnNames, ", "))
+	}
+
+	if ipFamily != "" && !checker.ValidIPFamily(ipFamily) {
+		return fmt.Errorf("invalid --ip-family %q; valid families: %s",
+			ipFamily, strings.Join(checker.IPFamilies(), ", "))
+	}
+
+	if maxDuration < 0 {
+		return fmt.Errorf("--max-duration must be > 0, got %s", maxDuration)
+	}
+	if maxRequests < 0 {
+		return fmt.Errorf("--max-requests must be >= 0, got %d", maxRequests)
+	}
+
+	if _, err := checker.ParseDNSServer(dnsServer); dnsServer != "" && err != nil {
+		return fmt.Errorf("invalid --dns-server: %w", err)
+	}
+
+	for _, name := range noResolvers {
+		known := slices.ContainsFunc(checker.DefaultResolvers(), func(r checker.Resolver) bool {
+			return r.Name() == name
+		})
+		if !known {
+			return fmt.Errorf("--disable-resolver: unknown resolver %q", name)
+		}
+	}
 
 	// Validate format if specified
 	if outputFormat != "" && !output.IsValidFormat(outputFormat) {
@@ -353,18 +1248,106 @@ func validateCheckFlags() error {
 			outputFormat, strings.Join(output.ValidFormats(), ", "))
 	}
 
+	// Catch unknown extensions before checking, not after
+	for _, file := range outputFiles {
+		if _, err := output.InferFormat(file); err != nil {
+			return fmt.Errorf("--output %s: %w", file, err)
+		}
+	}
+
 	return nil
 }
 
+// WithCheckFlags returns the configuration gone check runs with: the config
+// file's settings with the check flags applied by the precedence rules of the
+// Get methods, and the defaults of unset settings filled in. Flags that add to
+// a list extend the config file's. The check is built from it, and
+// 'gone config show --effective' prints it.
+func (lc *LoadedConfig) WithCheckFlags() (*LoadedConfig, error) {
+	if _, err := lc.GetStaleAfter(staleAfter); err != nil {
+		return nil, fmt.Errorf("invalid --stale-after: %w", err)
+	}
+	profile, err := lc.GetUAProfile(uaProfile)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --ua-profile: %w", err)
+	}
+	cachePath, err := lc.CachePath()
+	if err != nil {
+		return nil, err
+	}
+
+	eff := *lc.cfg
+	eff.Types = lc.GetTypes(fileTypes, []string{"md"})
+	eff.Scan.PriorityPaths = lc.GetPriorityPaths(priorityPath)
+	if budget := lc.BuildParseBudget(parseTimeout); budget.Timeout > 0 {
+		eff.Scan.ParseTimeout = shortDuration(budget.Timeout)
+	}
+
+	eff.Check.Concurrency = lc.GetConcurrency(concurrency, checker.DefaultConcurrency)
+	eff.Check.Timeout = lc.GetTimeout(timeout, int(checker.DefaultTimeout.Seconds()))
+	eff.Check.Retries = lc.GetRetries(retries, checker.DefaultMaxRetries)
+	eff.Check.MaxRedirects = lc.GetMaxRedirects(maxRedirects, checker.DefaultMaxRedirects)
+	eff.Check.RedirectWarnHops = lc.GetRedirectWarnHops(warnHops)
+	eff.Check.RedirectPolicy = lc.GetRedirectPolicy(redirectMode).String()
+	eff.Check.MaxRequests = lc.GetMaxRequests(maxRequests)
+	if d := lc.GetMaxDuration(maxDuration); d > 0 {
+		eff.Check.MaxDuration = shortDuration(d)
+	}
+	eff.Check.StaleAfter = cmp.Or(staleAfter, lc.cfg.Check.StaleAfter)
+	eff.Check.UAProfile = cmp.Or(profile, checker.ProfileDefault)
+	eff.Check.IPFamily = cmp.Or(lc.GetIPFamily(ipFamily), checker.IPFamilyAuto)
+	eff.Check.DNSServer = lc.GetDNSServer(dnsServer)
+	eff.Check.FailOn = cmp.Or(lc.GetFailOn(failOn), failOnDead)
+	eff.Check.Strict = lc.GetStrict(strictMode)
+	eff.Check.ProbeHosts = lc.GetProbeHosts(probeHosts)
+	eff.Check.InspectPages = lc.GetInspectPages(inspectPages)
+	eff.Check.CheckAnchors = lc.GetCheckAnchors(checkAnchors)
+	eff.Check.DetectSoft404 = lc.GetDetectSoft404(detectSoft404)
+	eff.Check.CheckInternal = lc.GetCheckInternal(checkInternal)
+	eff.Check.Insecure = lc.GetInsecure(insecureTLS)
+	eff.Check.Lint = lc.GetLint(lintLinks)
+	eff.Check.HTTP3 = lc.GetHTTP3(useHTTP3)
+	eff.Check.EnableCookies = lc.GetEnableCookies(enableCookies)
+	eff.Check.DisableResolvers = slices.Concat(lc.cfg.Check.DisableResolvers, noResolvers)
+	eff.Check.StatusCodes.Alive = slices.Concat(lc.cfg.Check.StatusCodes.Alive, acceptStatus)
+	eff.Check.LoginPatterns = slices.Concat(lc.cfg.Check.LoginPatterns, loginPattern)
+
+	eff.Ignore.Domains = slices.Concat(lc.cfg.Ignore.Domains, ignoreDomains)
+	eff.Ignore.Patterns = slices.Concat(lc.cfg.Ignore.Patterns, ignorePatterns)
+	eff.Ignore.Regex = slices.Concat(lc.cfg.Ignore.Regex, ignoreRegex)
+	eff.Ignore.Badges = skipBadges || lc.cfg.Ignore.Badges
+
+	eff.Output.Format = lc.GetOutputFormat(outputFormat)
+	eff.Output.Template = lc.GetTemplate(templatePath)
+	eff.Output.MetricsFile = lc.GetMetricsFile(metricsFile)
+	eff.Output.Theme = cmp.Or(lc.GetTheme(themeName), "default")
+	eff.Output.GroupBy = cmp.Or(lc.GetGroupBy(groupBy), string(output.GroupByStatus))
+	eff.Output.Sort = lc.GetSort(sortBy)
+	eff.Output.Top = lc.GetTop(topResults)
+	eff.Output.ReportAll = lc.GetReportAll(reportAll)
+	eff.Output.JUnitSuitePer = cmp.Or(lc.GetJUnitSuitePer(junitSuitePer), string(output.GroupByFile))
+	eff.Output.JUnitIncludePassed = lc.GetJUnitIncludePassed(junitPassed)
+	eff.Output.ShowStats = lc.GetShowStats(showStats)
+	showWarnings, showDead := lc.GetShowWarnings(false), lc.GetShowDead(false)
+	eff.Output.ShowWarnings, eff.Output.ShowDead = &showWarnings, &showDead
+
+	eff.Cache.Enabled = lc.GetCache(useCache)
+	eff.Cache.Path = cachePath
+	eff.Cache.TTL = cmp.Or(lc.cfg.Cache.TTL, shortDuration(cache.DefaultTTL))
+
+	return &LoadedConfig{cfg: &eff, noConfig: lc.noConfig}, nil
+}
+
 // handleEmptyLinksWithStatsV2 handles the case when no links are found, with config.
 func handleEmptyLinksWithStatsV2(files []string, useStructuredOutput bool, perf *stats.Stats, effectiveShowStats bool) {
 	switch {
 	case useStructuredOutput:
 		handleStructuredOutputWithStatsV2(files, nil, checker.Summary{}, nil, perf, outputFormat, effectiveShowStats)
-	case outputFile != "":
+	case len(outputFiles) > 0:
 		handleFileOutputWithStatsV2(files, nil, checker.Summary{}, nil, perf, effectiveShowStats)
 	default:
 		fmt.Println("No links found.")
+		printInternalFindings(internalFindings)
 		if effectiveShowStats {
 			fmt.Print(perf.String())
 		}
@@ -381,10 +1364,12 @@ func handleAllFilteredWithStatsV2(
 		handleStructuredOutputWithStatsV2(
 			files, nil, checker.Summary{}, urlFilter, perf, outputFormat, effectiveShowStats,
 		)
-	case outputFile != "":
+	case len(outputFiles) > 0:
 		handleFileOutputWithStatsV2(files, nil, checker.Summary{}, urlFilter, perf, effectiveShowStats)
 	default:
 		fmt.Println("\nAll links were ignored by filter rules.")
+		printPolicyViolations(policyViolations)
+		printInternalFindings(internalFindings)
 		if showIgnored && urlFilter != nil {
 			printIgnoredURLs(urlFilter)
 		}
@@ -399,15 +1384,33 @@ func handleStructuredOutputWithStatsV2(
 	files []string, results []checker.Result, summary checker.Summary,
 	urlFilter *filter.Filter, perf *stats.Stats, effectiveFormat string, effectiveShowStats bool,
 ) {
-	report := buildReportWithStatsV2(files, results, summary, urlFilter, perf, effectiveShowStats)
+	report := buildReportWithStatsV2(
+		files, results, summary, urlFilter, perf, effectiveShowStats, output.Format(effectiveFormat),
+	)
+
+	// Streamed results have been printed already
+	if resultStream != nil {
+		if err := resultStream.WriteTrailer(report); err != nil {
+			fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+			exit(exitInternal)
+		}
+		return
+	}
 
 	data, err := output.FormatReport(report, output.Format(effectiveFormat))
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
-		os.Exit(1)
+		exit(exitInternal)
 	}
 
 	fmt.Print(string(data))
+
+	// Annotations show on the diff; the job summary gets the full report
+	if output.Format(effectiveFormat) == output.FormatGitHub {
+		if err := output.WriteStepSummary(report); err != nil {
+			slog.Warn("writing step summary", "error", err)
+		}
+	}
 }
 
 // handleFileOutputWithStatsV2 writes to file with optional stats, using config.
@@ -415,18 +1418,43 @@ func handleFileOutputWithStatsV2(
 	files []string, results []checker.Result, summary checker.Summary,
 	urlFilter *filter.Filter, perf *stats.Stats, effectiveShowStats bool,
 ) {
-	report := buildReportWithStatsV2(files, results, summary, urlFilter, perf, effectiveShowStats)
-
-	if err := output.WriteToFile(report, outputFile); err != nil {
-		fmt.Fprintf(os.Stderr, "Error writing file: %v\n", err)
-		os.Exit(1)
+	// Metrics and JUnit reports with passing test cases count every result,
+	// so they get a report of their own
+	var reportFiles, metricsFiles, junitFiles []string
+	for _, file := range outputFiles {
+		switch format, _ := output.InferFormat(file); {
+		case format == output.FormatPrometheus:
+			metricsFiles = append(metricsFiles, file)
+		case format == output.FormatJUnit && junitAll:
+			junitFiles = append(junitFiles, file)
+		default:
+			reportFiles = append(reportFiles, file)
+		}
+	}
+	write := func(paths []string, format output.Format) {
+		if len(paths) == 0 {
+			return
+		}
+		report := buildReportWithStatsV2(files, results, summary, urlFilter, perf, effectiveShowStats, format)
+		if err := output.WriteToFiles(report, paths); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing file: %v\n", err)
+			exit(exitInternal)
+		}
 	}
+	write(reportFiles, "")
+	write(metricsFiles, output.FormatPrometheus)
+	write(junitFiles, output.FormatJUnit)
 
-	fmt.Printf("Wrote report to %s\n", outputFile)
+	for _, file := range outputFiles {
+		fmt.Printf("Wrote report to %s\n", file)
+	}
 
 	// Also print summary to stdout
 	fmt.Printf("\nSummary: %d alive | %d warnings | %d dead | %d duplicates",
 		summary.Alive, summary.WarningsCount(), summary.Dead+summary.Errors, summary.Duplicates)
+	if summary.Skipped > 0 {
+		fmt.Printf(" | %d skipped", summary.Skipped)
+	}
 	if urlFilter != nil && urlFilter.IgnoredCount() > 0 {
 		fmt.Printf(" | %d ignored", urlFilter.IgnoredCount())
 	}
@@ -437,13 +1465,25 @@ func handleFileOutputWithStatsV2(
 	}
 }
 
-// buildReportWithStatsV2 creates an output.Report with optional stats, using config.
+// buildReportWithStatsV2 creates an output.Report for format with optional stats, using config.
 func buildReportWithStatsV2(
 	files []string, results []checker.Result, summary checker.Summary,
-	urlFilter *filter.Filter, perf *stats.Stats, effectiveShowStats bool,
+	urlFilter *filter.Filter, perf *stats.Stats, effectiveShowStats bool, format output.Format,
 ) *output.Report {
 	report := buildReport(files, results, summary, urlFilter)
 
+	// Metrics count every link, not only those the --show flags select,
+	// and so do JUnit reports with passing test cases
+	switch {
+	case format == output.FormatPrometheus:
+		report.Results = results
+	case format == output.FormatJUnit && junitAll:
+		report.Results = output.OrderResults(results, resultOrder, resultLimit)
+	}
+	if perf != nil {
+		report.Duration = perf.TotalDuration()
+	}
+
 	// Add stats if requested
 	if effectiveShowStats && perf != nil {
 		report.Stats = perf.ToJSON()
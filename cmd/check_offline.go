@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/leonardomso/gone/internal/checker"
+	"github.com/leonardomso/gone/internal/filter"
+	"github.com/leonardomso/gone/internal/stats"
+)
+
+// runOfflineCheck validates links without network access and prints the outcome.
+// Malformed URLs count as dead links for exitOnFailures.
+func runOfflineCheck(links []checker.Link, urlFilter *filter.Filter, perf *stats.Stats, effectiveShowStats bool) {
+	summary := checker.ValidateOffline(links)
+	ignoredCount := getIgnoredCount(urlFilter)
+
+	fmt.Printf("Offline: %d link(s) | %d unique URLs | %d duplicates | %d malformed",
+		summary.Total, summary.UniqueURLs, summary.Duplicates, len(summary.Malformed))
+	if ignoredCount > 0 {
+		fmt.Printf(" | %d ignored", ignoredCount)
+	}
+	fmt.Println()
+	fmt.Println()
+
+	printSection("Malformed URLs", summary.Malformed, deadEntry)
+	printPolicyViolations(policyViolations)
+	printInternalFindings(internalFindings)
+	printLintFindings(lintFindings)
+
+	if showIgnored && urlFilter != nil {
+		printIgnoredURLs(urlFilter)
+	}
+
+	if effectiveShowStats {
+		fmt.Print(perf.String())
+	}
+
+	exitOnFailures(summary.HasIssues(), false)
+	if !summary.HasIssues() {
+		fmt.Println("No malformed URLs found (network checks skipped).")
+	}
+}
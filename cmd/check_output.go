@@ -7,6 +7,7 @@ import (
 	"github.com/leonardomso/gone/internal/checker"
 	"github.com/leonardomso/gone/internal/filter"
 	"github.com/leonardomso/gone/internal/output"
+	"github.com/leonardomso/gone/internal/term"
 )
 
 // buildReport creates an output.Report from check results.
@@ -20,7 +21,27 @@ func buildReport(
 		TotalLinks:  summary.Total,
 		UniqueURLs:  summary.UniqueURLs,
 		Summary:     summary,
-		Results:     filterResults(results),
+		Results:     output.OrderResults(filterResults(results), resultOrder, resultLimit),
+		Skipped:     ConvertSkippedFiles(skippedFiles),
+		ParseErrors: ConvertParseErrors(parseErrors),
+		Lint:        ConvertLintFindings(lintFindings),
+		Violations:  ConvertPolicyViolations(policyViolations),
+		Internal:    ConvertInternalFindings(internalFindings),
+		Fixed:       fixedLinks,
+		Inventory:   inventory,
+		GroupBy:     resultGrouping,
+		Template:    reportTemplate,
+
+		JUnitSuitePer:      junitSuites,
+		JUnitIncludePassed: junitAll,
+	}
+
+	if uniqueDead {
+		report.UniqueDead = checker.UniqueDead(results)
+	}
+
+	if byDomain {
+		report.Domains = summarizeDomains(results, urlFilter)
 	}
 
 	// Add ignored URLs if filter is present and --show-ignored is set
@@ -61,11 +82,11 @@ func filterResults(results []checker.Result) []checker.Result {
 		return results
 	}
 
-	// Default: show warnings + dead + duplicates (non-alive)
+	// Default: show warnings + dead + duplicates (non-alive), plus alive links with hints
 	// Pre-allocate with estimated capacity
 	filtered := make([]checker.Result, 0, len(results)/4)
 	for _, r := range results {
-		if !r.IsAlive() {
+		if !r.IsAlive() || len(r.Hints) > 0 {
 			filtered = append(filtered, r)
 		}
 	}
@@ -78,23 +99,53 @@ func outputText(results []checker.Result, summary checker.Summary, urlFilter *fi
 	ignoredCount := getFilterIgnoredCount(urlFilter)
 	printSummaryLine(summary, ignoredCount)
 
-	filtered := filterResults(results)
+	selected := filterResults(results)
+	filtered := output.OrderResults(selected, resultOrder, resultLimit)
+	if len(filtered) < len(selected) {
+		fmt.Printf("Listing the first %d of %d results.\n\n", len(filtered), len(selected))
+	}
 
 	if len(filtered) == 0 {
 		fmt.Println(getEmptyResultsMessage(summary))
+		printFixedLinks(fixedLinks)
+		printDomains(results, urlFilter)
+		printPolicyViolations(policyViolations)
+		printInternalFindings(internalFindings)
+		printLintFindings(lintFindings)
 		maybeShowIgnored(urlFilter)
 		return
 	}
 
-	if shouldGroupResults() {
+	switch {
+	case uniqueDead && !showAlive && !showWarnings:
+		outputUniqueResults(results, filtered)
+	case resultGrouping != output.GroupByStatus:
+		outputResultGroups(filtered)
+	case shouldGroupResults():
 		outputGroupedResults(filtered)
-	} else {
+	default:
 		outputFlatResults(filtered)
 	}
 
+	printFixedLinks(fixedLinks)
+	printDomains(results, urlFilter)
+	printPolicyViolations(policyViolations)
+	printInternalFindings(internalFindings)
+	printLintFindings(lintFindings)
 	maybeShowIgnored(urlFilter)
 }
 
+// summarizeDomains groups all results, ignored URLs, and policy violations by domain.
+func summarizeDomains(results []checker.Result, urlFilter *filter.Filter) []output.DomainSummary {
+	var ignored []output.IgnoredURL
+	if urlFilter != nil {
+		for _, ig := range urlFilter.IgnoredURLs() {
+			ignored = append(ignored, output.IgnoredURL{URL: ig.URL, Reason: ig.Type, Rule: ig.Rule})
+		}
+	}
+	return output.SummarizeDomains(results, ignored, ConvertPolicyViolations(policyViolations))
+}
+
 // getFilterIgnoredCount returns the ignored count from filter, or 0 if nil.
 func getFilterIgnoredCount(urlFilter *filter.Filter) int {
 	if urlFilter != nil {
@@ -106,20 +157,34 @@ func getFilterIgnoredCount(urlFilter *filter.Filter) int {
 // printSummaryLine prints the summary statistics line.
 func printSummaryLine(summary checker.Summary, ignoredCount int) {
 	fmt.Println()
+	fmt.Printf("Summary: %s | %s | %s | %s",
+		summaryCount(term.KindAlive, summary.Alive, "alive"),
+		summaryCount(term.KindWarning, summary.WarningsCount(), "warnings"),
+		summaryCount(term.KindDead, summary.Dead+summary.Errors, "dead"),
+		summaryCount(term.KindDuplicate, summary.Duplicates, "duplicates"))
+	if summary.Skipped > 0 {
+		fmt.Printf(" | %d skipped", summary.Skipped)
+	}
 	if ignoredCount > 0 {
-		fmt.Printf("Summary: %d alive | %d warnings | %d dead | %d duplicates | %d ignored\n\n",
-			summary.Alive, summary.WarningsCount(), summary.Dead+summary.Errors,
-			summary.Duplicates, ignoredCount)
-	} else {
-		fmt.Printf("Summary: %d alive | %d warnings | %d dead | %d duplicates\n\n",
-			summary.Alive, summary.WarningsCount(), summary.Dead+summary.Errors,
-			summary.Duplicates)
+		fmt.Printf(" | %d ignored", ignoredCount)
 	}
+	fmt.Print("\n\n")
+}
+
+// summaryCount formats a count of the summary line, colored by kind unless it's 0.
+func summaryCount(kind term.Kind, n int, label string) string {
+	text := fmt.Sprintf("%d %s", n, label)
+	if n == 0 {
+		return text
+	}
+	return console.Status(kind, text)
 }
 
 // getEmptyResultsMessage returns the appropriate message when no results match filters.
 func getEmptyResultsMessage(summary checker.Summary) string {
 	switch {
+	case baselinePath != "" && !showAlive && !showWarnings:
+		return "No links broken since the baseline."
 	case showAlive && summary.Alive == 0:
 		return "No alive links found."
 	case showWarnings && summary.WarningsCount() == 0:
@@ -138,20 +203,74 @@ func shouldGroupResults() bool {
 
 // outputGroupedResults prints results grouped by status sections.
 func outputGroupedResults(filtered []checker.Result) {
-	printSection("Warnings", FilterResultsWarnings(filtered), printWarningResult)
-	printSection("Dead Links", FilterResultsDead(filtered), printDeadResult)
-	printSection("Duplicates", FilterResultsDuplicates(filtered), printDuplicateResult)
+	printSection("Warnings", FilterResultsWarnings(filtered), warningEntry)
+	deadTitle := "Dead Links"
+	if baselinePath != "" {
+		deadTitle = "Broken Since Baseline"
+	}
+	printSection(deadTitle, FilterResultsDead(filtered), deadEntry)
+	printSection("Duplicates", FilterResultsDuplicates(filtered), duplicateEntry)
+	printSection("Skipped", FilterResultsSkipped(filtered), skippedEntry)
 
 	if showAll {
-		printSection("Alive", FilterResultsAlive(filtered), printAliveResult)
+		printSection("Alive", FilterResultsAlive(filtered), aliveEntry)
+	} else {
+		printSection("Hints", FilterResultsAlive(filtered), aliveEntry)
+	}
+}
+
+// outputUniqueResults prints each dead URL once instead of once per occurrence.
+// Duplicates of dead links are folded into the unique section.
+func outputUniqueResults(results, filtered []checker.Result) {
+	unique := checker.UniqueDead(results)
+	if len(unique) > 0 {
+		entries := make([]term.Entry, len(unique))
+		for i, u := range unique {
+			entries[i] = uniqueDeadEntry(u)
+		}
+		console.Section(fmt.Sprintf("Dead URLs (%d unique)", len(unique)), entries)
+	}
+
+	if showDead {
+		return
+	}
+
+	printSection("Warnings", FilterResultsWarnings(filtered), warningEntry)
+
+	var duplicates []checker.Result
+	for _, r := range FilterResultsDuplicates(filtered) {
+		if r.DuplicateOf == nil || !r.DuplicateOf.IsDead() {
+			duplicates = append(duplicates, r)
+		}
+	}
+	printSection("Duplicates", duplicates, duplicateEntry)
+	printSection("Skipped", FilterResultsSkipped(filtered), skippedEntry)
+
+	if showAll {
+		printSection("Alive", FilterResultsAlive(filtered), aliveEntry)
+	} else {
+		printSection("Hints", FilterResultsAlive(filtered), aliveEntry)
+	}
+}
+
+// outputResultGroups prints a section per file or domain (--group-by).
+func outputResultGroups(filtered []checker.Result) {
+	for _, g := range output.GroupResults(filtered, resultGrouping) {
+		entries := make([]term.Entry, len(g.Results))
+		for i, r := range g.Results {
+			entries[i] = resultEntry(r)
+		}
+		console.Section(g.Title(), entries)
 	}
 }
 
 // outputFlatResults prints results as a flat list.
 func outputFlatResults(filtered []checker.Result) {
-	for _, r := range filtered {
-		printResult(r)
+	entries := make([]term.Entry, len(filtered))
+	for i, r := range filtered {
+		entries[i] = resultEntry(r)
 	}
+	console.Entries(entries)
 }
 
 // maybeShowIgnored shows ignored URLs if the flag is set and filter exists.
@@ -2,11 +2,15 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"strconv"
 	"strings"
 
 	"github.com/leonardomso/gone/internal/checker"
 	"github.com/leonardomso/gone/internal/filter"
 	"github.com/leonardomso/gone/internal/helpers"
+	"github.com/leonardomso/gone/internal/stats"
+	"github.com/leonardomso/gone/internal/term"
 )
 
 // printProgressMessage displays the scanning progress with ignore info.
@@ -29,104 +33,235 @@ func printProgressMessage(total, afterFilter, unique, duplicates, ignored int) {
 	}
 }
 
+// console renders the text output. runCheck applies --no-color and --theme.
+var console = term.NewPrinter(os.Stdout)
+
 // printSection prints a titled section of results if any exist.
-// Uses the provided printer function to format each individual result.
-func printSection(title string, results []checker.Result, printer func(checker.Result)) {
+// Uses the provided entry function to format each individual result.
+func printSection(title string, results []checker.Result, entry func(checker.Result) term.Entry) {
 	if len(results) == 0 {
 		return
 	}
-	fmt.Printf("=== %s (%d) ===\n\n", title, len(results))
-	for _, r := range results {
-		printer(r)
+	entries := make([]term.Entry, len(results))
+	for i, r := range results {
+		entries[i] = entry(r)
 	}
-	fmt.Println()
+	console.Section(fmt.Sprintf("%s (%d)", title, len(results)), entries)
 }
 
-// printResult dispatches to the appropriate printer based on result status.
-func printResult(r checker.Result) {
+// printFixedLinks prints the baseline's dead links that no longer are (--baseline).
+func printFixedLinks(fixed []checker.Result) {
+	printSection("Fixed Since Baseline", fixed, func(r checker.Result) term.Entry {
+		return term.Entry{
+			Tag: "[FIXED]", Kind: term.KindAlive, URL: r.Link.URL,
+			Fields: []term.Field{fileField(r.Link)},
+		}
+	})
+}
+
+// resultEntry dispatches to the appropriate entry function based on result status.
+func resultEntry(r checker.Result) term.Entry {
 	switch r.Status {
-	case checker.StatusAlive:
-		printAliveResult(r)
-	case checker.StatusRedirect, checker.StatusBlocked:
-		printWarningResult(r)
-	case checker.StatusDead, checker.StatusError:
-		printDeadResult(r)
+	case checker.StatusRedirect, checker.StatusLongRedirect, checker.StatusLoginRequired,
+		checker.StatusBrokenAnchor, checker.StatusInsecure, checker.StatusSuspect, checker.StatusBlocked:
+		return warningEntry(r)
+	case checker.StatusDead, checker.StatusError, checker.StatusTLSError:
+		return deadEntry(r)
 	case checker.StatusDuplicate:
-		printDuplicateResult(r)
+		return duplicateEntry(r)
+	case checker.StatusSkipped:
+		return skippedEntry(r)
+	default:
+		return aliveEntry(r)
 	}
 }
 
-// printAliveResult formats and prints a result with alive status.
-func printAliveResult(r checker.Result) {
-	fmt.Printf("  [%d] %s\n", r.StatusCode, r.Link.URL)
-	if text := helpers.TruncateText(r.Link.Text, 50); text != "" {
-		fmt.Printf("       Text: %q\n", text)
-	}
-	fmt.Printf("       File: %s", r.Link.FilePath)
-	if r.Link.Line > 0 {
-		fmt.Printf(":%d", r.Link.Line)
+// fileField returns the File field of a link's location.
+func fileField(l checker.Link) term.Field {
+	return term.FileField("File", l.FilePath, l.Line)
+}
+
+// textField appends the Text field of a link, if it has text.
+func textField(fields []term.Field, l checker.Link) []term.Field {
+	if text := helpers.TruncateText(l.Text, 50); text != "" {
+		fields = append(fields, term.Field{Label: "Text", Value: fmt.Sprintf("%q", text)})
 	}
-	fmt.Println()
-	fmt.Println()
+	return fields
 }
 
-// printWarningResult formats and prints a result with warning status (redirect or blocked).
-func printWarningResult(r checker.Result) {
-	fmt.Printf("  %s %s\n", r.StatusDisplay(), r.Link.URL)
+// aliveEntry formats a result with alive status.
+func aliveEntry(r checker.Result) term.Entry {
+	fields := textField(nil, r.Link)
+	fields = append(fields, fileField(r.Link))
+	fields = profileField(fields, r)
+	fields = hintFields(fields, r)
+	return term.Entry{Tag: fmt.Sprintf("[%d]", r.StatusCode), Kind: term.KindAlive, URL: r.Link.URL, Fields: fields}
+}
 
-	if text := helpers.TruncateText(r.Link.Text, 50); text != "" {
-		fmt.Printf("       Text: %q\n", text)
+// warningEntry formats a result with warning status (redirect or blocked).
+func warningEntry(r checker.Result) term.Entry {
+	fields := textField(nil, r.Link)
+	if len(r.RedirectChain) > 0 {
+		fields = append(fields,
+			term.Field{Label: "Chain", Value: formatRedirectChain(r)},
+			term.Field{Label: "Final", Value: r.FinalURL, Link: r.FinalURL})
 	}
+	if r.Anchor != "" {
+		value := "#" + r.Anchor
+		if r.ClosestAnchor != "" {
+			value += " (closest: #" + r.ClosestAnchor + ")"
+		}
+		fields = append(fields, term.Field{Label: "Missing anchor", Value: value})
+	}
+	fields = append(fields, fileField(r.Link))
+	fields = protocolField(fields, r)
+	fields = profileField(fields, r)
+	fields = certificateField(fields, r)
+	fields = suggestionField(fields, r)
+	fields = hintFields(fields, r)
+	fields = append(fields, term.Field{Label: "Note", Value: r.Status.Description()})
+	return term.Entry{Tag: r.StatusDisplay(), Kind: term.KindWarning, URL: r.Link.URL, Fields: fields}
+}
 
-	if r.Status == checker.StatusRedirect && len(r.RedirectChain) > 0 {
-		fmt.Printf("       Chain: %s\n", formatRedirectChain(r))
-		fmt.Printf("       Final: %s\n", r.FinalURL)
+// protocolField appends the negotiated protocol of a failing or blocked link,
+// which helps diagnose servers that behave differently across HTTP versions.
+func protocolField(fields []term.Field, r checker.Result) []term.Field {
+	if r.Protocol != "" && (r.Status == checker.StatusBlocked || r.IsDead()) {
+		fields = append(fields, term.Field{Label: "Protocol", Value: r.Protocol})
 	}
+	return fields
+}
 
-	fmt.Printf("       File: %s", r.Link.FilePath)
-	if r.Link.Line > 0 {
-		fmt.Printf(":%d", r.Link.Line)
+// profileField appends the header profile that decided the link's status,
+// such as the browser profile that got past a 403.
+func profileField(fields []term.Field, r checker.Result) []term.Field {
+	if r.Profile != "" {
+		fields = append(fields, term.Field{Label: "Profile", Value: r.Profile})
 	}
-	fmt.Println()
-	fmt.Printf("       Note: %s\n\n", r.Status.Description())
+	return fields
 }
 
-// printDeadResult formats and prints a result with dead or error status.
-func printDeadResult(r checker.Result) {
-	fmt.Printf("  %s %s\n", r.StatusDisplay(), r.Link.URL)
-	if text := helpers.TruncateText(r.Link.Text, 50); text != "" {
-		fmt.Printf("       Text: %q\n", text)
+// certificateField appends the certificate problem found on the link's host, if any.
+func certificateField(fields []term.Field, r checker.Result) []term.Field {
+	if r.TLS != nil {
+		fields = append(fields, term.Field{Label: "Certificate", Value: r.TLS.Problem + " for " + r.TLS.Host})
 	}
-	fmt.Printf("       File: %s", r.Link.FilePath)
-	if r.Link.Line > 0 {
-		fmt.Printf(":%d", r.Link.Line)
+	return fields
+}
+
+// suggestionField appends the fix the fixer would apply, if any.
+func suggestionField(fields []term.Field, r checker.Result) []term.Field {
+	if r.Suggestion != nil {
+		fields = append(fields, term.Field{
+			Label: "Fix", Value: r.Suggestion.URL, Link: r.Suggestion.URL,
+			Suffix: " (" + r.Suggestion.Source + ")",
+		})
 	}
-	fmt.Println()
+	return fields
+}
 
+// hintFields appends content hints about the target page, if any.
+func hintFields(fields []term.Field, r checker.Result) []term.Field {
+	for _, hint := range r.Hints {
+		fields = append(fields, term.Field{Label: "Hint", Value: hint})
+	}
+	return fields
+}
+
+// deadEntry formats a result with dead or error status.
+func deadEntry(r checker.Result) term.Entry {
+	fields := textField(nil, r.Link)
+	fields = append(fields, fileField(r.Link))
+	if context := helpers.TruncateText(r.Link.Context, 100); context != "" {
+		fields = append(fields, term.Field{Label: "Context", Value: context})
+	}
+	fields = protocolField(fields, r)
+	fields = profileField(fields, r)
+	if r.Resolver != "" {
+		fields = append(fields, term.Field{Label: "Checked via", Value: r.Resolver + " API"})
+	}
 	if r.Error != "" {
-		fmt.Printf("       Error: %s\n", r.Error)
+		fields = append(fields, term.Field{Label: "Error", Value: r.Error})
 	}
-	fmt.Println()
+	fields = certificateField(fields, r)
+	fields = suggestionField(fields, r)
+	return term.Entry{Tag: r.StatusDisplay(), Kind: term.KindDead, URL: r.Link.URL, Fields: fields}
 }
 
-// printDuplicateResult formats and prints a result that is a duplicate of another link.
-func printDuplicateResult(r checker.Result) {
-	fmt.Printf("  [DUPLICATE] %s\n", r.Link.URL)
-	if text := helpers.TruncateText(r.Link.Text, 50); text != "" {
-		fmt.Printf("              Text: %q\n", text)
+// maxPrintedLocations caps the locations printed per unique dead URL.
+const maxPrintedLocations = 10
+
+// uniqueDeadEntry formats a dead URL with all of its locations.
+func uniqueDeadEntry(u checker.UniqueURL) term.Entry {
+	r := u.Result
+	fields := []term.Field{{Label: "Occurrences", Value: strconv.Itoa(u.Count())}}
+	for i, l := range u.Locations {
+		if i == maxPrintedLocations {
+			fields = append(fields, term.Field{Value: fmt.Sprintf("... and %d more", u.Count()-i)})
+			break
+		}
+		fields = append(fields, fileField(l))
 	}
-	fmt.Printf("              File: %s", r.Link.FilePath)
-	if r.Link.Line > 0 {
-		fmt.Printf(":%d", r.Link.Line)
+	if r.Error != "" {
+		fields = append(fields, term.Field{Label: "Error", Value: r.Error})
 	}
-	fmt.Println()
+	return term.Entry{Tag: r.StatusDisplay(), Kind: term.KindDead, URL: r.Link.URL, Fields: fields}
+}
 
+// duplicateEntry formats a result that is a duplicate of another link.
+func duplicateEntry(r checker.Result) term.Entry {
+	fields := textField(nil, r.Link)
+	fields = append(fields, fileField(r.Link))
 	if r.DuplicateOf != nil {
-		fmt.Printf("              Same as: %s", r.DuplicateOf.Link.FilePath)
-		if r.DuplicateOf.Link.Line > 0 {
-			fmt.Printf(":%d", r.DuplicateOf.Link.Line)
+		same := term.FileField("Same as", r.DuplicateOf.Link.FilePath, r.DuplicateOf.Link.Line)
+		same.Suffix = " → Status: " + r.DuplicateOf.Status.Label()
+		fields = append(fields, same)
+	}
+	return term.Entry{Tag: "[DUPLICATE]", Kind: term.KindDuplicate, URL: r.Link.URL, Fields: fields}
+}
+
+// skippedEntry formats a link the run's budget left unchecked.
+func skippedEntry(r checker.Result) term.Entry {
+	fields := []term.Field{fileField(r.Link)}
+	if r.Error != "" {
+		fields = append(fields, term.Field{Label: "Reason", Value: r.Error})
+	}
+	return term.Entry{Tag: "[SKIPPED]", Kind: term.KindSkipped, URL: r.Link.URL, Fields: fields}
+}
+
+// printDomains prints the per-domain breakdown when --by-domain is set.
+func printDomains(results []checker.Result, urlFilter *filter.Filter) {
+	if !byDomain {
+		return
+	}
+	domains := summarizeDomains(results, urlFilter)
+	if len(domains) == 0 {
+		return
+	}
+
+	width := 0
+	for _, d := range domains {
+		width = max(width, len(d.Domain))
+	}
+	console.Heading(fmt.Sprintf("Domains (%d)", len(domains)))
+	for _, d := range domains {
+		fmt.Printf("  %-*s  %d link(s)", width, d.Domain, d.Links)
+		if list := d.StatusList(); list != "" {
+			fmt.Printf(" (%s)", list)
+		}
+		if d.Links > 0 {
+			fmt.Printf("  %s", stats.FormatDuration(d.Duration))
+		}
+		fmt.Println()
+		if d.Ignored > 0 {
+			fmt.Printf("       Ignored: %d\n", d.Ignored)
+		}
+		if d.Denied > 0 {
+			fmt.Printf("       Denied: %d\n", d.Denied)
+		}
+		for _, rule := range d.Rules {
+			fmt.Printf("       Rule: %s\n", rule)
 		}
-		fmt.Printf(" → Status: %s\n", r.DuplicateOf.Status.Label())
 	}
 	fmt.Println()
 }
@@ -138,25 +273,36 @@ func printIgnoredURLs(urlFilter *filter.Filter) {
 		return
 	}
 
-	fmt.Printf("\n=== Ignored URLs (%d) ===\n\n", len(ignored))
-	for _, ig := range ignored {
-		fmt.Printf("  [IGNORED] %s\n", ig.URL)
-		fmt.Printf("            File: %s", ig.File)
-		if ig.Line > 0 {
-			fmt.Printf(":%d", ig.Line)
+	entries := make([]term.Entry, len(ignored))
+	for i, ig := range ignored {
+		entries[i] = term.Entry{
+			Tag: "[IGNORED]", Kind: term.KindSkipped, URL: ig.URL,
+			Fields: []term.Field{
+				term.FileField("File", ig.File, ig.Line),
+				{Label: "Reason", Value: fmt.Sprintf("%s %q", ig.Type, ig.Rule)},
+			},
 		}
-		fmt.Println()
-		fmt.Printf("            Reason: %s %q\n\n", ig.Type, ig.Rule)
 	}
+	fmt.Println()
+	console.Heading(fmt.Sprintf("Ignored URLs (%d)", len(ignored)))
+	console.Entries(entries)
 }
 
 // formatRedirectChain formats a redirect chain as a string showing status codes.
-// Example output: "301 → 302 → 200".
+// Example output: "301 → 302 → 200 (2 hops)".
 func formatRedirectChain(r checker.Result) string {
 	parts := make([]string, 0, len(r.RedirectChain)+1)
 	for _, red := range r.RedirectChain {
 		parts = append(parts, fmt.Sprintf("%d", red.StatusCode))
 	}
 	parts = append(parts, fmt.Sprintf("%d", r.FinalStatus))
-	return strings.Join(parts, " → ")
+	return fmt.Sprintf("%s (%s)", strings.Join(parts, " → "), formatHops(r.ChainLength()))
+}
+
+// formatHops formats a redirect hop count with the correct plural.
+func formatHops(n int) string {
+	if n == 1 {
+		return "1 hop"
+	}
+	return fmt.Sprintf("%d hops", n)
 }
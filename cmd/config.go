@@ -0,0 +1,405 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/leonardomso/gone/internal/config"
+	"github.com/leonardomso/gone/internal/output"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+// Config command flag variables.
+var configShowEffective bool
+
+// configCmd groups commands that work on the config file.
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Validate and show the configuration",
+	Long: `Validate the .gonerc.yaml config file, and show the configuration
+'gone check' runs with once flags and defaults are applied.`,
+}
+
+// configValidateCmd checks a config file for errors.
+var configValidateCmd = &cobra.Command{
+	Use:   "validate [file]",
+	Short: "Check a config file for errors",
+	Long: `Check a config file (.gonerc.yaml by default) for YAML syntax errors,
+unknown keys, values of the wrong type, and invalid settings, and print each
+problem with its line.
+
+Unknown keys are only reported here: other commands ignore them, so a typo
+such as 'timout' silently leaves the default in place.
+
+Exits with status 1 if the file has problems.
+
+Examples:
+  gone config validate                  # Check .gonerc.yaml
+  gone config validate ci/.gonerc.yaml  # Check another file`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runConfigValidate,
+}
+
+// configShowCmd prints the configuration.
+var configShowCmd = &cobra.Command{
+	Use:   "show [flags]",
+	Short: "Print the configuration",
+	Long: `Print the settings of .gonerc.yaml as gone reads them.
+
+With --effective, print the configuration 'gone check' would run with
+instead: the config file's settings, overridden or extended by the check
+flags given, with defaults filled in. Settings that don't come from the
+config file say where they do, as a comment: a flag, the default, or the
+config file and a flag, for lists that flags add to.
+
+Settings only read environment variables through ${VAR} references, such
+as in headers or recheck_webhook; these are shown unexpanded, with whether
+each variable is set, so secrets aren't printed. With the github format, the
+step summary goes to $GITHUB_STEP_SUMMARY, which is shown the same way.
+
+Examples:
+  gone config show                                        # The config file's settings
+  gone config show --effective                            # What 'gone check' runs with
+  gone config show --effective -c 100 --fail-on=warnings  # ...given these flags`,
+	Args: cobra.NoArgs,
+	Run:  runConfigShow,
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configValidateCmd, configShowCmd)
+
+	configShowCmd.Flags().BoolVar(&configShowEffective, "effective", false,
+		"Print the configuration 'gone check' would run with, given the check flags")
+	addCheckFlags(configShowCmd.Flags())
+}
+
+// runConfigValidate is the entry point for the config validate command.
+func runConfigValidate(_ *cobra.Command, args []string) {
+	path := config.DefaultConfigFileName
+	if len(args) > 0 {
+		path = args[0]
+	}
+	data, err := os.ReadFile(path)
+	exitOnError(err, "Error reading config")
+
+	problems := config.CheckFile(data)
+	for _, p := range problems {
+		if p.Line > 0 {
+			fmt.Printf("%s:%d: %s\n", path, p.Line, p.Message)
+		} else {
+			fmt.Printf("%s: %s\n", path, p.Message)
+		}
+	}
+	if len(problems) > 0 {
+		fmt.Printf("\n%d problem(s) found\n", len(problems))
+		exit(1)
+	}
+	fmt.Printf("%s is valid\n", path)
+}
+
+// runConfigShow is the entry point for the config show command.
+func runConfigShow(cmd *cobra.Command, _ []string) {
+	if !configShowEffective {
+		cmd.Flags().Visit(func(f *pflag.Flag) {
+			if f.Name != "no-config" {
+				exitOnError(fmt.Errorf("--%s only applies with --effective", f.Name), "Invalid flags")
+			}
+		})
+	}
+
+	loadedCfg, err := LoadConfig(noConfig)
+	exitOnError(err, "Config error")
+
+	var doc yaml.Node
+	if configShowEffective {
+		eff, err := loadedCfg.WithCheckFlags()
+		exitOnError(err, "Invalid flags")
+		sources, err := configSources(cmd.Flags(), loadedCfg.Config(), eff.Config())
+		exitOnError(err, "Error encoding config")
+		exitOnError(doc.Encode(eff.Config()), "Error encoding config")
+		annotateConfig(&doc, "", sources)
+		pruneConfig(&doc)
+		fmt.Println("# The configuration 'gone check' runs with. Settings without a comment")
+		fmt.Println("# come from the config file; unset ones are left out.")
+	} else {
+		exitOnError(doc.Encode(loadedCfg.Config()), "Error encoding config")
+		pruneConfig(&doc)
+		if len(doc.Content) == 0 {
+			fmt.Printf("# No settings in %s\n", config.DefaultConfigFileName)
+			return
+		}
+	}
+	data, err := yaml.Marshal(&doc)
+	exitOnError(err, "Error encoding config")
+	fmt.Print(string(data))
+}
+
+// checkFlagPaths maps the settings check flags change, by path, to the flags.
+var checkFlagPaths = map[string]string{
+	"types":                     "types",
+	"scan.priority_paths":       "priority-paths",
+	"scan.parse_timeout":        "parse-timeout",
+	"check.concurrency":         "concurrency",
+	"check.timeout":             "timeout",
+	"check.retries":             "retries",
+	"check.max_redirects":       "max-redirects",
+	"check.redirect_warn_hops":  "redirect-warn-hops",
+	"check.redirect_policy":     "redirect-policy",
+	"check.max_requests":        "max-requests",
+	"check.max_duration":        "max-duration",
+	"check.stale_after":         "stale-after",
+	"check.ua_profile":          "ua-profile",
+	"check.ip_family":           "ip-family",
+	"check.dns_server":          "dns-server",
+	"check.fail_on":             "fail-on",
+	"check.strict":              "strict",
+	"check.probe_hosts":         "probe-hosts",
+	"check.inspect_pages":       "inspect-pages",
+	"check.check_anchors":       "check-anchors",
+	"check.detect_soft_404":     "detect-soft-404",
+	"check.check_internal":      "check-internal",
+	"check.insecure":            "insecure",
+	"check.lint":                "lint",
+	"check.http3":               "http3",
+	"check.enable_cookies":      "enable-cookies",
+	"check.disable_resolvers":   "disable-resolver",
+	"check.status_codes.alive":  "accept-status",
+	"check.login_patterns":      "login-pattern",
+	"ignore.domains":            "ignore-domain",
+	"ignore.patterns":           "ignore-pattern",
+	"ignore.regex":              "ignore-regex",
+	"ignore.badges":             "skip-badges",
+	"output.format":             "format",
+	"output.template":           "template",
+	"output.metricsFile":        "metrics-file",
+	"output.theme":              "theme",
+	"output.groupBy":            "group-by",
+	"output.sort":               "sort",
+	"output.top":                "top",
+	"output.reportAll":          "report-all",
+	"output.junitSuitePer":      "junit-suite-per",
+	"output.junitIncludePassed": "junit-include-passed",
+	"output.showStats":          "stats",
+	"cache.enabled":             "cache",
+}
+
+// configSources returns where the settings of eff, the effective
+// configuration, that don't come from the config file come from, by path:
+// "default" for unset settings, or the flag that changed the setting, with
+// the config file too for lists the flag adds to.
+func configSources(flags *pflag.FlagSet, file, eff *config.Config) (map[string]string, error) {
+	var fileDoc, effDoc yaml.Node
+	if err := fileDoc.Encode(file); err != nil {
+		return nil, err
+	}
+	if err := effDoc.Encode(eff); err != nil {
+		return nil, err
+	}
+	fileValues, effValues := map[string]*yaml.Node{}, map[string]*yaml.Node{}
+	configValues(&fileDoc, "", fileValues)
+	configValues(&effDoc, "", effValues)
+
+	sources := map[string]string{}
+	for path, value := range effValues {
+		fileValue := fileValues[path]
+		flag := checkFlagPaths[path]
+		switch {
+		case flag != "" && flags.Changed(flag) && (isUnset(fileValue) || !sameValue(fileValue, value)):
+			sources[path] = "--" + flag
+			if value.Kind == yaml.SequenceNode && !isUnset(fileValue) {
+				sources[path] = "config file + --" + flag
+			}
+		case isUnset(fileValue) && !isUnset(value):
+			sources[path] = "default"
+		}
+	}
+
+	if src, ok := sources["check.login_patterns"]; ok {
+		sources["check.login_patterns"] = src + ", plus the built-in ones"
+	} else {
+		sources["check.login_patterns"] = "plus the built-in ones"
+	}
+	if output.Format(eff.Output.Format) == output.FormatGitHub {
+		state := "unset"
+		if _, ok := os.LookupEnv(output.StepSummaryEnv); ok {
+			state = "set"
+		}
+		sources["output.format"] = joinSources(sources["output.format"],
+			"step summary to $"+output.StepSummaryEnv+" "+state)
+	}
+	return sources, nil
+}
+
+// configValues collects the values under node by path. Lists are values of
+// their own; mappings are walked.
+func configValues(node *yaml.Node, path string, values map[string]*yaml.Node) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			configValues(child, path, values)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			child := node.Content[i].Value
+			if path != "" {
+				child = path + "." + child
+			}
+			configValues(node.Content[i+1], child, values)
+		}
+	default:
+		values[path] = node
+	}
+}
+
+// sameValue reports whether two config values are equal. A missing value
+// equals an unset one.
+func sameValue(a, b *yaml.Node) bool {
+	if a == nil || b == nil {
+		return isUnset(a) && isUnset(b)
+	}
+	if a.Kind != b.Kind || a.Value != b.Value || len(a.Content) != len(b.Content) {
+		return false
+	}
+	for i := range a.Content {
+		if !sameValue(a.Content[i], b.Content[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// joinSources joins the comments of a setting.
+func joinSources(a, b string) string {
+	if a == "" {
+		return b
+	}
+	return a + "; " + b
+}
+
+// annotateConfig comments the settings under node with their sources, and
+// header values with the environment variables they reference. Lists of
+// values are written on one line, so their comment is too.
+func annotateConfig(node *yaml.Node, path string, sources map[string]string) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			annotateConfig(child, path, sources)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			child := key.Value
+			if path != "" {
+				child = path + "." + key.Value
+			}
+			if value.Kind == yaml.SequenceNode && !slices.ContainsFunc(value.Content, isCollection) {
+				value.Style = yaml.FlowStyle
+			}
+			comment := sources[child]
+			if value.Kind == yaml.ScalarNode && strings.Contains(value.Value, "$") {
+				comment = describeEnv(value.Value)
+			}
+			switch {
+			case comment == "":
+			case value.Style == yaml.FlowStyle:
+				// yaml writes the comments of keys after their value's
+				// first line, so comment one-line lists themselves
+				value.LineComment = comment
+			default:
+				key.LineComment = comment
+			}
+			annotateConfig(value, child, sources)
+		}
+	}
+}
+
+// isCollection reports whether node is a mapping or a sequence.
+func isCollection(node *yaml.Node) bool {
+	return node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode
+}
+
+// describeEnv lists the environment variables value references, and
+// whether each is set.
+func describeEnv(value string) string {
+	var vars []string
+	os.Expand(value, func(name string) string {
+		state := "unset"
+		if _, ok := os.LookupEnv(name); ok {
+			state = "set"
+		}
+		vars = append(vars, "$"+name+" "+state)
+		return ""
+	})
+	if len(vars) == 0 {
+		return ""
+	}
+	return "expands " + strings.Join(vars, ", ")
+}
+
+// pruneConfig removes the unset settings under node: empty values, zeros,
+// and sections left empty, unless annotateConfig commented them.
+func pruneConfig(node *yaml.Node) {
+	for _, child := range node.Content {
+		pruneConfig(child)
+	}
+	if node.Kind != yaml.MappingNode && node.Kind != yaml.DocumentNode {
+		return
+	}
+	if node.Kind == yaml.DocumentNode {
+		if len(node.Content) > 0 && len(node.Content[0].Content) == 0 {
+			node.Content = nil
+		}
+		return
+	}
+	content := node.Content[:0]
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if key.LineComment != "" || value.LineComment != "" || !isUnset(value) {
+			content = append(content, key, value)
+		}
+	}
+	node.Content = content
+}
+
+// isUnset reports whether a config value is missing or the zero value of its type.
+func isUnset(node *yaml.Node) bool {
+	if node == nil {
+		return true
+	}
+	if node.Kind != yaml.ScalarNode {
+		return len(node.Content) == 0
+	}
+	switch node.Tag {
+	case "!!null":
+		return true
+	case "!!bool":
+		return node.Value == "false"
+	case "!!int":
+		n, err := strconv.Atoi(node.Value)
+		return err == nil && n == 0
+	case "!!str":
+		return node.Value == ""
+	}
+	return false
+}
+
+// shortDuration formats d without trailing zero units, such as 24h instead
+// of 24h0m0s.
+func shortDuration(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/leonardomso/gone/internal/config"
+)
+
+// The check flags are package variables, so these tests don't run in parallel.
+
+func TestWithCheckFlags_Sources(t *testing.T) {
+	file := &config.Config{
+		Check:  config.CheckConfig{Concurrency: 10, Timeout: 20},
+		Ignore: config.IgnoreConfig{Domains: []string{"a.com"}},
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		want       string
+		wantSource string
+		args       []string
+	}{
+		{"flag overrides", "check.timeout", "9", "--timeout", []string{"--timeout=9"}},
+		{"config file wins over default flag value", "check.concurrency", "10", "",
+			[]string{"--concurrency=50"}},
+		{"config file", "check.concurrency", "10", "", nil},
+		{"flag extends", "ignore.domains", "a.com,b.com", "config file + --ignore-domain",
+			[]string{"--ignore-domain=b.com"}},
+		{"flag sets list", "ignore.patterns", "*.local", "--ignore-pattern", []string{"--ignore-pattern=*.local"}},
+		{"default", "check.retries", "1", "default", nil},
+		{"flag sets zero", "check.retries", "0", "--retries", []string{"--retries=0"}},
+		{"flag turns on", "check.check_anchors", "true", "--check-anchors", []string{"--check-anchors"}},
+		{"flag replaces default", "check.fail_on", "none", "--fail-on", []string{"--fail-on=none"}},
+		{"default string", "check.fail_on", "dead", "default", nil},
+		{"unset", "output.top", "0", "", nil},
+		{"flag sets top", "output.top", "5", "--top", []string{"--top=5"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Registering resets the flag variables to their defaults
+			flags := pflag.NewFlagSet("check", pflag.ContinueOnError)
+			addCheckFlags(flags)
+			require.NoError(t, flags.Parse(tt.args))
+
+			eff, err := (&LoadedConfig{cfg: file}).WithCheckFlags()
+			require.NoError(t, err)
+			sources, err := configSources(flags, file, eff.Config())
+			require.NoError(t, err)
+
+			values := configNodes(t, eff.Config())
+			assert.Equal(t, tt.want, values[tt.path])
+			assert.Equal(t, tt.wantSource, sources[tt.path])
+		})
+	}
+}
+
+func TestCheckFlagPaths(t *testing.T) {
+	flags := pflag.NewFlagSet("check", pflag.ContinueOnError)
+	addCheckFlags(flags)
+	eff, err := (&LoadedConfig{cfg: &config.Config{}}).WithCheckFlags()
+	require.NoError(t, err)
+	values := configNodes(t, eff.Config())
+
+	for path, flag := range checkFlagPaths {
+		assert.NotNil(t, flags.Lookup(flag), "--%s", flag)
+		_, ok := values[path]
+		assert.True(t, ok, path)
+	}
+}
+
+// configNodes returns the values of cfg by path, with lists joined by commas
+// and nulls empty.
+func configNodes(t *testing.T, cfg *config.Config) map[string]string {
+	t.Helper()
+	var doc yaml.Node
+	require.NoError(t, doc.Encode(cfg))
+	nodes := map[string]*yaml.Node{}
+	configValues(&doc, "", nodes)
+
+	values := make(map[string]string, len(nodes))
+	for path, node := range nodes {
+		switch {
+		case node.Tag == "!!null":
+			values[path] = ""
+		case node.Kind == yaml.SequenceNode:
+			items := make([]string, 0, len(node.Content))
+			for _, item := range node.Content {
+				items = append(items, item.Value)
+			}
+			values[path] = strings.Join(items, ",")
+		default:
+			values[path] = node.Value
+		}
+	}
+	return values
+}
@@ -2,13 +2,24 @@ package cmd
 
 import (
 	"bufio"
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/leonardomso/gone/internal/archive"
 	"github.com/leonardomso/gone/internal/checker"
 	"github.com/leonardomso/gone/internal/fixer"
+	"github.com/leonardomso/gone/internal/forge"
+	"github.com/leonardomso/gone/internal/gitrepo"
 	"github.com/leonardomso/gone/internal/parser"
+	"github.com/leonardomso/gone/internal/parser/markdown"
+	"github.com/leonardomso/gone/internal/relative"
 	"github.com/leonardomso/gone/internal/scanner"
 	"github.com/leonardomso/gone/internal/stats"
 
@@ -23,6 +34,22 @@ var (
 	fixTimeout     int
 	fixRetries     int
 	fixShowStats   bool
+	fixArchiveDead bool
+	fixUpgradeHTTP bool
+	fixNormalize   []string
+	fixLineScoped  bool
+	fixAnchors     bool
+	fixMaxRisk     string
+	fixUndo        bool
+	fixNoBackup    bool
+	fixGitCommit   bool
+	fixGitBranch   string
+	fixAllowDirty  bool
+	fixCreatePR    bool
+	fixInteractive string
+	fixDiff        bool
+	fixPatchFile   string
+	fixPlanFile    string
 
 	// File type flags.
 	fixFileTypes  []string
@@ -35,6 +62,21 @@ var (
 	fixNoConfig       bool
 )
 
+// fixBackup keeps the originals of the files the fix session modifies.
+var fixBackup *fixer.Backup
+
+// fixRepo is the git working tree fixes are committed to, if requested.
+var fixRepo *gitrepo.Repo
+
+// Where --create-pr opens its pull request.
+var (
+	fixForge      forge.Forge
+	fixBaseBranch string
+)
+
+// fixPRRemote is the remote --create-pr pushes to.
+const fixPRRemote = "origin"
+
 // fixCmd represents the fix command.
 var fixCmd = &cobra.Command{
 	Use:   "fix [path]",
@@ -42,14 +84,78 @@ var fixCmd = &cobra.Command{
 	Long: `Scan files for redirect URLs and update them to their final destinations.
 
 Only redirects where the final destination returns 200 OK are fixed.
-Dead links, errors, and blocked URLs are not modified.
+Dead links, errors, and blocked URLs are not modified, unless
+--archive-dead is set (see below).
 
 By default, scans only markdown files (.md).
 Use --types to scan additional file types.
 
 By default, the command runs interactively, prompting for each file.
+Use --interactive=link to review each fix on its own instead: the changed
+line is shown before and after, and each fix can be accepted, rejected,
+or edited to use a URL you type.
 Use --yes to apply all fixes automatically (useful for CI/scripts).
+With --yes, all files are updated as one transaction: if any file fails,
+no file is modified.
 Use --dry-run to preview changes without modifying files.
+Use --diff to show the changes as a unified diff instead of the summary,
+and --patch-file to write that diff to a file instead of modifying files,
+so it can be reviewed or applied later with 'git apply'.
+--plan writes the fixes to a JSON file instead of modifying files: every
+replacement with its file, line, old and new URL, and the reason for it
+(redirect, rewrite, archive, https, normalize, or anchor), for tools that apply
+changes their own way.
+
+Before modifying files, the originals are backed up to
+.gone/backup/<time>/, with an apply-log.json describing every change.
+Use --undo to restore the files of the last fix session, or --no-backup
+to skip the backup.
+
+With --git-commit, the applied fixes are committed with a message listing
+every URL change. --git-branch=<name> commits them on a new branch instead.
+Both refuse to run when the working tree has uncommitted changes, unless
+--allow-dirty is passed.
+
+--create-pr commits the fixes on a new branch, pushes it to origin, and
+opens a pull request on GitHub (token from GITHUB_TOKEN or GH_TOKEN) or a
+merge request on GitLab (token from GITLAB_TOKEN). The branch is named
+gone/fix-links-<time> unless --git-branch is given.
+
+URLs matching a "rewrites" rule in .gonerc.yaml are replaced directly,
+without checking them over the network.
+
+With --archive-dead, dead links are replaced with their latest snapshot
+on the Wayback Machine (archive.org), when one exists. Dead links without
+a snapshot are left alone. In per-file interactive mode, each snapshot is
+confirmed link by link before the per-file prompts.
+
+With --upgrade-https, working http:// links are replaced with their https://
+version when it returns 200 with the same content (the same body, or the
+same page title), whether or not the server redirects to it.
+
+--normalize cleans up URLs with the given rules: "tracking" strips utm_*
+and fbclid query parameters, "slashes" collapses duplicate slashes, and
+"trailing-slash" follows redirects that only add or remove a trailing
+slash. Without "trailing-slash", those redirects are left alone once
+normalization is on. The rules also apply to the URLs that replace links.
+
+By default, a fixed URL is replaced wherever it appears in a file. With
+--line-scoped, it's only replaced on the lines it was found on as a link,
+so the same URL in a code block example or in plain text is left alone.
+A link whose line isn't known is skipped with a warning.
+
+With --fix-anchors, links to a #fragment missing from the target page are
+pointed at its closest heading or anchor, when one is close enough (#instal
+becomes #install). This covers external pages, checked as with
+'gone check --check-anchors', and relative links to markdown files.
+
+Every fix is rated low, medium, or high risk. Redirects that only change
+the scheme or host are low risk, redirects to another page of the same
+site medium, and redirects to another domain high. A fix is raised a level
+when the title of its new page shares no word with the link text. Rewrite
+rules, normalization, and https upgrades are low risk, archived snapshots
+and closest anchors medium. --max-risk=low (or medium) leaves riskier fixes out, so --yes only
+applies the safe ones.
 
 Examples:
   gone fix                      # Interactive mode, scan current directory
@@ -57,11 +163,24 @@ Examples:
   gone fix --types=md,json      # Scan markdown and JSON files
   gone fix --types=toml,xml     # Scan TOML and XML files
   gone fix --dry-run            # Preview what would be fixed
+  gone fix --interactive=link   # Review each fix, with a diff of its line
+  gone fix --dry-run --diff     # Preview the changes as a unified diff
+  gone fix --patch-file=p.diff # Write the changes to a patch file
+  gone fix --plan=plan.json     # Write the fixes to a JSON plan
   gone fix --yes                # Apply all fixes without prompting
   gone fix --yes --dry-run      # Preview all fixes (no prompts, no changes)
+  gone fix --archive-dead       # Also replace dead links with archived snapshots
+  gone fix --upgrade-https      # Also move http:// links that work over https://
+  gone fix --normalize=tracking,slashes  # Also strip tracking parameters and extra slashes
+  gone fix --line-scoped        # Only replace URLs on the lines they're linked on
+  gone fix --fix-anchors        # Also point broken #anchors at the closest heading
+  gone fix --yes --max-risk=low # Only apply fixes that keep the same page
+  gone fix --undo               # Restore the files of the last fix session
+  gone fix --yes --git-commit   # Apply all fixes and commit them
+  gone fix --yes --create-pr    # Open a pull request with the fixes
   gone fix --stats              # Show performance statistics
 
-Supported file types: md, json, yaml, toml, xml
+Supported file types: md, json, yaml, toml, xml, html
 
 Ignore patterns (same as check command):
   gone fix --ignore-domain=localhost
@@ -79,10 +198,42 @@ func init() {
 		"Apply all fixes without prompting")
 	fixCmd.Flags().BoolVarP(&fixDryRun, "dry-run", "n", false,
 		"Preview changes without modifying files")
+	fixCmd.Flags().BoolVar(&fixUndo, "undo", false,
+		"Restore the files modified by the last fix session")
+	fixCmd.Flags().BoolVar(&fixNoBackup, "no-backup", false,
+		"Don't back up files before modifying them")
+	fixCmd.Flags().BoolVar(&fixGitCommit, "git-commit", false,
+		"Commit the applied fixes with a message listing the URL changes")
+	fixCmd.Flags().StringVar(&fixGitBranch, "git-branch", "",
+		"Commit the applied fixes on a new branch with this name")
+	fixCmd.Flags().BoolVar(&fixAllowDirty, "allow-dirty", false,
+		"Allow --git-commit and --git-branch with uncommitted changes")
+	fixCmd.Flags().BoolVar(&fixCreatePR, "create-pr", false,
+		"Push the fixes to a new branch and open a pull request")
+	fixCmd.Flags().BoolVar(&fixDiff, "diff", false,
+		"Show the changes as a unified diff")
+	fixCmd.Flags().StringVar(&fixPatchFile, "patch-file", "",
+		"Write the changes to this file as a patch instead of applying them")
+	fixCmd.Flags().StringVar(&fixPlanFile, "plan", "",
+		"Write the fixes to this file as a JSON plan instead of applying them")
+	fixCmd.Flags().StringVar(&fixInteractive, "interactive", fixModeFile,
+		"Interactive prompt granularity: file or link")
+	fixCmd.Flags().BoolVar(&fixArchiveDead, "archive-dead", false,
+		"Replace dead links with their latest Wayback Machine snapshot")
+	fixCmd.Flags().BoolVar(&fixUpgradeHTTP, "upgrade-https", false,
+		"Replace http:// links with https:// when it serves the same content")
+	fixCmd.Flags().StringSliceVar(&fixNormalize, "normalize", nil,
+		"URL normalization rules (comma-separated): tracking, slashes, trailing-slash")
+	fixCmd.Flags().BoolVar(&fixLineScoped, "line-scoped", false,
+		"Only replace URLs on the lines they were found on as links")
+	fixCmd.Flags().BoolVar(&fixAnchors, "fix-anchors", false,
+		"Replace links to missing #anchors with the closest heading in the target")
+	fixCmd.Flags().StringVar(&fixMaxRisk, "max-risk", "",
+		"Leave out fixes riskier than this: low, medium, or high")
 
 	// File type options
 	fixCmd.Flags().StringSliceVarP(&fixFileTypes, "types", "T", []string{"md"},
-		"File types to scan (comma-separated): md, json, yaml, toml, xml")
+		"File types to scan (comma-separated): md, json, yaml, toml, xml, html, ini, env, properties, docker, code, txt")
 	fixCmd.Flags().BoolVar(&fixStrictMode, "strict", false,
 		"Fail on malformed files instead of skipping them")
 
@@ -112,6 +263,11 @@ func init() {
 // runFix is the main entry point for the fix command.
 // It scans for redirects and applies fixes interactively or automatically.
 func runFix(_ *cobra.Command, args []string) {
+	if fixUndo {
+		runUndo()
+		return
+	}
+
 	// Initialize stats tracking
 	perf := stats.New()
 
@@ -122,11 +278,42 @@ func runFix(_ *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	maxRisk := fixer.RiskHigh
+	if fixMaxRisk != "" {
+		var ok bool
+		if maxRisk, ok = fixer.ParseRisk(fixMaxRisk); !ok {
+			fmt.Fprintf(os.Stderr, "Error: invalid --max-risk %q (valid: %s)\n",
+				fixMaxRisk, strings.Join(fixer.RiskNames(), ", "))
+			os.Exit(1)
+		}
+	}
+
+	if fixInteractive != fixModeFile && fixInteractive != fixModeLink {
+		fmt.Fprintf(os.Stderr, "Error: invalid --interactive %q (valid: %s, %s)\n",
+			fixInteractive, fixModeFile, fixModeLink)
+		os.Exit(1)
+	}
+
 	// Determine the path to scan
 	path := "."
 	if len(args) > 0 {
 		path = args[0]
 	}
+	if archive.IsArchive(path) {
+		fmt.Fprintf(os.Stderr, "Error: can't fix files inside an archive; extract %s first\n", path)
+		os.Exit(1)
+	}
+
+	// Check the working tree before anything is modified
+	if fixCreatePR && fixGitBranch == "" {
+		fixGitBranch = "gone/fix-links-" + time.Now().UTC().Format("20060102-150405")
+	}
+	if (fixGitCommit || fixGitBranch != "") && !fixDryRun && fixPatchFile == "" && fixPlanFile == "" {
+		fixRepo = openFixRepo(path)
+		if fixCreatePR {
+			openForge(fixRepo)
+		}
+	}
 
 	// Get effective file types from config
 	effectiveTypes := loadedCfg.GetTypes(fixFileTypes, []string{"md"})
@@ -155,6 +342,12 @@ func runFix(_ *cobra.Command, args []string) {
 	}
 	perf.EndScan(len(files))
 
+	// Relative links to missing anchors are found on disk, without checking
+	var anchorFindings []relative.Finding
+	if fixAnchors {
+		anchorFindings = relative.Check(path, markdownLinks(files, markdown.ExtractInternalLinks))
+	}
+
 	typeStr := strings.Join(effectiveTypes, ", ")
 	fmt.Printf("Found %d file(s) of type(s): %s\n", len(files), typeStr)
 
@@ -163,18 +356,22 @@ func runFix(_ *cobra.Command, args []string) {
 
 	// Phase 2: Parse links
 	perf.StartParse()
-	parserLinks, err := parser.ExtractLinksFromMultipleFilesWithRegistry(files, effectiveStrict)
+	parserLinks, skipped, err := parser.ExtractLinksWithBudget(files, effectiveStrict, loadedCfg.BuildParseBudget(0))
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error parsing files: %v\n", err)
 		os.Exit(1)
 	}
+	printSkippedFiles(skipped)
 
 	// Get effective show stats
 	effectiveShowStats := loadedCfg.GetShowStats(fixShowStats)
 
-	if len(parserLinks) == 0 {
+	if len(parserLinks) == 0 && len(anchorFindings) == 0 {
 		perf.EndParse(0, 0, 0, 0)
 		fmt.Println("No links found.")
+		if fixPlanFile != "" {
+			writePlanFile(nil, fixPlanFile)
+		}
 		if effectiveShowStats {
 			fmt.Print(perf.String())
 		}
@@ -182,7 +379,7 @@ func runFix(_ *cobra.Command, args []string) {
 	}
 
 	// Load and create filter using config + CLI
-	urlFilter, err := CreateFilterWithConfig(loadedCfg.Config(), fixIgnoreDomains, fixIgnorePatterns, fixIgnoreRegex)
+	urlFilter, err := CreateFilterWithConfig(loadedCfg.Config(), fixIgnoreDomains, fixIgnorePatterns, fixIgnoreRegex, false)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error creating filter: %v\n", err)
 		os.Exit(1)
@@ -202,35 +399,81 @@ func runFix(_ *cobra.Command, args []string) {
 
 	perf.EndParse(len(parserLinks), uniqueURLs, duplicates, ignoredCount)
 
-	if len(links) == 0 {
+	if len(links) == 0 && len(anchorFindings) == 0 {
 		fmt.Println("All links were ignored by filter rules.")
+		if fixPlanFile != "" {
+			writePlanFile(nil, fixPlanFile)
+		}
 		if effectiveShowStats {
 			fmt.Print(perf.String())
 		}
 		return
 	}
 
-	fmt.Printf("Checking %d unique URL(s) for redirects...\n", uniqueURLs)
+	// Create fixer; links matching a rewrite rule are fixed without checking
+	rules, err := loadedCfg.BuildRewriteRules()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error in rewrite rules: %v\n", err)
+		os.Exit(1)
+	}
+	normalizer, err := loadedCfg.BuildNormalizer(fixNormalize)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	f := fixer.New()
+	f.SetParserLinks(parserLinks)
+	f.SetRewriteRules(rules)
+	f.SetNormalizer(normalizer)
+	f.SetLineScoped(fixLineScoped)
+	f.SetFixAnchors(fixAnchors)
+	f.SetInternalAnchors(anchorFindings)
+	rewritten, links := f.SplitRewrites(links)
+
+	if len(rewritten) > 0 {
+		fmt.Printf("Rewriting %d link(s) using rewrite rules.\n", len(rewritten))
+	}
+	fmt.Printf("Checking %d unique URL(s) for redirects...\n", CountUniqueURLs(links))
 
 	// Phase 3: Check URLs
 	perf.StartCheck()
 
 	// Create checker with config values
-	opts := loadedCfg.BuildCheckerOptions(fixConcurrency, fixTimeout, fixRetries)
+	opts := loadedCfg.BuildCheckerOptions(fixConcurrency, fixTimeout, fixRetries).
+		WithHooks(statsHooks(perf))
+	if fixAnchors {
+		opts = opts.WithCheckAnchors(true)
+	}
 
 	c := checker.New(opts)
 	results := c.CheckAll(links)
 
 	perf.EndCheck()
 
-	// Create fixer and find fixable items
-	f := fixer.New()
-	f.SetParserLinks(parserLinks)
-	changes := f.FindFixes(results)
+	if fixArchiveDead {
+		f.SetSnapshots(lookupSnapshots(os.Stdout, results, time.Duration(fixTimeout)*time.Second))
+	}
+	if fixUpgradeHTTP {
+		f.SetUpgrades(lookupUpgrades(os.Stdout, results, time.Duration(fixTimeout)*time.Second))
+	}
+
+	// Find fixable items
+	changes := f.FindAllFixes(results, rewritten)
+	if urls := fixer.TitleURLs(changes); len(urls) > 0 {
+		f.SetTitles(lookupTitles(urls))
+		changes = f.FindAllFixes(results, rewritten)
+	}
+	for _, link := range f.Unscoped() {
+		fmt.Fprintf(os.Stderr, "Warning: skipped %s in %s: line unknown with --line-scoped\n", link.URL, link.FilePath)
+	}
 
 	if len(changes) == 0 {
 		fmt.Println("\nNo fixable redirects found.")
 		printFixSummary(results)
+		if fixPlanFile != "" {
+			writePlanFile(changes, fixPlanFile)
+		}
 		if effectiveShowStats {
 			fmt.Print(perf.String())
 		}
@@ -239,7 +482,50 @@ func runFix(_ *cobra.Command, args []string) {
 
 	// Show preview
 	fmt.Println()
-	fmt.Print(f.Preview(changes))
+	if fixDiff {
+		diff, diffErr := f.Diff(changes)
+		if diffErr != nil {
+			fmt.Fprintf(os.Stderr, "Error creating diff: %v\n", diffErr)
+			os.Exit(1)
+		}
+		fmt.Print(diff)
+	} else {
+		fmt.Print(f.Preview(changes))
+	}
+
+	// Leave out fixes above --max-risk
+	var dropped int
+	if changes, dropped = fixer.FilterRisk(changes, maxRisk); dropped > 0 {
+		fmt.Printf("Leaving out %d fix(es) above %s risk.\n", dropped, maxRisk)
+		if len(changes) == 0 {
+			fmt.Println("No fixes left to apply.")
+			if fixPlanFile != "" {
+				writePlanFile(changes, fixPlanFile)
+			}
+			if effectiveShowStats {
+				fmt.Print(perf.String())
+			}
+			return
+		}
+	}
+
+	// Write a patch instead of modifying files
+	if fixPatchFile != "" {
+		writePatchFile(f, changes, fixPatchFile)
+		if effectiveShowStats {
+			fmt.Print(perf.String())
+		}
+		return
+	}
+
+	// Write a plan instead of modifying files
+	if fixPlanFile != "" {
+		writePlanFile(changes, fixPlanFile)
+		if effectiveShowStats {
+			fmt.Print(perf.String())
+		}
+		return
+	}
 
 	// Handle dry-run mode
 	if fixDryRun {
@@ -250,9 +536,18 @@ func runFix(_ *cobra.Command, args []string) {
 		return
 	}
 
+	if !fixNoBackup {
+		fixBackup, err = fixer.NewBackup(fixer.DefaultBackupDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error starting backup: %v\n", err)
+			os.Exit(1)
+		}
+		f.SetBackup(fixBackup)
+	}
+
 	// Handle automatic mode
 	if fixYes {
-		applyAllFixes(f, changes)
+		finishFixSession(applyAllFixes(f, changes))
 		if effectiveShowStats {
 			fmt.Print(perf.String())
 		}
@@ -260,21 +555,289 @@ func runFix(_ *cobra.Command, args []string) {
 	}
 
 	// Interactive mode
-	runInteractiveFix(f, changes)
+	if fixInteractive == fixModeLink {
+		finishFixSession(runLinkFix(f, changes))
+	} else {
+		finishFixSession(runInteractiveFix(f, changes))
+	}
 	if effectiveShowStats {
 		fmt.Print(perf.String())
 	}
 }
 
+// runUndo restores the files modified by the last fix session.
+func runUndo() {
+	dir, err := fixer.LastSession(fixer.DefaultBackupDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading backups: %v\n", err)
+		os.Exit(1)
+	}
+	if dir == "" {
+		fmt.Println("No fix session to undo.")
+		return
+	}
+
+	log, err := fixer.Undo(dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error undoing fix session %s: %v\n", dir, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Restored %d file(s) modified by the fix session of %s:\n",
+		len(log.Files), log.StartedAt.Local().Format(time.DateTime))
+	for _, file := range log.Files {
+		fmt.Printf("  %s (%d change(s))\n", file.Path, len(file.Changes))
+	}
+}
+
+// openFixRepo opens the git working tree containing path and checks that
+// fixes can be committed to it.
+func openFixRepo(path string) *gitrepo.Repo {
+	ctx := context.Background()
+	repo, err := gitrepo.Open(ctx, path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if fixGitBranch != "" {
+		if err := repo.CheckNewBranch(ctx, fixGitBranch); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if fixAllowDirty {
+		return repo
+	}
+	// Backups are never committed, so they don't make the tree dirty
+	var ignore []string
+	if backupDir, err := filepath.Abs(fixer.DefaultBackupDir); err == nil {
+		if rel, err := filepath.Rel(repo.Dir, backupDir); err == nil {
+			ignore = append(ignore, filepath.ToSlash(rel)+"/")
+		}
+	}
+	dirty, err := repo.Dirty(ctx, ignore...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error checking working tree: %v\n", err)
+		os.Exit(1)
+	}
+	if len(dirty) > 0 {
+		fmt.Fprintf(os.Stderr, "Error: the working tree has uncommitted changes in %d file(s):\n", len(dirty))
+		for _, p := range dirty {
+			fmt.Fprintf(os.Stderr, "  %s\n", p)
+		}
+		fmt.Fprintln(os.Stderr, "Commit or stash them first, or pass --allow-dirty.")
+		os.Exit(1)
+	}
+	return repo
+}
+
+// openForge finds where to open the pull request for --create-pr: the
+// forge hosting the origin remote, merging into the current branch.
+func openForge(repo *gitrepo.Repo) {
+	ctx := context.Background()
+	base, err := repo.CurrentBranch(ctx)
+	if err == nil && base == "" {
+		err = errors.New("HEAD is detached; check out the branch to open the pull request against")
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	remoteURL, err := repo.RemoteURL(ctx, fixPRRemote)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	remote, err := forge.ParseRemote(remoteURL)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fixForge, err = forge.New(remote, &http.Client{Timeout: 30 * time.Second}, os.Getenv)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fixBaseBranch = base
+}
+
+// finishFixSession reports where the originals were backed up and commits
+// the applied fixes if asked to.
+func finishFixSession(results []fixer.FixResult) {
+	printBackupNote()
+	if fixRepo != nil {
+		commitFixes(results)
+	}
+}
+
+// commitFixes commits the files the fixes were applied to, on a new branch
+// if one was requested.
+func commitFixes(results []fixer.FixResult) {
+	paths := fixer.ModifiedFiles(results)
+	if len(paths) == 0 {
+		fmt.Println("No files were modified; nothing to commit.")
+		return
+	}
+
+	ctx := context.Background()
+	if fixGitBranch != "" {
+		if err := fixRepo.CreateBranch(ctx, fixGitBranch); err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating branch: %v\nThe fixes were applied but not committed.\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Switched to a new branch %s.\n", fixGitBranch)
+	}
+
+	hash, err := fixRepo.Commit(ctx, paths, fixer.CommitMessage(results))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error committing fixes: %v\nThe fixes were applied but not committed.\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Committed fixes to %d file(s) as %s.\n", len(paths), hash)
+
+	if fixForge != nil {
+		openPullRequest(results)
+	}
+}
+
+// openPullRequest pushes the fix branch and opens a pull request for it,
+// with the detailed summary of the fixes as its description.
+func openPullRequest(results []fixer.FixResult) {
+	ctx := context.Background()
+	if err := fixRepo.Push(ctx, fixPRRemote, fixGitBranch); err != nil {
+		fmt.Fprintf(os.Stderr, "Error pushing %s: %v\n", fixGitBranch, err)
+		os.Exit(1)
+	}
+
+	title, _, _ := strings.Cut(fixer.CommitMessage(results), "\n")
+	pr := forge.PullRequest{
+		Title: title,
+		Body:  "Links updated by `gone fix`.\n\n```\n" + fixer.DetailedSummary(results) + "```\n",
+		Head:  fixGitBranch,
+		Base:  fixBaseBranch,
+	}
+	prURL, err := fixForge.CreatePullRequest(ctx, pr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\nThe fixes were pushed to %s.\n", err, fixGitBranch)
+		os.Exit(1)
+	}
+	fmt.Printf("Opened pull request: %s\n", prURL)
+}
+
+// printBackupNote tells where the originals of modified files were backed up.
+func printBackupNote() {
+	if fixBackup == nil {
+		return
+	}
+	if _, err := os.Stat(fixBackup.Dir()); err != nil {
+		return // Nothing was modified
+	}
+	fmt.Printf("Originals backed up to %s; run 'gone fix --undo' to restore them.\n", fixBackup.Dir())
+}
+
+// lookupSnapshots looks up the archived snapshots of the dead links,
+// writing its progress to w. Failed lookups are reported but don't stop the
+// run.
+func lookupSnapshots(w io.Writer, results []checker.Result, timeout time.Duration) map[string]string {
+	urls := fixer.DeadURLs(results)
+	if len(urls) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(w, "Looking up %d dead URL(s) on the Wayback Machine...\n", len(urls))
+	wayback := &fixer.Wayback{
+		Client: &http.Client{Timeout: timeout},
+	}
+	snapshots, err := wayback.Snapshots(context.Background(), urls)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: some snapshot lookups failed: %v\n", err)
+	}
+	fmt.Fprintf(w, "Found archived snapshots for %d of %d dead URL(s).\n", len(snapshots), len(urls))
+	return snapshots
+}
+
+// lookupTitles fetches the titles of the pages fixes point to, to assess
+// their risk. Failed lookups are reported but don't stop the fix.
+func lookupTitles(urls []string) map[string]string {
+	fmt.Printf("Fetching %d page title(s) to assess the fixes...\n", len(urls))
+	fetcher := &fixer.TitleFetcher{
+		Client: &http.Client{Timeout: time.Duration(fixTimeout) * time.Second},
+	}
+	titles, err := fetcher.Titles(context.Background(), urls)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: some page titles couldn't be fetched: %v\n", err)
+	}
+	return titles
+}
+
+// lookupUpgrades finds the http:// links that work over https://, writing
+// its progress to w. Failed checks are reported but don't stop the run.
+func lookupUpgrades(w io.Writer, results []checker.Result, timeout time.Duration) map[string]string {
+	urls := fixer.HTTPURLs(results)
+	if len(urls) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(w, "Checking %d http:// URL(s) over https://...\n", len(urls))
+	upgrader := &fixer.HTTPSUpgrader{
+		Client: &http.Client{Timeout: timeout},
+	}
+	upgrades, err := upgrader.Upgrades(context.Background(), urls)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: some https:// checks failed: %v\n", err)
+	}
+	fmt.Fprintf(w, "Found %d of %d http:// URL(s) that work over https://.\n", len(upgrades), len(urls))
+	return upgrades
+}
+
+// writePatchFile writes the fixes to path as a unified diff.
+func writePatchFile(f *fixer.Fixer, changes []fixer.FileChanges, path string) {
+	diff, err := f.Diff(changes)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating diff: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(path, []byte(diff), 0o600); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing patch: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Wrote patch to %s; no files were modified. Apply it with: git apply %s\n", path, path)
+}
+
+// writePlanFile writes the fixes to path as a JSON plan.
+func writePlanFile(changes []fixer.FileChanges, path string) {
+	if err := fixer.WritePlan(path, changes); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing plan: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Wrote plan to %s; no files were modified.\n", path)
+}
+
 // applyAllFixes applies all fixes without prompting.
-func applyAllFixes(f *fixer.Fixer, changes []fixer.FileChanges) {
-	results := f.ApplyAll(changes)
+// Changes are applied as one transaction: on error no file is modified.
+func applyAllFixes(f *fixer.Fixer, changes []fixer.FileChanges) []fixer.FixResult {
+	results, err := f.ApplyAtomic(changes)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\nNo files were modified.\n", err)
+		os.Exit(1)
+	}
 	fmt.Println(fixer.DetailedSummary(results))
+	return results
 }
 
 // runInteractiveFix prompts the user for each file before applying fixes.
-func runInteractiveFix(f *fixer.Fixer, changes []fixer.FileChanges) {
+// Archived snapshots are confirmed link by link first.
+func runInteractiveFix(f *fixer.Fixer, changes []fixer.FileChanges) []fixer.FixResult {
 	reader := bufio.NewReader(os.Stdin)
+	changes = confirmArchiveFixes(reader, changes)
+	if len(changes) == 0 {
+		fmt.Println("\nNo fixes left to apply.")
+		return nil
+	}
+
 	var allResults []fixer.FixResult
 	applyAll := false
 
@@ -337,6 +900,7 @@ func runInteractiveFix(f *fixer.Fixer, changes []fixer.FileChanges) {
 				})
 			}
 			printInteractiveResults(allResults)
+			finishFixSession(allResults)
 			os.Exit(2)
 
 		case "?", "help":
@@ -351,6 +915,47 @@ func runInteractiveFix(f *fixer.Fixer, changes []fixer.FileChanges) {
 
 	fmt.Println()
 	printInteractiveResults(allResults)
+	return allResults
+}
+
+// confirmArchiveFixes asks whether to apply each archived snapshot and
+// returns the changes without the rejected ones.
+func confirmArchiveFixes(reader *bufio.Reader, changes []fixer.FileChanges) []fixer.FileChanges {
+	// Fixes are keyed by file and old URL, which FindAllFixes keeps unique
+	rejected := map[[2]string]bool{}
+	for _, fc := range changes {
+		for _, fix := range fc.Fixes {
+			if !fix.Archive {
+				continue
+			}
+			if !promptArchiveFix(reader, fix) {
+				rejected[[2]string{fix.FilePath, fix.OldURL}] = true
+			}
+		}
+	}
+	if len(rejected) == 0 {
+		return changes
+	}
+	return fixer.FilterFixes(changes, func(fix fixer.Fix) bool {
+		return !rejected[[2]string{fix.FilePath, fix.OldURL}]
+	})
+}
+
+// promptArchiveFix asks whether to replace one dead link with its snapshot.
+func promptArchiveFix(reader *bufio.Reader, fix fixer.Fix) bool {
+	for {
+		fmt.Printf("\n%s:%d: %s is dead\n  Replace with archived snapshot %s? [y/n] ",
+			fix.FilePath, fix.Line, fix.OldURL, fix.NewURL)
+
+		switch strings.TrimSpace(strings.ToLower(readLine(reader))) {
+		case "y", "yes":
+			return true
+		case "n", "no":
+			return false
+		default:
+			fmt.Println("Invalid input. Use y or n")
+		}
+	}
 }
 
 // printInteractiveHelp displays help for interactive mode options.
@@ -401,7 +1006,7 @@ func printFixSummary(results []checker.Result) {
 		// Count how many redirects are not fixable (final status != 200)
 		notFixable := 0
 		for _, r := range results {
-			if r.Status == checker.StatusRedirect && r.FinalStatus != 200 {
+			if r.IsRedirect() && r.FinalStatus != 200 {
 				notFixable++
 			}
 		}
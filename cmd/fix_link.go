@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/leonardomso/gone/internal/fixer"
+	"github.com/leonardomso/gone/internal/term"
+)
+
+// Interactive fix modes (--interactive).
+const (
+	fixModeFile = "file"
+	fixModeLink = "link"
+)
+
+// linkDecision is the answer to a per-link fix prompt.
+type linkDecision int
+
+const (
+	linkAccept linkDecision = iota
+	linkReject
+	linkAcceptAll
+	linkQuit
+)
+
+// runLinkFix prompts for each fix, showing the affected line before and
+// after it, then applies the accepted fixes as one transaction.
+func runLinkFix(f *fixer.Fixer, changes []fixer.FileChanges) []fixer.FixResult {
+	reader := bufio.NewReader(os.Stdin)
+	printer := term.NewPrinter(os.Stdout)
+
+	total := 0
+	for _, fc := range changes {
+		total += len(fc.Fixes)
+	}
+
+	// Fixes are keyed by file and old URL, which FindAllFixes keeps unique
+	rejected := map[[2]string]bool{}
+	acceptAll, quit := false, false
+	n := 0
+	for i := range changes {
+		for j := range changes[i].Fixes {
+			fix := &changes[i].Fixes[j]
+			n++
+			if quit {
+				rejected[[2]string{fix.FilePath, fix.OldURL}] = true
+				continue
+			}
+			if acceptAll {
+				continue
+			}
+
+			switch promptLinkFix(reader, printer, fix, n, total) {
+			case linkReject:
+				rejected[[2]string{fix.FilePath, fix.OldURL}] = true
+			case linkAcceptAll:
+				acceptAll = true
+			case linkQuit:
+				rejected[[2]string{fix.FilePath, fix.OldURL}] = true
+				quit = true
+			case linkAccept:
+				// Nothing to do; fixes are applied unless rejected
+			}
+		}
+	}
+
+	accepted := fixer.FilterFixes(changes, func(fix fixer.Fix) bool {
+		return !rejected[[2]string{fix.FilePath, fix.OldURL}]
+	})
+
+	fmt.Println()
+	if len(rejected) > 0 {
+		fmt.Printf("Skipped %d fix(es).\n", len(rejected))
+	}
+	var results []fixer.FixResult
+	if len(accepted) > 0 {
+		results = applyAllFixes(f, accepted)
+	}
+	if quit {
+		finishFixSession(results)
+		os.Exit(2)
+	}
+	return results
+}
+
+// promptLinkFix asks what to do with one fix. Editing replaces the fix's
+// new URL and asks again, so the edited line can be reviewed.
+func promptLinkFix(reader *bufio.Reader, printer *term.Printer, fix *fixer.Fix, n, total int) linkDecision {
+	for {
+		fmt.Printf("\n[%d/%d] %s:%d%s\n", n, total, fix.FilePath, fix.Line, fix.Tag())
+		before, after, err := fixer.LineDiff(*fix)
+		if err != nil {
+			// The line can't be shown; fall back to the URLs
+			before, after = fix.OldURL, fix.NewURL
+		}
+		printer.Diff(before, after)
+		if fix.Occurrences > 1 {
+			fmt.Printf("  (%d occurrence(s) in this file)\n", fix.Occurrences)
+		}
+		for _, w := range fix.Warnings {
+			fmt.Printf("  warning: %s\n", w)
+		}
+		fmt.Printf("  %s\n", fix.RiskSummary())
+
+		fmt.Print("Apply this fix? [y/n/e/a/q/?] ")
+		switch strings.TrimSpace(strings.ToLower(readLine(reader))) {
+		case "y", "yes":
+			return linkAccept
+		case "n", "no":
+			return linkReject
+		case "e", "edit":
+			if newURL, ok := promptReplacementURL(reader); ok {
+				fix.NewURL = newURL
+				fix.Rewrite, fix.Archive, fix.Upgrade = false, false, false
+			}
+		case "a", "all":
+			return linkAcceptAll
+		case "q", "quit":
+			fmt.Println("\nQuitting. Remaining fixes were skipped.")
+			return linkQuit
+		case "?", "help":
+			printLinkFixHelp()
+		default:
+			fmt.Println("Invalid input. Use y/n/e/a/q/? (or type 'help')")
+		}
+	}
+}
+
+// promptReplacementURL reads a replacement URL typed by the user.
+// An empty answer cancels the edit.
+func promptReplacementURL(reader *bufio.Reader) (string, bool) {
+	for {
+		fmt.Print("Replacement URL (empty to cancel): ")
+		input := strings.TrimSpace(readLine(reader))
+		if input == "" {
+			return "", false
+		}
+		if u, err := url.Parse(input); err != nil || u.Scheme == "" || u.Host == "" ||
+			strings.ContainsAny(input, " \t") {
+			fmt.Println("Invalid URL. Enter an absolute URL such as https://example.com/page")
+			continue
+		}
+		return input, true
+	}
+}
+
+// readLine reads a line of input, exiting if stdin can't be read.
+func readLine(reader *bufio.Reader) string {
+	input, err := reader.ReadString('\n')
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\nError reading input: %v\n", err)
+		os.Exit(1)
+	}
+	return input
+}
+
+// printLinkFixHelp displays help for per-link interactive mode options.
+func printLinkFixHelp() {
+	fmt.Println(`
+Per-link interactive mode options:
+  y, yes  - Apply this fix
+  n, no   - Skip this fix
+  e, edit - Type a different replacement URL
+  a, all  - Apply this fix and all remaining fixes
+  q, quit - Apply the fixes accepted so far and skip the rest
+  ?, help - Show this help`)
+}
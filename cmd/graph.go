@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/leonardomso/gone/internal/graph"
+	"github.com/leonardomso/gone/internal/parser"
+	"github.com/leonardomso/gone/internal/parser/markdown"
+	"github.com/leonardomso/gone/internal/scanner"
+
+	"github.com/spf13/cobra"
+)
+
+// Graph command flag variables.
+var (
+	graphFormat    string
+	graphOutput    string
+	graphFileTypes []string
+	graphOrphans   bool
+	graphNoConfig  bool
+)
+
+// graphCmd exports the repository's link graph.
+var graphCmd = &cobra.Command{
+	Use:   "graph [path]",
+	Short: "Export the link graph of files and URLs",
+	Long: `Export a graph of which files link to which URLs, and which markdown
+files link to each other through relative links ("./setup.md").
+
+No URLs are checked. The graph can be rendered with Graphviz (dot format)
+or processed by other tools (json format). Files that no other scanned
+file links to are marked as orphans: dashed in dot, "orphan": true in json.
+Entry points such as the top-level README are naturally orphans.
+
+Examples:
+  gone graph                          # DOT graph of the current directory
+  gone graph ./docs --format=json     # JSON graph of a specific directory
+  gone graph -o links.dot && dot -Tsvg links.dot > links.svg
+  gone graph --orphans                # List pages nothing links to`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runGraph,
+}
+
+func init() {
+	rootCmd.AddCommand(graphCmd)
+
+	graphCmd.Flags().StringVarP(&graphFormat, "format", "f", "dot",
+		"Graph format: dot, json")
+	graphCmd.Flags().StringVarP(&graphOutput, "output", "o", "",
+		"Write the graph to a file instead of stdout")
+	graphCmd.Flags().StringSliceVarP(&graphFileTypes, "types", "T", []string{"md"},
+		"File types to scan: md, json, yaml, toml, xml, html, ini, env, properties, docker, code, txt")
+	graphCmd.Flags().BoolVar(&graphOrphans, "orphans", false,
+		"Only list scanned files that no other file links to")
+	graphCmd.Flags().BoolVar(&graphNoConfig, "no-config", false,
+		"Skip loading .gonerc.yaml config file")
+}
+
+// runGraph is the entry point for the graph command.
+func runGraph(_ *cobra.Command, args []string) {
+	if graphFormat != "dot" && graphFormat != "json" {
+		exitOnError(fmt.Errorf("invalid format %q; valid formats: dot, json", graphFormat), "")
+	}
+
+	loadedCfg, err := LoadConfig(graphNoConfig)
+	exitOnError(err, "Config error")
+
+	path := getPathArg(args)
+	exitOnError(validateFileTypes(loadedCfg.GetTypes(graphFileTypes, []string{"md"})), "Invalid file types")
+
+	files, err := scanner.FindFilesWithOptions(loadedCfg.BuildScanOptions(path, graphFileTypes, []string{"md"}))
+	exitOnError(err, "Error scanning directory")
+
+	links, _, err := parser.ExtractLinksWithBudget(files, false, loadedCfg.BuildParseBudget(0))
+	exitOnError(err, "Error parsing files")
+
+	g := graph.Build(path, files, links, markdownLinks(files, markdown.ExtractRelativeLinks))
+
+	if graphOrphans {
+		for _, f := range g.Orphans() {
+			fmt.Println(f)
+		}
+		return
+	}
+
+	var data []byte
+	if graphFormat == "json" {
+		data, err = g.JSON()
+		exitOnError(err, "Error formatting graph")
+	} else {
+		data = g.DOT()
+	}
+
+	if graphOutput == "" {
+		fmt.Print(string(data))
+		return
+	}
+	exitOnError(os.WriteFile(graphOutput, data, 0o600), "Error writing graph")
+	fmt.Printf("Wrote graph of %d node(s) and %d edge(s) to %s\n", len(g.Nodes), len(g.Edges), graphOutput)
+}
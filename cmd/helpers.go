@@ -2,20 +2,35 @@ package cmd
 
 import (
 	"fmt"
+	"log/slog"
+	"maps"
+	"os"
 	"slices"
+	"strings"
 	"time"
 
+	"github.com/leonardomso/gone/internal/cache"
 	"github.com/leonardomso/gone/internal/checker"
 	"github.com/leonardomso/gone/internal/config"
 	"github.com/leonardomso/gone/internal/filter"
+	"github.com/leonardomso/gone/internal/fixer"
+	"github.com/leonardomso/gone/internal/lint"
+	"github.com/leonardomso/gone/internal/output"
 	"github.com/leonardomso/gone/internal/parser"
+	"github.com/leonardomso/gone/internal/relative"
 	"github.com/leonardomso/gone/internal/scanner"
+	"github.com/leonardomso/gone/internal/term"
 
 	// Import parser subpackages to trigger their init() registration.
+	_ "github.com/leonardomso/gone/internal/parser/code"
+	_ "github.com/leonardomso/gone/internal/parser/docker"
+	_ "github.com/leonardomso/gone/internal/parser/html"
+	_ "github.com/leonardomso/gone/internal/parser/ini"
 	_ "github.com/leonardomso/gone/internal/parser/json"
 	_ "github.com/leonardomso/gone/internal/parser/markdown"
+	_ "github.com/leonardomso/gone/internal/parser/text"
 	_ "github.com/leonardomso/gone/internal/parser/toml"
-	_ "github.com/leonardomso/gone/internal/parser/xml"
+	xmlparser "github.com/leonardomso/gone/internal/parser/xml"
 	_ "github.com/leonardomso/gone/internal/parser/yaml"
 )
 
@@ -42,9 +57,31 @@ func LoadConfig(noConfig bool) (*LoadedConfig, error) {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 
+	if len(cfg.Scan.XMLAttributes) > 0 {
+		parser.RegisterParser(xmlparser.New().WithAttributes(cfg.Scan.XMLAttributes))
+	}
+	registerExternalParsers(cfg.Parsers)
+
 	return &LoadedConfig{cfg: cfg, noConfig: false}, nil
 }
 
+// registerExternalParsers registers the external parsers of the config file
+// with --allow-external-parsers, and warns that they don't run otherwise.
+func registerExternalParsers(parsers map[string]string) {
+	if len(parsers) == 0 {
+		return
+	}
+	if !allowExternalParsers {
+		slog.Warn("not running the external parsers of the config file; "+
+			"pass --allow-external-parsers if you trust its commands",
+			"extensions", slices.Sorted(maps.Keys(parsers)))
+		return
+	}
+	for ext, command := range parsers {
+		parser.RegisterExternal(ext, command)
+	}
+}
+
 // Config returns the underlying config for direct access.
 func (lc *LoadedConfig) Config() *config.Config {
 	return lc.cfg
@@ -70,6 +107,11 @@ func (lc *LoadedConfig) GetScanOptions() (include, exclude []string) {
 	return lc.cfg.Scan.Include, lc.cfg.Scan.Exclude
 }
 
+// GetPriorityPaths returns the priority path patterns from config and CLI combined.
+func (lc *LoadedConfig) GetPriorityPaths(cliValues []string) []string {
+	return append(slices.Clone(lc.cfg.Scan.PriorityPaths), cliValues...)
+}
+
 // GetConcurrency returns the effective concurrency.
 // CLI overrides config if it differs from the default.
 func (lc *LoadedConfig) GetConcurrency(cliValue, defaultValue int) int {
@@ -106,6 +148,38 @@ func (lc *LoadedConfig) GetRetries(cliValue, defaultValue int) int {
 	return defaultValue
 }
 
+// GetMaxRedirects returns the effective redirect limit.
+// CLI overrides config if it differs from the default.
+func (lc *LoadedConfig) GetMaxRedirects(cliValue, defaultValue int) int {
+	if cliValue != defaultValue {
+		return cliValue // CLI explicitly set
+	}
+	if lc.cfg.Check.MaxRedirects > 0 {
+		return lc.cfg.Check.MaxRedirects
+	}
+	return defaultValue
+}
+
+// GetRedirectWarnHops returns the effective long-redirect threshold.
+// CLI overrides config if set (non-zero).
+func (lc *LoadedConfig) GetRedirectWarnHops(cliValue int) int {
+	if cliValue > 0 {
+		return cliValue // CLI explicitly set
+	}
+	return lc.cfg.Check.RedirectWarnHops
+}
+
+// GetRedirectPolicy returns the effective redirect policy.
+// CLI overrides config if set. Both were validated, so they always parse.
+func (lc *LoadedConfig) GetRedirectPolicy(cliValue string) checker.RedirectPolicy {
+	name := lc.cfg.Check.RedirectPolicy
+	if cliValue != "" {
+		name = cliValue
+	}
+	policy, _ := checker.ParseRedirectPolicy(name)
+	return policy
+}
+
 // GetStrict returns the effective strict mode setting.
 // CLI true overrides config.
 func (lc *LoadedConfig) GetStrict(cliValue bool) bool {
@@ -115,6 +189,242 @@ func (lc *LoadedConfig) GetStrict(cliValue bool) bool {
 	return lc.cfg.Check.Strict
 }
 
+// GetProbeHosts returns the effective pre-flight probe setting.
+// CLI true overrides config.
+func (lc *LoadedConfig) GetProbeHosts(cliValue bool) bool {
+	if cliValue {
+		return true
+	}
+	return lc.cfg.Check.ProbeHosts
+}
+
+// GetInspectPages returns the effective page inspection setting.
+// CLI true overrides config.
+func (lc *LoadedConfig) GetInspectPages(cliValue bool) bool {
+	if cliValue {
+		return true
+	}
+	return lc.cfg.Check.InspectPages
+}
+
+// GetCheckAnchors returns the effective anchor verification setting.
+// CLI true overrides config.
+func (lc *LoadedConfig) GetCheckAnchors(cliValue bool) bool {
+	if cliValue {
+		return true
+	}
+	return lc.cfg.Check.CheckAnchors
+}
+
+// GetInsecure returns the effective TLS verification skipping setting.
+// CLI true overrides config.
+func (lc *LoadedConfig) GetInsecure(cliValue bool) bool {
+	if cliValue {
+		return true
+	}
+	return lc.cfg.Check.Insecure
+}
+
+// GetDetectSoft404 returns the effective soft 404 detection setting.
+// CLI true overrides config.
+func (lc *LoadedConfig) GetDetectSoft404(cliValue bool) bool {
+	if cliValue {
+		return true
+	}
+	return lc.cfg.Check.DetectSoft404
+}
+
+// GetCheckInternal returns the effective relative link checking setting.
+// CLI true overrides config.
+func (lc *LoadedConfig) GetCheckInternal(cliValue bool) bool {
+	if cliValue {
+		return true
+	}
+	return lc.cfg.Check.CheckInternal
+}
+
+// GetLint returns the effective lint setting.
+// CLI true overrides config.
+func (lc *LoadedConfig) GetLint(cliValue bool) bool {
+	if cliValue {
+		return true
+	}
+	return lc.cfg.Check.Lint
+}
+
+// GetCache returns whether the persistent result cache is enabled.
+// CLI true overrides config.
+func (lc *LoadedConfig) GetCache(cliValue bool) bool {
+	if cliValue {
+		return true
+	}
+	return lc.cfg.Cache.Enabled
+}
+
+// CachePath returns the configured cache file location, or the default one.
+func (lc *LoadedConfig) CachePath() (string, error) {
+	if lc.cfg.Cache.Path != "" {
+		return lc.cfg.Cache.Path, nil
+	}
+	return cache.DefaultPath()
+}
+
+// OpenCache opens the persistent result cache at the configured path and TTL.
+func (lc *LoadedConfig) OpenCache() (*cache.Cache, error) {
+	path, err := lc.CachePath()
+	if err != nil {
+		return nil, err
+	}
+	return cache.Open(path, lc.cacheTTL())
+}
+
+// cacheTTL returns the configured cache TTL, or zero for the default.
+// Config was validated on load, so the TTL always parses.
+func (lc *LoadedConfig) cacheTTL() time.Duration {
+	var ttl time.Duration
+	if lc.cfg.Cache.TTL != "" {
+		ttl, _ = time.ParseDuration(lc.cfg.Cache.TTL)
+	}
+	return ttl
+}
+
+// OpenSession resumes the interrupted run over root, or starts journaling a new
+// one. The journal lives next to the cache file, and is only resumed by a run
+// checking with the same opts within the cache TTL. opts.Cache, if set,
+// answers for URLs the journal doesn't have.
+func (lc *LoadedConfig) OpenSession(root string, links []checker.Link, opts checker.Options) (*cache.Session, error) {
+	cachePath, err := lc.CachePath()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(links))
+	urls := make([]string, 0, len(links))
+	for _, l := range links {
+		if !seen[l.URL] {
+			seen[l.URL] = true
+			urls = append(urls, l.URL)
+		}
+	}
+	return cache.OpenSession(cache.SessionPath(cachePath, root), urls, opts.Fingerprint(), lc.cacheTTL(), opts.Cache)
+}
+
+// GetHTTP3 returns the effective HTTP/3 setting.
+// CLI true overrides config.
+func (lc *LoadedConfig) GetHTTP3(cliValue bool) bool {
+	if cliValue {
+		return true
+	}
+	return lc.cfg.Check.HTTP3
+}
+
+// GetEnableCookies returns the effective cookie jar setting.
+// CLI true overrides config.
+func (lc *LoadedConfig) GetEnableCookies(cliValue bool) bool {
+	if cliValue {
+		return true
+	}
+	return lc.cfg.Check.EnableCookies
+}
+
+// GetIPFamily returns the effective IP family.
+// CLI overrides config if set.
+func (lc *LoadedConfig) GetIPFamily(cliValue string) string {
+	if cliValue != "" {
+		return cliValue
+	}
+	return lc.cfg.Check.IPFamily
+}
+
+// GetDNSServer returns the effective DNS server.
+// CLI overrides config if set.
+func (lc *LoadedConfig) GetDNSServer(cliValue string) string {
+	if cliValue != "" {
+		return cliValue
+	}
+	return lc.cfg.Check.DNSServer
+}
+
+// GetMaxDuration returns the effective time budget of a run.
+// CLI overrides config if set (non-zero). Config was validated on load.
+func (lc *LoadedConfig) GetMaxDuration(cliValue time.Duration) time.Duration {
+	if cliValue > 0 {
+		return cliValue
+	}
+	d, _ := time.ParseDuration(lc.cfg.Check.MaxDuration)
+	return d
+}
+
+// GetRecheckInterval returns how often watch and serve recheck all their
+// links, or 0 if they don't. Config was validated on load.
+func (lc *LoadedConfig) GetRecheckInterval() time.Duration {
+	if lc.cfg.Check.RecheckInterval == "" {
+		return 0
+	}
+	d, _ := config.ParseInterval(lc.cfg.Check.RecheckInterval)
+	return d
+}
+
+// GetRecheckWebhook returns the URL rechecks post status changes to, with
+// environment variables expanded, or "" if there is none.
+func (lc *LoadedConfig) GetRecheckWebhook() (string, error) {
+	url := os.ExpandEnv(lc.cfg.Check.RecheckWebhook)
+	if url != "" && !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return "", fmt.Errorf("check.recheck_webhook must be an http:// or https:// URL, got %q", url)
+	}
+	return url, nil
+}
+
+// GetMaxRequests returns the effective request budget of a run.
+// CLI overrides config if set (non-zero).
+func (lc *LoadedConfig) GetMaxRequests(cliValue int) int {
+	if cliValue > 0 {
+		return cliValue
+	}
+	return lc.cfg.Check.MaxRequests
+}
+
+// GetLoginPatterns returns the built-in login page patterns plus those from
+// config and CLI.
+func (lc *LoadedConfig) GetLoginPatterns(cliValues []string) []string {
+	patterns := slices.Clone(checker.DefaultLoginPatterns)
+	patterns = append(patterns, lc.cfg.Check.LoginPatterns...)
+	return append(patterns, cliValues...)
+}
+
+// GetStaleAfter returns the effective stale threshold (0 when disabled).
+// CLI overrides config if set. Config was validated on load, so it always parses.
+func (lc *LoadedConfig) GetStaleAfter(cliValue string) (time.Duration, error) {
+	if cliValue != "" {
+		d, err := checker.ParseAge(cliValue)
+		if err != nil {
+			return 0, err
+		}
+		if d <= 0 {
+			return 0, fmt.Errorf("stale threshold must be > 0, got %s", cliValue)
+		}
+		return d, nil
+	}
+	if lc.cfg.Check.StaleAfter == "" {
+		return 0, nil
+	}
+	d, _ := checker.ParseAge(lc.cfg.Check.StaleAfter)
+	return d, nil
+}
+
+// GetUAProfile returns the effective header profile.
+// CLI overrides config if set; it may name a built-in or a config profile.
+func (lc *LoadedConfig) GetUAProfile(cliValue string) (string, error) {
+	if cliValue == "" {
+		return lc.cfg.Check.UAProfile, nil
+	}
+	if !checker.ValidProfile(cliValue, lc.cfg.Check.UAProfiles) {
+		names := append(checker.Profiles(), slices.Sorted(maps.Keys(lc.cfg.Check.UAProfiles))...)
+		return "", fmt.Errorf("unknown profile %q; valid profiles: %s", cliValue, strings.Join(names, ", "))
+	}
+	return cliValue, nil
+}
+
 // GetOutputFormat returns the effective output format.
 // CLI overrides config if set.
 func (lc *LoadedConfig) GetOutputFormat(cliValue string) string {
@@ -124,6 +434,96 @@ func (lc *LoadedConfig) GetOutputFormat(cliValue string) string {
 	return lc.cfg.Output.Format
 }
 
+// GetMetricsFile returns the effective metrics file path.
+// CLI overrides config if set.
+func (lc *LoadedConfig) GetMetricsFile(cliValue string) string {
+	if cliValue != "" {
+		return cliValue
+	}
+	return lc.cfg.Output.MetricsFile
+}
+
+// GetTemplate returns the effective template file for the template format.
+// CLI overrides config if set.
+func (lc *LoadedConfig) GetTemplate(cliValue string) string {
+	if cliValue != "" {
+		return cliValue
+	}
+	return lc.cfg.Output.Template
+}
+
+// GetTheme returns the effective color theme.
+// CLI overrides config if set.
+func (lc *LoadedConfig) GetTheme(cliValue string) string {
+	if cliValue != "" {
+		return cliValue
+	}
+	return lc.cfg.Output.Theme
+}
+
+// GetGroupBy returns the effective result grouping.
+// CLI overrides config if set.
+func (lc *LoadedConfig) GetGroupBy(cliValue string) string {
+	if cliValue != "" {
+		return cliValue
+	}
+	return lc.cfg.Output.GroupBy
+}
+
+// GetSort returns the effective result order.
+// CLI overrides config if set.
+func (lc *LoadedConfig) GetSort(cliValue string) string {
+	if cliValue != "" {
+		return cliValue
+	}
+	return lc.cfg.Output.Sort
+}
+
+// GetTop returns the effective number of results to list, 0 for all.
+// CLI overrides config if set.
+func (lc *LoadedConfig) GetTop(cliValue int) int {
+	if cliValue > 0 {
+		return cliValue
+	}
+	return lc.cfg.Output.Top
+}
+
+// GetFailOn returns the effective --fail-on value, empty for the default.
+// CLI overrides config if set.
+func (lc *LoadedConfig) GetFailOn(cliValue string) string {
+	if cliValue != "" {
+		return cliValue
+	}
+	return lc.cfg.Check.FailOn
+}
+
+// GetReportAll returns the effective --report-all setting.
+// CLI true overrides config.
+func (lc *LoadedConfig) GetReportAll(cliValue bool) bool {
+	if cliValue {
+		return true
+	}
+	return lc.cfg.Output.ReportAll
+}
+
+// GetJUnitSuitePer returns the effective JUnit suite grouping.
+// CLI overrides config if set.
+func (lc *LoadedConfig) GetJUnitSuitePer(cliValue string) string {
+	if cliValue != "" {
+		return cliValue
+	}
+	return lc.cfg.Output.JUnitSuitePer
+}
+
+// GetJUnitIncludePassed returns the effective --junit-include-passed setting.
+// CLI true overrides config.
+func (lc *LoadedConfig) GetJUnitIncludePassed(cliValue bool) bool {
+	if cliValue {
+		return true
+	}
+	return lc.cfg.Output.JUnitIncludePassed
+}
+
 // GetShowAlive returns the effective showAlive setting.
 // CLI true overrides config.
 func (lc *LoadedConfig) GetShowAlive(cliValue bool) bool {
@@ -163,22 +563,321 @@ func (lc *LoadedConfig) GetShowStats(cliValue bool) bool {
 // BuildCheckerOptions creates checker.Options from config and CLI values.
 func (lc *LoadedConfig) BuildCheckerOptions(cliConcurrency, cliTimeout, cliRetries int) checker.Options {
 	defaultOpts := checker.DefaultOptions()
+	staleAge, _ := lc.GetStaleAfter("") // Config was validated on load
 
 	return defaultOpts.
+		WithLogger(slog.Default()).
 		WithConcurrency(lc.GetConcurrency(cliConcurrency, checker.DefaultConcurrency)).
-		WithTimeout(time.Duration(lc.GetTimeout(cliTimeout, int(checker.DefaultTimeout.Seconds()))) * time.Second).
-		WithMaxRetries(lc.GetRetries(cliRetries, checker.DefaultMaxRetries))
+		WithTimeout(time.Duration(lc.GetTimeout(cliTimeout, int(checker.DefaultTimeout.Seconds())))*time.Second).
+		WithMaxRetries(lc.GetRetries(cliRetries, checker.DefaultMaxRetries)).
+		WithMaxRedirects(lc.cfg.Check.MaxRedirects).
+		WithDomainMaxRedirects(lc.cfg.Check.DomainMaxRedirects).
+		WithHeaders(expandHeaders(lc.cfg.Check.Headers)).
+		WithStatusOverrides(lc.StatusOverrides()).
+		WithContentRules(lc.ContentRules()...).
+		WithDomainHeaders(expandDomainHeaders(lc.cfg.Check.DomainHeaders)).
+		WithProfile(lc.cfg.Check.UAProfile).
+		WithCustomProfiles(expandDomainHeaders(lc.cfg.Check.UAProfiles)).
+		WithMethodPolicy(checker.MethodGet, lc.cfg.Check.ForceGetDomains...).
+		WithMethodPolicy(checker.MethodHead, lc.cfg.Check.HeadOnlyDomains...).
+		WithoutResolvers(lc.cfg.Check.DisableResolvers...).
+		WithRedirectWarnHops(lc.cfg.Check.RedirectWarnHops).
+		WithRedirectPolicy(lc.GetRedirectPolicy("")).
+		WithHostProbe(lc.cfg.Check.ProbeHosts).
+		WithFetchBodies(lc.cfg.Check.InspectPages).
+		WithCheckAnchors(lc.cfg.Check.CheckAnchors).
+		WithDetectSoft404(lc.cfg.Check.DetectSoft404).
+		WithInsecure(lc.cfg.Check.Insecure).
+		WithHTTP3(lc.cfg.Check.HTTP3).
+		WithEnableCookies(lc.cfg.Check.EnableCookies).
+		WithIPFamily(lc.cfg.Check.IPFamily).
+		WithDNSServer(lc.cfg.Check.DNSServer).
+		WithMaxDuration(lc.GetMaxDuration(0)).
+		WithMaxRequests(lc.cfg.Check.MaxRequests).
+		WithStaleAfter(staleAge).
+		WithLoginPatterns(lc.GetLoginPatterns(nil))
+}
+
+// StatusOverrides returns the status code classification overrides from config.
+func (lc *LoadedConfig) StatusOverrides() map[int]checker.LinkStatus {
+	codes := lc.cfg.Check.StatusCodes
+	if codes.IsEmpty() {
+		return nil
+	}
+	overrides := map[int]checker.LinkStatus{}
+	for _, code := range codes.Alive {
+		overrides[code] = checker.StatusAlive
+	}
+	for _, code := range codes.Dead {
+		overrides[code] = checker.StatusDead
+	}
+	for _, code := range codes.Blocked {
+		overrides[code] = checker.StatusBlocked
+	}
+	return overrides
+}
+
+// ContentRules returns the content assertions from config. Rules were
+// validated when the config was loaded.
+func (lc *LoadedConfig) ContentRules() []checker.ContentRule {
+	rules := make([]checker.ContentRule, 0, len(lc.cfg.Check.ContentRules))
+	for _, rc := range lc.cfg.Check.ContentRules {
+		if rule, err := checker.NewContentRule(rc.Pattern, rc.MustContain, rc.MustMatch); err == nil {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// expandHeaders returns headers with environment variables in their values
+// expanded, so tokens can stay out of the config file.
+func expandHeaders(headers map[string]string) map[string]string {
+	if len(headers) == 0 {
+		return nil
+	}
+	expanded := make(map[string]string, len(headers))
+	for name, value := range headers {
+		expanded[name] = os.ExpandEnv(value)
+	}
+	return expanded
+}
+
+// expandDomainHeaders applies expandHeaders to each domain's headers.
+func expandDomainHeaders(headers map[string]map[string]string) map[string]map[string]string {
+	if len(headers) == 0 {
+		return nil
+	}
+	expanded := make(map[string]map[string]string, len(headers))
+	for domain, h := range headers {
+		expanded[domain] = expandHeaders(h)
+	}
+	return expanded
 }
 
 // BuildScanOptions creates scanner.ScanOptions from config and path.
 func (lc *LoadedConfig) BuildScanOptions(path string, cliTypes, cliDefaultTypes []string) scanner.ScanOptions {
 	include, exclude := lc.GetScanOptions()
+	types := parser.ExpandFileTypes(lc.GetTypes(cliTypes, cliDefaultTypes))
 	return scanner.ScanOptions{
-		Root:    path,
-		Types:   lc.GetTypes(cliTypes, cliDefaultTypes),
-		Include: include,
-		Exclude: exclude,
+		Root:      path,
+		Types:     types,
+		Filenames: parser.FilenamesForFileTypes(types),
+		Include:   include,
+		Exclude:   exclude,
+	}
+}
+
+// BuildParseBudget creates the per-file parse budget from config and CLI values.
+// CLI timeout overrides config if set (non-zero). Config was validated on load.
+func (lc *LoadedConfig) BuildParseBudget(cliTimeout time.Duration) parser.Budget {
+	budget := parser.Budget{MaxBytes: lc.cfg.Scan.MaxFileSize}
+	if cliTimeout > 0 {
+		budget.Timeout = cliTimeout
+	} else if lc.cfg.Scan.ParseTimeout != "" {
+		budget.Timeout, _ = time.ParseDuration(lc.cfg.Scan.ParseTimeout)
+	}
+	return budget
+}
+
+// ConvertSkippedFiles converts files skipped by the parse budget for reporting.
+func ConvertSkippedFiles(skipped []parser.SkippedFile) []output.SkippedFile {
+	if len(skipped) == 0 {
+		return nil
+	}
+	converted := make([]output.SkippedFile, len(skipped))
+	for i, sk := range skipped {
+		converted[i] = output.SkippedFile{File: sk.FilePath, Reason: sk.Reason}
 	}
+	return converted
+}
+
+// ConvertLintFindings converts lint findings for reporting.
+func ConvertLintFindings(findings []lint.Finding) []output.LintFinding {
+	if len(findings) == 0 {
+		return nil
+	}
+	converted := make([]output.LintFinding, len(findings))
+	for i, f := range findings {
+		converted[i] = output.LintFinding{
+			URL:     f.Link.URL,
+			File:    f.Link.FilePath,
+			Line:    f.Link.Line,
+			Rule:    f.Rule,
+			Message: f.Message,
+		}
+	}
+	return converted
+}
+
+// printLintFindings prints lint findings as a text section.
+func printLintFindings(findings []lint.Finding) {
+	if len(findings) == 0 {
+		return
+	}
+	entries := make([]term.Entry, len(findings))
+	for i, f := range findings {
+		entries[i] = term.Entry{
+			Tag: "[" + f.Rule + "]", Kind: term.KindWarning, URL: f.Link.URL,
+			Fields: []term.Field{fileField(f.Link), {Label: "Note", Value: f.Message}},
+		}
+	}
+	console.Heading(fmt.Sprintf("Lint (%d)", len(findings)))
+	console.Entries(entries)
+}
+
+// ConvertInternalFindings converts broken relative links for reporting.
+func ConvertInternalFindings(findings []relative.Finding) []output.InternalLink {
+	if len(findings) == 0 {
+		return nil
+	}
+	converted := make([]output.InternalLink, len(findings))
+	for i, f := range findings {
+		converted[i] = output.InternalLink{
+			URL:     f.Link.URL,
+			File:    f.Link.FilePath,
+			Line:    f.Link.Line,
+			Problem: f.Problem,
+			Message: f.Message,
+		}
+	}
+	return converted
+}
+
+// printInternalFindings prints broken relative links as a text section.
+func printInternalFindings(findings []relative.Finding) {
+	if len(findings) == 0 {
+		return
+	}
+	entries := make([]term.Entry, len(findings))
+	for i, f := range findings {
+		entries[i] = term.Entry{
+			Tag: "[" + f.Problem + "]", Kind: term.KindDead, URL: f.Link.URL,
+			Fields: []term.Field{term.FileField("File", f.Link.FilePath, f.Link.Line), {Label: "Note", Value: f.Message}},
+		}
+	}
+	console.Heading(fmt.Sprintf("Broken Internal Links (%d)", len(findings)))
+	console.Entries(entries)
+}
+
+// markdownLinks collects the links extract finds in the scanned markdown files.
+// Files that can't be read were already reported while parsing, so they are skipped.
+func markdownLinks(files []string, extract func(content []byte, filePath string) []parser.Link) []parser.Link {
+	var links []parser.Link
+	for _, f := range files {
+		if parser.FileTypeForFile(f) != "md" {
+			continue
+		}
+		content, err := os.ReadFile(f) //nolint:gosec // Path comes from the scanner
+		if err != nil {
+			continue
+		}
+		links = append(links, extract(content, f)...)
+	}
+	return links
+}
+
+// CreateDenyFilter builds a filter from the deny rules in config.
+// Returns nil if no deny rules are defined.
+func CreateDenyFilter(cfg *config.Config) (*filter.Filter, error) {
+	if !cfg.HasDenyRules() {
+		return nil, nil
+	}
+	return filter.New(filter.Config{
+		Domains:       cfg.Deny.Domains,
+		GlobPatterns:  cfg.Deny.Patterns,
+		RegexPatterns: cfg.Deny.Regex,
+	})
+}
+
+// FindPolicyViolations returns every link matching a deny rule.
+// Links are matched before ignore rules apply, so ignoring a URL can't hide a violation.
+func FindPolicyViolations(parserLinks []parser.Link, denyFilter *filter.Filter) []filter.IgnoreReason {
+	if denyFilter == nil {
+		return nil
+	}
+	var violations []filter.IgnoreReason
+	for _, pl := range parserLinks {
+		if ruleType, rule, ok := denyFilter.Match(pl.URL); ok {
+			slog.Info("link matches deny rule", "url", pl.URL, "file", pl.FilePath, "line", pl.Line,
+				"rule_type", ruleType, "rule", rule)
+			violations = append(violations, filter.IgnoreReason{
+				Type: ruleType,
+				Rule: rule,
+				URL:  pl.URL,
+				File: pl.FilePath,
+				Line: pl.Line,
+			})
+		}
+	}
+	return violations
+}
+
+// ConvertPolicyViolations converts policy violations for reporting.
+func ConvertPolicyViolations(violations []filter.IgnoreReason) []output.PolicyViolation {
+	if len(violations) == 0 {
+		return nil
+	}
+	converted := make([]output.PolicyViolation, len(violations))
+	for i, v := range violations {
+		converted[i] = output.PolicyViolation{
+			URL:    v.URL,
+			File:   v.File,
+			Line:   v.Line,
+			Reason: v.Type,
+			Rule:   v.Rule,
+		}
+	}
+	return converted
+}
+
+// printPolicyViolations prints links matching deny rules as a text section.
+func printPolicyViolations(violations []filter.IgnoreReason) {
+	if len(violations) == 0 {
+		return
+	}
+	entries := make([]term.Entry, len(violations))
+	for i, v := range violations {
+		entries[i] = term.Entry{
+			Tag: "[" + v.Type + "]", Kind: term.KindDead, URL: v.URL,
+			Fields: []term.Field{term.FileField("File", v.File, v.Line), {Label: "Rule", Value: v.Rule}},
+		}
+	}
+	console.Heading(fmt.Sprintf("Policy Violations (%d)", len(violations)))
+	console.Entries(entries)
+}
+
+// printSkippedFiles lists files skipped for exceeding the parse budget.
+func printSkippedFiles(skipped []parser.SkippedFile) {
+	if len(skipped) == 0 {
+		return
+	}
+	fmt.Printf("Skipped %d file(s) over the parse budget:\n", len(skipped))
+	for _, sk := range skipped {
+		fmt.Printf("  %s: %s\n", sk.FilePath, sk.Reason)
+	}
+}
+
+// printParseErrors lists files that failed to parse in strict mode.
+func printParseErrors(parseErrors []parser.ParseError) {
+	if len(parseErrors) == 0 {
+		return
+	}
+	fmt.Printf("Failed to parse %d file(s):\n", len(parseErrors))
+	for _, pe := range parseErrors {
+		fmt.Printf("  %s\n", pe.Error())
+	}
+}
+
+// ConvertParseErrors converts files that failed to parse for reporting.
+func ConvertParseErrors(parseErrors []parser.ParseError) []output.ParseError {
+	if len(parseErrors) == 0 {
+		return nil
+	}
+	converted := make([]output.ParseError, len(parseErrors))
+	for i, pe := range parseErrors {
+		converted[i] = output.ParseError{File: pe.FilePath, Line: pe.Line, Error: pe.Err.Error()}
+	}
+	return converted
 }
 
 // FilterOptions holds the configuration for creating a URL filter.
@@ -223,13 +922,17 @@ func CreateFilter(opts FilterOptions) (*filter.Filter, error) {
 		Domains:       cfg.Ignore.Domains,
 		GlobPatterns:  cfg.Ignore.Patterns,
 		RegexPatterns: cfg.Ignore.Regex,
+		Badges:        cfg.Ignore.Badges,
 	})
 }
 
 // CreateFilterWithConfig builds a URL filter using a pre-loaded config.
-// CLI flags are merged additively with the config settings.
+// CLI flags are merged additively with the config settings; badges are
+// skipped if either cliBadges or ignore.badges is set.
 // Returns nil if no filter rules are defined.
-func CreateFilterWithConfig(cfg *config.Config, cliDomains, cliPatterns, cliRegex []string) (*filter.Filter, error) {
+func CreateFilterWithConfig(
+	cfg *config.Config, cliDomains, cliPatterns, cliRegex []string, cliBadges bool,
+) (*filter.Filter, error) {
 	// Merge CLI flags (additive)
 	domains := append([]string{}, cfg.Ignore.Domains...)
 	domains = append(domains, cliDomains...)
@@ -240,8 +943,10 @@ func CreateFilterWithConfig(cfg *config.Config, cliDomains, cliPatterns, cliRege
 	regex := append([]string{}, cfg.Ignore.Regex...)
 	regex = append(regex, cliRegex...)
 
+	badges := cliBadges || cfg.Ignore.Badges
+
 	// If no ignore rules, return nil (no filtering)
-	if len(domains) == 0 && len(patterns) == 0 && len(regex) == 0 {
+	if len(domains) == 0 && len(patterns) == 0 && len(regex) == 0 && !badges {
 		return nil, nil
 	}
 
@@ -250,9 +955,63 @@ func CreateFilterWithConfig(cfg *config.Config, cliDomains, cliPatterns, cliRege
 		Domains:       domains,
 		GlobPatterns:  patterns,
 		RegexPatterns: regex,
+		Badges:        badges,
 	})
 }
 
+// BuildRewriteRules compiles the rewrite rules from config.
+func (lc *LoadedConfig) BuildRewriteRules() ([]fixer.RewriteRule, error) {
+	rules := make([]fixer.RewriteRule, 0, len(lc.cfg.Rewrites))
+	for _, r := range lc.cfg.Rewrites {
+		rule, err := fixer.NewRewriteRule(r.From, r.To)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// BuildNormalizer returns the URL normalizer for the given rules.
+// CLI overrides config if set.
+func (lc *LoadedConfig) BuildNormalizer(cliValues []string) (fixer.Normalizer, error) {
+	if len(cliValues) > 0 {
+		return fixer.ParseNormalizer(cliValues)
+	}
+	return fixer.ParseNormalizer(lc.cfg.Normalize)
+}
+
+// AddFixSuggestions annotates results with the fix that 'gone fix' would apply,
+// including the archived snapshots and https:// upgrades looked up, if any.
+func (lc *LoadedConfig) AddFixSuggestions(results []checker.Result, snapshots, upgrades map[string]string) error {
+	f, err := lc.BuildSuggestionFixer()
+	if err != nil {
+		return err
+	}
+	f.SetSnapshots(snapshots)
+	f.SetUpgrades(upgrades)
+	f.AddSuggestions(results)
+	return nil
+}
+
+// BuildSuggestionFixer returns a fixer that suggests fixes using the config's rewrite
+// and normalization rules, and the closest anchor for broken anchors.
+func (lc *LoadedConfig) BuildSuggestionFixer() (*fixer.Fixer, error) {
+	rules, err := lc.BuildRewriteRules()
+	if err != nil {
+		return nil, err
+	}
+	normalizer, err := lc.BuildNormalizer(nil)
+	if err != nil {
+		return nil, err
+	}
+	f := fixer.New()
+	f.SetRewriteRules(rules)
+	f.SetNormalizer(normalizer)
+	f.SetFixAnchors(true)
+	return f, nil
+}
+
 // CountUniqueURLs returns the number of unique URLs in a slice of checker.Link.
 // This is useful for displaying progress information and deduplication stats.
 func CountUniqueURLs(links []checker.Link) int {
@@ -272,12 +1031,37 @@ func ConvertParserLinks(parserLinks []parser.Link) []checker.Link {
 			URL:      pl.URL,
 			FilePath: pl.FilePath,
 			Line:     pl.Line,
+			Column:   pl.Column,
+			Offset:   pl.Offset,
 			Text:     pl.Text,
+			Parser:   parser.FileTypeForFile(pl.FilePath),
+			Type:     pl.Type.String(),
+			Context:  pl.Context,
 		}
 	}
 	return links
 }
 
+// PrioritizeLinks moves links found in priority files to the front, keeping
+// the order within both groups. Links are returned unchanged if priority is empty.
+func PrioritizeLinks(links []parser.Link, priority map[string]bool) []parser.Link {
+	if len(priority) == 0 {
+		return links
+	}
+	ordered := make([]parser.Link, 0, len(links))
+	for _, l := range links {
+		if priority[l.FilePath] {
+			ordered = append(ordered, l)
+		}
+	}
+	for _, l := range links {
+		if !priority[l.FilePath] {
+			ordered = append(ordered, l)
+		}
+	}
+	return ordered
+}
+
 // FilterParserLinks applies a URL filter to parser links and returns checker links.
 // Links that match the filter are excluded from the result.
 // Returns all links converted to checker.Link if urlFilter is nil.
@@ -292,7 +1076,12 @@ func FilterParserLinks(parserLinks []parser.Link, urlFilter *filter.Filter) []ch
 			URL:      pl.URL,
 			FilePath: pl.FilePath,
 			Line:     pl.Line,
+			Column:   pl.Column,
+			Offset:   pl.Offset,
 			Text:     pl.Text,
+			Parser:   parser.FileTypeForFile(pl.FilePath),
+			Type:     pl.Type.String(),
+			Context:  pl.Context,
 		})
 	}
 	return links
@@ -337,6 +1126,17 @@ func FilterResultsDuplicates(results []checker.Result) []checker.Result {
 	return filtered
 }
 
+// FilterResultsSkipped returns results left unchecked by the run's budget.
+func FilterResultsSkipped(results []checker.Result) []checker.Result {
+	var filtered []checker.Result
+	for _, r := range results {
+		if r.IsSkipped() {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
+
 // FilterResultsAlive returns only alive results.
 // Pre-allocates slice capacity - alive is typically the majority (~70-80%).
 func FilterResultsAlive(results []checker.Result) []checker.Result {
@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/leonardomso/gone/internal/config"
+	"github.com/leonardomso/gone/internal/filter"
+	"github.com/leonardomso/gone/internal/gitrepo"
+	"github.com/leonardomso/gone/internal/parser"
+	"github.com/leonardomso/gone/internal/scanner"
+
+	"github.com/spf13/cobra"
+)
+
+// Init command flag variables.
+var (
+	initFileTypes []string
+	initForce     bool
+	initHook      bool
+)
+
+// initCmd writes a starter config file.
+var initCmd = &cobra.Command{
+	Use:   "init [path]",
+	Short: "Create a .gonerc.yaml config file",
+	Long: `Write a .gonerc.yaml config file to the current directory, listing every
+option with a comment, commented out at its default.
+
+The files under path (the current directory by default) are scanned for
+links that are never meant to be checked, which are ignored in the new file:
+localhost, loopback and private addresses, the reserved example.com domains,
+hosts under local or test top-level domains such as .local and .test, and
+badge images such as shields.io. Nothing is checked.
+
+With --hook, a git pre-commit hook running 'gone check --cache' is installed
+too, so commits with dead links are stopped. Skip it once with
+'git commit --no-verify'.
+
+Examples:
+  gone init                      # Write .gonerc.yaml for the current directory
+  gone init ./docs               # Only look for ignore candidates in ./docs
+  gone init --types=md,html      # Scan and configure HTML files too
+  gone init --hook               # Also install a pre-commit hook
+  gone init --force              # Overwrite an existing config file and hook`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runInit,
+}
+
+func init() {
+	rootCmd.AddCommand(initCmd)
+
+	initCmd.Flags().StringSliceVarP(&initFileTypes, "types", "T", []string{"md"},
+		"File types to scan and write to the config")
+	initCmd.Flags().BoolVar(&initForce, "force", false,
+		"Overwrite an existing config file and pre-commit hook")
+	initCmd.Flags().BoolVar(&initHook, "hook", false,
+		"Install a git pre-commit hook running gone check")
+}
+
+// runInit is the entry point for the init command.
+func runInit(_ *cobra.Command, args []string) {
+	path := getPathArg(args)
+	exitOnError(validateFileTypes(initFileTypes), "Invalid file types")
+
+	configPath := config.DefaultConfigFileName
+	if _, err := os.Stat(configPath); err == nil && !initForce {
+		exitOnError(fmt.Errorf("%s already exists; use --force to overwrite it", configPath), "")
+	}
+
+	// The hook can't be installed outside a repository or over an existing
+	// one, so that is found out before anything is written
+	var hook preparedHook
+	if initHook {
+		var err error
+		hook, err = prepareHook(path)
+		exitOnError(err, "Error installing pre-commit hook")
+	}
+
+	opts, err := detectIgnores(path)
+	exitOnError(err, "Error scanning files")
+	opts.Types = initFileTypes
+
+	data, err := config.Scaffold(opts)
+	exitOnError(err, "Error writing config")
+	//nolint:gosec // The config file is meant to be committed and shared
+	exitOnError(os.WriteFile(configPath, data, 0o644), "Error writing config")
+	fmt.Printf("Wrote %s\n", configPath)
+
+	if initHook {
+		exitOnError(hook.install(), "Error installing pre-commit hook")
+		fmt.Printf("Installed the pre-commit hook %s\n", hook.path)
+	}
+}
+
+// detectIgnores scans the files under path for links worth ignoring, and
+// prints what it found.
+func detectIgnores(path string) (config.ScaffoldOptions, error) {
+	loadedCfg, _ := LoadConfig(true) // Never fails without a config file
+	files, err := scanner.FindFilesWithOptions(loadedCfg.BuildScanOptions(path, initFileTypes, []string{"md"}))
+	if err != nil {
+		return config.ScaffoldOptions{}, err
+	}
+	ex := parser.Extract(files, loadedCfg.BuildParseBudget(0))
+	printParseErrors(ex.Errors)
+
+	urls := make([]string, 0, len(ex.Links))
+	for _, link := range ex.Links {
+		urls = append(urls, link.URL)
+	}
+	candidates, badges := filter.FindCandidates(urls)
+	fmt.Printf("Scanned %d file(s) with %d link(s).\n", len(files), len(urls))
+
+	opts := config.ScaffoldOptions{BadgeLinks: badges}
+	if len(candidates) > 0 {
+		opts.IgnoreDomains = make(map[string]int, len(candidates))
+	}
+	for _, c := range candidates {
+		opts.IgnoreDomains[c.Domain] = c.Links
+		fmt.Printf("  Ignoring %s (%d link(s))\n", c.Domain, c.Links)
+	}
+	if badges > 0 {
+		fmt.Printf("  Skipping badges (%d link(s))\n", badges)
+	}
+	return opts, nil
+}
+
+// preparedHook is a pre-commit hook ready to be written.
+type preparedHook struct {
+	dir    string // Hooks directory
+	path   string
+	script string
+}
+
+// prepareHook builds a pre-commit hook checking the links under path, with
+// the config file of the current directory. It fails outside a repository,
+// or if a hook already exists and --force isn't set.
+func prepareHook(path string) (preparedHook, error) {
+	ctx := context.Background()
+	repo, err := gitrepo.Open(ctx, ".")
+	if err != nil {
+		return preparedHook{}, err
+	}
+	hooksDir, err := repo.HooksDir(ctx)
+	if err != nil {
+		return preparedHook{}, err
+	}
+	hookPath := filepath.Join(hooksDir, "pre-commit")
+	if _, err := os.Stat(hookPath); err == nil && !initForce {
+		return preparedHook{}, fmt.Errorf(
+			"%s already exists; add 'gone check' to it, or use --force to overwrite it", hookPath)
+	}
+
+	// Hooks run from the top of the working tree, and gone reads the
+	// config file of the directory it runs in
+	dir, err := relativeToRepo(repo.Dir)
+	if err != nil {
+		return preparedHook{}, err
+	}
+	script := "#!/bin/sh\n" +
+		"# Checks links before each commit. Installed by gone init.\n" +
+		"# Skip it once with: git commit --no-verify\n"
+	if dir != "." {
+		script += "cd " + shellQuote(dir) + " || exit 1\n"
+	}
+	script += "exec gone check --cache --no-progress"
+	if path != "." {
+		script += " " + shellQuote(filepath.ToSlash(path))
+	}
+	script += "\n"
+
+	return preparedHook{dir: hooksDir, path: hookPath, script: script}, nil
+}
+
+// install writes the hook.
+func (h preparedHook) install() error {
+	if err := os.MkdirAll(h.dir, 0o750); err != nil {
+		return err
+	}
+	//nolint:gosec // Git only runs executable hooks
+	if err := os.WriteFile(h.path, []byte(h.script), 0o755); err != nil {
+		return err
+	}
+	// WriteFile keeps the mode of an existing file
+	return os.Chmod(h.path, 0o755) //nolint:gosec // As above
+}
+
+// relativeToRepo returns the current directory relative to the top-level
+// directory of its repository.
+func relativeToRepo(top string) (string, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	// Resolve symlinks on both sides, as git does for the top-level directory
+	if wd, err = filepath.EvalSymlinks(wd); err != nil {
+		return "", err
+	}
+	if top, err = filepath.EvalSymlinks(top); err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(top, wd)
+	if err != nil || !filepath.IsLocal(rel) {
+		return "", fmt.Errorf("%s is not inside %s", wd, top)
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// shellQuote quotes s for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
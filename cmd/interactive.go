@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
 	"strings"
 
@@ -42,7 +43,7 @@ Controls:
   ?             Toggle help
   q             Quit
 
-Supported file types: md, json, yaml, toml, xml
+Supported file types: md, json, yaml, toml, xml, html
 
 Ignore patterns:
   gone interactive --ignore-domain=localhost,example.com
@@ -57,7 +58,7 @@ func init() {
 
 	// File type options
 	interactiveCmd.Flags().StringSliceVarP(&iFileTypes, "types", "T", []string{"md"},
-		"File types to scan (comma-separated): md, json, yaml, toml, xml")
+		"File types to scan (comma-separated): md, json, yaml, toml, xml, html, ini, env, properties, docker, code, txt")
 	interactiveCmd.Flags().BoolVar(&iStrictMode, "strict", false,
 		"Fail on malformed files instead of skipping them")
 
@@ -74,6 +75,9 @@ func init() {
 
 // runInteractive launches the interactive TUI for link checking.
 func runInteractive(_ *cobra.Command, args []string) {
+	// The terminal UI owns the screen, so logs would only garble it
+	slog.SetDefault(slog.New(slog.DiscardHandler))
+
 	// Load configuration
 	loadedCfg, err := LoadConfig(iNoConfig)
 	if err != nil {
@@ -107,7 +111,7 @@ func runInteractive(_ *cobra.Command, args []string) {
 	effectiveStrict := loadedCfg.GetStrict(iStrictMode)
 
 	// Create filter from config and flags using shared helper
-	urlFilter, err := CreateFilterWithConfig(loadedCfg.Config(), iIgnoreDomains, iIgnorePatterns, iIgnoreRegex)
+	urlFilter, err := CreateFilterWithConfig(loadedCfg.Config(), iIgnoreDomains, iIgnorePatterns, iIgnoreRegex, false)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error creating filter: %v\n", err)
 		os.Exit(1) //nolint:revive // deep-exit is acceptable for CLI entry points
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+)
+
+// Logging flags, shared by every command.
+var (
+	quiet     bool
+	verbosity int
+)
+
+func init() {
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false,
+		"Log only errors to stderr")
+	rootCmd.PersistentFlags().CountVarP(&verbosity, "verbose", "v",
+		"Log each request attempt, retry, and filter decision to stderr; "+
+			"-vv also logs every HTTP request and scanned file")
+}
+
+// setupLogging makes the default logger write to w at the level selected
+// by --quiet and --verbose. Logs go to stderr, so stdout keeps only the report.
+func setupLogging(w io.Writer) error {
+	if quiet && verbosity > 0 {
+		return errors.New("--quiet and --verbose are mutually exclusive")
+	}
+	slog.SetDefault(slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{
+		Level:       logLevel(),
+		ReplaceAttr: dropTime,
+	})))
+	return nil
+}
+
+// logLevel returns the level --quiet and --verbose select: warnings by
+// default, errors with --quiet, info with -v, and debug with -vv.
+func logLevel() slog.Level {
+	switch {
+	case quiet:
+		return slog.LevelError
+	case verbosity >= 2:
+		return slog.LevelDebug
+	case verbosity == 1:
+		return slog.LevelInfo
+	default:
+		return slog.LevelWarn
+	}
+}
+
+// logging reports whether anything but warnings and errors is logged.
+func logging() bool {
+	return logLevel() < slog.LevelWarn
+}
+
+// dropTime removes the timestamp from log records; lines are read as they're
+// written, and the report has the run's time.
+func dropTime(groups []string, a slog.Attr) slog.Attr {
+	if a.Key == slog.TimeKey && len(groups) == 0 {
+		return slog.Attr{}
+	}
+	return a
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/leonardomso/gone/internal/checker"
+	"github.com/leonardomso/gone/internal/monitor"
+	"github.com/leonardomso/gone/internal/output"
+)
+
+// webhookTimeout bounds each post to check.recheck_webhook.
+const webhookTimeout = 10 * time.Second
+
+// newRecheckMonitor returns the monitor rechecking the links of gone watch
+// and gone serve every check.recheck_interval with c, and the sinks of the
+// status changes it finds, or a nil monitor if rechecks are off.
+func newRecheckMonitor(cfg *LoadedConfig, c monitor.Checker) (*monitor.Monitor, []monitor.Sink, error) {
+	interval := cfg.GetRecheckInterval()
+	if interval == 0 {
+		return nil, nil, nil
+	}
+	m := monitor.New(c, interval)
+	m.OnError(func(err error) {
+		fmt.Fprintf(os.Stderr, "Error rechecking links: %v\n", err)
+	})
+
+	var sinks []monitor.Sink
+	url, err := cfg.GetRecheckWebhook()
+	if err != nil {
+		return nil, nil, err
+	}
+	if url != "" {
+		sinks = append(sinks, monitor.Webhook(url, &http.Client{Timeout: webhookTimeout}))
+	}
+	return m, sinks, nil
+}
+
+// writeRecheckMetrics writes Prometheus metrics of the results of a check
+// of files to output.metricsFile, if set, so a textfile collector always
+// has those of the latest recheck.
+func writeRecheckMetrics(cfg *LoadedConfig, files []string, results []checker.Result, duration time.Duration) {
+	path := cfg.GetMetricsFile("")
+	if path == "" {
+		return
+	}
+	summary := checker.Summarize(results)
+	report := &output.Report{
+		GeneratedAt: time.Now(),
+		Files:       files,
+		TotalLinks:  summary.Total,
+		UniqueURLs:  summary.UniqueURLs,
+		Summary:     summary,
+		Results:     results,
+		Duration:    duration,
+	}
+	if err := output.WriteMetricsFile(report, path); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing metrics: %v\n", err)
+	}
+}
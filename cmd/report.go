@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/leonardomso/gone/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+// Report command flag variables.
+var (
+	mergeOutput []string
+	mergeFormat string
+)
+
+// reportCmd groups commands that work on saved reports.
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Work with saved JSON reports",
+	Long: `Work with JSON reports written by 'gone check --output=report.json'.
+
+No URLs are checked again.`,
+}
+
+// reportMergeCmd merges JSON reports into one.
+var reportMergeCmd = &cobra.Command{
+	Use:   "merge <report.json>...",
+	Short: "Merge JSON reports from several runs into one",
+	Long: `Merge JSON reports from several runs, such as sharded CI jobs that each
+scan a different directory, into one report with a combined summary.
+
+Links reported by more than one input are listed once, and a URL checked
+by several inputs is kept once with the other occurrences as duplicates.
+Reports list only dead links and warnings unless written with --all, so
+counts for unlisted (alive) links are summed as is.
+
+Exits with status 1 if the merged report has dead links, policy
+violations, or parse errors, like 'gone check' would.
+
+Examples:
+  gone report merge docs.json api.json                  # Print the combined summary
+  gone report merge *.json --output=combined.html       # Write an HTML report
+  gone report merge *.json --output=combined.junit.xml  # Write JUnit XML for CI/CD
+  gone report merge *.json -o combined.md -o combined.json  # Write several formats at once
+  gone report merge *.json --format=markdown            # Markdown report to stdout`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  runReportMerge,
+}
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+	reportCmd.AddCommand(reportMergeCmd)
+
+	reportMergeCmd.Flags().StringSliceVarP(&mergeOutput, "output", "o", nil,
+		"Write the merged report to a file (format inferred from extension: "+
+			".json, .yaml, .xml, .junit.xml, .md, .html, .csv, .ndjson, .tap; can be repeated)")
+	reportMergeCmd.Flags().StringVarP(&mergeFormat, "format", "f", "",
+		"Print the merged report to stdout: "+strings.Join(output.ValidFormats(), ", "))
+}
+
+// runReportMerge is the entry point for the report merge command.
+func runReportMerge(_ *cobra.Command, args []string) {
+	if len(mergeOutput) > 0 && mergeFormat != "" {
+		exitOnError(fmt.Errorf("--format and --output are mutually exclusive"), "")
+	}
+	if mergeFormat != "" && !output.IsValidFormat(mergeFormat) {
+		exitOnError(fmt.Errorf("invalid format %q; valid formats: %s",
+			mergeFormat, strings.Join(output.ValidFormats(), ", ")), "")
+	}
+
+	reports := make([]*output.Report, 0, len(args))
+	for _, path := range args {
+		data, err := os.ReadFile(path) //nolint:gosec // Path comes from the command line
+		exitOnError(err, "Error reading report")
+		report, err := output.ReadJSONReport(data)
+		exitOnError(err, path)
+		reports = append(reports, report)
+	}
+	merged := output.Merge(reports...)
+
+	switch {
+	case mergeFormat != "":
+		format := output.Format(strings.ToLower(mergeFormat))
+		data, err := output.FormatReport(merged, format)
+		exitOnError(err, "Error formatting report")
+		fmt.Print(string(data))
+		if format == output.FormatGitHub {
+			exitOnError(output.WriteStepSummary(merged), "Error writing step summary")
+		}
+	case len(mergeOutput) > 0:
+		exitOnError(output.WriteToFiles(merged, mergeOutput), "Error writing report")
+		fmt.Printf("Merged %d report(s) into %s\n", len(reports), strings.Join(mergeOutput, ", "))
+		printSummaryLine(merged.Summary, len(merged.Ignored))
+	default:
+		fmt.Printf("Merged %d report(s): %d file(s), %d link(s), %d unique URL(s)\n",
+			len(reports), merged.FilesScanned(), merged.TotalLinks, merged.UniqueURLs)
+		printSummaryLine(merged.Summary, len(merged.Ignored))
+	}
+
+	if merged.Summary.HasDeadLinks() || len(merged.Violations) > 0 || len(merged.ParseErrors) > 0 {
+		os.Exit(1)
+	}
+}
@@ -31,6 +31,19 @@ Examples:
   gone check ./docs       # Scan specific directory
   gone check --format=json
   gone interactive        # Launch interactive TUI`,
+	PersistentPreRunE: func(_ *cobra.Command, _ []string) error {
+		return setupLogging(os.Stderr)
+	},
+}
+
+// allowExternalParsers lets the config file's external parsers run. They're
+// commands, which a cloned repository's config file could otherwise use to
+// run anything on a plain 'gone check'.
+var allowExternalParsers bool
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&allowExternalParsers, "allow-external-parsers", false,
+		"Run the commands the config file sets as external parsers (parsers)")
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -38,6 +51,6 @@ Examples:
 func Execute() {
 	err := rootCmd.Execute()
 	if err != nil {
-		os.Exit(1) //nolint:revive // deep-exit is acceptable for CLI entry points
+		os.Exit(exitInternal) //nolint:revive // deep-exit is acceptable for CLI entry points
 	}
 }
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/leonardomso/gone/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+// schemaCmd prints the JSON Schema of JSON reports.
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of JSON reports",
+	Long: `Print the JSON Schema (draft 2020-12) of the reports written by
+'gone check --format=json', for tools that validate or generate code
+from them.
+
+Every JSON report carries a schema_version, which is bumped when a field
+is removed, renamed, or changes type. New fields don't bump it.
+
+Examples:
+  gone schema > report.schema.json
+  gone check --format=json | check-jsonschema --schemafile report.schema.json -`,
+	Args: cobra.NoArgs,
+	Run:  runSchema,
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+}
+
+// runSchema is the entry point for the schema command.
+func runSchema(_ *cobra.Command, _ []string) {
+	data, err := output.JSONSchema()
+	exitOnError(err, "Error generating schema")
+	_, err = os.Stdout.Write(data)
+	exitOnError(err, "Error writing schema")
+}
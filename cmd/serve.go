@@ -0,0 +1,303 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/leonardomso/gone/internal/checker"
+	"github.com/leonardomso/gone/internal/output"
+	"github.com/leonardomso/gone/internal/parser"
+	"github.com/leonardomso/gone/internal/scanner"
+	"github.com/leonardomso/gone/internal/server"
+
+	"github.com/spf13/cobra"
+)
+
+// Serve command flag variables.
+var (
+	serveAddr        string
+	serveFileTypes   []string
+	serveConcurrency int
+	serveTimeout     int
+	serveRetries     int
+	serveShowAll     bool
+
+	// Ignore flags (shared with check).
+	serveIgnoreDomains  []string
+	serveIgnorePatterns []string
+	serveIgnoreRegex    []string
+	serveNoConfig       bool
+)
+
+// serveRequestFile is the file name given to the links of a check of URLs,
+// whose line is the URL's position in the request.
+const serveRequestFile = "request"
+
+// serveCmd runs link checks over HTTP.
+var serveCmd = &cobra.Command{
+	Use:   "serve [path]",
+	Short: "Serve link checks over HTTP",
+	Long: `Run gone as an HTTP service, so a team can check links from CI jobs,
+bots, or scripts and see the results of the last check in a browser.
+
+Endpoints:
+  POST /check           Check URLs or the files under a path, and answer
+                        with the report
+  GET  /reports/latest  The report of the last check
+  GET  /                A dashboard of the last check
+  GET  /healthz         Answers "ok" while the server is up
+
+POST /check takes {"urls": [...]}, a JSON array of URLs, or plain text with
+a URL per line, or {"path": "docs"} to check the files under a path of the
+served directory (the current directory by default). Paths can't leave the
+served directory. Reports are JSON, or any other format but template with
+?format=, such as ?format=markdown or ?format=junit. One check runs at a
+time; a POST /check while one is running is answered with 503.
+
+The server listens on localhost by default. POST /check has no
+authentication and makes the host request any URL it's sent, including
+internal addresses, so only listen on other interfaces (--addr=:8080) on a
+network whose users may do that.
+
+Like 'gone check', reports list the dead links and warnings, and links that
+work only with --all. Files are scanned with the same --types, scan include
+and exclude patterns, and ignore rules.
+
+With check.recheck_interval set in .gonerc.yaml, the last check is run again
+at that interval, its files scanned again, so the latest report and the
+dashboard stay current. The status changes each recheck finds are posted to
+check.recheck_webhook if set, and output.metricsFile is rewritten.
+
+Examples:
+  gone serve                           # Serve the current directory on localhost:8080
+  gone serve ./docs --addr=:9000       # Serve ./docs on port 9000 of every interface
+  curl -X POST -d '{"path": "."}' http://localhost:8080/check
+  curl -X POST -d 'https://example.com' http://localhost:8080/check?format=markdown`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runServe,
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+
+	serveCmd.Flags().StringVar(&serveAddr, "addr", "localhost:8080",
+		"Address to listen on (:8080 for every interface)")
+	serveCmd.Flags().StringSliceVarP(&serveFileTypes, "types", "T", []string{"md"},
+		"File types to scan: md, json, yaml, toml, xml, html, ini, env, properties, docker, code, txt")
+	serveCmd.Flags().IntVarP(&serveConcurrency, "concurrency", "c", checker.DefaultConcurrency,
+		"Number of concurrent workers per check")
+	serveCmd.Flags().IntVarP(&serveTimeout, "timeout", "t", int(checker.DefaultTimeout.Seconds()),
+		"Timeout per request in seconds")
+	serveCmd.Flags().IntVarP(&serveRetries, "retries", "r", checker.DefaultMaxRetries,
+		"Number of retries for failed requests")
+	serveCmd.Flags().BoolVarP(&serveShowAll, "all", "a", false,
+		"Also report links that work")
+
+	serveCmd.Flags().StringSliceVar(&serveIgnoreDomains, "ignore-domain", nil,
+		"Domains to ignore (comma-separated or repeated)")
+	serveCmd.Flags().StringSliceVar(&serveIgnorePatterns, "ignore-pattern", nil,
+		"Glob patterns to ignore (comma-separated or repeated)")
+	serveCmd.Flags().StringSliceVar(&serveIgnoreRegex, "ignore-regex", nil,
+		"Regex patterns to ignore (comma-separated or repeated)")
+	serveCmd.Flags().BoolVar(&serveNoConfig, "no-config", false,
+		"Skip loading .gonerc.yaml config file")
+}
+
+// serveRunner runs the checks of gone serve.
+type serveRunner struct {
+	cfg     *LoadedConfig
+	root    string
+	checked func(results []checker.Result) // Called with the results of each check, if set
+}
+
+// serveCheck is a check of gone serve about to run: the report of the files
+// of its request, and the links to check.
+type serveCheck struct {
+	report  *output.Report
+	links   []checker.Link
+	ignored int
+	start   time.Time
+}
+
+// runServe is the entry point for the serve command.
+func runServe(_ *cobra.Command, args []string) {
+	loadedCfg, err := LoadConfig(serveNoConfig)
+	exitOnError(err, "Config error")
+
+	root := getPathArg(args)
+	info, err := os.Stat(root)
+	exitOnError(err, "Invalid path")
+	if !info.IsDir() {
+		exitOnError(fmt.Errorf("%s is not a directory", root), "Invalid path")
+	}
+	exitOnError(validateFileTypes(loadedCfg.GetTypes(serveFileTypes, []string{"md"})), "Invalid file types")
+
+	// Check the ignore rules and rewrite rules once, so a request can't be
+	// the first to find them broken
+	_, err = CreateFilterWithConfig(loadedCfg.Config(), serveIgnoreDomains, serveIgnorePatterns, serveIgnoreRegex, false)
+	exitOnError(err, "Error creating filter")
+	_, err = loadedCfg.BuildSuggestionFixer()
+	exitOnError(err, "Invalid rewrite rules")
+
+	runner := &serveRunner{cfg: loadedCfg, root: root}
+	checks := server.New(runner.run)
+	srv := &http.Server{
+		Addr:              serveAddr,
+		Handler:           checks.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	rechecks, sinks, err := newRecheckMonitor(loadedCfg,
+		checker.New(loadedCfg.BuildCheckerOptions(serveConcurrency, serveTimeout, serveRetries)))
+	exitOnError(err, "Config error")
+	if rechecks != nil {
+		// Rechecks report the changes since the last check, whichever ran it
+		runner.checked = rechecks.Seed
+		rc := &serveRechecks{runner: runner, srv: checks}
+		rechecks.OnRecheck(rc.refresh)
+		go func() { _ = rechecks.Run(ctx, rc.links, sinks...) }()
+		fmt.Printf("Rechecking the last check every %s.\n", rechecks.Interval())
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Printf("Serving link checks of %s on %s. Press Ctrl+C to stop.\n", root, serveAddr)
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		exitOnError(err, "Error serving")
+	}
+}
+
+// run checks the URLs or the files under the path of req, and reports the
+// dead links and warnings, or every link with --all.
+func (sr *serveRunner) run(ctx context.Context, req server.Request) (*output.Report, error) {
+	sc, err := sr.collect(req)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]checker.Result, 0, len(sc.links))
+	checker.New(sr.cfg.BuildCheckerOptions(serveConcurrency, serveTimeout, serveRetries)).
+		CheckFunc(ctx, sc.links, func(r checker.Result) { results = append(results, r) })
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if sr.checked != nil {
+		sr.checked(results)
+	}
+	return sr.finish(sc, results)
+}
+
+// collect scans the files of req, or takes its URLs, and returns the links
+// to check, without those the ignore rules skip.
+func (sr *serveRunner) collect(req server.Request) (*serveCheck, error) {
+	sc := &serveCheck{report: &output.Report{}, start: time.Now()}
+	report := sc.report
+
+	var parserLinks []parser.Link
+	if len(req.URLs) > 0 {
+		report.Files = []string{serveRequestFile}
+		for i, u := range req.URLs {
+			parserLinks = append(parserLinks, parser.Link{URL: u, FilePath: serveRequestFile, Line: i + 1})
+		}
+	} else {
+		path := filepath.Join(sr.root, filepath.FromSlash(req.Path))
+		if _, err := os.Stat(path); err != nil {
+			return nil, fmt.Errorf("%w: no such file or directory: %s", server.ErrInvalidRequest, req.Path)
+		}
+		files, err := scanner.FindFilesWithOptions(sr.cfg.BuildScanOptions(path, serveFileTypes, []string{"md"}))
+		if err != nil {
+			return nil, fmt.Errorf("scanning directory: %w", err)
+		}
+		ex := parser.Extract(files, sr.cfg.BuildParseBudget(0))
+		report.Files = files
+		report.Skipped = ConvertSkippedFiles(ex.Skipped)
+		report.ParseErrors = ConvertParseErrors(ex.Errors)
+		parserLinks = ex.Links
+	}
+
+	// A filter per check, since it counts the links it ignores
+	urlFilter, err := CreateFilterWithConfig(
+		sr.cfg.Config(), serveIgnoreDomains, serveIgnorePatterns, serveIgnoreRegex, false)
+	if err != nil {
+		return nil, fmt.Errorf("creating filter: %w", err)
+	}
+	sc.links = FilterParserLinks(parserLinks, urlFilter)
+	sc.ignored = urlFilter.IgnoredCount()
+	return sc, nil
+}
+
+// finish returns the report of the check sc with its results: the dead
+// links and warnings, or every link with --all.
+func (sr *serveRunner) finish(sc *serveCheck, results []checker.Result) (*output.Report, error) {
+	suggester, err := sr.cfg.BuildSuggestionFixer()
+	if err != nil {
+		return nil, err
+	}
+	suggester.AddSuggestions(results)
+
+	report := sc.report
+	summary := checker.Summarize(results)
+	report.GeneratedAt = time.Now()
+	report.Summary = summary
+	report.TotalLinks = summary.Total + sc.ignored
+	report.UniqueURLs = summary.UniqueURLs
+	report.Duration = time.Since(sc.start)
+	for _, r := range results {
+		if serveShowAll || !r.IsAlive() || len(r.Hints) > 0 {
+			report.Results = append(report.Results, r)
+		}
+	}
+	return report, nil
+}
+
+// serveRechecks runs the last check of gone serve again for its monitor.
+type serveRechecks struct {
+	runner  *serveRunner
+	srv     *server.Server
+	req     *server.Request // Of the recheck in progress
+	pending *serveCheck
+}
+
+// links collects the links of the last check again, scanning its files
+// again, or returns none before the first check.
+func (rc *serveRechecks) links() ([]checker.Link, error) {
+	rc.req, rc.pending = rc.srv.Last(), nil
+	if rc.req == nil {
+		return nil, nil
+	}
+	sc, err := rc.runner.collect(*rc.req)
+	if err != nil {
+		return nil, err
+	}
+	rc.pending = sc
+	return sc.links, nil
+}
+
+// refresh makes the report of a recheck the latest, unless another check
+// ran meanwhile, and writes its metrics.
+func (rc *serveRechecks) refresh(results []checker.Result) {
+	if rc.pending == nil {
+		return
+	}
+	report, err := rc.runner.finish(rc.pending, results)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error rechecking links: %v\n", err)
+		return
+	}
+	if rc.srv.Refresh(rc.req, report) {
+		writeRecheckMetrics(rc.runner.cfg, report.Files, results, report.Duration)
+	}
+}
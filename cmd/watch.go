@@ -0,0 +1,294 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/leonardomso/gone/internal/checker"
+	"github.com/leonardomso/gone/internal/filter"
+	"github.com/leonardomso/gone/internal/fixer"
+	"github.com/leonardomso/gone/internal/monitor"
+	"github.com/leonardomso/gone/internal/parser"
+	"github.com/leonardomso/gone/internal/scanner"
+	"github.com/leonardomso/gone/internal/term"
+	"github.com/leonardomso/gone/internal/watch"
+
+	"github.com/spf13/cobra"
+)
+
+// Watch command flag variables.
+var (
+	watchFileTypes   []string
+	watchConcurrency int
+	watchTimeout     int
+	watchRetries     int
+	watchDebounce    time.Duration
+	watchShowAll     bool
+	watchNoColor     bool
+
+	// Ignore flags (shared with check).
+	watchIgnoreDomains  []string
+	watchIgnorePatterns []string
+	watchIgnoreRegex    []string
+	watchNoConfig       bool
+)
+
+// watchCmd checks links again as files change.
+var watchCmd = &cobra.Command{
+	Use:   "watch [path]",
+	Short: "Check links again whenever files change",
+	Long: `Check the links of a directory, then keep watching its files and check
+the links of each file again when it's saved, while writing documentation.
+
+Only links new to a changed file are checked: a URL already checked for
+another file, or already in the file before the change, keeps its result.
+Each change prints the problems found in the new links (dead links and
+warnings such as redirects), how many URLs the file no longer links to,
+and a summary of all watched links. New files and directories are picked up,
+and removed ones forgotten.
+
+With check.recheck_interval set in .gonerc.yaml, all watched links are
+also checked again at that interval, to catch links that break without a
+file changing. Links whose status changed are printed, posted to
+check.recheck_webhook if set, and output.metricsFile is rewritten.
+
+Files are scanned as by 'gone check', with the same --types, scan include
+and exclude patterns, and ignore rules. Hidden directories such as .git
+are not watched. Stop watching with Ctrl+C.
+
+Examples:
+  gone watch                    # Watch the markdown files of the current directory
+  gone watch ./docs             # Watch a specific directory
+  gone watch README.md          # Watch a single file
+  gone watch --types=md,html    # Also watch HTML files
+  gone watch --all              # Also list the new links that work
+  gone watch --debounce=1s      # Wait for 1s without changes before checking`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runWatch,
+}
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+
+	watchCmd.Flags().StringSliceVarP(&watchFileTypes, "types", "T", []string{"md"},
+		"File types to watch: md, json, yaml, toml, xml, html, ini, env, properties, docker, code, txt")
+	watchCmd.Flags().IntVarP(&watchConcurrency, "concurrency", "c", checker.DefaultConcurrency,
+		"Number of concurrent workers")
+	watchCmd.Flags().IntVarP(&watchTimeout, "timeout", "t", int(checker.DefaultTimeout.Seconds()),
+		"Timeout per request in seconds")
+	watchCmd.Flags().IntVarP(&watchRetries, "retries", "r", checker.DefaultMaxRetries,
+		"Number of retries for failed requests")
+	watchCmd.Flags().DurationVar(&watchDebounce, "debounce", watch.DefaultDebounce,
+		"How long to wait for more changes before checking")
+	watchCmd.Flags().BoolVarP(&watchShowAll, "all", "a", false,
+		"Also list new links that work")
+	watchCmd.Flags().BoolVar(&watchNoColor, "no-color", false,
+		"Disable colors and hyperlinks")
+
+	watchCmd.Flags().StringSliceVar(&watchIgnoreDomains, "ignore-domain", nil,
+		"Domains to ignore (comma-separated or repeated)")
+	watchCmd.Flags().StringSliceVar(&watchIgnorePatterns, "ignore-pattern", nil,
+		"Glob patterns to ignore (comma-separated or repeated)")
+	watchCmd.Flags().StringSliceVar(&watchIgnoreRegex, "ignore-regex", nil,
+		"Regex patterns to ignore (comma-separated or repeated)")
+	watchCmd.Flags().BoolVar(&watchNoConfig, "no-config", false,
+		"Skip loading .gonerc.yaml config file")
+}
+
+// linkWatcher keeps the links of the watched files checked.
+type linkWatcher struct {
+	cfg       *LoadedConfig
+	urlFilter *filter.Filter
+	scanOpts  scanner.ScanOptions
+	index     *watch.Index
+	suggester *fixer.Fixer
+
+	// mu guards index and the output, which file changes and rechecks share
+	mu sync.Mutex
+}
+
+// runWatch is the entry point for the watch command.
+func runWatch(_ *cobra.Command, args []string) {
+	if watchDebounce <= 0 {
+		exitOnError(fmt.Errorf("--debounce must be positive, got %s", watchDebounce), "Invalid flags")
+	}
+
+	loadedCfg, err := LoadConfig(watchNoConfig)
+	exitOnError(err, "Config error")
+	theme, _ := term.ThemeByName(loadedCfg.GetTheme(""))
+	console = console.WithTheme(theme).WithColor(!watchNoColor)
+
+	path := getPathArg(args)
+	exitOnError(validateFileTypes(loadedCfg.GetTypes(watchFileTypes, []string{"md"})), "Invalid file types")
+
+	urlFilter, err := CreateFilterWithConfig(
+		loadedCfg.Config(), watchIgnoreDomains, watchIgnorePatterns, watchIgnoreRegex, false)
+	exitOnError(err, "Error creating filter")
+	suggester, err := loadedCfg.BuildSuggestionFixer()
+	exitOnError(err, "Invalid rewrite rules")
+
+	c := checker.New(loadedCfg.BuildCheckerOptions(watchConcurrency, watchTimeout, watchRetries))
+	rechecks, sinks, err := newRecheckMonitor(loadedCfg, c)
+	exitOnError(err, "Config error")
+	lw := &linkWatcher{
+		cfg:       loadedCfg,
+		urlFilter: urlFilter,
+		scanOpts:  loadedCfg.BuildScanOptions(path, watchFileTypes, []string{"md"}),
+		index:     watch.NewIndex(c),
+		suggester: suggester,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Start watching before the first check, so no change is missed
+	w, err := watch.NewWatcher(path, lw.scanOpts.Matches)
+	exitOnError(err, "Error watching files")
+	defer func() { _ = w.Close() }()
+	w.SetDebounce(watchDebounce)
+	w.OnError(func(err error) {
+		fmt.Fprintf(os.Stderr, "Error watching files: %v\n", err)
+	})
+
+	files, err := scanner.FindFilesWithOptions(lw.scanOpts)
+	exitOnError(err, "Error scanning directory")
+	fmt.Printf("Checking the links of %d file(s)...\n", len(files))
+	lw.printChanges(lw.index.Update(ctx, lw.parse(files)), true)
+	lw.printSummary()
+	if rechecks != nil {
+		rechecks.Seed(lw.index.Results())
+		rechecks.OnRecheck(lw.refresh)
+		sinks = append([]monitor.Sink{monitor.SinkFunc(lw.printRechecked)}, sinks...)
+		go func() { _ = rechecks.Run(ctx, lw.links, sinks...) }()
+		fmt.Printf("Rechecking all links every %s.\n", rechecks.Interval())
+	}
+	fmt.Printf("Watching %s for changes. Press Ctrl+C to stop.\n", path)
+
+	exitOnError(w.Run(ctx, func(paths []string) {
+		lw.mu.Lock()
+		defer lw.mu.Unlock()
+		if changes := lw.update(ctx, paths); ctx.Err() == nil && lw.printChanges(changes, false) {
+			lw.printSummary()
+		}
+	}), "Error watching files")
+}
+
+// links returns a link to every watched URL, for rechecks.
+func (lw *linkWatcher) links() ([]checker.Link, error) {
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+	return lw.index.Links(), nil
+}
+
+// refresh keeps the results of a recheck, and writes their metrics.
+func (lw *linkWatcher) refresh(results []checker.Result) {
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+	lw.index.Refresh(results)
+	writeRecheckMetrics(lw.cfg, lw.index.Paths(), lw.index.Results(), 0)
+}
+
+// printRechecked prints the links whose status changed since the last
+// recheck, and a summary of all watched links. Added and removed URLs were
+// printed with the file changes that added or removed them.
+func (lw *linkWatcher) printRechecked(_ context.Context, deltas []monitor.Delta) error {
+	var entries []term.Entry
+	for _, d := range deltas {
+		if d.Kind == monitor.DeltaChanged {
+			entries = append(entries, resultEntry(d.Current))
+		}
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+	fmt.Printf("[%s] Recheck: %d link(s) changed status\n", time.Now().Format(time.TimeOnly), len(entries))
+	console.Entries(entries)
+	lw.printSummary()
+	return nil
+}
+
+// update forgets the removed paths and checks the new links of the others.
+func (lw *linkWatcher) update(ctx context.Context, paths []string) []watch.Change {
+	var changed, removed []string
+	for _, p := range paths {
+		if _, err := os.Stat(p); err != nil {
+			removed = append(removed, p)
+		} else if lw.scanOpts.Matches(p) {
+			changed = append(changed, p)
+		}
+	}
+
+	changes := lw.index.Remove(removed...)
+	if len(changed) > 0 {
+		changes = append(changes, lw.index.Update(ctx, lw.parse(changed))...)
+	}
+	return changes
+}
+
+// parse extracts the links of files, applying the ignore rules. Every file
+// has an entry, so files that no longer have links are updated too.
+func (lw *linkWatcher) parse(files []string) map[string][]checker.Link {
+	ex := parser.Extract(files, lw.cfg.BuildParseBudget(0))
+	printSkippedFiles(ex.Skipped)
+	printParseErrors(ex.Errors)
+
+	links := make(map[string][]checker.Link, len(files))
+	for _, f := range files {
+		links[f] = nil
+	}
+	for _, link := range FilterParserLinks(ex.Links, lw.urlFilter) {
+		links[link.FilePath] = append(links[link.FilePath], link)
+	}
+	return links
+}
+
+// printChanges prints the results of the links new to each changed file,
+// and how many URLs it no longer links to, and reports whether anything was
+// printed. Links that work are only listed with --all. With onlyListed, as
+// for the first check, files without links to list aren't mentioned.
+func (lw *linkWatcher) printChanges(changes []watch.Change, onlyListed bool) bool {
+	printed := false
+	for _, change := range changes {
+		lw.suggester.AddSuggestions(change.Added)
+		var entries []term.Entry
+		for _, r := range change.Added {
+			if watchShowAll || r.IsDead() || r.IsWarning() {
+				entries = append(entries, resultEntry(r))
+			}
+		}
+		if len(entries) == 0 && (onlyListed || len(change.Added) == 0 && len(change.Dropped) == 0) {
+			continue
+		}
+		printed = true
+
+		stamp := time.Now().Format(time.TimeOnly)
+		switch {
+		case change.Removed:
+			fmt.Printf("[%s] %s removed\n", stamp, change.File)
+		case len(change.Dropped) > 0:
+			fmt.Printf("[%s] %s: %d new link(s), %d URL(s) no longer linked\n",
+				stamp, change.File, len(change.Added), len(change.Dropped))
+		default:
+			fmt.Printf("[%s] %s: %d new link(s)\n", stamp, change.File, len(change.Added))
+		}
+		console.Entries(entries)
+	}
+	return printed
+}
+
+// printSummary prints the counts of all watched links by status.
+func (lw *linkWatcher) printSummary() {
+	summary := checker.Summarize(lw.index.Results())
+	fmt.Printf("%d file(s), %d link(s): %s, %s, %s\n\n", lw.index.Files(), summary.Total,
+		summaryCount(term.KindAlive, summary.Alive, "alive"),
+		summaryCount(term.KindWarning, summary.WarningsCount(), "warnings"),
+		summaryCount(term.KindDead, summary.Dead+summary.Errors, "dead"))
+}
@@ -0,0 +1,95 @@
+// Package anchor suggests the anchor a broken #fragment most likely meant,
+// such as a heading that was renamed or renumbered.
+package anchor
+
+import (
+	"slices"
+	"strings"
+)
+
+// githubPrefix is the prefix GitHub gives the ids of rendered headings;
+// fragments name them without it.
+const githubPrefix = "user-content-"
+
+// minSimilarity is how similar a fragment and an anchor must be for the
+// anchor to be suggested, from 0 (nothing in common) to 1 (the same).
+const minSimilarity = 0.5
+
+// minPrefix is the shortest fragment an anchor may be suggested for only
+// because it starts with the fragment ("config" for "configuration").
+const minPrefix = 4
+
+// Closest returns the anchor closest to fragment, ignoring case and
+// whether words are joined with "-" or "_". Anchors are candidates if
+// they're similar enough or start with the fragment. It returns false if
+// no anchor is close enough, or if several are equally close.
+func Closest(fragment string, anchors map[string]bool) (string, bool) {
+	target := normalize(fragment)
+	if target == "" {
+		return "", false
+	}
+
+	candidates := make([]string, 0, len(anchors))
+	for a := range anchors {
+		candidates = append(candidates, strings.TrimPrefix(a, githubPrefix))
+	}
+	slices.Sort(candidates)
+	candidates = slices.Compact(candidates)
+
+	var best string
+	bestScore, tied := -1.0, false
+	for _, c := range candidates {
+		norm := normalize(c)
+		score := similarity(target, norm)
+		if score < minSimilarity && (len(target) < minPrefix || !strings.HasPrefix(norm, target)) {
+			continue
+		}
+		switch {
+		case score > bestScore:
+			best, bestScore, tied = c, score, false
+		case score == bestScore && normalize(best) != norm:
+			tied = true
+		}
+	}
+	if bestScore < 0 || tied || best == fragment {
+		return "", false
+	}
+	return best, true
+}
+
+// normalize lowercases an anchor and joins its words with "-".
+func normalize(s string) string {
+	return strings.NewReplacer("_", "-", " ", "-").Replace(strings.ToLower(strings.TrimSpace(s)))
+}
+
+// similarity returns 1 minus the edit distance between a and b, relative
+// to the longer one.
+func similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(distance(ra, rb))/float64(longest)
+}
+
+// distance returns the Levenshtein distance between a and b.
+func distance(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,55 @@
+package anchor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClosest(t *testing.T) {
+	t.Parallel()
+
+	anchors := map[string]bool{
+		"installation":              true,
+		"install-from-source":       true,
+		"step-3-configure":          true,
+		"user-content-api-overview": true,
+		"faq":                       true,
+		"Usage_Notes":               true,
+	}
+
+	tests := []struct {
+		fragment string
+		want     string
+		wantOK   bool
+	}{
+		{"instalation", "installation", true},
+		{"install", "installation", true},
+		{"step-2-configure", "step-3-configure", true},
+		{"api_overview", "api-overview", true},
+		{"usage-notes", "Usage_Notes", true},
+		{"Installation", "installation", true},
+		{"api", "", false},
+		{"license", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := Closest(tt.fragment, anchors)
+		assert.Equal(t, tt.wantOK, ok, tt.fragment)
+		assert.Equal(t, tt.want, got, tt.fragment)
+	}
+
+	// Equally close anchors are ambiguous
+	_, ok := Closest("step-2", map[string]bool{"step-1": true, "step-3": true})
+	assert.False(t, ok)
+}
+
+func TestSimilarity(t *testing.T) {
+	t.Parallel()
+
+	assert.InDelta(t, 1.0, similarity("", ""), 0.001)
+	assert.InDelta(t, 1.0, similarity("abc", "abc"), 0.001)
+	assert.InDelta(t, 0.0, similarity("abc", "xyz"), 0.001)
+	assert.InDelta(t, 0.75, similarity("café", "cafe"), 0.001)
+}
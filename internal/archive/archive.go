@@ -0,0 +1,323 @@
+// Package archive reads the files of zip and tar archives in memory, so
+// documentation bundles can be checked without extracting them. A file
+// inside an archive is named by the archive's path and its own, joined by
+// Separator, such as docs.zip!/guide/intro.md.
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path"
+	"strings"
+	"sync"
+)
+
+// Separator joins an archive's path and the path of a file inside it.
+const Separator = "!/"
+
+// MaxSize is the largest total size of an archive's files, uncompressed,
+// that is read into memory at once.
+const MaxSize = 512 << 20
+
+// suffixes are the file name suffixes of supported archives.
+var suffixes = []string{".zip", ".tar", ".tar.gz", ".tgz"}
+
+// IsArchive reports whether path names a supported archive, by its suffix.
+func IsArchive(path string) bool {
+	lower := strings.ToLower(path)
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(lower, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Join returns the name of the file member inside the archive at archivePath.
+func Join(archivePath, member string) string {
+	return archivePath + Separator + member
+}
+
+// Split splits the name of a file inside an archive into the archive's
+// path and the file's path in it. ok is false for other paths.
+func Split(name string) (archivePath, member string, ok bool) {
+	for i := 0; ; {
+		j := strings.Index(name[i:], Separator)
+		if j < 0 {
+			return "", "", false
+		}
+		i += j
+		if IsArchive(name[:i]) {
+			return name[:i], name[i+len(Separator):], true
+		}
+		i += len(Separator)
+	}
+}
+
+// bundle is the content of the files of an archive read by Load.
+type bundle struct {
+	files map[string][]byte // By path in the archive
+	refs  int               // Loads not released yet
+}
+
+var (
+	mu      sync.Mutex
+	bundles = map[string]*bundle{} // Loaded archives, by path
+)
+
+// member is a regular file in an archive.
+type member struct {
+	open func() (io.ReadCloser, error)
+	name string // Path in the archive
+	size int64  // Uncompressed size, as the archive states it
+}
+
+// Files returns the names of the regular files in the archive at
+// archivePath, in archive order, each joined to archivePath. Nothing is read
+// but the archive's listing.
+func Files(archivePath string) ([]string, error) {
+	var names []string
+	seen := map[string]bool{}
+	err := walk(archivePath, func(m member) error {
+		if !seen[m.name] {
+			seen[m.name] = true
+			names = append(names, Join(archivePath, m.name))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// Load reads the files of the archive at archivePath that keep reports true
+// for, by path in the archive and size, into memory, so ReadFile and Size
+// answer for them without reading the archive again. They stay in memory
+// until every Load of the archive is released with Release.
+func Load(archivePath string, keep func(name string, size int64) bool) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if b, ok := bundles[archivePath]; ok {
+		b.refs++
+		return nil
+	}
+	b := &bundle{files: map[string][]byte{}, refs: 1}
+	var total int64
+	err := walk(archivePath, func(m member) error {
+		if !keep(m.name, m.size) {
+			return nil
+		}
+		content, err := m.read(MaxSize - total)
+		if err != nil {
+			return err
+		}
+		total += int64(len(content) - len(b.files[m.name]))
+		b.files[m.name] = content
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	bundles[archivePath] = b
+	return nil
+}
+
+// Release drops the files read by Load from memory, once every Load of the
+// archive at archivePath is released.
+func Release(archivePath string) {
+	mu.Lock()
+	defer mu.Unlock()
+	if b, ok := bundles[archivePath]; ok {
+		if b.refs--; b.refs <= 0 {
+			delete(bundles, archivePath)
+		}
+	}
+}
+
+// loaded returns the content of a file read by Load.
+func loaded(archivePath, name string) ([]byte, bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	b, ok := bundles[archivePath]
+	if !ok {
+		return nil, false
+	}
+	content, ok := b.files[name]
+	return content, ok
+}
+
+// ReadFile returns the content of a file, which may be inside an archive.
+// A file in an archive that wasn't read by Load is read on its own.
+func ReadFile(name string) ([]byte, error) {
+	archivePath, memberName, ok := Split(name)
+	if !ok {
+		return os.ReadFile(name) //nolint:gosec // Path comes from the scanner
+	}
+	if content, ok := loaded(archivePath, memberName); ok {
+		return content, nil
+	}
+
+	// The last file by a name wins, as when extracting the archive
+	var content []byte
+	found := false
+	err := walk(archivePath, func(m member) error {
+		if m.name != memberName {
+			return nil
+		}
+		var err error
+		content, err = m.read(MaxSize)
+		found = true
+		return err
+	})
+	switch {
+	case err != nil:
+		return nil, err
+	case !found:
+		return nil, fmt.Errorf("open %s: %w", name, os.ErrNotExist)
+	}
+	return content, nil
+}
+
+// Size returns the size of a file, which may be inside an archive.
+func Size(name string) (int64, error) {
+	archivePath, memberName, ok := Split(name)
+	if !ok {
+		info, err := os.Stat(name)
+		if err != nil {
+			return 0, err
+		}
+		return info.Size(), nil
+	}
+	if content, ok := loaded(archivePath, memberName); ok {
+		return int64(len(content)), nil
+	}
+
+	size := int64(-1)
+	err := walk(archivePath, func(m member) error {
+		if m.name == memberName {
+			size = m.size
+		}
+		return nil
+	})
+	switch {
+	case err != nil:
+		return 0, err
+	case size < 0:
+		return 0, fmt.Errorf("stat %s: %w", name, os.ErrNotExist)
+	}
+	return size, nil
+}
+
+// errTooLarge is returned for archive files exceeding MaxSize.
+var errTooLarge = fmt.Errorf("files exceed %d bytes", MaxSize)
+
+// read returns the content of a file, failing if it's over limit bytes.
+func (m member) read(limit int64) ([]byte, error) {
+	rc, err := m.open()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rc.Close() }()
+
+	content, err := io.ReadAll(io.LimitReader(rc, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(content)) > limit {
+		return nil, errTooLarge
+	}
+	return content, nil
+}
+
+// walk calls fn for each regular file of the archive at archivePath, in
+// archive order, skipping those whose path is unsafe.
+func walk(archivePath string, fn func(m member) error) error {
+	var err error
+	if strings.HasSuffix(strings.ToLower(archivePath), ".zip") {
+		err = walkZip(archivePath, fn)
+	} else {
+		err = walkTar(archivePath, fn)
+	}
+	if err != nil {
+		return fmt.Errorf("reading archive %s: %w", archivePath, err)
+	}
+	return nil
+}
+
+// cleanName returns the path of a file in an archive, and false if it
+// would land outside the archive.
+func cleanName(name string) (string, bool) {
+	name = path.Clean(strings.TrimPrefix(name, "./"))
+	if name == "." || strings.HasPrefix(name, "../") || path.IsAbs(name) {
+		return "", false
+	}
+	return name, true
+}
+
+// walkZip walks the regular files of a zip archive.
+func walkZip(archivePath string, fn func(m member) error) error {
+	r, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = r.Close() }()
+
+	for _, f := range r.File {
+		name, ok := cleanName(f.Name)
+		if !ok || !f.Mode().IsRegular() {
+			continue
+		}
+		size := int64(min(f.UncompressedSize64, math.MaxInt64))
+		if err := fn(member{name: name, size: size, open: f.Open}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// walkTar walks the regular files of a tar archive, gzip-compressed if its
+// name ends in .gz or .tgz.
+func walkTar(archivePath string, fn func(m member) error) error {
+	f, err := os.Open(archivePath) //nolint:gosec // Path comes from the user
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	var r io.Reader = f
+	if lower := strings.ToLower(archivePath); strings.HasSuffix(lower, ".gz") || strings.HasSuffix(lower, ".tgz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = gz.Close() }()
+		r = gz
+	}
+
+	tr := tar.NewReader(r)
+	open := func() (io.ReadCloser, error) { return io.NopCloser(tr), nil }
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		name, ok := cleanName(header.Name)
+		if !ok || header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err := fn(member{name: name, size: header.Size, open: open}); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testFiles are the files written to test archives, in order.
+var testFiles = [][2]string{
+	{"guide/intro.md", "[Docs](https://example.com)\n"},
+	{"./index.html", `<a href="https://example.com/home">Home</a>`},
+	{"../escape.md", "outside the archive"},
+}
+
+func writeZip(t *testing.T, path string) {
+	t.Helper()
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	w := zip.NewWriter(f)
+	_, err = w.Create("guide/")
+	require.NoError(t, err)
+	for _, file := range testFiles {
+		fw, err := w.Create(file[0])
+		require.NoError(t, err)
+		_, err = fw.Write([]byte(file[1]))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+}
+
+func writeTarGz(t *testing.T, path string) {
+	t.Helper()
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	gz := gzip.NewWriter(f)
+	w := tar.NewWriter(gz)
+	require.NoError(t, w.WriteHeader(&tar.Header{Name: "guide/", Typeflag: tar.TypeDir, Mode: 0o755}))
+	for _, file := range testFiles {
+		header := &tar.Header{Name: file[0], Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(file[1]))}
+		require.NoError(t, w.WriteHeader(header))
+		_, err = w.Write([]byte(file[1]))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	require.NoError(t, gz.Close())
+	require.NoError(t, f.Close())
+}
+
+func TestIsArchive(t *testing.T) {
+	t.Parallel()
+
+	for _, path := range []string{"docs.zip", "docs.tar", "docs.tar.gz", "DOCS.TGZ"} {
+		assert.True(t, IsArchive(path), path)
+	}
+	for _, path := range []string{"docs", "docs.gz", "docs.md", "zip"} {
+		assert.False(t, IsArchive(path), path)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	t.Parallel()
+
+	archivePath, member, ok := Split("out/docs.zip!/guide/intro.md")
+	require.True(t, ok)
+	assert.Equal(t, "out/docs.zip", archivePath)
+	assert.Equal(t, "guide/intro.md", member)
+	assert.Equal(t, "out/docs.zip!/guide/intro.md", Join(archivePath, member))
+
+	// The separator only counts after an archive's name
+	archivePath, member, ok = Split("wow!/docs.tgz!/a!/b.md")
+	require.True(t, ok)
+	assert.Equal(t, "wow!/docs.tgz", archivePath)
+	assert.Equal(t, "a!/b.md", member)
+
+	_, _, ok = Split("docs/guide/intro.md")
+	assert.False(t, ok)
+}
+
+func TestFilesAndReadFile(t *testing.T) {
+	t.Parallel()
+
+	for name, write := range map[string]func(*testing.T, string){
+		"docs.zip":    writeZip,
+		"docs.tar.gz": writeTarGz,
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			path := filepath.Join(t.TempDir(), name)
+			write(t, path)
+
+			// Directories and paths leaving the archive are skipped
+			files, err := Files(path)
+			require.NoError(t, err)
+			assert.Equal(t, []string{Join(path, "guide/intro.md"), Join(path, "index.html")}, files)
+
+			content, err := ReadFile(files[0])
+			require.NoError(t, err)
+			assert.Equal(t, testFiles[0][1], string(content))
+
+			size, err := Size(files[1])
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(testFiles[1][1])), size)
+
+			_, err = ReadFile(Join(path, "missing.md"))
+			assert.ErrorIs(t, err, os.ErrNotExist)
+		})
+	}
+
+	t.Run("NotAnArchive", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "broken.zip")
+		require.NoError(t, os.WriteFile(path, []byte("not a zip"), 0o600))
+		_, err := Files(path)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "reading archive")
+	})
+}
+
+func TestLoadAndRelease(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "docs.tar.gz")
+	writeTarGz(t, path)
+
+	var listed []string
+	require.NoError(t, Load(path, func(name string, _ int64) bool {
+		listed = append(listed, name)
+		return name == "guide/intro.md"
+	}))
+	assert.Equal(t, []string{"guide/intro.md", "index.html"}, listed)
+
+	// Only kept files are read into memory; others are read on their own
+	_, ok := loaded(path, "guide/intro.md")
+	assert.True(t, ok)
+	_, ok = loaded(path, "index.html")
+	assert.False(t, ok)
+	content, err := ReadFile(Join(path, "index.html"))
+	require.NoError(t, err)
+	assert.Equal(t, testFiles[1][1], string(content))
+
+	// Loads are counted, so the files stay until the last is released
+	require.NoError(t, Load(path, func(string, int64) bool { return false }))
+	Release(path)
+	_, ok = loaded(path, "guide/intro.md")
+	assert.True(t, ok)
+	Release(path)
+	_, ok = loaded(path, "guide/intro.md")
+	assert.False(t, ok)
+
+	// The archive is read again once released, so changes are seen
+	require.NoError(t, os.Remove(path))
+	_, err = ReadFile(Join(path, "guide/intro.md"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
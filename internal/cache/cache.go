@@ -0,0 +1,310 @@
+// Package cache persists link check results between runs.
+// A URL checked recently enough is reported from the cache instead of being
+// requested again, which makes repeated checks of the same docs nearly instant.
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/leonardomso/gone/internal/checker"
+)
+
+const (
+	// DefaultTTL is how long a cached result stays fresh.
+	DefaultTTL = 24 * time.Hour
+
+	// DefaultFileName is the cache file name inside the user cache directory.
+	DefaultFileName = "results.json"
+
+	// fileVersion is the cache file format version.
+	fileVersion = 1
+)
+
+// entry is a cached result for a single URL.
+type entry struct {
+	CheckedAt     time.Time  `json:"checked_at"`
+	LastModified  time.Time  `json:"last_modified,omitzero"`
+	Status        string     `json:"status"`
+	Error         string     `json:"error,omitempty"`
+	FinalURL      string     `json:"final_url,omitempty"`
+	Options       string     `json:"options,omitempty"` // Fingerprint of the options of the check
+	Protocol      string     `json:"protocol,omitempty"`
+	Resolver      string     `json:"resolver,omitempty"`
+	Anchor        string     `json:"anchor,omitempty"`
+	ClosestAnchor string     `json:"closest_anchor,omitempty"`
+	RedirectChain []redirect `json:"redirect_chain,omitempty"`
+	Robots        []string   `json:"robots,omitempty"`
+	Hints         []string   `json:"hints,omitempty"`
+	StatusCode    int        `json:"status_code"`
+	FinalStatus   int        `json:"final_status,omitempty"`
+	Age           int64      `json:"age_seconds,omitempty"`
+}
+
+// redirect is a cached redirect hop.
+type redirect struct {
+	URL        string `json:"url"`
+	StatusCode int    `json:"status_code"`
+}
+
+// file is the on-disk cache format.
+type file struct {
+	Entries map[string]entry `json:"entries"`
+	Version int              `json:"version"`
+}
+
+// Cache is a persistent store of check results keyed by URL.
+// It implements checker.ResultCache and is safe for concurrent use. A result
+// only answers for the options it was checked with: runs use For to get the
+// view of the cache for their options.
+type Cache struct {
+	entries map[string]entry
+	now     func() time.Time
+	path    string
+	ttl     time.Duration
+	mu      sync.Mutex
+}
+
+// DefaultPath returns the cache file location in the user cache directory.
+func DefaultPath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("locating user cache directory: %w", err)
+	}
+	return filepath.Join(dir, "gone", DefaultFileName), nil
+}
+
+// Open loads the cache at path. A missing file yields an empty cache.
+// Results older than ttl are treated as missing; a non-positive ttl uses DefaultTTL.
+func Open(path string, ttl time.Duration) (*Cache, error) {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+
+	c := &Cache{
+		entries: map[string]entry{},
+		now:     time.Now,
+		path:    path,
+		ttl:     ttl,
+	}
+
+	data, err := os.ReadFile(path) //nolint:gosec // Path comes from config or the user cache dir
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return c, nil
+		}
+		return nil, fmt.Errorf("reading cache: %w", err)
+	}
+
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("parsing cache %s: %w", path, err)
+	}
+
+	// An older format is simply discarded; the cache refills on the next run
+	if f.Version == fileVersion && f.Entries != nil {
+		c.entries = f.Entries
+	}
+	return c, nil
+}
+
+// Path returns the cache file location.
+func (c *Cache) Path() string {
+	return c.path
+}
+
+// Len returns the number of cached URLs, including expired ones.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// Get returns the cached result for url if it is still fresh and was
+// stored without options, through Put.
+func (c *Cache) Get(url string) (checker.Result, bool) {
+	return c.get(url, "")
+}
+
+// get returns the cached result for url if it is still fresh and was checked
+// with the options of the given fingerprint.
+func (c *Cache) get(url, options string) (checker.Result, bool) {
+	c.mu.Lock()
+	e, ok := c.entries[url]
+	c.mu.Unlock()
+
+	if !ok || e.Options != options || c.now().Sub(e.CheckedAt) > c.ttl {
+		return checker.Result{}, false
+	}
+
+	return e.result(url)
+}
+
+// result rebuilds the check result stored in the entry.
+func (e entry) result(url string) (checker.Result, bool) {
+	status, ok := checker.ParseLinkStatus(e.Status)
+	if !ok {
+		return checker.Result{}, false
+	}
+
+	result := checker.Result{
+		Link:          checker.Link{URL: url},
+		Status:        status,
+		StatusCode:    e.StatusCode,
+		Error:         e.Error,
+		FinalURL:      e.FinalURL,
+		FinalStatus:   e.FinalStatus,
+		Protocol:      e.Protocol,
+		Resolver:      e.Resolver,
+		Anchor:        e.Anchor,
+		ClosestAnchor: e.ClosestAnchor,
+		Robots:        e.Robots,
+		Hints:         e.Hints,
+		LastModified:  e.LastModified,
+		Age:           time.Duration(e.Age) * time.Second,
+	}
+	for _, r := range e.RedirectChain {
+		result.RedirectChain = append(result.RedirectChain, checker.Redirect{URL: r.URL, StatusCode: r.StatusCode})
+	}
+	return result, true
+}
+
+// Put stores a result. Transient failures (network errors, 5xx, 429) are
+// not cached so that they are retried on the next run.
+func (c *Cache) Put(result checker.Result) {
+	c.put(result, "")
+}
+
+// put stores a result checked with the options of the given fingerprint,
+// replacing the result of the URL for any other options.
+func (c *Cache) put(result checker.Result, options string) {
+	if !cacheable(result) {
+		return
+	}
+
+	e := newEntry(result, c.now())
+	e.Options = options
+
+	c.mu.Lock()
+	c.entries[result.Link.URL] = e
+	c.mu.Unlock()
+}
+
+// newEntry converts a check result for storage.
+func newEntry(result checker.Result, checkedAt time.Time) entry {
+	e := entry{
+		CheckedAt:     checkedAt,
+		Status:        result.Status.String(),
+		StatusCode:    result.StatusCode,
+		Error:         result.Error,
+		FinalURL:      result.FinalURL,
+		FinalStatus:   result.FinalStatus,
+		Protocol:      result.Protocol,
+		Resolver:      result.Resolver,
+		Anchor:        result.Anchor,
+		ClosestAnchor: result.ClosestAnchor,
+		Robots:        result.Robots,
+		Hints:         result.Hints,
+		LastModified:  result.LastModified,
+		Age:           int64(result.Age / time.Second),
+	}
+	for _, r := range result.RedirectChain {
+		e.RedirectChain = append(e.RedirectChain, redirect{URL: r.URL, StatusCode: r.StatusCode})
+	}
+	return e
+}
+
+// Save writes the cache to disk, dropping expired entries.
+// The file is replaced atomically so a crash never leaves it half-written.
+func (c *Cache) Save() error {
+	c.mu.Lock()
+	now := c.now()
+	for url, e := range c.entries {
+		if now.Sub(e.CheckedAt) > c.ttl {
+			delete(c.entries, url)
+		}
+	}
+	data, err := json.Marshal(file{Version: fileVersion, Entries: c.entries})
+	c.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("encoding cache: %w", err)
+	}
+
+	dir := filepath.Dir(c.path)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("creating cache directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(c.path)+".*")
+	if err != nil {
+		return fmt.Errorf("writing cache: %w", err)
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), c.path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("writing cache: %w", err)
+	}
+	return nil
+}
+
+// For returns the view of the cache for runs with opts. It only serves
+// results checked with options of the same fingerprint, such as the same
+// headers and body checks, and stores results under that fingerprint.
+func (c *Cache) For(opts checker.Options) checker.ResultCache {
+	return view{cache: c, options: opts.Fingerprint()}
+}
+
+// WriteOnly returns a view of the cache for runs with opts that stores
+// results but never serves them, so every URL is checked again. Used to
+// refresh the whole cache.
+func (c *Cache) WriteOnly(opts checker.Options) checker.ResultCache {
+	return view{cache: c, options: opts.Fingerprint(), writeOnly: true}
+}
+
+// view is a checker.ResultCache over the results of one set of options.
+type view struct {
+	cache     *Cache
+	options   string
+	writeOnly bool
+}
+
+// Get implements checker.ResultCache.
+func (v view) Get(url string) (checker.Result, bool) {
+	if v.writeOnly {
+		return checker.Result{}, false
+	}
+	return v.cache.get(url, v.options)
+}
+
+// Put implements checker.ResultCache.
+func (v view) Put(result checker.Result) {
+	v.cache.put(result, v.options)
+}
+
+// cacheable reports whether a result is stable enough to reuse.
+func cacheable(result checker.Result) bool {
+	switch {
+	case result.Status == checker.StatusError, result.Status == checker.StatusDuplicate:
+		return false
+	case result.TLS != nil:
+		// Certificates get fixed or renewed, and whether a problem was
+		// skipped depends on --insecure, so certificate problems are
+		// checked again on every run.
+		return false
+	default:
+		return result.StatusCode < http.StatusInternalServerError &&
+			result.StatusCode != http.StatusTooManyRequests
+	}
+}
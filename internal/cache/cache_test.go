@@ -0,0 +1,157 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/leonardomso/gone/internal/checker"
+)
+
+func TestOpen_MissingFile(t *testing.T) {
+	t.Parallel()
+	c, err := Open(filepath.Join(t.TempDir(), "nested", "results.json"), 0)
+	require.NoError(t, err)
+	assert.Equal(t, 0, c.Len())
+	assert.Equal(t, DefaultTTL, c.ttl)
+}
+
+func TestOpen_Invalid(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "results.json")
+	require.NoError(t, os.WriteFile(path, []byte("not json"), 0o600))
+
+	_, err := Open(path, time.Hour)
+	require.Error(t, err)
+}
+
+func TestCache_PutGetSave(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "nested", "results.json")
+
+	c, err := Open(path, time.Hour)
+	require.NoError(t, err)
+
+	c.Put(checker.Result{
+		Link:          checker.Link{URL: "https://example.com/old", FilePath: "README.md"},
+		Status:        checker.StatusRedirect,
+		StatusCode:    301,
+		FinalURL:      "https://example.com/new",
+		FinalStatus:   200,
+		RedirectChain: []checker.Redirect{{URL: "https://example.com/old", StatusCode: 301}},
+	})
+	c.Put(checker.Result{Link: checker.Link{URL: "https://example.com/gone"}, Status: checker.StatusDead, StatusCode: 404})
+	require.NoError(t, c.Save())
+
+	reopened, err := Open(path, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 2, reopened.Len())
+
+	got, ok := reopened.Get("https://example.com/old")
+	require.True(t, ok)
+	assert.Equal(t, checker.StatusRedirect, got.Status)
+	assert.Equal(t, "https://example.com/new", got.FinalURL)
+	assert.Equal(t, 200, got.FinalStatus)
+	require.Len(t, got.RedirectChain, 1)
+	assert.Empty(t, got.Link.FilePath, "location is not cached")
+
+	got, ok = reopened.Get("https://example.com/gone")
+	require.True(t, ok)
+	assert.Equal(t, checker.StatusDead, got.Status)
+
+	_, ok = reopened.Get("https://example.com/unknown")
+	assert.False(t, ok)
+}
+
+func TestCache_SkipsTransientResults(t *testing.T) {
+	t.Parallel()
+	c, err := Open(filepath.Join(t.TempDir(), "results.json"), time.Hour)
+	require.NoError(t, err)
+
+	c.Put(checker.Result{Link: checker.Link{URL: "https://a.example"}, Status: checker.StatusError, Error: "timeout"})
+	c.Put(checker.Result{Link: checker.Link{URL: "https://b.example"}, Status: checker.StatusDead, StatusCode: 503})
+	c.Put(checker.Result{Link: checker.Link{URL: "https://c.example"}, Status: checker.StatusDead, StatusCode: 429})
+	c.Put(checker.Result{Link: checker.Link{URL: "https://d.example"}, Status: checker.StatusDuplicate, StatusCode: 200})
+
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestCache_Expiry(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "results.json")
+	c, err := Open(path, time.Hour)
+	require.NoError(t, err)
+
+	now := time.Now()
+	c.now = func() time.Time { return now }
+	c.Put(checker.Result{Link: checker.Link{URL: "https://example.com"}, Status: checker.StatusAlive, StatusCode: 200})
+
+	_, ok := c.Get("https://example.com")
+	assert.True(t, ok)
+
+	c.now = func() time.Time { return now.Add(2 * time.Hour) }
+	_, ok = c.Get("https://example.com")
+	assert.False(t, ok)
+
+	// Expired entries are dropped on save
+	require.NoError(t, c.Save())
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestCache_WriteOnly(t *testing.T) {
+	t.Parallel()
+	c, err := Open(filepath.Join(t.TempDir(), "results.json"), time.Hour)
+	require.NoError(t, err)
+
+	w := c.WriteOnly(checker.DefaultOptions())
+	w.Put(checker.Result{Link: checker.Link{URL: "https://example.com"}, Status: checker.StatusAlive, StatusCode: 200})
+
+	_, ok := w.Get("https://example.com")
+	assert.False(t, ok, "write-only view never serves results")
+
+	_, ok = c.For(checker.DefaultOptions()).Get("https://example.com")
+	assert.True(t, ok)
+}
+
+func TestCache_For(t *testing.T) {
+	t.Parallel()
+	c, err := Open(filepath.Join(t.TempDir(), "results.json"), time.Hour)
+	require.NoError(t, err)
+
+	opts := checker.DefaultOptions()
+	plain := c.For(opts)
+	plain.Put(checker.Result{Link: checker.Link{URL: "https://example.com"}, Status: checker.StatusAlive, StatusCode: 200})
+
+	_, ok := c.For(checker.DefaultOptions()).Get("https://example.com")
+	assert.True(t, ok, "same options share results")
+
+	// Results checked without a body check don't answer for runs with one
+	for name, other := range map[string]checker.Options{
+		"anchors":  opts.WithCheckAnchors(true),
+		"soft 404": opts.WithDetectSoft404(true),
+		"headers":  opts.WithHeaders(map[string]string{"Authorization": "Bearer x"}),
+		"override": opts.WithAcceptStatus([]int{403}),
+	} {
+		_, ok := c.For(other).Get("https://example.com")
+		assert.False(t, ok, name)
+	}
+
+	// Speed settings don't change the result
+	_, ok = c.For(opts.WithConcurrency(2).WithTimeout(time.Minute)).Get("https://example.com")
+	assert.True(t, ok)
+
+	// The latest check of a URL replaces the result for other options
+	anchors := c.For(opts.WithCheckAnchors(true))
+	anchors.Put(checker.Result{
+		Link: checker.Link{URL: "https://example.com"}, Status: checker.StatusAlive, StatusCode: 200,
+	})
+	_, ok = anchors.Get("https://example.com")
+	assert.True(t, ok)
+	_, ok = plain.Get("https://example.com")
+	assert.False(t, ok)
+	assert.Equal(t, 1, c.Len())
+}
@@ -0,0 +1,252 @@
+package cache
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/leonardomso/gone/internal/checker"
+)
+
+// sessionVersion is the session journal format version.
+const sessionVersion = 1
+
+// sessionHeader is the first line of a session journal.
+type sessionHeader struct {
+	StartedAt time.Time `json:"started_at"`
+	Options   string    `json:"options"` // Fingerprint of the options the run checks with
+	Pending   []string  `json:"pending"` // Unique URLs queued when the run started
+	Version   int       `json:"version"`
+}
+
+// sessionRecord is a journal line holding one completed check.
+type sessionRecord struct {
+	URL string `json:"url"`
+	entry
+}
+
+// Session journals the results of a run as they complete, one JSON line per
+// URL, so a run that is interrupted (crash, CI timeout) can be resumed by
+// checking only the URLs that were still pending.
+//
+// Session implements checker.ResultCache: Get serves every journaled result,
+// whatever its status, and falls back to the wrapped cache, if any. Put
+// appends to the journal and forwards to the wrapped cache.
+type Session struct {
+	next      checker.ResultCache
+	results   map[string]entry
+	file      *os.File
+	startedAt time.Time
+	path      string
+	options   string
+	ttl       time.Duration
+	queued    int
+	resumed   int
+	mu        sync.Mutex
+}
+
+// SessionPath returns the journal location for a run over root, next to the
+// cache file at cachePath. Each root gets its own journal.
+func SessionPath(cachePath, root string) string {
+	if abs, err := filepath.Abs(root); err == nil {
+		root = abs
+	}
+	sum := sha256.Sum256([]byte(root))
+	return filepath.Join(filepath.Dir(cachePath), "session-"+hex.EncodeToString(sum[:6])+".jsonl")
+}
+
+// OpenSession resumes the journal at path, or starts a new one queuing urls.
+// A journal is only resumed by a run with the same options (see
+// checker.Options.Fingerprint) that starts within ttl of it; a non-positive
+// ttl uses DefaultTTL. next, if not nil, is consulted for URLs the journal
+// doesn't have.
+func OpenSession(
+	path string,
+	urls []string,
+	options string,
+	ttl time.Duration,
+	next checker.ResultCache,
+) (*Session, error) {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	s := &Session{
+		next:    next,
+		results: map[string]entry{},
+		path:    path,
+		options: options,
+		ttl:     ttl,
+	}
+
+	data, err := os.ReadFile(path) //nolint:gosec // Path is derived from the cache location
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return s, s.start(urls)
+	case err != nil:
+		return nil, fmt.Errorf("reading session: %w", err)
+	}
+
+	// A crash can leave a partial last line; it is dropped and overwritten
+	valid := bytes.LastIndexByte(data, '\n') + 1
+	if !s.load(data[:valid]) {
+		// Unreadable, from another version, checked with other options,
+		// or stale: start over
+		return s, s.start(urls)
+	}
+
+	s.file, err = os.OpenFile(path, os.O_WRONLY, 0o600) //nolint:gosec // Path is derived from the cache location
+	if err != nil {
+		return nil, fmt.Errorf("opening session: %w", err)
+	}
+	if err := s.file.Truncate(int64(valid)); err != nil {
+		_ = s.file.Close()
+		return nil, fmt.Errorf("opening session: %w", err)
+	}
+	if _, err := s.file.Seek(int64(valid), 0); err != nil {
+		_ = s.file.Close()
+		return nil, fmt.Errorf("opening session: %w", err)
+	}
+	return s, nil
+}
+
+// load reads a journal, reporting whether its header was valid and matches
+// the session's options and ttl.
+func (s *Session) load(data []byte) bool {
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 0, 64*1024), 16<<20)
+
+	if !sc.Scan() {
+		return false
+	}
+	var header sessionHeader
+	if err := json.Unmarshal(sc.Bytes(), &header); err != nil || header.Version != sessionVersion ||
+		header.Options != s.options || time.Since(header.StartedAt) > s.ttl {
+		return false
+	}
+	s.startedAt = header.StartedAt
+	s.queued = len(header.Pending)
+
+	for sc.Scan() {
+		var rec sessionRecord
+		if err := json.Unmarshal(sc.Bytes(), &rec); err != nil || rec.URL == "" {
+			continue
+		}
+		s.results[rec.URL] = rec.entry
+	}
+	s.resumed = len(s.results)
+	return true
+}
+
+// start creates a new journal with a header listing the queued URLs.
+func (s *Session) start(urls []string) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o750); err != nil {
+		return fmt.Errorf("creating session directory: %w", err)
+	}
+
+	file, err := os.Create(s.path)
+	if err != nil {
+		return fmt.Errorf("creating session: %w", err)
+	}
+	s.file = file
+	s.startedAt = time.Now()
+	s.queued = len(urls)
+
+	line, err := json.Marshal(sessionHeader{
+		Version:   sessionVersion,
+		StartedAt: s.startedAt,
+		Options:   s.options,
+		Pending:   urls,
+	})
+	if err != nil {
+		return fmt.Errorf("encoding session: %w", err)
+	}
+	if _, err := s.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("writing session: %w", err)
+	}
+	return nil
+}
+
+// Path returns the journal location.
+func (s *Session) Path() string {
+	return s.path
+}
+
+// Resumed returns how many URLs an earlier, interrupted run already checked.
+// It is zero for a new session.
+func (s *Session) Resumed() int {
+	return s.resumed
+}
+
+// Queued returns how many URLs were pending when the session started.
+func (s *Session) Queued() int {
+	return s.queued
+}
+
+// StartedAt returns when the session was first started.
+func (s *Session) StartedAt() time.Time {
+	return s.startedAt
+}
+
+// Get implements checker.ResultCache.
+func (s *Session) Get(url string) (checker.Result, bool) {
+	s.mu.Lock()
+	e, ok := s.results[url]
+	s.mu.Unlock()
+
+	if ok {
+		return e.result(url)
+	}
+	if s.next != nil {
+		return s.next.Get(url)
+	}
+	return checker.Result{}, false
+}
+
+// Put implements checker.ResultCache. Results are written to disk right away,
+// so they survive the process being killed.
+func (s *Session) Put(result checker.Result) {
+	if s.next != nil {
+		s.next.Put(result)
+	}
+	if result.Status == checker.StatusDuplicate {
+		return
+	}
+
+	e := newEntry(result, time.Now())
+	line, err := json.Marshal(sessionRecord{URL: result.Link.URL, entry: e})
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.results[result.Link.URL] = e
+	// A failed write only means the URL is checked again after an interruption
+	_, _ = s.file.Write(append(line, '\n'))
+}
+
+// Close closes the journal, keeping it on disk for a later resume.
+func (s *Session) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
+
+// Finish closes and removes the journal once the run has completed.
+func (s *Session) Finish() error {
+	if err := s.Close(); err != nil {
+		return fmt.Errorf("closing session: %w", err)
+	}
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("removing session: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/leonardomso/gone/internal/checker"
+)
+
+func TestSessionPath(t *testing.T) {
+	t.Parallel()
+	cachePath := filepath.Join("cache", "results.json")
+
+	a := SessionPath(cachePath, "docs")
+	b := SessionPath(cachePath, "site")
+
+	assert.Equal(t, "cache", filepath.Dir(a))
+	assert.True(t, strings.HasPrefix(filepath.Base(a), "session-"))
+	assert.True(t, strings.HasSuffix(a, ".jsonl"))
+	assert.NotEqual(t, a, b)
+	assert.Equal(t, a, SessionPath(cachePath, "docs"))
+}
+
+func TestSession_NewPutGet(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "nested", "session.jsonl")
+	urls := []string{"https://example.com/a", "https://example.com/b"}
+
+	s, err := OpenSession(path, urls, "", 0, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, s.Resumed())
+	assert.Equal(t, 2, s.Queued())
+	assert.False(t, s.StartedAt().IsZero())
+
+	_, ok := s.Get("https://example.com/a")
+	assert.False(t, ok)
+
+	s.Put(checker.Result{
+		Link:       checker.Link{URL: "https://example.com/a"},
+		Status:     checker.StatusDead,
+		StatusCode: 404,
+	})
+	// Duplicates carry no result of their own
+	s.Put(checker.Result{Link: checker.Link{URL: "https://example.com/b"}, Status: checker.StatusDuplicate})
+
+	got, ok := s.Get("https://example.com/a")
+	require.True(t, ok)
+	assert.Equal(t, checker.StatusDead, got.Status)
+	assert.Equal(t, 404, got.StatusCode)
+
+	_, ok = s.Get("https://example.com/b")
+	assert.False(t, ok)
+	require.NoError(t, s.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"pending"`)
+	assert.Contains(t, lines[1], "https://example.com/a")
+}
+
+func TestSession_Resume(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+	urls := []string{"https://example.com/a", "https://example.com/b", "https://example.com/c"}
+
+	s, err := OpenSession(path, urls, "", 0, nil)
+	require.NoError(t, err)
+	started := s.StartedAt()
+	s.Put(checker.Result{Link: checker.Link{URL: "https://example.com/a"}, Status: checker.StatusAlive, StatusCode: 200})
+	s.Put(checker.Result{Link: checker.Link{URL: "https://example.com/b"}, Status: checker.StatusError, Error: "timeout"})
+	require.NoError(t, s.Close())
+
+	// Simulate a crash in the middle of writing a line
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"url":"https://example.com/c","sta`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	s, err = OpenSession(path, nil, "", 0, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, s.Resumed())
+	assert.Equal(t, 3, s.Queued())
+	assert.True(t, started.Equal(s.StartedAt()))
+
+	got, ok := s.Get("https://example.com/b")
+	require.True(t, ok)
+	assert.Equal(t, checker.StatusError, got.Status)
+	assert.Equal(t, "timeout", got.Error)
+	_, ok = s.Get("https://example.com/c")
+	assert.False(t, ok)
+
+	s.Put(checker.Result{Link: checker.Link{URL: "https://example.com/c"}, Status: checker.StatusAlive, StatusCode: 200})
+	require.NoError(t, s.Close())
+
+	s, err = OpenSession(path, nil, "", 0, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 3, s.Resumed())
+	require.NoError(t, s.Close())
+}
+
+func TestSession_InvalidHeaderStartsOver(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte("not json\n"), 0o600))
+
+	s, err := OpenSession(path, []string{"https://example.com"}, "", 0, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, s.Resumed())
+	assert.Equal(t, 1, s.Queued())
+	require.NoError(t, s.Close())
+}
+
+func TestSession_StartsOverForOtherOptionsOrAge(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+	urls := []string{"https://example.com/a", "https://example.com/b"}
+
+	s, err := OpenSession(path, urls, "opts", time.Hour, nil)
+	require.NoError(t, err)
+	s.Put(checker.Result{Link: checker.Link{URL: "https://example.com/a"}, Status: checker.StatusAlive, StatusCode: 200})
+	require.NoError(t, s.Close())
+
+	tests := []struct {
+		name    string
+		options string
+		ttl     time.Duration
+		resumed int
+	}{
+		{"Same", "opts", time.Hour, 1},
+		{"OtherOptions", "other", time.Hour, 0},
+		{"Stale", "opts", time.Nanosecond, 0},
+	}
+	for _, tt := range tests {
+		// Runs in order: a run starting over replaces the journal
+		s, err := OpenSession(path, urls, tt.options, tt.ttl, nil)
+		require.NoError(t, err, tt.name)
+		assert.Equal(t, tt.resumed, s.Resumed(), tt.name)
+		_, ok := s.Get("https://example.com/a")
+		assert.Equal(t, tt.resumed > 0, ok, tt.name)
+		require.NoError(t, s.Close())
+	}
+}
+
+func TestSession_FallsBackToNext(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	next, err := Open(filepath.Join(dir, "results.json"), time.Hour)
+	require.NoError(t, err)
+	next.Put(checker.Result{Link: checker.Link{URL: "https://example.com/cached"}, Status: checker.StatusAlive, StatusCode: 200})
+
+	s, err := OpenSession(filepath.Join(dir, "session.jsonl"), nil, "", 0, next)
+	require.NoError(t, err)
+
+	got, ok := s.Get("https://example.com/cached")
+	require.True(t, ok)
+	assert.Equal(t, checker.StatusAlive, got.Status)
+
+	s.Put(checker.Result{Link: checker.Link{URL: "https://example.com/new"}, Status: checker.StatusAlive, StatusCode: 200})
+	_, ok = next.Get("https://example.com/new")
+	assert.True(t, ok)
+	require.NoError(t, s.Close())
+}
+
+func TestSession_Finish(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+
+	s, err := OpenSession(path, nil, "", 0, nil)
+	require.NoError(t, err)
+	require.NoError(t, s.Finish())
+
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,136 @@
+package checker
+
+import (
+	"bytes"
+	"context"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	"github.com/leonardomso/gone/internal/anchor"
+)
+
+// maxAnchorPageBytes caps how much of a page is read when looking for anchors.
+// Anchors can be anywhere in the document, so this is larger than maxPageBytes.
+const maxAnchorPageBytes = 8 << 20
+
+// githubLineAnchor matches GitHub's line anchors ("#L10", "#L10-L20", "#L3C5"),
+// which are handled by JavaScript and have no element on the page.
+var githubLineAnchor = regexp.MustCompile(`^L\d+(C\d+)?(-L\d+(C\d+)?)?$`)
+
+// anchorPage holds the anchors of a fetched page. Each page is fetched once
+// per run, however many links point at fragments on it.
+type anchorPage struct {
+	anchors map[string]bool
+	once    sync.Once
+	ok      bool // False if the page couldn't be fetched or isn't HTML
+}
+
+// checkAnchor verifies that the fragment of a working link names an element
+// on the target page, reporting StatusBrokenAnchor if it doesn't, with the
+// closest anchor on the page. Pages that can't be fetched or aren't HTML are
+// left as they are.
+func (c *Checker) checkAnchor(ctx context.Context, result *Result, body *pageBody) {
+	fragment := fragmentOf(result.Link.URL)
+	if !verifiableFragment(fragment) {
+		return
+	}
+
+	anchors, ok := c.pageAnchors(ctx, body)
+	if !ok || hasAnchor(anchors, fragment) {
+		return
+	}
+
+	result.Status = StatusBrokenAnchor
+	result.Anchor = fragment
+	result.ClosestAnchor, _ = anchor.Closest(fragment, anchors)
+}
+
+// pageAnchors returns the anchors of the page body is read from, reading it
+// only if no other link to the page did.
+func (c *Checker) pageAnchors(ctx context.Context, body *pageBody) (map[string]bool, bool) {
+	c.pagesMu.Lock()
+	p, ok := c.anchors[body.url]
+	if !ok {
+		p = &anchorPage{}
+		c.anchors[body.url] = p
+	}
+	c.pagesMu.Unlock()
+
+	p.once.Do(func() {
+		page, err := body.get(ctx, maxAnchorPageBytes)
+		if err != nil || page.StatusCode < 200 || page.StatusCode >= 300 {
+			return
+		}
+		if !strings.Contains(strings.ToLower(page.Header.Get("Content-Type")), "html") {
+			return
+		}
+		p.anchors = htmlAnchors(page.Body)
+		p.ok = true
+	})
+	return p.anchors, p.ok
+}
+
+// htmlAnchors collects the id attributes of all elements and the name
+// attributes of <a> elements in an HTML document.
+func htmlAnchors(body []byte) map[string]bool {
+	anchors := map[string]bool{}
+	z := html.NewTokenizer(bytes.NewReader(body))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return anchors
+		case html.StartTagToken, html.SelfClosingTagToken:
+			tok := z.Token()
+			if id := attr(tok, "id"); id != "" {
+				anchors[id] = true
+			}
+			if tok.DataAtom == atom.A {
+				if name := attr(tok, "name"); name != "" {
+					anchors[name] = true
+				}
+			}
+		}
+	}
+}
+
+// hasAnchor reports whether fragment names one of the anchors. GitHub
+// prefixes the ids of rendered headings with "user-content-" and resolves
+// plain fragments with JavaScript, so the prefixed form also counts.
+func hasAnchor(anchors map[string]bool, fragment string) bool {
+	return anchors[fragment] || anchors["user-content-"+fragment]
+}
+
+// verifiableFragment reports whether a fragment is expected to match an
+// element id. Empty fragments, "#top", client-side routes ("#!/page",
+// "#/page"), text fragments ("#:~:text="), and GitHub line anchors always work
+// or can't be verified from the HTML.
+func verifiableFragment(fragment string) bool {
+	switch {
+	case fragment == "", strings.EqualFold(fragment, "top"):
+		return false
+	case strings.HasPrefix(fragment, "!"), strings.HasPrefix(fragment, "/"), strings.HasPrefix(fragment, ":~:"):
+		return false
+	default:
+		return !githubLineAnchor.MatchString(fragment)
+	}
+}
+
+// fragmentOf returns the decoded fragment of a URL, or "" if it has none.
+func fragmentOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return u.Fragment
+}
+
+// stripFragment returns the URL without its fragment.
+func stripFragment(rawURL string) string {
+	before, _, _ := strings.Cut(rawURL, "#")
+	return before
+}
@@ -0,0 +1,99 @@
+package checker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+// runBudget tracks the MaxDuration and MaxRequests limits of one Check run.
+// It travels in the run's context, so the transport can count requests.
+type runBudget struct {
+	timeUp      error // Cause of the run context when MaxDuration ran out
+	maxRequests int64
+	requests    atomic.Int64
+}
+
+// budgetKey is the context key of the run's budget.
+type budgetKey struct{}
+
+// errRequestBudget fails requests over MaxRequests.
+var errRequestBudget = errors.New("request budget exhausted")
+
+// withBudget returns the context for a run with the budget in opts, and a
+// function that releases it. Without limits, ctx is returned as is.
+func withBudget(ctx context.Context, opts Options) (context.Context, context.CancelFunc) {
+	if opts.MaxDuration <= 0 && opts.MaxRequests <= 0 {
+		return ctx, func() {}
+	}
+	b := &runBudget{maxRequests: int64(opts.MaxRequests)}
+	ctx = context.WithValue(ctx, budgetKey{}, b)
+	if opts.MaxDuration <= 0 {
+		return ctx, func() {}
+	}
+	b.timeUp = fmt.Errorf("time budget of %s exhausted", opts.MaxDuration)
+	return context.WithTimeoutCause(ctx, opts.MaxDuration, b.timeUp)
+}
+
+// budgetFrom returns the budget of the run ctx belongs to, or nil.
+func budgetFrom(ctx context.Context) *runBudget {
+	b, _ := ctx.Value(budgetKey{}).(*runBudget)
+	return b
+}
+
+// take counts a request against the budget and reports whether it may be sent.
+func (b *runBudget) take() bool {
+	return b.maxRequests <= 0 || b.requests.Add(1) <= b.maxRequests
+}
+
+// exhausted returns why the run's budget is used up, or "" if it isn't.
+// Requests already sent count once they are over the limit.
+func (b *runBudget) exhausted(ctx context.Context) string {
+	switch {
+	case b == nil:
+		return ""
+	case b.timeUp != nil && errors.Is(context.Cause(ctx), b.timeUp):
+		return b.timeUp.Error()
+	case b.maxRequests > 0 && b.requests.Load() >= b.maxRequests:
+		return fmt.Sprintf("request budget of %d exhausted", b.maxRequests)
+	default:
+		return ""
+	}
+}
+
+// skip returns the result of a link the budget left no room for.
+func skip(link Link, reason string) Result {
+	return Result{Link: link, Status: StatusSkipped, Error: reason}
+}
+
+// budgetSkipped reports whether a failed check failed because the budget ran
+// out while it was running, rather than because of the link.
+func budgetSkipped(ctx context.Context, result Result) bool {
+	if result.Status != StatusError && result.Status != StatusDead {
+		return false
+	}
+	b := budgetFrom(ctx)
+	if b == nil {
+		return false
+	}
+	if b.timeUp != nil && errors.Is(context.Cause(ctx), b.timeUp) {
+		return true
+	}
+	return strings.Contains(result.Error, errRequestBudget.Error())
+}
+
+// budgetTransport refuses requests once the run's MaxRequests is used up.
+type budgetTransport struct {
+	next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *budgetTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if b := budgetFrom(req.Context()); b != nil && !b.take() {
+		return nil, errRequestBudget
+	}
+	return t.next.RoundTrip(req)
+}
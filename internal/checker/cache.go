@@ -0,0 +1,106 @@
+package checker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// ResultCache stores check results across runs so that recently checked URLs
+// can be reported without new requests. Implementations must be safe for
+// concurrent use and decide for themselves which results are worth keeping.
+type ResultCache interface {
+	// Get returns a stored result for url, if one is still fresh.
+	Get(url string) (Result, bool)
+
+	// Put stores the result of a completed check.
+	Put(result Result)
+}
+
+// applyCache splits links into those that still need checking and results
+// served from the cache. Cached results are attached to the current link.
+func applyCache(cache ResultCache, links []Link) (pending []Link, cached []Result) {
+	pending = make([]Link, 0, len(links))
+	for _, link := range links {
+		result, ok := cache.Get(link.URL)
+		if !ok {
+			pending = append(pending, link)
+			continue
+		}
+		result.Link = link
+		cached = append(cached, result)
+	}
+	return pending, cached
+}
+
+// fingerprint holds the options that change the result of checking a URL.
+// Options that only change how fast or how gently a run goes (concurrency,
+// timeouts, retries, budgets) are left out, as is StaleAfter: staleness is
+// marked after results are cached, so it never is.
+type fingerprint struct {
+	Headers            map[string]string            `json:"headers,omitempty"`
+	DomainHeaders      map[string]map[string]string `json:"domain_headers,omitempty"`
+	DomainMaxRedirects map[string]int               `json:"domain_max_redirects,omitempty"`
+	DomainMethods      map[string]MethodPolicy      `json:"domain_methods,omitempty"`
+	StatusOverrides    map[int]LinkStatus           `json:"status_overrides,omitempty"`
+	CustomProfiles     map[string]map[string]string `json:"custom_profiles,omitempty"`
+	UserAgent          string                       `json:"user_agent,omitempty"`
+	Profile            string                       `json:"profile,omitempty"`
+	IPFamily           string                       `json:"ip_family,omitempty"`
+	ContentRules       [][3]string                  `json:"content_rules,omitempty"`
+	Resolvers          []string                     `json:"resolvers,omitempty"`
+	LoginPatterns      []string                     `json:"login_patterns,omitempty"`
+	MaxRedirects       int                          `json:"max_redirects,omitempty"`
+	RedirectPolicy     RedirectPolicy               `json:"redirect_policy,omitempty"`
+	RedirectWarnHops   int                          `json:"redirect_warn_hops,omitempty"`
+	FetchBodies        bool                         `json:"fetch_bodies,omitempty"`
+	CheckAnchors       bool                         `json:"check_anchors,omitempty"`
+	DetectSoft404      bool                         `json:"detect_soft404,omitempty"`
+	Insecure           bool                         `json:"insecure,omitempty"`
+	HTTP3              bool                         `json:"http3,omitempty"`
+	EnableCookies      bool                         `json:"enable_cookies,omitempty"`
+}
+
+// Fingerprint identifies the options that change the result of checking a
+// URL, such as headers, status overrides, and body checks. A ResultCache
+// should only serve a result to a run whose options have the same
+// fingerprint as the run that stored it.
+func (o Options) Fingerprint() string {
+	f := fingerprint{
+		Headers:            o.Headers,
+		DomainHeaders:      o.DomainHeaders,
+		DomainMaxRedirects: o.DomainMaxRedirects,
+		DomainMethods:      o.DomainMethods,
+		StatusOverrides:    o.StatusOverrides,
+		CustomProfiles:     o.CustomProfiles,
+		UserAgent:          o.UserAgent,
+		Profile:            o.Profile,
+		IPFamily:           o.IPFamily,
+		LoginPatterns:      o.LoginPatterns,
+		MaxRedirects:       o.MaxRedirects,
+		RedirectPolicy:     o.RedirectPolicy,
+		RedirectWarnHops:   o.RedirectWarnHops,
+		FetchBodies:        o.FetchBodies,
+		CheckAnchors:       o.CheckAnchors,
+		DetectSoft404:      o.DetectSoft404,
+		Insecure:           o.Insecure,
+		HTTP3:              o.HTTP3,
+		EnableCookies:      o.EnableCookies,
+	}
+	for _, rule := range o.ContentRules {
+		var mustMatch string
+		if rule.MustMatch != nil {
+			mustMatch = rule.MustMatch.String()
+		}
+		f.ContentRules = append(f.ContentRules, [3]string{rule.Pattern, rule.MustContain, mustMatch})
+	}
+	for _, r := range o.Resolvers {
+		f.Resolvers = append(f.Resolvers, r.Name())
+	}
+
+	// Maps are encoded with sorted keys, so equal options always match.
+	// Plain maps, slices, and scalars can't fail to encode.
+	data, _ := json.Marshal(f)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,252 @@
+package checker
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// cassetteVersion is the file format version written by Cassette.Save.
+	cassetteVersion = 1
+
+	// maxRecordedBodyBytes caps the recorded body of a response at what the
+	// body check reading the most of a page looks at.
+	maxRecordedBodyBytes = max(maxPageBytes, maxContentBytes, maxAnchorPageBytes)
+)
+
+// Interaction is a single recorded HTTP exchange.
+// Transport errors are recorded as Error with no status code. Bodies longer
+// than any check reads are cut, and marked Truncated. TLS is the certificate
+// problem the request failed on, or with Insecure, the one it skipped.
+type Interaction struct {
+	Header    http.Header `json:"header,omitempty"`
+	TLS       *TLSInfo    `json:"tls,omitempty"`
+	Method    string      `json:"method"`
+	URL       string      `json:"url"`
+	Body      string      `json:"body,omitempty"`
+	Error     string      `json:"error,omitempty"`
+	Proto     string      `json:"proto,omitempty"`
+	Status    int         `json:"status,omitempty"`
+	Truncated bool        `json:"truncated,omitempty"`
+}
+
+// Cassette holds recorded HTTP exchanges.
+// A checker records into a cassette with WithRecord and serves responses from
+// one with WithReplay, so a run can be reproduced without network access.
+type Cassette struct {
+	played       map[string]int           // Replay position per request
+	byRequest    map[string][]Interaction // Interactions indexed by request
+	interactions []Interaction
+	mu           sync.Mutex
+}
+
+// cassetteFile is the on-disk cassette format.
+type cassetteFile struct {
+	Interactions []Interaction `json:"interactions"`
+	Version      int           `json:"version"`
+}
+
+// NewCassette creates an empty cassette for recording.
+func NewCassette() *Cassette {
+	return &Cassette{
+		played:    map[string]int{},
+		byRequest: map[string][]Interaction{},
+	}
+}
+
+// LoadCassette reads a cassette previously written by Save.
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // Path comes from the user
+	if err != nil {
+		return nil, fmt.Errorf("reading cassette: %w", err)
+	}
+
+	var file cassetteFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parsing cassette %s: %w", path, err)
+	}
+	if file.Version != cassetteVersion {
+		return nil, fmt.Errorf("unsupported cassette version %d in %s", file.Version, path)
+	}
+
+	c := NewCassette()
+	for _, in := range file.Interactions {
+		c.add(in)
+	}
+	return c, nil
+}
+
+// Save writes the cassette to path as JSON.
+// Interactions are grouped by request so the file is stable across runs,
+// while repeated requests keep the order they were made in.
+func (c *Cassette) Save(path string) error {
+	interactions := c.Interactions()
+	sort.SliceStable(interactions, func(i, j int) bool {
+		return requestKey(interactions[i].Method, interactions[i].URL) <
+			requestKey(interactions[j].Method, interactions[j].URL)
+	})
+
+	data, err := json.MarshalIndent(cassetteFile{
+		Version:      cassetteVersion,
+		Interactions: interactions,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding cassette: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("writing cassette: %w", err)
+	}
+	return nil
+}
+
+// Interactions returns a copy of the recorded interactions in recording order.
+func (c *Cassette) Interactions() []Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Interaction(nil), c.interactions...)
+}
+
+// Len returns the number of recorded interactions.
+func (c *Cassette) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.interactions)
+}
+
+// add appends an interaction to the cassette.
+func (c *Cassette) add(in Interaction) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.interactions = append(c.interactions, in)
+	key := requestKey(in.Method, in.URL)
+	c.byRequest[key] = append(c.byRequest[key], in)
+}
+
+// next returns the next recorded interaction for a request.
+// Repeated requests are answered in recording order; once they run out,
+// the last one is repeated.
+func (c *Cassette) next(method, url string) (Interaction, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := requestKey(method, url)
+	recorded := c.byRequest[key]
+	if len(recorded) == 0 {
+		return Interaction{}, false
+	}
+
+	i := min(c.played[key], len(recorded)-1)
+	c.played[key]++
+	return recorded[i], true
+}
+
+// requestKey identifies a request within a cassette.
+func requestKey(method, url string) string {
+	return method + " " + url
+}
+
+// recordingTransport records every exchange made through the wrapped transport.
+type recordingTransport struct {
+	next     http.RoundTripper
+	cassette *Cassette
+	issues   *tlsIssues // Certificate problems skipped with Insecure, if set
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	in := Interaction{Method: req.Method, URL: req.URL.String()}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		in.Error = err.Error()
+		in.TLS = tlsProblem(err, req.URL.Hostname())
+		t.cassette.add(in)
+		return nil, err
+	}
+	if t.issues != nil && resp.TLS != nil {
+		in.TLS = t.issues.lookup(strings.ToLower(req.URL.Hostname()))
+	}
+
+	// Buffer the body (up to what any body check reads) so it can be both
+	// recorded and handed back to the caller. One more byte tells whether
+	// the body was cut.
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxRecordedBodyBytes+1))
+	_ = resp.Body.Close()
+	if err != nil {
+		in.Error = err.Error()
+		t.cassette.add(in)
+		return nil, err
+	}
+	if len(body) > maxRecordedBodyBytes {
+		body = body[:maxRecordedBodyBytes]
+		in.Truncated = true
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	in.Status = resp.StatusCode
+	in.Proto = resp.Proto
+	in.Header = resp.Header.Clone()
+	in.Body = string(body)
+	t.cassette.add(in)
+
+	return resp, nil
+}
+
+// replayTransport answers requests from a cassette without touching the network.
+// Recorded certificate problems are restored: as errors classified like the
+// originals, or with Insecure, as problems skipped on the host.
+type replayTransport struct {
+	cassette *Cassette
+	issues   *tlsIssues // Certificate problems skipped with Insecure, if set
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	in, ok := t.cassette.next(req.Method, req.URL.String())
+	if !ok {
+		return nil, fmt.Errorf("no recorded response for %s %s", req.Method, req.URL)
+	}
+	switch {
+	case in.TLS != nil && in.Error != "":
+		return nil, &tlsError{info: in.TLS, msg: in.Error}
+	case in.TLS != nil && t.issues == nil:
+		// Recorded with Insecure and replayed without, the response
+		// would never have been received
+		return nil, &tlsError{info: in.TLS, msg: "tls: failed to verify certificate: " + in.TLS.Detail}
+	case in.Error != "":
+		return nil, errors.New(in.Error)
+	case in.TLS != nil:
+		t.issues.record(req.URL.Hostname(), in.TLS)
+	}
+
+	header := in.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+
+	proto, major, minor := "HTTP/1.1", 1, 1
+	if m, n, ok := http.ParseHTTPVersion(in.Proto); ok {
+		proto, major, minor = in.Proto, m, n
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", in.Status, http.StatusText(in.Status)),
+		StatusCode:    in.Status,
+		Proto:         proto,
+		ProtoMajor:    major,
+		ProtoMinor:    minor,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader([]byte(in.Body))),
+		ContentLength: int64(len(in.Body)),
+		Request:       req,
+	}, nil
+}
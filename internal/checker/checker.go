@@ -6,36 +6,62 @@ package checker
 import (
 	"context"
 	"crypto/tls"
-	"errors"
 	"fmt"
 	"io"
+	"iter"
+	"log/slog"
 	"math/rand/v2"
 	"net"
 	"net/http"
+	"net/http/cookiejar"
 	"net/url"
+	"strings"
 	"sync"
 	"time"
+
+	"golang.org/x/net/publicsuffix"
 )
 
 // Checker performs concurrent link checking with configurable options.
 type Checker struct {
-	client *http.Client
-	opts   Options
+	client  *http.Client
+	probe   *ProbeReport           // Result of the last pre-flight probe
+	anchors map[string]*anchorPage // Anchors of pages fetched for CheckAnchors, by URL
+	tls     *tlsIssues             // Certificate problems skipped with Insecure
+	opts    Options
+	probeMu sync.Mutex
+	pagesMu sync.Mutex
 }
 
 // New creates a new Checker with the given options.
 func New(opts Options) *Checker {
-	return &Checker{
-		opts:   opts,
-		client: newHTTPClient(opts),
+	if opts.Logger == nil {
+		opts.Logger = slog.New(slog.DiscardHandler)
+	}
+	c := &Checker{
+		opts:    opts,
+		anchors: map[string]*anchorPage{},
+	}
+	if opts.Insecure {
+		c.tls = newTLSIssues()
 	}
+	c.client = newHTTPClient(opts, c.tls)
+	return c
 }
 
 // newHTTPClient creates an optimized HTTP client for link checking.
 // It configures connection pooling for efficiency, proper timeouts for reliability,
 // and TLS settings for security. The client does NOT follow redirects automatically
-// so that redirect chains can be tracked and analyzed.
-func newHTTPClient(opts Options) *http.Client {
+// so that redirect chains can be tracked and analyzed. With Insecure, certificate
+// verification is skipped and problems are recorded in issues instead. With
+// EnableCookies, the client keeps cookies across requests, scoped by host.
+func newHTTPClient(opts Options, issues *tlsIssues) *http.Client {
+	dial := &dialer{
+		dialer: &net.Dialer{Timeout: opts.Timeout, KeepAlive: 30 * time.Second},
+		dns:    newDNSCache(opts),
+		family: opts.IPFamily,
+	}
+
 	transport := &http.Transport{
 		// Connection pooling - optimized for high concurrency
 		MaxIdleConns:        500,              // Support many concurrent connections
@@ -50,10 +76,7 @@ func newHTTPClient(opts Options) *http.Client {
 		},
 
 		// Timeout layers for different phases - tuned for speed
-		DialContext: (&net.Dialer{
-			Timeout:   opts.Timeout,
-			KeepAlive: 30 * time.Second,
-		}).DialContext,
+		DialContext:           dial.DialContext,
 		TLSHandshakeTimeout:   5 * time.Second, // Faster TLS handshake timeout
 		ResponseHeaderTimeout: opts.Timeout,
 		ExpectContinueTimeout: 1 * time.Second,
@@ -63,14 +86,50 @@ func newHTTPClient(opts Options) *http.Client {
 		ForceAttemptHTTP2:  true, // Enable HTTP/2 for connection multiplexing
 	}
 
-	return &http.Client{
+	if issues != nil {
+		// Opt-in with Insecure; tlsVerifyingTransport still verifies and reports.
+		transport.TLSClientConfig.InsecureSkipVerify = true //nolint:gosec // See above
+	}
+
+	var rt http.RoundTripper = transport
+	if opts.HTTP3 {
+		rt = newHTTP3Transport(transport, dial)
+	}
+	if issues != nil {
+		rt = &tlsVerifyingTransport{next: rt, issues: issues}
+	}
+
+	switch {
+	case opts.Replay != nil:
+		rt = &replayTransport{cassette: opts.Replay, issues: issues}
+	case opts.Record != nil:
+		rt = &recordingTransport{next: rt, cassette: opts.Record, issues: issues}
+	}
+
+	if opts.Hooks.OnRequest != nil {
+		rt = &hooksTransport{next: rt, onRequest: opts.Hooks.OnRequest}
+	}
+	if opts.Logger != nil && opts.Logger.Enabled(context.Background(), slog.LevelDebug) {
+		rt = &hooksTransport{next: rt, onRequest: func(e RequestEvent) { logRequest(opts.Logger, e) }}
+	}
+	if opts.MaxRequests > 0 {
+		rt = &budgetTransport{next: rt}
+	}
+
+	client := &http.Client{
 		Timeout:   opts.Timeout,
-		Transport: transport,
+		Transport: rt,
 		// Don't follow redirects - we handle them manually to track the chain
 		CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
 			return http.ErrUseLastResponse
 		},
 	}
+	if opts.EnableCookies {
+		// cookiejar.New never fails; the public suffix list keeps a host
+		// from setting cookies for a whole registry such as github.io
+		client.Jar, _ = cookiejar.New(&cookiejar.Options{PublicSuffixList: publicsuffix.List})
+	}
+	return client
 }
 
 // CheckAll checks all links and returns results after all are complete.
@@ -84,6 +143,36 @@ func (c *Checker) CheckAll(links []Link) []Result {
 	return results
 }
 
+// CheckIter checks links like Check and yields results as they complete.
+// Breaking out of the loop cancels the checks still running; the iterator
+// returns once the workers have stopped.
+func (c *Checker) CheckIter(ctx context.Context, links []Link) iter.Seq[Result] {
+	return func(yield func(Result) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		results := c.Check(ctx, links)
+		for result := range results {
+			if !yield(result) {
+				cancel()
+				for range results {
+					// Drain so the workers can exit
+				}
+				return
+			}
+		}
+	}
+}
+
+// CheckFunc checks links like Check and calls fn with each result as it
+// completes. It returns when all links have been checked or ctx is canceled.
+// fn is called from a single goroutine.
+func (c *Checker) CheckFunc(ctx context.Context, links []Link, fn func(Result)) {
+	for result := range c.CheckIter(ctx, links) {
+		fn(result)
+	}
+}
+
 // Check checks links concurrently using a worker pool and streams results.
 // URLs are deduplicated - each unique URL is checked once, with duplicate
 // occurrences reported as StatusDuplicate.
@@ -95,6 +184,11 @@ func (c *Checker) Check(ctx context.Context, links []Link) <-chan Result {
 	go func() {
 		defer close(results)
 
+		// Requests run under the run's budget; links it leaves no room for are skipped
+		runCtx, cancel := withBudget(ctx, c.opts)
+		defer cancel()
+		budget := budgetFrom(runCtx)
+
 		// Deduplicate: group links by URL
 		// Pre-allocate with estimated capacity (assume ~70% unique URLs)
 		urlToLinks := make(map[string][]Link, len(links)*7/10)
@@ -112,6 +206,20 @@ func (c *Checker) Check(ctx context.Context, links []Link) <-chan Result {
 			uniqueLinks = append(uniqueLinks, urlToLinks[u][0])
 		}
 
+		// Fresh results from earlier runs don't need a request
+		var skipped []Result
+		if c.opts.Cache != nil {
+			uniqueLinks, skipped = applyCache(c.opts.Cache, uniqueLinks)
+		}
+
+		// Optional pre-flight probe: skip dead hosts, defer rate-limited ones
+		if c.opts.ProbeHosts {
+			report := c.ProbeHosts(runCtx, uniqueLinks)
+			var unreachable []Result
+			uniqueLinks, unreachable = applyProbe(report, uniqueLinks)
+			skipped = append(skipped, unreachable...)
+		}
+
 		// Store primary results for duplicates
 		primaryResults := make(map[string]*Result, len(urlOrder))
 		var resultsMu sync.Mutex
@@ -126,6 +234,10 @@ func (c *Checker) Check(ctx context.Context, links []Link) <-chan Result {
 		for range c.opts.Concurrency {
 			wg.Go(func() {
 				for link := range jobs {
+					if reason := budget.exhausted(runCtx); reason != "" {
+						primaryChan <- skip(link, reason)
+						continue
+					}
 					select {
 					case <-ctx.Done():
 						primaryChan <- Result{
@@ -134,13 +246,31 @@ func (c *Checker) Check(ctx context.Context, links []Link) <-chan Result {
 							Error:  "check canceled",
 						}
 					default:
-						result := c.checkWithRetry(ctx, link)
+						start := time.Now()
+						result := c.checkWithRetry(runCtx, link)
+						result.Duration = time.Since(start)
+						if budgetSkipped(runCtx, result) {
+							result = skip(link, budget.exhausted(runCtx))
+						}
+						if c.opts.Cache != nil && result.Status != StatusSkipped {
+							c.opts.Cache.Put(result)
+						}
 						primaryChan <- result
 					}
 				}
 			})
 		}
 
+		// Cached links and links on unreachable hosts already have their results
+		wg.Go(func() {
+			for _, r := range skipped {
+				if budgetSkipped(runCtx, r) {
+					r = skip(r.Link, budget.exhausted(runCtx))
+				}
+				primaryChan <- r
+			}
+		})
+
 		// Send jobs to workers
 		go func() {
 		sendLoop:
@@ -161,6 +291,9 @@ func (c *Checker) Check(ctx context.Context, links []Link) <-chan Result {
 		}()
 
 		for result := range primaryChan {
+			// Staleness depends on this run's threshold, so it is never cached
+			c.markStale(&result)
+
 			// Store as primary result
 			resultsMu.Lock()
 			resultCopy := result
@@ -171,7 +304,7 @@ func (c *Checker) Check(ctx context.Context, links []Link) <-chan Result {
 			occurrences := urlToLinks[result.Link.URL]
 
 			// Emit primary result (first occurrence)
-			results <- result
+			c.emit(results, result)
 
 			// Emit duplicate results for additional occurrences
 			for i := 1; i < len(occurrences); i++ {
@@ -181,7 +314,13 @@ func (c *Checker) Check(ctx context.Context, links []Link) <-chan Result {
 					Status:      StatusDuplicate,
 					DuplicateOf: &resultCopy,
 				}
-				results <- dupResult
+				if c.opts.ExpandDuplicates {
+					dupResult = result
+					dupResult.Link = occurrences[i]
+					dupResult.Status = StatusDuplicate
+					dupResult.DuplicateOf = &resultCopy
+				}
+				c.emit(results, dupResult)
 			}
 		}
 	}()
@@ -190,19 +329,39 @@ func (c *Checker) Check(ctx context.Context, links []Link) <-chan Result {
 }
 
 // checkWithRetry attempts to check a link with exponential backoff retry.
+// The result records how many attempts it took and the time spent backing off.
 func (c *Checker) checkWithRetry(ctx context.Context, link Link) Result {
 	var lastResult Result
+	var backoff time.Duration
 
 	for attempt := 0; attempt <= c.opts.MaxRetries; attempt++ {
+		// A retry would only be refused or cut short
+		if attempt > 0 && budgetFrom(ctx).exhausted(ctx) != "" {
+			return lastResult
+		}
+
+		// Replayed responses don't need time to recover, so skip the backoff
+		var delay time.Duration
+		if attempt > 0 && c.opts.Replay == nil {
+			delay = backoffDelay(attempt)
+		}
 		if attempt > 0 {
+			c.opts.Logger.Info("retrying link", "url", link.URL, "retry", attempt, "delay", delay)
+		}
+		if attempt > 0 && c.opts.Hooks.OnRetry != nil {
+			c.opts.Hooks.OnRetry(RetryEvent{Link: link, Attempt: attempt, Delay: delay, Previous: lastResult})
+		}
+
+		if delay > 0 {
 			// Exponential backoff with jitter
 			// Using time.NewTimer instead of time.After to prevent memory leak
 			// time.After creates a timer not GC'd until it fires, which leaks if context cancels first
-			delay := backoffDelay(attempt)
 			timer := time.NewTimer(delay)
+			waitStart := time.Now()
 			select {
 			case <-timer.C:
 				// Timer fired normally
+				backoff += delay
 			case <-ctx.Done():
 				// Clean up timer to prevent leak
 				if !timer.Stop() {
@@ -212,14 +371,20 @@ func (c *Checker) checkWithRetry(ctx context.Context, link Link) Result {
 					}
 				}
 				return Result{
-					Link:   link,
-					Status: StatusError,
-					Error:  "check canceled during retry",
+					Link:     link,
+					Status:   StatusError,
+					Error:    "check canceled during retry",
+					Attempts: attempt,
+					Backoff:  backoff + time.Since(waitStart),
 				}
 			}
 		}
 
+		start := time.Now()
 		result := c.checkSingle(ctx, link)
+		result.Attempts = attempt + 1
+		result.Backoff = backoff
+		logAttempt(c.opts.Logger, result, time.Since(start))
 
 		// Success or non-retryable - return immediately
 		if result.Status == StatusAlive || !isRetryable(result) {
@@ -236,6 +401,18 @@ func (c *Checker) checkWithRetry(ctx context.Context, link Link) Result {
 	return lastResult
 }
 
+// logAttempt logs the outcome of one attempt at checking a link.
+func logAttempt(log *slog.Logger, r Result, elapsed time.Duration) {
+	args := []any{"url", r.Link.URL, "attempt", r.Attempts, "status", r.Status.Label(), "duration", elapsed}
+	if r.StatusCode > 0 {
+		args = append(args, "code", r.StatusCode)
+	}
+	if r.Error != "" {
+		args = append(args, "error", r.Error)
+	}
+	log.Info("checked link", args...)
+}
+
 // backoffDelay calculates delay for retry with exponential backoff and jitter.
 // Uses math/rand/v2 which is auto-seeded and sufficient for non-cryptographic jitter.
 func backoffDelay(attempt int) time.Duration {
@@ -277,31 +454,62 @@ func isRetryable(result Result) bool {
 	return false
 }
 
-// checkSingle performs a single check on a link (HEAD with GET fallback).
+// checkSingle performs a single check on a link: through a resolver's API
+// when one handles it, otherwise HEAD with GET fallback, unless the host has
+// another method policy.
 func (c *Checker) checkSingle(ctx context.Context, link Link) Result {
+	if result, ok := c.resolve(ctx, link); ok {
+		return result
+	}
+
 	result := Result{Link: link}
+	policy := c.methodPolicy(link.URL)
 
-	// Try HEAD first (faster, no body)
-	statusCode, err := c.doRequest(ctx, http.MethodHead, link.URL, false)
+	// Try HEAD first (faster, no body), unless the host is forced to GET
+	method := http.MethodHead
+	if policy == MethodGet {
+		method = http.MethodGet
+	}
+	profile := c.profile()
+	statusCode, info, err := c.doRequest(ctx, method, link.URL, profile)
 
 	// If HEAD fails with 405 or 501, try GET
-	if statusCode == http.StatusMethodNotAllowed || statusCode == http.StatusNotImplemented {
-		statusCode, err = c.doRequest(ctx, http.MethodGet, link.URL, false)
+	headRejected := statusCode == http.StatusMethodNotAllowed || statusCode == http.StatusNotImplemented
+	if policy == MethodAuto && headRejected {
+		statusCode, info, err = c.doRequest(ctx, http.MethodGet, link.URL, profile)
+	}
+	result.Timing = info.Timing
+	if profile != ProfileDefault {
+		result.Profile = profile
 	}
 
 	if err != nil {
 		result.Status = StatusError
 		result.Error = err.Error()
+		if tlsInfo := tlsProblem(err, hostOf(link.URL)); tlsInfo != nil {
+			result.Status = StatusTLSError
+			result.TLS = tlsInfo
+		}
 		return result
 	}
 
+	result.Protocol = info.Proto
+
 	result.StatusCode = statusCode
 
 	// Determine status based on response code
+	override, overridden := c.opts.StatusOverrides[statusCode]
 	switch {
+	case overridden:
+		result.Status = override
+		if override == StatusAlive {
+			recordLastModified(&result, info.Header)
+		}
+
 	case statusCode >= 200 && statusCode < 300:
 		// 2xx - alive
 		result.Status = StatusAlive
+		recordLastModified(&result, info.Header)
 
 	case statusCode >= 300 && statusCode < 400:
 		// 3xx - follow redirect chain
@@ -309,13 +517,25 @@ func (c *Checker) checkSingle(ctx context.Context, link Link) Result {
 		result.RedirectChain = chain
 		result.FinalURL = finalURL
 		result.FinalStatus = finalStatus
+		finalOverride, finalOverridden := c.opts.StatusOverrides[finalStatus]
 
 		switch {
 		case err != nil:
 			result.Status = StatusDead
 			result.Error = err.Error()
+			if tlsInfo := tlsProblem(err, hostOf(finalURL)); tlsInfo != nil {
+				result.Status = StatusTLSError
+				result.TLS = tlsInfo
+			}
+		case finalOverridden && finalOverride == StatusAlive:
+			result.Status = c.classifyRedirect(&result)
+		case finalOverridden:
+			result.Status = finalOverride
+		case c.isLoginURL(finalURL):
+			// The target hides behind a login wall; whatever it returns is the login page
+			result.Status = StatusLoginRequired
 		case finalStatus >= 200 && finalStatus < 300:
-			result.Status = StatusRedirect // Warning - redirect works
+			result.Status = c.classifyRedirect(&result) // Warning - redirect works
 		case finalStatus == 403:
 			// Final destination is blocked, try with browser headers
 			result.Status = c.handleBlockedFinal(ctx, finalURL, &result)
@@ -332,16 +552,32 @@ func (c *Checker) checkSingle(ctx context.Context, link Link) Result {
 		result.Status = StatusDead
 	}
 
+	// The body checks share one fetch of the page, made by the first that needs it
+	body := c.newPageBody(&result)
+	if len(c.opts.ContentRules) > 0 && (result.IsAlive() || result.IsRedirect()) {
+		c.checkContent(ctx, &result, body)
+	}
+	if c.opts.DetectSoft404 && (result.IsAlive() || result.IsRedirect()) {
+		c.detectSoft404(ctx, &result, body)
+	}
+	if c.opts.FetchBodies && (result.IsAlive() || result.IsRedirect()) {
+		c.inspectPage(ctx, &result, body)
+	}
+	if c.opts.CheckAnchors && (result.IsAlive() || result.IsRedirect()) {
+		c.checkAnchor(ctx, &result, body)
+	}
+	c.applyInsecureTLS(&result)
+
 	return result
 }
 
-// handleBlocked tries to access a 403 URL with browser-like headers.
+// handleBlocked tries to access a 403 URL with the browser profiles.
 func (c *Checker) handleBlocked(ctx context.Context, urlStr string, result *Result) LinkStatus {
-	// Retry with browser-like headers
-	statusCode, err := c.doRequest(ctx, http.MethodGet, urlStr, true)
-	if err == nil && statusCode >= 200 && statusCode < 300 {
+	statusCode, info, ok := c.unblock(ctx, urlStr, result)
+	if ok {
 		// It was just blocking our bot UA
 		result.StatusCode = statusCode
+		recordLastModified(result, info.Header)
 		return StatusAlive
 	}
 	// Still blocked
@@ -350,14 +586,41 @@ func (c *Checker) handleBlocked(ctx context.Context, urlStr string, result *Resu
 
 // handleBlockedFinal handles a redirect chain that ends in 403.
 func (c *Checker) handleBlockedFinal(ctx context.Context, finalURL string, result *Result) LinkStatus {
-	statusCode, err := c.doRequest(ctx, http.MethodGet, finalURL, true)
-	if err == nil && statusCode >= 200 && statusCode < 300 {
+	if statusCode, _, ok := c.unblock(ctx, finalURL, result); ok {
 		result.FinalStatus = statusCode
-		return StatusRedirect // Redirect works with browser headers
+		return c.classifyRedirect(result) // Redirect works with browser headers
 	}
 	return StatusDead // Even with browser headers, it's dead
 }
 
+// unblock retries a URL answered with 403 with each fallback profile until
+// one gets a 2xx, and records that profile in result. When none does, the
+// profiles tried are recorded as a hint.
+func (c *Checker) unblock(ctx context.Context, urlStr string, result *Result) (int, responseInfo, bool) {
+	tried := []string{c.profile()}
+	for _, profile := range blockedFallbacks {
+		if profile == tried[0] {
+			continue
+		}
+		tried = append(tried, profile)
+		statusCode, info, err := c.doRequest(ctx, http.MethodGet, urlStr, profile)
+		if err == nil && statusCode >= 200 && statusCode < 300 {
+			result.Profile = profile
+			return statusCode, info, true
+		}
+	}
+	result.Hints = append(result.Hints, blockedHint(tried))
+	return 0, responseInfo{}, false
+}
+
+// maxRedirectsFor returns the redirect limit for a URL, honoring per-domain overrides.
+func (c *Checker) maxRedirectsFor(rawURL string) int {
+	if n, ok := lookupDomain(c.opts.DomainMaxRedirects, hostOf(rawURL)); ok {
+		return n
+	}
+	return c.opts.MaxRedirects
+}
+
 // followRedirectChain follows redirects and returns the chain, final URL, and final status.
 //
 //nolint:gocritic // Named returns would make this function harder to read
@@ -365,9 +628,12 @@ func (c *Checker) followRedirectChain(ctx context.Context, startURL string) ([]R
 	// Pre-allocate for typical redirect chain (1-3 hops)
 	chain := make([]Redirect, 0, 4)
 	currentURL := startURL
+	maxRedirects := c.maxRedirectsFor(startURL)
 
-	for i := 0; i < c.opts.MaxRedirects; i++ {
+	for range maxRedirects {
+		start := time.Now()
 		statusCode, location, err := c.doRequestGetLocation(ctx, currentURL)
+		elapsed := time.Since(start)
 		if err != nil {
 			return chain, currentURL, 0, err
 		}
@@ -378,7 +644,7 @@ func (c *Checker) followRedirectChain(ctx context.Context, startURL string) ([]R
 		}
 
 		// It's a redirect - record it and continue
-		chain = append(chain, Redirect{URL: currentURL, StatusCode: statusCode})
+		chain = append(chain, Redirect{URL: currentURL, StatusCode: statusCode, Duration: elapsed})
 
 		// Resolve relative URLs
 		nextURL, err := resolveURL(currentURL, location)
@@ -388,7 +654,7 @@ func (c *Checker) followRedirectChain(ctx context.Context, startURL string) ([]R
 		currentURL = nextURL
 	}
 
-	return chain, currentURL, 0, errors.New("too many redirects")
+	return chain, currentURL, 0, fmt.Errorf("too many redirects (limit %d)", maxRedirects)
 }
 
 // resolveURL resolves a potentially relative URL against a base URL.
@@ -404,24 +670,34 @@ func resolveURL(baseURL, ref string) (string, error) {
 	return base.ResolveReference(refURL).String(), nil
 }
 
-// doRequest performs an HTTP request and returns the status code.
-func (c *Checker) doRequest(ctx context.Context, method, urlStr string, useBrowserHeaders bool) (int, error) {
+// responseInfo holds the parts of a response a check looks at besides the status code.
+type responseInfo struct {
+	Header http.Header
+	Proto  string // Negotiated protocol (e.g. "HTTP/2.0")
+	Timing Timing // Also set when the request failed
+}
+
+// doRequest performs an HTTP request with the headers of the named profile
+// and returns the status code along with the response headers, negotiated
+// protocol, and timing.
+//
+//nolint:gocritic // Named returns would make this function harder to read
+func (c *Checker) doRequest(
+	ctx context.Context, method, urlStr, profile string,
+) (int, responseInfo, error) {
 	req, err := http.NewRequestWithContext(ctx, method, urlStr, http.NoBody)
 	if err != nil {
-		return 0, err
+		return 0, responseInfo{}, err
 	}
 
-	// Set headers
-	if useBrowserHeaders {
-		c.setBrowserHeaders(req)
-	} else {
-		req.Header.Set("User-Agent", c.opts.UserAgent)
-		req.Header.Set("Accept", "*/*")
-	}
+	c.setProfileHeaders(req, profile)
+	c.setCustomHeaders(req)
 
-	resp, err := c.client.Do(req)
+	traceCtx, trace := withTimingTrace(ctx)
+	resp, err := c.client.Do(req.WithContext(traceCtx))
+	timing := trace.finish()
 	if err != nil {
-		return 0, err
+		return 0, responseInfo{Timing: timing}, err
 	}
 	defer func() {
 		_ = resp.Body.Close()
@@ -433,20 +709,24 @@ func (c *Checker) doRequest(ctx context.Context, method, urlStr string, useBrows
 		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024)) // 64KB max
 	}
 
-	return resp.StatusCode, nil
+	return resp.StatusCode, responseInfo{Header: resp.Header, Proto: resp.Proto, Timing: timing}, nil
 }
 
 // doRequestGetLocation performs a request and returns status code and Location header.
 //
 //nolint:gocritic // Named returns would make this function harder to read
 func (c *Checker) doRequestGetLocation(ctx context.Context, urlStr string) (int, string, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodHead, urlStr, http.NoBody)
+	method := http.MethodHead
+	if c.methodPolicy(urlStr) == MethodGet {
+		method = http.MethodGet
+	}
+	req, err := http.NewRequestWithContext(ctx, method, urlStr, http.NoBody)
 	if err != nil {
 		return 0, "", err
 	}
 
-	req.Header.Set("User-Agent", c.opts.UserAgent)
-	req.Header.Set("Accept", "*/*")
+	c.setProfileHeaders(req, c.profile())
+	c.setCustomHeaders(req)
 
 	resp, err := c.client.Do(req)
 	if err != nil {
@@ -459,21 +739,21 @@ func (c *Checker) doRequestGetLocation(ctx context.Context, urlStr string) (int,
 	return resp.StatusCode, resp.Header.Get("Location"), nil
 }
 
-// setBrowserHeaders sets headers that mimic a real browser to bypass bot detection.
-// Some servers block requests that don't look like they come from a real browser.
-// These headers include User-Agent, Accept-Language, and Sec-Fetch-* headers
-// that modern browsers send.
-func (*Checker) setBrowserHeaders(req *http.Request) {
-	req.Header.Set("User-Agent", BrowserUserAgent)
-	req.Header.Set("Accept",
-		"text/html,application/xhtml+xml,application/xml;q=0.9,image/webp,*/*;q=0.8")
-	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
-	req.Header.Set("Accept-Encoding", "gzip, deflate, br")
-	req.Header.Set("Connection", "keep-alive")
-	req.Header.Set("Upgrade-Insecure-Requests", "1")
-	req.Header.Set("Sec-Fetch-Dest", "document")
-	req.Header.Set("Sec-Fetch-Mode", "navigate")
-	req.Header.Set("Sec-Fetch-Site", "none")
-	req.Header.Set("Sec-Fetch-User", "?1")
-	req.Header.Set("Cache-Control", "max-age=0")
+// methodPolicy returns the method policy for a URL's host.
+func (c *Checker) methodPolicy(rawURL string) MethodPolicy {
+	policy, _ := lookupDomain(c.opts.DomainMethods, hostOf(rawURL))
+	return policy
+}
+
+// setCustomHeaders sets the configured headers for the request's host,
+// overriding the defaults. Domain headers win over global ones.
+func (c *Checker) setCustomHeaders(req *http.Request) {
+	for name, value := range c.opts.Headers {
+		req.Header.Set(name, value)
+	}
+	if headers, ok := lookupDomain(c.opts.DomainHeaders, strings.ToLower(req.URL.Hostname())); ok {
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+	}
 }
@@ -1,16 +1,28 @@
 package checker
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"log/slog"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
 )
 
 // =============================================================================
@@ -434,6 +446,38 @@ func TestSummarize(t *testing.T) {
 	assert.Equal(t, 1, summary.Duplicates)
 }
 
+func TestSummarize_Breakdown(t *testing.T) {
+	t.Parallel()
+
+	summary := Summarize([]Result{
+		{Link: Link{URL: "http://a.com", Parser: "md", Type: "inline"}, Status: StatusAlive},
+		{Link: Link{URL: "http://b.com", Parser: "md", Type: "image"}, Status: StatusDead},
+		{Link: Link{URL: "http://a.com", Parser: "json", Type: "autolink"}, Status: StatusDuplicate},
+		{Link: Link{URL: "http://c.com"}, Status: StatusAlive},
+	})
+
+	assert.Equal(t, map[string]int{"md": 2, "json": 1}, summary.ByParser)
+	assert.Equal(t, map[string]int{"inline": 1, "image": 1, "autolink": 1}, summary.ByLinkType)
+}
+
+func TestSummarize_Retries(t *testing.T) {
+	t.Parallel()
+
+	primary := Result{Link: Link{URL: "http://a.com"}, Status: StatusAlive, Attempts: 3, Backoff: 3 * time.Second}
+	summary := Summarize([]Result{
+		primary,
+		{Link: Link{URL: "http://b.com"}, Status: StatusDead, Attempts: 2, Backoff: time.Second},
+		{Link: Link{URL: "http://c.com"}, Status: StatusAlive, Attempts: 1},
+		{Link: Link{URL: "http://d.com"}, Status: StatusAlive}, // Cached
+		// Expanded duplicates carry the primary's data but weren't retried again
+		{Link: Link{URL: "http://a.com"}, Status: StatusDuplicate, Attempts: 3, Backoff: 3 * time.Second},
+	})
+
+	assert.Equal(t, 3, summary.Retries)
+	assert.Equal(t, 2, summary.RetriedURLs)
+	assert.Equal(t, 4*time.Second, summary.Backoff)
+}
+
 func TestSummary_HasIssues(t *testing.T) {
 	t.Parallel()
 
@@ -585,6 +629,24 @@ func TestChecker_CheckAll_500ServerError(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, results[0].StatusCode)
 }
 
+func TestChecker_CheckAll_Logger(t *testing.T) {
+	t.Parallel()
+
+	cassette := NewCassette()
+	cassette.add(Interaction{Method: http.MethodHead, URL: "https://flaky.example.com", Status: 503})
+	cassette.add(Interaction{Method: http.MethodHead, URL: "https://flaky.example.com", Status: 200})
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	opts := DefaultOptions().WithMaxRetries(1).WithReplay(cassette).WithLogger(logger)
+	New(opts).CheckAll([]Link{{URL: "https://flaky.example.com"}})
+
+	out := buf.String()
+	assert.Contains(t, out, `msg="checked link"`)
+	assert.Contains(t, out, `msg="retrying link"`)
+	assert.Contains(t, out, "msg=request")
+}
+
 func TestChecker_CheckAll_HeadFallbackToGet(t *testing.T) {
 	t.Parallel()
 
@@ -684,7 +746,10 @@ func TestChecker_CheckAll_RedirectChain(t *testing.T) {
 	require.Len(t, results, 1)
 	assert.Equal(t, StatusRedirect, results[0].Status)
 	assert.Equal(t, serverC.URL, results[0].FinalURL)
-	assert.Len(t, results[0].RedirectChain, 2) // A -> B, B -> C
+	require.Len(t, results[0].RedirectChain, 2) // A -> B, B -> C
+	for _, hop := range results[0].RedirectChain {
+		assert.Positive(t, hop.Duration, "every hop records its latency")
+	}
 }
 
 func TestChecker_CheckAll_TooManyRedirects(t *testing.T) {
@@ -804,6 +869,180 @@ func TestChecker_CheckAll_Duplicates(t *testing.T) {
 	assert.Equal(t, 2, duplicates)
 }
 
+func TestChecker_CheckAll_ExpandDuplicates(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/old" {
+			http.Redirect(w, r, "/gone", http.StatusMovedPermanently)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	links := []Link{
+		{URL: server.URL + "/old", FilePath: "a.md", Line: 1},
+		{URL: server.URL + "/old", FilePath: "b.md", Line: 5},
+	}
+	results := New(DefaultOptions().WithMaxRetries(0).WithExpandDuplicates(true)).CheckAll(links)
+	require.Len(t, results, 2)
+
+	var primary, dup Result
+	for _, r := range results {
+		if r.IsDuplicate() {
+			dup = r
+		} else {
+			primary = r
+		}
+	}
+
+	require.NotNil(t, dup.DuplicateOf)
+	assert.Equal(t, "b.md", dup.Link.FilePath)
+	assert.Equal(t, StatusDead, primary.Status)
+	assert.Equal(t, primary.StatusCode, dup.StatusCode)
+	assert.Equal(t, primary.RedirectChain, dup.RedirectChain)
+	assert.Equal(t, primary.FinalURL, dup.FinalURL)
+	assert.Equal(t, primary.FinalStatus, dup.FinalStatus)
+	assert.Equal(t, primary.Duration, dup.Duration)
+	assert.Positive(t, dup.Duration)
+}
+
+func TestChecker_Hooks(t *testing.T) {
+	t.Parallel()
+
+	var failures atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/flaky":
+			// Fail once, then succeed
+			if failures.Add(1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		case "/old":
+			http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	var (
+		mu       sync.Mutex
+		requests []RequestEvent
+		retries  []RetryEvent
+		results  []Result
+	)
+	hooks := Hooks{
+		OnRequest: func(e RequestEvent) {
+			mu.Lock()
+			defer mu.Unlock()
+			requests = append(requests, e)
+		},
+		OnRetry: func(e RetryEvent) {
+			mu.Lock()
+			defer mu.Unlock()
+			retries = append(retries, e)
+		},
+		OnResult: func(r Result) {
+			results = append(results, r)
+		},
+	}
+
+	links := []Link{
+		{URL: server.URL + "/flaky"},
+		{URL: server.URL + "/old"},
+		{URL: server.URL + "/old"},
+	}
+	// A single retry keeps the backoff to about a second
+	c := New(DefaultOptions().WithMaxRetries(1).WithHooks(hooks))
+	emitted := c.CheckAll(links)
+
+	// OnResult sees exactly what the channel delivers
+	assert.ElementsMatch(t, emitted, results)
+
+	require.Len(t, retries, 1)
+	assert.Equal(t, server.URL+"/flaky", retries[0].Link.URL)
+	assert.Equal(t, 1, retries[0].Attempt)
+	assert.Equal(t, 503, retries[0].Previous.StatusCode)
+	assert.Positive(t, retries[0].Delay)
+
+	// flaky: 2 attempts; old: HEAD plus the redirect chain (old, new)
+	assert.Len(t, requests, 5)
+	for _, e := range requests {
+		require.NoError(t, e.Err)
+		assert.Equal(t, http.MethodHead, e.Method)
+		assert.NotZero(t, e.StatusCode)
+	}
+}
+
+func TestChecker_CheckAll_LoginRequired(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/private":
+			http.Redirect(w, r, "/login?next=/private", http.StatusFound)
+		case "/team":
+			http.Redirect(w, r, "/auth/start", http.StatusFound)
+		case "/moved":
+			http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	links := []Link{{URL: server.URL + "/private"}, {URL: server.URL + "/team"}, {URL: server.URL + "/moved"}}
+
+	results := New(DefaultOptions().WithMaxRetries(0)).CheckAll(links)
+	byURL := map[string]Result{}
+	for _, r := range results {
+		byURL[r.Link.URL] = r
+	}
+	assert.Equal(t, StatusLoginRequired, byURL[server.URL+"/private"].Status)
+	assert.Equal(t, server.URL+"/login?next=/private", byURL[server.URL+"/private"].FinalURL)
+	assert.True(t, byURL[server.URL+"/private"].IsWarning())
+	assert.False(t, byURL[server.URL+"/private"].IsRedirect())
+	assert.Equal(t, StatusRedirect, byURL[server.URL+"/team"].Status)
+	assert.Equal(t, StatusRedirect, byURL[server.URL+"/moved"].Status)
+
+	// Custom patterns replace the defaults
+	opts := DefaultOptions().WithMaxRetries(0).WithLoginPatterns([]string{"/auth/"})
+	results = New(opts).CheckAll(links)
+	byURL = map[string]Result{}
+	for _, r := range results {
+		byURL[r.Link.URL] = r
+	}
+	assert.Equal(t, StatusRedirect, byURL[server.URL+"/private"].Status)
+	assert.Equal(t, StatusLoginRequired, byURL[server.URL+"/team"].Status)
+
+	summary := Summarize(results)
+	assert.Equal(t, 1, summary.LoginRequired)
+	assert.Equal(t, 3, summary.WarningsCount())
+}
+
+func TestResult_Resolved(t *testing.T) {
+	t.Parallel()
+
+	primary := Result{
+		Link:       Link{URL: "https://x.com", FilePath: "a.md", Line: 1},
+		Status:     StatusError,
+		Error:      "connection refused",
+		StatusCode: 0,
+	}
+	dup := Result{Link: Link{URL: "https://x.com", FilePath: "b.md", Line: 2}, Status: StatusDuplicate, DuplicateOf: &primary}
+
+	resolved := dup.Resolved()
+	assert.Equal(t, StatusError, resolved.Status)
+	assert.Equal(t, "connection refused", resolved.Error)
+	assert.Equal(t, "b.md", resolved.Link.FilePath)
+
+	assert.Equal(t, primary, primary.Resolved())
+}
+
 func TestChecker_CheckAll_MultipleDifferentURLs(t *testing.T) {
 	t.Parallel()
 
@@ -858,6 +1097,15 @@ func TestChecker_CheckAll_RetryOn5xx(t *testing.T) {
 	require.Len(t, results, 1)
 	assert.Equal(t, StatusAlive, results[0].Status)
 	assert.GreaterOrEqual(t, atomic.LoadInt32(&attempts), int32(3))
+	assert.Equal(t, 3, results[0].Attempts)
+	// Backoff of 1s and 2s, plus up to 25% jitter each
+	assert.GreaterOrEqual(t, results[0].Backoff, 3*time.Second)
+	assert.Less(t, results[0].Backoff, 4*time.Second)
+
+	summary := Summarize(results)
+	assert.Equal(t, 2, summary.Retries)
+	assert.Equal(t, 1, summary.RetriedURLs)
+	assert.Equal(t, results[0].Backoff, summary.Backoff)
 }
 
 func TestChecker_CheckAll_RetryOn429(t *testing.T) {
@@ -1475,3 +1723,1907 @@ func TestChecker_CheckAll_308PermanentRedirect(t *testing.T) {
 	assert.Equal(t, StatusRedirect, results[0].Status)
 	assert.Equal(t, http.StatusPermanentRedirect, results[0].StatusCode)
 }
+
+// =============================================================================
+// Redirect Depth Tests
+// =============================================================================
+
+func TestChecker_CheckAll_LongRedirectChain(t *testing.T) {
+	t.Parallel()
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/a":
+			http.Redirect(w, r, server.URL+"/b", http.StatusMovedPermanently)
+		case "/b":
+			http.Redirect(w, r, server.URL+"/c", http.StatusFound)
+		case "/c":
+			http.Redirect(w, r, server.URL+"/final", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		warnHops int
+		expected LinkStatus
+	}{
+		{name: "disabled", warnHops: 0, expected: StatusRedirect},
+		{name: "under threshold", warnHops: 3, expected: StatusRedirect},
+		{name: "over threshold", warnHops: 2, expected: StatusLongRedirect},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(DefaultOptions().WithConcurrency(1).WithMaxRetries(0).WithRedirectWarnHops(tt.warnHops))
+			results := c.CheckAll([]Link{{URL: server.URL + "/a"}})
+
+			require.Len(t, results, 1)
+			assert.Equal(t, tt.expected, results[0].Status)
+			assert.Equal(t, 3, results[0].ChainLength())
+			assert.True(t, results[0].IsRedirect())
+			assert.True(t, results[0].IsWarning())
+		})
+	}
+}
+
+func TestChecker_CheckAll_DomainMaxRedirects(t *testing.T) {
+	t.Parallel()
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/a":
+			http.Redirect(w, r, server.URL+"/b", http.StatusMovedPermanently)
+		case "/b":
+			http.Redirect(w, r, server.URL+"/final", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	// httptest servers listen on 127.0.0.1, so the override key is the IP host.
+	opts := DefaultOptions().WithConcurrency(1).WithMaxRetries(0).
+		WithDomainMaxRedirects(map[string]int{"127.0.0.1": 1})
+	results := New(opts).CheckAll([]Link{{URL: server.URL + "/a"}})
+
+	require.Len(t, results, 1)
+	assert.Equal(t, StatusDead, results[0].Status)
+	assert.Contains(t, results[0].Error, "limit 1")
+
+	// Without the override, the global limit allows the chain.
+	results = New(DefaultOptions().WithConcurrency(1).WithMaxRetries(0)).
+		CheckAll([]Link{{URL: server.URL + "/a"}})
+	require.Len(t, results, 1)
+	assert.Equal(t, StatusRedirect, results[0].Status)
+}
+
+func TestOptions_WithDomainMaxRedirects(t *testing.T) {
+	t.Parallel()
+
+	opts := DefaultOptions().
+		WithDomainMaxRedirects(map[string]int{"GitHub.com": 10, "bad.com": 0}).
+		WithDomainMaxRedirects(map[string]int{"docs.example.com": 2})
+
+	assert.Equal(t, map[string]int{"github.com": 10, "docs.example.com": 2}, opts.DomainMaxRedirects)
+}
+
+func TestLookupDomain(t *testing.T) {
+	t.Parallel()
+
+	m := map[string]int{"github.com": 10, "api.github.com": 3}
+
+	tests := []struct {
+		host     string
+		expected int
+		found    bool
+	}{
+		{host: "github.com", expected: 10, found: true},
+		{host: "www.github.com", expected: 10, found: true},
+		{host: "api.github.com", expected: 3, found: true},
+		{host: "v2.api.github.com", expected: 3, found: true},
+		{host: "notgithub.com", found: false},
+		{host: "", found: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			t.Parallel()
+			v, ok := lookupDomain(m, tt.host)
+			assert.Equal(t, tt.found, ok)
+			assert.Equal(t, tt.expected, v)
+		})
+	}
+}
+
+func TestSummarize_LongRedirects(t *testing.T) {
+	t.Parallel()
+
+	summary := Summarize([]Result{
+		{Link: Link{URL: "http://a.com"}, Status: StatusRedirect},
+		{Link: Link{URL: "http://b.com"}, Status: StatusLongRedirect},
+		{Link: Link{URL: "http://c.com"}, Status: StatusBlocked},
+	})
+
+	assert.Equal(t, 1, summary.Redirects)
+	assert.Equal(t, 1, summary.LongRedirects)
+	assert.Equal(t, 3, summary.WarningsCount())
+	assert.True(t, summary.HasIssues())
+	assert.False(t, summary.HasDeadLinks())
+	assert.Equal(t, "long-redirect", StatusLongRedirect.String())
+	assert.Equal(t, "[LONG REDIRECT]", Result{Status: StatusLongRedirect}.StatusDisplay())
+}
+
+// =============================================================================
+// Offline Validation Tests
+// =============================================================================
+
+func TestValidateURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		url     string
+		wantErr string
+	}{
+		{url: "https://example.com/path?q=1#frag"},
+		{url: "http://localhost:8080"},
+		{url: "", wantErr: "empty URL"},
+		{url: "https://exa mple.com", wantErr: "whitespace"},
+		{url: "ftp://example.com", wantErr: "unsupported scheme"},
+		{url: "https://", wantErr: "missing host"},
+		{url: "https://example..com", wantErr: "invalid host"},
+		{url: "https://example.com:99999", wantErr: "invalid port"},
+		{url: "https://example.com:abc", wantErr: "invalid port"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateURL(tt.url)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateOffline(t *testing.T) {
+	t.Parallel()
+
+	links := []Link{
+		{URL: "https://example.com", FilePath: "a.md", Line: 1},
+		{URL: "https://example.com", FilePath: "b.md", Line: 2},
+		{URL: "https://bad host.com", FilePath: "a.md", Line: 3},
+		{URL: "https://bad host.com", FilePath: "c.md", Line: 4},
+		{URL: "https://other.com", FilePath: "c.md", Line: 5},
+	}
+
+	summary := ValidateOffline(links)
+	assert.Equal(t, 5, summary.Total)
+	assert.Equal(t, 3, summary.UniqueURLs)
+	assert.Equal(t, 2, summary.Duplicates)
+	assert.True(t, summary.HasIssues())
+
+	// Every occurrence of a malformed URL is reported.
+	require.Len(t, summary.Malformed, 2)
+	assert.Equal(t, "a.md", summary.Malformed[0].Link.FilePath)
+	assert.Equal(t, "c.md", summary.Malformed[1].Link.FilePath)
+	assert.Equal(t, StatusError, summary.Malformed[0].Status)
+	assert.Contains(t, summary.Malformed[0].Error, "whitespace")
+
+	assert.False(t, ValidateOffline(links[:2]).HasIssues())
+}
+
+// =============================================================================
+// Pre-flight Probe Tests
+// =============================================================================
+
+func TestChecker_CheckAll_HostProbe(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// Grab a free port and close it so connections are refused.
+	dead := httptest.NewServer(http.NotFoundHandler())
+	deadURL := dead.URL
+	dead.Close()
+
+	opts := DefaultOptions().WithMaxRetries(0).WithHostProbe(true)
+	c := New(opts)
+
+	links := []Link{
+		{URL: server.URL + "/a"},
+		{URL: server.URL + "/b"},
+		{URL: deadURL + "/x"},
+		{URL: deadURL + "/y"},
+	}
+	results := c.CheckAll(links)
+	require.Len(t, results, 4)
+
+	byURL := map[string]Result{}
+	for _, r := range results {
+		byURL[r.Link.URL] = r
+	}
+	assert.Equal(t, StatusAlive, byURL[server.URL+"/a"].Status)
+	assert.Equal(t, StatusError, byURL[deadURL+"/x"].Status)
+	assert.Contains(t, byURL[deadURL+"/y"].Error, "pre-flight probe")
+
+	// One probe plus one request per live link.
+	assert.Equal(t, int32(3), requests.Load())
+
+	report, ok := c.ProbeReport()
+	require.True(t, ok)
+	assert.Len(t, report.Hosts, 2)
+	assert.Equal(t, 1, report.Unreachable())
+	assert.Equal(t, 0, report.RateLimited())
+}
+
+func TestChecker_CheckAll_HostProbeRetries(t *testing.T) {
+	t.Parallel()
+
+	cassette := NewCassette()
+	cassette.add(Interaction{Method: http.MethodHead, URL: "https://flaky.example.com/", Error: "connection reset by peer"})
+	cassette.add(Interaction{Method: http.MethodHead, URL: "https://flaky.example.com/", Status: 200})
+	cassette.add(Interaction{Method: http.MethodHead, URL: "https://flaky.example.com/a", Status: 200})
+	cassette.add(Interaction{Method: http.MethodHead, URL: "https://down.example.com/", Error: "no such host"})
+
+	c := New(DefaultOptions().WithMaxRetries(1).WithHostProbe(true).WithReplay(cassette))
+	results := c.CheckAll([]Link{{URL: "https://flaky.example.com/a"}, {URL: "https://down.example.com/b"}})
+	require.Len(t, results, 2)
+
+	byURL := map[string]Result{}
+	for _, r := range results {
+		byURL[r.Link.URL] = r
+	}
+	assert.Equal(t, StatusAlive, byURL["https://flaky.example.com/a"].Status, "a retry of the probe reached the host")
+	assert.Contains(t, byURL["https://down.example.com/b"].Error, "pre-flight probe")
+
+	report, ok := c.ProbeReport()
+	require.True(t, ok)
+	assert.Equal(t, 1, report.Unreachable())
+}
+
+func TestChecker_ProbeReport_NotRun(t *testing.T) {
+	t.Parallel()
+
+	_, ok := New(DefaultOptions()).ProbeReport()
+	assert.False(t, ok)
+}
+
+func TestApplyProbe(t *testing.T) {
+	t.Parallel()
+
+	report := ProbeReport{Hosts: []HostProbe{
+		{Host: "ok.com", Reachable: true, StatusCode: 200},
+		{Host: "slow.com", Reachable: true, RateLimited: true, StatusCode: 429},
+		{Host: "down.com", Error: "no such host"},
+	}}
+	links := []Link{
+		{URL: "https://slow.com/1"},
+		{URL: "https://ok.com/1"},
+		{URL: "https://down.com/1"},
+		{URL: "https://unprobed.com/1"},
+		{URL: "https://ok.com/2"},
+	}
+
+	pending, skipped := applyProbe(report, links)
+
+	urls := make([]string, len(pending))
+	for i, l := range pending {
+		urls[i] = l.URL
+	}
+	assert.Equal(t, []string{
+		"https://ok.com/1", "https://unprobed.com/1", "https://ok.com/2", "https://slow.com/1",
+	}, urls)
+
+	require.Len(t, skipped, 1)
+	assert.Equal(t, "https://down.com/1", skipped[0].Link.URL)
+	assert.Contains(t, skipped[0].Error, "no such host")
+}
+
+// =============================================================================
+// Page Inspection Tests
+// =============================================================================
+
+func TestRobotsDirectives(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		header http.Header
+		body   string
+		want   []string
+	}{
+		{
+			name: "MetaRobots",
+			body: `<html><head><meta name="robots" content="NOINDEX, nofollow"></head><body></body></html>`,
+			want: []string{"noindex", "nofollow"},
+		},
+		{
+			name:   "HeaderAndMetaDeduplicated",
+			header: http.Header{"X-Robots-Tag": []string{"noindex"}},
+			body:   `<head><meta name="googlebot" content="noindex, noarchive"/></head>`,
+			want:   []string{"noindex", "noarchive"},
+		},
+		{
+			name: "UnavailableAfter",
+			body: `<head><meta name="robots" content="unavailable_after: 2025-01-01"></head>`,
+			want: []string{"unavailable_after"},
+		},
+		{
+			name: "IgnoresMetaInBody",
+			body: `<head><title>x</title></head><body><meta name="robots" content="noindex"></body>`,
+			want: nil,
+		},
+		{
+			name: "OtherMetaIgnored",
+			body: `<head><meta name="description" content="noindex"></head>`,
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			header := tt.header
+			if header == nil {
+				header = http.Header{}
+			}
+			assert.Equal(t, tt.want, robotsDirectives(header, []byte(tt.body)))
+		})
+	}
+}
+
+func TestRobotsHint(t *testing.T) {
+	t.Parallel()
+
+	assert.Contains(t, robotsHint([]string{"noindex"}), "noindex")
+	assert.Contains(t, robotsHint([]string{"none"}), "noindex")
+	assert.Contains(t, robotsHint([]string{"unavailable_after"}), "unavailable_after")
+	assert.Empty(t, robotsHint([]string{"nofollow"}))
+	assert.Empty(t, robotsHint(nil))
+}
+
+func TestChecker_CheckAll_FetchBodies(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/stub":
+			_, _ = w.Write([]byte(`<html><head><meta name="robots" content="noindex"></head></html>`))
+		case "/old":
+			http.Redirect(w, r, "/stub", http.StatusMovedPermanently)
+		default:
+			_, _ = w.Write([]byte(`<html><head><title>ok</title></head></html>`))
+		}
+	}))
+	defer server.Close()
+
+	links := []Link{
+		{URL: server.URL + "/stub"},
+		{URL: server.URL + "/old"},
+		{URL: server.URL + "/page"},
+	}
+
+	t.Run("Enabled", func(t *testing.T) {
+		c := New(DefaultOptions().WithFetchBodies(true))
+		byURL := map[string]Result{}
+		for _, r := range c.CheckAll(links) {
+			byURL[r.Link.URL] = r
+		}
+
+		stub := byURL[server.URL+"/stub"]
+		assert.Equal(t, StatusAlive, stub.Status)
+		assert.True(t, stub.IsNoIndex())
+		require.Len(t, stub.Hints, 1)
+
+		// Redirects are inspected at their final destination.
+		old := byURL[server.URL+"/old"]
+		assert.Equal(t, StatusRedirect, old.Status)
+		assert.True(t, old.IsNoIndex())
+
+		page := byURL[server.URL+"/page"]
+		assert.False(t, page.IsNoIndex())
+		assert.Empty(t, page.Hints)
+
+		assert.Equal(t, 2, Summarize(c.CheckAll(links)).NoIndex)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		c := New(DefaultOptions())
+		for _, r := range c.CheckAll(links) {
+			assert.Empty(t, r.Robots)
+			assert.Empty(t, r.Hints)
+		}
+	})
+}
+
+func TestChecker_CheckAll_CheckAnchors(t *testing.T) {
+	t.Parallel()
+
+	var docsFetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/docs":
+			if r.Method == http.MethodGet {
+				docsFetches.Add(1)
+			}
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write([]byte(`<html><body>
+<h2 id="install">Install</h2>
+<a name="legacy"></a>
+<h2 id="user-content-usage">Usage</h2>
+</body></html>`))
+		case "/old":
+			http.Redirect(w, r, "/docs", http.StatusMovedPermanently)
+		case "/data":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	links := []Link{
+		{URL: server.URL + "/docs#install"},
+		{URL: server.URL + "/docs#legacy"},
+		{URL: server.URL + "/docs#usage"},
+		{URL: server.URL + "/docs#missing"},
+		{URL: server.URL + "/docs#usge"},
+		{URL: server.URL + "/docs#L10-L20"},
+		{URL: server.URL + "/old#gone"},
+		{URL: server.URL + "/data#anything"},
+		{URL: server.URL + "/nope#install"},
+	}
+
+	t.Run("Enabled", func(t *testing.T) {
+		c := New(DefaultOptions().WithCheckAnchors(true))
+		byURL := map[string]Result{}
+		for _, r := range c.CheckAll(links) {
+			byURL[r.Link.URL] = r
+		}
+
+		for _, frag := range []string{"install", "legacy", "usage", "L10-L20"} {
+			assert.Equal(t, StatusAlive, byURL[server.URL+"/docs#"+frag].Status, frag)
+		}
+
+		missing := byURL[server.URL+"/docs#missing"]
+		assert.Equal(t, StatusBrokenAnchor, missing.Status)
+		assert.Equal(t, "missing", missing.Anchor)
+		assert.Empty(t, missing.ClosestAnchor)
+		assert.True(t, missing.IsWarning())
+
+		// Misspelled fragments get the closest anchor, without GitHub's prefix
+		typo := byURL[server.URL+"/docs#usge"]
+		assert.Equal(t, StatusBrokenAnchor, typo.Status)
+		assert.Equal(t, "usage", typo.ClosestAnchor)
+
+		// Redirects are checked at their final destination
+		old := byURL[server.URL+"/old#gone"]
+		assert.Equal(t, StatusBrokenAnchor, old.Status)
+		assert.NotEmpty(t, old.RedirectChain)
+
+		// Non-HTML pages can't be verified, and dead pages stay dead
+		assert.Equal(t, StatusAlive, byURL[server.URL+"/data#anything"].Status)
+		assert.Equal(t, StatusDead, byURL[server.URL+"/nope#install"].Status)
+
+		assert.Equal(t, int32(1), docsFetches.Load(), "each page is fetched once")
+		assert.Equal(t, 3, Summarize(c.CheckAll(links)).BrokenAnchors)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		c := New(DefaultOptions())
+		for _, r := range c.CheckAll(links) {
+			assert.NotEqual(t, StatusBrokenAnchor, r.Status)
+		}
+	})
+}
+
+func TestVerifiableFragment(t *testing.T) {
+	t.Parallel()
+
+	for _, f := range []string{"install", "Section-2", "L10x"} {
+		assert.True(t, verifiableFragment(f), f)
+	}
+	for _, f := range []string{"", "top", "TOP", "!/route", "/route", ":~:text=foo", "L10", "L10-L20", "L3C5-L4C1"} {
+		assert.False(t, verifiableFragment(f), f)
+	}
+}
+
+func TestUniqueDead(t *testing.T) {
+	t.Parallel()
+
+	dead := Result{Link: Link{URL: "https://dead.com", FilePath: "a.md", Line: 1}, Status: StatusDead, StatusCode: 404}
+	errored := Result{Link: Link{URL: "https://error.com", FilePath: "a.md", Line: 2}, Status: StatusError, Error: "timeout"}
+	alive := Result{Link: Link{URL: "https://alive.com", FilePath: "a.md", Line: 3}, Status: StatusAlive}
+
+	results := []Result{
+		dead,
+		errored,
+		alive,
+		{Link: Link{URL: "https://dead.com", FilePath: "b.md", Line: 7}, Status: StatusDuplicate, DuplicateOf: &dead},
+		{Link: Link{URL: "https://alive.com", FilePath: "b.md", Line: 8}, Status: StatusDuplicate, DuplicateOf: &alive},
+	}
+
+	unique := UniqueDead(results)
+	require.Len(t, unique, 2)
+
+	// Most referenced first
+	assert.Equal(t, "https://dead.com", unique[0].Result.Link.URL)
+	assert.Equal(t, StatusDead, unique[0].Result.Status)
+	assert.Equal(t, 2, unique[0].Count())
+	assert.Equal(t, "b.md", unique[0].Locations[1].FilePath)
+
+	assert.Equal(t, "https://error.com", unique[1].Result.Link.URL)
+	assert.Equal(t, 1, unique[1].Count())
+
+	assert.Empty(t, UniqueDead([]Result{alive}))
+}
+
+func TestChecker_RecordAndReplay(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/ok", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/gone", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	server := httptest.NewServer(mux)
+
+	links := []Link{
+		{URL: server.URL + "/ok"},
+		{URL: server.URL + "/old"},
+		{URL: server.URL + "/gone"},
+	}
+
+	cassette := NewCassette()
+	recorded := New(DefaultOptions().WithMaxRetries(0).WithRecord(cassette)).CheckAll(links)
+	server.Close()
+	require.Positive(t, cassette.Len())
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	require.NoError(t, cassette.Save(path))
+
+	loaded, err := LoadCassette(path)
+	require.NoError(t, err)
+	assert.Equal(t, cassette.Len(), loaded.Len())
+
+	// The server is gone; replay must reproduce the recorded results
+	replayed := New(DefaultOptions().WithMaxRetries(0).WithReplay(loaded)).CheckAll(links)
+	require.Len(t, replayed, len(recorded))
+	byURL := map[string]Result{}
+	for _, r := range replayed {
+		byURL[r.Link.URL] = r
+	}
+	for _, want := range recorded {
+		got := byURL[want.Link.URL]
+		assert.Equal(t, want.Status, got.Status, want.Link.URL)
+		assert.Equal(t, want.StatusCode, got.StatusCode, want.Link.URL)
+		assert.Equal(t, want.FinalURL, got.FinalURL, want.Link.URL)
+	}
+}
+
+func TestChecker_RecordAndReplay_LargeBodies(t *testing.T) {
+	t.Parallel()
+
+	// The anchor is past the first megabyte, which robots hints read
+	page := `<html><body>` + strings.Repeat("<p>filler</p>", 200_000) + `<h2 id="deep">Deep</h2></body></html>`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(page))
+	}))
+
+	links := []Link{{URL: server.URL + "/docs#deep"}}
+	opts := DefaultOptions().WithMaxRetries(0).WithCheckAnchors(true)
+	cassette := NewCassette()
+	recorded := New(opts.WithRecord(cassette)).CheckAll(links)
+	server.Close()
+	require.Len(t, recorded, 1)
+	assert.Equal(t, StatusAlive, recorded[0].Status)
+
+	for _, in := range cassette.Interactions() {
+		if in.Method == http.MethodGet {
+			assert.Len(t, in.Body, len(page))
+			assert.False(t, in.Truncated)
+		}
+	}
+
+	replayed := New(opts.WithReplay(cassette)).CheckAll(links)
+	require.Len(t, replayed, 1)
+	assert.Equal(t, StatusAlive, replayed[0].Status, "the anchor is still found in the recorded body")
+}
+
+func TestChecker_Replay_MissingAndErrors(t *testing.T) {
+	t.Parallel()
+
+	cassette := NewCassette()
+	cassette.add(Interaction{Method: http.MethodHead, URL: "https://down.example.com", Error: "dial tcp: connection refused"})
+	cassette.add(Interaction{Method: http.MethodHead, URL: "https://flaky.example.com", Status: 503})
+	cassette.add(Interaction{Method: http.MethodHead, URL: "https://flaky.example.com", Status: 200})
+
+	c := New(DefaultOptions().WithConcurrency(1).WithMaxRetries(1).WithReplay(cassette))
+	results := c.CheckAll([]Link{
+		{URL: "https://down.example.com"},
+		{URL: "https://flaky.example.com"},
+		{URL: "https://unrecorded.example.com"},
+	})
+
+	require.Len(t, results, 3)
+	assert.Equal(t, StatusError, results[0].Status)
+	assert.Contains(t, results[0].Error, "connection refused")
+	assert.Equal(t, StatusAlive, results[1].Status, "repeated requests replay in recorded order")
+	assert.Equal(t, StatusError, results[2].Status)
+	assert.Contains(t, results[2].Error, "no recorded response")
+}
+
+func TestLoadCassette_Invalid(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	_, err := LoadCassette(filepath.Join(dir, "missing.json"))
+	require.Error(t, err)
+
+	path := filepath.Join(dir, "bad.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"version": 99}`), 0o600))
+	_, err = LoadCassette(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported cassette version")
+}
+
+// mapCache is an in-memory ResultCache for tests.
+type mapCache struct {
+	results map[string]Result
+	mu      sync.Mutex
+}
+
+func (m *mapCache) Get(url string) (Result, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	r, ok := m.results[url]
+	return r, ok
+}
+
+func (m *mapCache) Put(result Result) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.results[result.Link.URL] = result
+}
+
+func TestChecker_CheckAll_Cache(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cache := &mapCache{results: map[string]Result{
+		"https://cached.example.com": {Status: StatusDead, StatusCode: http.StatusNotFound},
+	}}
+	c := New(DefaultOptions().WithConcurrency(1).WithMaxRetries(0).WithCache(cache))
+
+	results := c.CheckAll([]Link{
+		{URL: "https://cached.example.com", FilePath: "a.md", Line: 3},
+		{URL: "https://cached.example.com", FilePath: "b.md", Line: 7},
+		{URL: server.URL, FilePath: "a.md", Line: 5},
+	})
+
+	require.Len(t, results, 3)
+	assert.Equal(t, int32(1), requests.Load(), "only the uncached URL is requested")
+
+	byStatus := map[LinkStatus][]Result{}
+	for _, r := range results {
+		byStatus[r.Status] = append(byStatus[r.Status], r)
+	}
+	require.Len(t, byStatus[StatusDead], 1)
+	assert.Equal(t, "a.md", byStatus[StatusDead][0].Link.FilePath, "cached result keeps the current location")
+	require.Len(t, byStatus[StatusDuplicate], 1)
+	require.Len(t, byStatus[StatusAlive], 1)
+
+	_, ok := cache.Get(server.URL)
+	assert.True(t, ok, "new results are stored")
+}
+
+func TestOptions_Fingerprint(t *testing.T) {
+	t.Parallel()
+
+	base := DefaultOptions()
+	assert.Equal(t, base.Fingerprint(), DefaultOptions().Fingerprint())
+	assert.NotEqual(t, base.Fingerprint(), base.WithCheckAnchors(true).Fingerprint())
+	assert.NotEqual(t, base.Fingerprint(), base.WithHeaders(map[string]string{"X-Token": "a"}).Fingerprint())
+
+	// Speed, and staleness, which is never cached, don't change results
+	assert.Equal(t, base.Fingerprint(), base.WithConcurrency(1).WithMaxRetries(5).Fingerprint())
+	assert.Equal(t, base.Fingerprint(), base.WithStaleAfter(time.Hour).Fingerprint())
+}
+
+func TestParseLinkStatus(t *testing.T) {
+	t.Parallel()
+	for _, s := range []LinkStatus{StatusAlive, StatusRedirect, StatusBlocked, StatusDead, StatusLongRedirect} {
+		got, ok := ParseLinkStatus(s.String())
+		require.True(t, ok)
+		assert.Equal(t, s, got)
+	}
+	_, ok := ParseLinkStatus("nope")
+	assert.False(t, ok)
+}
+
+// roundTripFunc adapts a function to http.RoundTripper.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestHTTP3Transport_Fallback(t *testing.T) {
+	t.Parallel()
+
+	var h3Calls, tcpCalls atomic.Int32
+	respond := func(proto string) func(*http.Request) (*http.Response, error) {
+		return func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Proto: proto, Body: http.NoBody, Request: req}, nil
+		}
+	}
+	tr := &http3Transport{
+		h3: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			h3Calls.Add(1)
+			if req.URL.Host == "h3.example.com" {
+				return respond("HTTP/3.0")(req)
+			}
+			return nil, errors.New("timeout: no recent network activity")
+		}),
+		tcp: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			tcpCalls.Add(1)
+			return respond("HTTP/2.0")(req)
+		}),
+	}
+
+	do := func(rawURL string) string {
+		req, err := http.NewRequest(http.MethodHead, rawURL, http.NoBody)
+		require.NoError(t, err)
+		resp, err := tr.RoundTrip(req)
+		require.NoError(t, err)
+		return resp.Proto
+	}
+
+	assert.Equal(t, "HTTP/3.0", do("https://h3.example.com/"))
+	assert.Equal(t, "HTTP/2.0", do("https://tcp-only.example.com/"))
+	assert.Equal(t, "HTTP/2.0", do("https://tcp-only.example.com/again"))
+	assert.Equal(t, "HTTP/2.0", do("http://plain.example.com/"))
+
+	assert.Equal(t, int32(2), h3Calls.Load(), "failed hosts and plain http skip HTTP/3")
+	assert.Equal(t, int32(3), tcpCalls.Load())
+}
+
+func TestChecker_CheckAll_RecordsProtocol(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	cassette := NewCassette()
+	results := New(DefaultOptions().WithMaxRetries(0).WithRecord(cassette)).CheckAll([]Link{{URL: server.URL}})
+	require.Len(t, results, 1)
+	assert.Equal(t, "HTTP/1.1", results[0].Protocol)
+
+	// Replayed responses keep the recorded protocol
+	interactions := cassette.Interactions()
+	require.NotEmpty(t, interactions)
+	replay := NewCassette()
+	for _, in := range interactions {
+		in.Proto = "HTTP/2.0"
+		replay.add(in)
+	}
+	results = New(DefaultOptions().WithMaxRetries(0).WithReplay(replay)).CheckAll([]Link{{URL: server.URL}})
+	require.Len(t, results, 1)
+	assert.Equal(t, "HTTP/2.0", results[0].Protocol)
+}
+
+func TestChecker_CheckAll_StaleAfter(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", "Mon, 01 Jan 2024 00:00:00 GMT")
+		switch r.URL.Path {
+		case "/old":
+			w.Header().Set("Last-Modified", "Thu, 01 Jan 2015 00:00:00 GMT")
+		case "/new":
+			w.Header().Set("Last-Modified", "Sat, 01 Jul 2023 00:00:00 GMT")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	links := []Link{{URL: server.URL + "/old"}, {URL: server.URL + "/new"}, {URL: server.URL + "/none"}}
+	opts := DefaultOptions().WithMaxRetries(0).WithStaleAfter(5 * 365 * 24 * time.Hour)
+	results := New(opts).CheckAll(links)
+	require.Len(t, results, 3)
+
+	byURL := map[string]Result{}
+	for _, r := range results {
+		byURL[r.Link.URL] = r
+	}
+
+	old := byURL[server.URL+"/old"]
+	assert.True(t, old.IsStale())
+	assert.Equal(t, time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC), old.LastModified)
+	assert.Equal(t, 9, int(old.Age/(365*24*time.Hour)))
+	require.Len(t, old.Hints, 1)
+	assert.Equal(t, "Not modified since 2015-01-01 (9 years)", old.Hints[0])
+
+	recent := byURL[server.URL+"/new"]
+	assert.False(t, recent.IsStale())
+	assert.False(t, recent.LastModified.IsZero())
+	assert.Empty(t, recent.Hints)
+
+	unknown := byURL[server.URL+"/none"]
+	assert.False(t, unknown.IsStale())
+	assert.True(t, unknown.LastModified.IsZero())
+
+	assert.Equal(t, 1, Summarize(results).Stale)
+}
+
+func TestParseAge(t *testing.T) {
+	t.Parallel()
+
+	day := 24 * time.Hour
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"5y", 5 * 365 * day},
+		{"2w", 14 * day},
+		{"90d", 90 * day},
+		{"1.5y", 547*day + 12*time.Hour},
+		{"720h", 720 * time.Hour},
+	}
+	for _, tt := range tests {
+		got, err := ParseAge(tt.in)
+		require.NoError(t, err, tt.in)
+		assert.Equal(t, tt.want, got, tt.in)
+	}
+
+	for _, bad := range []string{"", "y", "five years", "-1d"} {
+		_, err := ParseAge(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestFormatAge(t *testing.T) {
+	t.Parallel()
+
+	day := 24 * time.Hour
+	assert.Equal(t, "1 day", FormatAge(day))
+	assert.Equal(t, "3 months", FormatAge(95*day))
+	assert.Equal(t, "6 years", FormatAge(6*365*day+10*day))
+}
+
+func TestChecker_CheckAll_Headers(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Header.Get("Authorization") != "Bearer secret":
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.Header.Get("X-Team") != "docs":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	links := []Link{{URL: server.URL + "/private"}}
+	base := DefaultOptions().WithConcurrency(1).WithMaxRetries(0)
+
+	results := New(base).CheckAll(links)
+	require.Len(t, results, 1)
+	assert.Equal(t, StatusDead, results[0].Status)
+
+	// httptest servers listen on 127.0.0.1, so the domain key is the IP host.
+	opts := base.
+		WithHeaders(map[string]string{"X-Team": "docs"}).
+		WithDomainHeaders(map[string]map[string]string{"127.0.0.1": {"Authorization": "Bearer secret"}})
+	results = New(opts).CheckAll(links)
+	require.Len(t, results, 1)
+	assert.Equal(t, StatusAlive, results[0].Status)
+
+	// Headers for other domains aren't sent.
+	opts = base.
+		WithHeaders(map[string]string{"X-Team": "docs"}).
+		WithDomainHeaders(map[string]map[string]string{"example.com": {"Authorization": "Bearer secret"}})
+	results = New(opts).CheckAll(links)
+	require.Len(t, results, 1)
+	assert.Equal(t, StatusDead, results[0].Status)
+	assert.Equal(t, http.StatusUnauthorized, results[0].StatusCode)
+}
+
+func TestOptions_WithDomainHeaders(t *testing.T) {
+	t.Parallel()
+
+	opts := DefaultOptions().
+		WithDomainHeaders(map[string]map[string]string{"GitHub.com": {"Authorization": "token a"}}).
+		WithDomainHeaders(map[string]map[string]string{"github.com": {"X-Extra": "1"}})
+
+	assert.Equal(t, map[string]map[string]string{
+		"github.com": {"Authorization": "token a", "X-Extra": "1"},
+	}, opts.DomainHeaders)
+}
+
+func TestChecker_CheckAll_MethodPolicy(t *testing.T) {
+	t.Parallel()
+
+	var heads, gets atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			heads.Add(1)
+			// Misbehaving API: HEAD answers 404 or 405 for pages that exist
+			if r.URL.Path == "/not-allowed" {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		gets.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	base := DefaultOptions().WithConcurrency(1).WithMaxRetries(0)
+	links := []Link{{URL: server.URL + "/api"}}
+
+	results := New(base).CheckAll(links)
+	require.Len(t, results, 1)
+	assert.Equal(t, StatusDead, results[0].Status)
+
+	// httptest servers listen on 127.0.0.1, so the domain key is the IP host.
+	heads.Store(0)
+	gets.Store(0)
+	results = New(base.WithMethodPolicy(MethodGet, "127.0.0.1")).CheckAll(links)
+	require.Len(t, results, 1)
+	assert.Equal(t, StatusAlive, results[0].Status)
+	assert.Equal(t, int32(0), heads.Load())
+	assert.Equal(t, int32(1), gets.Load())
+
+	// HEAD-only hosts skip the GET fallback.
+	heads.Store(0)
+	gets.Store(0)
+	results = New(base.WithMethodPolicy(MethodHead, "127.0.0.1")).CheckAll([]Link{{URL: server.URL + "/not-allowed"}})
+	require.Len(t, results, 1)
+	assert.Equal(t, http.StatusMethodNotAllowed, results[0].StatusCode)
+	assert.Equal(t, int32(1), heads.Load())
+	assert.Equal(t, int32(0), gets.Load())
+}
+
+func TestOptions_WithMethodPolicy(t *testing.T) {
+	t.Parallel()
+
+	opts := DefaultOptions().
+		WithMethodPolicy(MethodGet, "API.example.com", " ").
+		WithMethodPolicy(MethodHead, "cdn.example.com.")
+
+	assert.Equal(t, map[string]MethodPolicy{
+		"api.example.com": MethodGet,
+		"cdn.example.com": MethodHead,
+	}, opts.DomainMethods)
+	assert.Nil(t, DefaultOptions().WithMethodPolicy(MethodGet).DomainMethods)
+}
+
+func TestChecker_CheckAll_StatusOverrides(t *testing.T) {
+	t.Parallel()
+
+	var browserRetries atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/linkedin":
+			w.WriteHeader(999)
+		case "/forbidden":
+			if r.Header.Get("User-Agent") == BrowserUserAgent {
+				browserRetries.Add(1)
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+			w.WriteHeader(http.StatusForbidden)
+		case "/moved":
+			http.Redirect(w, r, "/linkedin", http.StatusMovedPermanently)
+		case "/moved-forbidden":
+			http.Redirect(w, r, "/forbidden", http.StatusMovedPermanently)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	opts := DefaultOptions().WithConcurrency(1).WithMaxRetries(0).
+		WithAcceptStatus([]int{999}).
+		WithStatusOverrides(map[int]LinkStatus{http.StatusForbidden: StatusDead})
+	byURL := map[string]Result{}
+	for _, r := range New(opts).CheckAll([]Link{
+		{URL: server.URL + "/linkedin"},
+		{URL: server.URL + "/forbidden"},
+		{URL: server.URL + "/moved"},
+		{URL: server.URL + "/moved-forbidden"},
+	}) {
+		byURL[r.Link.URL] = r
+	}
+
+	assert.Equal(t, StatusAlive, byURL[server.URL+"/linkedin"].Status)
+	assert.Equal(t, 999, byURL[server.URL+"/linkedin"].StatusCode)
+	assert.Equal(t, StatusDead, byURL[server.URL+"/forbidden"].Status)
+	assert.Equal(t, StatusRedirect, byURL[server.URL+"/moved"].Status)
+	assert.Equal(t, StatusDead, byURL[server.URL+"/moved-forbidden"].Status)
+	assert.Equal(t, int32(0), browserRetries.Load())
+}
+
+func TestChecker_CheckAll_TLS(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	links := []Link{{URL: server.URL + "/page"}}
+
+	t.Run("Verified", func(t *testing.T) {
+		results := New(DefaultOptions().WithConcurrency(1).WithMaxRetries(0)).CheckAll(links)
+		require.Len(t, results, 1)
+		assert.Equal(t, StatusTLSError, results[0].Status)
+		require.NotNil(t, results[0].TLS)
+		assert.Equal(t, TLSUnknownAuthority, results[0].TLS.Problem)
+		assert.Equal(t, "127.0.0.1", results[0].TLS.Host)
+		assert.True(t, results[0].IsDead())
+
+		summary := Summarize(results)
+		assert.Equal(t, 1, summary.TLSErrors)
+		assert.True(t, summary.HasDeadLinks())
+	})
+
+	t.Run("Insecure", func(t *testing.T) {
+		results := New(DefaultOptions().WithConcurrency(1).WithMaxRetries(0).WithInsecure(true)).CheckAll(links)
+		require.Len(t, results, 1)
+		assert.Equal(t, StatusInsecure, results[0].Status)
+		assert.Equal(t, http.StatusOK, results[0].StatusCode)
+		require.NotNil(t, results[0].TLS)
+		assert.Equal(t, TLSUnknownAuthority, results[0].TLS.Problem)
+		assert.True(t, results[0].IsWarning())
+	})
+}
+
+func TestChecker_RecordAndReplay_TLS(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	links := []Link{{URL: server.URL + "/page"}}
+	base := DefaultOptions().WithConcurrency(1).WithMaxRetries(0)
+
+	verified, insecure := NewCassette(), NewCassette()
+	New(base.WithRecord(verified)).CheckAll(links)
+	New(base.WithInsecure(true).WithRecord(insecure)).CheckAll(links)
+	server.Close()
+
+	// Saved and loaded, as --record and --replay do
+	dir := t.TempDir()
+	load := func(c *Cassette, name string) *Cassette {
+		path := filepath.Join(dir, name)
+		require.NoError(t, c.Save(path))
+		loaded, err := LoadCassette(path)
+		require.NoError(t, err)
+		return loaded
+	}
+	verified, insecure = load(verified, "verified.json"), load(insecure, "insecure.json")
+
+	tests := []struct {
+		cassette *Cassette
+		name     string
+		want     LinkStatus
+		insecure bool
+	}{
+		{verified, "Verified", StatusTLSError, false},
+		{insecure, "Insecure", StatusInsecure, true},
+		{insecure, "InsecureReplayedVerified", StatusTLSError, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			results := New(base.WithInsecure(tt.insecure).WithReplay(tt.cassette)).CheckAll(links)
+			require.Len(t, results, 1)
+			assert.Equal(t, tt.want, results[0].Status)
+			require.NotNil(t, results[0].TLS)
+			assert.Equal(t, TLSUnknownAuthority, results[0].TLS.Problem)
+			assert.Equal(t, "127.0.0.1", results[0].TLS.Host)
+			assert.NotEmpty(t, results[0].TLS.Detail)
+		})
+	}
+}
+
+func TestTLSProblem(t *testing.T) {
+	t.Parallel()
+
+	future := &x509.Certificate{NotBefore: time.Now().Add(time.Hour), NotAfter: time.Now().Add(2 * time.Hour)}
+	past := &x509.Certificate{NotBefore: time.Now().Add(-2 * time.Hour), NotAfter: time.Now().Add(-time.Hour)}
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"Hostname", &url.Error{Op: "Head", Err: x509.HostnameError{Certificate: past, Host: "a.example"}},
+			TLSHostnameMismatch},
+		{"Unknown", x509.UnknownAuthorityError{}, TLSUnknownAuthority},
+		{"Expired", x509.CertificateInvalidError{Cert: past, Reason: x509.Expired}, TLSExpired},
+		{"NotYetValid", x509.CertificateInvalidError{Cert: future, Reason: x509.Expired}, TLSNotYetValid},
+		{"Other", x509.CertificateInvalidError{Cert: past, Reason: x509.NotAuthorizedToSign}, TLSInvalid},
+		{"Verification", &tls.CertificateVerificationError{Err: errors.New("bad")}, TLSInvalid},
+	}
+	for _, tt := range tests {
+		info := tlsProblem(tt.err, "a.example")
+		require.NotNil(t, info, tt.name)
+		assert.Equal(t, tt.want, info.Problem, tt.name)
+		assert.Equal(t, "a.example", info.Host, tt.name)
+	}
+
+	assert.Nil(t, tlsProblem(errors.New("connection refused"), "a.example"))
+}
+
+// testResolver resolves links on example.com through an API served by a test server.
+type testResolver struct {
+	api string
+}
+
+func (testResolver) Name() string { return "test" }
+
+func (r testResolver) Request(ctx context.Context, link *url.URL) *http.Request {
+	if link.Hostname() != "example.com" {
+		return nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.api+link.Path, http.NoBody)
+	if err != nil {
+		return nil
+	}
+	return req
+}
+
+func TestChecker_CheckAll_Resolvers(t *testing.T) {
+	t.Parallel()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/exists":
+			w.WriteHeader(http.StatusOK)
+		case "/removed":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer api.Close()
+	site := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer site.Close()
+
+	opts := DefaultOptions().WithConcurrency(1).WithMaxRetries(0).WithResolvers(testResolver{api: api.URL})
+	results := New(opts).CheckAll([]Link{
+		{URL: "https://example.com/exists"},
+		{URL: "https://example.com/removed"},
+		{URL: site.URL + "/page"},
+	})
+	require.Len(t, results, 3)
+
+	assert.Equal(t, StatusAlive, results[0].Status)
+	assert.Equal(t, "test", results[0].Resolver)
+	assert.Equal(t, StatusDead, results[1].Status)
+	assert.Equal(t, http.StatusNotFound, results[1].StatusCode)
+	assert.Equal(t, "test", results[1].Resolver)
+	assert.Equal(t, StatusAlive, results[2].Status)
+	assert.Empty(t, results[2].Resolver)
+
+	// Inconclusive answers fall back to the regular check.
+	opts = DefaultOptions().WithConcurrency(1).WithMaxRetries(0).
+		WithResolvers(testResolver{api: api.URL}).WithoutResolvers("github")
+	result, ok := New(opts).resolve(context.Background(), Link{URL: "https://example.com/limited"})
+	assert.False(t, ok)
+	assert.Empty(t, result.Resolver)
+	assert.Len(t, opts.Resolvers, len(DefaultResolvers()))
+	assert.Len(t, opts.WithoutResolvers().Resolvers, len(DefaultResolvers()))
+	assert.Len(t, opts.WithoutResolvers("test", "npm", "pypi", "youtube").Resolvers, 0)
+}
+
+func TestDefaultResolvers_Request(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		url  string
+		want string // Empty when no resolver handles the URL
+	}{
+		{"https://github.com/leonardomso/gone", "https://api.github.com/repos/leonardomso/gone"},
+		{"https://github.com/leonardomso/gone.git", "https://api.github.com/repos/leonardomso/gone"},
+		{"https://github.com/leonardomso/gone/issues/12", "https://api.github.com/repos/leonardomso/gone/issues/12"},
+		{"https://github.com/leonardomso/gone/pull/7", "https://api.github.com/repos/leonardomso/gone/pulls/7"},
+		{"https://github.com/leonardomso/gone/blob/main/README.md", ""},
+		{"https://github.com/features/actions", ""},
+		{"https://github.com/leonardomso", ""},
+		{"https://www.npmjs.com/package/react", "https://registry.npmjs.org/react"},
+		{"https://www.npmjs.com/package/@types/node", "https://registry.npmjs.org/@types%2Fnode"},
+		{"https://pypi.org/project/requests/", "https://pypi.org/pypi/requests/json"},
+		{"https://pypi.org/project/requests/2.31.0/", "https://pypi.org/pypi/requests/2.31.0/json"},
+		{
+			"https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=10",
+			"https://www.youtube.com/oembed?format=json&url=https%3A%2F%2Fwww.youtube.com%2Fwatch%3Fv%3DdQw4w9WgXcQ",
+		},
+		{
+			"https://youtu.be/dQw4w9WgXcQ",
+			"https://www.youtube.com/oembed?format=json&url=https%3A%2F%2Fwww.youtube.com%2Fwatch%3Fv%3DdQw4w9WgXcQ",
+		},
+		{"https://www.youtube.com/channel/UC123", ""},
+		{"https://example.com/package/react", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			t.Parallel()
+			u, err := url.Parse(tt.url)
+			require.NoError(t, err)
+
+			got := ""
+			for _, r := range DefaultResolvers() {
+				if req := r.Request(context.Background(), u); req != nil {
+					got = req.URL.String()
+					break
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestChecker_CheckAll_ContentRules(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			switch r.URL.Path {
+			case "/app/docs":
+				_, _ = w.Write([]byte(`<div id="root"><h1>Docs</h1><footer>v2.1.0</footer></div>`))
+			default:
+				_, _ = w.Write([]byte(`<div id="root"><h1>Page Not Found</h1></div>`))
+			}
+		}
+	}))
+	defer server.Close()
+
+	contains, err := NewContentRule(server.URL+"/app/*", "<h1>Docs</h1>", "")
+	require.NoError(t, err)
+	matches, err := NewContentRule(server.URL+"/app/docs", "", `v\d+\.\d+`)
+	require.NoError(t, err)
+
+	opts := DefaultOptions().WithConcurrency(1).WithMaxRetries(0).WithContentRules(contains, matches)
+	results := New(opts).CheckAll([]Link{
+		{URL: server.URL + "/app/docs"},
+		{URL: server.URL + "/app/missing"},
+		{URL: server.URL + "/other"},
+	})
+	require.Len(t, results, 3)
+
+	assert.Equal(t, StatusAlive, results[0].Status)
+	assert.Equal(t, StatusDead, results[1].Status)
+	assert.Equal(t, http.StatusOK, results[1].StatusCode)
+	assert.Contains(t, results[1].Error, `page doesn't contain "<h1>Docs</h1>"`)
+	assert.Equal(t, StatusAlive, results[2].Status)
+}
+
+func TestChecker_CheckAll_BodyChecksShareFetch(t *testing.T) {
+	t.Parallel()
+
+	var gets atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			gets.Add(1)
+		}
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><head><title>Docs</title><meta name="robots" content="noindex"></head>
+<body><h1 id="install">Install</h1><footer>v2.1.0</footer></body></html>`))
+	}))
+	defer server.Close()
+
+	rule, err := NewContentRule(server.URL+"/*", "v2.1.0", "")
+	require.NoError(t, err)
+	opts := DefaultOptions().WithMaxRetries(0).WithContentRules(rule).
+		WithDetectSoft404(true).WithFetchBodies(true).WithCheckAnchors(true)
+	results := New(opts).CheckAll([]Link{{URL: server.URL + "/docs#setup"}})
+	require.Len(t, results, 1)
+
+	assert.Equal(t, StatusBrokenAnchor, results[0].Status)
+	assert.Equal(t, []string{"noindex"}, results[0].Robots)
+	assert.Equal(t, int32(1), gets.Load(), "content rules, soft 404s, robots, and anchors read one fetch")
+}
+
+func TestNewContentRule(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewContentRule("https://example.com/*", "", "")
+	require.ErrorContains(t, err, "must_contain or must_match")
+
+	_, err = NewContentRule("https://example.com/[", "Docs", "")
+	require.ErrorContains(t, err, "invalid pattern")
+
+	_, err = NewContentRule("https://example.com/*", "", "(")
+	require.ErrorContains(t, err, "invalid must_match")
+
+	rule, err := NewContentRule("https://example.com/*", "Docs", "")
+	require.NoError(t, err)
+	assert.True(t, rule.Matches("https://example.com/a/b"))
+	assert.False(t, rule.Matches("https://example.org/a"))
+	assert.False(t, ContentRule{}.Matches("https://example.com/"))
+}
+
+func TestChecker_CheckAll_DetectSoft404(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/moved":
+			http.Redirect(w, r, "/errors/not-found", http.StatusMovedPermanently)
+			return
+		case "/errors/not-found", "/docs":
+			w.WriteHeader(http.StatusOK)
+		case "/spa":
+			_, _ = w.Write([]byte(`<html><head><title>Acme</title></head><body><h1>Page Not Found</h1></body></html>`))
+		case "/http-404":
+			_, _ = w.Write([]byte(`<html><head><title>404 Not Found - HTTP | MDN</title></head></html>`))
+		default:
+			_, _ = w.Write([]byte(`<html><head><title>Docs</title></head><body><h1>Install</h1></body></html>`))
+		}
+	}))
+	defer server.Close()
+
+	links := []Link{
+		{URL: server.URL + "/docs"},
+		{URL: server.URL + "/spa"},
+		{URL: server.URL + "/moved"},
+		{URL: server.URL + "/http-404"},
+	}
+	opts := DefaultOptions().WithConcurrency(1).WithMaxRetries(0)
+
+	results := New(opts).CheckAll(links)
+	require.Len(t, results, 4)
+	assert.Equal(t, StatusAlive, results[1].Status)
+
+	results = New(opts.WithDetectSoft404(true)).CheckAll(links)
+	require.Len(t, results, 4)
+	assert.Equal(t, StatusAlive, results[0].Status)
+	assert.Equal(t, StatusSuspect, results[1].Status)
+	assert.Equal(t, []string{`soft 404: page says "Page Not Found"`}, results[1].Hints)
+	assert.Equal(t, StatusSuspect, results[2].Status)
+	assert.Contains(t, results[2].Hints[0], "redirects to")
+	assert.Equal(t, StatusAlive, results[3].Status)
+
+	summary := Summarize(results)
+	assert.Equal(t, 2, summary.Suspect)
+	assert.Equal(t, 2, summary.WarningsCount())
+}
+
+func TestSoft404Hint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"Title", `<title>Error 404 &ndash; Acme</title>`, `soft 404: page says "Error 404 – Acme"`},
+		{"Heading", `<title>Acme</title><h1 class="x">Sorry, this page <em>doesn't exist</em></h1>`,
+			`soft 404: page says "Sorry, this page doesn't exist"`},
+		{"Parked", `<title>example.com</title><p>This domain is for sale!</p>`,
+			`soft 404: parked domain ("this domain is for sale")`},
+		{"TitleEnd", `<title>Acme | Not Found</title>`, `soft 404: page says "Acme | Not Found"`},
+		{"PageNotFound", `<title>Oops! Page not found on Acme</title>`,
+			`soft 404: page says "Oops! Page not found on Acme"`},
+		{"Regular", `<title>Release 4040 notes</title><h1>Not everything is found here</h1>`, ""},
+		{"AboutNotFound", `<title>Module not found error – Stack Overflow</title>`, ""},
+		{"About404", `<title>HTTP 404 – MDN</title>`, ""},
+		{"NoTitle", `plain text`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, soft404Hint([]byte(tt.body), true))
+		})
+	}
+	assert.Empty(t, soft404Hint([]byte(`<title>404 Not Found</title>`), false))
+}
+
+func TestChecker_CheckAll_RedirectPolicy(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/docs":
+			http.Redirect(w, r, "/docs/", http.StatusMovedPermanently)
+		case "/old":
+			http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+		case "/elsewhere":
+			other := "http://" + strings.Replace(r.Host, "127.0.0.1", "localhost", 1) + "/new"
+			http.Redirect(w, r, other, http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	links := []Link{{URL: server.URL + "/docs"}, {URL: server.URL + "/old"}, {URL: server.URL + "/elsewhere"}}
+	opts := DefaultOptions().WithConcurrency(1).WithMaxRetries(0)
+
+	results := New(opts).CheckAll(links)
+	require.Len(t, results, 3)
+	assert.Equal(t, StatusRedirect, results[0].Status)
+	assert.Equal(t, StatusRedirect, results[1].Status)
+	assert.Equal(t, StatusRedirect, results[2].Status)
+	assert.Equal(t, []string{"redirects to another domain: localhost"}, results[2].Hints)
+
+	results = New(opts.WithRedirectPolicy(RedirectLenient)).CheckAll(links)
+	require.Len(t, results, 3)
+	assert.Equal(t, StatusAlive, results[0].Status)
+	assert.Equal(t, server.URL+"/docs/", results[0].FinalURL)
+	assert.Equal(t, StatusRedirect, results[1].Status)
+	assert.Equal(t, StatusRedirect, results[2].Status)
+}
+
+func TestHarmlessHop(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		from, to string
+		upgrade  bool // Under RedirectIgnoreHTTPSUpgrade
+		lenient  bool // Under RedirectLenient
+	}{
+		{"http://example.com/docs", "https://example.com/docs", true, true},
+		{"http://example.com", "https://example.com/", true, true},
+		{"http://example.com:80/a", "https://example.com/a", true, true},
+		{"http://example.com/docs", "https://example.com/docs/", false, true},
+		{"https://example.com/docs", "https://example.com/docs/", false, true},
+		{"https://example.com/docs/", "https://example.com/docs", false, true},
+		{"https://example.com/docs", "http://example.com/docs", false, false},
+		{"http://example.com/docs", "https://www.example.com/docs", false, false},
+		{"https://example.com/a?x=1", "https://example.com/a/?x=2", false, false},
+		{"https://example.com/old", "https://example.com/new", false, false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.upgrade, harmlessHop(RedirectIgnoreHTTPSUpgrade, tt.from, tt.to), "%s → %s", tt.from, tt.to)
+		assert.Equal(t, tt.lenient, harmlessHop(RedirectLenient, tt.from, tt.to), "%s → %s", tt.from, tt.to)
+		assert.False(t, harmlessHop(RedirectStrict, tt.from, tt.to))
+	}
+
+	for _, name := range RedirectPolicyNames() {
+		policy, ok := ParseRedirectPolicy(name)
+		require.True(t, ok)
+		assert.Equal(t, name, policy.String())
+	}
+	_, ok := ParseRedirectPolicy("loose")
+	assert.False(t, ok)
+}
+
+func TestChecker_CheckAll_IPFamily(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	links := []Link{{URL: server.URL}}
+	opts := DefaultOptions().WithConcurrency(1).WithMaxRetries(0)
+
+	for _, family := range []string{"", IPFamilyAuto, IPFamilyIPv4} {
+		results := New(opts.WithIPFamily(family)).CheckAll(links)
+		require.Len(t, results, 1)
+		assert.Equal(t, StatusAlive, results[0].Status, "family %q", family)
+	}
+
+	// The test server only listens on 127.0.0.1.
+	results := New(opts.WithIPFamily(IPFamilyIPv6)).CheckAll(links)
+	require.Len(t, results, 1)
+	assert.Equal(t, StatusError, results[0].Status)
+
+	assert.True(t, ValidIPFamily(IPFamilyIPv6))
+	assert.False(t, ValidIPFamily("ipv5"))
+	assert.Equal(t, "tcp4", familyNetwork("tcp", IPFamilyIPv4))
+	assert.Equal(t, "udp6", familyNetwork("udp", IPFamilyIPv6))
+	assert.Equal(t, "tcp", familyNetwork("tcp", IPFamilyAuto))
+	assert.Equal(t, "unix", familyNetwork("unix", IPFamilyIPv4))
+}
+
+// startDNSServer runs a DNS server on UDP that answers every A query with
+// 127.0.0.1 and counts the queries.
+func startDNSServer(t *testing.T) (string, *atomic.Int64) {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	var queries atomic.Int64
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			var msg dnsmessage.Message
+			if msg.Unpack(buf[:n]) != nil || len(msg.Questions) == 0 {
+				continue
+			}
+			q := msg.Questions[0]
+			if q.Type == dnsmessage.TypeA {
+				queries.Add(1)
+			}
+			msg.Header.Response = true
+			msg.Header.RecursionAvailable = true
+			msg.Answers = nil
+			if q.Type == dnsmessage.TypeA {
+				msg.Answers = []dnsmessage.Resource{{
+					Header: dnsmessage.ResourceHeader{Name: q.Name, Type: q.Type, Class: q.Class, TTL: 60},
+					Body:   &dnsmessage.AResource{A: [4]byte{127, 0, 0, 1}},
+				}}
+			}
+			if resp, err := msg.Pack(); err == nil {
+				_, _ = conn.WriteTo(resp, addr)
+			}
+		}
+	}()
+	return conn.LocalAddr().String(), &queries
+}
+
+func TestChecker_CheckAll_DNSServer(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Connection", "close") // Every request dials, and looks the host up
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	dns, queries := startDNSServer(t)
+
+	_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+	base := "http://docs.gone.test:" + port
+	links := []Link{{URL: base + "/a"}, {URL: base + "/b"}, {URL: base + "/c"}}
+
+	var lookups, hits atomic.Int64
+	opts := DefaultOptions().WithConcurrency(1).WithMaxRetries(0).
+		WithIPFamily(IPFamilyIPv4).
+		WithDNSServer(dns).
+		WithHooks(Hooks{OnDNS: func(e DNSEvent) {
+			assert.Equal(t, "docs.gone.test", e.Host)
+			assert.NoError(t, e.Err)
+			if e.Cached {
+				hits.Add(1)
+			} else {
+				lookups.Add(1)
+			}
+		}})
+
+	results := New(opts).CheckAll(links)
+	require.Len(t, results, 3)
+	for _, r := range results {
+		assert.Equal(t, StatusAlive, r.Status, r.Error)
+	}
+	assert.Equal(t, int64(1), queries.Load())
+	assert.Equal(t, int64(1), lookups.Load())
+	assert.Equal(t, int64(2), hits.Load())
+}
+
+func TestParseDNSServer(t *testing.T) {
+	t.Parallel()
+
+	for in, want := range map[string]string{
+		"1.1.1.1":               "1.1.1.1:53",
+		"10.0.0.2:5353":         "10.0.0.2:5353",
+		"2606:4700::1111":       "[2606:4700::1111]:53",
+		"[2606:4700::1111]:853": "[2606:4700::1111]:853",
+	} {
+		got, err := ParseDNSServer(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got)
+	}
+	for _, in := range []string{"", "dns.example.com", "dns.example.com:53"} {
+		_, err := ParseDNSServer(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestChecker_CheckAll_Timing(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			time.Sleep(20 * time.Millisecond)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var hooked []Timing
+	opts := DefaultOptions().WithConcurrency(1).WithMaxRetries(0).
+		WithHooks(Hooks{OnResult: func(r Result) { hooked = append(hooked, r.Timing) }})
+	results := New(opts).CheckAll([]Link{{URL: server.URL + "/slow"}, {URL: server.URL + "/fast"}})
+	require.Len(t, results, 2)
+
+	slow := results[0].Timing
+	assert.GreaterOrEqual(t, slow.TTFB, 20*time.Millisecond)
+	assert.GreaterOrEqual(t, slow.Total, slow.TTFB)
+	assert.Positive(t, slow.Connect)
+	assert.Zero(t, slow.TLS)
+	assert.Zero(t, results[1].Timing.Connect, "second request reuses the connection")
+	assert.False(t, results[1].Timing.IsZero())
+	assert.Equal(t, []Timing{results[0].Timing, results[1].Timing}, hooked)
+
+	// Failed requests are timed too, to tell timeouts from refusals
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+	results = New(opts).CheckAll([]Link{{URL: closed.URL}})
+	require.Len(t, results, 1)
+	assert.Equal(t, StatusError, results[0].Status)
+	assert.False(t, results[0].Timing.IsZero())
+}
+
+func TestChecker_CheckAll_Budget(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch r.URL.Path {
+		case "/slow":
+			select {
+			case <-time.After(2 * time.Second):
+			case <-r.Context().Done():
+			}
+		case "/old":
+			http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	opts := DefaultOptions().WithConcurrency(1).WithMaxRetries(0)
+
+	t.Run("MaxRequests", func(t *testing.T) {
+		links := []Link{
+			{URL: server.URL + "/a"}, {URL: server.URL + "/old"}, {URL: server.URL + "/b"}, {URL: server.URL + "/a"},
+		}
+		before := requests.Load()
+		results := New(opts.WithMaxRequests(2)).CheckAll(links)
+		require.Len(t, results, 4)
+
+		byURL := map[string]Result{}
+		for _, r := range results {
+			if !r.IsDuplicate() {
+				byURL[r.Link.URL] = r
+			}
+		}
+		assert.Equal(t, StatusAlive, byURL[server.URL+"/a"].Status)
+		// The redirect's first request fit, following it didn't
+		assert.Equal(t, StatusSkipped, byURL[server.URL+"/old"].Status)
+		assert.Equal(t, "request budget of 2 exhausted", byURL[server.URL+"/old"].Error)
+		assert.Equal(t, StatusSkipped, byURL[server.URL+"/b"].Status)
+		assert.Equal(t, int64(2), requests.Load()-before)
+
+		summary := Summarize(results)
+		assert.Equal(t, 2, summary.Skipped)
+		assert.False(t, summary.HasIssues())
+	})
+
+	t.Run("MaxDuration", func(t *testing.T) {
+		links := []Link{{URL: server.URL + "/a"}, {URL: server.URL + "/slow"}, {URL: server.URL + "/b"}}
+		start := time.Now()
+		results := New(opts.WithMaxDuration(200 * time.Millisecond)).CheckAll(links)
+		assert.Less(t, time.Since(start), time.Second)
+		require.Len(t, results, 3)
+
+		assert.Equal(t, StatusAlive, results[0].Status)
+		for _, r := range results[1:] {
+			assert.Equal(t, StatusSkipped, r.Status, r.Link.URL)
+			assert.Equal(t, "time budget of 200ms exhausted", r.Error)
+		}
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		for r := range New(opts.WithMaxDuration(time.Minute)).Check(ctx, []Link{{URL: server.URL + "/a"}}) {
+			assert.NotEqual(t, StatusSkipped, r.Status)
+		}
+	})
+}
+
+func TestChecker_CheckAll_EnableCookies(t *testing.T) {
+	t.Parallel()
+
+	// A consent gate: /start sets a cookie and redirects to /docs, which is
+	// forbidden without it
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/start":
+			http.SetCookie(w, &http.Cookie{Name: "consent", Value: "yes", Path: "/"})
+			http.Redirect(w, r, "/docs", http.StatusFound)
+		case "/docs":
+			if c, err := r.Cookie("consent"); err != nil || c.Value != "yes" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	links := []Link{{URL: server.URL + "/start"}}
+	opts := DefaultOptions().WithMaxRetries(0)
+
+	results := New(opts).CheckAll(links)
+	require.Len(t, results, 1)
+	assert.Equal(t, StatusDead, results[0].Status)
+	assert.Equal(t, http.StatusForbidden, results[0].FinalStatus)
+
+	results = New(opts.WithEnableCookies(true)).CheckAll(links)
+	require.Len(t, results, 1)
+	assert.Equal(t, StatusRedirect, results[0].Status)
+	assert.Equal(t, server.URL+"/docs", results[0].FinalURL)
+	assert.Equal(t, http.StatusOK, results[0].FinalStatus)
+}
+
+func TestChecker_CheckAll_Profiles(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var agents []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ua := r.Header.Get("User-Agent")
+		mu.Lock()
+		agents = append(agents, ua)
+		mu.Unlock()
+		switch r.URL.Path {
+		case "/firefox-only":
+			if !strings.Contains(ua, "Firefox") {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+		case "/forbidden":
+			w.WriteHeader(http.StatusForbidden)
+			return
+		case "/team":
+			if r.Header.Get("X-Team") != "docs" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	opts := DefaultOptions().WithConcurrency(1).WithMaxRetries(0)
+
+	t.Run("Fallback", func(t *testing.T) {
+		results := New(opts).CheckAll([]Link{
+			{URL: server.URL + "/firefox-only"},
+			{URL: server.URL + "/forbidden"},
+			{URL: server.URL + "/ok"},
+		})
+		require.Len(t, results, 3)
+
+		assert.Equal(t, StatusAlive, results[0].Status)
+		assert.Equal(t, ProfileFirefox, results[0].Profile)
+
+		assert.Equal(t, StatusBlocked, results[1].Status)
+		assert.Empty(t, results[1].Profile)
+		assert.Equal(t, []string{"403 with every header profile tried: default, chrome, firefox"}, results[1].Hints)
+
+		assert.Equal(t, StatusAlive, results[2].Status)
+		assert.Empty(t, results[2].Profile)
+	})
+
+	t.Run("Selected", func(t *testing.T) {
+		mu.Lock()
+		agents = nil
+		mu.Unlock()
+
+		results := New(opts.WithProfile(ProfileCurl)).CheckAll([]Link{{URL: server.URL + "/ok"}})
+		require.Len(t, results, 1)
+		assert.Equal(t, StatusAlive, results[0].Status)
+		assert.Equal(t, ProfileCurl, results[0].Profile)
+		mu.Lock()
+		assert.Equal(t, []string{CurlUserAgent}, agents)
+		mu.Unlock()
+	})
+
+	t.Run("Custom", func(t *testing.T) {
+		mu.Lock()
+		agents = nil
+		mu.Unlock()
+
+		custom := map[string]map[string]string{"docs": {"X-Team": "docs"}}
+		results := New(opts.WithCustomProfiles(custom).WithProfile("docs")).CheckAll([]Link{{URL: server.URL + "/team"}})
+		require.Len(t, results, 1)
+		assert.Equal(t, StatusAlive, results[0].Status)
+		assert.Equal(t, "docs", results[0].Profile)
+		mu.Lock()
+		// Custom profiles keep the default User-Agent
+		assert.Equal(t, []string{DefaultUserAgent}, agents)
+		mu.Unlock()
+	})
+}
+
+func TestChecker_CheckIter(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	links := make([]Link, 20)
+	for i := range links {
+		links[i] = Link{URL: server.URL + "/" + strconv.Itoa(i)}
+	}
+	c := New(DefaultOptions().WithConcurrency(1).WithMaxRetries(0))
+
+	t.Run("All", func(t *testing.T) {
+		seen := map[string]bool{}
+		for r := range c.CheckIter(context.Background(), links[:5]) {
+			assert.Equal(t, StatusAlive, r.Status)
+			seen[r.Link.URL] = true
+		}
+		assert.Len(t, seen, 5)
+	})
+
+	t.Run("Break", func(t *testing.T) {
+		before := requests.Load()
+		count := 0
+		for range c.CheckIter(context.Background(), links) {
+			count++
+			if count == 2 {
+				break
+			}
+		}
+		assert.Equal(t, 2, count)
+		// Checks in flight may finish, but the rest are canceled
+		assert.Less(t, requests.Load()-before, int64(len(links)))
+	})
+
+	t.Run("Func", func(t *testing.T) {
+		var results []Result
+		c.CheckFunc(context.Background(), links[:3], func(r Result) {
+			results = append(results, r)
+		})
+		assert.Len(t, results, 3)
+	})
+}
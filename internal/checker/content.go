@@ -0,0 +1,100 @@
+package checker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/gobwas/glob"
+)
+
+// maxContentBytes caps how much of a page body content rules look at.
+const maxContentBytes = 4 << 20
+
+// ContentRule asserts what the body of working pages with matching URLs must
+// contain, for sites that answer 200 with a "Not Found" page (such as some
+// single-page apps). Create rules with NewContentRule.
+type ContentRule struct {
+	match       glob.Glob
+	MustMatch   *regexp.Regexp // Pattern the body must match, if set
+	Pattern     string         // Glob matched against the link URL, e.g. "https://app.example.com/*"
+	MustContain string         // Text the body must contain, if set
+}
+
+// NewContentRule compiles a content rule. At least one of mustContain and
+// mustMatch must be set.
+func NewContentRule(pattern, mustContain, mustMatch string) (ContentRule, error) {
+	if mustContain == "" && mustMatch == "" {
+		return ContentRule{}, errors.New("content rule needs must_contain or must_match")
+	}
+	g, err := glob.Compile(pattern)
+	if err != nil {
+		return ContentRule{}, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	rule := ContentRule{match: g, Pattern: pattern, MustContain: mustContain}
+	if mustMatch != "" {
+		if rule.MustMatch, err = regexp.Compile(mustMatch); err != nil {
+			return ContentRule{}, fmt.Errorf("invalid must_match %q: %w", mustMatch, err)
+		}
+	}
+	return rule, nil
+}
+
+// Matches reports whether the rule applies to a URL.
+func (r ContentRule) Matches(rawURL string) bool {
+	return r.match != nil && r.match.Match(rawURL)
+}
+
+// failure describes why body doesn't satisfy the rule, or returns "".
+func (r ContentRule) failure(body []byte) string {
+	if r.MustContain != "" && !strings.Contains(string(body), r.MustContain) {
+		return fmt.Sprintf("page doesn't contain %q (content rule %s)", r.MustContain, r.Pattern)
+	}
+	if r.MustMatch != nil && !r.MustMatch.Match(body) {
+		return fmt.Sprintf("page doesn't match /%s/ (content rule %s)", r.MustMatch, r.Pattern)
+	}
+	return ""
+}
+
+// contentRules returns the content rules that apply to a URL.
+func (c *Checker) contentRules(rawURL string) []ContentRule {
+	var rules []ContentRule
+	for _, r := range c.opts.ContentRules {
+		if r.Matches(rawURL) {
+			rules = append(rules, r)
+		}
+	}
+	return rules
+}
+
+// checkContent reads the body of a working link that content rules apply to
+// and reports the link as dead when a rule fails.
+func (c *Checker) checkContent(ctx context.Context, result *Result, body *pageBody) {
+	rules := c.contentRules(result.Link.URL)
+	if len(rules) == 0 {
+		return
+	}
+
+	p, err := body.get(ctx, maxContentBytes)
+	switch {
+	case err != nil:
+		result.Status = StatusError
+		result.Error = "fetching page for content rules: " + err.Error()
+		return
+	case p.StatusCode < http.StatusOK || p.StatusCode >= http.StatusMultipleChoices:
+		result.Status = StatusDead
+		result.Error = fmt.Sprintf("fetching page for content rules: status %d", p.StatusCode)
+		return
+	}
+
+	for _, r := range rules {
+		if msg := r.failure(p.Body); msg != "" {
+			result.Status = StatusDead
+			result.Error = msg
+			return
+		}
+	}
+}
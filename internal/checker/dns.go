@@ -0,0 +1,239 @@
+package checker
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"github.com/quic-go/quic-go"
+)
+
+const (
+	// dnsCacheTTL is how long resolved addresses are reused. The resolver
+	// doesn't report record TTLs, so long-running modes (watch, serve)
+	// look hosts up again after this.
+	dnsCacheTTL = 5 * time.Minute
+
+	// defaultDNSTimeout bounds lookups when no request timeout is set.
+	defaultDNSTimeout = 10 * time.Second
+)
+
+// ParseDNSServer validates a DNS server address, an IP with an optional
+// port such as "1.1.1.1" or "[2606:4700::1111]:53", and returns it as
+// host:port with port 53 by default.
+func ParseDNSServer(s string) (string, error) {
+	host, port, err := net.SplitHostPort(s)
+	if err != nil {
+		host, port = s, "53"
+	}
+	if net.ParseIP(host) == nil {
+		return "", fmt.Errorf("DNS server must be an IP address, got %q", s)
+	}
+	return net.JoinHostPort(host, port), nil
+}
+
+// DNSEvent describes a host name lookup made while dialing.
+type DNSEvent struct {
+	Err      error // Lookup error, nil when the host resolved
+	Host     string
+	Duration time.Duration // Time spent waiting for the answer
+	Cached   bool          // Answered from the cache, without a query
+}
+
+// dnsEntry is a cached lookup. ready is closed once addrs and err are set,
+// so workers asking for a host being looked up wait for that lookup.
+type dnsEntry struct {
+	expires time.Time
+	err     error
+	ready   chan struct{}
+	addrs   []net.IP
+}
+
+// dnsCache resolves host names once for all workers of a Checker and reuses
+// the answers. Temporary failures and timeouts aren't cached.
+type dnsCache struct {
+	resolver *net.Resolver
+	onLookup func(DNSEvent)
+	entries  map[string]*dnsEntry
+	network  string // "ip", "ip4", or "ip6"
+	timeout  time.Duration
+	mu       sync.Mutex
+}
+
+// newDNSCache creates a cache that looks hosts up with opts.DNSServer, or
+// the system resolver if it isn't set. DNSServer is validated by callers;
+// an invalid one falls back to the system resolver.
+func newDNSCache(opts Options) *dnsCache {
+	c := &dnsCache{
+		resolver: net.DefaultResolver,
+		onLookup: opts.Hooks.OnDNS,
+		entries:  map[string]*dnsEntry{},
+		network:  "ip",
+		timeout:  opts.Timeout,
+	}
+	switch opts.IPFamily {
+	case IPFamilyIPv4:
+		c.network = "ip4"
+	case IPFamilyIPv6:
+		c.network = "ip6"
+	}
+	if c.timeout <= 0 {
+		c.timeout = defaultDNSTimeout
+	}
+	if server, err := ParseDNSServer(opts.DNSServer); opts.DNSServer != "" && err == nil {
+		d := &net.Dialer{Timeout: c.timeout}
+		c.resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				return d.DialContext(ctx, network, server)
+			},
+		}
+	}
+	return c
+}
+
+// lookup returns the addresses of host in the cache's IP family.
+func (c *dnsCache) lookup(ctx context.Context, host string) ([]net.IP, error) {
+	start := time.Now()
+
+	c.mu.Lock()
+	e, ok := c.entries[host]
+	if ok && !e.expires.IsZero() && time.Now().After(e.expires) {
+		ok = false
+	}
+	leader := !ok
+	if leader {
+		e = &dnsEntry{ready: make(chan struct{})}
+		c.entries[host] = e
+	}
+	c.mu.Unlock()
+
+	trace := httptrace.ContextClientTrace(ctx)
+	if trace != nil && trace.DNSStart != nil {
+		trace.DNSStart(httptrace.DNSStartInfo{Host: host})
+	}
+	if leader {
+		c.resolve(host, e)
+	} else {
+		select {
+		case <-e.ready:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if trace != nil && trace.DNSDone != nil {
+		trace.DNSDone(httptrace.DNSDoneInfo{Err: e.err, Coalesced: !leader})
+	}
+
+	if c.onLookup != nil {
+		c.onLookup(DNSEvent{Host: host, Duration: time.Since(start), Cached: !leader, Err: e.err})
+	}
+	return e.addrs, e.err
+}
+
+// resolve looks host up and fills in e. The lookup doesn't use the
+// request's context: other workers may be waiting for it, and lookup reports
+// to the request's trace itself.
+func (c *dnsCache) resolve(host string, e *dnsEntry) {
+	lookupCtx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	e.addrs, e.err = c.resolver.LookupIP(lookupCtx, c.network, host)
+	if e.err == nil && len(e.addrs) == 0 {
+		e.err = &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+
+	var dnsErr *net.DNSError
+	c.mu.Lock()
+	switch {
+	case e.err == nil || (errors.As(e.err, &dnsErr) && dnsErr.IsNotFound):
+		e.expires = time.Now().Add(dnsCacheTTL)
+	default:
+		delete(c.entries, host) // Let the next request try again
+	}
+	c.mu.Unlock()
+	close(e.ready)
+}
+
+// dialer connects to hosts resolved through a dnsCache, restricted to the
+// IP family of the cache.
+type dialer struct {
+	dialer *net.Dialer
+	dns    *dnsCache
+	family string
+}
+
+// DialContext connects to addr over TCP, trying each address of its host in
+// the resolver's order. Like net.Dialer, each attempt gets a share of the
+// remaining timeout, so an unreachable address doesn't use all of it.
+func (d *dialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	network = familyNetwork(network, d.family)
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil || net.ParseIP(host) != nil {
+		return d.dialer.DialContext(ctx, network, addr)
+	}
+	ips, err := d.dns.lookup(ctx, host)
+	if err != nil {
+		return nil, &net.OpError{Op: "dial", Net: network, Err: err}
+	}
+
+	var deadline time.Time
+	if d.dialer.Timeout > 0 {
+		deadline = time.Now().Add(d.dialer.Timeout)
+	}
+	if dl, ok := ctx.Deadline(); ok && (deadline.IsZero() || dl.Before(deadline)) {
+		deadline = dl
+	}
+	var firstErr error
+	for i, ip := range ips {
+		attemptCtx, cancel := attemptContext(ctx, deadline, len(ips)-i)
+		conn, err := d.dialer.DialContext(attemptCtx, network, net.JoinHostPort(ip.String(), port))
+		cancel()
+		if err == nil {
+			return conn, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, firstErr
+}
+
+// attemptContext returns the context for one of remaining connection
+// attempts that must all finish by deadline (if set).
+func attemptContext(ctx context.Context, deadline time.Time, remaining int) (context.Context, context.CancelFunc) {
+	if deadline.IsZero() {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, time.Until(deadline)/time.Duration(remaining))
+}
+
+// DialQUIC is the HTTP/3 Dial function. It connects to the first address of
+// the host; tlsCfg already carries the host name as ServerName.
+func (d *dialer) DialQUIC(
+	ctx context.Context, addr string, tlsCfg *tls.Config, cfg *quic.Config,
+) (*quic.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	if net.ParseIP(host) == nil {
+		ips, err := d.dns.lookup(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		addr = net.JoinHostPort(ips[0].String(), port)
+	}
+	udpAddr, err := net.ResolveUDPAddr(familyNetwork("udp", d.family), addr)
+	if err != nil {
+		return nil, err
+	}
+	return quic.DialAddrEarly(ctx, udpAddr.String(), tlsCfg, cfg)
+}
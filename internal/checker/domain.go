@@ -0,0 +1,43 @@
+package checker
+
+import (
+	"net/url"
+	"strings"
+)
+
+// hostOf returns the lowercase hostname of a URL, or "" if it cannot be parsed.
+func hostOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Hostname())
+}
+
+// normalizeDomain lowercases a domain and strips surrounding whitespace and dots.
+func normalizeDomain(domain string) string {
+	return strings.Trim(strings.ToLower(strings.TrimSpace(domain)), ".")
+}
+
+// matchesDomain reports whether host equals domain or is one of its subdomains.
+func matchesDomain(host, domain string) bool {
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+// lookupDomain finds the most specific entry in m whose key matches host.
+// Longer (more specific) domains win, so "api.github.com" beats "github.com".
+func lookupDomain[V any](m map[string]V, host string) (V, bool) {
+	var (
+		best    V
+		bestLen = -1
+	)
+	if host == "" {
+		return best, false
+	}
+	for domain, v := range m {
+		if len(domain) > bestLen && matchesDomain(host, domain) {
+			best, bestLen = v, len(domain)
+		}
+	}
+	return best, bestLen >= 0
+}
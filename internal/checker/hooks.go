@@ -0,0 +1,91 @@
+package checker
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Hooks are optional callbacks that observe a check run as it happens.
+// Nil hooks are skipped. OnRequest, OnDNS, and OnRetry are called from worker
+// goroutines and must be safe for concurrent use; OnResult is called from
+// a single goroutine, in the order results are emitted.
+type Hooks struct {
+	// OnRequest is called after every HTTP request the checker makes,
+	// including redirect hops, browser-header retries, probes, and page fetches.
+	OnRequest func(RequestEvent)
+
+	// OnDNS is called for every host name lookup made while connecting,
+	// including those answered from the DNS cache.
+	OnDNS func(DNSEvent)
+
+	// OnRetry is called before a failed check is retried.
+	OnRetry func(RetryEvent)
+
+	// OnResult is called for every result, duplicates included, just before
+	// it is sent on the channel returned by Check.
+	OnResult func(Result)
+}
+
+// RequestEvent describes a completed HTTP request.
+type RequestEvent struct {
+	Err        error // Transport error, nil when a response was received
+	Method     string
+	URL        string
+	Proto      string
+	StatusCode int
+	Duration   time.Duration
+}
+
+// RetryEvent describes a retry about to happen.
+type RetryEvent struct {
+	Previous Result        // Result of the failed attempt
+	Link     Link          // Link being retried
+	Attempt  int           // Retry number, starting at 1
+	Delay    time.Duration // Backoff before the retry is sent
+}
+
+// hooksTransport reports every round trip to an OnRequest hook.
+type hooksTransport struct {
+	next      http.RoundTripper
+	onRequest func(RequestEvent)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *hooksTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+
+	event := RequestEvent{
+		Method:   req.Method,
+		URL:      req.URL.String(),
+		Duration: time.Since(start),
+		Err:      err,
+	}
+	if resp != nil {
+		event.StatusCode = resp.StatusCode
+		event.Proto = resp.Proto
+	}
+	t.onRequest(event)
+
+	return resp, err
+}
+
+// logRequest logs a completed HTTP request at debug level.
+func logRequest(log *slog.Logger, e RequestEvent) {
+	args := []any{"method", e.Method, "url", e.URL, "duration", e.Duration}
+	if e.Err != nil {
+		args = append(args, "error", e.Err)
+	} else {
+		args = append(args, "code", e.StatusCode, "proto", e.Proto)
+	}
+	log.Debug("request", args...)
+}
+
+// emit reports r to the OnResult hook and sends it to results.
+func (c *Checker) emit(results chan<- Result, r Result) {
+	if c.opts.Hooks.OnResult != nil {
+		c.opts.Hooks.OnResult(r)
+	}
+	results <- r
+}
@@ -0,0 +1,59 @@
+package checker
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/quic-go/quic-go"
+	"github.com/quic-go/quic-go/http3"
+)
+
+// http3HandshakeTimeout bounds the QUIC handshake, so hosts that don't speak
+// HTTP/3 (or whose UDP traffic is blocked) fall back to TCP quickly.
+const http3HandshakeTimeout = 2 * time.Second
+
+// http3Transport tries HTTP/3 first for https URLs and falls back to the TCP
+// transport (HTTP/2 or HTTP/1.1) when a request fails over QUIC. Hosts that
+// fail are remembered, so later requests to them go straight to TCP.
+type http3Transport struct {
+	h3      http.RoundTripper
+	tcp     http.RoundTripper
+	noHTTP3 sync.Map // host -> struct{}
+}
+
+// newHTTP3Transport wraps tcp with an HTTP/3 transport using the same TLS
+// settings and dialer.
+func newHTTP3Transport(tcp *http.Transport, dial *dialer) *http3Transport {
+	return &http3Transport{
+		h3: &http3.Transport{
+			TLSClientConfig: tcp.TLSClientConfig.Clone(),
+			QUICConfig:      &quic.Config{HandshakeIdleTimeout: http3HandshakeTimeout},
+			Dial:            dial.DialQUIC,
+		},
+		tcp: tcp,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *http3Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Scheme != "https" {
+		return t.tcp.RoundTrip(req)
+	}
+	if _, failed := t.noHTTP3.Load(req.URL.Host); failed {
+		return t.tcp.RoundTrip(req)
+	}
+
+	resp, err := t.h3.RoundTrip(req)
+	if err == nil {
+		return resp, nil
+	}
+
+	// A canceled request won't do any better over TCP
+	if req.Context().Err() != nil {
+		return nil, err
+	}
+
+	t.noHTTP3.Store(req.URL.Host, struct{}{})
+	return t.tcp.RoundTrip(req)
+}
@@ -0,0 +1,36 @@
+package checker
+
+import "slices"
+
+// IP families accepted by WithIPFamily.
+const (
+	IPFamilyAuto = "auto" // Any address the resolver returns (default)
+	IPFamilyIPv4 = "ipv4"
+	IPFamilyIPv6 = "ipv6"
+)
+
+// IPFamilies returns the names of all IP families.
+func IPFamilies() []string {
+	return []string{IPFamilyAuto, IPFamilyIPv4, IPFamilyIPv6}
+}
+
+// ValidIPFamily reports whether family is one of IPFamilies.
+func ValidIPFamily(family string) bool {
+	return slices.Contains(IPFamilies(), family)
+}
+
+// familyNetwork restricts a "tcp" or "udp" network to the IP family, e.g.
+// "tcp4" for IPFamilyIPv4. Other networks and families are returned as is.
+func familyNetwork(network, family string) string {
+	if network != "tcp" && network != "udp" {
+		return network
+	}
+	switch family {
+	case IPFamilyIPv4:
+		return network + "4"
+	case IPFamilyIPv6:
+		return network + "6"
+	default:
+		return network
+	}
+}
@@ -0,0 +1,36 @@
+package checker
+
+import (
+	"net/url"
+	"strings"
+)
+
+// DefaultLoginPatterns match common login and single sign-on pages.
+// A pattern matches when it appears in the lowercase host and path of a URL.
+var DefaultLoginPatterns = []string{
+	"/login",
+	"/signin",
+	"/sign-in",
+	"/sso/",
+	"/oauth/authorize",
+	"/saml/",
+	"accounts.google.com/",
+	"login.microsoftonline.com/",
+	"login.live.com/",
+	"github.com/session",
+}
+
+// isLoginURL reports whether rawURL looks like a login page.
+func (c *Checker) isLoginURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	target := strings.ToLower(u.Host + u.Path)
+	for _, p := range c.opts.LoginPatterns {
+		if p != "" && strings.Contains(target, strings.ToLower(p)) {
+			return true
+		}
+	}
+	return false
+}
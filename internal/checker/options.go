@@ -1,6 +1,11 @@
 package checker
 
-import "time"
+import (
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+)
 
 // Default values for checker options.
 // These are tuned for optimal performance while maintaining reliability.
@@ -25,12 +30,33 @@ const (
 	DefaultUserAgent = "gone-link-checker/1.0"
 )
 
+// MethodPolicy controls which HTTP method checks links on a host.
+type MethodPolicy int
+
+const (
+	// MethodAuto sends HEAD and falls back to GET on 405 or 501.
+	MethodAuto MethodPolicy = iota
+	// MethodGet always sends GET, for hosts that misbehave on HEAD.
+	MethodGet
+	// MethodHead only sends HEAD, for hosts where GET is expensive.
+	MethodHead
+)
+
 // Options configures the behavior of the link checker.
 type Options struct {
 	// UserAgent is the User-Agent header sent with requests.
 	// Some servers block requests without a proper User-Agent.
 	UserAgent string
 
+	// Headers are sent with every request, overriding the defaults
+	// (including User-Agent). Useful for auth on private link targets.
+	Headers map[string]string
+
+	// DomainHeaders are sent only to specific hosts, on top of Headers.
+	// Keys are domain names and also match their subdomains; the most
+	// specific domain wins. Redirect hops to other hosts don't get them.
+	DomainHeaders map[string]map[string]string
+
 	// Concurrency is the number of concurrent workers checking links.
 	// Higher values = faster checking but more resource usage.
 	Concurrency int
@@ -45,17 +71,147 @@ type Options struct {
 
 	// MaxRedirects is the maximum number of redirects to follow.
 	MaxRedirects int
+
+	// DomainMaxRedirects overrides MaxRedirects for specific hosts.
+	// Keys are domain names and also match their subdomains.
+	DomainMaxRedirects map[string]int
+
+	// DomainMethods sets the method policy for specific hosts; other hosts
+	// use MethodAuto. Keys are domain names and also match their subdomains.
+	// The policy applies to every request of a check, including redirect hops.
+	DomainMethods map[string]MethodPolicy
+
+	// ContentRules assert what the bodies of working pages must contain.
+	// Links that fail a rule are reported as StatusDead.
+	ContentRules []ContentRule
+
+	// Resolvers check links on known hosts through lightweight APIs before
+	// the regular check. The first resolver that handles a URL is used.
+	// DefaultOptions includes DefaultResolvers.
+	Resolvers []Resolver
+
+	// StatusOverrides maps response status codes to the status links
+	// returning them are reported as, replacing the default classification
+	// (e.g. 999 from LinkedIn as StatusAlive, 403 as StatusDead). Overridden
+	// codes skip the browser-headers retry. For redirects, the code of the
+	// final destination is looked up, and StatusAlive means the redirect works.
+	StatusOverrides map[int]LinkStatus
+
+	// RedirectPolicy selects which working redirects are reported as alive
+	// instead of warnings, such as plain http→https upgrades.
+	// Default: RedirectStrict (every redirect is a warning).
+	RedirectPolicy RedirectPolicy
+
+	// RedirectWarnHops is the chain length above which a working redirect
+	// is reported as StatusLongRedirect instead of StatusRedirect.
+	// Zero disables the check.
+	RedirectWarnHops int
+
+	// LoginPatterns identify login and SSO pages. A redirect chain ending on a
+	// URL whose host and path contain one of them is reported as
+	// StatusLoginRequired. Empty disables the check.
+	LoginPatterns []string
+
+	// ProbeHosts enables a pre-flight phase that sends one cheap request per
+	// unique host before checking. Links on unreachable hosts are reported
+	// without further requests, and rate-limited hosts are checked last.
+	ProbeHosts bool
+
+	// FetchBodies fetches the body of working links to inspect page content,
+	// such as robots noindex markers on deprecation stubs.
+	FetchBodies bool
+
+	// CheckAnchors fetches working pages linked with a #fragment and reports
+	// StatusBrokenAnchor when no element on the page has that id or name.
+	CheckAnchors bool
+
+	// DetectSoft404 fetches working pages and reports those that look like
+	// "not found" or parked-domain pages as StatusSuspect.
+	DetectSoft404 bool
+
+	// Insecure skips certificate verification. Certificate problems are
+	// still recorded in Result.TLS, and links that only work this way are
+	// reported as StatusInsecure (a warning) instead of StatusTLSError.
+	Insecure bool
+
+	// ExpandDuplicates copies the primary's full check data (status code,
+	// redirect chain, error, duration, ...) into each duplicate result, so every
+	// occurrence can be reported as a standalone record.
+	ExpandDuplicates bool
+
+	// HTTP3 tries HTTP/3 (QUIC) first for https URLs, falling back to
+	// HTTP/2 or HTTP/1.1 over TCP for hosts that can't be reached over QUIC.
+	HTTP3 bool
+
+	// Profile names the header profile requests are sent with: one of
+	// Profiles() or a key of CustomProfiles. Links answered with 403 are
+	// retried with the browser profiles either way. Empty means ProfileDefault.
+	Profile string
+
+	// CustomProfiles are header sets that can be selected with Profile. They
+	// extend the default profile, so User-Agent and Accept are optional.
+	CustomProfiles map[string]map[string]string
+
+	// EnableCookies keeps a cookie jar for the run, so cookies set by one
+	// response are sent with the next request to the same host. Some sites
+	// only answer a redirect target once the cookie from the first hop is
+	// replayed. Cookies are kept in memory only.
+	EnableCookies bool
+
+	// MaxDuration bounds how long a Check run may take. When it runs out,
+	// in-flight and remaining links are reported as StatusSkipped.
+	// Zero means no limit.
+	MaxDuration time.Duration
+
+	// MaxRequests bounds how many HTTP requests a Check run may send,
+	// redirect hops, retries, probes, and page fetches included. Links left
+	// without requests are reported as StatusSkipped. Zero means no limit.
+	MaxRequests int
+
+	// DNSServer resolves host names with this DNS server (an IP with an
+	// optional port, e.g. "1.1.1.1") instead of the system resolver. Either
+	// way, answers are cached and shared by all workers.
+	DNSServer string
+
+	// IPFamily restricts connections to IPv4 or IPv6 addresses
+	// (IPFamilyIPv4, IPFamilyIPv6), for networks where the other is broken.
+	// Default: IPFamilyAuto (either).
+	IPFamily string
+
+	// StaleAfter flags working links whose Last-Modified header is older
+	// than this as stale, with a hint. Zero disables the check.
+	StaleAfter time.Duration
+
+	// Cache, when set, serves fresh results from earlier runs without new
+	// requests and stores the results of new checks.
+	Cache ResultCache
+
+	// Record, when set, captures every HTTP exchange into the cassette.
+	Record *Cassette
+
+	// Replay, when set, answers every request from the cassette instead of
+	// the network, reproducing a recorded run deterministically.
+	Replay *Cassette
+
+	// Hooks observe requests, retries, and results as the run progresses.
+	Hooks Hooks
+
+	// Logger receives a record of every attempt and retry at info level,
+	// and of every HTTP request at debug level. Nil discards them.
+	Logger *slog.Logger
 }
 
 // DefaultOptions returns optimized default configuration.
 // These defaults prioritize speed while maintaining reasonable reliability.
 func DefaultOptions() Options {
 	return Options{
-		Concurrency:  DefaultConcurrency,
-		Timeout:      DefaultTimeout,
-		MaxRetries:   DefaultMaxRetries,
-		MaxRedirects: DefaultMaxRedirects,
-		UserAgent:    DefaultUserAgent,
+		Concurrency:   DefaultConcurrency,
+		Timeout:       DefaultTimeout,
+		MaxRetries:    DefaultMaxRetries,
+		MaxRedirects:  DefaultMaxRedirects,
+		UserAgent:     DefaultUserAgent,
+		LoginPatterns: slices.Clone(DefaultLoginPatterns),
+		Resolvers:     DefaultResolvers(),
 	}
 }
 
@@ -91,6 +247,261 @@ func (o Options) WithMaxRedirects(n int) Options {
 	return o
 }
 
+// WithDomainMaxRedirects sets per-domain redirect limits.
+// Entries with a non-positive limit are ignored.
+func (o Options) WithDomainMaxRedirects(limits map[string]int) Options {
+	if len(limits) == 0 {
+		return o
+	}
+	merged := make(map[string]int, len(o.DomainMaxRedirects)+len(limits))
+	maps.Copy(merged, o.DomainMaxRedirects)
+	for domain, n := range limits {
+		if n > 0 {
+			merged[normalizeDomain(domain)] = n
+		}
+	}
+	o.DomainMaxRedirects = merged
+	return o
+}
+
+// WithMethodPolicy sets the method policy for the given domains and their
+// subdomains, merged with earlier policies.
+func (o Options) WithMethodPolicy(policy MethodPolicy, domains ...string) Options {
+	if len(domains) == 0 {
+		return o
+	}
+	merged := make(map[string]MethodPolicy, len(o.DomainMethods)+len(domains))
+	maps.Copy(merged, o.DomainMethods)
+	for _, domain := range domains {
+		if domain = normalizeDomain(domain); domain != "" {
+			merged[domain] = policy
+		}
+	}
+	o.DomainMethods = merged
+	return o
+}
+
+// WithContentRules adds rules asserting the content of working pages.
+func (o Options) WithContentRules(rules ...ContentRule) Options {
+	if len(rules) == 0 {
+		return o
+	}
+	o.ContentRules = append(slices.Clone(o.ContentRules), rules...)
+	return o
+}
+
+// WithResolvers adds resolvers, tried before the ones already set.
+func (o Options) WithResolvers(resolvers ...Resolver) Options {
+	o.Resolvers = append(slices.Clone(resolvers), o.Resolvers...)
+	return o
+}
+
+// WithoutResolvers removes the resolvers with the given names.
+func (o Options) WithoutResolvers(names ...string) Options {
+	if len(names) == 0 {
+		return o
+	}
+	o.Resolvers = slices.DeleteFunc(slices.Clone(o.Resolvers), func(r Resolver) bool {
+		return slices.Contains(names, r.Name())
+	})
+	return o
+}
+
+// WithStatusOverrides sets how specific status codes are classified,
+// merged with earlier overrides.
+func (o Options) WithStatusOverrides(overrides map[int]LinkStatus) Options {
+	if len(overrides) == 0 {
+		return o
+	}
+	merged := make(map[int]LinkStatus, len(o.StatusOverrides)+len(overrides))
+	maps.Copy(merged, o.StatusOverrides)
+	maps.Copy(merged, overrides)
+	o.StatusOverrides = merged
+	return o
+}
+
+// WithAcceptStatus reports links returning any of codes as alive.
+func (o Options) WithAcceptStatus(codes []int) Options {
+	overrides := make(map[int]LinkStatus, len(codes))
+	for _, code := range codes {
+		overrides[code] = StatusAlive
+	}
+	return o.WithStatusOverrides(overrides)
+}
+
+// WithHeaders adds headers sent with every request.
+func (o Options) WithHeaders(headers map[string]string) Options {
+	if len(headers) == 0 {
+		return o
+	}
+	merged := make(map[string]string, len(o.Headers)+len(headers))
+	maps.Copy(merged, o.Headers)
+	maps.Copy(merged, headers)
+	o.Headers = merged
+	return o
+}
+
+// WithDomainHeaders adds headers sent only to the given domains and their subdomains.
+// Headers for a domain that already has some are merged with them.
+func (o Options) WithDomainHeaders(headers map[string]map[string]string) Options {
+	if len(headers) == 0 {
+		return o
+	}
+	merged := make(map[string]map[string]string, len(o.DomainHeaders)+len(headers))
+	maps.Copy(merged, o.DomainHeaders)
+	for domain, h := range headers {
+		domain = normalizeDomain(domain)
+		combined := make(map[string]string, len(merged[domain])+len(h))
+		maps.Copy(combined, merged[domain])
+		maps.Copy(combined, h)
+		merged[domain] = combined
+	}
+	o.DomainHeaders = merged
+	return o
+}
+
+// WithRedirectPolicy sets which working redirects are reported as alive.
+func (o Options) WithRedirectPolicy(policy RedirectPolicy) Options {
+	o.RedirectPolicy = policy
+	return o
+}
+
+// WithRedirectWarnHops sets the chain length threshold for long redirect warnings.
+func (o Options) WithRedirectWarnHops(n int) Options {
+	if n >= 0 {
+		o.RedirectWarnHops = n
+	}
+	return o
+}
+
+// WithHostProbe enables or disables the pre-flight host probe.
+func (o Options) WithHostProbe(enabled bool) Options {
+	o.ProbeHosts = enabled
+	return o
+}
+
+// WithLoginPatterns sets the patterns identifying login pages, replacing the defaults.
+func (o Options) WithLoginPatterns(patterns []string) Options {
+	o.LoginPatterns = patterns
+	return o
+}
+
+// WithExpandDuplicates enables or disables copying check data into duplicate results.
+func (o Options) WithExpandDuplicates(enabled bool) Options {
+	o.ExpandDuplicates = enabled
+	return o
+}
+
+// WithFetchBodies enables or disables page body inspection for working links.
+func (o Options) WithFetchBodies(enabled bool) Options {
+	o.FetchBodies = enabled
+	return o
+}
+
+// WithCheckAnchors enables or disables fragment verification for working links.
+func (o Options) WithCheckAnchors(enabled bool) Options {
+	o.CheckAnchors = enabled
+	return o
+}
+
+// WithDetectSoft404 enables or disables the soft 404 heuristic for working links.
+func (o Options) WithDetectSoft404(enabled bool) Options {
+	o.DetectSoft404 = enabled
+	return o
+}
+
+// WithInsecure enables or disables skipping certificate verification.
+func (o Options) WithInsecure(enabled bool) Options {
+	o.Insecure = enabled
+	return o
+}
+
+// WithStaleAfter sets the age above which working links are reported as stale.
+func (o Options) WithStaleAfter(d time.Duration) Options {
+	o.StaleAfter = d
+	return o
+}
+
+// WithHTTP3 enables or disables HTTP/3 with TCP fallback.
+func (o Options) WithHTTP3(enabled bool) Options {
+	o.HTTP3 = enabled
+	return o
+}
+
+// WithProfile sets the header profile requests are sent with.
+func (o Options) WithProfile(name string) Options {
+	o.Profile = name
+	return o
+}
+
+// WithCustomProfiles sets the header sets Profile may name besides the built-in ones.
+func (o Options) WithCustomProfiles(profiles map[string]map[string]string) Options {
+	o.CustomProfiles = profiles
+	return o
+}
+
+// WithEnableCookies enables or disables the run's cookie jar.
+func (o Options) WithEnableCookies(enabled bool) Options {
+	o.EnableCookies = enabled
+	return o
+}
+
+// WithMaxDuration sets how long a Check run may take (0 for no limit).
+func (o Options) WithMaxDuration(d time.Duration) Options {
+	o.MaxDuration = d
+	return o
+}
+
+// WithMaxRequests sets how many requests a Check run may send (0 for no limit).
+func (o Options) WithMaxRequests(n int) Options {
+	o.MaxRequests = n
+	return o
+}
+
+// WithDNSServer resolves host names with the DNS server at addr, e.g.
+// "1.1.1.1" or "1.1.1.1:53"; "" uses the system resolver.
+func (o Options) WithDNSServer(addr string) Options {
+	o.DNSServer = addr
+	return o
+}
+
+// WithIPFamily restricts connections to "ipv4" or "ipv6" addresses; "auto"
+// (or "") allows either.
+func (o Options) WithIPFamily(family string) Options {
+	o.IPFamily = family
+	return o
+}
+
+// WithCache sets the cache used to skip recently checked URLs.
+func (o Options) WithCache(cache ResultCache) Options {
+	o.Cache = cache
+	return o
+}
+
+// WithRecord records every HTTP exchange into c.
+func (o Options) WithRecord(c *Cassette) Options {
+	o.Record = c
+	return o
+}
+
+// WithReplay answers requests from c instead of the network.
+func (o Options) WithReplay(c *Cassette) Options {
+	o.Replay = c
+	return o
+}
+
+// WithHooks sets the callbacks that observe the run.
+func (o Options) WithHooks(h Hooks) Options {
+	o.Hooks = h
+	return o
+}
+
+// WithLogger sets the logger attempts, retries, and requests are logged to.
+func (o Options) WithLogger(l *slog.Logger) Options {
+	o.Logger = l
+	return o
+}
+
 // WithUserAgent sets the User-Agent header.
 func (o Options) WithUserAgent(ua string) Options {
 	if ua != "" {
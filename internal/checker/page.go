@@ -0,0 +1,111 @@
+package checker
+
+import (
+	"context"
+	"io"
+	"net/http"
+)
+
+// maxPageBytes caps how much of a page body is read for content inspection.
+// Robots meta tags live in <head>, so the first megabyte is plenty.
+const maxPageBytes = 1 << 20
+
+// page is a fetched response body used for content inspection.
+type page struct {
+	Header     http.Header
+	Body       []byte
+	StatusCode int
+}
+
+// pageBody is the body of a link's target, fetched once for all the body
+// checks of the link, up to the largest limit among them.
+type pageBody struct {
+	c       *Checker
+	page    *page
+	err     error
+	url     string
+	limit   int64
+	fetched bool
+}
+
+// newPageBody prepares the fetch of the target of result for the body checks
+// that apply to it. Nothing is fetched until a check asks for the body.
+func (c *Checker) newPageBody(result *Result) *pageBody {
+	target := result.Link.URL
+	if result.FinalURL != "" {
+		target = result.FinalURL
+	}
+
+	var limit int64
+	if c.opts.DetectSoft404 || c.opts.FetchBodies {
+		limit = maxPageBytes
+	}
+	if len(c.contentRules(result.Link.URL)) > 0 {
+		limit = max(limit, maxContentBytes)
+	}
+	if c.opts.CheckAnchors && verifiableFragment(fragmentOf(result.Link.URL)) {
+		limit = max(limit, maxAnchorPageBytes)
+	}
+	return &pageBody{c: c, url: stripFragment(target), limit: limit}
+}
+
+// get returns the page with at most limit bytes of its body, fetching it on
+// first use. Each check keeps to its own limit, whatever was fetched.
+func (b *pageBody) get(ctx context.Context, limit int64) (*page, error) {
+	if !b.fetched {
+		b.fetched = true
+		b.page, b.err = b.c.fetchPage(ctx, b.url, max(b.limit, limit))
+	}
+	if b.err != nil {
+		return nil, b.err
+	}
+	p := *b.page
+	if int64(len(p.Body)) > limit {
+		p.Body = p.Body[:limit]
+	}
+	return &p, nil
+}
+
+// fetchPage performs a GET request and reads up to limit bytes of the body.
+func (c *Checker) fetchPage(ctx context.Context, urlStr string, limit int64) (*page, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, urlStr, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	c.setProfileHeaders(req, c.profile())
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,*/*;q=0.8")
+	c.setCustomHeaders(req)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, limit))
+	if err != nil {
+		return nil, err
+	}
+
+	return &page{StatusCode: resp.StatusCode, Header: resp.Header, Body: body}, nil
+}
+
+// inspectPage reads the body of a working link and records content hints.
+// Failures are ignored: inspection never changes a link's status.
+func (c *Checker) inspectPage(ctx context.Context, result *Result, body *pageBody) {
+	p, err := body.get(ctx, maxPageBytes)
+	if err != nil || p.StatusCode < 200 || p.StatusCode >= 300 {
+		return
+	}
+
+	if result.LastModified.IsZero() {
+		recordLastModified(result, p.Header)
+	}
+
+	result.Robots = robotsDirectives(p.Header, p.Body)
+	if hint := robotsHint(result.Robots); hint != "" {
+		result.Hints = append(result.Hints, hint)
+	}
+}
@@ -0,0 +1,206 @@
+package checker
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+)
+
+// HostProbe is the outcome of a pre-flight request to a single host.
+type HostProbe struct {
+	Host        string        // Host (with port, if any) that was probed
+	Error       string        // Transport error, if the host could not be reached
+	StatusCode  int           // Status code of the probe response (0 on error)
+	Duration    time.Duration // Time taken by the probe request
+	Reachable   bool          // Whether the host answered at all
+	RateLimited bool          // Whether the host answered 429 Too Many Requests
+}
+
+// ProbeReport summarizes a pre-flight probe run.
+type ProbeReport struct {
+	Hosts    []HostProbe   // Probe results sorted by host
+	Duration time.Duration // Wall time of the whole probe phase
+}
+
+// Unreachable returns the number of hosts that could not be reached.
+func (r ProbeReport) Unreachable() int {
+	n := 0
+	for _, h := range r.Hosts {
+		if !h.Reachable {
+			n++
+		}
+	}
+	return n
+}
+
+// RateLimited returns the number of hosts that answered with 429.
+func (r ProbeReport) RateLimited() int {
+	n := 0
+	for _, h := range r.Hosts {
+		if h.RateLimited {
+			n++
+		}
+	}
+	return n
+}
+
+// ProbeReport returns the result of the last pre-flight probe, if one ran.
+func (c *Checker) ProbeReport() (ProbeReport, bool) {
+	c.probeMu.Lock()
+	defer c.probeMu.Unlock()
+	if c.probe == nil {
+		return ProbeReport{}, false
+	}
+	return *c.probe, true
+}
+
+// ProbeHosts sends one HEAD request to the root of every unique host in links.
+// Any HTTP response counts as reachable; only transport errors mark a host
+// down, once the retries of Options.MaxRetries failed too.
+func (c *Checker) ProbeHosts(ctx context.Context, links []Link) ProbeReport {
+	start := time.Now()
+
+	targets := map[string]string{} // host -> probe URL
+	for _, link := range links {
+		u, err := url.Parse(link.URL)
+		if err != nil || u.Host == "" {
+			continue
+		}
+		if _, ok := targets[u.Host]; !ok {
+			targets[u.Host] = u.Scheme + "://" + u.Host + "/"
+		}
+	}
+
+	jobs := make(chan string, len(targets))
+	for host := range targets {
+		jobs <- host
+	}
+	close(jobs)
+
+	var (
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+		hosts = make([]HostProbe, 0, len(targets))
+	)
+	for range min(c.opts.Concurrency, len(targets)) {
+		wg.Go(func() {
+			for host := range jobs {
+				probe := c.probeHost(ctx, host, targets[host])
+				mu.Lock()
+				hosts = append(hosts, probe)
+				mu.Unlock()
+			}
+		})
+	}
+	wg.Wait()
+
+	sort.Slice(hosts, func(i, j int) bool {
+		return hosts[i].Host < hosts[j].Host
+	})
+
+	report := ProbeReport{Hosts: hosts, Duration: time.Since(start)}
+
+	c.probeMu.Lock()
+	c.probe = &report
+	c.probeMu.Unlock()
+
+	return report
+}
+
+// probeHost performs the pre-flight request for a single host, retried
+// with backoff like the checks of links, so a single timeout or reset
+// doesn't mark the host down.
+func (c *Checker) probeHost(ctx context.Context, host, probeURL string) HostProbe {
+	start := time.Now()
+	method := http.MethodHead
+	if c.methodPolicy(probeURL) == MethodGet {
+		method = http.MethodGet
+	}
+
+	var (
+		statusCode int
+		reachable  bool
+		err        error
+	)
+	for attempt := 0; attempt <= c.opts.MaxRetries; attempt++ {
+		// Replayed responses don't need time to recover, so skip the backoff
+		if attempt > 0 && c.opts.Replay == nil && !sleep(ctx, backoffDelay(attempt)) {
+			break
+		}
+		statusCode, _, err = c.doRequest(ctx, method, probeURL, c.profile())
+
+		// A host with a bad certificate answers; its links are checked to report the problem
+		reachable = err == nil || tlsProblem(err, host) != nil
+		if reachable || ctx.Err() != nil {
+			break
+		}
+	}
+
+	probe := HostProbe{
+		Host:       host,
+		StatusCode: statusCode,
+		Duration:   time.Since(start),
+		Reachable:  reachable,
+	}
+	if err != nil {
+		probe.Error = err.Error()
+	}
+	probe.RateLimited = statusCode == http.StatusTooManyRequests
+	return probe
+}
+
+// sleep waits for d, and reports whether it did before ctx was done.
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// applyProbe splits links into those that still need checking and results for
+// links on unreachable hosts. Links on rate-limited hosts are moved to the end
+// of the queue so the host has time to recover.
+func applyProbe(report ProbeReport, links []Link) (pending []Link, skipped []Result) {
+	byHost := make(map[string]HostProbe, len(report.Hosts))
+	for _, h := range report.Hosts {
+		byHost[h.Host] = h
+	}
+
+	pending = make([]Link, 0, len(links))
+	var deferred []Link
+	for _, link := range links {
+		probe, ok := byHost[hostPort(link.URL)]
+		switch {
+		case !ok:
+			pending = append(pending, link)
+		case !probe.Reachable:
+			skipped = append(skipped, Result{
+				Link:   link,
+				Status: StatusError,
+				Error:  "host unreachable (pre-flight probe): " + probe.Error,
+			})
+		case probe.RateLimited:
+			deferred = append(deferred, link)
+		default:
+			pending = append(pending, link)
+		}
+	}
+
+	return append(pending, deferred...), skipped
+}
+
+// hostPort returns the host (including port) of a URL, or "" if it cannot be parsed.
+func hostPort(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
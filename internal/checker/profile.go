@@ -0,0 +1,106 @@
+package checker
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Built-in header profiles, selected with Options.Profile.
+const (
+	ProfileDefault = "default" // Options.UserAgent, accepting anything
+	ProfileChrome  = "chrome"  // Desktop Chrome, including its Sec-Fetch headers
+	ProfileFirefox = "firefox" // Desktop Firefox
+	ProfileCurl    = "curl"    // The curl command-line tool
+)
+
+// FirefoxUserAgent is the User-Agent of the firefox profile.
+const FirefoxUserAgent = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:121.0) Gecko/20100101 Firefox/121.0"
+
+// CurlUserAgent is the User-Agent of the curl profile.
+const CurlUserAgent = "curl/8.5.0"
+
+// builtinProfiles maps the built-in profiles to the headers they send. The
+// default profile's User-Agent is Options.UserAgent.
+var builtinProfiles = map[string]map[string]string{
+	ProfileDefault: {
+		"Accept": "*/*",
+	},
+	ProfileChrome: {
+		"User-Agent":                BrowserUserAgent,
+		"Accept":                    "text/html,application/xhtml+xml,application/xml;q=0.9,image/webp,*/*;q=0.8",
+		"Accept-Language":           "en-US,en;q=0.9",
+		"Accept-Encoding":           "gzip, deflate, br",
+		"Connection":                "keep-alive",
+		"Upgrade-Insecure-Requests": "1",
+		"Sec-Fetch-Dest":            "document",
+		"Sec-Fetch-Mode":            "navigate",
+		"Sec-Fetch-Site":            "none",
+		"Sec-Fetch-User":            "?1",
+		"Cache-Control":             "max-age=0",
+	},
+	ProfileFirefox: {
+		"User-Agent":                FirefoxUserAgent,
+		"Accept":                    "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,*/*;q=0.8",
+		"Accept-Language":           "en-US,en;q=0.5",
+		"Accept-Encoding":           "gzip, deflate, br",
+		"Upgrade-Insecure-Requests": "1",
+		"Sec-Fetch-Dest":            "document",
+		"Sec-Fetch-Mode":            "navigate",
+		"Sec-Fetch-Site":            "none",
+		"Sec-Fetch-User":            "?1",
+	},
+	ProfileCurl: {
+		"User-Agent": CurlUserAgent,
+		"Accept":     "*/*",
+	},
+}
+
+// blockedFallbacks are the profiles tried, in order, after a 403. Servers
+// that only turn away bots usually let one of them through.
+var blockedFallbacks = []string{ProfileChrome, ProfileFirefox}
+
+// Profiles returns the names of the built-in header profiles.
+func Profiles() []string {
+	return []string{ProfileDefault, ProfileChrome, ProfileFirefox, ProfileCurl}
+}
+
+// IsBuiltinProfile reports whether name is a built-in header profile.
+func IsBuiltinProfile(name string) bool {
+	_, ok := builtinProfiles[name]
+	return ok
+}
+
+// ValidProfile reports whether name is a built-in profile or one of custom.
+func ValidProfile(name string, custom map[string]map[string]string) bool {
+	_, ok := custom[name]
+	return ok || IsBuiltinProfile(name)
+}
+
+// profile returns the name of the profile first requests are sent with.
+func (c *Checker) profile() string {
+	if c.opts.Profile == "" {
+		return ProfileDefault
+	}
+	return c.opts.Profile
+}
+
+// setProfileHeaders sets the headers of the named profile on req. Custom
+// profiles start from the default one, so they only need the headers they change.
+func (c *Checker) setProfileHeaders(req *http.Request, name string) {
+	headers, custom := c.opts.CustomProfiles[name]
+	if !custom {
+		headers = builtinProfiles[name]
+	}
+	if custom || name == ProfileDefault {
+		req.Header.Set("User-Agent", c.opts.UserAgent)
+		req.Header.Set("Accept", "*/*")
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+}
+
+// blockedHint explains which profiles a server turned away.
+func blockedHint(tried []string) string {
+	return "403 with every header profile tried: " + strings.Join(tried, ", ")
+}
@@ -0,0 +1,133 @@
+package checker
+
+import (
+	"net/url"
+	"strings"
+)
+
+// RedirectPolicy controls which working redirects are reported as warnings.
+type RedirectPolicy int
+
+const (
+	// RedirectStrict reports every working redirect as a warning.
+	RedirectStrict RedirectPolicy = iota
+	// RedirectIgnoreHTTPSUpgrade reports redirects that only switch from
+	// http to https as alive.
+	RedirectIgnoreHTTPSUpgrade
+	// RedirectLenient reports redirects that only switch to https or add or
+	// remove a trailing slash as alive.
+	RedirectLenient
+)
+
+// redirectPolicyNames are the names accepted by ParseRedirectPolicy.
+var redirectPolicyNames = [...]string{
+	RedirectStrict:             "strict",
+	RedirectIgnoreHTTPSUpgrade: "ignore-https-upgrade",
+	RedirectLenient:            "lenient",
+}
+
+// String returns the name of the policy.
+func (p RedirectPolicy) String() string {
+	if p >= 0 && int(p) < len(redirectPolicyNames) {
+		return redirectPolicyNames[p]
+	}
+	return "unknown"
+}
+
+// ParseRedirectPolicy returns the policy whose String() is s.
+func ParseRedirectPolicy(s string) (RedirectPolicy, bool) {
+	for i, name := range redirectPolicyNames {
+		if name == s {
+			return RedirectPolicy(i), true
+		}
+	}
+	return RedirectStrict, false
+}
+
+// RedirectPolicyNames returns the names of all redirect policies.
+func RedirectPolicyNames() []string {
+	return redirectPolicyNames[:]
+}
+
+// classifyRedirect returns the status for a working redirect chain. Chains
+// the RedirectPolicy considers harmless are alive, chains longer than
+// RedirectWarnHops are StatusLongRedirect, and others StatusRedirect.
+// Redirects to another domain get a hint.
+func (c *Checker) classifyRedirect(result *Result) LinkStatus {
+	if from, to := hostOf(result.Link.URL), hostOf(result.FinalURL); from != "" && to != "" &&
+		strings.TrimPrefix(from, "www.") != strings.TrimPrefix(to, "www.") {
+		result.Hints = append(result.Hints, "redirects to another domain: "+to)
+	}
+
+	if c.harmlessRedirect(result.RedirectChain, result.FinalURL) {
+		return StatusAlive
+	}
+	if c.opts.RedirectWarnHops > 0 && len(result.RedirectChain) > c.opts.RedirectWarnHops {
+		return StatusLongRedirect
+	}
+	return StatusRedirect
+}
+
+// harmlessRedirect reports whether every hop of a chain is one the
+// RedirectPolicy reports as alive.
+func (c *Checker) harmlessRedirect(chain []Redirect, finalURL string) bool {
+	if c.opts.RedirectPolicy == RedirectStrict || len(chain) == 0 {
+		return false
+	}
+	for i, hop := range chain {
+		next := finalURL
+		if i+1 < len(chain) {
+			next = chain[i+1].URL
+		}
+		if !harmlessHop(c.opts.RedirectPolicy, hop.URL, next) {
+			return false
+		}
+	}
+	return true
+}
+
+// harmlessHop reports whether a redirect from one URL to another only makes
+// changes the policy allows.
+func harmlessHop(policy RedirectPolicy, from, to string) bool {
+	a, err := url.Parse(from)
+	if err != nil {
+		return false
+	}
+	b, err := url.Parse(to)
+	if err != nil {
+		return false
+	}
+
+	upgrade := strings.EqualFold(a.Scheme, "http") && strings.EqualFold(b.Scheme, "https")
+	if !upgrade && !strings.EqualFold(a.Scheme, b.Scheme) {
+		return false
+	}
+	if !strings.EqualFold(a.Hostname(), b.Hostname()) || effectivePort(a) != effectivePort(b) ||
+		a.RawQuery != b.RawQuery {
+		return false
+	}
+
+	pathA, pathB := a.EscapedPath(), b.EscapedPath()
+	switch policy {
+	case RedirectIgnoreHTTPSUpgrade:
+		return upgrade && strings.TrimSuffix(pathA, "/") == strings.TrimSuffix(pathB, "/") &&
+			(pathA == pathB || pathA == "" || pathB == "")
+	case RedirectLenient:
+		return (upgrade || pathA != pathB) && strings.TrimSuffix(pathA, "/") == strings.TrimSuffix(pathB, "/")
+	default:
+		return false
+	}
+}
+
+// effectivePort returns the port of a URL, or "" when it is the default
+// port of its scheme, so that http://host:80 → https://host counts as an
+// upgrade.
+func effectivePort(u *url.URL) string {
+	switch port := u.Port(); {
+	case port == "80" && strings.EqualFold(u.Scheme, "http"),
+		port == "443" && strings.EqualFold(u.Scheme, "https"):
+		return ""
+	default:
+		return port
+	}
+}
@@ -0,0 +1,219 @@
+package checker
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Resolver checks links on a known host through a lightweight API instead of
+// the page itself. Sites such as GitHub and YouTube often answer automated
+// page requests with 403 or 429, while their APIs answer reliably.
+type Resolver interface {
+	// Name identifies the resolver, e.g. "github". It is recorded in
+	// Result.Resolver for links it resolved.
+	Name() string
+
+	// Request returns the API request that checks link, or nil when the
+	// resolver doesn't handle the URL.
+	Request(ctx context.Context, link *url.URL) *http.Request
+}
+
+// DefaultResolvers returns the built-in resolvers for GitHub, npm, PyPI, and
+// YouTube.
+func DefaultResolvers() []Resolver {
+	return []Resolver{githubResolver{}, npmResolver{}, pypiResolver{}, youtubeResolver{}}
+}
+
+// resolve checks a link with the first resolver that handles it. The API's
+// answer is only trusted when it is conclusive: 2xx means alive, 404 and 410
+// mean dead. Anything else, such as an API rate limit, returns false so the
+// link gets the regular check.
+func (c *Checker) resolve(ctx context.Context, link Link) (Result, bool) {
+	if len(c.opts.Resolvers) == 0 {
+		return Result{}, false
+	}
+	u, err := url.Parse(link.URL)
+	if err != nil {
+		return Result{}, false
+	}
+
+	for _, r := range c.opts.Resolvers {
+		req := r.Request(ctx, u)
+		if req == nil {
+			continue
+		}
+		if req.Header.Get("User-Agent") == "" {
+			req.Header.Set("User-Agent", c.opts.UserAgent)
+		}
+		c.setCustomHeaders(req)
+
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return Result{}, false
+		}
+		_ = resp.Body.Close()
+
+		result := Result{Link: link, StatusCode: resp.StatusCode, Protocol: resp.Proto, Resolver: r.Name()}
+		switch {
+		case resp.StatusCode >= 200 && resp.StatusCode < 300:
+			result.Status = StatusAlive
+		case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+			result.Status = StatusDead
+		default:
+			return Result{}, false
+		}
+		return result, true
+	}
+	return Result{}, false
+}
+
+// newAPIRequest builds a GET request for a resolver, or nil if rawURL is invalid.
+func newAPIRequest(ctx context.Context, rawURL, accept string) *http.Request {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, http.NoBody)
+	if err != nil {
+		return nil
+	}
+	req.Header.Set("Accept", accept)
+	return req
+}
+
+// pathSegments splits a URL path into its non-empty segments.
+func pathSegments(u *url.URL) []string {
+	return strings.FieldsFunc(u.Path, func(r rune) bool { return r == '/' })
+}
+
+// githubReserved are the first path segments on github.com that aren't users
+// or organizations.
+var githubReserved = map[string]bool{
+	"about": true, "apps": true, "collections": true, "contact": true, "customer-stories": true,
+	"enterprise": true, "events": true, "explore": true, "features": true, "login": true,
+	"marketplace": true, "new": true, "notifications": true, "orgs": true, "pricing": true,
+	"pulls": true, "issues": true, "search": true, "security": true, "settings": true,
+	"sponsors": true, "topics": true, "trending": true,
+}
+
+// githubResolver checks repositories, issues, and pull requests through
+// api.github.com. Unauthenticated API requests are rate limited; send a token
+// with a domain header for api.github.com to raise the limit.
+type githubResolver struct{}
+
+// Name implements Resolver.
+func (githubResolver) Name() string { return "github" }
+
+// Request implements Resolver. Links into files or other repository pages
+// aren't handled, because the repository existing doesn't mean they do.
+func (githubResolver) Request(ctx context.Context, link *url.URL) *http.Request {
+	host := strings.ToLower(link.Hostname())
+	if host != "github.com" && host != "www.github.com" {
+		return nil
+	}
+	seg := pathSegments(link)
+	if len(seg) < 2 || githubReserved[strings.ToLower(seg[0])] {
+		return nil
+	}
+	repo := "https://api.github.com/repos/" + seg[0] + "/" + strings.TrimSuffix(seg[1], ".git")
+
+	const accept = "application/vnd.github+json"
+	switch {
+	case len(seg) == 2:
+		return newAPIRequest(ctx, repo, accept)
+	case len(seg) == 4 && seg[2] == "issues" && isDigits(seg[3]):
+		return newAPIRequest(ctx, repo+"/issues/"+seg[3], accept)
+	case len(seg) == 4 && seg[2] == "pull" && isDigits(seg[3]):
+		return newAPIRequest(ctx, repo+"/pulls/"+seg[3], accept)
+	default:
+		return nil
+	}
+}
+
+// npmResolver checks package pages on npmjs.com through the registry.
+type npmResolver struct{}
+
+// Name implements Resolver.
+func (npmResolver) Name() string { return "npm" }
+
+// Request implements Resolver.
+func (npmResolver) Request(ctx context.Context, link *url.URL) *http.Request {
+	host := strings.ToLower(link.Hostname())
+	if host != "www.npmjs.com" && host != "npmjs.com" {
+		return nil
+	}
+	seg := pathSegments(link)
+	if len(seg) < 2 || seg[0] != "package" {
+		return nil
+	}
+	name := seg[1]
+	if strings.HasPrefix(name, "@") {
+		if len(seg) < 3 {
+			return nil
+		}
+		name += "%2F" + seg[2]
+	}
+	return newAPIRequest(ctx, "https://registry.npmjs.org/"+name, "application/vnd.npm.install-v1+json")
+}
+
+// pypiResolver checks project pages on pypi.org through its JSON API.
+type pypiResolver struct{}
+
+// Name implements Resolver.
+func (pypiResolver) Name() string { return "pypi" }
+
+// Request implements Resolver.
+func (pypiResolver) Request(ctx context.Context, link *url.URL) *http.Request {
+	if strings.ToLower(link.Hostname()) != "pypi.org" {
+		return nil
+	}
+	seg := pathSegments(link)
+	switch {
+	case len(seg) == 2 && seg[0] == "project":
+		return newAPIRequest(ctx, "https://pypi.org/pypi/"+seg[1]+"/json", "application/json")
+	case len(seg) == 3 && seg[0] == "project":
+		return newAPIRequest(ctx, "https://pypi.org/pypi/"+seg[1]+"/"+seg[2]+"/json", "application/json")
+	default:
+		return nil
+	}
+}
+
+// youtubeVideoID matches the ids of YouTube videos.
+var youtubeVideoID = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+
+// youtubeResolver checks videos through YouTube's oEmbed endpoint, which
+// answers 404 for videos that were removed.
+type youtubeResolver struct{}
+
+// Name implements Resolver.
+func (youtubeResolver) Name() string { return "youtube" }
+
+// Request implements Resolver.
+func (youtubeResolver) Request(ctx context.Context, link *url.URL) *http.Request {
+	var id string
+	switch strings.ToLower(link.Hostname()) {
+	case "youtube.com", "www.youtube.com", "m.youtube.com":
+		if strings.TrimSuffix(link.Path, "/") == "/watch" {
+			id = link.Query().Get("v")
+		}
+	case "youtu.be":
+		id = strings.Trim(link.Path, "/")
+	}
+	if !youtubeVideoID.MatchString(id) {
+		return nil
+	}
+	watch := url.QueryEscape("https://www.youtube.com/watch?v=" + id)
+	return newAPIRequest(ctx, "https://www.youtube.com/oembed?format=json&url="+watch, "application/json")
+}
+
+// isDigits reports whether s is a non-empty string of ASCII digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
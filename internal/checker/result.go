@@ -1,6 +1,11 @@
 package checker
 
-import "fmt"
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
 
 // LinkStatus represents the category of a checked link.
 type LinkStatus int
@@ -18,35 +23,70 @@ const (
 	StatusError
 	// StatusDuplicate indicates this link was already checked (references primary result).
 	StatusDuplicate
+	// StatusLongRedirect indicates a working redirect whose chain exceeds the configured hop threshold.
+	StatusLongRedirect
+	// StatusLoginRequired indicates a redirect chain that ends on a login or SSO page.
+	StatusLoginRequired
+	// StatusBrokenAnchor indicates a working page that lacks the anchor named by the URL fragment.
+	StatusBrokenAnchor
+	// StatusTLSError indicates the host's certificate failed verification (expired, wrong host, untrusted).
+	StatusTLSError
+	// StatusInsecure indicates a link that only works with certificate verification skipped (Insecure).
+	StatusInsecure
+	// StatusSuspect indicates a page answering 2xx that looks like a "not found" or parked-domain page.
+	StatusSuspect
+	// StatusSkipped indicates a link that wasn't checked because the run's time or request budget ran out.
+	StatusSkipped
 )
 
 // Pre-defined strings to avoid allocations in String() methods.
 var (
 	statusStrings = [...]string{
-		StatusAlive:     "alive",
-		StatusRedirect:  "redirect",
-		StatusBlocked:   "blocked",
-		StatusDead:      "dead",
-		StatusError:     "error",
-		StatusDuplicate: "duplicate",
+		StatusAlive:         "alive",
+		StatusRedirect:      "redirect",
+		StatusBlocked:       "blocked",
+		StatusDead:          "dead",
+		StatusError:         "error",
+		StatusDuplicate:     "duplicate",
+		StatusLongRedirect:  "long-redirect",
+		StatusLoginRequired: "login-required",
+		StatusBrokenAnchor:  "broken-anchor",
+		StatusTLSError:      "tls-error",
+		StatusInsecure:      "insecure",
+		StatusSuspect:       "suspect",
+		StatusSkipped:       "skipped",
 	}
 
 	statusLabels = [...]string{
-		StatusAlive:     "OK",
-		StatusRedirect:  "REDIRECT",
-		StatusBlocked:   "BLOCKED",
-		StatusDead:      "DEAD",
-		StatusError:     "ERROR",
-		StatusDuplicate: "DUPLICATE",
+		StatusAlive:         "OK",
+		StatusRedirect:      "REDIRECT",
+		StatusBlocked:       "BLOCKED",
+		StatusDead:          "DEAD",
+		StatusError:         "ERROR",
+		StatusDuplicate:     "DUPLICATE",
+		StatusLongRedirect:  "LONG REDIRECT",
+		StatusLoginRequired: "LOGIN REQUIRED",
+		StatusBrokenAnchor:  "BROKEN ANCHOR",
+		StatusTLSError:      "TLS ERROR",
+		StatusInsecure:      "INSECURE",
+		StatusSuspect:       "SUSPECT",
+		StatusSkipped:       "SKIPPED",
 	}
 
 	statusDescriptions = [...]string{
-		StatusAlive:     "Link is working (2xx response)",
-		StatusRedirect:  "URL redirected but final destination works. Consider updating the URL.",
-		StatusBlocked:   "Server returned 403 Forbidden. May be blocking automated requests.",
-		StatusDead:      "Link is broken (4xx/5xx response or redirect leads to dead page)",
-		StatusError:     "Network error (DNS failure, timeout, connection refused)",
-		StatusDuplicate: "This URL appears multiple times. See original occurrence for status.",
+		StatusAlive:         "Link is working (2xx response)",
+		StatusRedirect:      "URL redirected but final destination works. Consider updating the URL.",
+		StatusBlocked:       "Server returned 403 Forbidden. May be blocking automated requests.",
+		StatusDead:          "Link is broken (4xx/5xx response or redirect leads to dead page)",
+		StatusError:         "Network error (DNS failure, timeout, connection refused)",
+		StatusDuplicate:     "This URL appears multiple times. See original occurrence for status.",
+		StatusLongRedirect:  "URL goes through a long redirect chain. Multi-hop chains are fragile; update the URL.",
+		StatusLoginRequired: "URL redirects to a login page. Readers without an account can't see the content.",
+		StatusBrokenAnchor:  "Page works, but the #fragment doesn't match any heading or anchor on it.",
+		StatusTLSError:      "Certificate failed verification (expired, wrong hostname, or untrusted chain).",
+		StatusInsecure:      "Page works, but only with certificate verification skipped. Browsers will warn.",
+		StatusSuspect:       "Page answers 2xx, but looks like a \"not found\" or parked-domain page (soft 404).",
+		StatusSkipped:       "Not checked: the run's time or request budget ran out first.",
 	}
 )
 
@@ -59,6 +99,16 @@ func (s LinkStatus) String() string {
 	return "unknown"
 }
 
+// ParseLinkStatus returns the status whose String() is s.
+func ParseLinkStatus(s string) (LinkStatus, bool) {
+	for i, name := range statusStrings {
+		if name == s {
+			return LinkStatus(i), true
+		}
+	}
+	return 0, false
+}
+
 // Label returns a short label for display (e.g., in badges).
 // Uses pre-defined strings to avoid allocations.
 func (s LinkStatus) Label() string {
@@ -79,8 +129,15 @@ func (s LinkStatus) Description() string {
 
 // Redirect represents a single hop in a redirect chain.
 type Redirect struct {
-	URL        string // The URL that redirected
-	StatusCode int    // The redirect status code (301, 302, 307, 308)
+	URL        string        // The URL that redirected
+	StatusCode int           // The redirect status code (301, 302, 307, 308)
+	Duration   time.Duration // How long the request to URL took
+}
+
+// Suggestion is a proposed replacement URL for a link.
+type Suggestion struct {
+	URL    string // Replacement URL
+	Source string // What produced it (e.g., "redirect", "rewrite")
 }
 
 // Link represents a URL to be checked.
@@ -89,7 +146,12 @@ type Link struct {
 	URL      string // The URL to check
 	FilePath string // Source file where the link was found
 	Text     string // Link text (e.g., "Click here") for display purposes
+	Parser   string // File type of the parser that found the link (md, json, yaml, ...)
+	Type     string // Kind of link (inline, reference, image, autolink, html)
 	Line     int    // Line number in the source file (0 if unknown)
+	Column   int    // Column of the link on Line (1-indexed, in bytes; 0 if unknown)
+	Offset   int    // Byte offset of the same position from the start of the file
+	Context  string // Source line the link is on, trimmed (empty if unknown)
 }
 
 // Result represents the outcome of checking a single link.
@@ -101,6 +163,9 @@ type Result struct {
 	Error       string  // Error message if applicable
 
 	FinalURL string // Final destination URL after following redirects
+	Protocol string // Negotiated protocol of the first response (e.g. "HTTP/2.0", "HTTP/3.0")
+	Resolver string // Name of the Resolver that checked the link through an API, if any
+	Profile  string // Header profile of the request that decided the status, if not the default
 
 	// Redirect info (populated when redirects occurred)
 	RedirectChain []Redirect // Full chain of redirects
@@ -108,6 +173,32 @@ type Result struct {
 	Status        LinkStatus // Computed status category
 	FinalStatus   int        // Status code of final destination
 
+	Duration time.Duration // Time spent checking the URL, including retries (0 for cached results)
+	Timing   Timing        // Phases of the request that decided the status (zero for cached results)
+	Attempts int           // Checks made, 1 plus retries (0 for cached results)
+	Backoff  time.Duration // Time spent waiting between attempts
+
+	// Fix the fixer would apply (populated by the caller, not the checker)
+	Suggestion *Suggestion
+
+	// Content inspection (populated when page bodies are fetched)
+	Robots []string // Robots directives from X-Robots-Tag and <meta name="robots">
+	Hints  []string // Human-readable hints about the target page
+
+	// Resource age (populated for working links whose server sends Last-Modified)
+	LastModified time.Time     // Last-Modified header of the target
+	Age          time.Duration // Time since LastModified, measured against the response Date
+	Stale        bool          // Age exceeds the configured StaleAfter threshold
+
+	// Anchor is the fragment missing from the target page (StatusBrokenAnchor)
+	Anchor string
+
+	// ClosestAnchor is the anchor on the target page closest to Anchor, if
+	// one is close enough (see anchor.Closest)
+	ClosestAnchor string
+
+	// TLS describes the certificate problem (StatusTLSError, or any status with Insecure)
+	TLS *TLSInfo
 }
 
 // IsAlive returns true if the link is considered alive (2xx response).
@@ -116,14 +207,41 @@ func (r Result) IsAlive() bool {
 	return r.Status == StatusAlive
 }
 
-// IsWarning returns true if the link has a warning status (redirect or blocked).
+// IsWarning returns true if the link has a warning status
+// (redirect, long redirect, login required, broken anchor, insecure, suspect, or blocked).
 func (r Result) IsWarning() bool {
-	return r.Status == StatusRedirect || r.Status == StatusBlocked
+	return r.IsRedirect() || r.Status == StatusLoginRequired || r.Status == StatusBrokenAnchor ||
+		r.Status == StatusInsecure || r.Status == StatusSuspect || r.Status == StatusBlocked
 }
 
-// IsDead returns true if the link is dead or errored.
+// IsRedirect returns true if the link redirected to a working destination.
+func (r Result) IsRedirect() bool {
+	return r.Status == StatusRedirect || r.Status == StatusLongRedirect
+}
+
+// ChainLength returns the number of redirect hops followed for this link.
+func (r Result) ChainLength() int {
+	return len(r.RedirectChain)
+}
+
+// IsDead returns true if the link is dead or errored, including certificate errors.
 func (r Result) IsDead() bool {
-	return r.Status == StatusDead || r.Status == StatusError
+	return r.Status == StatusDead || r.Status == StatusError || r.Status == StatusTLSError
+}
+
+// IsNoIndex returns true if the target page is marked noindex.
+func (r Result) IsNoIndex() bool {
+	return slices.Contains(r.Robots, "noindex") || slices.Contains(r.Robots, "none")
+}
+
+// IsStale returns true if the target hasn't changed for longer than the stale threshold.
+func (r Result) IsStale() bool {
+	return r.Stale
+}
+
+// IsSkipped returns true if the link wasn't checked because the run's budget ran out.
+func (r Result) IsSkipped() bool {
+	return r.Status == StatusSkipped
 }
 
 // IsDuplicate returns true if this is a duplicate of another checked link.
@@ -131,6 +249,17 @@ func (r Result) IsDuplicate() bool {
 	return r.Status == StatusDuplicate
 }
 
+// Resolved returns the result with a duplicate's check data taken from its
+// primary, keeping the duplicate's own link. Other results are returned as is.
+func (r Result) Resolved() Result {
+	if !r.IsDuplicate() || r.DuplicateOf == nil {
+		return r
+	}
+	resolved := *r.DuplicateOf
+	resolved.Link = r.Link
+	return resolved
+}
+
 // StatusDisplay returns a formatted string for CLI display.
 func (r Result) StatusDisplay() string {
 	switch r.Status {
@@ -138,6 +267,16 @@ func (r Result) StatusDisplay() string {
 		return fmt.Sprintf("[%d]", r.StatusCode)
 	case StatusRedirect:
 		return "[REDIRECT]"
+	case StatusLongRedirect:
+		return "[LONG REDIRECT]"
+	case StatusLoginRequired:
+		return "[LOGIN REQUIRED]"
+	case StatusBrokenAnchor:
+		return "[BROKEN ANCHOR]"
+	case StatusInsecure:
+		return "[INSECURE]"
+	case StatusSuspect:
+		return "[SUSPECT]"
 	case StatusBlocked:
 		return "[BLOCKED]"
 	case StatusDead:
@@ -147,8 +286,12 @@ func (r Result) StatusDisplay() string {
 		return "[DEAD]"
 	case StatusError:
 		return "[ERROR]"
+	case StatusTLSError:
+		return "[TLS ERROR]"
 	case StatusDuplicate:
 		return "[DUPLICATE]"
+	case StatusSkipped:
+		return "[SKIPPED]"
 	default:
 		return "[???]"
 	}
@@ -167,7 +310,7 @@ func FilterByStatus(results []Result, status LinkStatus) []Result {
 	return filtered
 }
 
-// FilterWarnings returns results with warning status (redirect or blocked).
+// FilterWarnings returns results with warning status (redirect, long redirect, or blocked).
 // Pre-allocates slice capacity based on expected ratio.
 func FilterWarnings(results []Result) []Result {
 	warnings := make([]Result, 0, len(results)/4)
@@ -217,20 +360,40 @@ func FilterDuplicates(results []Result) []Result {
 
 // Summary provides statistics about check results.
 type Summary struct {
-	Total      int // Total links checked (including duplicates)
-	UniqueURLs int // Number of unique URLs actually checked
-	Alive      int // Links that are alive (2xx)
-	Redirects  int // Links that redirect to working pages
-	Blocked    int // Links blocked by 403
-	Dead       int // Links that are dead (4xx/5xx)
-	Errors     int // Links that failed with network errors
-	Duplicates int // Duplicate occurrences
+	Total         int // Total links checked (including duplicates)
+	UniqueURLs    int // Number of unique URLs actually checked
+	Alive         int // Links that are alive (2xx)
+	Redirects     int // Links that redirect to working pages
+	LongRedirects int // Links whose redirect chain exceeds the hop threshold
+	LoginRequired int // Links that redirect to a login page
+	BrokenAnchors int // Links whose #fragment is missing from the target page
+	Insecure      int // Links that only work with certificate verification skipped
+	Suspect       int // Links to 2xx pages that look like soft 404s
+	Blocked       int // Links blocked by 403
+	Dead          int // Links that are dead (4xx/5xx)
+	Errors        int // Links that failed with network or certificate errors
+	TLSErrors     int // Links whose certificate failed verification (included in Errors)
+	Duplicates    int // Duplicate occurrences
+	Skipped       int // Links not checked because the run's budget ran out
+	Retries       int // Retry attempts made across all checked URLs
+	RetriedURLs   int // Checked URLs that needed more than one attempt
+
+	Backoff time.Duration // Total time spent waiting between attempts
+	NoIndex int           // Working links whose target is marked noindex
+	Stale   int           // Working links whose target hasn't changed within the stale threshold
+
+	ByParser   map[string]int // Link occurrences per parser (md, json, yaml, ...)
+	ByLinkType map[string]int // Link occurrences per link type (inline, image, ...)
 }
 
 // Summarize creates a summary from a slice of results.
 // Uses a single pass to count both unique URLs and status categories.
 func Summarize(results []Result) Summary {
-	s := Summary{Total: len(results)}
+	s := Summary{
+		Total:      len(results),
+		ByParser:   map[string]int{},
+		ByLinkType: map[string]int{},
+	}
 
 	// Pre-allocate map with estimated capacity
 	seen := make(map[string]struct{}, len(results))
@@ -242,19 +405,54 @@ func Summarize(results []Result) Summary {
 			s.UniqueURLs++
 		}
 
+		if r.Attempts > 1 && r.Status != StatusDuplicate {
+			s.Retries += r.Attempts - 1
+			s.RetriedURLs++
+			s.Backoff += r.Backoff
+		}
+
+		if r.IsNoIndex() {
+			s.NoIndex++
+		}
+		if r.IsStale() {
+			s.Stale++
+		}
+
+		if r.Link.Parser != "" {
+			s.ByParser[r.Link.Parser]++
+		}
+		if r.Link.Type != "" {
+			s.ByLinkType[r.Link.Type]++
+		}
+
 		switch r.Status {
 		case StatusAlive:
 			s.Alive++
 		case StatusRedirect:
 			s.Redirects++
+		case StatusLongRedirect:
+			s.LongRedirects++
+		case StatusLoginRequired:
+			s.LoginRequired++
+		case StatusBrokenAnchor:
+			s.BrokenAnchors++
+		case StatusInsecure:
+			s.Insecure++
+		case StatusSuspect:
+			s.Suspect++
 		case StatusBlocked:
 			s.Blocked++
 		case StatusDead:
 			s.Dead++
 		case StatusError:
 			s.Errors++
+		case StatusTLSError:
+			s.Errors++
+			s.TLSErrors++
 		case StatusDuplicate:
 			s.Duplicates++
+		case StatusSkipped:
+			s.Skipped++
 		}
 	}
 	return s
@@ -262,7 +460,7 @@ func Summarize(results []Result) Summary {
 
 // HasIssues returns true if there are any warnings or dead links.
 func (s Summary) HasIssues() bool {
-	return s.Redirects > 0 || s.Blocked > 0 || s.Dead > 0 || s.Errors > 0
+	return s.WarningsCount() > 0 || s.Dead > 0 || s.Errors > 0
 }
 
 // HasDeadLinks returns true if there are dead links or errors (exit code 1 condition).
@@ -270,7 +468,54 @@ func (s Summary) HasDeadLinks() bool {
 	return s.Dead > 0 || s.Errors > 0
 }
 
-// WarningsCount returns total warnings (redirects + blocked).
+// WarningsCount returns total warnings
+// (redirects + long redirects + login required + broken anchors + insecure + suspect + blocked).
 func (s Summary) WarningsCount() int {
-	return s.Redirects + s.Blocked
+	return s.Redirects + s.LongRedirects + s.LoginRequired + s.BrokenAnchors + s.Insecure + s.Suspect + s.Blocked
+}
+
+// UniqueURL groups every occurrence of one checked URL.
+type UniqueURL struct {
+	Result    Result // Result of the first (checked) occurrence
+	Locations []Link // All occurrences, including the first
+}
+
+// Count returns how many times the URL occurs.
+func (u UniqueURL) Count() int {
+	return len(u.Locations)
+}
+
+// UniqueDead groups dead and errored results by URL, folding in duplicates of
+// dead links, so each dead URL is listed once with all its locations.
+// Sorted by occurrence count (most referenced first), then URL.
+func UniqueDead(results []Result) []UniqueURL {
+	index := map[string]int{}
+	var unique []UniqueURL
+
+	for _, r := range results {
+		checked := r
+		if r.IsDuplicate() && r.DuplicateOf != nil {
+			checked = *r.DuplicateOf
+		}
+		if !checked.IsDead() {
+			continue
+		}
+
+		i, ok := index[r.Link.URL]
+		if !ok {
+			i = len(unique)
+			index[r.Link.URL] = i
+			unique = append(unique, UniqueURL{Result: checked})
+		}
+		unique[i].Locations = append(unique[i].Locations, r.Link)
+	}
+
+	slices.SortStableFunc(unique, func(a, b UniqueURL) int {
+		if a.Count() != b.Count() {
+			return b.Count() - a.Count()
+		}
+		return strings.Compare(a.Result.Link.URL, b.Result.Link.URL)
+	})
+
+	return unique
 }
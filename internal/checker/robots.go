@@ -0,0 +1,77 @@
+package checker
+
+import (
+	"bytes"
+	"net/http"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// robotsMetaNames are the <meta name> values whose content holds robots directives.
+var robotsMetaNames = []string{"robots", "googlebot"}
+
+// robotsDirectives extracts robots directives from the X-Robots-Tag header and
+// <meta name="robots"> tags in the document head. Directives are lowercased
+// and deduplicated in order of appearance.
+func robotsDirectives(header http.Header, body []byte) []string {
+	var directives []string
+	add := func(content string) {
+		for d := range strings.SplitSeq(content, ",") {
+			d = strings.ToLower(strings.TrimSpace(d))
+			// Keep only the directive name of "unavailable_after: <date>"
+			if name, _, ok := strings.Cut(d, ":"); ok {
+				d = strings.TrimSpace(name)
+			}
+			if d != "" && !slices.Contains(directives, d) {
+				directives = append(directives, d)
+			}
+		}
+	}
+
+	for _, v := range header.Values("X-Robots-Tag") {
+		add(v)
+	}
+
+	z := html.NewTokenizer(bytes.NewReader(body))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return directives
+		case html.StartTagToken, html.SelfClosingTagToken:
+			tok := z.Token()
+			switch tok.DataAtom {
+			case atom.Body:
+				return directives // Robots meta tags only count in <head>
+			case atom.Meta:
+				if slices.Contains(robotsMetaNames, strings.ToLower(attr(tok, "name"))) {
+					add(attr(tok, "content"))
+				}
+			}
+		}
+	}
+}
+
+// attr returns the value of an HTML token attribute, or "" if missing.
+func attr(tok html.Token, key string) string {
+	for _, a := range tok.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// robotsHint turns robots directives into a human-readable hint, or "".
+func robotsHint(directives []string) string {
+	switch {
+	case slices.Contains(directives, "noindex"), slices.Contains(directives, "none"):
+		return "target is marked noindex; it may be a deprecation stub"
+	case slices.Contains(directives, "unavailable_after"):
+		return "target is marked unavailable_after; it may be removed soon"
+	default:
+		return ""
+	}
+}
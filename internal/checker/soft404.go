@@ -0,0 +1,108 @@
+package checker
+
+import (
+	"context"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	// pageTitle and pageHeading capture the <title> and first <h1> of a page.
+	pageTitle   = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	pageHeading = regexp.MustCompile(`(?is)<h1[^>]*>(.*?)</h1>`)
+	htmlTag     = regexp.MustCompile(`<[^>]+>`)
+
+	// notFoundPath matches URL paths of "not found" pages, or pages about them.
+	notFoundPath = regexp.MustCompile(`(?i)404|not[-_]?found`)
+
+	// notFoundText matches titles and headings of "not found" pages: those
+	// starting or ending with "404" or "not found", or saying the page wasn't
+	// found. Titles that only mention 404 or "not found", such as "HTTP 404 -
+	// MDN" or "Module not found error", don't match.
+	notFoundText = regexp.MustCompile(`(?i)^(?:error\s*)?(?:404|not found)\b|\b(?:404|not found)$|` +
+		`page not found|404 not found|` +
+		`page (?:does not|doesn't|no longer) exists?|page (?:is )?(?:no longer available|unavailable|missing)`)
+)
+
+// parkedMarkers appear in the templates of parked and for-sale domains.
+var parkedMarkers = []string{
+	"this domain is for sale",
+	"this domain may be for sale",
+	"buy this domain",
+	"domain is parked",
+	"this domain has been registered",
+	"parked free, courtesy of",
+	"sedoparking.com",
+	"parkingcrew.net",
+	"bodis.com",
+}
+
+// detectSoft404 reports a working link as StatusSuspect when it redirects to
+// a "not found" page, or when the title or main heading of the target page
+// says it wasn't found, or the page is a parked-domain template. Titles of
+// pages linked as being about 404s, such as the MDN page on the status code,
+// aren't checked. Fetch failures are ignored.
+func (c *Checker) detectSoft404(ctx context.Context, result *Result, body *pageBody) {
+	aboutNotFound := notFoundPath.MatchString(urlPath(result.Link.URL))
+	if target := result.FinalURL; target != "" && !aboutNotFound && notFoundPath.MatchString(urlPath(target)) {
+		result.Status = StatusSuspect
+		result.Hints = append(result.Hints, "soft 404: redirects to "+target)
+		return
+	}
+
+	p, err := body.get(ctx, maxPageBytes)
+	if err != nil || p.StatusCode < 200 || p.StatusCode >= 300 {
+		return
+	}
+	if hint := soft404Hint(p.Body, !aboutNotFound); hint != "" {
+		result.Status = StatusSuspect
+		result.Hints = append(result.Hints, hint)
+	}
+}
+
+// soft404Hint returns why a page body looks like a soft 404, or "".
+// Titles and headings are only checked when checkTitle is set.
+func soft404Hint(body []byte, checkTitle bool) string {
+	var headings []*regexp.Regexp
+	if checkTitle {
+		headings = []*regexp.Regexp{pageTitle, pageHeading}
+	}
+	for _, re := range headings {
+		m := re.FindSubmatch(body)
+		if m == nil {
+			continue
+		}
+		text := strings.Join(strings.Fields(html.UnescapeString(htmlTag.ReplaceAllString(string(m[1]), " "))), " ")
+		if notFoundText.MatchString(text) {
+			return "soft 404: page says " + quoteHint(text)
+		}
+	}
+
+	lower := strings.ToLower(string(body))
+	for _, marker := range parkedMarkers {
+		if strings.Contains(lower, marker) {
+			return "soft 404: parked domain (" + quoteHint(marker) + ")"
+		}
+	}
+	return ""
+}
+
+// urlPath returns the path of a URL, or "" if it cannot be parsed.
+func urlPath(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return u.Path
+}
+
+// quoteHint quotes text for a hint, shortening long text.
+func quoteHint(text string) string {
+	const maxLen = 60
+	if r := []rune(text); len(r) > maxLen {
+		text = string(r[:maxLen-3]) + "..."
+	}
+	return `"` + text + `"`
+}
@@ -0,0 +1,94 @@
+package checker
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Calendar units accepted by ParseAge on top of Go durations.
+const (
+	day  = 24 * time.Hour
+	week = 7 * day
+	year = 365 * day
+)
+
+// ParseAge parses an age such as "5y", "6w", or "90d".
+// Plain Go durations ("720h") are accepted too.
+func ParseAge(s string) (time.Duration, error) {
+	units := []struct {
+		suffix string
+		unit   time.Duration
+	}{{"y", year}, {"w", week}, {"d", day}}
+
+	for _, u := range units {
+		n, ok := strings.CutSuffix(s, u.suffix)
+		if !ok {
+			continue
+		}
+		v, err := strconv.ParseFloat(n, 64)
+		if err != nil || v < 0 {
+			return 0, fmt.Errorf("invalid age %q", s)
+		}
+		return time.Duration(v * float64(u.unit)), nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid age %q: use a number with y, w, or d, or a Go duration", s)
+	}
+	return d, nil
+}
+
+// recordLastModified stores the Last-Modified time of a working link and its
+// age. The age is measured against the response Date header when present, so
+// replayed responses report the same age they had when recorded.
+func recordLastModified(result *Result, header http.Header) {
+	lastModified, err := http.ParseTime(header.Get("Last-Modified"))
+	if err != nil {
+		return
+	}
+
+	now := time.Now()
+	if date, err := http.ParseTime(header.Get("Date")); err == nil {
+		now = date
+	}
+
+	result.LastModified = lastModified.UTC()
+	result.Age = max(now.Sub(lastModified), 0)
+}
+
+// markStale flags a working link as stale when its age exceeds StaleAfter.
+func (c *Checker) markStale(result *Result) {
+	if c.opts.StaleAfter <= 0 || result.LastModified.IsZero() || result.Age <= c.opts.StaleAfter {
+		return
+	}
+	if !result.IsAlive() && !result.IsRedirect() {
+		return
+	}
+
+	result.Stale = true
+	result.Hints = append(result.Hints, fmt.Sprintf("Not modified since %s (%s)",
+		result.LastModified.Format(time.DateOnly), FormatAge(result.Age)))
+}
+
+// FormatAge returns a coarse, human-readable age such as "6 years" or "3 months".
+func FormatAge(d time.Duration) string {
+	plural := func(n int, unit string) string {
+		if n == 1 {
+			return "1 " + unit
+		}
+		return fmt.Sprintf("%d %ss", n, unit)
+	}
+
+	switch {
+	case d >= year:
+		return plural(int(d/year), "year")
+	case d >= 30*day:
+		return plural(int(d/(30*day)), "month")
+	default:
+		return plural(int(d/day), "day")
+	}
+}
@@ -0,0 +1,76 @@
+package checker
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// Timing breaks down where the time of a link's request went. Phases that
+// didn't happen, such as DNS, connect, and TLS on a reused connection, are
+// zero. Only the request that decided the status is timed; redirect hops
+// and page fetches aren't included.
+type Timing struct {
+	DNS     time.Duration // Host name lookup
+	Connect time.Duration // TCP connection setup
+	TLS     time.Duration // TLS handshake
+	TTFB    time.Duration // From sending the request to the first response byte
+	Total   time.Duration // From starting the request to the response headers, or the failure
+}
+
+// IsZero reports whether no request was timed, e.g. for cached results.
+func (t Timing) IsZero() bool {
+	return t.Total == 0
+}
+
+// timingTrace records the phases of one request through httptrace. Its
+// callbacks can run on the transport's dial goroutine, hence the lock.
+type timingTrace struct {
+	start, dnsStart, connectStart, tlsStart, wrote time.Time
+	timing                                         Timing
+	mu                                             sync.Mutex
+}
+
+// withTimingTrace returns ctx with a client trace that records into a new
+// timingTrace, started now.
+func withTimingTrace(ctx context.Context) (context.Context, *timingTrace) {
+	t := &timingTrace{start: time.Now()}
+	trace := &httptrace.ClientTrace{
+		DNSStart:             func(httptrace.DNSStartInfo) { t.mark(&t.dnsStart) },
+		DNSDone:              func(httptrace.DNSDoneInfo) { t.since(&t.timing.DNS, &t.dnsStart) },
+		ConnectStart:         func(_, _ string) { t.mark(&t.connectStart) },
+		ConnectDone:          func(_, _ string, _ error) { t.since(&t.timing.Connect, &t.connectStart) },
+		TLSHandshakeStart:    func() { t.mark(&t.tlsStart) },
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { t.since(&t.timing.TLS, &t.tlsStart) },
+		WroteRequest:         func(httptrace.WroteRequestInfo) { t.mark(&t.wrote) },
+		GotFirstResponseByte: func() { t.since(&t.timing.TTFB, &t.wrote) },
+	}
+	return httptrace.WithClientTrace(ctx, trace), t
+}
+
+// mark sets *at to the current time.
+func (t *timingTrace) mark(at *time.Time) {
+	t.mu.Lock()
+	*at = time.Now()
+	t.mu.Unlock()
+}
+
+// since adds the time elapsed since start to *d. Connect adds up when
+// several addresses are tried.
+func (t *timingTrace) since(d *time.Duration, start *time.Time) {
+	t.mu.Lock()
+	if !start.IsZero() {
+		*d += time.Since(*start)
+	}
+	t.mu.Unlock()
+}
+
+// finish returns the recorded timing, with Total measured up to now.
+func (t *timingTrace) finish() Timing {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.timing.Total = time.Since(t.start)
+	return t.timing
+}
@@ -0,0 +1,166 @@
+package checker
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Certificate problems reported in TLSInfo.Problem.
+const (
+	TLSExpired          = "expired"
+	TLSNotYetValid      = "not-yet-valid"
+	TLSHostnameMismatch = "hostname-mismatch"
+	TLSUnknownAuthority = "unknown-authority" // Self-signed or untrusted chain
+	TLSInvalid          = "invalid"
+)
+
+// TLSInfo describes a certificate problem on a link's host.
+type TLSInfo struct {
+	Host    string `json:"host"`             // Host that presented the certificate
+	Problem string `json:"problem"`          // One of the TLS* problem constants
+	Detail  string `json:"detail,omitempty"` // Verification error
+}
+
+// tlsError is a certificate problem replayed from a cassette, which no
+// longer has the verification error it was classified from.
+type tlsError struct {
+	info *TLSInfo
+	msg  string
+}
+
+// Error implements error.
+func (e *tlsError) Error() string {
+	return e.msg
+}
+
+// tlsIssues records the certificate problems an insecure client skipped, by host.
+type tlsIssues struct {
+	byHost   map[string]*TLSInfo
+	verified map[string]bool
+	mu       sync.Mutex
+}
+
+// newTLSIssues creates an empty record.
+func newTLSIssues() *tlsIssues {
+	return &tlsIssues{byHost: map[string]*TLSInfo{}, verified: map[string]bool{}}
+}
+
+// verify checks the certificate chain a host presented as the default
+// client would, once per host, and records any problem. Verification holds
+// the lock, so a result is never looked up while its host is being verified.
+func (t *tlsIssues) verify(host string, cs *tls.ConnectionState) {
+	host = strings.ToLower(host)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.verified[host] || len(cs.PeerCertificates) == 0 {
+		return
+	}
+	t.verified[host] = true
+
+	opts := x509.VerifyOptions{DNSName: host, Intermediates: x509.NewCertPool()}
+	for _, cert := range cs.PeerCertificates[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	if _, err := cs.PeerCertificates[0].Verify(opts); err != nil {
+		if info := tlsProblem(err, host); info != nil {
+			t.byHost[host] = info
+		}
+	}
+}
+
+// record sets the problem of a host, as replayed from a cassette.
+func (t *tlsIssues) record(host string, info *TLSInfo) {
+	host = strings.ToLower(host)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.verified[host] = true
+	t.byHost[host] = info
+}
+
+// lookup returns the problem recorded for the first of hosts that has one.
+func (t *tlsIssues) lookup(hosts ...string) *TLSInfo {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, host := range hosts {
+		if info, ok := t.byHost[host]; ok {
+			return info
+		}
+	}
+	return nil
+}
+
+// tlsVerifyingTransport verifies the certificates of responses received
+// with verification skipped (Insecure) and records their problems.
+type tlsVerifyingTransport struct {
+	next   http.RoundTripper
+	issues *tlsIssues
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *tlsVerifyingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err == nil && resp.TLS != nil {
+		t.issues.verify(req.URL.Hostname(), resp.TLS)
+	}
+	return resp, err
+}
+
+// applyInsecureTLS records a certificate problem skipped with Insecure on
+// the result. Links that only work because verification was skipped are
+// reported as StatusInsecure.
+func (c *Checker) applyInsecureTLS(result *Result) {
+	if c.tls == nil {
+		return
+	}
+	hosts := []string{hostOf(result.Link.URL)}
+	if result.FinalURL != "" {
+		hosts = append(hosts, hostOf(result.FinalURL))
+	}
+	info := c.tls.lookup(hosts...)
+	if info == nil {
+		return
+	}
+	result.TLS = info
+	if result.IsAlive() || result.IsRedirect() {
+		result.Status = StatusInsecure
+	}
+}
+
+// tlsProblem classifies a certificate verification error. It returns nil
+// for errors that aren't about certificates.
+func tlsProblem(err error, host string) *TLSInfo {
+	var (
+		hostname x509.HostnameError
+		unknown  x509.UnknownAuthorityError
+		invalid  x509.CertificateInvalidError
+		verify   *tls.CertificateVerificationError
+		replayed *tlsError
+	)
+	switch {
+	case errors.As(err, &replayed):
+		info := *replayed.info
+		info.Host = host
+		return &info
+	case errors.As(err, &hostname):
+		return &TLSInfo{Host: host, Problem: TLSHostnameMismatch, Detail: hostname.Error()}
+	case errors.As(err, &unknown):
+		return &TLSInfo{Host: host, Problem: TLSUnknownAuthority, Detail: unknown.Error()}
+	case errors.As(err, &invalid) && invalid.Reason == x509.Expired:
+		problem := TLSExpired
+		if invalid.Cert != nil && time.Now().Before(invalid.Cert.NotBefore) {
+			problem = TLSNotYetValid
+		}
+		return &TLSInfo{Host: host, Problem: problem, Detail: invalid.Error()}
+	case errors.As(err, &invalid):
+		return &TLSInfo{Host: host, Problem: TLSInvalid, Detail: invalid.Error()}
+	case errors.As(err, &verify):
+		return &TLSInfo{Host: host, Problem: TLSInvalid, Detail: verify.Err.Error()}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,88 @@
+package checker
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ValidateURL performs offline syntax checks on an HTTP(S) URL.
+// It catches URLs that could never be requested successfully, such as
+// missing hosts, embedded whitespace, or invalid ports, without any network I/O.
+func ValidateURL(rawURL string) error {
+	if rawURL == "" {
+		return errors.New("empty URL")
+	}
+	if strings.ContainsAny(rawURL, " \t\r\n") {
+		return errors.New("URL contains whitespace")
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return urlErr.Err
+		}
+		return err
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return errors.New("missing host")
+	}
+	if strings.Contains(u.Hostname(), "..") || strings.HasPrefix(u.Hostname(), ".") {
+		return fmt.Errorf("invalid host %q", u.Hostname())
+	}
+	if port := u.Port(); port != "" {
+		n, err := strconv.Atoi(port)
+		if err != nil || n < 1 || n > 65535 {
+			return fmt.Errorf("invalid port %q", port)
+		}
+	}
+	return nil
+}
+
+// OfflineSummary describes links that were validated without network access.
+type OfflineSummary struct {
+	Malformed  []Result // One result per malformed link occurrence (StatusError)
+	Total      int
+	UniqueURLs int
+	Duplicates int
+}
+
+// HasIssues reports whether any malformed URLs were found.
+func (s OfflineSummary) HasIssues() bool {
+	return len(s.Malformed) > 0
+}
+
+// ValidateOffline checks links for duplicates and malformed URLs without
+// making any requests. Every occurrence of a malformed URL is reported so
+// each location can be fixed.
+func ValidateOffline(links []Link) OfflineSummary {
+	summary := OfflineSummary{Total: len(links)}
+	seen := make(map[string]error, len(links))
+
+	for _, link := range links {
+		err, ok := seen[link.URL]
+		if ok {
+			summary.Duplicates++
+		} else {
+			err = ValidateURL(link.URL)
+			seen[link.URL] = err
+		}
+		if err != nil {
+			summary.Malformed = append(summary.Malformed, Result{
+				Link:   link,
+				Status: StatusError,
+				Error:  err.Error(),
+			})
+		}
+	}
+
+	summary.UniqueURLs = len(seen)
+	return summary
+}
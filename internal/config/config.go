@@ -4,13 +4,19 @@ package config
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"regexp"
 	"slices"
+	"strings"
+	"time"
 
 	"github.com/gobwas/glob"
 	"gopkg.in/yaml.v3"
+
+	"github.com/leonardomso/gone/internal/checker"
+	"github.com/leonardomso/gone/internal/term"
 )
 
 // DefaultConfigFileName is the default configuration file name.
@@ -19,7 +25,7 @@ const DefaultConfigFileName = ".gonerc.yaml"
 // Config represents the complete configuration structure.
 type Config struct {
 	// Types specifies which file types to scan.
-	// Supported: md, json, yaml, toml, xml
+	// Supported: md, json, yaml, toml, xml, html
 	// If empty, defaults to ["md"] at runtime.
 	Types []string `yaml:"types"`
 
@@ -32,8 +38,32 @@ type Config struct {
 	// Output holds output preferences.
 	Output OutputConfig `yaml:"output"`
 
+	// Cache holds persistent result cache settings.
+	Cache CacheConfig `yaml:"cache"`
+
 	// Ignore holds URL ignore rules (backwards compatible).
 	Ignore IgnoreConfig `yaml:"ignore"`
+
+	// Deny holds URL policy rules; matching links are reported as violations
+	// and fail the run, whether or not they are alive.
+	Deny DenyConfig `yaml:"deny"`
+
+	// Rewrites are URL rewrite rules applied by the fixer without checking.
+	// Example: [{from: "^http://old.docs/(.*)", to: "https://new.docs/$1"}]
+	Rewrites []RewriteConfig `yaml:"rewrites"`
+
+	// Normalize lists the URL normalization rules the fixer applies:
+	// "tracking" (strip utm_* and fbclid parameters), "slashes" (collapse
+	// duplicate slashes), and "trailing-slash" (follow redirects that only
+	// add or remove a trailing slash).
+	// Example: ["tracking", "slashes"]
+	Normalize []string `yaml:"normalize"`
+
+	// Parsers maps file extensions to external parser commands, which get
+	// the file path as their last argument and print its links as JSON.
+	// Their extensions become file types for Types and --types.
+	// Example: {"adoc": "asciidoc-links --json"}
+	Parsers map[string]string `yaml:"parsers"`
 }
 
 // ScanConfig holds scanner settings for file discovery.
@@ -46,6 +76,25 @@ type ScanConfig struct {
 	// Exclude specifies glob patterns for paths to exclude.
 	// Example: ["node_modules/**", "vendor/**", "**/testdata/**"]
 	Exclude []string `yaml:"exclude"`
+
+	// MaxFileSize skips files larger than this many bytes instead of parsing them.
+	// Default: 0 (no limit)
+	MaxFileSize int64 `yaml:"max_file_size"`
+
+	// ParseTimeout abandons parsing a single file after this duration (e.g. "10s").
+	// Default: "" (no limit)
+	ParseTimeout string `yaml:"parse_timeout"`
+
+	// PriorityPaths specifies glob patterns for the most visible files.
+	// Matching files are parsed and checked first, and are exempt from the
+	// parse budget. Example: ["README.md", "docs/**"]
+	PriorityPaths []string `yaml:"priority_paths"`
+
+	// XMLAttributes replaces the attributes whose values the XML parser
+	// reads as links, by qualified name. URLs in element text are found
+	// either way. Default: href, src, url, xlink:href, and other common ones.
+	// Example: ["href", "xlink:href", "resource"]
+	XMLAttributes []string `yaml:"xml_attributes"`
 }
 
 // CheckConfig holds checker settings for URL validation.
@@ -65,15 +114,205 @@ type CheckConfig struct {
 	// Strict fails on malformed files instead of skipping them.
 	// Default: false
 	Strict bool `yaml:"strict"`
+
+	// MaxRedirects is the maximum number of redirects to follow.
+	// Default: 5 (set at runtime if 0)
+	MaxRedirects int `yaml:"max_redirects"`
+
+	// DomainMaxRedirects overrides MaxRedirects for specific domains (and their subdomains).
+	// Example: {"github.com": 10}
+	DomainMaxRedirects map[string]int `yaml:"domain_max_redirects"`
+
+	// Headers are sent with every request, e.g. a custom User-Agent.
+	// Values may reference environment variables ("Bearer ${TOKEN}").
+	Headers map[string]string `yaml:"headers"`
+
+	// DomainHeaders are sent only to specific domains (and their subdomains),
+	// such as auth for GitHub Enterprise, Jira, or internal wikis.
+	// Example: {"github.example.com": {"Authorization": "token ${GHE_TOKEN}"}}
+	DomainHeaders map[string]map[string]string `yaml:"domain_headers"`
+
+	// UAProfile is the header profile requests are sent with: "default",
+	// "chrome", "firefox", "curl", or a key of UAProfiles. Links answered with
+	// 403 are retried with the browser profiles either way.
+	// Default: "default"
+	UAProfile string `yaml:"ua_profile"`
+
+	// UAProfiles are custom header sets that UAProfile can select. They
+	// extend the default profile, so User-Agent and Accept are optional.
+	// Example: {"corp": {"User-Agent": "corp-bot/1.0", "X-Team": "docs"}}
+	UAProfiles map[string]map[string]string `yaml:"ua_profiles"`
+
+	// ForceGetDomains are checked with GET only, skipping the HEAD request,
+	// for hosts that answer HEAD with errors (and their subdomains).
+	ForceGetDomains []string `yaml:"force_get_domains"`
+
+	// HeadOnlyDomains are checked with HEAD only, without the GET fallback
+	// on 405 or 501 (and their subdomains).
+	HeadOnlyDomains []string `yaml:"head_only_domains"`
+
+	// RedirectWarnHops reports working redirects with more hops than this
+	// as "long-redirect" warnings. 0 disables the check.
+	RedirectWarnHops int `yaml:"redirect_warn_hops"`
+
+	// RedirectPolicy selects which working redirects are warnings: "strict"
+	// (all), "ignore-https-upgrade" (not plain http→https upgrades), or
+	// "lenient" (not upgrades or trailing-slash changes).
+	// Default: "strict"
+	RedirectPolicy string `yaml:"redirect_policy"`
+
+	// RecheckInterval is how often long-running modes (watch, serve) re-validate
+	// all known links in the background. Accepts Go durations ("30m") or
+	// cron-like shorthands ("@hourly", "@daily", "@every 2h").
+	// Empty disables periodic rechecks.
+	RecheckInterval string `yaml:"recheck_interval"`
+
+	// RecheckWebhook receives the status changes found by each recheck, as
+	// a JSON POST. ${VARS} are expanded. Requires RecheckInterval.
+	RecheckWebhook string `yaml:"recheck_webhook"`
+
+	// ProbeHosts sends one request per unique host before checking to skip
+	// unreachable hosts and defer rate-limited ones.
+	// Default: false
+	ProbeHosts bool `yaml:"probe_hosts"`
+
+	// InspectPages fetches the body of working links to detect pages marked
+	// noindex (often deprecation stubs) and report them as hints.
+	// Default: false
+	InspectPages bool `yaml:"inspect_pages"`
+
+	// CheckAnchors fetches working pages linked with a #fragment and reports
+	// the link as a broken anchor when no heading or anchor on the page matches.
+	// Default: false
+	CheckAnchors bool `yaml:"check_anchors"`
+
+	// DetectSoft404 fetches working pages and reports those that look like
+	// "not found" or parked-domain pages as "suspect" warnings.
+	// Default: false
+	DetectSoft404 bool `yaml:"detect_soft_404"`
+
+	// CheckInternal resolves relative links between files in the scanned tree
+	// and reports links to missing files or missing heading anchors.
+	// Default: false
+	CheckInternal bool `yaml:"check_internal"`
+
+	// Insecure skips TLS certificate verification. Certificate problems are
+	// still reported, as insecure warnings instead of tls-error dead links.
+	// Default: false
+	Insecure bool `yaml:"insecure"`
+
+	// Lint enables static checks on links, such as link text showing a
+	// different URL than the target.
+	// Default: false
+	Lint bool `yaml:"lint"`
+
+	// HTTP3 tries HTTP/3 (QUIC) first, falling back to HTTP/2 or HTTP/1.1.
+	HTTP3 bool `yaml:"http3"`
+
+	// EnableCookies replays cookies set by earlier responses to the same
+	// host, for redirect chains that only work with them.
+	// Default: false
+	EnableCookies bool `yaml:"enable_cookies"`
+
+	// IPFamily restricts connections to "ipv4" or "ipv6" addresses, for CI
+	// runners with broken IPv6.
+	// Default: "auto" (either)
+	IPFamily string `yaml:"ip_family"`
+
+	// DNSServer resolves host names with this DNS server, an IP with an
+	// optional port ("1.1.1.1", "10.0.0.2:5353"), instead of the system
+	// resolver.
+	DNSServer string `yaml:"dns_server"`
+
+	// MaxDuration bounds how long checking may take (e.g. "5m"), so CI runs
+	// can't hang. Links left unchecked are reported as "skipped".
+	MaxDuration string `yaml:"max_duration"`
+
+	// MaxRequests bounds how many HTTP requests a run may send. Links left
+	// unchecked are reported as "skipped". 0 means no limit.
+	MaxRequests int `yaml:"max_requests"`
+
+	// DisableResolvers turns off built-in API resolvers by name ("github",
+	// "npm", "pypi", "youtube"), so those links get the regular check.
+	DisableResolvers []string `yaml:"disable_resolvers"`
+
+	// LoginPatterns adds to the built-in patterns identifying login and SSO
+	// pages (e.g. "/auth/", "sso.example.com"). Redirects ending on a matching
+	// URL are reported as "login-required".
+	LoginPatterns []string `yaml:"login_patterns"`
+
+	// StaleAfter reports working links whose Last-Modified header is older
+	// than this as stale (e.g. "5y", "18w", "90d").
+	// Default: "" (disabled)
+	StaleAfter string `yaml:"stale_after"`
+
+	// FailOn selects what fails the run: "dead" (dead links, the default),
+	// "warnings" (dead links, then warnings such as redirects and blocked
+	// links), or "none" (never, only errors stopping the run do).
+	// Default: "dead"
+	FailOn string `yaml:"fail_on"`
+
+	// StatusCodes overrides how response status codes are classified,
+	// e.g. 999 (LinkedIn) as alive or 403 as dead.
+	StatusCodes StatusCodesConfig `yaml:"status_codes"`
+
+	// ContentRules assert what working pages must contain, for sites that
+	// answer 200 with a "Not Found" page. Links failing a rule are dead.
+	ContentRules []ContentRuleConfig `yaml:"content_rules"`
+}
+
+// ContentRuleConfig asserts the body of pages whose URL matches Pattern.
+type ContentRuleConfig struct {
+	// Pattern is a glob matched against the link URL, like ignore patterns.
+	Pattern string `yaml:"pattern"`
+
+	// MustContain is text the page must contain.
+	MustContain string `yaml:"must_contain"`
+
+	// MustMatch is a regular expression the page must match.
+	MustMatch string `yaml:"must_match"`
+}
+
+// StatusCodesConfig lists status codes reported as a given status instead of
+// the default classification.
+type StatusCodesConfig struct {
+	// Alive codes are reported as working links.
+	Alive []int `yaml:"alive"`
+
+	// Dead codes are reported as dead links.
+	Dead []int `yaml:"dead"`
+
+	// Blocked codes are reported as blocked (a warning).
+	Blocked []int `yaml:"blocked"`
+}
+
+// IsEmpty returns true if no status code overrides are defined.
+func (s StatusCodesConfig) IsEmpty() bool {
+	return len(s.Alive) == 0 && len(s.Dead) == 0 && len(s.Blocked) == 0
+}
+
+// CacheConfig holds settings for the persistent result cache.
+type CacheConfig struct {
+	// Path is the cache file. Defaults to results.json in the user cache directory.
+	Path string `yaml:"path"`
+
+	// TTL is how long a cached result stays fresh (e.g. "12h"). Defaults to 24h.
+	TTL string `yaml:"ttl"`
+
+	// Enabled makes check serve fresh results from the cache.
+	Enabled bool `yaml:"enabled"`
 }
 
 // OutputConfig holds output preferences for the check command.
 type OutputConfig struct {
 	// Format specifies the default output format.
-	// Valid: json, yaml, xml, junit, markdown
+	// Valid: json, yaml, xml, junit, markdown, shield-json, html, github, csv, ndjson, tap, prometheus, template
 	// Empty means text output to stdout.
 	Format string `yaml:"format"`
 
+	// Template is the Go text/template file the template format renders.
+	Template string `yaml:"template"`
+
 	// ShowAlive shows alive links in output.
 	// Default: false
 	ShowAlive bool `yaml:"showAlive"`
@@ -89,6 +328,44 @@ type OutputConfig struct {
 	// ShowStats shows performance statistics.
 	// Default: false
 	ShowStats bool `yaml:"showStats"`
+
+	// MetricsFile is a file to write Prometheus metrics to after every
+	// check, in addition to the regular output.
+	MetricsFile string `yaml:"metricsFile"`
+
+	// Theme selects the colors of the text output.
+	// Valid: default, ansi, mono
+	// Empty means default.
+	Theme string `yaml:"theme"`
+
+	// GroupBy groups results in text, Markdown, and HTML output.
+	// Valid: status, file, domain
+	// Empty means status.
+	GroupBy string `yaml:"groupBy"`
+
+	// Sort orders results in every format.
+	// Valid: url, status, file, latency
+	// Empty keeps the order links were checked in.
+	Sort string `yaml:"sort"`
+
+	// Top lists only the first results, after sorting.
+	// Default: 0 (all)
+	Top int `yaml:"top"`
+
+	// ReportAll adds a Full Inventory section to Markdown reports, listing
+	// every link by file with its status.
+	// Default: false
+	ReportAll bool `yaml:"reportAll"`
+
+	// JUnitSuitePer groups JUnit test cases into a suite per file or domain.
+	// Valid: file, domain
+	// Empty means file.
+	JUnitSuitePer string `yaml:"junitSuitePer"`
+
+	// JUnitIncludePassed adds links that didn't fail to JUnit reports as
+	// passing test cases.
+	// Default: false
+	JUnitIncludePassed bool `yaml:"junitIncludePassed"`
 }
 
 // IgnoreConfig holds all ignore rules.
@@ -104,14 +381,65 @@ type IgnoreConfig struct {
 	// Regex are regular expression patterns for URL matching.
 	// Example: ".*\\.test$", ".*/v[0-9]+/draft/.*"
 	Regex []string `yaml:"regex"`
+
+	// Badges skips badge images, such as shields.io and CI status badges,
+	// which are generated per request and often rate limited.
+	// Default: false
+	Badges bool `yaml:"badges"`
+}
+
+// DenyConfig holds forbidden URL rules, e.g. internal hostnames or trackers.
+// Rules use the same syntax as IgnoreConfig.
+type DenyConfig struct {
+	// Domains that must not be linked (automatically includes subdomains).
+	Domains []string `yaml:"domains"`
+
+	// Patterns are glob patterns for URL matching.
+	Patterns []string `yaml:"patterns"`
+
+	// Regex are regular expression patterns for URL matching.
+	Regex []string `yaml:"regex"`
+}
+
+// RewriteConfig is a single URL rewrite rule.
+type RewriteConfig struct {
+	// From is a regular expression matched against each URL.
+	From string `yaml:"from"`
+
+	// To is the replacement, which may reference capture groups ($1, ${name}).
+	To string `yaml:"to"`
 }
 
 // validOutputFormats lists all valid output format values.
-var validOutputFormats = []string{"json", "yaml", "xml", "junit", "markdown"}
+var validOutputFormats = []string{
+	"json", "yaml", "xml", "junit", "markdown", "shield-json", "html", "github", "csv", "ndjson", "tap", "prometheus",
+	"template",
+}
+
+// validNormalizeRules lists all valid normalize values.
+var validNormalizeRules = []string{"tracking", "slashes", "trailing-slash"}
+
+// validGroupBy lists all valid output.groupBy values.
+var validGroupBy = []string{"status", "file", "domain"}
+
+// validSortKeys lists all valid output.sort values.
+var validSortKeys = []string{"url", "status", "file", "latency"}
+
+// validJUnitSuitePer lists all valid output.junitSuitePer values.
+var validJUnitSuitePer = []string{"file", "domain"}
+
+// validFailOn lists all valid check.fail_on values.
+var validFailOn = []string{"dead", "warnings", "none"}
 
 // validFileTypes lists all valid file type values.
 // This is duplicated here to avoid circular dependency with parser package.
-var validFileTypes = []string{"md", "json", "yaml", "toml", "xml"}
+var validFileTypes = []string{
+	"md", "json", "yaml", "toml", "xml", "html",
+	"code", "go", "py", "js", "ts", "rs", "java", // Comments in source files
+	"ini", "conf", "env", "properties", // Key-value configuration files
+	"docker", "dockerfile", "compose", // Dockerfiles and Docker Compose files
+	"txt", // Plain text fallback
+}
 
 // Load reads configuration from .gonerc.yaml in the current directory.
 // Returns an empty config if the file doesn't exist (not an error).
@@ -168,12 +496,32 @@ func FindAndLoad(startDir string) (*Config, error) {
 	}
 }
 
+// hasParser reports whether an external parser is configured for the file type t.
+func (c *Config) hasParser(t string) bool {
+	for ext := range c.Parsers {
+		if strings.EqualFold(strings.TrimPrefix(ext, "."), t) {
+			return true
+		}
+	}
+	return false
+}
+
 // Validate checks the configuration for errors.
 // Returns an error if any configuration value is invalid.
 func (c *Config) Validate() error {
+	// Validate external parsers, whose extensions are valid types
+	for ext, command := range c.Parsers {
+		if name := strings.TrimPrefix(ext, "."); name == "" || strings.ContainsAny(name, "./\\ ") {
+			return fmt.Errorf("parsers has invalid extension %q", ext)
+		}
+		if strings.TrimSpace(command) == "" {
+			return fmt.Errorf("parsers[%s] must have a command", ext)
+		}
+	}
+
 	// Validate file types
 	for _, t := range c.Types {
-		if !slices.Contains(validFileTypes, t) {
+		if !slices.Contains(validFileTypes, t) && !c.hasParser(t) {
 			return fmt.Errorf("invalid type %q: valid types are %v", t, validFileTypes)
 		}
 	}
@@ -188,12 +536,164 @@ func (c *Config) Validate() error {
 	if c.Check.Retries < 0 {
 		return fmt.Errorf("check.retries must be >= 0, got %d", c.Check.Retries)
 	}
+	if c.Check.MaxRedirects < 0 {
+		return fmt.Errorf("check.max_redirects must be >= 0, got %d", c.Check.MaxRedirects)
+	}
+	for domain, n := range c.Check.DomainMaxRedirects {
+		if n <= 0 {
+			return fmt.Errorf("check.domain_max_redirects[%s] must be > 0, got %d", domain, n)
+		}
+	}
+	if err := validateHeaders("check.headers", c.Check.Headers); err != nil {
+		return err
+	}
+	for domain, headers := range c.Check.DomainHeaders {
+		if strings.TrimSpace(domain) == "" {
+			return errors.New("check.domain_headers must not contain empty domains")
+		}
+		if err := validateHeaders("check.domain_headers["+domain+"]", headers); err != nil {
+			return err
+		}
+	}
+	for name, headers := range c.Check.UAProfiles {
+		if strings.TrimSpace(name) == "" || checker.IsBuiltinProfile(name) {
+			return fmt.Errorf("check.ua_profiles has invalid profile name %q", name)
+		}
+		if err := validateHeaders("check.ua_profiles["+name+"]", headers); err != nil {
+			return err
+		}
+	}
+	if c.Check.UAProfile != "" && !checker.ValidProfile(c.Check.UAProfile, c.Check.UAProfiles) {
+		return fmt.Errorf("check.ua_profile must be one of %s or a key of check.ua_profiles, got %q",
+			strings.Join(checker.Profiles(), ", "), c.Check.UAProfile)
+	}
+	if _, ok := checker.ParseRedirectPolicy(c.Check.RedirectPolicy); c.Check.RedirectPolicy != "" && !ok {
+		return fmt.Errorf("check.redirect_policy must be one of %s, got %q",
+			strings.Join(checker.RedirectPolicyNames(), ", "), c.Check.RedirectPolicy)
+	}
+	if c.Check.IPFamily != "" && !checker.ValidIPFamily(c.Check.IPFamily) {
+		return fmt.Errorf("check.ip_family must be one of %s, got %q",
+			strings.Join(checker.IPFamilies(), ", "), c.Check.IPFamily)
+	}
+	if _, err := checker.ParseDNSServer(c.Check.DNSServer); c.Check.DNSServer != "" && err != nil {
+		return fmt.Errorf("check.dns_server: %w", err)
+	}
+	if c.Check.MaxDuration != "" {
+		d, err := time.ParseDuration(c.Check.MaxDuration)
+		if err != nil {
+			return fmt.Errorf("invalid check.max_duration: %w", err)
+		}
+		if d <= 0 {
+			return fmt.Errorf("check.max_duration must be > 0, got %s", c.Check.MaxDuration)
+		}
+	}
+	if c.Check.MaxRequests < 0 {
+		return fmt.Errorf("check.max_requests must be >= 0, got %d", c.Check.MaxRequests)
+	}
+	if c.Check.RedirectWarnHops < 0 {
+		return fmt.Errorf("check.redirect_warn_hops must be >= 0, got %d", c.Check.RedirectWarnHops)
+	}
+	if c.Check.RecheckInterval != "" {
+		if _, err := ParseInterval(c.Check.RecheckInterval); err != nil {
+			return fmt.Errorf("invalid check.recheck_interval: %w", err)
+		}
+	}
+	if c.Check.RecheckWebhook != "" {
+		if c.Check.RecheckInterval == "" {
+			return errors.New("check.recheck_webhook needs check.recheck_interval")
+		}
+		// URLs from environment variables are checked once expanded
+		if !strings.Contains(c.Check.RecheckWebhook, "$") &&
+			!strings.HasPrefix(c.Check.RecheckWebhook, "http://") &&
+			!strings.HasPrefix(c.Check.RecheckWebhook, "https://") {
+			return fmt.Errorf("check.recheck_webhook must be an http:// or https:// URL, got %q",
+				c.Check.RecheckWebhook)
+		}
+	}
+	for i, rule := range c.Check.ContentRules {
+		if strings.TrimSpace(rule.Pattern) == "" {
+			return fmt.Errorf("check.content_rules[%d]: pattern must not be empty", i)
+		}
+		if _, err := checker.NewContentRule(rule.Pattern, rule.MustContain, rule.MustMatch); err != nil {
+			return fmt.Errorf("check.content_rules[%d]: %w", i, err)
+		}
+	}
+	if err := c.Check.StatusCodes.validate(); err != nil {
+		return err
+	}
+	for _, name := range c.Check.DisableResolvers {
+		if !isResolverName(name) {
+			return fmt.Errorf("check.disable_resolvers has unknown resolver %q", name)
+		}
+	}
+	if err := validateMethodDomains(c.Check.ForceGetDomains, c.Check.HeadOnlyDomains); err != nil {
+		return err
+	}
+	for _, p := range c.Check.LoginPatterns {
+		if strings.TrimSpace(p) == "" {
+			return errors.New("check.login_patterns must not contain empty patterns")
+		}
+	}
+	if c.Check.StaleAfter != "" {
+		d, err := checker.ParseAge(c.Check.StaleAfter)
+		if err != nil {
+			return fmt.Errorf("invalid check.stale_after: %w", err)
+		}
+		if d <= 0 {
+			return fmt.Errorf("check.stale_after must be > 0, got %s", c.Check.StaleAfter)
+		}
+	}
+	if c.Check.FailOn != "" && !slices.Contains(validFailOn, c.Check.FailOn) {
+		return fmt.Errorf("invalid check.fail_on %q: valid values are %v", c.Check.FailOn, validFailOn)
+	}
 
 	// Validate output format
 	if c.Output.Format != "" && !slices.Contains(validOutputFormats, c.Output.Format) {
 		return fmt.Errorf("invalid output.format %q: valid formats are %v", c.Output.Format, validOutputFormats)
 	}
 
+	if c.Output.GroupBy != "" && !slices.Contains(validGroupBy, c.Output.GroupBy) {
+		return fmt.Errorf("invalid output.groupBy %q: valid groupings are %v", c.Output.GroupBy, validGroupBy)
+	}
+	if c.Output.Sort != "" && !slices.Contains(validSortKeys, c.Output.Sort) {
+		return fmt.Errorf("invalid output.sort %q: valid keys are %v", c.Output.Sort, validSortKeys)
+	}
+	if c.Output.Top < 0 {
+		return fmt.Errorf("output.top must be >= 0, got %d", c.Output.Top)
+	}
+	if c.Output.JUnitSuitePer != "" && !slices.Contains(validJUnitSuitePer, c.Output.JUnitSuitePer) {
+		return fmt.Errorf("invalid output.junitSuitePer %q: valid groupings are %v",
+			c.Output.JUnitSuitePer, validJUnitSuitePer)
+	}
+	if _, ok := term.ThemeByName(c.Output.Theme); !ok {
+		return fmt.Errorf("invalid output.theme %q: valid themes are %v", c.Output.Theme, term.ThemeNames())
+	}
+
+	// Validate parse budget
+	if c.Scan.MaxFileSize < 0 {
+		return fmt.Errorf("scan.max_file_size must be >= 0, got %d", c.Scan.MaxFileSize)
+	}
+	if c.Scan.ParseTimeout != "" {
+		d, err := time.ParseDuration(c.Scan.ParseTimeout)
+		if err != nil {
+			return fmt.Errorf("invalid scan.parse_timeout: %w", err)
+		}
+		if d <= 0 {
+			return fmt.Errorf("scan.parse_timeout must be > 0, got %s", c.Scan.ParseTimeout)
+		}
+	}
+
+	// Validate cache TTL
+	if c.Cache.TTL != "" {
+		d, err := time.ParseDuration(c.Cache.TTL)
+		if err != nil {
+			return fmt.Errorf("invalid cache.ttl: %w", err)
+		}
+		if d <= 0 {
+			return fmt.Errorf("cache.ttl must be > 0, got %s", c.Cache.TTL)
+		}
+	}
+
 	// Validate scan include patterns
 	for _, p := range c.Scan.Include {
 		if _, err := glob.Compile(p); err != nil {
@@ -208,6 +708,20 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	// Validate scan priority patterns
+	for _, p := range c.Scan.PriorityPaths {
+		if _, err := glob.Compile(p); err != nil {
+			return fmt.Errorf("invalid scan.priority_paths pattern %q: %w", p, err)
+		}
+	}
+
+	// Validate XML attribute names
+	for _, name := range c.Scan.XMLAttributes {
+		if name == "" || strings.ContainsAny(name, " \t\n=<>\"'") {
+			return fmt.Errorf("invalid scan.xml_attributes name %q", name)
+		}
+	}
+
 	// Validate ignore glob patterns
 	for _, p := range c.Ignore.Patterns {
 		if _, err := glob.Compile(p); err != nil {
@@ -222,6 +736,100 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	// Validate deny glob patterns
+	for _, p := range c.Deny.Patterns {
+		if _, err := glob.Compile(p); err != nil {
+			return fmt.Errorf("invalid deny.patterns pattern %q: %w", p, err)
+		}
+	}
+
+	// Validate deny regex patterns
+	for _, p := range c.Deny.Regex {
+		if _, err := regexp.Compile(p); err != nil {
+			return fmt.Errorf("invalid deny.regex pattern %q: %w", p, err)
+		}
+	}
+
+	// Validate rewrite rules
+	for i, r := range c.Rewrites {
+		if r.From == "" || r.To == "" {
+			return fmt.Errorf("rewrites[%d] must set both from and to", i)
+		}
+		if _, err := regexp.Compile(r.From); err != nil {
+			return fmt.Errorf("invalid rewrites[%d].from pattern %q: %w", i, r.From, err)
+		}
+	}
+
+	// Validate normalization rules
+	for _, rule := range c.Normalize {
+		if !slices.Contains(validNormalizeRules, rule) {
+			return fmt.Errorf("invalid normalize rule %q: valid rules are %v", rule, validNormalizeRules)
+		}
+	}
+
+	return nil
+}
+
+// validate checks that codes are valid HTTP status codes listed under one status only.
+func (s StatusCodesConfig) validate() error {
+	seen := map[int]string{}
+	for _, list := range []struct {
+		name  string
+		codes []int
+	}{{"alive", s.Alive}, {"dead", s.Dead}, {"blocked", s.Blocked}} {
+		for _, code := range list.codes {
+			if code < 100 || code > 999 {
+				return fmt.Errorf("check.status_codes.%s: invalid status code %d", list.name, code)
+			}
+			if prev, ok := seen[code]; ok && prev != list.name {
+				return fmt.Errorf("check.status_codes: %d is listed as both %s and %s", code, prev, list.name)
+			}
+			seen[code] = list.name
+		}
+	}
+	return nil
+}
+
+// validateHeaders checks that header names are non-empty HTTP tokens.
+func validateHeaders(key string, headers map[string]string) error {
+	for name := range headers {
+		if name == "" || strings.ContainsAny(name, " \t\r\n:") {
+			return fmt.Errorf("%s has invalid header name %q", key, name)
+		}
+	}
+	return nil
+}
+
+// isResolverName reports whether name is the name of a built-in resolver.
+func isResolverName(name string) bool {
+	for _, r := range checker.DefaultResolvers() {
+		if r.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// validateMethodDomains checks that the forced-method domain lists have no
+// empty entries and don't list a domain under both methods.
+func validateMethodDomains(forceGet, headOnly []string) error {
+	get := map[string]bool{}
+	for _, domain := range forceGet {
+		domain = strings.ToLower(strings.TrimSpace(domain))
+		if domain == "" {
+			return errors.New("check.force_get_domains must not contain empty domains")
+		}
+		get[domain] = true
+	}
+	for _, domain := range headOnly {
+		domain = strings.ToLower(strings.TrimSpace(domain))
+		if domain == "" {
+			return errors.New("check.head_only_domains must not contain empty domains")
+		}
+		if get[domain] {
+			return fmt.Errorf("domain %s is in both check.force_get_domains and check.head_only_domains", domain)
+		}
+	}
 	return nil
 }
 
@@ -231,25 +839,92 @@ func (c *Config) IsEmpty() bool {
 	return len(c.Types) == 0 &&
 		len(c.Scan.Include) == 0 &&
 		len(c.Scan.Exclude) == 0 &&
+		c.Scan.MaxFileSize == 0 &&
+		c.Scan.ParseTimeout == "" &&
+		len(c.Scan.PriorityPaths) == 0 &&
+		len(c.Scan.XMLAttributes) == 0 &&
 		c.Check.Concurrency == 0 &&
 		c.Check.Timeout == 0 &&
 		c.Check.Retries == 0 &&
 		!c.Check.Strict &&
+		c.Check.MaxRedirects == 0 &&
+		len(c.Check.DomainMaxRedirects) == 0 &&
+		len(c.Check.Headers) == 0 &&
+		len(c.Check.DomainHeaders) == 0 &&
+		c.Check.UAProfile == "" &&
+		len(c.Check.UAProfiles) == 0 &&
+		len(c.Check.ForceGetDomains) == 0 &&
+		len(c.Check.DisableResolvers) == 0 &&
+		len(c.Check.ContentRules) == 0 &&
+		len(c.Check.HeadOnlyDomains) == 0 &&
+		c.Check.RedirectWarnHops == 0 &&
+		c.Check.RedirectPolicy == "" &&
+		c.Check.RecheckInterval == "" &&
+		c.Check.RecheckWebhook == "" &&
+		!c.Check.ProbeHosts &&
+		!c.Check.InspectPages &&
+		!c.Check.CheckAnchors &&
+		!c.Check.DetectSoft404 &&
+		!c.Check.CheckInternal &&
+		!c.Check.Insecure &&
+		!c.Check.Lint &&
+		!c.Check.HTTP3 &&
+		!c.Check.EnableCookies &&
+		c.Check.IPFamily == "" &&
+		c.Check.DNSServer == "" &&
+		c.Check.MaxDuration == "" &&
+		c.Check.MaxRequests == 0 &&
+		c.Check.StaleAfter == "" &&
+		c.Check.FailOn == "" &&
+		len(c.Check.LoginPatterns) == 0 &&
+		c.Check.StatusCodes.IsEmpty() &&
 		c.Output.Format == "" &&
 		!c.Output.ShowAlive &&
 		c.Output.ShowWarnings == nil &&
 		c.Output.ShowDead == nil &&
 		!c.Output.ShowStats &&
+		c.Output.MetricsFile == "" &&
+		c.Output.Theme == "" &&
+		c.Output.Template == "" &&
+		c.Output.GroupBy == "" &&
+		c.Output.Sort == "" &&
+		c.Output.Top == 0 &&
+		!c.Output.ReportAll &&
+		c.Output.JUnitSuitePer == "" &&
+		!c.Output.JUnitIncludePassed &&
+		!c.Cache.Enabled &&
+		c.Cache.Path == "" &&
+		c.Cache.TTL == "" &&
 		len(c.Ignore.Domains) == 0 &&
 		len(c.Ignore.Patterns) == 0 &&
-		len(c.Ignore.Regex) == 0
+		len(c.Ignore.Regex) == 0 &&
+		!c.Ignore.Badges &&
+		len(c.Deny.Domains) == 0 &&
+		len(c.Deny.Patterns) == 0 &&
+		len(c.Deny.Regex) == 0 &&
+		len(c.Rewrites) == 0 &&
+		len(c.Normalize) == 0 &&
+		len(c.Parsers) == 0
 }
 
 // HasIgnoreRules returns true if any ignore rules are defined.
 func (c *Config) HasIgnoreRules() bool {
 	return len(c.Ignore.Domains) > 0 ||
 		len(c.Ignore.Patterns) > 0 ||
-		len(c.Ignore.Regex) > 0
+		len(c.Ignore.Regex) > 0 ||
+		c.Ignore.Badges
+}
+
+// HasDenyRules returns true if any deny (policy) rules are defined.
+func (c *Config) HasDenyRules() bool {
+	return len(c.Deny.Domains) > 0 ||
+		len(c.Deny.Patterns) > 0 ||
+		len(c.Deny.Regex) > 0
+}
+
+// HasRewrites returns true if any rewrite rules are defined.
+func (c *Config) HasRewrites() bool {
+	return len(c.Rewrites) > 0
 }
 
 // HasTypes returns true if file types are configured.
@@ -259,7 +934,12 @@ func (c *Config) HasTypes() bool {
 
 // HasScanConfig returns true if any scan configuration is set.
 func (c *Config) HasScanConfig() bool {
-	return len(c.Scan.Include) > 0 || len(c.Scan.Exclude) > 0
+	return len(c.Scan.Include) > 0 ||
+		len(c.Scan.Exclude) > 0 ||
+		c.Scan.MaxFileSize > 0 ||
+		c.Scan.ParseTimeout != "" ||
+		len(c.Scan.PriorityPaths) > 0 ||
+		len(c.Scan.XMLAttributes) > 0
 }
 
 // HasCheckConfig returns true if any check configuration is set.
@@ -267,7 +947,38 @@ func (c *Config) HasCheckConfig() bool {
 	return c.Check.Concurrency > 0 ||
 		c.Check.Timeout > 0 ||
 		c.Check.Retries > 0 ||
-		c.Check.Strict
+		c.Check.Strict ||
+		c.Check.MaxRedirects > 0 ||
+		len(c.Check.DomainMaxRedirects) > 0 ||
+		len(c.Check.Headers) > 0 ||
+		len(c.Check.DomainHeaders) > 0 ||
+		c.Check.UAProfile != "" ||
+		len(c.Check.UAProfiles) > 0 ||
+		len(c.Check.ForceGetDomains) > 0 ||
+		len(c.Check.DisableResolvers) > 0 ||
+		len(c.Check.ContentRules) > 0 ||
+		len(c.Check.HeadOnlyDomains) > 0 ||
+		c.Check.RedirectWarnHops > 0 ||
+		c.Check.RedirectPolicy != "" ||
+		c.Check.RecheckInterval != "" ||
+		c.Check.RecheckWebhook != "" ||
+		c.Check.ProbeHosts ||
+		c.Check.InspectPages ||
+		c.Check.CheckAnchors ||
+		c.Check.DetectSoft404 ||
+		c.Check.CheckInternal ||
+		c.Check.Insecure ||
+		c.Check.Lint ||
+		c.Check.HTTP3 ||
+		c.Check.EnableCookies ||
+		c.Check.IPFamily != "" ||
+		c.Check.DNSServer != "" ||
+		c.Check.MaxDuration != "" ||
+		c.Check.MaxRequests > 0 ||
+		c.Check.StaleAfter != "" ||
+		c.Check.FailOn != "" ||
+		len(c.Check.LoginPatterns) > 0 ||
+		!c.Check.StatusCodes.IsEmpty()
 }
 
 // HasOutputConfig returns true if any output configuration is set.
@@ -276,7 +987,23 @@ func (c *Config) HasOutputConfig() bool {
 		c.Output.ShowAlive ||
 		c.Output.ShowWarnings != nil ||
 		c.Output.ShowDead != nil ||
-		c.Output.ShowStats
+		c.Output.ShowStats ||
+		c.Output.MetricsFile != "" ||
+		c.Output.Theme != "" ||
+		c.Output.Template != "" ||
+		c.Output.GroupBy != "" ||
+		c.Output.Sort != "" ||
+		c.Output.Top != 0 ||
+		c.Output.ReportAll ||
+		c.Output.JUnitSuitePer != "" ||
+		c.Output.JUnitIncludePassed
+}
+
+// HasCacheConfig returns true if any cache configuration is set.
+func (c *Config) HasCacheConfig() bool {
+	return c.Cache.Enabled ||
+		c.Cache.Path != "" ||
+		c.Cache.TTL != ""
 }
 
 // GetShowWarnings returns the ShowWarnings value, defaulting to true if not set.
@@ -308,6 +1035,14 @@ func (c *Config) Merge(other *Config) {
 	// Merge scan config (additive)
 	c.Scan.Include = append(c.Scan.Include, other.Scan.Include...)
 	c.Scan.Exclude = append(c.Scan.Exclude, other.Scan.Exclude...)
+	c.Scan.PriorityPaths = append(c.Scan.PriorityPaths, other.Scan.PriorityPaths...)
+	c.Scan.XMLAttributes = append(c.Scan.XMLAttributes, other.Scan.XMLAttributes...)
+	if other.Scan.MaxFileSize > 0 {
+		c.Scan.MaxFileSize = other.Scan.MaxFileSize
+	}
+	if other.Scan.ParseTimeout != "" {
+		c.Scan.ParseTimeout = other.Scan.ParseTimeout
+	}
 
 	// Merge check config (other overrides if set)
 	if other.Check.Concurrency > 0 {
@@ -322,6 +1057,101 @@ func (c *Config) Merge(other *Config) {
 	if other.Check.Strict {
 		c.Check.Strict = true
 	}
+	if other.Check.MaxRedirects > 0 {
+		c.Check.MaxRedirects = other.Check.MaxRedirects
+	}
+	if len(other.Check.DomainMaxRedirects) > 0 {
+		if c.Check.DomainMaxRedirects == nil {
+			c.Check.DomainMaxRedirects = make(map[string]int, len(other.Check.DomainMaxRedirects))
+		}
+		maps.Copy(c.Check.DomainMaxRedirects, other.Check.DomainMaxRedirects)
+	}
+	if len(other.Check.Headers) > 0 {
+		if c.Check.Headers == nil {
+			c.Check.Headers = make(map[string]string, len(other.Check.Headers))
+		}
+		maps.Copy(c.Check.Headers, other.Check.Headers)
+	}
+	if len(other.Check.DomainHeaders) > 0 {
+		if c.Check.DomainHeaders == nil {
+			c.Check.DomainHeaders = make(map[string]map[string]string, len(other.Check.DomainHeaders))
+		}
+		maps.Copy(c.Check.DomainHeaders, other.Check.DomainHeaders)
+	}
+	if other.Check.UAProfile != "" {
+		c.Check.UAProfile = other.Check.UAProfile
+	}
+	if len(other.Check.UAProfiles) > 0 {
+		if c.Check.UAProfiles == nil {
+			c.Check.UAProfiles = make(map[string]map[string]string, len(other.Check.UAProfiles))
+		}
+		maps.Copy(c.Check.UAProfiles, other.Check.UAProfiles)
+	}
+	if other.Check.RedirectPolicy != "" {
+		c.Check.RedirectPolicy = other.Check.RedirectPolicy
+	}
+	if other.Check.RedirectWarnHops > 0 {
+		c.Check.RedirectWarnHops = other.Check.RedirectWarnHops
+	}
+	if other.Check.RecheckInterval != "" {
+		c.Check.RecheckInterval = other.Check.RecheckInterval
+	}
+	if other.Check.RecheckWebhook != "" {
+		c.Check.RecheckWebhook = other.Check.RecheckWebhook
+	}
+	if other.Check.ProbeHosts {
+		c.Check.ProbeHosts = true
+	}
+	if other.Check.InspectPages {
+		c.Check.InspectPages = true
+	}
+	if other.Check.CheckAnchors {
+		c.Check.CheckAnchors = true
+	}
+	if other.Check.DetectSoft404 {
+		c.Check.DetectSoft404 = true
+	}
+	if other.Check.CheckInternal {
+		c.Check.CheckInternal = true
+	}
+	if other.Check.Insecure {
+		c.Check.Insecure = true
+	}
+	if other.Check.Lint {
+		c.Check.Lint = true
+	}
+	if other.Check.HTTP3 {
+		c.Check.HTTP3 = true
+	}
+	if other.Check.EnableCookies {
+		c.Check.EnableCookies = true
+	}
+	if other.Check.IPFamily != "" {
+		c.Check.IPFamily = other.Check.IPFamily
+	}
+	if other.Check.DNSServer != "" {
+		c.Check.DNSServer = other.Check.DNSServer
+	}
+	if other.Check.MaxDuration != "" {
+		c.Check.MaxDuration = other.Check.MaxDuration
+	}
+	if other.Check.MaxRequests > 0 {
+		c.Check.MaxRequests = other.Check.MaxRequests
+	}
+	if other.Check.StaleAfter != "" {
+		c.Check.StaleAfter = other.Check.StaleAfter
+	}
+	if other.Check.FailOn != "" {
+		c.Check.FailOn = other.Check.FailOn
+	}
+	c.Check.LoginPatterns = append(c.Check.LoginPatterns, other.Check.LoginPatterns...)
+	c.Check.ForceGetDomains = append(c.Check.ForceGetDomains, other.Check.ForceGetDomains...)
+	c.Check.DisableResolvers = append(c.Check.DisableResolvers, other.Check.DisableResolvers...)
+	c.Check.ContentRules = append(c.Check.ContentRules, other.Check.ContentRules...)
+	c.Check.HeadOnlyDomains = append(c.Check.HeadOnlyDomains, other.Check.HeadOnlyDomains...)
+	c.Check.StatusCodes.Alive = append(c.Check.StatusCodes.Alive, other.Check.StatusCodes.Alive...)
+	c.Check.StatusCodes.Dead = append(c.Check.StatusCodes.Dead, other.Check.StatusCodes.Dead...)
+	c.Check.StatusCodes.Blocked = append(c.Check.StatusCodes.Blocked, other.Check.StatusCodes.Blocked...)
 
 	// Merge output config (other overrides if set)
 	if other.Output.Format != "" {
@@ -339,9 +1169,71 @@ func (c *Config) Merge(other *Config) {
 	if other.Output.ShowStats {
 		c.Output.ShowStats = true
 	}
+	if other.Output.MetricsFile != "" {
+		c.Output.MetricsFile = other.Output.MetricsFile
+	}
+	if other.Output.Theme != "" {
+		c.Output.Theme = other.Output.Theme
+	}
+	if other.Output.Template != "" {
+		c.Output.Template = other.Output.Template
+	}
+	if other.Output.GroupBy != "" {
+		c.Output.GroupBy = other.Output.GroupBy
+	}
+	if other.Output.Sort != "" {
+		c.Output.Sort = other.Output.Sort
+	}
+	if other.Output.Top != 0 {
+		c.Output.Top = other.Output.Top
+	}
+	if other.Output.ReportAll {
+		c.Output.ReportAll = true
+	}
+	if other.Output.JUnitSuitePer != "" {
+		c.Output.JUnitSuitePer = other.Output.JUnitSuitePer
+	}
+	if other.Output.JUnitIncludePassed {
+		c.Output.JUnitIncludePassed = true
+	}
+
+	// Merge cache config (other overrides if set)
+	if other.Cache.Enabled {
+		c.Cache.Enabled = true
+	}
+	if other.Cache.Path != "" {
+		c.Cache.Path = other.Cache.Path
+	}
+	if other.Cache.TTL != "" {
+		c.Cache.TTL = other.Cache.TTL
+	}
 
 	// Merge ignore config (additive)
 	c.Ignore.Domains = append(c.Ignore.Domains, other.Ignore.Domains...)
 	c.Ignore.Patterns = append(c.Ignore.Patterns, other.Ignore.Patterns...)
 	c.Ignore.Regex = append(c.Ignore.Regex, other.Ignore.Regex...)
+	if other.Ignore.Badges {
+		c.Ignore.Badges = true
+	}
+
+	// Merge deny config (additive)
+	c.Deny.Domains = append(c.Deny.Domains, other.Deny.Domains...)
+	c.Deny.Patterns = append(c.Deny.Patterns, other.Deny.Patterns...)
+	c.Deny.Regex = append(c.Deny.Regex, other.Deny.Regex...)
+
+	// Merge rewrite rules (additive)
+	c.Rewrites = append(c.Rewrites, other.Rewrites...)
+
+	// Merge normalization rules (other wins if set)
+	if len(other.Normalize) > 0 {
+		c.Normalize = other.Normalize
+	}
+
+	// Merge external parsers (other wins per extension)
+	if len(other.Parsers) > 0 {
+		if c.Parsers == nil {
+			c.Parsers = make(map[string]string, len(other.Parsers))
+		}
+		maps.Copy(c.Parsers, other.Parsers)
+	}
 }
@@ -422,6 +422,649 @@ func TestConfig_Validate(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("Parsers", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{
+			Types:   []string{"md", "adoc"},
+			Parsers: map[string]string{".adoc": "asciidoc-links --json"},
+		}
+		require.NoError(t, cfg.Validate())
+		assert.False(t, cfg.IsEmpty())
+
+		cfg.Parsers = map[string]string{"adoc": " "}
+		err := cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "parsers[adoc]")
+
+		cfg.Parsers = map[string]string{"docs/x": "cmd"}
+		err = cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid extension")
+
+		cfg.Parsers = nil
+		err = cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid type "adoc"`)
+	})
+
+	t.Run("RedirectSettings", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{Check: CheckConfig{
+			MaxRedirects:       10,
+			DomainMaxRedirects: map[string]int{"github.com": 3},
+			RedirectWarnHops:   2,
+		}}
+		require.NoError(t, cfg.Validate())
+		assert.True(t, cfg.HasCheckConfig())
+		assert.False(t, cfg.IsEmpty())
+
+		cfg.Check.DomainMaxRedirects["github.com"] = 0
+		err := cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "check.domain_max_redirects[github.com]")
+
+		cfg = &Config{Check: CheckConfig{RedirectWarnHops: -1}}
+		require.Error(t, cfg.Validate())
+	})
+
+	t.Run("Headers", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{Check: CheckConfig{
+			Headers:       map[string]string{"X-Team": "docs"},
+			DomainHeaders: map[string]map[string]string{"jira.example.com": {"Authorization": "Bearer ${TOKEN}"}},
+		}}
+		require.NoError(t, cfg.Validate())
+		assert.True(t, cfg.HasCheckConfig())
+		assert.False(t, cfg.IsEmpty())
+
+		merged := &Config{Check: CheckConfig{Headers: map[string]string{"X-Other": "1"}}}
+		merged.Merge(cfg)
+		assert.Equal(t, map[string]string{"X-Team": "docs", "X-Other": "1"}, merged.Check.Headers)
+		assert.Equal(t, cfg.Check.DomainHeaders, merged.Check.DomainHeaders)
+
+		cfg.Check.DomainHeaders["jira.example.com"]["Bad Name"] = "x"
+		err := cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "check.domain_headers[jira.example.com]")
+
+		cfg = &Config{Check: CheckConfig{Headers: map[string]string{"": "x"}}}
+		require.Error(t, cfg.Validate())
+	})
+
+	t.Run("StatusCodes", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{Check: CheckConfig{StatusCodes: StatusCodesConfig{Alive: []int{999}, Dead: []int{403}}}}
+		require.NoError(t, cfg.Validate())
+		assert.True(t, cfg.HasCheckConfig())
+		assert.False(t, cfg.IsEmpty())
+
+		merged := &Config{}
+		merged.Merge(cfg)
+		assert.Equal(t, []int{999}, merged.Check.StatusCodes.Alive)
+		assert.Equal(t, []int{403}, merged.Check.StatusCodes.Dead)
+
+		cfg.Check.StatusCodes.Blocked = []int{403}
+		err := cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "both dead and blocked")
+
+		cfg = &Config{Check: CheckConfig{StatusCodes: StatusCodesConfig{Alive: []int{42}}}}
+		require.Error(t, cfg.Validate())
+	})
+
+	t.Run("ProbeHosts", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{Check: CheckConfig{ProbeHosts: true}}
+		require.NoError(t, cfg.Validate())
+		assert.True(t, cfg.HasCheckConfig())
+		assert.False(t, cfg.IsEmpty())
+
+		merged := &Config{}
+		merged.Merge(cfg)
+		assert.True(t, merged.Check.ProbeHosts)
+	})
+
+	t.Run("InspectPages", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{Check: CheckConfig{InspectPages: true}}
+		require.NoError(t, cfg.Validate())
+		assert.True(t, cfg.HasCheckConfig())
+
+		merged := &Config{}
+		merged.Merge(cfg)
+		assert.True(t, merged.Check.InspectPages)
+	})
+
+	t.Run("CheckAnchors", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{Check: CheckConfig{CheckAnchors: true}}
+		require.NoError(t, cfg.Validate())
+		assert.False(t, cfg.IsEmpty())
+		assert.True(t, cfg.HasCheckConfig())
+
+		merged := &Config{}
+		merged.Merge(cfg)
+		assert.True(t, merged.Check.CheckAnchors)
+	})
+
+	t.Run("DetectSoft404", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{Check: CheckConfig{DetectSoft404: true}}
+		require.NoError(t, cfg.Validate())
+		assert.False(t, cfg.IsEmpty())
+		assert.True(t, cfg.HasCheckConfig())
+
+		merged := &Config{}
+		merged.Merge(cfg)
+		assert.True(t, merged.Check.DetectSoft404)
+	})
+
+	t.Run("CheckInternal", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{Check: CheckConfig{CheckInternal: true}}
+		require.NoError(t, cfg.Validate())
+		assert.False(t, cfg.IsEmpty())
+		assert.True(t, cfg.HasCheckConfig())
+
+		merged := &Config{}
+		merged.Merge(cfg)
+		assert.True(t, merged.Check.CheckInternal)
+	})
+
+	t.Run("MethodDomains", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{Check: CheckConfig{
+			ForceGetDomains: []string{"api.example.com"},
+			HeadOnlyDomains: []string{"cdn.example.com"},
+		}}
+		require.NoError(t, cfg.Validate())
+		assert.False(t, cfg.IsEmpty())
+		assert.True(t, cfg.HasCheckConfig())
+
+		merged := &Config{Check: CheckConfig{ForceGetDomains: []string{"legacy.example.com"}}}
+		merged.Merge(cfg)
+		assert.Equal(t, []string{"legacy.example.com", "api.example.com"}, merged.Check.ForceGetDomains)
+		assert.Equal(t, []string{"cdn.example.com"}, merged.Check.HeadOnlyDomains)
+
+		both := &Config{Check: CheckConfig{
+			ForceGetDomains: []string{"api.example.com"},
+			HeadOnlyDomains: []string{"API.example.com"},
+		}}
+		require.ErrorContains(t, both.Validate(), "both")
+
+		empty := &Config{Check: CheckConfig{ForceGetDomains: []string{" "}}}
+		require.ErrorContains(t, empty.Validate(), "check.force_get_domains")
+	})
+
+	t.Run("DisableResolvers", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{Check: CheckConfig{DisableResolvers: []string{"github", "youtube"}}}
+		require.NoError(t, cfg.Validate())
+		assert.False(t, cfg.IsEmpty())
+		assert.True(t, cfg.HasCheckConfig())
+
+		merged := &Config{}
+		merged.Merge(cfg)
+		assert.Equal(t, []string{"github", "youtube"}, merged.Check.DisableResolvers)
+
+		unknown := &Config{Check: CheckConfig{DisableResolvers: []string{"gitlab"}}}
+		require.ErrorContains(t, unknown.Validate(), `unknown resolver "gitlab"`)
+	})
+
+	t.Run("ContentRules", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{Check: CheckConfig{ContentRules: []ContentRuleConfig{
+			{Pattern: "https://app.example.com/*", MustContain: "<h1>"},
+		}}}
+		require.NoError(t, cfg.Validate())
+		assert.False(t, cfg.IsEmpty())
+		assert.True(t, cfg.HasCheckConfig())
+
+		merged := &Config{}
+		merged.Merge(cfg)
+		assert.Equal(t, cfg.Check.ContentRules, merged.Check.ContentRules)
+
+		noAssertion := &Config{Check: CheckConfig{ContentRules: []ContentRuleConfig{{Pattern: "*"}}}}
+		require.ErrorContains(t, noAssertion.Validate(), "check.content_rules[0]")
+
+		badRegex := &Config{Check: CheckConfig{ContentRules: []ContentRuleConfig{{Pattern: "*", MustMatch: "("}}}}
+		require.ErrorContains(t, badRegex.Validate(), "invalid must_match")
+
+		noPattern := &Config{Check: CheckConfig{ContentRules: []ContentRuleConfig{{MustContain: "x"}}}}
+		require.ErrorContains(t, noPattern.Validate(), "pattern must not be empty")
+	})
+
+	t.Run("RedirectPolicy", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{Check: CheckConfig{RedirectPolicy: "lenient"}}
+		require.NoError(t, cfg.Validate())
+		assert.False(t, cfg.IsEmpty())
+		assert.True(t, cfg.HasCheckConfig())
+
+		merged := &Config{Check: CheckConfig{RedirectPolicy: "strict"}}
+		merged.Merge(cfg)
+		assert.Equal(t, "lenient", merged.Check.RedirectPolicy)
+
+		invalid := &Config{Check: CheckConfig{RedirectPolicy: "loose"}}
+		require.ErrorContains(t, invalid.Validate(), "check.redirect_policy must be one of")
+	})
+
+	t.Run("Theme", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{Output: OutputConfig{Theme: "mono"}}
+		require.NoError(t, cfg.Validate())
+		assert.False(t, cfg.IsEmpty())
+		assert.True(t, cfg.HasOutputConfig())
+
+		merged := &Config{Output: OutputConfig{Theme: "ansi"}}
+		merged.Merge(cfg)
+		assert.Equal(t, "mono", merged.Output.Theme)
+
+		invalid := &Config{Output: OutputConfig{Theme: "neon"}}
+		require.ErrorContains(t, invalid.Validate(), "invalid output.theme")
+	})
+
+	t.Run("GroupBy", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{Output: OutputConfig{GroupBy: "domain"}}
+		require.NoError(t, cfg.Validate())
+		assert.False(t, cfg.IsEmpty())
+		assert.True(t, cfg.HasOutputConfig())
+
+		merged := &Config{Output: OutputConfig{GroupBy: "file"}}
+		merged.Merge(cfg)
+		assert.Equal(t, "domain", merged.Output.GroupBy)
+
+		invalid := &Config{Output: OutputConfig{GroupBy: "host"}}
+		require.ErrorContains(t, invalid.Validate(), "invalid output.groupBy")
+	})
+
+	t.Run("SortAndTop", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{Output: OutputConfig{Sort: "latency", Top: 20}}
+		require.NoError(t, cfg.Validate())
+		assert.False(t, cfg.IsEmpty())
+		assert.True(t, cfg.HasOutputConfig())
+
+		merged := &Config{Output: OutputConfig{Sort: "url"}}
+		merged.Merge(cfg)
+		assert.Equal(t, "latency", merged.Output.Sort)
+		assert.Equal(t, 20, merged.Output.Top)
+
+		invalid := &Config{Output: OutputConfig{Sort: "speed"}}
+		require.ErrorContains(t, invalid.Validate(), "invalid output.sort")
+
+		negative := &Config{Output: OutputConfig{Top: -1}}
+		require.ErrorContains(t, negative.Validate(), "output.top must be >= 0")
+	})
+
+	t.Run("Template", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{Output: OutputConfig{Format: "template", Template: "slack.tmpl"}}
+		require.NoError(t, cfg.Validate())
+		assert.False(t, cfg.IsEmpty())
+		assert.True(t, cfg.HasOutputConfig())
+
+		merged := &Config{}
+		merged.Merge(cfg)
+		assert.Equal(t, "slack.tmpl", merged.Output.Template)
+	})
+
+	t.Run("ReportAll", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{Output: OutputConfig{ReportAll: true}}
+		assert.False(t, cfg.IsEmpty())
+		assert.True(t, cfg.HasOutputConfig())
+
+		merged := &Config{}
+		merged.Merge(cfg)
+		assert.True(t, merged.Output.ReportAll)
+	})
+
+	t.Run("JUnit", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{Output: OutputConfig{JUnitSuitePer: "domain", JUnitIncludePassed: true}}
+		require.NoError(t, cfg.Validate())
+		assert.False(t, cfg.IsEmpty())
+		assert.True(t, cfg.HasOutputConfig())
+
+		merged := &Config{Output: OutputConfig{JUnitSuitePer: "file"}}
+		merged.Merge(cfg)
+		assert.Equal(t, "domain", merged.Output.JUnitSuitePer)
+		assert.True(t, merged.Output.JUnitIncludePassed)
+
+		invalid := &Config{Output: OutputConfig{JUnitSuitePer: "status"}}
+		require.ErrorContains(t, invalid.Validate(), "invalid output.junitSuitePer")
+	})
+
+	t.Run("IPFamily", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{Check: CheckConfig{IPFamily: "ipv4"}}
+		require.NoError(t, cfg.Validate())
+		assert.False(t, cfg.IsEmpty())
+		assert.True(t, cfg.HasCheckConfig())
+
+		merged := &Config{Check: CheckConfig{IPFamily: "auto"}}
+		merged.Merge(cfg)
+		assert.Equal(t, "ipv4", merged.Check.IPFamily)
+
+		invalid := &Config{Check: CheckConfig{IPFamily: "ipv5"}}
+		require.ErrorContains(t, invalid.Validate(), "check.ip_family must be one of")
+	})
+
+	t.Run("DNSServer", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{Check: CheckConfig{DNSServer: "1.1.1.1"}}
+		require.NoError(t, cfg.Validate())
+		assert.False(t, cfg.IsEmpty())
+		assert.True(t, cfg.HasCheckConfig())
+
+		merged := &Config{}
+		merged.Merge(cfg)
+		assert.Equal(t, "1.1.1.1", merged.Check.DNSServer)
+
+		invalid := &Config{Check: CheckConfig{DNSServer: "dns.example.com"}}
+		require.ErrorContains(t, invalid.Validate(), "check.dns_server")
+	})
+
+	t.Run("Budget", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{Check: CheckConfig{MaxDuration: "5m", MaxRequests: 2000}}
+		require.NoError(t, cfg.Validate())
+		assert.False(t, cfg.IsEmpty())
+		assert.True(t, cfg.HasCheckConfig())
+
+		merged := &Config{Check: CheckConfig{MaxDuration: "1h"}}
+		merged.Merge(cfg)
+		assert.Equal(t, "5m", merged.Check.MaxDuration)
+		assert.Equal(t, 2000, merged.Check.MaxRequests)
+
+		invalid := &Config{Check: CheckConfig{MaxDuration: "soon"}}
+		require.ErrorContains(t, invalid.Validate(), "invalid check.max_duration")
+		invalid = &Config{Check: CheckConfig{MaxDuration: "-1m"}}
+		require.ErrorContains(t, invalid.Validate(), "check.max_duration must be > 0")
+		invalid = &Config{Check: CheckConfig{MaxRequests: -1}}
+		require.ErrorContains(t, invalid.Validate(), "check.max_requests must be >= 0")
+	})
+
+	t.Run("EnableCookies", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{Check: CheckConfig{EnableCookies: true}}
+		assert.False(t, cfg.IsEmpty())
+		assert.True(t, cfg.HasCheckConfig())
+
+		merged := &Config{}
+		merged.Merge(cfg)
+		assert.True(t, merged.Check.EnableCookies)
+	})
+
+	t.Run("UAProfile", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{Check: CheckConfig{
+			UAProfile:  "corp",
+			UAProfiles: map[string]map[string]string{"corp": {"User-Agent": "corp-bot/1.0"}},
+		}}
+		require.NoError(t, cfg.Validate())
+		assert.False(t, cfg.IsEmpty())
+		assert.True(t, cfg.HasCheckConfig())
+
+		merged := &Config{Check: CheckConfig{UAProfile: "chrome"}}
+		merged.Merge(cfg)
+		assert.Equal(t, "corp", merged.Check.UAProfile)
+		assert.Contains(t, merged.Check.UAProfiles, "corp")
+
+		require.NoError(t, (&Config{Check: CheckConfig{UAProfile: "firefox"}}).Validate())
+		invalid := &Config{Check: CheckConfig{UAProfile: "safari"}}
+		require.ErrorContains(t, invalid.Validate(), "check.ua_profile must be one of")
+		invalid = &Config{Check: CheckConfig{UAProfiles: map[string]map[string]string{"curl": {}}}}
+		require.ErrorContains(t, invalid.Validate(), "invalid profile name")
+		invalid = &Config{Check: CheckConfig{UAProfiles: map[string]map[string]string{"x": {"Bad Name": "v"}}}}
+		require.ErrorContains(t, invalid.Validate(), "invalid header name")
+	})
+
+	t.Run("Insecure", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{Check: CheckConfig{Insecure: true}}
+		require.NoError(t, cfg.Validate())
+		assert.False(t, cfg.IsEmpty())
+		assert.True(t, cfg.HasCheckConfig())
+
+		merged := &Config{}
+		merged.Merge(cfg)
+		assert.True(t, merged.Check.Insecure)
+	})
+
+	t.Run("RecheckInterval", func(t *testing.T) {
+		t.Parallel()
+		for _, v := range []string{"30m", "@hourly", "@every 2h"} {
+			cfg := &Config{Check: CheckConfig{RecheckInterval: v}}
+			require.NoError(t, cfg.Validate(), v)
+			assert.False(t, cfg.IsEmpty())
+		}
+
+		cfg := &Config{Check: CheckConfig{RecheckInterval: "sometimes"}}
+		err := cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "check.recheck_interval")
+	})
+
+	t.Run("RecheckWebhook", func(t *testing.T) {
+		t.Parallel()
+		for _, v := range []string{"https://hooks.example.com/gone", "${GONE_WEBHOOK}"} {
+			cfg := &Config{Check: CheckConfig{RecheckInterval: "1h", RecheckWebhook: v}}
+			require.NoError(t, cfg.Validate(), v)
+			assert.True(t, cfg.HasCheckConfig())
+		}
+
+		cfg := &Config{Check: CheckConfig{RecheckInterval: "1h", RecheckWebhook: "hooks.example.com"}}
+		require.ErrorContains(t, cfg.Validate(), "check.recheck_webhook must be an http")
+		cfg = &Config{Check: CheckConfig{RecheckWebhook: "https://hooks.example.com/gone"}}
+		require.ErrorContains(t, cfg.Validate(), "needs check.recheck_interval")
+
+		merged := &Config{}
+		merged.Merge(&Config{Check: CheckConfig{RecheckWebhook: "https://hooks.example.com/gone"}})
+		assert.Equal(t, "https://hooks.example.com/gone", merged.Check.RecheckWebhook)
+	})
+
+	t.Run("Lint", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{Check: CheckConfig{Lint: true}}
+		assert.False(t, cfg.IsEmpty())
+		assert.True(t, cfg.HasCheckConfig())
+
+		merged := &Config{}
+		merged.Merge(cfg)
+		assert.True(t, merged.Check.Lint)
+	})
+
+	t.Run("ParseBudget", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{Scan: ScanConfig{MaxFileSize: 1000, ParseTimeout: "10s"}}
+		require.NoError(t, cfg.Validate())
+		assert.False(t, cfg.IsEmpty())
+		assert.True(t, cfg.HasScanConfig())
+
+		for _, bad := range []ScanConfig{{MaxFileSize: -1}, {ParseTimeout: "soon"}, {ParseTimeout: "0s"}} {
+			cfg = &Config{Scan: bad}
+			require.Error(t, cfg.Validate(), bad)
+		}
+
+		merged := &Config{}
+		merged.Merge(&Config{Scan: ScanConfig{MaxFileSize: 5, ParseTimeout: "1s"}})
+		assert.Equal(t, int64(5), merged.Scan.MaxFileSize)
+		assert.Equal(t, "1s", merged.Scan.ParseTimeout)
+	})
+
+	t.Run("PriorityPaths", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{Scan: ScanConfig{PriorityPaths: []string{"README.md", "docs/**"}}}
+		require.NoError(t, cfg.Validate())
+		assert.False(t, cfg.IsEmpty())
+		assert.True(t, cfg.HasScanConfig())
+
+		cfg = &Config{Scan: ScanConfig{PriorityPaths: []string{"[invalid"}}}
+		err := cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "scan.priority_paths")
+
+		merged := &Config{Scan: ScanConfig{PriorityPaths: []string{"README.md"}}}
+		merged.Merge(&Config{Scan: ScanConfig{PriorityPaths: []string{"docs/**"}}})
+		assert.Equal(t, []string{"README.md", "docs/**"}, merged.Scan.PriorityPaths)
+	})
+
+	t.Run("XMLAttributes", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{Scan: ScanConfig{XMLAttributes: []string{"href", "xlink:href"}}}
+		require.NoError(t, cfg.Validate())
+		assert.False(t, cfg.IsEmpty())
+		assert.True(t, cfg.HasScanConfig())
+
+		cfg = &Config{Scan: ScanConfig{XMLAttributes: []string{"data href"}}}
+		err := cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "scan.xml_attributes")
+
+		merged := &Config{Scan: ScanConfig{XMLAttributes: []string{"href"}}}
+		merged.Merge(&Config{Scan: ScanConfig{XMLAttributes: []string{"resource"}}})
+		assert.Equal(t, []string{"href", "resource"}, merged.Scan.XMLAttributes)
+	})
+
+	t.Run("Rewrites", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{Rewrites: []RewriteConfig{{From: "^http://old.docs/(.*)", To: "https://new.docs/$1"}}}
+		require.NoError(t, cfg.Validate())
+		assert.False(t, cfg.IsEmpty())
+		assert.True(t, cfg.HasRewrites())
+
+		cfg = &Config{Rewrites: []RewriteConfig{{From: "[invalid", To: "x"}}}
+		err := cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "rewrites[0].from")
+
+		cfg = &Config{Rewrites: []RewriteConfig{{From: "^http://old"}}}
+		require.Error(t, cfg.Validate())
+
+		merged := &Config{}
+		merged.Merge(&Config{Rewrites: []RewriteConfig{{From: "a", To: "b"}}})
+		assert.Len(t, merged.Rewrites, 1)
+	})
+
+	t.Run("Normalize", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{Normalize: []string{"tracking", "trailing-slash"}}
+		require.NoError(t, cfg.Validate())
+		assert.False(t, cfg.IsEmpty())
+
+		cfg = &Config{Normalize: []string{"query"}}
+		err := cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid normalize rule "query"`)
+
+		merged := &Config{Normalize: []string{"tracking"}}
+		merged.Merge(&Config{Normalize: []string{"slashes"}})
+		assert.Equal(t, []string{"slashes"}, merged.Normalize)
+		merged.Merge(&Config{})
+		assert.Equal(t, []string{"slashes"}, merged.Normalize)
+	})
+
+	t.Run("Deny", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{Deny: DenyConfig{Domains: []string{"intranet.corp"}, Regex: []string{"^http://"}}}
+		require.NoError(t, cfg.Validate())
+		assert.False(t, cfg.IsEmpty())
+		assert.True(t, cfg.HasDenyRules())
+		assert.False(t, cfg.HasIgnoreRules())
+
+		cfg = &Config{Deny: DenyConfig{Regex: []string{"[invalid"}}}
+		err := cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "deny.regex")
+
+		cfg = &Config{Deny: DenyConfig{Patterns: []string{"[invalid"}}}
+		err = cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "deny.patterns")
+
+		merged := &Config{Deny: DenyConfig{Domains: []string{"a.corp"}}}
+		merged.Merge(&Config{Deny: DenyConfig{Domains: []string{"b.corp"}}})
+		assert.Equal(t, []string{"a.corp", "b.corp"}, merged.Deny.Domains)
+	})
+
+	t.Run("Cache", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{Cache: CacheConfig{Enabled: true, Path: ".gone-cache.json", TTL: "12h"}}
+		require.NoError(t, cfg.Validate())
+		assert.False(t, cfg.IsEmpty())
+		assert.True(t, cfg.HasCacheConfig())
+
+		cfg = &Config{Cache: CacheConfig{TTL: "soon"}}
+		err := cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cache.ttl")
+
+		cfg = &Config{Cache: CacheConfig{TTL: "-1h"}}
+		require.Error(t, cfg.Validate())
+
+		merged := &Config{}
+		merged.Merge(&Config{Cache: CacheConfig{Enabled: true, TTL: "1h"}})
+		assert.True(t, merged.Cache.Enabled)
+		assert.Equal(t, "1h", merged.Cache.TTL)
+	})
+
+	t.Run("LoginPatterns", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{Check: CheckConfig{LoginPatterns: []string{"sso.example.com"}}}
+		require.NoError(t, cfg.Validate())
+		assert.False(t, cfg.IsEmpty())
+		assert.True(t, cfg.HasCheckConfig())
+
+		cfg = &Config{Check: CheckConfig{LoginPatterns: []string{" "}}}
+		err := cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "check.login_patterns")
+
+		merged := &Config{Check: CheckConfig{LoginPatterns: []string{"/auth/"}}}
+		merged.Merge(&Config{Check: CheckConfig{LoginPatterns: []string{"sso.example.com"}}})
+		assert.Equal(t, []string{"/auth/", "sso.example.com"}, merged.Check.LoginPatterns)
+	})
+
+	t.Run("StaleAfter", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{Check: CheckConfig{StaleAfter: "5y"}}
+		require.NoError(t, cfg.Validate())
+		assert.False(t, cfg.IsEmpty())
+		assert.True(t, cfg.HasCheckConfig())
+
+		cfg = &Config{Check: CheckConfig{StaleAfter: "ages"}}
+		err := cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "check.stale_after")
+
+		cfg = &Config{Check: CheckConfig{StaleAfter: "0d"}}
+		require.Error(t, cfg.Validate())
+
+		merged := &Config{Check: CheckConfig{StaleAfter: "1y"}}
+		merged.Merge(&Config{Check: CheckConfig{StaleAfter: "90d"}})
+		assert.Equal(t, "90d", merged.Check.StaleAfter)
+	})
+
+	t.Run("FailOn", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{Check: CheckConfig{FailOn: "warnings"}}
+		require.NoError(t, cfg.Validate())
+		assert.False(t, cfg.IsEmpty())
+		assert.True(t, cfg.HasCheckConfig())
+
+		cfg = &Config{Check: CheckConfig{FailOn: "redirects"}}
+		err := cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "check.fail_on")
+
+		merged := &Config{Check: CheckConfig{FailOn: "warnings"}}
+		merged.Merge(&Config{Check: CheckConfig{FailOn: "none"}})
+		assert.Equal(t, "none", merged.Check.FailOn)
+	})
+
 	t.Run("InvalidType", func(t *testing.T) {
 		t.Parallel()
 		cfg, err := LoadFrom("testdata/invalid_type.yaml")
@@ -430,7 +1073,7 @@ func TestConfig_Validate(t *testing.T) {
 		err = cfg.Validate()
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "invalid type")
-		assert.Contains(t, err.Error(), "html")
+		assert.Contains(t, err.Error(), "pdf")
 	})
 
 	t.Run("NegativeConcurrency", func(t *testing.T) {
@@ -683,7 +1326,8 @@ func TestConfig_Merge_AllSections(t *testing.T) {
 				Timeout: 30,
 			},
 			Output: OutputConfig{
-				ShowStats: true,
+				ShowStats:   true,
+				MetricsFile: "gone.prom",
 			},
 		}
 
@@ -705,5 +1349,6 @@ func TestConfig_Merge_AllSections(t *testing.T) {
 		// Output should be merged (override if set)
 		assert.Equal(t, "json", cfg1.Output.Format)
 		assert.True(t, cfg1.Output.ShowStats)
+		assert.Equal(t, "gone.prom", cfg1.Output.MetricsFile)
 	})
 }
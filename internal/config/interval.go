@@ -0,0 +1,36 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ParseInterval parses a cron-like recheck interval.
+// Accepted forms are Go durations ("30m", "1h30m"), "@every <duration>",
+// and the shorthands "@hourly", "@daily", and "@weekly".
+func ParseInterval(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+
+	switch s {
+	case "":
+		return 0, errors.New("empty interval")
+	case "@hourly":
+		return time.Hour, nil
+	case "@daily":
+		return 24 * time.Hour, nil
+	case "@weekly":
+		return 7 * 24 * time.Hour, nil
+	}
+
+	s = strings.TrimSpace(strings.TrimPrefix(s, "@every"))
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid interval %q: %w", s, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("interval must be positive, got %s", d)
+	}
+	return d, nil
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInterval(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{input: "30m", want: 30 * time.Minute},
+		{input: " 1h30m ", want: 90 * time.Minute},
+		{input: "@every 2h", want: 2 * time.Hour},
+		{input: "@hourly", want: time.Hour},
+		{input: "@daily", want: 24 * time.Hour},
+		{input: "@weekly", want: 7 * 24 * time.Hour},
+		{input: "", wantErr: true},
+		{input: "@every", wantErr: true},
+		{input: "-5m", wantErr: true},
+		{input: "0s", wantErr: true},
+		{input: "often", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseInterval(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package config
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/leonardomso/gone/internal/anchor"
+)
+
+// Problem is an error in a config file.
+type Problem struct {
+	Line    int // Line of the setting at fault, or 0 if unknown
+	Message string
+}
+
+// String returns the problem as "line N: message".
+func (p Problem) String() string {
+	if p.Line > 0 {
+		return fmt.Sprintf("line %d: %s", p.Line, p.Message)
+	}
+	return p.Message
+}
+
+// CheckFile parses config file data strictly and returns its problems,
+// sorted by line: YAML syntax errors, unknown keys, values of the wrong type,
+// and the first invalid setting Validate finds. Unlike LoadFrom, which
+// ignores unknown keys, it reports them, since they're usually typos.
+func CheckFile(data []byte) []Problem {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return []Problem{syntaxProblem(err)}
+	}
+	nodes := keyNodes(&root)
+
+	var cfg Config
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	var problems []Problem
+	var typeErr *yaml.TypeError
+	switch err := dec.Decode(&cfg); {
+	case errors.As(err, &typeErr):
+		for _, msg := range typeErr.Errors {
+			problems = append(problems, decodeProblem(msg, nodes))
+		}
+	case err != nil && !errors.Is(err, io.EOF):
+		return []Problem{syntaxProblem(err)}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		problems = append(problems, Problem{Line: locate(err.Error(), nodes), Message: err.Error()})
+	}
+	slices.SortStableFunc(problems, func(a, b Problem) int { return cmp.Compare(a.Line, b.Line) })
+	return problems
+}
+
+// keyNode is a setting of a config file: a mapping value or a list item.
+type keyNode struct {
+	path  string // Such as check.timeout or rewrites[0].from
+	name  string // Key of the setting, or "" for list items
+	line  int    // Line of the key, or of the item
+	value *yaml.Node
+}
+
+// keyNodes returns the settings under root, in document order. Mapping keys
+// are listed both as "parent.key" and "parent[key]", since validation
+// errors name keys of maps, such as domains, the latter way.
+func keyNodes(root *yaml.Node) []keyNode {
+	var nodes []keyNode
+	var walk func(node *yaml.Node, path string)
+	walk = func(node *yaml.Node, path string) {
+		switch node.Kind {
+		case yaml.DocumentNode:
+			for _, child := range node.Content {
+				walk(child, path)
+			}
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				child := key.Value
+				if path != "" {
+					nodes = append(nodes, keyNode{path + "[" + key.Value + "]", key.Value, key.Line, value})
+					child = path + "." + key.Value
+				}
+				nodes = append(nodes, keyNode{child, key.Value, key.Line, value})
+				walk(value, child)
+			}
+		case yaml.SequenceNode:
+			for i, item := range node.Content {
+				child := fmt.Sprintf("%s[%d]", path, i)
+				nodes = append(nodes, keyNode{child, "", item.Line, item})
+				walk(item, child)
+			}
+		}
+	}
+	walk(root, "")
+	return nodes
+}
+
+// yamlLineError matches the line of YAML syntax and decoding errors.
+var yamlLineError = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// syntaxProblem returns the problem of a YAML syntax error.
+func syntaxProblem(err error) Problem {
+	m := yamlLineError.FindStringSubmatch(err.Error())
+	if m == nil {
+		return Problem{Message: strings.TrimPrefix(err.Error(), "yaml: ")}
+	}
+	line, _ := strconv.Atoi(m[1])
+	return Problem{Line: line, Message: "invalid YAML: " + m[2]}
+}
+
+var (
+	// unknownField matches yaml's error for unknown keys.
+	unknownField = regexp.MustCompile(`^field (\S+) not found in type (\S+)$`)
+
+	// wrongType matches yaml's error for values of the wrong type.
+	wrongType = regexp.MustCompile("^cannot unmarshal !!(\\w+) `(.*)` into (\\S+)$")
+)
+
+// decodeProblem rewrites an error decoding the settings at a line, such as
+// "line 3: field timout not found in type config.CheckConfig", to name the
+// setting and, for unknown keys, the closest known one.
+func decodeProblem(msg string, nodes []keyNode) Problem {
+	m := yamlLineError.FindStringSubmatch(msg)
+	if m == nil {
+		return Problem{Message: msg}
+	}
+	line, _ := strconv.Atoi(m[1])
+	msg = m[2]
+
+	if f := unknownField.FindStringSubmatch(msg); f != nil {
+		path := f[1]
+		if n, ok := nodeAt(nodes, line, f[1]); ok {
+			path = n.path
+		}
+		msg = fmt.Sprintf("unknown key %q", path)
+		if known, ok := anchor.Closest(f[1], knownKeys()[f[2]]); ok {
+			msg += fmt.Sprintf("; did you mean %q?", strings.TrimSuffix(path, f[1])+known)
+		}
+		return Problem{Line: line, Message: msg}
+	}
+	if t := wrongType.FindStringSubmatch(msg); t != nil {
+		msg = fmt.Sprintf("expected %s, got %s", describeGoType(t[3]), describeYAML(t[1], t[2]))
+	}
+	if n, ok := nodeAt(nodes, line, ""); ok {
+		msg = n.path + ": " + msg
+	}
+	return Problem{Line: line, Message: msg}
+}
+
+// nodeAt returns the innermost setting at line, named name unless name is
+// empty.
+func nodeAt(nodes []keyNode, line int, name string) (keyNode, bool) {
+	var found keyNode
+	ok := false
+	for _, n := range nodes {
+		if n.line == line && (name == "" || n.name == name) && !strings.HasSuffix(n.path, "["+n.name+"]") {
+			found, ok = n, true
+		}
+	}
+	return found, ok
+}
+
+// describeGoType names the type a setting must have.
+func describeGoType(typ string) string {
+	switch {
+	case strings.HasPrefix(typ, "[]"):
+		return "a list"
+	case strings.HasPrefix(typ, "map["), strings.HasPrefix(typ, "config."):
+		return "a mapping"
+	case strings.HasPrefix(typ, "int"):
+		return "a number"
+	case typ == "bool":
+		return "true or false"
+	}
+	return typ
+}
+
+// describeYAML names a YAML value by its tag.
+func describeYAML(tag, value string) string {
+	switch tag {
+	case "seq":
+		return "a list"
+	case "map":
+		return "a mapping"
+	}
+	return strconv.Quote(value)
+}
+
+// quotedValue matches the quoted values of validation errors.
+var quotedValue = regexp.MustCompile(`"(?:[^"\\]|\\.)*"`)
+
+// locate returns the line of the setting a validation error is about: the
+// longest path the error names, narrowed to the item with the value it
+// quotes, if any. Errors naming no path are located by the value alone.
+func locate(msg string, nodes []keyNode) int {
+	var values []string
+	for _, q := range quotedValue.FindAllString(msg, -1) {
+		if v, err := strconv.Unquote(q); err == nil {
+			values = append(values, v)
+		}
+	}
+
+	best := -1
+	for i, n := range nodes {
+		if strings.Contains(msg, n.path) && (best < 0 || len(n.path) > len(nodes[best].path)) {
+			best = i
+		}
+	}
+	if best < 0 {
+		for _, n := range nodes {
+			if n.value.Kind == yaml.ScalarNode && slices.Contains(values, n.value.Value) {
+				return n.value.Line
+			}
+		}
+		return 0
+	}
+
+	prefix := nodes[best].path
+	for _, n := range nodes {
+		if strings.HasPrefix(n.path, prefix) && n.value.Kind == yaml.ScalarNode &&
+			slices.Contains(values, n.value.Value) {
+			return n.value.Line
+		}
+	}
+	return nodes[best].line
+}
+
+// knownKeys returns the keys of each config struct type, by the name yaml
+// gives the type in its errors, such as "config.CheckConfig".
+func knownKeys() map[string]map[string]bool {
+	keys := map[string]map[string]bool{}
+	var walk func(typ reflect.Type)
+	walk = func(typ reflect.Type) {
+		names := map[string]bool{}
+		keys[typ.String()] = names
+		for i := range typ.NumField() {
+			field := typ.Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+			if name == "" || name == "-" {
+				continue
+			}
+			names[name] = true
+			ft := field.Type
+			if ft.Kind() == reflect.Slice {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				walk(ft)
+			}
+		}
+	}
+	walk(reflect.TypeFor[Config]())
+	return keys
+}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckFile(t *testing.T) {
+	t.Parallel()
+
+	scaffold, err := Scaffold(ScaffoldOptions{IgnoreDomains: map[string]int{"localhost": 1}})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		data string
+		want []Problem
+	}{
+		{name: "valid", data: "types: [md]\ncheck:\n  timeout: 10\n"},
+		{name: "empty", data: ""},
+		{name: "scaffold", data: string(scaffold)},
+		{
+			name: "syntax error",
+			data: "check:\n  timeout: 10\n  retries: : 2\n",
+			want: []Problem{{Line: 3, Message: "invalid YAML: mapping values are not allowed in this context"}},
+		},
+		{
+			name: "unknown keys",
+			data: "check:\n  timout: 10\noutput:\n  showalive: true\nfoo: bar\n",
+			want: []Problem{
+				{Line: 2, Message: `unknown key "check.timout"; did you mean "check.timeout"?`},
+				{Line: 4, Message: `unknown key "output.showalive"; did you mean "output.showAlive"?`},
+				{Line: 5, Message: `unknown key "foo"`},
+			},
+		},
+		{
+			name: "wrong types",
+			data: "check:\n  timeout: soon\n  insecure: maybe\ntypes: md\n",
+			want: []Problem{
+				{Line: 2, Message: `check.timeout: expected a number, got "soon"`},
+				{Line: 3, Message: `check.insecure: expected true or false, got "maybe"`},
+				{Line: 4, Message: `types: expected a list, got "md"`},
+			},
+		},
+		{
+			name: "invalid setting",
+			data: "check:\n  concurrency: 10\n  fail_on: sometimes\n",
+			want: []Problem{{Line: 3, Message: `invalid check.fail_on "sometimes": valid values are [dead warnings none]`}},
+		},
+		{
+			name: "invalid list item",
+			data: "ignore:\n  regex:\n    - ok\n    - \"(\"\n",
+			want: []Problem{{Line: 4, Message: "invalid ignore.regex pattern \"(\": " +
+				"error parsing regexp: missing closing ): `(`"}},
+		},
+		{
+			name: "invalid map value",
+			data: "check:\n  domain_max_redirects:\n    a.com: 2\n    github.com: 0\n",
+			want: []Problem{{Line: 4, Message: "check.domain_max_redirects[github.com] must be > 0, got 0"}},
+		},
+		{
+			name: "invalid type",
+			data: "types:\n  - md\n  - docx\n",
+			want: []Problem{{Line: 3, Message: `invalid type "docx": valid types are ` + formatTypes()}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, CheckFile([]byte(tt.data)))
+		})
+	}
+}
+
+func TestProblem_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "line 3: bad", Problem{Line: 3, Message: "bad"}.String())
+	assert.Equal(t, "bad", Problem{Message: "bad"}.String())
+}
+
+// formatTypes returns the valid types as the type error lists them.
+func formatTypes() string {
+	var cfg Config
+	cfg.Types = []string{"docx"}
+	msg := cfg.Validate().Error()
+	return msg[len(`invalid type "docx": valid types are `):]
+}
@@ -0,0 +1,177 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+)
+
+// ScaffoldOptions are the settings gone init fills in a new config file.
+type ScaffoldOptions struct {
+	// Types are the file types to scan. Empty means md.
+	Types []string
+
+	// IgnoreDomains are the domains to ignore, with the number of links
+	// found to each.
+	IgnoreDomains map[string]int
+
+	// BadgeLinks is the number of badge links found. Any enables
+	// ignore.badges.
+	BadgeLinks int
+}
+
+// Scaffold returns a commented config file listing every option, with
+// those of opts set and the others commented out at their defaults.
+func Scaffold(opts ScaffoldOptions) ([]byte, error) {
+	if len(opts.Types) == 0 {
+		opts.Types = []string{"md"}
+	}
+	var buf bytes.Buffer
+	if err := scaffoldTemplate.Execute(&buf, opts); err != nil {
+		return nil, fmt.Errorf("rendering config file: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// scaffoldTemplate is the config file written by gone init.
+var scaffoldTemplate = template.Must(template.New("gonerc").Parse(
+	`# Configuration for gone (https://github.com/leonardomso/gone).
+# Uncomment a setting to change it; CLI flags override it for a single run.
+
+# File types to scan: md, json, yaml, toml, xml, html, ini, env, properties,
+# docker, code, txt, or one of the parsers below
+types:
+{{- range .Types}}
+  - {{.}}
+{{- end}}
+
+# Scanner settings
+scan:
+  # Only scan files matching these glob patterns
+  # include:
+  #   - "docs/**"
+  #   - "README.md"
+  # Skip files matching these glob patterns
+  # exclude:
+  #   - "node_modules/**"
+  #   - "vendor/**"
+  # max_file_size: 0       # Skip files over this many bytes (0 = no limit)
+  # parse_timeout: ""      # Stop parsing a file after this long, such as 10s
+  # priority_paths: []     # Parse and check these files first, exempt from the limits above
+  # xml_attributes: []     # Attributes read as links in XML files (replaces the defaults)
+
+# Checker settings
+check:
+  # concurrency: 50        # Number of concurrent workers
+  # timeout: 5             # Request timeout in seconds
+  # retries: 1             # Retry attempts for failed requests
+  # strict: false          # Fail on malformed files
+  # max_redirects: 5       # Redirect hops to follow
+  # domain_max_redirects: {} # Per-domain override, such as {github.com: 10}
+  # headers: {}            # Headers sent with every request; ${VARS} are expanded
+  # domain_headers:        # Headers sent only to a domain and its subdomains
+  #   github.example.com:
+  #     Authorization: "token ${GHE_TOKEN}"
+  # ua_profile: default    # default, chrome, firefox, curl, or a ua_profiles key
+  # ua_profiles: {}        # Custom header sets, such as {corp: {User-Agent: corp-bot/1.0}}
+  # force_get_domains: []  # Check these domains with GET only
+  # head_only_domains: []  # Check these domains with HEAD only
+  # disable_resolvers: []  # Check these as regular pages: github, npm, pypi, youtube
+  # redirect_warn_hops: 0  # Warn on redirect chains longer than this (0 = off)
+  # redirect_policy: strict # strict, ignore-https-upgrade, or lenient
+  # recheck_interval: ""   # Recheck all links in watch and serve, such as 30m or "@hourly"
+  # recheck_webhook: ""    # POST the status changes rechecks find to this URL; ${VARS} are expanded
+  # probe_hosts: false     # Probe every host once before checking
+  # inspect_pages: false   # Flag working pages marked noindex
+  # check_anchors: false   # Verify #fragments exist on their target pages
+  # detect_soft_404: false # Flag working pages that look like "not found" pages
+  # check_internal: false  # Verify relative links and heading anchors between files
+  # insecure: false        # Report certificate problems as warnings
+  # lint: false            # Flag link text that shows a different URL than the target
+  # http3: false           # Try HTTP/3 first
+  # enable_cookies: false  # Replay cookies along redirect chains
+  # ip_family: auto        # auto, ipv4, or ipv6
+  # dns_server: ""         # Resolve host names with this DNS server, such as 1.1.1.1
+  # max_duration: ""       # Report links not checked in time as skipped, such as 5m
+  # max_requests: 0        # Send at most this many requests per run (0 = no limit)
+  # stale_after: ""        # Hint at working links not modified for this long, such as 5y
+  # fail_on: dead          # What fails the run: dead, warnings, or none
+  # login_patterns: []     # Extra login page patterns, such as sso.example.com
+  # status_codes:          # Override how status codes are classified
+  #   alive: []
+  #   dead: []
+  #   blocked: []
+  # content_rules:         # Assert the content of working pages
+  #   - pattern: "https://app.example.com/*"
+  #     must_contain: "<h1>"
+  #     must_match: 'v\d+\.\d+'
+
+# Output preferences
+output:
+  # format: ""             # json, yaml, xml, junit, markdown, shield-json, html, github,
+  #                        # csv, ndjson, tap, prometheus, or template (empty = text)
+  # template: ""           # Go template file for the template format
+  # showAlive: false       # Show alive links
+  # showWarnings: true     # Show warnings (redirects, blocked)
+  # showDead: true         # Show dead links
+  # showStats: false       # Show performance statistics
+  # metricsFile: ""        # Also write Prometheus metrics here after every check
+  # theme: ""              # Colors of text output: default, ansi, or mono
+  # groupBy: ""            # Group results by status (default), file, or domain
+  # sort: ""               # Order results by url, status, file, or latency
+  # top: 0                 # List only the first N results (0 = all)
+  # reportAll: false       # Add a Full Inventory of every link to Markdown reports
+  # junitSuitePer: ""      # A JUnit test suite per file (default) or domain
+  # junitIncludePassed: false # Add passing links to JUnit reports
+
+# Persistent result cache (gone check --cache, gone cache warm)
+cache:
+  # enabled: false         # Use the cache on every check
+  # path: ""               # Default: results.json in the user cache directory
+  # ttl: 24h               # How long cached results stay fresh
+
+# Ignore rules
+ignore:
+  # Domains to ignore, with their subdomains
+{{- if .IgnoreDomains}}
+  domains:
+{{- range $domain, $links := .IgnoreDomains}}
+    - {{printf "%q" $domain}} # {{$links}} link(s)
+{{- end}}
+{{- else}}
+  # domains:
+  #   - localhost
+  #   - example.com
+{{- end}}
+  # Glob patterns matched against the URL
+  # patterns:
+  #   - "*/internal/*"
+  # Regular expressions matched against the URL
+  # regex:
+  #   - "192\\.168\\..*"
+  # Skip shields.io and CI status badges
+{{- if .BadgeLinks}}
+  badges: true # {{.BadgeLinks}} link(s)
+{{- else}}
+  badges: false
+{{- end}}
+
+# Deny rules: matching links are reported as policy violations and fail the
+# run, whether or not they work. They take domains, patterns, and regex too.
+# deny:
+#   domains:
+#     - intranet.corp
+
+# URL rewrite rules applied by gone fix without checking each URL
+# rewrites:
+#   - from: "^http://old.docs/(.*)"
+#     to: "https://new.docs/$1"
+
+# URL normalization rules applied by gone fix: tracking, slashes, trailing-slash
+# normalize:
+#   - tracking
+
+# External parsers for formats gone doesn't read, by file extension
+# parsers:
+#   adoc: "asciidoc-links --json"
+`))
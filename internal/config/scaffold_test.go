@@ -0,0 +1,80 @@
+package config
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestScaffold(t *testing.T) {
+	t.Parallel()
+
+	data, err := Scaffold(ScaffoldOptions{
+		Types:         []string{"md", "html"},
+		IgnoreDomains: map[string]int{"localhost": 3, "::1": 1, "example.com": 2},
+		BadgeLinks:    4,
+	})
+	require.NoError(t, err)
+
+	var cfg Config
+	require.NoError(t, yaml.Unmarshal(data, &cfg))
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, []string{"md", "html"}, cfg.Types)
+	assert.Equal(t, []string{"::1", "example.com", "localhost"}, cfg.Ignore.Domains)
+	assert.True(t, cfg.Ignore.Badges)
+	assert.Contains(t, string(data), `- "localhost" # 3 link(s)`)
+
+	// Everything else is left at its default
+	cfg.Types, cfg.Ignore = nil, IgnoreConfig{}
+	assert.True(t, cfg.IsEmpty())
+}
+
+func TestScaffold_Defaults(t *testing.T) {
+	t.Parallel()
+
+	data, err := Scaffold(ScaffoldOptions{})
+	require.NoError(t, err)
+	var cfg Config
+	require.NoError(t, yaml.Unmarshal(data, &cfg))
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, []string{"md"}, cfg.Types)
+	assert.Empty(t, cfg.Ignore.Domains)
+	assert.False(t, cfg.Ignore.Badges)
+}
+
+// TestScaffold_ListsEveryOption keeps the scaffold in sync with Config: every
+// key must appear, set or commented out.
+func TestScaffold_ListsEveryOption(t *testing.T) {
+	t.Parallel()
+
+	scaffold, err := Scaffold(ScaffoldOptions{})
+	require.NoError(t, err)
+	for _, key := range yamlKeys(reflect.TypeFor[Config]()) {
+		pattern := regexp.MustCompile(`(?m)^[#\s]*(- )?` + regexp.QuoteMeta(key) + `:`)
+		assert.Regexp(t, pattern, string(scaffold), "option %q is missing", key)
+	}
+}
+
+// yamlKeys returns the yaml keys of a struct type and of its nested structs.
+func yamlKeys(typ reflect.Type) []string {
+	var keys []string
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		key, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if key == "" || key == "-" {
+			continue
+		}
+		keys = append(keys, key)
+		if ft := field.Type; ft.Kind() == reflect.Struct {
+			keys = append(keys, yamlKeys(ft)...)
+		} else if ft.Kind() == reflect.Slice && ft.Elem().Kind() == reflect.Struct {
+			keys = append(keys, yamlKeys(ft.Elem())...)
+		}
+	}
+	return keys
+}
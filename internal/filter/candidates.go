@@ -0,0 +1,72 @@
+package filter
+
+import (
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/leonardomso/gone/internal/parser"
+)
+
+// Candidate is a domain that links point to but that is never meant to be
+// checked, suggested as an ignore rule.
+type Candidate struct {
+	Domain string // Domain to ignore, covering its subdomains
+	Links  int    // Number of links to it
+}
+
+// exampleDomains are reserved for documentation (RFC 2606). A link to one
+// of their subdomains is suggested as the domain itself.
+var exampleDomains = []string{"example.com", "example.net", "example.org"}
+
+// localTLDs are top-level domains that never resolve publicly: reserved
+// ones (RFC 2606, RFC 6761), mDNS, and the one for private networks.
+var localTLDs = []string{"localhost", "test", "example", "invalid", "local", "internal"}
+
+// FindCandidates returns the domains among urls worth ignoring, sorted:
+// localhost, loopback and private addresses, the reserved example domains,
+// and hosts under local or reserved top-level domains. It also returns the
+// number of badge links (see parser.IsBadgeURL), which the badges rule skips.
+func FindCandidates(urls []string) (candidates []Candidate, badges int) {
+	counts := map[string]int{}
+	for _, rawURL := range urls {
+		if parser.IsBadgeURL(rawURL) {
+			badges++
+			continue
+		}
+		parsed, err := url.Parse(rawURL)
+		if err != nil {
+			continue
+		}
+		if domain, ok := candidateDomain(strings.ToLower(parsed.Hostname())); ok {
+			counts[domain]++
+		}
+	}
+
+	for domain, n := range counts {
+		candidates = append(candidates, Candidate{Domain: domain, Links: n})
+	}
+	slices.SortFunc(candidates, func(a, b Candidate) int {
+		return strings.Compare(a.Domain, b.Domain)
+	})
+	return candidates, badges
+}
+
+// candidateDomain returns the domain to ignore for links to host, if any.
+func candidateDomain(host string) (string, bool) {
+	if host == "" {
+		return "", false
+	}
+	if addr, err := netip.ParseAddr(host); err == nil {
+		local := addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() || addr.IsLinkLocalUnicast()
+		return host, local
+	}
+	for _, d := range exampleDomains {
+		if host == d || strings.HasSuffix(host, "."+d) {
+			return d, true
+		}
+	}
+	tld := host[strings.LastIndex(host, ".")+1:]
+	return host, slices.Contains(localTLDs, tld)
+}
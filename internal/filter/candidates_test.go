@@ -0,0 +1,59 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindCandidates(t *testing.T) {
+	t.Parallel()
+
+	candidates, badges := FindCandidates([]string{
+		"http://localhost:3000/api",
+		"http://localhost:8080",
+		"http://127.0.0.1:9000/metrics",
+		"http://[::1]/",
+		"http://192.168.1.10/admin",
+		"https://api.example.com/v1",
+		"https://example.org",
+		"http://myapp.test/login",
+		"http://printer.local",
+		"https://img.shields.io/badge/go-1.25-blue",
+		"https://github.com/leonardomso/gone",
+		"https://8.8.8.8/",
+		"https://example.community/",
+		"::not a url",
+	})
+
+	assert.Equal(t, []Candidate{
+		{Domain: "127.0.0.1", Links: 1},
+		{Domain: "192.168.1.10", Links: 1},
+		{Domain: "::1", Links: 1},
+		{Domain: "example.com", Links: 1},
+		{Domain: "example.org", Links: 1},
+		{Domain: "localhost", Links: 2},
+		{Domain: "myapp.test", Links: 1},
+		{Domain: "printer.local", Links: 1},
+	}, candidates)
+	assert.Equal(t, 1, badges)
+}
+
+func TestFindCandidates_Ignored(t *testing.T) {
+	t.Parallel()
+
+	// Every candidate, as a domain rule, ignores the links it was found in
+	urls := []string{"http://localhost:3000", "https://docs.example.com/a", "http://[::1]:8080/", "http://a.b.test"}
+	candidates, _ := FindCandidates(urls)
+	domains := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		domains = append(domains, c.Domain)
+	}
+
+	f, err := New(Config{Domains: domains})
+	require.NoError(t, err)
+	for _, u := range urls {
+		assert.True(t, f.ShouldIgnore(u, "README.md", 1), u)
+	}
+}
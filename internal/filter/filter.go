@@ -3,16 +3,19 @@ package filter
 
 import (
 	"fmt"
+	"log/slog"
 	"net/url"
 	"regexp"
 	"strings"
 
 	"github.com/gobwas/glob"
+
+	"github.com/leonardomso/gone/internal/parser"
 )
 
 // IgnoreReason describes why a URL was ignored.
 type IgnoreReason struct {
-	Type string // "domain", "pattern", or "regex"
+	Type string // "domain", "pattern", "regex", or "badge"
 	Rule string // The rule that matched
 	URL  string // The URL that was ignored
 	File string // Source file
@@ -31,6 +34,9 @@ type Filter struct {
 	// regexPatterns are compiled regex patterns for URL matching.
 	regexPatterns []compiledRegex
 
+	// badges skips badge images; see parser.IsBadgeURL.
+	badges bool
+
 	// Track ignored URLs for reporting
 	ignored []IgnoreReason
 }
@@ -52,6 +58,7 @@ type Config struct {
 	Domains       []string // Domains to ignore (includes subdomains)
 	GlobPatterns  []string // Glob patterns (e.g., "*.local/*")
 	RegexPatterns []string // Regex patterns (e.g., ".*\\.internal\\..*")
+	Badges        bool     // Skip badge images (shields.io, CI status badges)
 }
 
 // New creates a new Filter from the given configuration.
@@ -61,6 +68,7 @@ func New(cfg Config) (*Filter, error) {
 	f := &Filter{
 		domains: map[string]bool{},
 		ignored: []IgnoreReason{},
+		badges:  cfg.Badges,
 	}
 
 	// Add domains to map (normalize to lowercase)
@@ -111,47 +119,54 @@ func New(cfg Config) (*Filter, error) {
 // If the URL matches any rule, it records the reason and returns true.
 // Check order (fastest first): domain → glob → regex.
 func (f *Filter) ShouldIgnore(rawURL, file string, line int) bool {
-	if f == nil {
+	ruleType, rule, ok := f.Match(rawURL)
+	if !ok {
 		return false
 	}
 
+	f.ignored = append(f.ignored, IgnoreReason{
+		Type: ruleType,
+		Rule: rule,
+		URL:  rawURL,
+		File: file,
+		Line: line,
+	})
+	slog.Info("ignoring link", "url", rawURL, "file", file, "line", line, "rule_type", ruleType, "rule", rule)
+	return true
+}
+
+// Match reports whether a URL matches any rule, without recording it.
+// ruleType is "domain", "pattern", "regex", or "badge", and rule is the rule
+// that matched; for badges, the badge service's host.
+func (f *Filter) Match(rawURL string) (ruleType, rule string, ok bool) {
+	if f == nil {
+		return "", "", false
+	}
+
 	// Check domain first (O(1) lookup)
 	if reason, ok := f.matchesDomain(rawURL); ok {
-		f.ignored = append(f.ignored, IgnoreReason{
-			Type: "domain",
-			Rule: reason,
-			URL:  rawURL,
-			File: file,
-			Line: line,
-		})
-		return true
+		return "domain", reason, true
 	}
 
 	// Check glob patterns
 	if reason, ok := f.matchesGlob(rawURL); ok {
-		f.ignored = append(f.ignored, IgnoreReason{
-			Type: "pattern",
-			Rule: reason,
-			URL:  rawURL,
-			File: file,
-			Line: line,
-		})
-		return true
+		return "pattern", reason, true
 	}
 
 	// Check regex patterns
 	if reason, ok := f.matchesRegex(rawURL); ok {
-		f.ignored = append(f.ignored, IgnoreReason{
-			Type: "regex",
-			Rule: reason,
-			URL:  rawURL,
-			File: file,
-			Line: line,
-		})
-		return true
+		return "regex", reason, true
+	}
+
+	if f.badges && parser.IsBadgeURL(rawURL) {
+		host := rawURL
+		if parsed, err := url.Parse(rawURL); err == nil {
+			host = parsed.Hostname()
+		}
+		return "badge", host, true
 	}
 
-	return false
+	return "", "", false
 }
 
 // matchesDomain checks if the URL's domain matches any ignored domain.
@@ -238,7 +253,7 @@ func (f *Filter) HasRules() bool {
 	if f == nil {
 		return false
 	}
-	return len(f.domains) > 0 || len(f.globPatterns) > 0 || len(f.regexPatterns) > 0
+	return len(f.domains) > 0 || len(f.globPatterns) > 0 || len(f.regexPatterns) > 0 || f.badges
 }
 
 // Stats returns a summary of the filter's rules.
@@ -240,6 +240,25 @@ func TestShouldIgnore_Regex(t *testing.T) {
 	}
 }
 
+func TestShouldIgnore_Badges(t *testing.T) {
+	t.Parallel()
+
+	f, err := New(Config{Badges: true})
+	require.NoError(t, err)
+	assert.True(t, f.HasRules())
+
+	assert.True(t, f.ShouldIgnore("https://img.shields.io/badge/go-1.25-blue", "README.md", 3))
+	assert.False(t, f.ShouldIgnore("https://github.com/badges/shields", "README.md", 4))
+	require.Len(t, f.IgnoredURLs(), 1)
+	assert.Equal(t, "badge", f.IgnoredURLs()[0].Type)
+	assert.Equal(t, "img.shields.io", f.IgnoredURLs()[0].Rule)
+
+	// Badges are only skipped when asked for
+	f, err = New(Config{})
+	require.NoError(t, err)
+	assert.False(t, f.ShouldIgnore("https://img.shields.io/badge/go-1.25-blue", "README.md", 3))
+}
+
 func TestShouldIgnore_EdgeCases(t *testing.T) {
 	t.Parallel()
 
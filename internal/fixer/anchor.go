@@ -0,0 +1,46 @@
+package fixer
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/leonardomso/gone/internal/checker"
+	"github.com/leonardomso/gone/internal/relative"
+)
+
+// SetFixAnchors sets whether links to a missing #fragment are replaced with
+// the closest anchor on the target page, when the checker found one close
+// enough (see checker.Result.ClosestAnchor).
+func (f *Fixer) SetFixAnchors(enabled bool) {
+	f.fixAnchors = enabled
+}
+
+// SetInternalAnchors sets the relative links to missing anchors found by
+// relative.Check. Those with a close enough anchor in the target file are
+// fixed by FindAllFixes.
+func (f *Fixer) SetInternalAnchors(findings []relative.Finding) {
+	f.internalAnchors = findings
+}
+
+// anchorFor returns the URL replacing a link to a missing anchor, with the
+// closest anchor as its fragment. If the link redirected, it points at the
+// final page.
+func (f *Fixer) anchorFor(r checker.Result) (string, bool) {
+	resolved := r.Resolved()
+	if !f.fixAnchors || resolved.Status != checker.StatusBrokenAnchor || resolved.ClosestAnchor == "" {
+		return "", false
+	}
+	base := r.Link.URL
+	if len(resolved.RedirectChain) > 0 && resolved.FinalURL != "" {
+		base = resolved.FinalURL
+	}
+	return withFragment(base, resolved.ClosestAnchor), true
+}
+
+// withFragment returns rawURL with its fragment replaced.
+func withFragment(rawURL, fragment string) string {
+	if i := strings.IndexByte(rawURL, '#'); i >= 0 {
+		rawURL = rawURL[:i]
+	}
+	return rawURL + "#" + (&url.URL{Fragment: fragment}).EscapedFragment()
+}
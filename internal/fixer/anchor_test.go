@@ -0,0 +1,110 @@
+package fixer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/leonardomso/gone/internal/checker"
+	"github.com/leonardomso/gone/internal/parser"
+	"github.com/leonardomso/gone/internal/relative"
+)
+
+func TestWithFragment(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "https://a.com/docs#usage", withFragment("https://a.com/docs#usge", "usage"))
+	assert.Equal(t, "https://a.com/docs#usage", withFragment("https://a.com/docs", "usage"))
+	assert.Equal(t, "#install", withFragment("#instal", "install"))
+	assert.Equal(t, "a.md#c%23-api", withFragment("a.md#c-api", "c#-api"))
+}
+
+func TestFixer_Suggest_Anchor(t *testing.T) {
+	t.Parallel()
+
+	broken := checker.Result{
+		Link:          checker.Link{URL: "https://a.com/docs#usge", FilePath: "README.md", Line: 1},
+		Status:        checker.StatusBrokenAnchor,
+		StatusCode:    200,
+		ClosestAnchor: "usage",
+	}
+
+	f := New()
+	_, ok := f.Suggest(broken)
+	assert.False(t, ok, "anchors are only fixed when enabled")
+
+	f.SetFixAnchors(true)
+	s, ok := f.Suggest(broken)
+	require.True(t, ok)
+	assert.Equal(t, checker.Suggestion{URL: "https://a.com/docs#usage", Source: SuggestionAnchor}, s)
+
+	// A redirecting link is pointed at the final page
+	broken.RedirectChain = []checker.Redirect{{URL: "https://a.com/docs", StatusCode: 301}}
+	broken.FinalURL = "https://a.com/guide#usge"
+	s, ok = f.Suggest(broken)
+	require.True(t, ok)
+	assert.Equal(t, "https://a.com/guide#usage", s.URL)
+
+	broken.ClosestAnchor = ""
+	_, ok = f.Suggest(broken)
+	assert.False(t, ok, "no anchor is close enough")
+}
+
+func TestFixer_FindAllFixes_Anchors(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "README.md")
+	content := "See [install](#instal), [installation](#installation), [setup](docs/setup.md#instal),\n" +
+		"and [usage](https://a.com/docs#usge).\n"
+	require.NoError(t, os.WriteFile(filePath, []byte(content), 0o600))
+
+	results := []checker.Result{{
+		Link:          checker.Link{URL: "https://a.com/docs#usge", FilePath: filePath, Line: 2},
+		Status:        checker.StatusBrokenAnchor,
+		StatusCode:    200,
+		ClosestAnchor: "usage",
+	}}
+	findings := []relative.Finding{
+		{
+			Problem: relative.ProblemMissingAnchor,
+			Anchor:  "instal",
+			Closest: "install",
+			Link:    parser.Link{URL: "#instal", FilePath: filePath, Line: 1, Type: parser.LinkTypeInline},
+		},
+		{
+			Problem: relative.ProblemMissingAnchor,
+			Anchor:  "instal",
+			Closest: "install",
+			Link:    parser.Link{URL: "docs/setup.md#instal", FilePath: filePath, Line: 1, Type: parser.LinkTypeInline},
+		},
+		{
+			Problem: relative.ProblemMissingFile,
+			Link:    parser.Link{URL: "missing.md", FilePath: filePath, Line: 1, Type: parser.LinkTypeInline},
+		},
+	}
+
+	f := New()
+	f.SetFixAnchors(true)
+	f.SetInternalAnchors(findings)
+	changes := f.FindAllFixes(results, nil)
+	require.Len(t, changes, 1)
+	require.Len(t, changes[0].Fixes, 3)
+	for _, fix := range changes[0].Fixes {
+		assert.True(t, fix.Anchor, fix.OldURL)
+		assert.Equal(t, SuggestionAnchor, fix.Source())
+		assert.Equal(t, RiskMedium, fix.Risk)
+	}
+
+	result, err := f.ApplyToFile(changes[0])
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Applied)
+
+	got, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, "See [install](#install), [installation](#installation), [setup](docs/setup.md#install),\n"+
+		"and [usage](https://a.com/docs#usage).\n", string(got))
+}
@@ -0,0 +1,234 @@
+package fixer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// DefaultBackupDir is where fix sessions keep the original files they
+// modify, one directory per session, named after when it started.
+const DefaultBackupDir = ".gone/backup"
+
+// ApplyLogName is the name of a session's apply log, next to its backups.
+const ApplyLogName = "apply-log.json"
+
+// applyLogVersion is the version of the apply log format.
+const applyLogVersion = 1
+
+// ApplyLog describes every change a fix session made.
+type ApplyLog struct {
+	Version   int           `json:"version"`
+	StartedAt time.Time     `json:"started_at"`
+	Root      string        `json:"root"` // Directory relative file paths are relative to
+	Files     []AppliedFile `json:"files"`
+}
+
+// AppliedFile is a file a fix session modified.
+type AppliedFile struct {
+	Path         string      `json:"path"`
+	Backup       string      `json:"backup"` // Original content, relative to the session directory
+	SHA256Before string      `json:"sha256_before"`
+	SHA256After  string      `json:"sha256_after"`
+	Changes      []URLChange `json:"changes"`
+}
+
+// Backup keeps the original content of the files a fix session modifies,
+// with an apply log, so the session can be undone (see Undo).
+type Backup struct {
+	dir string // Session directory, created with the first backup
+	log ApplyLog
+}
+
+// NewBackup starts a backup session under root, usually DefaultBackupDir.
+// Nothing is written until a file is modified.
+func NewBackup(root string) (*Backup, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	b := &Backup{
+		dir: sessionDir(root, now),
+		log: ApplyLog{Version: applyLogVersion, StartedAt: now.UTC(), Root: wd},
+	}
+	return b, nil
+}
+
+// sessionDir returns an unused session directory for a session started at t.
+func sessionDir(root string, t time.Time) string {
+	name := t.UTC().Format("20060102-150405")
+	dir := filepath.Join(root, name)
+	for i := 2; ; i++ {
+		if _, err := os.Stat(dir); errors.Is(err, os.ErrNotExist) {
+			return dir
+		}
+		dir = filepath.Join(root, name+"-"+strconv.Itoa(i))
+	}
+}
+
+// Dir returns the session directory.
+func (b *Backup) Dir() string {
+	return b.dir
+}
+
+// record saves a file's original content and logs its changes. It's called
+// before the modified content is written.
+func (b *Backup) record(path, original, modified string, changes []URLChange) error {
+	backup := filepath.Join("files", strconv.Itoa(len(b.log.Files)+1)+"-"+filepath.Base(path))
+	fullPath := filepath.Join(b.dir, backup)
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0o750); err != nil {
+		return fmt.Errorf("creating backup directory: %w", err)
+	}
+	if err := os.WriteFile(fullPath, []byte(original), 0o600); err != nil {
+		return fmt.Errorf("backing up %s: %w", path, err)
+	}
+
+	b.log.Files = append(b.log.Files, AppliedFile{
+		Path:         path,
+		Backup:       filepath.ToSlash(backup),
+		SHA256Before: checksum(original),
+		SHA256After:  checksum(modified),
+		Changes:      changes,
+	})
+	return b.writeLog()
+}
+
+// forget drops a logged file whose changes were rolled back, and the
+// session directory once no file is left.
+func (b *Backup) forget(path string) error {
+	i := slices.IndexFunc(b.log.Files, func(f AppliedFile) bool { return f.Path == path })
+	if i < 0 {
+		return nil
+	}
+	_ = os.Remove(filepath.Join(b.dir, b.log.Files[i].Backup))
+	b.log.Files = slices.Delete(b.log.Files, i, i+1)
+	if len(b.log.Files) == 0 {
+		return os.RemoveAll(b.dir)
+	}
+	return b.writeLog()
+}
+
+// writeLog writes the apply log, replacing the previous one.
+func (b *Backup) writeLog() error {
+	data, err := json.MarshalIndent(b.log, "", "  ")
+	if err != nil {
+		return err
+	}
+	tempPath, err := writeTemp(filepath.Join(b.dir, ApplyLogName), string(data)+"\n", 0o600)
+	if err == nil {
+		err = os.Rename(tempPath, filepath.Join(b.dir, ApplyLogName))
+	}
+	if err != nil {
+		return fmt.Errorf("writing apply log: %w", err)
+	}
+	return nil
+}
+
+// checksum returns the hex SHA-256 of content.
+func checksum(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// LastSession returns the directory of the most recent backup session under
+// root, or "" if there is none.
+func LastSession(root string) (string, error) {
+	entries, err := os.ReadDir(root)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	// Session names sort by time; a -N suffix sorts after the same second
+	for i := len(entries) - 1; i >= 0; i-- {
+		dir := filepath.Join(root, entries[i].Name())
+		if _, err := os.Stat(filepath.Join(dir, ApplyLogName)); entries[i].IsDir() && err == nil {
+			return dir, nil
+		}
+	}
+	return "", nil
+}
+
+// ReadApplyLog reads the apply log of a backup session.
+func ReadApplyLog(dir string) (*ApplyLog, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ApplyLogName))
+	if err != nil {
+		return nil, fmt.Errorf("reading apply log: %w", err)
+	}
+	var log ApplyLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		return nil, fmt.Errorf("reading apply log: %w", err)
+	}
+	if log.Version > applyLogVersion {
+		return nil, fmt.Errorf("apply log version %d is newer than this version of gone supports (%d)",
+			log.Version, applyLogVersion)
+	}
+	return &log, nil
+}
+
+// Undo restores the files modified by the backup session in dir and then
+// removes the session, so undoing again goes back one more session.
+// Nothing is restored if any file changed since the session modified it.
+func Undo(dir string) (*ApplyLog, error) {
+	log, err := ReadApplyLog(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check everything before restoring anything
+	originals := make([]string, len(log.Files))
+	var changed []error
+	for i, f := range log.Files {
+		current, err := os.ReadFile(log.path(f))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Path, err)
+		}
+		if checksum(string(current)) != f.SHA256After {
+			changed = append(changed, fmt.Errorf("%s changed since it was fixed", f.Path))
+			continue
+		}
+		original, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(f.Backup)))
+		if err != nil {
+			return nil, fmt.Errorf("%s: reading backup: %w", f.Path, err)
+		}
+		originals[i] = string(original)
+	}
+	if len(changed) > 0 {
+		return nil, fmt.Errorf("%w; restore files by hand from %s", errors.Join(changed...), dir)
+	}
+
+	for i, f := range log.Files {
+		path := log.path(f)
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Path, err)
+		}
+		tempPath, err := writeTemp(path, originals[i], info.Mode().Perm())
+		if err == nil {
+			err = os.Rename(tempPath, path)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: restoring original: %w", f.Path, err)
+		}
+	}
+
+	return log, os.RemoveAll(dir)
+}
+
+// path returns where a logged file is, resolving relative paths against
+// the directory the session ran in.
+func (l *ApplyLog) path(f AppliedFile) string {
+	if filepath.IsAbs(f.Path) || l.Root == "" {
+		return f.Path
+	}
+	return filepath.Join(l.Root, f.Path)
+}
@@ -0,0 +1,177 @@
+package fixer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// oldToNew returns the changes replacing https://old.com in each file.
+func oldToNew(paths ...string) []FileChanges {
+	changes := make([]FileChanges, 0, len(paths))
+	for _, path := range paths {
+		changes = append(changes, FileChanges{
+			FilePath:   path,
+			TotalFixes: 1,
+			Fixes:      []Fix{{FilePath: path, Line: 1, OldURL: "https://old.com", NewURL: "https://new.com"}},
+		})
+	}
+	return changes
+}
+
+func TestBackup_UndoRestoresFiles(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	root := filepath.Join(tmpDir, ".gone", "backup")
+	file1 := filepath.Join(tmpDir, "a.md")
+	file2 := filepath.Join(tmpDir, "b.md")
+	require.NoError(t, os.WriteFile(file1, []byte("[a](https://old.com)\n"), 0o644))
+	require.NoError(t, os.WriteFile(file2, []byte("[b](https://old.com)\n"), 0o600))
+
+	b, err := NewBackup(root)
+	require.NoError(t, err)
+	f := New()
+	f.SetBackup(b)
+
+	_, err = f.ApplyAtomic(oldToNew(file1, file2))
+	require.NoError(t, err)
+
+	log, err := ReadApplyLog(b.Dir())
+	require.NoError(t, err)
+	assert.Equal(t, applyLogVersion, log.Version)
+	require.Len(t, log.Files, 2)
+	assert.Equal(t, file1, log.Files[0].Path)
+	assert.Equal(t, checksum("[a](https://old.com)\n"), log.Files[0].SHA256Before)
+	assert.Equal(t, checksum("[a](https://new.com)\n"), log.Files[0].SHA256After)
+	assert.Equal(t, []URLChange{{OldURL: "https://old.com", NewURL: "https://new.com", Line: 1}}, log.Files[0].Changes)
+
+	last, err := LastSession(root)
+	require.NoError(t, err)
+	assert.Equal(t, b.Dir(), last)
+
+	undone, err := Undo(last)
+	require.NoError(t, err)
+	assert.Len(t, undone.Files, 2)
+
+	content, err := os.ReadFile(file1)
+	require.NoError(t, err)
+	assert.Equal(t, "[a](https://old.com)\n", string(content))
+	content, err = os.ReadFile(file2)
+	require.NoError(t, err)
+	assert.Equal(t, "[b](https://old.com)\n", string(content))
+
+	// File modes are preserved and the session is gone
+	info, err := os.Stat(file1)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o644), info.Mode().Perm())
+
+	last, err = LastSession(root)
+	require.NoError(t, err)
+	assert.Empty(t, last)
+}
+
+func TestBackup_UndoRefusesChangedFiles(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	file1 := filepath.Join(tmpDir, "a.md")
+	file2 := filepath.Join(tmpDir, "b.md")
+	require.NoError(t, os.WriteFile(file1, []byte("https://old.com"), 0o600))
+	require.NoError(t, os.WriteFile(file2, []byte("https://old.com"), 0o600))
+
+	b, err := NewBackup(filepath.Join(tmpDir, "backup"))
+	require.NoError(t, err)
+	f := New()
+	f.SetBackup(b)
+	_, err = f.ApplyAtomic(oldToNew(file1, file2))
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(file2, []byte("edited by hand"), 0o600))
+
+	_, err = Undo(b.Dir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "b.md changed since it was fixed")
+
+	// Nothing is restored and the backup is kept
+	content, err := os.ReadFile(file1)
+	require.NoError(t, err)
+	assert.Equal(t, "https://new.com", string(content))
+	_, err = os.Stat(filepath.Join(b.Dir(), ApplyLogName))
+	require.NoError(t, err)
+}
+
+func TestBackup_NothingWrittenWithoutChanges(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "a.md")
+	require.NoError(t, os.WriteFile(filePath, []byte("no links here"), 0o600))
+
+	b, err := NewBackup(filepath.Join(tmpDir, "backup"))
+	require.NoError(t, err)
+	f := New()
+	f.SetBackup(b)
+
+	result, err := f.ApplyToFile(oldToNew(filePath)[0])
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Applied)
+
+	_, err = os.Stat(b.Dir())
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestBackup_Forget(t *testing.T) {
+	t.Parallel()
+
+	b, err := NewBackup(filepath.Join(t.TempDir(), "backup"))
+	require.NoError(t, err)
+
+	require.NoError(t, b.record("a.md", "old a", "new a", nil))
+	require.NoError(t, b.record("b.md", "old b", "new b", nil))
+
+	require.NoError(t, b.forget("a.md"))
+	log, err := ReadApplyLog(b.Dir())
+	require.NoError(t, err)
+	require.Len(t, log.Files, 1)
+	assert.Equal(t, "b.md", log.Files[0].Path)
+
+	require.NoError(t, b.forget("b.md"))
+	_, err = os.Stat(b.Dir())
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestLastSession(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	for _, name := range []string{"20240101-120000", "20240101-120000-2", "20230101-120000"} {
+		dir := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(dir, 0o750))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, ApplyLogName), []byte(`{"version":1}`), 0o600))
+	}
+	// A later session without an apply log is ignored
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "20250101-120000"), 0o750))
+
+	last, err := LastSession(root)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, "20240101-120000-2"), last)
+
+	last, err = LastSession(filepath.Join(root, "missing"))
+	require.NoError(t, err)
+	assert.Empty(t, last)
+}
+
+func TestReadApplyLog_NewerVersion(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ApplyLogName), []byte(`{"version":99}`), 0o600))
+
+	_, err := ReadApplyLog(dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "newer")
+}
@@ -1,14 +1,22 @@
-// Package fixer provides functionality to automatically fix redirect URLs in markdown files.
+// Package fixer provides functionality to automatically fix redirect URLs in source files.
+// Markdown and other text formats replace whole URLs, never the start of a
+// longer URL or a URL inside another; HTML files only rewrite URL attributes;
+// JSON, YAML, TOML, and XML files are only changed where the parser found
+// each URL, with the new URL escaped to match.
 package fixer
 
 import (
 	"fmt"
+	"maps"
 	"os"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/leonardomso/gone/internal/checker"
 	"github.com/leonardomso/gone/internal/parser"
+	"github.com/leonardomso/gone/internal/relative"
 )
 
 // Fix represents a single URL replacement to be made.
@@ -22,6 +30,26 @@ type Fix struct {
 	LinkType    parser.LinkType
 	RefUsages   int  // How many places use this reference
 	IsRefDef    bool // Is this a reference definition line?
+	Rewrite     bool // Produced by a rewrite rule rather than a checked redirect
+	Archive     bool // Replaces a dead link with an archived snapshot
+	Upgrade     bool // Replaces an http:// link with its working https:// version
+	Normalize   bool // Only removes tracking parameters or extra slashes
+	Anchor      bool // Replaces a broken #fragment with the closest anchor
+
+	// Byte offsets of the URL in the file, where structured files
+	// (JSON, YAML, TOML, XML) are fixed; other occurrences are left alone
+	Offsets []int
+
+	// Lines the URL was found on as a link, where other files are fixed in
+	// line-scoped mode (see SetLineScoped); empty fixes every line
+	Lines []int
+
+	// Other links the fix changes that may not expect the new URL
+	Warnings []string
+
+	Text        string   // Text of the first link, compared with the new page's title
+	Risk        Risk     // How likely the new URL isn't what the link meant
+	RiskReasons []string // What the risk is based on
 }
 
 // FileChanges groups all fixes for a single file.
@@ -42,15 +70,45 @@ type FixResult struct {
 
 // URLChange represents a single URL that was changed.
 type URLChange struct {
-	OldURL string
-	NewURL string
-	Line   int
+	OldURL string `json:"old_url"`
+	NewURL string `json:"new_url"`
+	Line   int    `json:"line"`
 }
 
-// Fixer handles URL replacement in markdown files.
+// Fixer handles URL replacement in source files.
 type Fixer struct {
 	// Track parser links for reference info
 	parserLinks []parser.Link
+
+	// Declarative URL rewrites applied without checking
+	rewriteRules []RewriteRule
+
+	// Archived snapshots replacing dead links, keyed by URL
+	snapshots map[string]string
+
+	// https:// versions replacing http:// links, keyed by URL
+	upgrades map[string]string
+
+	// Keeps the originals of modified files, if set
+	backup *Backup
+
+	// Cleans up links and the URLs replacing them
+	normalizer Normalizer
+
+	// Only replace URLs on the lines they were found on as links
+	lineScoped bool
+
+	// Links left unfixed in line-scoped mode because their line is unknown
+	unscoped []checker.Link
+
+	// Titles of the pages fixes point to, keyed by URL
+	titles map[string]string
+
+	// Replace broken #fragments with the closest anchor
+	fixAnchors bool
+
+	// Relative links to missing anchors, fixed like broken external anchors
+	internalAnchors []relative.Finding
 }
 
 // New creates a new Fixer instance.
@@ -63,18 +121,72 @@ func (f *Fixer) SetParserLinks(links []parser.Link) {
 	f.parserLinks = links
 }
 
+// SetBackup makes the fixer back up every file before modifying it.
+func (f *Fixer) SetBackup(b *Backup) {
+	f.backup = b
+}
+
+// SetLineScoped makes fixes only replace a URL on the lines the parser
+// found it on as a link, rather than wherever it appears in the file, so
+// the same URL in a code block example or in plain text is left alone.
+// Structured files are always fixed only where the URL was found.
+func (f *Fixer) SetLineScoped(scoped bool) {
+	f.lineScoped = scoped
+}
+
+// Unscoped returns the links the last FindFixes or FindAllFixes left alone
+// in line-scoped mode because no line they were found on is known, rather
+// than replacing their URL everywhere in the file.
+func (f *Fixer) Unscoped() []checker.Link {
+	return f.unscoped
+}
+
 // FindFixes analyzes check results and returns fixable items grouped by file.
 // Only redirects with a successful final destination (200) are considered fixable.
 func (f *Fixer) FindFixes(results []checker.Result) []FileChanges {
+	return f.FindAllFixes(results, nil)
+}
+
+// FindAllFixes combines fixable redirects from check results with links
+// rewritten by the configured rewrite rules (see SplitRewrites), and dead
+// links that have an archived snapshot (see SetSnapshots), and http:// links
+// whose https:// version works (see SetUpgrades), and links the normalizer
+// cleans up (see SetNormalizer), and links to missing anchors that have a
+// close match (see SetFixAnchors and SetInternalAnchors).
+// Rewritten links don't need a check result.
+func (f *Fixer) FindAllFixes(results []checker.Result, rewritten []checker.Link) []FileChanges {
 	fileFixMap := map[string]map[string]*Fix{}
+	f.unscoped = nil
 	urlToParserLink := f.buildURLToLinksMap()
 
 	for _, r := range results {
-		if !isFixableRedirect(r) {
+		if newURL, source, ok := f.replacementFor(r); ok {
+			f.addOrUpdateFix(fileFixMap, r.Link, newURL, source, urlToParserLink)
+		}
+	}
+
+	for _, link := range rewritten {
+		newURL, ok := f.Rewrite(link.URL)
+		if !ok {
 			continue
 		}
 
-		f.addOrUpdateFix(fileFixMap, r, urlToParserLink)
+		f.addOrUpdateFix(fileFixMap, link, newURL, SuggestionRewrite, urlToParserLink)
+	}
+
+	for _, finding := range f.internalAnchors {
+		if finding.Problem != relative.ProblemMissingAnchor || finding.Closest == "" {
+			continue
+		}
+		link := checker.Link{
+			URL:      finding.Link.URL,
+			FilePath: finding.Link.FilePath,
+			Text:     finding.Link.Text,
+			Type:     finding.Link.Type.String(),
+			Line:     finding.Link.Line,
+			Offset:   finding.Link.Offset,
+		}
+		f.addOrUpdateFix(fileFixMap, link, withFragment(link.URL, finding.Closest), SuggestionAnchor, urlToParserLink)
 	}
 
 	return f.buildFileChanges(fileFixMap)
@@ -91,20 +203,29 @@ func (f *Fixer) buildURLToLinksMap() map[string][]parser.Link {
 
 // isFixableRedirect checks if a result is a fixable redirect.
 func isFixableRedirect(r checker.Result) bool {
-	return r.Status == checker.StatusRedirect &&
+	return r.IsRedirect() &&
 		r.FinalStatus == 200 &&
 		r.FinalURL != "" &&
 		r.FinalURL != r.Link.URL
 }
 
 // addOrUpdateFix adds a new fix or increments occurrence count for existing fix.
+// The source is one of the Suggestion constants.
 func (f *Fixer) addOrUpdateFix(
 	fileFixMap map[string]map[string]*Fix,
-	r checker.Result,
+	link checker.Link,
+	newURL string,
+	source string,
 	urlToParserLink map[string][]parser.Link,
 ) {
-	filePath := r.Link.FilePath
-	oldURL := r.Link.URL
+	filePath := link.FilePath
+	oldURL := link.URL
+
+	if f.lineScoped && structuredFormat(filePath) == "" &&
+		len(linkLines(link, urlToParserLink[oldURL])) == 0 {
+		f.unscoped = append(f.unscoped, link)
+		return
+	}
 
 	if fileFixMap[filePath] == nil {
 		fileFixMap[filePath] = map[string]*Fix{}
@@ -112,33 +233,92 @@ func (f *Fixer) addOrUpdateFix(
 
 	if existing, ok := fileFixMap[filePath][oldURL]; ok {
 		existing.Occurrences++
+		existing.Offsets = addOffset(existing.Offsets, link.Offset)
+		if existing.Lines != nil && link.Type != parser.LinkTypeReference.String() {
+			existing.Lines = addLine(existing.Lines, link.Line)
+		}
 		return
 	}
 
-	fix := f.createFix(r, urlToParserLink)
+	fix := f.createFix(link, newURL, urlToParserLink)
+	fix.Rewrite = source == SuggestionRewrite
+	fix.Archive = source == SuggestionArchive
+	fix.Upgrade = source == SuggestionHTTPS
+	fix.Normalize = source == SuggestionNormalize
+	fix.Anchor = source == SuggestionAnchor
+	fix.Risk, fix.RiskReasons = assessRisk(*fix, f.titles[newURL])
 	fileFixMap[filePath][oldURL] = fix
 }
 
-// createFix creates a Fix from a checker result.
-func (f *Fixer) createFix(r checker.Result, urlToParserLink map[string][]parser.Link) *Fix {
+// createFix creates a Fix replacing a link's URL with newURL.
+func (f *Fixer) createFix(link checker.Link, newURL string, urlToParserLink map[string][]parser.Link) *Fix {
 	fix := &Fix{
-		FilePath:    r.Link.FilePath,
-		Line:        r.Link.Line,
-		OldURL:      r.Link.URL,
-		NewURL:      r.FinalURL,
+		FilePath:    link.FilePath,
+		Line:        link.Line,
+		OldURL:      link.URL,
+		NewURL:      newURL,
+		Text:        link.Text,
 		Occurrences: 1,
 		LinkType:    parser.LinkTypeInline,
 	}
 
-	if pLinks, ok := urlToParserLink[r.Link.URL]; ok {
+	if structuredFormat(link.FilePath) != "" {
+		// Every occurrence the parser found, including ones not checked
+		fix.Offsets = []int{link.Offset}
+		for _, pl := range urlToParserLink[link.URL] {
+			if pl.FilePath == link.FilePath {
+				fix.Offsets = addOffset(fix.Offsets, pl.Offset)
+			}
+		}
+	} else if f.lineScoped {
+		fix.Lines = linkLines(link, urlToParserLink[link.URL])
+	}
+
+	if pLinks, ok := urlToParserLink[link.URL]; ok {
 		f.applyRefInfo(fix, pLinks)
 	}
 
 	return fix
 }
 
+// addOffset adds an offset to a fix's offsets, unless it's already there.
+func addOffset(offsets []int, offset int) []int {
+	if offsets == nil || slices.Contains(offsets, offset) {
+		return offsets
+	}
+	return append(offsets, offset)
+}
+
+// linkLines returns the lines a link's URL was found on in its file: the
+// line of every link with the URL, or of its definition for reference links.
+func linkLines(link checker.Link, pLinks []parser.Link) []int {
+	lines := []int{}
+	if link.Type != parser.LinkTypeReference.String() {
+		lines = addLine(lines, link.Line)
+	}
+	for _, pl := range pLinks {
+		switch {
+		case pl.FilePath != link.FilePath:
+		case pl.RefDefLine > 0:
+			lines = addLine(lines, pl.RefDefLine)
+		default:
+			lines = addLine(lines, pl.Line)
+		}
+	}
+	return lines
+}
+
+// addLine adds a line to a fix's lines, keeping them sorted and unique.
+func addLine(lines []int, line int) []int {
+	i, found := slices.BinarySearch(lines, line)
+	if found || line < 1 {
+		return lines
+	}
+	return slices.Insert(lines, i, line)
+}
+
 // applyRefInfo applies reference definition info to a fix.
-func (*Fixer) applyRefInfo(fix *Fix, pLinks []parser.Link) {
+func (f *Fixer) applyRefInfo(fix *Fix, pLinks []parser.Link) {
 	// First pass: find link type and ref name for this file
 	for _, pl := range pLinks {
 		if pl.FilePath != fix.FilePath {
@@ -159,10 +339,58 @@ func (*Fixer) applyRefInfo(fix *Fix, pLinks []parser.Link) {
 		fix.IsRefDef = true
 		fix.RefName = pl.RefName
 		fix.RefUsages = countRefUsages(pLinks, fix.FilePath, pl.RefName)
+		fix.Warnings = f.refDefWarnings(fix, pLinks)
 		break
 	}
 }
 
+// refDefWarnings checks what else rewriting a reference definition changes.
+// The URL is replaced wherever it appears in the file, so links written
+// inline and other definitions with the same URL change too. Usages of the
+// reference that the parser resolved to another URL would be left behind.
+func (f *Fixer) refDefWarnings(fix *Fix, pLinks []parser.Link) []string {
+	var warnings []string
+
+	var inline []int
+	otherRefs := map[string]int{}
+	for _, pl := range pLinks {
+		switch {
+		case pl.FilePath != fix.FilePath || pl.RefName == fix.RefName:
+		case pl.Type == parser.LinkTypeReference && pl.RefDefLine > 0:
+			otherRefs[pl.RefName] = pl.RefDefLine
+		default:
+			inline = append(inline, pl.Line)
+		}
+	}
+	if len(inline) > 0 {
+		slices.Sort(inline)
+		lines := make([]string, 0, len(inline))
+		for _, line := range slices.Compact(inline) {
+			lines = append(lines, strconv.Itoa(line))
+		}
+		warnings = append(warnings, fmt.Sprintf("also linked inline on line(s) %s, which change too",
+			strings.Join(lines, ", ")))
+	}
+	for _, name := range slices.Sorted(maps.Keys(otherRefs)) {
+		warnings = append(warnings, fmt.Sprintf("[%s] on line %d has the same URL and changes too",
+			name, otherRefs[name]))
+	}
+
+	elsewhere := 0
+	for _, pl := range f.parserLinks {
+		if pl.FilePath == fix.FilePath && pl.RefName == fix.RefName &&
+			pl.Type == parser.LinkTypeReference && pl.URL != fix.OldURL {
+			elsewhere++
+		}
+	}
+	if elsewhere > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d usage(s) of [%s] point to another URL and aren't changed",
+			elsewhere, fix.RefName))
+	}
+
+	return warnings
+}
+
 // countRefUsages counts how many times a reference is used in a file.
 func countRefUsages(links []parser.Link, filePath, refName string) int {
 	count := 0
@@ -218,8 +446,12 @@ func (*Fixer) Preview(changes []FileChanges) string {
 
 	var b strings.Builder
 	totalFixes := 0
+	var risks [len(riskNames)]int
 	for _, fc := range changes {
 		totalFixes += fc.TotalFixes
+		for _, fix := range fc.Fixes {
+			risks[fix.Risk]++
+		}
 	}
 
 	b.WriteString(fmt.Sprintf("Found %d fixable redirect(s) across %d file(s):\n\n",
@@ -237,22 +469,95 @@ func (*Fixer) Preview(changes []FileChanges) string {
 				if fix.RefUsages > 0 {
 					b.WriteString(fmt.Sprintf(" (used %d time(s))", fix.RefUsages))
 				}
+				b.WriteString(fix.Tag())
 				b.WriteString("\n")
+				for _, w := range fix.Warnings {
+					b.WriteString(fmt.Sprintf("          warning: %s\n", w))
+				}
+				b.WriteString(fmt.Sprintf("          %s\n", fix.RiskSummary()))
 			} else {
 				b.WriteString(fmt.Sprintf("%s%s\n", lineInfo, truncateURL(fix.OldURL, 60)))
 				b.WriteString(fmt.Sprintf("          -> %s", truncateURL(fix.NewURL, 60)))
 				if fix.Occurrences > 1 {
 					b.WriteString(fmt.Sprintf(" (%d occurrence(s))", fix.Occurrences))
 				}
+				b.WriteString(fix.Tag())
 				b.WriteString("\n")
+				b.WriteString(fmt.Sprintf("          %s\n", fix.RiskSummary()))
 			}
 		}
 		b.WriteString("\n")
 	}
 
+	b.WriteString(fmt.Sprintf("Risk: %d low, %d medium, %d high\n\n",
+		risks[RiskLow], risks[RiskMedium], risks[RiskHigh]))
+
 	return b.String()
 }
 
+// RiskSummary describes a fix's risk, such as "risk: medium (path changed)".
+func (fix Fix) RiskSummary() string {
+	if len(fix.RiskReasons) == 0 {
+		return "risk: " + fix.Risk.String()
+	}
+	return fmt.Sprintf("risk: %s (%s)", fix.Risk, strings.Join(fix.RiskReasons, "; "))
+}
+
+// LineDiff returns the line a fix changes, before and after the change,
+// with surrounding whitespace trimmed. For a fix with several occurrences,
+// it's the first one's line.
+func LineDiff(fix Fix) (before, after string, err error) {
+	result, original, modified := rewriteFile(FileChanges{FilePath: fix.FilePath, Fixes: []Fix{fix}})
+	if result.Error != nil {
+		return "", "", result.Error
+	}
+
+	originalLines := strings.Split(original, "\n")
+	modifiedLines := strings.Split(modified, "\n")
+	if fix.Line < 1 || fix.Line > len(originalLines) || len(modifiedLines) != len(originalLines) {
+		return "", "", fmt.Errorf("%s has no line %d", fix.FilePath, fix.Line)
+	}
+	return strings.TrimSpace(originalLines[fix.Line-1]), strings.TrimSpace(modifiedLines[fix.Line-1]), nil
+}
+
+// Tag returns the preview tag of a fix that doesn't come from a checked
+// redirect, such as " (rewrite rule)", or "" for redirects.
+func (fix Fix) Tag() string {
+	switch {
+	case fix.Rewrite:
+		return " (rewrite rule)"
+	case fix.Archive:
+		return " (archived snapshot)"
+	case fix.Upgrade:
+		return " (https upgrade)"
+	case fix.Normalize:
+		return " (normalized)"
+	case fix.Anchor:
+		return " (closest anchor)"
+	default:
+		return ""
+	}
+}
+
+// FilterFixes returns the changes with only the fixes keep accepts,
+// dropping files left without fixes.
+func FilterFixes(changes []FileChanges, keep func(Fix) bool) []FileChanges {
+	filtered := make([]FileChanges, 0, len(changes))
+	for _, fc := range changes {
+		kept := FileChanges{FilePath: fc.FilePath}
+		for _, fix := range fc.Fixes {
+			if keep(fix) {
+				kept.Fixes = append(kept.Fixes, fix)
+				kept.TotalFixes += fix.Occurrences
+			}
+		}
+		if len(kept.Fixes) > 0 {
+			filtered = append(filtered, kept)
+		}
+	}
+	return filtered
+}
+
 // truncateURL shortens a URL for display.
 func truncateURL(url string, maxLen int) string {
 	if len(url) <= maxLen {
@@ -262,8 +567,38 @@ func truncateURL(url string, maxLen int) string {
 }
 
 // ApplyToFile applies all fixes to a single file.
-func (*Fixer) ApplyToFile(fc FileChanges) (*FixResult, error) {
-	result := &FixResult{
+func (f *Fixer) ApplyToFile(fc FileChanges) (*FixResult, error) {
+	result, original, modified := rewriteFile(fc)
+	if result.Error != nil {
+		return result, result.Error
+	}
+
+	// Only write if content changed
+	if modified == original {
+		return result, nil
+	}
+
+	if f.backup != nil {
+		if err := f.backup.record(fc.FilePath, original, modified, result.ChangedURLs); err != nil {
+			result.Error = err
+			return result, err
+		}
+	}
+
+	// Write modified content back to file
+	err := os.WriteFile(fc.FilePath, []byte(modified), 0o600)
+	if err != nil {
+		result.Error = fmt.Errorf("writing file: %w", err)
+		return result, result.Error
+	}
+
+	return result, nil
+}
+
+// rewriteFile reads a file and applies its fixes in memory.
+// It returns the result along with the original and modified content.
+func rewriteFile(fc FileChanges) (result *FixResult, original, modified string) {
+	result = &FixResult{
 		FilePath:    fc.FilePath,
 		ChangedURLs: []URLChange{},
 	}
@@ -272,57 +607,47 @@ func (*Fixer) ApplyToFile(fc FileChanges) (*FixResult, error) {
 	content, err := os.ReadFile(fc.FilePath)
 	if err != nil {
 		result.Error = fmt.Errorf("reading file: %w", err)
-		return result, result.Error
+		return result, "", ""
 	}
 
-	originalContent := string(content)
-	modifiedContent := originalContent
+	original = string(content)
+	modified = original
 
-	// Apply each fix
-	// We need to be careful about overlapping replacements
-	// Process fixes in reverse line order to avoid offset issues...
-	// Actually, since we're doing string replacement on full URLs, order shouldn't matter
-	// as long as URLs are unique
+	htmlFile := isHTMLFile(fc.FilePath)
 
-	for _, fix := range fc.Fixes {
-		// Count occurrences before replacement
-		countBefore := strings.Count(modifiedContent, fix.OldURL)
+	// Structured files are fixed only where the parser found each URL, and
+	// other text files wherever the whole URL appears
+	format := structuredFormat(fc.FilePath)
+	var counts []int
+	switch {
+	case format != "":
+		modified, counts = replaceAtOffsets(modified, format, fc.Fixes)
+	case !htmlFile:
+		modified, counts = replaceTextURLs(modified, fc.Fixes)
+	}
 
-		if countBefore == 0 {
+	for i, fix := range fc.Fixes {
+		var replaced int
+		if htmlFile {
+			// HTML files only rewrite URL attributes, never raw text
+			modified, replaced = replaceHTMLURL(modified, fix.OldURL, fix.NewURL, fix.Lines)
+		} else {
+			replaced = counts[i]
+		}
+		if replaced == 0 {
 			result.Skipped++
 			continue
 		}
 
-		// Replace all occurrences of the old URL with the new URL
-		modifiedContent = strings.ReplaceAll(modifiedContent, fix.OldURL, fix.NewURL)
-
-		// Verify replacement worked
-		countAfter := strings.Count(modifiedContent, fix.OldURL)
-		replaced := countBefore - countAfter
-
-		if replaced > 0 {
-			result.Applied += replaced
-			result.ChangedURLs = append(result.ChangedURLs, URLChange{
-				Line:   fix.Line,
-				OldURL: fix.OldURL,
-				NewURL: fix.NewURL,
-			})
-		}
-	}
-
-	// Only write if content changed
-	if modifiedContent == originalContent {
-		return result, nil
-	}
-
-	// Write modified content back to file
-	err = os.WriteFile(fc.FilePath, []byte(modifiedContent), 0o600)
-	if err != nil {
-		result.Error = fmt.Errorf("writing file: %w", err)
-		return result, result.Error
+		result.Applied += replaced
+		result.ChangedURLs = append(result.ChangedURLs, URLChange{
+			Line:   fix.Line,
+			OldURL: fix.OldURL,
+			NewURL: fix.NewURL,
+		})
 	}
 
-	return result, nil
+	return result, original, modified
 }
 
 // ApplyAll applies fixes to all files and returns results.
@@ -411,3 +736,39 @@ func DetailedSummary(results []FixResult) string {
 
 	return b.String()
 }
+
+// ModifiedFiles returns the paths of the files that fixes were applied to.
+func ModifiedFiles(results []FixResult) []string {
+	var paths []string
+	for _, r := range results {
+		if r.Applied > 0 {
+			paths = append(paths, r.FilePath)
+		}
+	}
+	return paths
+}
+
+// CommitMessage returns a commit message for the applied fixes, with a
+// subject line counting them and a body listing every URL change by file.
+// It returns "" if nothing was applied.
+func CommitMessage(results []FixResult) string {
+	paths := ModifiedFiles(results)
+	if len(paths) == 0 {
+		return ""
+	}
+
+	total := 0
+	var body strings.Builder
+	for _, r := range results {
+		if r.Applied == 0 {
+			continue
+		}
+		total += r.Applied
+		fmt.Fprintf(&body, "\n%s:\n", r.FilePath)
+		for _, change := range r.ChangedURLs {
+			fmt.Fprintf(&body, "- %s -> %s (line %d)\n", change.OldURL, change.NewURL, change.Line)
+		}
+	}
+
+	return fmt.Sprintf("Fix %d link(s) in %d file(s)\n\nUpdated by gone fix.\n", total, len(paths)) + body.String()
+}
@@ -212,6 +212,30 @@ func TestFixer_FindFixes_DuplicateURLsInSameFile(t *testing.T) {
 	assert.Equal(t, 2, changes[0].TotalFixes)
 }
 
+func TestFixer_FindFixes_DuplicateInOtherFile(t *testing.T) {
+	t.Parallel()
+
+	primary := checker.Result{
+		Link:        checker.Link{URL: "https://old.com", FilePath: "a.md", Line: 1},
+		Status:      checker.StatusRedirect,
+		FinalURL:    "https://new.com",
+		FinalStatus: 200,
+	}
+	results := []checker.Result{
+		primary,
+		{
+			Link:        checker.Link{URL: "https://old.com", FilePath: "b.md", Line: 4},
+			Status:      checker.StatusDuplicate,
+			DuplicateOf: &primary,
+		},
+	}
+
+	changes := New().FindFixes(results)
+	require.Len(t, changes, 2)
+	assert.Equal(t, "b.md", changes[1].FilePath)
+	assert.Equal(t, "https://new.com", changes[1].Fixes[0].NewURL)
+}
+
 func TestFixer_FindFixes_SortedByLine(t *testing.T) {
 	t.Parallel()
 
@@ -281,6 +305,78 @@ func TestFixer_FindFixes_WithParserLinks_Reference(t *testing.T) {
 	assert.Equal(t, 100, fix.Line) // Should use RefDefLine
 }
 
+func TestFixer_FindFixes_RefDefWarnings(t *testing.T) {
+	t.Parallel()
+
+	ref := func(line int, name string, defLine int) parser.Link {
+		return parser.Link{
+			URL: "https://old.com", FilePath: "test.md", Line: line,
+			Type: parser.LinkTypeReference, RefName: name, RefDefLine: defLine,
+		}
+	}
+	parserLinks := []parser.Link{
+		ref(1, "docs", 20),
+		ref(2, "docs", 20),
+		{URL: "https://old.com", FilePath: "test.md", Line: 12, Type: parser.LinkTypeInline},
+		{URL: "https://old.com", FilePath: "test.md", Line: 9, Type: parser.LinkTypeInline},
+		ref(5, "guide", 21),
+		{URL: "https://old.com", FilePath: "other.md", Line: 1, Type: parser.LinkTypeInline},
+		// Resolved by the parser to another definition of the label
+		{URL: "https://else.com", FilePath: "test.md", Line: 7, Type: parser.LinkTypeReference, RefName: "docs"},
+	}
+
+	results := []checker.Result{{
+		Link:        checker.Link{URL: "https://old.com", FilePath: "test.md", Line: 1},
+		Status:      checker.StatusRedirect,
+		FinalURL:    "https://new.com",
+		FinalStatus: 200,
+	}}
+
+	f := New()
+	f.SetParserLinks(parserLinks)
+	changes := f.FindFixes(results)
+	require.Len(t, changes, 1)
+	fix := changes[0].Fixes[0]
+
+	assert.True(t, fix.IsRefDef)
+	assert.Equal(t, "docs", fix.RefName)
+	assert.Equal(t, []string{
+		"also linked inline on line(s) 9, 12, which change too",
+		"[guide] on line 21 has the same URL and changes too",
+		"1 usage(s) of [docs] point to another URL and aren't changed",
+	}, fix.Warnings)
+	assert.Contains(t, f.Preview(changes), "warning: also linked inline on line(s) 9, 12")
+}
+
+func TestFixer_FindFixes_RefDefWithoutWarnings(t *testing.T) {
+	t.Parallel()
+
+	usage := func(line int) parser.Link {
+		return parser.Link{
+			URL: "https://old.com", FilePath: "test.md", Line: line,
+			Type: parser.LinkTypeReference, RefName: "docs", RefDefLine: 5,
+		}
+	}
+	parserLinks := []parser.Link{
+		usage(1),
+		usage(3),
+		{URL: "https://old.com/path", FilePath: "test.md", Line: 4, Type: parser.LinkTypeInline},
+	}
+	results := []checker.Result{{
+		Link:        checker.Link{URL: "https://old.com", FilePath: "test.md", Line: 1},
+		Status:      checker.StatusRedirect,
+		FinalURL:    "https://new.com",
+		FinalStatus: 200,
+	}}
+
+	f := New()
+	f.SetParserLinks(parserLinks)
+	changes := f.FindFixes(results)
+	require.Len(t, changes, 1)
+	assert.Equal(t, 2, changes[0].Fixes[0].RefUsages)
+	assert.Empty(t, changes[0].Fixes[0].Warnings)
+}
+
 // =============================================================================
 // Preview Tests
 // =============================================================================
@@ -689,6 +785,31 @@ func TestDetailedSummary_WithChanges(t *testing.T) {
 // Helper Function Tests
 // =============================================================================
 
+func TestLineDiff(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	mdPath := filepath.Join(tmpDir, "test.md")
+	htmlPath := filepath.Join(tmpDir, "test.html")
+	require.NoError(t, os.WriteFile(mdPath, []byte("# Title\n\n  See [a](https://old.com) and https://old.com.\n"), 0o600))
+	require.NoError(t, os.WriteFile(htmlPath, []byte(`<p>https://old.com <a href="https://old.com">a</a></p>`), 0o600))
+
+	before, after, err := LineDiff(Fix{FilePath: mdPath, Line: 3, OldURL: "https://old.com", NewURL: "https://new.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "See [a](https://old.com) and https://old.com.", before)
+	assert.Equal(t, "See [a](https://new.com) and https://new.com.", after)
+
+	_, after, err = LineDiff(Fix{FilePath: htmlPath, Line: 1, OldURL: "https://old.com", NewURL: "https://new.com"})
+	require.NoError(t, err)
+	assert.Equal(t, `<p>https://old.com <a href="https://new.com">a</a></p>`, after)
+
+	_, _, err = LineDiff(Fix{FilePath: mdPath, Line: 9})
+	require.ErrorContains(t, err, "has no line 9")
+
+	_, _, err = LineDiff(Fix{FilePath: filepath.Join(tmpDir, "missing.md"), Line: 1})
+	require.Error(t, err)
+}
+
 func TestTruncateURL(t *testing.T) {
 	t.Parallel()
 
@@ -1004,3 +1125,330 @@ func TestFixer_Preview_MultipleFixes(t *testing.T) {
 	assert.Contains(t, preview, "a.md (2 fix(es))")
 	assert.Contains(t, preview, "b.md (1 fix(es))")
 }
+
+// =============================================================================
+// HTML Tests
+// =============================================================================
+
+func TestFixer_ApplyToFile_HTML(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "index.html")
+
+	content := `<!-- https://old.com -->
+<p>Visit https://old.com or <a class="x" href="https://old.com">old</a>.</p>
+<img src='https://old.com' srcset="https://old.com 1x, https://keep.com 2x">
+`
+	require.NoError(t, os.WriteFile(filePath, []byte(content), 0o600))
+
+	changes := FileChanges{
+		FilePath: filePath,
+		Fixes: []Fix{
+			{FilePath: filePath, Line: 2, OldURL: "https://old.com", NewURL: "https://new.com"},
+			{FilePath: filePath, Line: 3, OldURL: "https://missing.com", NewURL: "https://x.com"},
+		},
+	}
+
+	result, err := New().ApplyToFile(changes)
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Applied)
+	assert.Equal(t, 1, result.Skipped)
+
+	newContent, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, `<!-- https://old.com -->
+<p>Visit https://old.com or <a class="x" href="https://new.com">old</a>.</p>
+<img src='https://new.com' srcset="https://new.com 1x, https://keep.com 2x">
+`, string(newContent))
+}
+
+func TestReplaceHTMLURL(t *testing.T) {
+	t.Parallel()
+
+	t.Run("PreservesUntouchedContent", func(t *testing.T) {
+		t.Parallel()
+		content := "<!DOCTYPE html>\n<html><head><script>var u = 'https://a.com';</script></head>" +
+			"<body><A HREF=https://b.com>b</A></body></html>"
+		out, n := replaceHTMLURL(content, "https://c.com", "https://d.com", nil)
+		assert.Equal(t, 0, n)
+		assert.Equal(t, content, out)
+	})
+
+	t.Run("UnquotedUppercase", func(t *testing.T) {
+		t.Parallel()
+		out, n := replaceHTMLURL(`<A HREF=https://b.com>b</A>`, "https://b.com", "https://c.com", nil)
+		assert.Equal(t, 1, n)
+		assert.Equal(t, `<A HREF=https://c.com>b</A>`, out)
+	})
+
+	t.Run("EscapedEntities", func(t *testing.T) {
+		t.Parallel()
+		content := `<a href="https://a.com/?x=1&amp;y=2">a</a>`
+		out, n := replaceHTMLURL(content, "https://a.com/?x=1&y=2", "https://b.com/?x=1&y=2", nil)
+		assert.Equal(t, 1, n)
+		assert.Equal(t, `<a href="https://b.com/?x=1&amp;y=2">a</a>`, out)
+	})
+
+	t.Run("OnlyOnLines", func(t *testing.T) {
+		t.Parallel()
+		content := "<a href=\"https://a.com\">a</a>\n<img\n  src=\"https://a.com\">"
+		out, n := replaceHTMLURL(content, "https://a.com", "https://b.com", []int{3})
+		assert.Equal(t, 1, n)
+		assert.Equal(t, "<a href=\"https://a.com\">a</a>\n<img\n  src=\"https://b.com\">", out)
+	})
+
+	t.Run("PrefixDoesNotMatch", func(t *testing.T) {
+		t.Parallel()
+		content := `<a href="https://a.com/page">a</a>`
+		out, n := replaceHTMLURL(content, "https://a.com", "https://b.com", nil)
+		assert.Equal(t, 0, n)
+		assert.Equal(t, content, out)
+	})
+}
+
+func TestFixer_ApplyToFile_OverlappingURLs(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "test.md")
+	content := `See [docs][docs], [the API](https://a.com/api), and [home](https://a.com).
+
+[docs]: https://a.com/docs
+`
+	require.NoError(t, os.WriteFile(filePath, []byte(content), 0o600))
+
+	changes := FileChanges{
+		FilePath: filePath,
+		Fixes: []Fix{
+			{FilePath: filePath, Line: 1, OldURL: "https://a.com", NewURL: "https://b.com"},
+			{FilePath: filePath, Line: 3, OldURL: "https://a.com/docs", NewURL: "https://a.com/docs/"},
+		},
+	}
+
+	result, err := New().ApplyToFile(changes)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Applied)
+	assert.Equal(t, 0, result.Skipped)
+	assert.Len(t, result.ChangedURLs, 2)
+
+	got, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, `See [docs][docs], [the API](https://a.com/api), and [home](https://b.com).
+
+[docs]: https://a.com/docs/
+`, string(got))
+}
+
+func TestFixer_ApplyToFile_LineScoped(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "test.md")
+	content := "See [docs](https://old.com) and the [guide][guide].\n" +
+		"\n" +
+		"```sh\n" +
+		"curl https://old.com\n" +
+		"```\n" +
+		"\n" +
+		"[guide]: https://old.com\n"
+	require.NoError(t, os.WriteFile(filePath, []byte(content), 0o600))
+
+	parserLinks := []parser.Link{
+		{URL: "https://old.com", FilePath: filePath, Line: 1, Type: parser.LinkTypeInline},
+		{
+			URL: "https://old.com", FilePath: filePath, Line: 1,
+			Type: parser.LinkTypeReference, RefName: "guide", RefDefLine: 7,
+		},
+	}
+	results := []checker.Result{{
+		Link:        checker.Link{URL: "https://old.com", FilePath: filePath, Line: 1, Type: "inline"},
+		Status:      checker.StatusRedirect,
+		FinalURL:    "https://new.com",
+		FinalStatus: 200,
+	}}
+
+	// By default the URL is replaced wherever it appears
+	f := New()
+	f.SetParserLinks(parserLinks)
+	changes := f.FindFixes(results)
+	require.Len(t, changes, 1)
+	assert.Empty(t, changes[0].Fixes[0].Lines)
+	diff, err := f.Diff(changes)
+	require.NoError(t, err)
+	assert.Contains(t, diff, "+curl https://new.com")
+
+	f.SetLineScoped(true)
+	changes = f.FindFixes(results)
+	require.Len(t, changes, 1)
+	assert.Equal(t, []int{1, 7}, changes[0].Fixes[0].Lines)
+
+	result, err := f.ApplyToFile(changes[0])
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Applied)
+
+	got, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, "See [docs](https://new.com) and the [guide][guide].\n"+
+		"\n"+
+		"```sh\n"+
+		"curl https://old.com\n"+
+		"```\n"+
+		"\n"+
+		"[guide]: https://new.com\n", string(got))
+}
+
+func TestFixer_FindFixes_LineScopedUnknownLine(t *testing.T) {
+	t.Parallel()
+
+	results := []checker.Result{{
+		Link:        checker.Link{URL: "https://old.com", FilePath: "test.md", Type: "inline"},
+		Status:      checker.StatusRedirect,
+		FinalURL:    "https://new.com",
+		FinalStatus: 200,
+	}}
+
+	// Without a line, a line-scoped fix would replace the URL everywhere
+	f := New()
+	f.SetLineScoped(true)
+	assert.Empty(t, f.FindFixes(results))
+	assert.Equal(t, []checker.Link{results[0].Link}, f.Unscoped())
+
+	// A parser link in the same file gives the line
+	f.SetParserLinks([]parser.Link{
+		{URL: "https://old.com", FilePath: "test.md", Line: 3, Type: parser.LinkTypeInline},
+	})
+	changes := f.FindFixes(results)
+	require.Len(t, changes, 1)
+	assert.Equal(t, []int{3}, changes[0].Fixes[0].Lines)
+	assert.Empty(t, f.Unscoped())
+
+	// Without line scoping, it's fixed everywhere
+	f = New()
+	assert.Len(t, f.FindFixes(results), 1)
+	assert.Empty(t, f.Unscoped())
+}
+
+// =============================================================================
+// ApplyAtomic Tests
+// =============================================================================
+
+func TestFixer_ApplyAtomic_Success(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	file1 := filepath.Join(tmpDir, "a.md")
+	file2 := filepath.Join(tmpDir, "b.md")
+
+	require.NoError(t, os.WriteFile(file1, []byte("[ref]: https://old.com"), 0o644))
+	require.NoError(t, os.WriteFile(file2, []byte("[ref]: https://old.com"), 0o600))
+
+	changes := []FileChanges{
+		{FilePath: file1, TotalFixes: 1, Fixes: []Fix{{FilePath: file1, OldURL: "https://old.com", NewURL: "https://new.com"}}},
+		{FilePath: file2, TotalFixes: 1, Fixes: []Fix{{FilePath: file2, OldURL: "https://old.com", NewURL: "https://new.com"}}},
+	}
+
+	results, err := New().ApplyAtomic(changes)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, 1, results[0].Applied)
+	assert.Equal(t, 1, results[1].Applied)
+
+	for _, path := range []string{file1, file2} {
+		content, readErr := os.ReadFile(path)
+		require.NoError(t, readErr)
+		assert.Equal(t, "[ref]: https://new.com", string(content))
+	}
+
+	// File modes are preserved and no temp files are left behind
+	info, err := os.Stat(file1)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o644), info.Mode().Perm())
+
+	entries, err := os.ReadDir(tmpDir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func TestFixer_ApplyAtomic_RollsBackOnError(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	file1 := filepath.Join(tmpDir, "a.md")
+	missing := filepath.Join(tmpDir, "missing.md")
+
+	require.NoError(t, os.WriteFile(file1, []byte("Link: https://old.com"), 0o600))
+
+	changes := []FileChanges{
+		{FilePath: file1, TotalFixes: 1, Fixes: []Fix{{FilePath: file1, OldURL: "https://old.com", NewURL: "https://new.com"}}},
+		{FilePath: missing, TotalFixes: 1, Fixes: []Fix{{FilePath: missing, OldURL: "https://old.com", NewURL: "https://new.com"}}},
+	}
+
+	results, err := New().ApplyAtomic(changes)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing.md")
+	require.Len(t, results, 2)
+	assert.Equal(t, 0, results[0].Applied)
+	assert.Equal(t, 1, results[0].Skipped)
+	require.Error(t, results[1].Error)
+
+	content, err := os.ReadFile(file1)
+	require.NoError(t, err)
+	assert.Equal(t, "Link: https://old.com", string(content))
+
+	entries, err := os.ReadDir(tmpDir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestRestoreOriginals(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "a.md")
+	require.NoError(t, os.WriteFile(path, []byte("modified"), 0o600))
+
+	err := restoreOriginals([]stagedFile{{path: path, original: "original", mode: 0o600}})
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "original", string(content))
+}
+
+func TestCommitMessage(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, CommitMessage([]FixResult{{FilePath: "a.md", Skipped: 1}}))
+
+	results := []FixResult{
+		{
+			FilePath: "README.md",
+			Applied:  2,
+			ChangedURLs: []URLChange{
+				{OldURL: "http://a.com", NewURL: "https://a.com", Line: 3},
+				{OldURL: "https://b.com/old", NewURL: "https://b.com/new", Line: 7},
+			},
+		},
+		{FilePath: "skipped.md", Skipped: 1},
+		{
+			FilePath:    "docs/guide.md",
+			Applied:     1,
+			ChangedURLs: []URLChange{{OldURL: "https://c.com", NewURL: "https://c.com/", Line: 1}},
+		},
+	}
+
+	want := `Fix 3 link(s) in 2 file(s)
+
+Updated by gone fix.
+
+README.md:
+- http://a.com -> https://a.com (line 3)
+- https://b.com/old -> https://b.com/new (line 7)
+
+docs/guide.md:
+- https://c.com -> https://c.com/ (line 1)
+`
+	assert.Equal(t, want, CommitMessage(results))
+	assert.Equal(t, []string{"README.md", "docs/guide.md"}, ModifiedFiles(results))
+}
@@ -0,0 +1,120 @@
+package fixer
+
+import (
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/net/html"
+
+	htmlparser "github.com/leonardomso/gone/internal/parser/html"
+)
+
+// isHTMLFile reports whether a file should use attribute-aware replacement.
+func isHTMLFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".html", ".htm":
+		return true
+	default:
+		return false
+	}
+}
+
+// replaceHTMLURL rewrites URL attributes (href, src, srcset, ...) whose value is
+// oldURL, leaving text, comments, scripts, and all other bytes untouched.
+// If lines isn't empty, only attributes on those lines are rewritten.
+// Returns the new content and the number of replacements.
+func replaceHTMLURL(content, oldURL, newURL string, lines []int) (string, int) {
+	var b strings.Builder
+	b.Grow(len(content))
+
+	lineOf := lineFinder(content)
+	replaced := 0
+	offset := 0
+	z := html.NewTokenizer(strings.NewReader(content))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			if err := z.Err(); !errors.Is(err, io.EOF) {
+				return content, 0 // Never risk mangling a file we can't tokenize
+			}
+			return b.String(), replaced
+		}
+
+		raw := string(z.Raw())
+		tagStart := offset
+		offset += len(raw)
+		if tt == html.StartTagToken || tt == html.SelfClosingTagToken {
+			var n int
+			raw, n = replaceInTag(raw, oldURL, newURL, func(pos int) bool {
+				return onLines(lines, lineOf(tagStart+pos))
+			})
+			replaced += n
+		}
+		b.WriteString(raw)
+	}
+}
+
+// replaceInTag rewrites matching URL attribute values within a single raw start tag,
+// if keep accepts their offset in raw.
+func replaceInTag(raw, oldURL, newURL string, keep func(pos int) bool) (string, int) {
+	spans := htmlparser.ScanAttrs(raw)
+
+	replaced := 0
+	// Walk backwards so earlier span offsets stay valid after rewriting
+	for i := len(spans) - 1; i >= 0; i-- {
+		span := spans[i]
+		if !htmlparser.IsURLAttribute(span.Key) || !keep(span.Start) {
+			continue
+		}
+
+		rawValue := raw[span.Start:span.End]
+		value, n := rewriteAttrValue(span, html.UnescapeString(rawValue), oldURL, newURL)
+		if n == 0 {
+			continue
+		}
+
+		raw = raw[:span.Start] + escapeAttrValue(value, rawValue, span.Quote) + raw[span.End:]
+		replaced += n
+	}
+
+	return raw, replaced
+}
+
+// rewriteAttrValue replaces oldURL in an unescaped attribute value.
+// Plain URL attributes must match exactly; srcset candidates are matched one by one.
+func rewriteAttrValue(span htmlparser.AttrSpan, value, oldURL, newURL string) (string, int) {
+	if span.Key != "srcset" {
+		if strings.TrimSpace(value) != oldURL {
+			return value, 0
+		}
+		return strings.Replace(value, oldURL, newURL, 1), 1
+	}
+
+	candidates := strings.Split(value, ",")
+	replaced := 0
+	for i, candidate := range candidates {
+		fields := strings.Fields(candidate)
+		if len(fields) > 0 && fields[0] == oldURL {
+			candidates[i] = strings.Replace(candidate, oldURL, newURL, 1)
+			replaced++
+		}
+	}
+	return strings.Join(candidates, ","), replaced
+}
+
+// escapeAttrValue escapes a rewritten value so it stays valid inside its quotes.
+// Ampersands are only escaped if the original value used entities.
+func escapeAttrValue(value, originalRaw string, quote byte) string {
+	if strings.Contains(originalRaw, "&") && originalRaw != html.UnescapeString(originalRaw) {
+		value = strings.ReplaceAll(value, "&", "&amp;")
+	}
+	switch quote {
+	case '"':
+		value = strings.ReplaceAll(value, `"`, "&quot;")
+	case '\'':
+		value = strings.ReplaceAll(value, "'", "&#39;")
+	}
+	return value
+}
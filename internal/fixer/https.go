@@ -0,0 +1,143 @@
+package fixer
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/leonardomso/gone/internal/checker"
+)
+
+// maxCompareBody caps how much of each page is read to compare them.
+const maxCompareBody = 1 << 20
+
+// titleRegex matches an HTML page's title.
+var titleRegex = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+
+// HTTPSUpgrader finds http:// links that also work over https://.
+type HTTPSUpgrader struct {
+	Client *http.Client
+}
+
+// page is what's compared between the http:// and https:// versions of a URL.
+type page struct {
+	status    int
+	mediaType string
+	sum       [sha256.Size]byte
+	title     string
+}
+
+// Upgrade returns the https:// version of an http:// URL if it returns 200
+// with the same content: the same media type and either the same body or,
+// for pages with dynamic parts, the same HTML title. Redirects are followed
+// on both sides. Only fetching the http:// version can fail; an https://
+// version that can't be fetched just isn't an upgrade.
+func (u *HTTPSUpgrader) Upgrade(ctx context.Context, rawURL string) (string, bool, error) {
+	rest, ok := strings.CutPrefix(rawURL, "http://")
+	if !ok {
+		return "", false, nil
+	}
+	secureURL := "https://" + rest
+
+	insecure, err := u.fetch(ctx, rawURL)
+	if err != nil {
+		return "", false, fmt.Errorf("fetching %s: %w", rawURL, err)
+	}
+	secure, err := u.fetch(ctx, secureURL)
+	if err != nil || !equivalent(insecure, secure) {
+		return "", false, nil
+	}
+	return secureURL, true, nil
+}
+
+// Upgrades finds the https:// versions of several URLs and returns those
+// that work, keyed by URL, along with the first error.
+func (u *HTTPSUpgrader) Upgrades(ctx context.Context, urls []string) (map[string]string, error) {
+	return lookupAll(ctx, urls, u.Upgrade)
+}
+
+// fetch gets a page, reading at most maxCompareBody bytes of it.
+func (u *HTTPSUpgrader) fetch(ctx context.Context, rawURL string) (page, error) {
+	return fetchPage(ctx, u.Client, rawURL)
+}
+
+// fetchPage gets a page with client, or http.DefaultClient if it's nil,
+// reading at most maxCompareBody bytes of it.
+func fetchPage(ctx context.Context, client *http.Client, rawURL string) (page, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, http.NoBody)
+	if err != nil {
+		return page{}, err
+	}
+	req.Header.Set("User-Agent", checker.DefaultUserAgent)
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return page{}, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxCompareBody))
+	if err != nil {
+		return page{}, err
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	p := page{status: resp.StatusCode, mediaType: mediaType, sum: sha256.Sum256(body)}
+	if m := titleRegex.FindSubmatch(body); m != nil {
+		p.title = strings.Join(strings.Fields(html.UnescapeString(string(bytes.TrimSpace(m[1])))), " ")
+	}
+	return p, nil
+}
+
+// equivalent reports whether two versions of a page have the same content.
+func equivalent(a, b page) bool {
+	if a.status != http.StatusOK || b.status != http.StatusOK || a.mediaType != b.mediaType {
+		return false
+	}
+	return a.sum == b.sum || (a.title != "" && a.title == b.title)
+}
+
+// SetUpgrades sets the https:// versions, keyed by URL, that replace http://
+// links (see HTTPSUpgrader.Upgrades).
+func (f *Fixer) SetUpgrades(upgrades map[string]string) {
+	f.upgrades = upgrades
+}
+
+// upgradeFor returns the https:// version replacing a link, if there is one.
+func (f *Fixer) upgradeFor(r checker.Result) (string, bool) {
+	if r.Resolved().IsDead() {
+		return "", false
+	}
+	upgrade, ok := f.upgrades[r.Link.URL]
+	return upgrade, ok
+}
+
+// HTTPURLs returns the unique http:// URLs whose https:// version may
+// replace them, in the order they were found: links that were checked and
+// work, unless a redirect fix already replaces them.
+func HTTPURLs(results []checker.Result) []string {
+	seen := map[string]bool{}
+	var urls []string
+	for _, r := range results {
+		url := r.Link.URL
+		resolved := r.Resolved()
+		if !strings.HasPrefix(url, "http://") || seen[url] || resolved.IsDead() ||
+			resolved.Status == checker.StatusSkipped || isFixableRedirect(resolved) {
+			continue
+		}
+		seen[url] = true
+		urls = append(urls, url)
+	}
+	return urls
+}
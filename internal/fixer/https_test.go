@@ -0,0 +1,183 @@
+package fixer
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/leonardomso/gone/internal/checker"
+)
+
+// schemeTransport serves requests from a handler per URL scheme, failing
+// for schemes without one.
+type schemeTransport map[string]http.HandlerFunc
+
+func (t schemeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	handler, ok := t[req.URL.Scheme]
+	if !ok {
+		return nil, errors.New("connection refused")
+	}
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+	resp := rec.Result()
+	resp.Request = req
+	return resp, nil
+}
+
+// pageHandler serves an HTML page with a title and a varying body.
+func pageHandler(title, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/missing":
+			http.NotFound(w, r)
+		case "/text":
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte(body))
+		default:
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write([]byte("<html><head><title>" + title + "</title></head><body>" + body + "</body></html>"))
+		}
+	}
+}
+
+func TestHTTPSUpgrader_Upgrade(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		transport schemeTransport
+		url       string
+		want      string
+		wantOK    bool
+		wantErr   bool
+	}{
+		{
+			name: "same body",
+			transport: schemeTransport{
+				"http":  pageHandler("Docs", "hello"),
+				"https": pageHandler("Docs", "hello"),
+			},
+			url:    "http://example.com/docs?a=1",
+			want:   "https://example.com/docs?a=1",
+			wantOK: true,
+		},
+		{
+			name: "same title with dynamic body",
+			transport: schemeTransport{
+				"http":  pageHandler("Docs &amp; Guides", "token 1"),
+				"https": pageHandler(" Docs &  Guides ", "token 2"),
+			},
+			url:    "http://example.com/docs",
+			want:   "https://example.com/docs",
+			wantOK: true,
+		},
+		{
+			name: "different page",
+			transport: schemeTransport{
+				"http":  pageHandler("Docs", "hello"),
+				"https": pageHandler("Welcome to nginx", "default"),
+			},
+			url: "http://example.com/docs",
+		},
+		{
+			name: "different text without title",
+			transport: schemeTransport{
+				"http":  pageHandler("", "one"),
+				"https": pageHandler("", "two"),
+			},
+			url: "http://example.com/text",
+		},
+		{
+			name: "https not found",
+			transport: schemeTransport{
+				"http":  pageHandler("Docs", "hello"),
+				"https": pageHandler("Docs", "hello"),
+			},
+			url: "http://example.com/missing",
+		},
+		{
+			name:      "no https",
+			transport: schemeTransport{"http": pageHandler("Docs", "hello")},
+			url:       "http://example.com/docs",
+		},
+		{
+			name:      "already https",
+			transport: schemeTransport{},
+			url:       "https://example.com/docs",
+		},
+		{
+			name:      "http fails",
+			transport: schemeTransport{"https": pageHandler("Docs", "hello")},
+			url:       "http://example.com/docs",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			u := &HTTPSUpgrader{Client: &http.Client{Transport: tt.transport}}
+			got, ok, err := u.Upgrade(ctx, tt.url)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestHTTPURLs(t *testing.T) {
+	t.Parallel()
+
+	alive := checker.Result{Link: checker.Link{URL: "http://a.com"}, Status: checker.StatusAlive}
+	results := []checker.Result{
+		alive,
+		{Link: checker.Link{URL: "https://b.com"}, Status: checker.StatusAlive},
+		{Link: checker.Link{URL: "http://a.com"}, Status: checker.StatusDuplicate, DuplicateOf: &alive},
+		{Link: checker.Link{URL: "http://dead.com"}, Status: checker.StatusDead},
+		{Link: checker.Link{URL: "http://skipped.com"}, Status: checker.StatusSkipped},
+		{
+			Link:        checker.Link{URL: "http://moved.com"},
+			Status:      checker.StatusRedirect,
+			FinalURL:    "https://moved.com/",
+			FinalStatus: 200,
+		},
+		{Link: checker.Link{URL: "http://blocked.com"}, Status: checker.StatusBlocked},
+	}
+
+	assert.Equal(t, []string{"http://a.com", "http://blocked.com"}, HTTPURLs(results))
+}
+
+func TestFixer_FindAllFixes_WithUpgrades(t *testing.T) {
+	t.Parallel()
+
+	f := New()
+	f.SetUpgrades(map[string]string{"http://a.com": "https://a.com"})
+
+	result := checker.Result{
+		Link:       checker.Link{URL: "http://a.com", FilePath: "a.md", Line: 2},
+		Status:     checker.StatusAlive,
+		StatusCode: 200,
+	}
+	changes := f.FindAllFixes([]checker.Result{result}, nil)
+	require.Len(t, changes, 1)
+	require.Len(t, changes[0].Fixes, 1)
+	assert.True(t, changes[0].Fixes[0].Upgrade)
+	assert.Equal(t, "https://a.com", changes[0].Fixes[0].NewURL)
+	assert.Contains(t, f.Preview(changes), "(https upgrade)")
+
+	s, ok := f.Suggest(result)
+	require.True(t, ok)
+	assert.Equal(t, checker.Suggestion{URL: "https://a.com", Source: SuggestionHTTPS}, s)
+}
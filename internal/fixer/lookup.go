@@ -0,0 +1,47 @@
+package fixer
+
+import (
+	"context"
+	"sync"
+)
+
+// lookupConcurrency caps concurrent requests made to find replacement URLs,
+// so lookups don't hammer a single service.
+const lookupConcurrency = 4
+
+// lookupAll runs lookup for each URL concurrently and returns the
+// replacements found, keyed by URL, along with the first lookup error.
+// URLs whose lookup failed or found nothing are left out.
+func lookupAll(
+	ctx context.Context,
+	urls []string,
+	lookup func(ctx context.Context, url string) (string, bool, error),
+) (map[string]string, error) {
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+		found    = make(map[string]string, len(urls))
+		sem      = make(chan struct{}, lookupConcurrency)
+	)
+	for _, u := range urls {
+		wg.Go(func() {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			replacement, ok, err := lookup(ctx, u)
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err != nil:
+				if firstErr == nil {
+					firstErr = err
+				}
+			case ok:
+				found[u] = replacement
+			}
+		})
+	}
+	wg.Wait()
+	return found, firstErr
+}
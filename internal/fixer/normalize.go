@@ -0,0 +1,167 @@
+package fixer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/leonardomso/gone/internal/checker"
+)
+
+// Normalization rules, as named in --normalize and the normalize setting.
+const (
+	// NormalizeTracking strips utm_* and fbclid query parameters.
+	NormalizeTracking = "tracking"
+
+	// NormalizeSlashes collapses duplicate slashes in the path.
+	NormalizeSlashes = "slashes"
+
+	// NormalizeTrailingSlash follows redirects that only add or remove a
+	// trailing slash.
+	NormalizeTrailingSlash = "trailing-slash"
+)
+
+// NormalizeRules lists the normalization rules.
+var NormalizeRules = []string{NormalizeTracking, NormalizeSlashes, NormalizeTrailingSlash}
+
+// duplicateSlashes matches runs of slashes.
+var duplicateSlashes = regexp.MustCompile(`//+`)
+
+// Normalizer cleans up URLs. Tracking parameters and duplicate slashes are
+// removed from links and from the URLs that replace them. A redirect that
+// only adds or removes a trailing slash is a matter of style, so with
+// normalization on it's only followed when TrailingSlash is set.
+type Normalizer struct {
+	Tracking      bool
+	Slashes       bool
+	TrailingSlash bool
+}
+
+// ParseNormalizer returns the normalizer applying the named rules (see
+// NormalizeRules).
+func ParseNormalizer(rules []string) (Normalizer, error) {
+	var n Normalizer
+	for _, rule := range rules {
+		switch strings.ToLower(strings.TrimSpace(rule)) {
+		case NormalizeTracking:
+			n.Tracking = true
+		case NormalizeSlashes:
+			n.Slashes = true
+		case NormalizeTrailingSlash:
+			n.TrailingSlash = true
+		default:
+			return Normalizer{}, fmt.Errorf("unknown normalize rule %q (valid: %s)",
+				rule, strings.Join(NormalizeRules, ", "))
+		}
+	}
+	return n, nil
+}
+
+// Enabled reports whether any rule is on.
+func (n Normalizer) Enabled() bool {
+	return n.Tracking || n.Slashes || n.TrailingSlash
+}
+
+// Normalize removes tracking parameters and duplicate slashes from a URL,
+// as enabled. The rest of the URL is kept as written.
+func (n Normalizer) Normalize(rawURL string) string {
+	if !n.Tracking && !n.Slashes {
+		return rawURL
+	}
+	prefix, path, query, fragment := splitURL(rawURL)
+	if prefix == "" {
+		return rawURL
+	}
+
+	if n.Slashes {
+		path = duplicateSlashes.ReplaceAllString(path, "/")
+	}
+	if n.Tracking && query != "" {
+		params := strings.Split(query[1:], "&")
+		kept := params[:0]
+		for _, p := range params {
+			key, _, _ := strings.Cut(p, "=")
+			key = strings.ToLower(key)
+			if !strings.HasPrefix(key, "utm_") && key != "fbclid" {
+				kept = append(kept, p)
+			}
+		}
+		query = ""
+		if len(kept) > 0 {
+			query = "?" + strings.Join(kept, "&")
+		}
+	}
+	return prefix + path + query + fragment
+}
+
+// splitURL splits a URL into its scheme and host, path, query (with its
+// "?"), and fragment (with its "#"). The prefix is empty if rawURL isn't an
+// absolute URL.
+func splitURL(rawURL string) (prefix, path, query, fragment string) {
+	schemeEnd := strings.Index(rawURL, "://")
+	if schemeEnd < 0 {
+		return "", "", "", ""
+	}
+	rest := rawURL
+	if i := strings.IndexByte(rest, '#'); i >= 0 {
+		rest, fragment = rest[:i], rest[i:]
+	}
+	if i := strings.IndexByte(rest, '?'); i >= 0 {
+		rest, query = rest[:i], rest[i:]
+	}
+	hostEnd := len(rest)
+	if i := strings.IndexByte(rest[schemeEnd+3:], '/'); i >= 0 {
+		hostEnd = schemeEnd + 3 + i
+	}
+	return rest[:hostEnd], rest[hostEnd:], query, fragment
+}
+
+// onlyTrailingSlash reports whether two URLs differ only in the trailing
+// slash of their path.
+func onlyTrailingSlash(a, b string) bool {
+	prefixA, pathA, queryA, fragA := splitURL(a)
+	prefixB, pathB, queryB, fragB := splitURL(b)
+	return a != b && prefixA == prefixB && queryA == queryB && fragA == fragB &&
+		strings.TrimSuffix(pathA, "/") == strings.TrimSuffix(pathB, "/")
+}
+
+// SetNormalizer sets how URLs are normalized (see Normalizer).
+func (f *Fixer) SetNormalizer(n Normalizer) {
+	f.normalizer = n
+}
+
+// redirectFix returns the URL replacing a fixable redirect, normalized,
+// and whether the redirect was only a normalization. It returns false if
+// there's nothing to replace: the link only differs from its destination
+// in the trailing slash, with that rule off, and in nothing else the rules
+// fix.
+func (f *Fixer) redirectFix(r checker.Result) (newURL string, normalized, ok bool) {
+	n := f.normalizer
+	newURL = n.Normalize(r.FinalURL)
+	if !n.Enabled() {
+		return newURL, false, newURL != r.Link.URL
+	}
+
+	link := n.Normalize(r.Link.URL)
+	switch {
+	case link == newURL:
+		return newURL, true, newURL != r.Link.URL
+	case onlyTrailingSlash(link, newURL) && !n.TrailingSlash:
+		return link, true, link != r.Link.URL
+	case onlyTrailingSlash(link, newURL):
+		return newURL, true, true
+	default:
+		return newURL, false, true
+	}
+}
+
+// normalizedFor returns the normalized URL replacing a working link, if it
+// differs from the link.
+func (f *Fixer) normalizedFor(r checker.Result) (string, bool) {
+	resolved := r.Resolved()
+	if resolved.IsDead() || resolved.Status == checker.StatusSkipped {
+		return "", false
+	}
+	normalized := f.normalizer.Normalize(r.Link.URL)
+	return normalized, normalized != r.Link.URL
+}
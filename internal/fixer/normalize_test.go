@@ -0,0 +1,141 @@
+package fixer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/leonardomso/gone/internal/checker"
+)
+
+func TestParseNormalizer(t *testing.T) {
+	t.Parallel()
+
+	n, err := ParseNormalizer([]string{"tracking", " Slashes "})
+	require.NoError(t, err)
+	assert.Equal(t, Normalizer{Tracking: true, Slashes: true}, n)
+	assert.True(t, n.Enabled())
+
+	n, err = ParseNormalizer(nil)
+	require.NoError(t, err)
+	assert.False(t, n.Enabled())
+
+	_, err = ParseNormalizer([]string{"tracking", "query"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown normalize rule "query"`)
+}
+
+func TestNormalizer_Normalize(t *testing.T) {
+	t.Parallel()
+
+	n := Normalizer{Tracking: true, Slashes: true}
+
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://a.com/docs?utm_source=x&UTM_Medium=y", "https://a.com/docs"},
+		{"https://a.com/docs?id=1&fbclid=abc&b=2#top", "https://a.com/docs?id=1&b=2#top"},
+		{"https://a.com//docs///guide/", "https://a.com/docs/guide/"},
+		{"https://a.com/?to=https://b.com//x", "https://a.com/?to=https://b.com//x"},
+		{"https://a.com", "https://a.com"},
+		{"https://a.com/docs?utm", "https://a.com/docs?utm"},
+		{"./docs//guide.md", "./docs//guide.md"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, n.Normalize(tt.url), tt.url)
+	}
+
+	// Disabled rules keep the URL
+	assert.Equal(t, "https://a.com//x?utm_source=y", Normalizer{}.Normalize("https://a.com//x?utm_source=y"))
+	assert.Equal(t, "https://a.com//x", Normalizer{Tracking: true}.Normalize("https://a.com//x?utm_source=y"))
+}
+
+func TestFixer_FindAllFixes_Normalize(t *testing.T) {
+	t.Parallel()
+
+	redirect := func(url, finalURL string) checker.Result {
+		return checker.Result{
+			Link:        checker.Link{URL: url, FilePath: "README.md", Line: 1},
+			Status:      checker.StatusRedirect,
+			StatusCode:  301,
+			FinalURL:    finalURL,
+			FinalStatus: 200,
+		}
+	}
+	alive := func(url string) checker.Result {
+		return checker.Result{
+			Link:       checker.Link{URL: url, FilePath: "README.md", Line: 2},
+			Status:     checker.StatusAlive,
+			StatusCode: 200,
+		}
+	}
+	results := []checker.Result{
+		redirect("https://a.com/docs", "https://a.com/docs/"),
+		redirect("https://b.com/x?utm_source=y", "https://b.com/x/"),
+		redirect("https://c.com/old", "https://c.com/new?utm_campaign=z"),
+		redirect("https://d.com/page?fbclid=1", "https://d.com/page"),
+		alive("https://e.com//guide?utm_medium=m"),
+		alive("https://f.com/guide"),
+	}
+
+	fixesByURL := func(f *Fixer) map[string]Fix {
+		fixes := map[string]Fix{}
+		for _, fc := range f.FindFixes(results) {
+			for _, fix := range fc.Fixes {
+				fixes[fix.OldURL] = fix
+			}
+		}
+		return fixes
+	}
+
+	// Without normalization every redirect is followed as is
+	fixes := fixesByURL(New())
+	assert.Len(t, fixes, 4)
+	assert.Equal(t, "https://c.com/new?utm_campaign=z", fixes["https://c.com/old"].NewURL)
+
+	f := New()
+	f.SetNormalizer(Normalizer{Tracking: true, Slashes: true})
+	fixes = fixesByURL(f)
+	assert.Len(t, fixes, 4)
+	assert.NotContains(t, fixes, "https://a.com/docs", "trailing slash only")
+	assert.Equal(t, "https://b.com/x", fixes["https://b.com/x?utm_source=y"].NewURL)
+	assert.True(t, fixes["https://b.com/x?utm_source=y"].Normalize)
+	assert.Equal(t, "https://c.com/new", fixes["https://c.com/old"].NewURL)
+	assert.False(t, fixes["https://c.com/old"].Normalize)
+	assert.Equal(t, "https://d.com/page", fixes["https://d.com/page?fbclid=1"].NewURL)
+	assert.True(t, fixes["https://d.com/page?fbclid=1"].Normalize)
+	assert.Equal(t, "https://e.com/guide", fixes["https://e.com//guide?utm_medium=m"].NewURL)
+	assert.Equal(t, " (normalized)", fixes["https://e.com//guide?utm_medium=m"].Tag())
+
+	f.SetNormalizer(Normalizer{Tracking: true, Slashes: true, TrailingSlash: true})
+	fixes = fixesByURL(f)
+	assert.Len(t, fixes, 5)
+	assert.Equal(t, "https://a.com/docs/", fixes["https://a.com/docs"].NewURL)
+	assert.True(t, fixes["https://a.com/docs"].Normalize)
+	assert.Equal(t, "https://b.com/x/", fixes["https://b.com/x?utm_source=y"].NewURL)
+}
+
+func TestFixer_Suggest_Normalize(t *testing.T) {
+	t.Parallel()
+
+	f := New()
+	f.SetNormalizer(Normalizer{Tracking: true})
+
+	s, ok := f.Suggest(checker.Result{
+		Link:       checker.Link{URL: "https://a.com/?utm_source=x"},
+		Status:     checker.StatusAlive,
+		StatusCode: 200,
+	})
+	require.True(t, ok)
+	assert.Equal(t, checker.Suggestion{URL: "https://a.com/", Source: SuggestionNormalize}, s)
+
+	_, ok = f.Suggest(checker.Result{
+		Link:       checker.Link{URL: "https://a.com/?utm_source=x"},
+		Status:     checker.StatusDead,
+		StatusCode: 404,
+	})
+	assert.False(t, ok)
+}
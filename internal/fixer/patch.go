@@ -0,0 +1,115 @@
+package fixer
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each change.
+const diffContext = 3
+
+// noNewline marks a last line without a trailing newline in a diff.
+const noNewline = "\\ No newline at end of file\n"
+
+// Diff returns the fixes as a unified diff, which git apply and patch
+// accept. Paths are relative to the current directory, with a/ and b/
+// prefixes as git writes them. Files are read but not modified.
+func (*Fixer) Diff(changes []FileChanges) (string, error) {
+	var b strings.Builder
+	for _, fc := range changes {
+		result, original, modified := rewriteFile(fc)
+		if result.Error != nil {
+			return "", fmt.Errorf("%s: %w", fc.FilePath, result.Error)
+		}
+		b.WriteString(unifiedDiff(fc.FilePath, original, modified))
+	}
+	return b.String(), nil
+}
+
+// unifiedDiff returns the unified diff of a file, or "" if it's unchanged.
+// Fixes replace URLs within lines, so both versions have the same lines
+// and a line either changed or didn't.
+func unifiedDiff(path, original, modified string) string {
+	if original == modified {
+		return ""
+	}
+	before, after := diffLines(original), diffLines(modified)
+	if len(before) != len(after) {
+		// Not produced by URL replacement; show the whole file as changed
+		return diffHeader(path) + formatHunk(before, after, 0, len(before), len(after))
+	}
+
+	var changed []int
+	for i := range before {
+		if before[i] != after[i] {
+			changed = append(changed, i)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(diffHeader(path))
+	for i := 0; i < len(changed); {
+		// Extend the hunk while the next change is close enough to share context
+		j := i
+		for j+1 < len(changed) && changed[j+1]-changed[j] <= 2*diffContext {
+			j++
+		}
+		start := max(changed[i]-diffContext, 0)
+		end := min(changed[j]+diffContext+1, len(before))
+		b.WriteString(formatHunk(before[start:end], after[start:end], start, end-start, end-start))
+		i = j + 1
+	}
+	return b.String()
+}
+
+// diffHeader returns the file header of a unified diff.
+func diffHeader(path string) string {
+	p := strings.TrimPrefix(filepath.ToSlash(filepath.Clean(path)), "/")
+	return fmt.Sprintf("--- a/%s\n+++ b/%s\n", p, p)
+}
+
+// formatHunk returns a hunk starting at line index start. When before and
+// after have the same length, equal lines are written as context;
+// otherwise every line is removed and added.
+func formatHunk(before, after []string, start, beforeCount, afterCount int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "@@ -%s +%s @@\n", hunkRange(start, beforeCount), hunkRange(start, afterCount))
+	if len(before) != len(after) {
+		for _, line := range before {
+			b.WriteString("-" + line)
+		}
+		for _, line := range after {
+			b.WriteString("+" + line)
+		}
+		return b.String()
+	}
+	for i := range before {
+		if before[i] == after[i] {
+			b.WriteString(" " + before[i])
+			continue
+		}
+		b.WriteString("-" + before[i])
+		b.WriteString("+" + after[i])
+	}
+	return b.String()
+}
+
+// hunkRange formats a hunk's 1-based start line and line count.
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// diffLines splits content into lines that keep their newline. A last line
+// without one is followed by the "No newline at end of file" marker.
+func diffLines(content string) []string {
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+	lines[len(lines)-1] += "\n" + noNewline
+	return lines
+}
@@ -0,0 +1,94 @@
+package fixer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	t.Parallel()
+
+	lines := make([]string, 20)
+	for i := range lines {
+		lines[i] = "line " + string(rune('a'+i))
+	}
+	original := strings.Join(lines, "\n") + "\n"
+
+	lines[1] = "line b https://new.com"
+	lines[4] = "line e https://new.com"
+	lines[15] = "line p https://new.com"
+	modified := strings.Join(lines, "\n") + "\n"
+
+	want := `--- a/docs/a.md
++++ b/docs/a.md
+@@ -1,8 +1,8 @@
+ line a
+-line b
++line b https://new.com
+ line c
+ line d
+-line e
++line e https://new.com
+ line f
+ line g
+ line h
+@@ -13,7 +13,7 @@
+ line m
+ line n
+ line o
+-line p
++line p https://new.com
+ line q
+ line r
+ line s
+`
+	assert.Equal(t, want, unifiedDiff("./docs/a.md", original, modified))
+	assert.Empty(t, unifiedDiff("a.md", original, original))
+}
+
+func TestUnifiedDiff_NoNewlineAtEnd(t *testing.T) {
+	t.Parallel()
+
+	want := `--- a/a.md
++++ b/a.md
+@@ -1,2 +1,2 @@
+ intro
+-[a]: https://old.com
+\ No newline at end of file
++[a]: https://new.com
+\ No newline at end of file
+`
+	assert.Equal(t, want, unifiedDiff("a.md", "intro\n[a]: https://old.com", "intro\n[a]: https://new.com"))
+}
+
+func TestFixer_Diff(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "test.md")
+	content := "# Links\n\n[a](https://old.com) and [b](https://old.com)\n"
+	require.NoError(t, os.WriteFile(filePath, []byte(content), 0o600))
+
+	f := New()
+	diff, err := f.Diff([]FileChanges{{
+		FilePath:   filePath,
+		Fixes:      []Fix{{FilePath: filePath, OldURL: "https://old.com", NewURL: "https://new.com", Line: 3}},
+		TotalFixes: 2,
+	}})
+	require.NoError(t, err)
+	assert.Contains(t, diff, "@@ -1,3 +1,3 @@\n # Links\n \n-[a](https://old.com) and [b](https://old.com)\n"+
+		"+[a](https://new.com) and [b](https://new.com)\n")
+
+	// The file itself is left alone
+	data, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(data))
+
+	_, err = f.Diff([]FileChanges{{FilePath: filepath.Join(tmpDir, "missing.md")}})
+	require.Error(t, err)
+}
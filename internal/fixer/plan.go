@@ -0,0 +1,99 @@
+package fixer
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// planVersion is the version of the fix plan format.
+const planVersion = 1
+
+// Plan lists the fixes a fix session would apply, for tools that apply
+// them their own way.
+type Plan struct {
+	Version    int        `json:"version"`
+	TotalFixes int        `json:"total_fixes"` // Replacements, counting every occurrence
+	Files      []PlanFile `json:"files"`
+}
+
+// PlanFile lists the fixes of one file.
+type PlanFile struct {
+	File       string    `json:"file"`
+	TotalFixes int       `json:"total_fixes"`
+	Fixes      []PlanFix `json:"fixes"`
+}
+
+// PlanFix is one URL replacement in a plan.
+type PlanFix struct {
+	File        string   `json:"file"`
+	Line        int      `json:"line"` // First line the URL is on
+	OldURL      string   `json:"old_url"`
+	NewURL      string   `json:"new_url"`
+	Reason      string   `json:"reason"` // One of the Suggestion constants
+	Risk        string   `json:"risk"`   // One of RiskNames
+	RiskReasons []string `json:"risk_reasons,omitempty"`
+	Occurrences int      `json:"occurrences"`
+	Offsets     []int    `json:"offsets,omitempty"` // Byte offsets, where only those are replaced
+	Lines       []int    `json:"lines,omitempty"`   // Lines, where only those are replaced
+	RefName     string   `json:"ref_name,omitempty"`
+	RefDef      bool     `json:"ref_definition,omitempty"` // The URL is in a reference definition
+	RefUsages   int      `json:"ref_usages,omitempty"`
+	Warnings    []string `json:"warnings,omitempty"`
+}
+
+// NewPlan returns the plan of the given changes.
+func NewPlan(changes []FileChanges) Plan {
+	plan := Plan{Version: planVersion, Files: make([]PlanFile, 0, len(changes))}
+	for _, fc := range changes {
+		pf := PlanFile{File: fc.FilePath, TotalFixes: fc.TotalFixes, Fixes: make([]PlanFix, 0, len(fc.Fixes))}
+		for _, fix := range fc.Fixes {
+			pf.Fixes = append(pf.Fixes, PlanFix{
+				File:        fix.FilePath,
+				Line:        fix.Line,
+				OldURL:      fix.OldURL,
+				NewURL:      fix.NewURL,
+				Reason:      fix.Source(),
+				Risk:        fix.Risk.String(),
+				RiskReasons: fix.RiskReasons,
+				Occurrences: fix.Occurrences,
+				Offsets:     fix.Offsets,
+				Lines:       fix.Lines,
+				RefName:     fix.RefName,
+				RefDef:      fix.IsRefDef,
+				RefUsages:   fix.RefUsages,
+				Warnings:    fix.Warnings,
+			})
+		}
+		plan.TotalFixes += fc.TotalFixes
+		plan.Files = append(plan.Files, pf)
+	}
+	return plan
+}
+
+// WritePlan writes the plan of the given changes to path as JSON.
+func WritePlan(path string, changes []FileChanges) error {
+	data, err := json.MarshalIndent(NewPlan(changes), "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o600)
+}
+
+// Source returns how the fix's new URL was found, one of the Suggestion
+// constants.
+func (fix Fix) Source() string {
+	switch {
+	case fix.Rewrite:
+		return SuggestionRewrite
+	case fix.Archive:
+		return SuggestionArchive
+	case fix.Upgrade:
+		return SuggestionHTTPS
+	case fix.Normalize:
+		return SuggestionNormalize
+	case fix.Anchor:
+		return SuggestionAnchor
+	default:
+		return SuggestionRedirect
+	}
+}
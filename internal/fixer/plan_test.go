@@ -0,0 +1,80 @@
+package fixer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWritePlan(t *testing.T) {
+	t.Parallel()
+
+	changes := []FileChanges{
+		{
+			FilePath:   "README.md",
+			TotalFixes: 3,
+			Fixes: []Fix{
+				{FilePath: "README.md", OldURL: "https://old.com", NewURL: "https://new.com", Line: 3, Occurrences: 2},
+				{
+					FilePath: "README.md", OldURL: "http://docs.com", NewURL: "https://docs.com", Line: 9,
+					Occurrences: 1, Upgrade: true, RefName: "docs", RefUsages: 2, IsRefDef: true,
+				},
+			},
+		},
+		{
+			FilePath:   "config.json",
+			TotalFixes: 1,
+			Fixes: []Fix{
+				{
+					FilePath: "config.json", OldURL: "https://gone.com", NewURL: "https://web.archive.org/x",
+					Line: 2, Occurrences: 1, Archive: true, Offsets: []int{14},
+				},
+			},
+		},
+	}
+
+	path := filepath.Join(t.TempDir(), "plan.json")
+	require.NoError(t, WritePlan(path, changes))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var plan Plan
+	require.NoError(t, json.Unmarshal(data, &plan))
+
+	assert.Equal(t, 1, plan.Version)
+	assert.Equal(t, 4, plan.TotalFixes)
+	require.Len(t, plan.Files, 2)
+	assert.Equal(t, "README.md", plan.Files[0].File)
+	assert.Equal(t, PlanFix{
+		File: "README.md", Line: 3, OldURL: "https://old.com", NewURL: "https://new.com",
+		Reason: SuggestionRedirect, Risk: "low", Occurrences: 2,
+	}, plan.Files[0].Fixes[0])
+	assert.Equal(t, SuggestionHTTPS, plan.Files[0].Fixes[1].Reason)
+	assert.Equal(t, "docs", plan.Files[0].Fixes[1].RefName)
+	assert.True(t, plan.Files[0].Fixes[1].RefDef)
+	assert.Equal(t, SuggestionArchive, plan.Files[1].Fixes[0].Reason)
+	assert.Equal(t, []int{14}, plan.Files[1].Fixes[0].Offsets)
+
+	// Optional fields are left out
+	assert.NotContains(t, string(data), `"warnings"`)
+
+	// No changes is an empty plan
+	require.NoError(t, WritePlan(path, nil))
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version": 1, "total_fixes": 0, "files": []}`, string(data))
+}
+
+func TestFix_Source(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, SuggestionRedirect, Fix{}.Source())
+	assert.Equal(t, SuggestionRewrite, Fix{Rewrite: true}.Source())
+	assert.Equal(t, SuggestionArchive, Fix{Archive: true}.Source())
+	assert.Equal(t, SuggestionHTTPS, Fix{Upgrade: true}.Source())
+	assert.Equal(t, SuggestionNormalize, Fix{Normalize: true}.Source())
+}
@@ -0,0 +1,86 @@
+package fixer
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/leonardomso/gone/internal/checker"
+)
+
+// RewriteRule maps URLs matching a regular expression to a new URL.
+// It is used for planned domain migrations, where the destination is known
+// and each URL doesn't need to be confirmed over the network.
+type RewriteRule struct {
+	from *regexp.Regexp
+	to   string
+}
+
+// NewRewriteRule compiles a rewrite rule. The replacement may reference
+// capture groups with $1, ${name}, etc. (see regexp.Regexp.Expand).
+func NewRewriteRule(from, to string) (RewriteRule, error) {
+	if from == "" {
+		return RewriteRule{}, errors.New("rewrite rule needs a from pattern")
+	}
+	if to == "" {
+		return RewriteRule{}, fmt.Errorf("rewrite rule %q needs a to replacement", from)
+	}
+
+	re, err := regexp.Compile(from)
+	if err != nil {
+		return RewriteRule{}, fmt.Errorf("invalid rewrite pattern %q: %w", from, err)
+	}
+
+	return RewriteRule{from: re, to: to}, nil
+}
+
+// Apply rewrites url if it matches the rule.
+// Returns false if the rule doesn't match or leaves the URL unchanged.
+func (r RewriteRule) Apply(url string) (string, bool) {
+	if !r.from.MatchString(url) {
+		return url, false
+	}
+
+	rewritten := r.from.ReplaceAllString(url, r.to)
+	return rewritten, rewritten != url
+}
+
+// String returns the rule as "from -> to".
+func (r RewriteRule) String() string {
+	return r.from.String() + " -> " + r.to
+}
+
+// SetRewriteRules sets the rewrite rules. The first matching rule wins.
+func (f *Fixer) SetRewriteRules(rules []RewriteRule) {
+	f.rewriteRules = rules
+}
+
+// Rewrite applies the first matching rewrite rule to url.
+func (f *Fixer) Rewrite(url string) (string, bool) {
+	for _, rule := range f.rewriteRules {
+		if rewritten, ok := rule.Apply(url); ok {
+			return rewritten, true
+		}
+	}
+	return url, false
+}
+
+// SplitRewrites separates links covered by a rewrite rule from those that
+// still need to be checked for redirects.
+//
+//nolint:gocritic // Named returns would make this function harder to read
+func (f *Fixer) SplitRewrites(links []checker.Link) ([]checker.Link, []checker.Link) {
+	if len(f.rewriteRules) == 0 {
+		return nil, links
+	}
+
+	var rewritten, remaining []checker.Link
+	for _, link := range links {
+		if _, ok := f.Rewrite(link.URL); ok {
+			rewritten = append(rewritten, link)
+		} else {
+			remaining = append(remaining, link)
+		}
+	}
+	return rewritten, remaining
+}
@@ -0,0 +1,128 @@
+package fixer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/leonardomso/gone/internal/checker"
+)
+
+func TestNewRewriteRule(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewRewriteRule("", "x")
+	require.Error(t, err)
+
+	_, err = NewRewriteRule("^http://old", "")
+	require.Error(t, err)
+
+	_, err = NewRewriteRule("[invalid", "x")
+	require.Error(t, err)
+
+	rule, err := NewRewriteRule("^http://old.docs/(.*)", "https://new.docs/$1")
+	require.NoError(t, err)
+	assert.Equal(t, "^http://old.docs/(.*) -> https://new.docs/$1", rule.String())
+}
+
+func TestRewriteRule_Apply(t *testing.T) {
+	t.Parallel()
+
+	rule, err := NewRewriteRule("^http://old.docs/(.*)", "https://new.docs/$1")
+	require.NoError(t, err)
+
+	got, ok := rule.Apply("http://old.docs/guide/intro")
+	assert.True(t, ok)
+	assert.Equal(t, "https://new.docs/guide/intro", got)
+
+	got, ok = rule.Apply("https://other.com/page")
+	assert.False(t, ok)
+	assert.Equal(t, "https://other.com/page", got)
+
+	// A rule that leaves the URL unchanged doesn't count as a match
+	noop, err := NewRewriteRule("^https://same.com", "https://same.com")
+	require.NoError(t, err)
+	_, ok = noop.Apply("https://same.com/page")
+	assert.False(t, ok)
+}
+
+func TestFixer_Rewrite_FirstMatchWins(t *testing.T) {
+	t.Parallel()
+
+	first, err := NewRewriteRule("^https://a.com/docs/", "https://docs.a.com/")
+	require.NoError(t, err)
+	second, err := NewRewriteRule("^https://a.com/", "https://b.com/")
+	require.NoError(t, err)
+
+	f := New()
+	f.SetRewriteRules([]RewriteRule{first, second})
+
+	got, ok := f.Rewrite("https://a.com/docs/x")
+	assert.True(t, ok)
+	assert.Equal(t, "https://docs.a.com/x", got)
+
+	got, ok = f.Rewrite("https://a.com/blog")
+	assert.True(t, ok)
+	assert.Equal(t, "https://b.com/blog", got)
+}
+
+func TestFixer_SplitRewrites(t *testing.T) {
+	t.Parallel()
+
+	links := []checker.Link{
+		{URL: "http://old.docs/a", FilePath: "a.md", Line: 1},
+		{URL: "https://keep.com", FilePath: "a.md", Line: 2},
+	}
+
+	f := New()
+	rewritten, remaining := f.SplitRewrites(links)
+	assert.Empty(t, rewritten)
+	assert.Equal(t, links, remaining)
+
+	rule, err := NewRewriteRule("^http://old.docs/(.*)", "https://new.docs/$1")
+	require.NoError(t, err)
+	f.SetRewriteRules([]RewriteRule{rule})
+
+	rewritten, remaining = f.SplitRewrites(links)
+	require.Len(t, rewritten, 1)
+	require.Len(t, remaining, 1)
+	assert.Equal(t, "http://old.docs/a", rewritten[0].URL)
+	assert.Equal(t, "https://keep.com", remaining[0].URL)
+}
+
+func TestFixer_FindAllFixes_WithRewrites(t *testing.T) {
+	t.Parallel()
+
+	rule, err := NewRewriteRule("^http://old.docs/(.*)", "https://new.docs/$1")
+	require.NoError(t, err)
+
+	f := New()
+	f.SetRewriteRules([]RewriteRule{rule})
+
+	results := []checker.Result{
+		{
+			Link:        checker.Link{URL: "https://redirect.com", FilePath: "a.md", Line: 5},
+			Status:      checker.StatusRedirect,
+			FinalURL:    "https://final.com",
+			FinalStatus: 200,
+		},
+	}
+	rewritten := []checker.Link{
+		{URL: "http://old.docs/a", FilePath: "a.md", Line: 1},
+		{URL: "http://old.docs/a", FilePath: "a.md", Line: 9},
+	}
+
+	changes := f.FindAllFixes(results, rewritten)
+	require.Len(t, changes, 1)
+	require.Len(t, changes[0].Fixes, 2)
+	assert.Equal(t, 3, changes[0].TotalFixes)
+
+	fix := changes[0].Fixes[0]
+	assert.Equal(t, "https://new.docs/a", fix.NewURL)
+	assert.True(t, fix.Rewrite)
+	assert.Equal(t, 2, fix.Occurrences)
+	assert.False(t, changes[0].Fixes[1].Rewrite)
+
+	assert.Contains(t, f.Preview(changes), "(rewrite rule)")
+}
@@ -0,0 +1,213 @@
+package fixer
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode"
+)
+
+// Risk rates how likely a fix is to point a link somewhere its author
+// didn't mean.
+type Risk int
+
+const (
+	// RiskLow fixes keep the page: rewrite rules, normalization, https
+	// upgrades, and redirects that only change the scheme or host.
+	RiskLow Risk = iota
+	// RiskMedium fixes move to another page of the same site, to an
+	// archived snapshot, or to the closest match of a missing anchor.
+	RiskMedium
+	// RiskHigh fixes move to another domain.
+	RiskHigh
+)
+
+// riskNames are the names accepted by ParseRisk.
+var riskNames = [...]string{
+	RiskLow:    "low",
+	RiskMedium: "medium",
+	RiskHigh:   "high",
+}
+
+// String returns the name of the risk.
+func (r Risk) String() string {
+	if r >= 0 && int(r) < len(riskNames) {
+		return riskNames[r]
+	}
+	return "unknown"
+}
+
+// ParseRisk returns the risk whose String() is s.
+func ParseRisk(s string) (Risk, bool) {
+	for i, name := range riskNames {
+		if name == s {
+			return Risk(i), true
+		}
+	}
+	return RiskLow, false
+}
+
+// RiskNames returns the names of all risks, from lowest to highest.
+func RiskNames() []string {
+	return riskNames[:]
+}
+
+// titleStopWords are words too common in link texts and titles to tell
+// whether they're about the same thing.
+var titleStopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "from": true, "this": true, "that": true,
+	"here": true, "click": true, "link": true, "page": true, "read": true, "more": true, "see": true,
+}
+
+// assessRisk rates a fix. Redirects are rated by how much of the URL
+// changes, and raised a level when the title of the new page, if known,
+// shares no word with the link text.
+func assessRisk(fix Fix, title string) (Risk, []string) {
+	switch fix.Source() {
+	case SuggestionRewrite:
+		return RiskLow, []string{"rewrite rule"}
+	case SuggestionNormalize:
+		return RiskLow, []string{"only removes tracking parameters or extra slashes"}
+	case SuggestionHTTPS:
+		return RiskLow, []string{"same content over https://"}
+	case SuggestionArchive:
+		return RiskMedium, []string{"archived snapshot of a dead page"}
+	case SuggestionAnchor:
+		return RiskMedium, []string{"closest match to a missing anchor"}
+	}
+
+	risk, reason := redirectRisk(fix.OldURL, fix.NewURL)
+	reasons := []string{reason}
+	if title == "" {
+		return risk, reasons
+	}
+	switch matches, ok := titleMatches(fix.Text, title); {
+	case !ok:
+	case matches:
+		reasons = append(reasons, "page title matches the link text")
+	default:
+		risk = min(risk+1, RiskHigh)
+		reasons = append(reasons, fmt.Sprintf("page title %q doesn't match the link text", title))
+	}
+	return risk, reasons
+}
+
+// redirectRisk rates a redirect by what it changes in the URL. Hosts that
+// only differ in a "www." prefix are the same domain.
+func redirectRisk(oldURL, newURL string) (Risk, string) {
+	a, errA := url.Parse(oldURL)
+	b, errB := url.Parse(newURL)
+	if errA != nil || errB != nil {
+		return RiskHigh, "URL can't be compared"
+	}
+
+	hostA := strings.TrimPrefix(strings.ToLower(a.Hostname()), "www.")
+	hostB := strings.TrimPrefix(strings.ToLower(b.Hostname()), "www.")
+	if hostA != hostB {
+		return RiskHigh, "redirects to another domain: " + b.Hostname()
+	}
+	if strings.TrimSuffix(a.EscapedPath(), "/") != strings.TrimSuffix(b.EscapedPath(), "/") ||
+		a.RawQuery != b.RawQuery {
+		return RiskMedium, "path changed"
+	}
+	return RiskLow, "only the scheme or host changed"
+}
+
+// titleMatches reports whether a page title shares a word with a link's
+// text. It returns false if the text has no words worth comparing, such as
+// "here" or a bare URL.
+func titleMatches(text, title string) (matches, ok bool) {
+	if strings.Contains(text, "://") {
+		return false, false
+	}
+	textWords := titleWords(text)
+	if len(textWords) == 0 {
+		return false, false
+	}
+	titleSet := map[string]bool{}
+	for _, w := range titleWords(title) {
+		titleSet[w] = true
+	}
+	for _, w := range textWords {
+		if titleSet[w] {
+			return true, true
+		}
+	}
+	return false, true
+}
+
+// titleWords returns the lowercased words of s worth comparing.
+func titleWords(s string) []string {
+	var words []string
+	for _, w := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len([]rune(w)) >= 3 && !titleStopWords[w] {
+			words = append(words, w)
+		}
+	}
+	return words
+}
+
+// FilterRisk returns the changes with only the fixes at most maxRisk
+// risky, and how many fixes were dropped.
+func FilterRisk(changes []FileChanges, maxRisk Risk) ([]FileChanges, int) {
+	dropped := 0
+	kept := FilterFixes(changes, func(fix Fix) bool {
+		if fix.Risk > maxRisk {
+			dropped++
+			return false
+		}
+		return true
+	})
+	return kept, dropped
+}
+
+// TitleURLs returns the unique new URLs of the redirect fixes that move to
+// another page, whose titles are worth comparing with the link text.
+func TitleURLs(changes []FileChanges) []string {
+	seen := map[string]bool{}
+	var urls []string
+	for _, fc := range changes {
+		for _, fix := range fc.Fixes {
+			if fix.Source() != SuggestionRedirect || fix.Risk == RiskLow || seen[fix.NewURL] {
+				continue
+			}
+			seen[fix.NewURL] = true
+			urls = append(urls, fix.NewURL)
+		}
+	}
+	return urls
+}
+
+// SetTitles sets the page titles, keyed by URL, that fixes are assessed
+// with (see TitleFetcher.Titles).
+func (f *Fixer) SetTitles(titles map[string]string) {
+	f.titles = titles
+}
+
+// TitleFetcher fetches the titles of the pages fixes point to.
+type TitleFetcher struct {
+	Client *http.Client
+}
+
+// Title returns the title of the page at rawURL, if it returns 200 and
+// has one.
+func (t *TitleFetcher) Title(ctx context.Context, rawURL string) (string, bool, error) {
+	p, err := fetchPage(ctx, t.Client, rawURL)
+	if err != nil {
+		return "", false, fmt.Errorf("fetching %s: %w", rawURL, err)
+	}
+	if p.status != http.StatusOK || p.title == "" {
+		return "", false, nil
+	}
+	return p.title, true, nil
+}
+
+// Titles fetches the titles of several pages and returns those found,
+// keyed by URL, along with the first error.
+func (t *TitleFetcher) Titles(ctx context.Context, urls []string) (map[string]string, error) {
+	return lookupAll(ctx, urls, t.Title)
+}
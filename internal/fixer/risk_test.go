@@ -0,0 +1,165 @@
+package fixer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/leonardomso/gone/internal/checker"
+)
+
+func TestParseRisk(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range RiskNames() {
+		risk, ok := ParseRisk(name)
+		require.True(t, ok, name)
+		assert.Equal(t, name, risk.String())
+	}
+	_, ok := ParseRisk("none")
+	assert.False(t, ok)
+	assert.True(t, RiskLow < RiskMedium && RiskMedium < RiskHigh)
+}
+
+func TestRedirectRisk(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		oldURL, newURL string
+		want           Risk
+		wantReason     string
+	}{
+		{"http://a.com/docs", "https://a.com/docs/", RiskLow, "only the scheme or host changed"},
+		{"https://a.com/docs", "https://www.a.com/docs", RiskLow, "only the scheme or host changed"},
+		{"https://a.com/docs", "https://a.com/guide", RiskMedium, "path changed"},
+		{"https://a.com/docs?v=1", "https://a.com/docs?v=2", RiskMedium, "path changed"},
+		{"https://a.com/docs", "https://b.com/docs", RiskHigh, "redirects to another domain: b.com"},
+		{"https://docs.a.com/x", "https://a.com/x", RiskHigh, "redirects to another domain: a.com"},
+	}
+
+	for _, tt := range tests {
+		risk, reason := redirectRisk(tt.oldURL, tt.newURL)
+		assert.Equal(t, tt.want, risk, tt.oldURL)
+		assert.Equal(t, tt.wantReason, reason, tt.oldURL)
+	}
+}
+
+func TestTitleMatches(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		text, title     string
+		matches, wantOK bool
+	}{
+		{"Installation guide", "Installing Gone - Guide", true, true},
+		{"the API reference", "Page not found", false, true},
+		{"here", "Anything", false, false},
+		{"https://a.com/docs", "Docs", false, false},
+		{"", "Docs", false, false},
+	}
+
+	for _, tt := range tests {
+		matches, ok := titleMatches(tt.text, tt.title)
+		assert.Equal(t, tt.matches, matches, tt.text)
+		assert.Equal(t, tt.wantOK, ok, tt.text)
+	}
+}
+
+func TestFixer_FindFixes_Risk(t *testing.T) {
+	t.Parallel()
+
+	redirect := func(text, url, finalURL string) checker.Result {
+		return checker.Result{
+			Link:        checker.Link{URL: url, FilePath: "README.md", Line: 1, Text: text},
+			Status:      checker.StatusRedirect,
+			FinalURL:    finalURL,
+			FinalStatus: 200,
+		}
+	}
+	results := []checker.Result{
+		redirect("Docs", "http://a.com/docs", "https://a.com/docs"),
+		redirect("API reference", "https://a.com/api", "https://a.com/v2/api"),
+		redirect("Blog", "https://a.com/blog", "https://a.com/"),
+		redirect("Forum", "https://forum.a.com", "https://b.com/forum"),
+	}
+
+	f := New()
+	changes := f.FindFixes(results)
+	fixes := map[string]Fix{}
+	for _, fix := range changes[0].Fixes {
+		fixes[fix.OldURL] = fix
+	}
+	assert.Equal(t, RiskLow, fixes["http://a.com/docs"].Risk)
+	assert.Equal(t, RiskMedium, fixes["https://a.com/api"].Risk)
+	assert.Equal(t, RiskMedium, fixes["https://a.com/blog"].Risk)
+	assert.Equal(t, RiskHigh, fixes["https://forum.a.com"].Risk)
+	assert.ElementsMatch(t, []string{"https://a.com/v2/api", "https://a.com/", "https://b.com/forum"}, TitleURLs(changes))
+
+	preview := f.Preview(changes)
+	assert.Contains(t, preview, "risk: medium (path changed)")
+	assert.Contains(t, preview, "Risk: 1 low, 2 medium, 1 high")
+
+	// Titles raise fixes whose page doesn't match the link text
+	f.SetTitles(map[string]string{"https://a.com/v2/api": "API Reference v2", "https://a.com/": "Welcome to A"})
+	changes = f.FindFixes(results)
+	for _, fix := range changes[0].Fixes {
+		fixes[fix.OldURL] = fix
+	}
+	assert.Equal(t, RiskMedium, fixes["https://a.com/api"].Risk)
+	assert.Equal(t, []string{"path changed", "page title matches the link text"}, fixes["https://a.com/api"].RiskReasons)
+	assert.Equal(t, RiskHigh, fixes["https://a.com/blog"].Risk)
+	assert.Equal(t, "risk: high (path changed; page title \"Welcome to A\" doesn't match the link text)",
+		fixes["https://a.com/blog"].RiskSummary())
+
+	kept, dropped := FilterRisk(changes, RiskMedium)
+	assert.Equal(t, 2, dropped)
+	require.Len(t, kept, 1)
+	assert.Len(t, kept[0].Fixes, 2)
+	_, dropped = FilterRisk(changes, RiskHigh)
+	assert.Equal(t, 0, dropped)
+}
+
+func TestAssessRisk_Sources(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		fix  Fix
+		want Risk
+	}{
+		{Fix{Rewrite: true, OldURL: "https://a.com", NewURL: "https://b.com"}, RiskLow},
+		{Fix{Normalize: true}, RiskLow},
+		{Fix{Upgrade: true}, RiskLow},
+		{Fix{Archive: true}, RiskMedium},
+	} {
+		risk, reasons := assessRisk(tt.fix, "")
+		assert.Equal(t, tt.want, risk, tt.fix.Source())
+		assert.Len(t, reasons, 1)
+	}
+}
+
+func TestTitleFetcher_Titles(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/docs":
+			_, _ = w.Write([]byte("<html><head><title> Docs &amp; Guides </title></head></html>"))
+		case "/untitled":
+			_, _ = w.Write([]byte("<html></html>"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	fetcher := &TitleFetcher{Client: server.Client()}
+	titles, err := fetcher.Titles(context.Background(), []string{
+		server.URL + "/docs", server.URL + "/untitled", server.URL + "/missing",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{server.URL + "/docs": "Docs & Guides"}, titles)
+}
@@ -0,0 +1,138 @@
+package fixer
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/leonardomso/gone/internal/parser"
+)
+
+// Structured file formats, whose URLs are replaced only where the parser
+// found them, escaped for where they're written.
+const (
+	formatJSON = "json"
+	formatYAML = "yaml"
+	formatTOML = "toml"
+	formatXML  = "xml"
+)
+
+// structuredFormat returns the format of a structured file, or "" for files
+// that use plain string replacement.
+func structuredFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return formatJSON
+	case ".yaml", ".yml":
+		return formatYAML
+	case ".toml":
+		return formatTOML
+	case ".xml":
+		return formatXML
+	default:
+		return ""
+	}
+}
+
+// xmlEscaper escapes a URL for XML text and attribute values alike.
+var xmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;", "'", "&apos;")
+
+// xmlTextEscaper escapes a URL the way XML text usually writes it, with
+// quotes left as they are.
+var xmlTextEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// replaceAtOffsets replaces each fix's URL at the byte offsets the parser
+// reported, leaving other occurrences (in comments, keys the parser skipped,
+// or longer URLs) alone. An offset is skipped if the URL isn't written there
+// as the parser decoded it, or if the new URL can't be written safely there.
+// It returns the new content and the number of replacements of each fix.
+func replaceAtOffsets(content, format string, fixes []Fix) (string, []int) {
+	type site struct {
+		offset int
+		fix    int
+	}
+	var sites []site
+	for i, fix := range fixes {
+		for _, offset := range fix.Offsets {
+			sites = append(sites, site{offset: offset, fix: i})
+		}
+	}
+	// Replace from the end, so earlier offsets stay valid
+	sort.Slice(sites, func(i, j int) bool { return sites[i].offset > sites[j].offset })
+
+	replaced := make([]int, len(fixes))
+	limit := len(content) // Start of the last replacement; sites must end before it
+	for _, s := range sites {
+		fix := fixes[s.fix]
+		n := matchURLAt(content, format, s.offset, fix.OldURL)
+		if n == 0 || s.offset+n > limit {
+			continue
+		}
+		newText, ok := escapeURL(content, format, s.offset, fix.NewURL)
+		if !ok {
+			continue
+		}
+		content = content[:s.offset] + newText + content[s.offset+n:]
+		replaced[s.fix]++
+		limit = s.offset
+	}
+	return content, replaced
+}
+
+// matchURLAt returns the length of url as written at offset, or 0 if it
+// isn't there as a whole URL. XML may write it with entities.
+func matchURLAt(content, format string, offset int, url string) int {
+	if offset < 0 || offset >= len(content) {
+		return 0
+	}
+	written := []string{url}
+	if format == formatXML {
+		written = append(written, xmlTextEscaper.Replace(url), xmlEscaper.Replace(url))
+	}
+	for _, w := range written {
+		if parser.IndexURL([]byte(content[offset:min(len(content), offset+len(w)+64)]), w, 0) == 0 {
+			return len(w)
+		}
+	}
+	return 0
+}
+
+// escapeURL returns url as it must be written at offset for the file to
+// keep its meaning, or false if that can't be done safely.
+func escapeURL(content, format string, offset int, url string) (string, bool) {
+	switch format {
+	case formatJSON:
+		// Every JSON string is double-quoted
+		var b bytes.Buffer
+		enc := json.NewEncoder(&b)
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(url); err != nil {
+			return "", false
+		}
+		quoted := strings.TrimSpace(b.String())
+		return quoted[1 : len(quoted)-1], true
+	case formatXML:
+		return xmlEscaper.Replace(url), true
+	}
+
+	// YAML and TOML: the quoting is known if the URL is a whole string
+	if !strings.ContainsAny(url, `"'\`) {
+		return url, true
+	}
+	var quote byte
+	if offset > 0 {
+		quote = content[offset-1]
+	}
+	switch {
+	case quote == '"':
+		return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(url), true
+	case quote == '\'' && format == formatYAML:
+		return strings.ReplaceAll(url, "'", "''"), true
+	default:
+		// A TOML literal string can't hold a quote, and in other contexts
+		// the quoting is unknown
+		return "", false
+	}
+}
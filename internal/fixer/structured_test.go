@@ -0,0 +1,178 @@
+package fixer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/leonardomso/gone/internal/checker"
+	"github.com/leonardomso/gone/internal/parser"
+	jsonparser "github.com/leonardomso/gone/internal/parser/json"
+	tomlparser "github.com/leonardomso/gone/internal/parser/toml"
+	xmlparser "github.com/leonardomso/gone/internal/parser/xml"
+	yamlparser "github.com/leonardomso/gone/internal/parser/yaml"
+)
+
+// fixStructuredFile parses a file, redirects every occurrence of oldURL to
+// newURL, and applies the fixes, returning the new content.
+func fixStructuredFile(t *testing.T, p parser.FileParser, name, content, oldURL, newURL string) string {
+	t.Helper()
+
+	filePath := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(filePath, []byte(content), 0o600))
+	parserLinks, err := p.ValidateAndParse(filePath, []byte(content))
+	require.NoError(t, err)
+
+	var results []checker.Result
+	for _, pl := range parserLinks {
+		if pl.URL != oldURL {
+			continue
+		}
+		results = append(results, checker.Result{
+			Link:          checker.Link{URL: pl.URL, FilePath: pl.FilePath, Line: pl.Line, Offset: pl.Offset},
+			Status:        checker.StatusRedirect,
+			RedirectChain: []checker.Redirect{{URL: pl.URL, StatusCode: 301}},
+			FinalURL:      newURL,
+			FinalStatus:   200,
+		})
+	}
+	require.NotEmpty(t, results)
+
+	f := New()
+	f.SetParserLinks(parserLinks)
+	changes := f.FindFixes(results)
+	require.Len(t, changes, 1)
+	_, err = f.ApplyToFile(changes[0])
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestFixer_StructuredFiles(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		parser  parser.FileParser
+		file    string
+		content string
+		oldURL  string
+		newURL  string
+		want    string
+	}{
+		{
+			name:   "JSON leaves longer URLs alone",
+			parser: jsonparser.New(),
+			file:   "links.json",
+			content: `{
+  "home": "https://old.com",
+  "page": "https://old.com/page",
+  "list": ["https://old.com"]
+}`,
+			oldURL: "https://old.com",
+			newURL: "https://new.com",
+			want: `{
+  "home": "https://new.com",
+  "page": "https://old.com/page",
+  "list": ["https://new.com"]
+}`,
+		},
+		{
+			name:    "JSON escapes the new URL",
+			parser:  jsonparser.New(),
+			file:    "links.json",
+			content: `{"home": "https://old.com"}`,
+			oldURL:  "https://old.com",
+			newURL:  `https://new.com/"q"`,
+			want:    `{"home": "https://new.com/\"q\""}`,
+		},
+		{
+			// Where the quoting is unknown, as in a plain scalar, a URL with
+			// quotes isn't written
+			name:   "YAML leaves comments alone and keeps quoting",
+			parser: yamlparser.New(),
+			file:   "config.yaml",
+			content: `# Moved from https://old.com
+home: https://old.com
+quoted: "https://old.com"
+single: 'https://old.com'
+`,
+			oldURL: "https://old.com",
+			newURL: "https://new.com/it's",
+			want: `# Moved from https://old.com
+home: https://old.com
+quoted: "https://new.com/it's"
+single: 'https://new.com/it''s'
+`,
+		},
+		{
+			name:    "YAML plain scalar",
+			parser:  yamlparser.New(),
+			file:    "config.yaml",
+			content: "home: https://old.com # old\n",
+			oldURL:  "https://old.com",
+			newURL:  "https://new.com",
+			want:    "home: https://new.com # old\n",
+		},
+		{
+			name:   "TOML leaves comments alone",
+			parser: tomlparser.New(),
+			file:   "config.toml",
+			content: `# See https://old.com
+home = "https://old.com"
+links = ['https://old.com']
+`,
+			oldURL: "https://old.com",
+			newURL: "https://new.com",
+			want: `# See https://old.com
+home = "https://new.com"
+links = ['https://new.com']
+`,
+		},
+		{
+			name:   "XML escapes ampersands",
+			parser: xmlparser.New(),
+			file:   "feed.xml",
+			content: `<root>
+  <!-- https://old.com/?a=1&amp;b=2 -->
+  <link href="https://old.com/?a=1&amp;b=2"/>
+</root>`,
+			oldURL: "https://old.com/?a=1&b=2",
+			newURL: "https://new.com/?a=1&b=2",
+			want: `<root>
+  <!-- https://old.com/?a=1&amp;b=2 -->
+  <link href="https://new.com/?a=1&amp;b=2"/>
+</root>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := fixStructuredFile(t, tt.parser, tt.file, tt.content, tt.oldURL, tt.newURL)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReplaceAtOffsets_Unsafe(t *testing.T) {
+	t.Parallel()
+
+	// A TOML literal string can't hold a quote
+	content := "home = 'https://old.com'\n"
+	fixes := []Fix{{OldURL: "https://old.com", NewURL: "https://new.com/it's", Offsets: []int{8}}}
+	got, replaced := replaceAtOffsets(content, formatTOML, fixes)
+	assert.Equal(t, content, got)
+	assert.Equal(t, []int{0}, replaced)
+
+	// The URL isn't at the offset
+	fixes = []Fix{{OldURL: "https://old.com", NewURL: "https://new.com", Offsets: []int{0}}}
+	got, replaced = replaceAtOffsets(content, formatTOML, fixes)
+	assert.Equal(t, content, got)
+	assert.Equal(t, []int{0}, replaced)
+}
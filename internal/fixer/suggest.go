@@ -0,0 +1,80 @@
+package fixer
+
+import "github.com/leonardomso/gone/internal/checker"
+
+// Suggestion sources, describing how a replacement URL was found.
+const (
+	// SuggestionRedirect replaces a redirecting URL with its working final destination.
+	SuggestionRedirect = "redirect"
+
+	// SuggestionRewrite replaces a URL using a configured rewrite rule.
+	SuggestionRewrite = "rewrite"
+
+	// SuggestionArchive replaces a dead URL with its latest archived snapshot.
+	SuggestionArchive = "archive"
+
+	// SuggestionHTTPS replaces an http:// URL with its working https:// version.
+	SuggestionHTTPS = "https"
+
+	// SuggestionNormalize removes tracking parameters and duplicate or
+	// trailing slashes from a URL (see Normalizer).
+	SuggestionNormalize = "normalize"
+
+	// SuggestionAnchor replaces a broken #fragment with the closest anchor
+	// on the target page.
+	SuggestionAnchor = "anchor"
+)
+
+// Suggest returns the replacement the fixer would apply to a checked link.
+// Rewrite rules win over redirects, matching what fix applies.
+// Duplicates get no suggestion; their primary result carries it.
+func (f *Fixer) Suggest(r checker.Result) (checker.Suggestion, bool) {
+	if r.Status == checker.StatusDuplicate {
+		return checker.Suggestion{}, false
+	}
+	if newURL, ok := f.Rewrite(r.Link.URL); ok {
+		return checker.Suggestion{URL: newURL, Source: SuggestionRewrite}, true
+	}
+	if newURL, source, ok := f.replacementFor(r); ok {
+		return checker.Suggestion{URL: newURL, Source: source}, true
+	}
+	return checker.Suggestion{}, false
+}
+
+// replacementFor returns the URL replacing a checked link and its source,
+// one of the Suggestion constants other than SuggestionRewrite.
+// Duplicates are replaced like the link they repeat.
+func (f *Fixer) replacementFor(r checker.Result) (newURL, source string, ok bool) {
+	if resolved := r.Resolved(); isFixableRedirect(resolved) {
+		if newURL, normalized, ok := f.redirectFix(resolved); ok {
+			if normalized {
+				return newURL, SuggestionNormalize, true
+			}
+			return newURL, SuggestionRedirect, true
+		}
+	}
+	if fixed, ok := f.anchorFor(r); ok {
+		return fixed, SuggestionAnchor, true
+	}
+	if snapshot, ok := f.snapshotFor(r); ok {
+		return snapshot, SuggestionArchive, true
+	}
+	if upgrade, ok := f.upgradeFor(r); ok {
+		return f.normalizer.Normalize(upgrade), SuggestionHTTPS, true
+	}
+	if f.normalizer.Enabled() {
+		if normalized, ok := f.normalizedFor(r); ok {
+			return normalized, SuggestionNormalize, true
+		}
+	}
+	return "", "", false
+}
+
+// AddSuggestions sets Suggestion on every result the fixer could fix.
+func (f *Fixer) AddSuggestions(results []checker.Result) {
+	for i := range results {
+		if s, ok := f.Suggest(results[i]); ok {
+			results[i].Suggestion = &s
+		}
+	}
+}
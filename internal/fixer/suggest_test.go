@@ -0,0 +1,100 @@
+package fixer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/leonardomso/gone/internal/checker"
+)
+
+func TestFixer_Suggest(t *testing.T) {
+	t.Parallel()
+
+	redirect := checker.Result{
+		Link:          checker.Link{URL: "https://old.com/a"},
+		Status:        checker.StatusRedirect,
+		StatusCode:    301,
+		RedirectChain: []checker.Redirect{{URL: "https://old.com/a", StatusCode: 301}},
+		FinalURL:      "https://new.com/a",
+		FinalStatus:   200,
+	}
+	dead := checker.Result{
+		Link:       checker.Link{URL: "http://old.docs/x"},
+		Status:     checker.StatusDead,
+		StatusCode: 404,
+	}
+	alive := checker.Result{
+		Link:       checker.Link{URL: "https://ok.com"},
+		Status:     checker.StatusAlive,
+		StatusCode: 200,
+	}
+
+	f := New()
+
+	s, ok := f.Suggest(redirect)
+	require.True(t, ok)
+	assert.Equal(t, checker.Suggestion{URL: "https://new.com/a", Source: SuggestionRedirect}, s)
+
+	_, ok = f.Suggest(dead)
+	assert.False(t, ok)
+	_, ok = f.Suggest(alive)
+	assert.False(t, ok)
+
+	dup := redirect
+	dup.Status = checker.StatusDuplicate
+	_, ok = f.Suggest(dup)
+	assert.False(t, ok)
+
+	rule, err := NewRewriteRule("^http://old.docs/(.*)", "https://new.docs/$1")
+	require.NoError(t, err)
+	f.SetRewriteRules([]RewriteRule{rule})
+
+	s, ok = f.Suggest(dead)
+	require.True(t, ok)
+	assert.Equal(t, checker.Suggestion{URL: "https://new.docs/x", Source: SuggestionRewrite}, s)
+}
+
+func TestFixer_Suggest_SnapshotsAndUpgrades(t *testing.T) {
+	t.Parallel()
+
+	dead := checker.Result{Link: checker.Link{URL: "https://gone.com/x"}, Status: checker.StatusDead, StatusCode: 404}
+	plain := checker.Result{Link: checker.Link{URL: "http://ok.com/"}, Status: checker.StatusAlive, StatusCode: 200}
+
+	f := New()
+	_, ok := f.Suggest(dead)
+	assert.False(t, ok, "no snapshots were looked up")
+
+	snapshot := "https://web.archive.org/web/2020/https://gone.com/x"
+	f.SetSnapshots(map[string]string{dead.Link.URL: snapshot})
+	f.SetUpgrades(map[string]string{plain.Link.URL: "https://ok.com/"})
+
+	s, ok := f.Suggest(dead)
+	require.True(t, ok)
+	assert.Equal(t, checker.Suggestion{URL: snapshot, Source: SuggestionArchive}, s)
+	s, ok = f.Suggest(plain)
+	require.True(t, ok)
+	assert.Equal(t, checker.Suggestion{URL: "https://ok.com/", Source: SuggestionHTTPS}, s)
+}
+
+func TestFixer_AddSuggestions(t *testing.T) {
+	t.Parallel()
+
+	results := []checker.Result{
+		{
+			Link:          checker.Link{URL: "https://old.com"},
+			Status:        checker.StatusRedirect,
+			RedirectChain: []checker.Redirect{{URL: "https://old.com", StatusCode: 301}},
+			FinalURL:      "https://new.com",
+			FinalStatus:   200,
+		},
+		{Link: checker.Link{URL: "https://ok.com"}, Status: checker.StatusAlive, StatusCode: 200},
+	}
+
+	New().AddSuggestions(results)
+
+	require.NotNil(t, results[0].Suggestion)
+	assert.Equal(t, "https://new.com", results[0].Suggestion.URL)
+	assert.Nil(t, results[1].Suggestion)
+}
@@ -0,0 +1,111 @@
+package fixer
+
+import (
+	"bytes"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/leonardomso/gone/internal/parser"
+)
+
+// urlStops are the characters around a URL that can't be part of it.
+const urlStops = " \t\r\n\"'<>()[]{}`,"
+
+// textMatch is an occurrence of a fix's old URL in a text file.
+type textMatch struct {
+	start, end int
+	fix        int
+}
+
+// replaceTextURLs replaces the old URL of each fix with its new URL in one
+// pass over content, so a new URL is never rewritten again by another fix.
+// Occurrences that are only the start of a longer URL (https://a.com in
+// https://a.com/page) or that sit inside another URL (https://a.com in
+// https://x.com/?to=https://a.com) are left alone, as are occurrences off
+// a fix's Lines, if it has any. Relative URLs such as #install must stand on
+// their own, so they're not replaced inside #installation or ../a.md#install.
+// It returns how many occurrences of each fix were replaced.
+func replaceTextURLs(content string, fixes []Fix) (string, []int) {
+	data := []byte(content)
+	lineOf := lineFinder(content)
+	var matches []textMatch
+	for i, fix := range fixes {
+		if fix.OldURL == "" {
+			continue
+		}
+		relativeURL := !strings.Contains(fix.OldURL, "://")
+		for from := 0; ; {
+			start := parser.IndexURL(data, fix.OldURL, from)
+			if start < 0 {
+				break
+			}
+			end := start + len(fix.OldURL)
+			if !insideURL(data, start) && (!relativeURL || standsAlone(data, start, end)) &&
+				onLines(fix.Lines, lineOf(start)) {
+				matches = append(matches, textMatch{start: start, end: end, fix: i})
+			}
+			from = end
+		}
+	}
+
+	counts := make([]int, len(fixes))
+	if len(matches) == 0 {
+		return content, counts
+	}
+
+	// Earlier matches first; of matches starting together, the longest
+	sort.Slice(matches, func(a, b int) bool {
+		if matches[a].start != matches[b].start {
+			return matches[a].start < matches[b].start
+		}
+		return matches[a].end > matches[b].end
+	})
+
+	var b strings.Builder
+	b.Grow(len(content))
+	last := 0
+	for _, m := range matches {
+		if m.start < last {
+			continue // Overlaps a replaced URL
+		}
+		b.WriteString(content[last:m.start])
+		b.WriteString(fixes[m.fix].NewURL)
+		last = m.end
+		counts[m.fix]++
+	}
+	b.WriteString(content[last:])
+	return b.String(), counts
+}
+
+// lineFinder returns a function giving the line of a byte offset in
+// content. The line index is built on first use.
+func lineFinder(content string) func(offset int) int {
+	var lines []int
+	return func(offset int) int {
+		if lines == nil {
+			lines = parser.BuildLineIndex([]byte(content))
+		}
+		line, _ := parser.OffsetToLineCol(lines, offset)
+		return line
+	}
+}
+
+// onLines reports whether line is one of lines, or lines is empty.
+func onLines(lines []int, line int) bool {
+	return len(lines) == 0 || slices.Contains(lines, line)
+}
+
+// standsAlone reports whether data[start:end] is bounded by URL stops or
+// the ends of data.
+func standsAlone(data []byte, start, end int) bool {
+	return (start == 0 || strings.IndexByte(urlStops, data[start-1]) >= 0) &&
+		(end == len(data) || strings.IndexByte(urlStops, data[end]) >= 0)
+}
+
+// insideURL reports whether the URL starting at start continues another
+// URL, such as a query parameter value.
+func insideURL(data []byte, start int) bool {
+	wordStart := bytes.LastIndexAny(data[:start], urlStops) + 1
+	return bytes.Contains(data[wordStart:start], []byte("://"))
+}
@@ -0,0 +1,85 @@
+package fixer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplaceTextURLs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		content    string
+		fixes      []Fix
+		want       string
+		wantCounts []int
+	}{
+		{
+			name:       "longer URL is left alone",
+			content:    "[a](https://a.com) [b](https://a.com/path) https://a.com.",
+			fixes:      []Fix{{OldURL: "https://a.com", NewURL: "https://new.com"}},
+			want:       "[a](https://new.com) [b](https://a.com/path) https://new.com.",
+			wantCounts: []int{2},
+		},
+		{
+			name:    "longer URL fixed separately",
+			content: "[b](https://a.com/path) [a](https://a.com)",
+			fixes: []Fix{
+				{OldURL: "https://a.com", NewURL: "https://new.com"},
+				{OldURL: "https://a.com/path", NewURL: "https://new.com/path"},
+			},
+			want:       "[b](https://new.com/path) [a](https://new.com)",
+			wantCounts: []int{1, 1},
+		},
+		{
+			name:       "new URL extends the old one",
+			content:    "<https://a.com/docs> and https://a.com/docs",
+			fixes:      []Fix{{OldURL: "https://a.com/docs", NewURL: "https://a.com/docs/"}},
+			want:       "<https://a.com/docs/> and https://a.com/docs/",
+			wantCounts: []int{2},
+		},
+		{
+			name:    "new URL isn't rewritten by another fix",
+			content: "[a](https://a.com) [b](https://b.com)",
+			fixes: []Fix{
+				{OldURL: "https://a.com", NewURL: "https://b.com"},
+				{OldURL: "https://b.com", NewURL: "https://c.com"},
+			},
+			want:       "[a](https://b.com) [b](https://c.com)",
+			wantCounts: []int{1, 1},
+		},
+		{
+			name:       "URL inside another URL is left alone",
+			content:    "[x](https://x.com/?to=https://a.com) [a](https://a.com)",
+			fixes:      []Fix{{OldURL: "https://a.com", NewURL: "https://new.com"}},
+			want:       "[x](https://x.com/?to=https://a.com) [a](https://new.com)",
+			wantCounts: []int{1},
+		},
+		{
+			name:       "only on the fix's lines",
+			content:    "[a](https://a.com)\n\n    curl https://a.com\n[b]: https://a.com\n",
+			fixes:      []Fix{{OldURL: "https://a.com", NewURL: "https://new.com", Lines: []int{1, 4}}},
+			want:       "[a](https://new.com)\n\n    curl https://a.com\n[b]: https://new.com\n",
+			wantCounts: []int{2},
+		},
+		{
+			name:       "not found",
+			content:    "[b](https://a.com/path)",
+			fixes:      []Fix{{OldURL: "https://a.com", NewURL: "https://new.com"}},
+			want:       "[b](https://a.com/path)",
+			wantCounts: []int{0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, counts := replaceTextURLs(tt.content, tt.fixes)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantCounts, counts)
+		})
+	}
+}
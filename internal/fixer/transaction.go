@@ -0,0 +1,176 @@
+package fixer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// stagedFile is a rewritten file waiting to be committed.
+type stagedFile struct {
+	path     string
+	tempPath string
+	original string
+	modified string
+	changes  []URLChange
+	mode     os.FileMode
+}
+
+// ApplyAtomic applies fixes to all files as a single transaction.
+// Every modified file is first written to a temp file next to it; only when
+// all files are staged are they renamed into place. If anything fails, no file
+// is left modified: staged temp files are removed and already-renamed files
+// are restored to their original content. With a backup set, originals are
+// backed up once everything is staged.
+func (f *Fixer) ApplyAtomic(changes []FileChanges) ([]FixResult, error) {
+	results := make([]FixResult, 0, len(changes))
+	staged := make([]stagedFile, 0, len(changes))
+
+	// Phase 1: rewrite everything in memory and stage temp files
+	for _, fc := range changes {
+		result, original, modified := rewriteFile(fc)
+		if result.Error == nil && modified != original {
+			sf, err := stageFile(fc.FilePath, original, modified)
+			if err != nil {
+				result.Error = err
+			} else {
+				sf.changes = result.ChangedURLs
+				staged = append(staged, sf)
+			}
+		}
+		results = append(results, *result)
+
+		if result.Error != nil {
+			discardStaged(staged)
+			return abortResults(results, changes), fmt.Errorf("%s: %w", fc.FilePath, result.Error)
+		}
+	}
+
+	// Phase 2: back up the originals
+	if f.backup != nil {
+		for i, sf := range staged {
+			if err := f.backup.record(sf.path, sf.original, sf.modified, sf.changes); err != nil {
+				discardStaged(staged)
+				return abortResults(results, changes), errors.Join(
+					fmt.Errorf("%s: %w", sf.path, err),
+					f.forgetStaged(staged[:i]),
+				)
+			}
+		}
+	}
+
+	// Phase 3: rename staged files into place
+	for i, sf := range staged {
+		if err := os.Rename(sf.tempPath, sf.path); err != nil {
+			discardStaged(staged[i:])
+			rollbackErr := restoreOriginals(staged[:i])
+			return abortResults(results, changes), errors.Join(
+				fmt.Errorf("%s: committing file: %w", sf.path, err),
+				rollbackErr,
+				f.forgetStaged(staged),
+			)
+		}
+	}
+
+	return results, nil
+}
+
+// stageFile writes modified content to a temp file in the same directory as
+// path, so the final rename stays on one filesystem.
+func stageFile(path, original, modified string) (stagedFile, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return stagedFile{}, fmt.Errorf("reading file: %w", err)
+	}
+
+	tempPath, err := writeTemp(path, modified, info.Mode().Perm())
+	if err != nil {
+		return stagedFile{}, fmt.Errorf("staging file: %w", err)
+	}
+
+	return stagedFile{
+		path:     path,
+		tempPath: tempPath,
+		original: original,
+		modified: modified,
+		mode:     info.Mode().Perm(),
+	}, nil
+}
+
+// writeTemp writes content to a new temp file next to path and returns its name.
+func writeTemp(path, content string, mode os.FileMode) (string, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".gone-*")
+	if err != nil {
+		return "", err
+	}
+
+	_, err = tmp.WriteString(content)
+	if err == nil {
+		err = tmp.Chmod(mode)
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return "", err
+	}
+
+	return tmp.Name(), nil
+}
+
+// forgetStaged drops the backups of staged files that were rolled back.
+func (f *Fixer) forgetStaged(staged []stagedFile) error {
+	if f.backup == nil {
+		return nil
+	}
+	var errs []error
+	for _, sf := range staged {
+		errs = append(errs, f.backup.forget(sf.path))
+	}
+	return errors.Join(errs...)
+}
+
+// discardStaged removes temp files that were never committed.
+func discardStaged(staged []stagedFile) {
+	for _, sf := range staged {
+		_ = os.Remove(sf.tempPath)
+	}
+}
+
+// restoreOriginals puts back the original content of already-committed files.
+func restoreOriginals(committed []stagedFile) error {
+	var errs []error
+	for _, sf := range committed {
+		tempPath, err := writeTemp(sf.path, sf.original, sf.mode)
+		if err == nil {
+			err = os.Rename(tempPath, sf.path)
+		}
+		if err != nil {
+			_ = os.Remove(tempPath)
+			errs = append(errs, fmt.Errorf("%s: restoring original: %w", sf.path, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// abortResults marks every file as untouched after a rolled-back transaction,
+// keeping the error of the file that caused the abort.
+func abortResults(results []FixResult, changes []FileChanges) []FixResult {
+	aborted := make([]FixResult, 0, len(changes))
+	for i, fc := range changes {
+		r := FixResult{
+			FilePath: fc.FilePath,
+			Skipped:  fc.TotalFixes,
+		}
+		if i < len(results) {
+			r.Error = results[i].Error
+		}
+		aborted = append(aborted, r)
+	}
+	return aborted
+}
@@ -0,0 +1,107 @@
+package fixer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/leonardomso/gone/internal/checker"
+)
+
+// DefaultWaybackEndpoint is the Wayback Machine availability API.
+const DefaultWaybackEndpoint = "https://archive.org/wayback/available"
+
+// Wayback looks up archive.org snapshots of dead links.
+type Wayback struct {
+	Client   *http.Client
+	Endpoint string // Availability API URL; DefaultWaybackEndpoint if empty
+}
+
+// waybackResponse is the availability API's answer.
+type waybackResponse struct {
+	ArchivedSnapshots struct {
+		Closest *struct {
+			URL       string `json:"url"`
+			Status    string `json:"status"`
+			Available bool   `json:"available"`
+		} `json:"closest"`
+	} `json:"archived_snapshots"`
+}
+
+// Snapshot returns the URL of the latest working snapshot of rawURL, or
+// false if archive.org has none.
+func (w *Wayback) Snapshot(ctx context.Context, rawURL string) (string, bool, error) {
+	endpoint := w.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultWaybackEndpoint
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"?url="+url.QueryEscape(rawURL), http.NoBody)
+	if err != nil {
+		return "", false, err
+	}
+	req.Header.Set("User-Agent", checker.DefaultUserAgent)
+
+	client := w.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", false, fmt.Errorf("looking up snapshot of %s: %w", rawURL, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return "", false, fmt.Errorf("looking up snapshot of %s: %s", rawURL, resp.Status)
+	}
+
+	var answer waybackResponse
+	if err := json.NewDecoder(resp.Body).Decode(&answer); err != nil {
+		return "", false, fmt.Errorf("looking up snapshot of %s: %w", rawURL, err)
+	}
+	closest := answer.ArchivedSnapshots.Closest
+	if closest == nil || !closest.Available || closest.Status != "200" || closest.URL == "" {
+		return "", false, nil
+	}
+	return strings.Replace(closest.URL, "http://", "https://", 1), true, nil
+}
+
+// Snapshots looks up the snapshots of several URLs and returns those found,
+// keyed by URL, along with the first lookup error. URLs whose lookup failed
+// are left out, as are those archive.org has no snapshot of.
+func (w *Wayback) Snapshots(ctx context.Context, urls []string) (map[string]string, error) {
+	return lookupAll(ctx, urls, w.Snapshot)
+}
+
+// SetSnapshots sets the archived snapshots, keyed by URL, that replace dead
+// links (see Wayback.Snapshots).
+func (f *Fixer) SetSnapshots(snapshots map[string]string) {
+	f.snapshots = snapshots
+}
+
+// snapshotFor returns the snapshot replacing a dead link, if there is one.
+func (f *Fixer) snapshotFor(r checker.Result) (string, bool) {
+	if !r.Resolved().IsDead() {
+		return "", false
+	}
+	snapshot, ok := f.snapshots[r.Link.URL]
+	return snapshot, ok
+}
+
+// DeadURLs returns the unique URLs of the dead links in results, in the
+// order they were found.
+func DeadURLs(results []checker.Result) []string {
+	seen := map[string]bool{}
+	var urls []string
+	for _, r := range results {
+		if r.Resolved().IsDead() && !seen[r.Link.URL] {
+			seen[r.Link.URL] = true
+			urls = append(urls, r.Link.URL)
+		}
+	}
+	return urls
+}
@@ -0,0 +1,165 @@
+package fixer
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/leonardomso/gone/internal/checker"
+)
+
+// newWaybackServer serves availability answers from snapshots, keyed by URL.
+func newWaybackServer(t *testing.T, snapshots map[string]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u := r.URL.Query().Get("url")
+		if u == "https://broken.com" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		snapshot, ok := snapshots[u]
+		if !ok {
+			_, _ = fmt.Fprint(w, `{"url": "`+u+`", "archived_snapshots": {}}`)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"archived_snapshots": {"closest": `+
+			`{"available": true, "url": %q, "timestamp": "20240101000000", "status": "200"}}}`, snapshot)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestWayback_Snapshot(t *testing.T) {
+	t.Parallel()
+
+	server := newWaybackServer(t, map[string]string{
+		"https://gone.com/page?a=1": "http://web.archive.org/web/20240101000000/https://gone.com/page?a=1",
+	})
+	w := &Wayback{Client: server.Client(), Endpoint: server.URL}
+
+	snapshot, ok, err := w.Snapshot(context.Background(), "https://gone.com/page?a=1")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "https://web.archive.org/web/20240101000000/https://gone.com/page?a=1", snapshot)
+
+	_, ok, err = w.Snapshot(context.Background(), "https://never-archived.com")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, _, err = w.Snapshot(context.Background(), "https://broken.com")
+	assert.ErrorContains(t, err, "503")
+}
+
+func TestWayback_Snapshots(t *testing.T) {
+	t.Parallel()
+
+	server := newWaybackServer(t, map[string]string{
+		"https://a.com": "https://web.archive.org/web/1/https://a.com",
+		"https://b.com": "https://web.archive.org/web/1/https://b.com",
+	})
+	w := &Wayback{Client: server.Client(), Endpoint: server.URL}
+
+	snapshots, err := w.Snapshots(context.Background(),
+		[]string{"https://a.com", "https://b.com", "https://c.com", "https://broken.com"})
+	require.Error(t, err)
+	assert.Equal(t, map[string]string{
+		"https://a.com": "https://web.archive.org/web/1/https://a.com",
+		"https://b.com": "https://web.archive.org/web/1/https://b.com",
+	}, snapshots)
+}
+
+func TestDeadURLs(t *testing.T) {
+	t.Parallel()
+
+	dead := checker.Result{Link: checker.Link{URL: "https://gone.com"}, Status: checker.StatusDead}
+	results := []checker.Result{
+		dead,
+		{Link: checker.Link{URL: "https://ok.com"}, Status: checker.StatusAlive},
+		{Link: checker.Link{URL: "https://gone.com"}, Status: checker.StatusDuplicate, DuplicateOf: &dead},
+		{Link: checker.Link{URL: "https://error.com"}, Status: checker.StatusError},
+	}
+
+	assert.Equal(t, []string{"https://gone.com", "https://error.com"}, DeadURLs(results))
+}
+
+func TestFixer_FindAllFixes_WithSnapshots(t *testing.T) {
+	t.Parallel()
+
+	f := New()
+	f.SetSnapshots(map[string]string{
+		"https://gone.com":  "https://web.archive.org/web/1/https://gone.com",
+		"https://alive.com": "https://web.archive.org/web/1/https://alive.com",
+	})
+
+	dead := checker.Result{
+		Link:       checker.Link{URL: "https://gone.com", FilePath: "a.md", Line: 3},
+		Status:     checker.StatusDead,
+		StatusCode: 404,
+	}
+	dup := checker.Result{
+		Link:        checker.Link{URL: "https://gone.com", FilePath: "a.md", Line: 8},
+		Status:      checker.StatusDuplicate,
+		DuplicateOf: &dead,
+	}
+	results := []checker.Result{
+		dead,
+		dup,
+		{
+			Link:       checker.Link{URL: "https://alive.com", FilePath: "a.md", Line: 5},
+			Status:     checker.StatusAlive,
+			StatusCode: 200,
+		},
+		{
+			Link:       checker.Link{URL: "https://missing.com", FilePath: "b.md", Line: 1},
+			Status:     checker.StatusDead,
+			StatusCode: 404,
+		},
+	}
+
+	changes := f.FindAllFixes(results, nil)
+	require.Len(t, changes, 1)
+	require.Len(t, changes[0].Fixes, 1)
+
+	fix := changes[0].Fixes[0]
+	assert.Equal(t, "https://web.archive.org/web/1/https://gone.com", fix.NewURL)
+	assert.True(t, fix.Archive)
+	assert.False(t, fix.Rewrite)
+	assert.Equal(t, 2, fix.Occurrences)
+	assert.Contains(t, f.Preview(changes), "(archived snapshot)")
+
+	s, ok := f.Suggest(dead)
+	require.True(t, ok)
+	assert.Equal(t, SuggestionArchive, s.Source)
+}
+
+func TestFilterFixes(t *testing.T) {
+	t.Parallel()
+
+	changes := []FileChanges{
+		{
+			FilePath: "a.md",
+			Fixes: []Fix{
+				{OldURL: "https://a.com", Occurrences: 2},
+				{OldURL: "https://gone.com", Occurrences: 1, Archive: true},
+			},
+			TotalFixes: 3,
+		},
+		{
+			FilePath:   "b.md",
+			Fixes:      []Fix{{OldURL: "https://gone.com", Occurrences: 1, Archive: true}},
+			TotalFixes: 1,
+		},
+	}
+
+	filtered := FilterFixes(changes, func(fix Fix) bool { return !fix.Archive })
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "a.md", filtered[0].FilePath)
+	assert.Equal(t, 2, filtered[0].TotalFixes)
+	require.Len(t, filtered[0].Fixes, 1)
+	assert.Equal(t, "https://a.com", filtered[0].Fixes[0].OldURL)
+}
@@ -0,0 +1,164 @@
+// Package forge opens pull requests on GitHub and GitLab.
+package forge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/leonardomso/gone/internal/checker"
+)
+
+// Environment variables holding the API tokens, in order of preference.
+var (
+	GitHubTokenEnv = []string{"GITHUB_TOKEN", "GH_TOKEN"}
+	GitLabTokenEnv = []string{"GITLAB_TOKEN"}
+)
+
+// maxErrorBody caps how much of an API error response is read.
+const maxErrorBody = 4096
+
+// PullRequest is a pull request (or GitLab merge request) to open.
+type PullRequest struct {
+	Title string
+	Body  string
+	Head  string // Branch with the changes
+	Base  string // Branch to merge into
+}
+
+// Forge opens pull requests on a hosted repository.
+type Forge interface {
+	// CreatePullRequest opens a pull request and returns its web URL.
+	CreatePullRequest(ctx context.Context, pr PullRequest) (string, error)
+}
+
+// Remote is a repository on a forge, parsed from a git remote URL.
+type Remote struct {
+	Host string // Host name, without port
+	Path string // Repository path, such as "owner/repo" or "group/subgroup/repo"
+}
+
+// ParseRemote parses a git remote URL, such as
+// https://github.com/owner/repo.git or git@gitlab.com:group/repo.git.
+func ParseRemote(rawURL string) (Remote, error) {
+	var host, path string
+	if u, err := url.Parse(rawURL); err == nil && u.Scheme != "" && u.Host != "" {
+		host, path = u.Hostname(), u.Path
+	} else if before, rest, ok := strings.Cut(rawURL, ":"); ok && !strings.Contains(before, "/") {
+		// scp-like syntax: [user@]host:path
+		host, path = before, rest
+		if _, h, ok := strings.Cut(before, "@"); ok {
+			host = h
+		}
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	if host == "" || !strings.Contains(path, "/") {
+		return Remote{}, fmt.Errorf("can't find a repository in remote URL %q", rawURL)
+	}
+	return Remote{Host: host, Path: path}, nil
+}
+
+// New returns the forge hosting remote, authenticated with the token read
+// from the environment through getenv. github.com and hosts with "gitlab"
+// in their name are supported.
+func New(remote Remote, client *http.Client, getenv func(string) string) (Forge, error) {
+	switch {
+	case remote.Host == "github.com":
+		token, err := token(GitHubTokenEnv, getenv)
+		if err != nil {
+			return nil, err
+		}
+		return &GitHub{Client: client, Token: token, Repo: remote.Path}, nil
+	case strings.Contains(remote.Host, "gitlab"):
+		token, err := token(GitLabTokenEnv, getenv)
+		if err != nil {
+			return nil, err
+		}
+		return &GitLab{Client: client, BaseURL: "https://" + remote.Host, Token: token, Project: remote.Path}, nil
+	default:
+		return nil, fmt.Errorf("can't open pull requests on %s (supported: github.com, GitLab)", remote.Host)
+	}
+}
+
+// token returns the first token set among envs.
+func token(envs []string, getenv func(string) string) (string, error) {
+	for _, env := range envs {
+		if t := getenv(env); t != "" {
+			return t, nil
+		}
+	}
+	return "", fmt.Errorf("set %s to open pull requests", envs[0])
+}
+
+// postJSON sends payload to endpoint and decodes the response into answer.
+func postJSON(
+	ctx context.Context,
+	client *http.Client,
+	endpoint string,
+	header http.Header,
+	payload, answer any,
+) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header = header
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", checker.DefaultUserAgent)
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return apiError(resp)
+	}
+	return json.NewDecoder(resp.Body).Decode(answer)
+}
+
+// apiError describes a failed API response, with the message the API
+// returned if there is one.
+func apiError(resp *http.Response) error {
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	var answer struct {
+		Message any `json:"message"`
+		Errors  []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if json.Unmarshal(data, &answer) != nil || answer.Message == nil {
+		return errors.New(resp.Status)
+	}
+	var msgs []string
+	if list, ok := answer.Message.([]any); ok {
+		for _, m := range list {
+			msgs = append(msgs, fmt.Sprint(m))
+		}
+	} else {
+		msgs = append(msgs, fmt.Sprint(answer.Message))
+	}
+	for _, e := range answer.Errors {
+		if e.Message != "" {
+			msgs = append(msgs, e.Message)
+		}
+	}
+	return fmt.Errorf("%s: %s", resp.Status, strings.Join(msgs, "; "))
+}
@@ -0,0 +1,42 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// DefaultGitHubAPI is the GitHub REST API.
+const DefaultGitHubAPI = "https://api.github.com"
+
+// GitHub opens pull requests on a GitHub repository.
+type GitHub struct {
+	Client  *http.Client
+	BaseURL string // API URL; DefaultGitHubAPI if empty
+	Token   string
+	Repo    string // "owner/repo"
+}
+
+// CreatePullRequest opens a pull request and returns its web URL.
+func (g *GitHub) CreatePullRequest(ctx context.Context, pr PullRequest) (string, error) {
+	baseURL := g.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultGitHubAPI
+	}
+
+	header := http.Header{}
+	header.Set("Accept", "application/vnd.github+json")
+	header.Set("Authorization", "Bearer "+g.Token)
+	header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	payload := map[string]string{"title": pr.Title, "body": pr.Body, "head": pr.Head, "base": pr.Base}
+	var answer struct {
+		HTMLURL string `json:"html_url"`
+	}
+	endpoint := strings.TrimSuffix(baseURL, "/") + "/repos/" + g.Repo + "/pulls"
+	if err := postJSON(ctx, g.Client, endpoint, header, payload, &answer); err != nil {
+		return "", fmt.Errorf("opening pull request on %s: %w", g.Repo, err)
+	}
+	return answer.HTMLURL, nil
+}
@@ -0,0 +1,39 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// GitLab opens merge requests on a GitLab project.
+type GitLab struct {
+	Client  *http.Client
+	BaseURL string // Instance URL, such as https://gitlab.com
+	Token   string
+	Project string // "group/project", with any subgroups
+}
+
+// CreatePullRequest opens a merge request and returns its web URL.
+func (g *GitLab) CreatePullRequest(ctx context.Context, pr PullRequest) (string, error) {
+	header := http.Header{}
+	header.Set("PRIVATE-TOKEN", g.Token)
+
+	payload := map[string]any{
+		"title":                pr.Title,
+		"description":          pr.Body,
+		"source_branch":        pr.Head,
+		"target_branch":        pr.Base,
+		"remove_source_branch": true,
+	}
+	var answer struct {
+		WebURL string `json:"web_url"`
+	}
+	endpoint := strings.TrimSuffix(g.BaseURL, "/") + "/api/v4/projects/" + url.PathEscape(g.Project) + "/merge_requests"
+	if err := postJSON(ctx, g.Client, endpoint, header, payload, &answer); err != nil {
+		return "", fmt.Errorf("opening merge request on %s: %w", g.Project, err)
+	}
+	return answer.WebURL, nil
+}
@@ -0,0 +1,175 @@
+// Package gitrepo runs the git commands gone fix uses to commit its fixes,
+// and gone init to install a pre-commit hook.
+package gitrepo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Repo is a git working tree.
+type Repo struct {
+	Dir string // Top-level directory of the working tree
+}
+
+// Open returns the working tree containing path, which may be a file or a
+// directory. Git must be installed.
+func Open(ctx context.Context, path string) (*Repo, error) {
+	dir := path
+	if info, err := os.Stat(path); err == nil && !info.IsDir() {
+		dir = filepath.Dir(path)
+	}
+	out, err := run(ctx, dir, nil, "rev-parse", "--show-toplevel")
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return nil, fmt.Errorf("%s is not in a git repository", path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Repo{Dir: strings.TrimSpace(out)}, nil
+}
+
+// Dirty returns the paths with uncommitted changes, including untracked
+// files, relative to the top-level directory. Paths under an ignored prefix
+// (such as gone's own ".gone/" directory) are left out.
+func (r *Repo) Dirty(ctx context.Context, ignore ...string) ([]string, error) {
+	out, err := run(ctx, r.Dir, nil, "status", "--porcelain", "-z", "--untracked-files=all")
+	if err != nil {
+		return nil, err
+	}
+
+	var dirty []string
+	entries := strings.Split(out, "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if len(entry) < 4 {
+			continue
+		}
+		path := entry[3:]
+		if entry[0] == 'R' || entry[0] == 'C' {
+			i++ // The original path of a rename or copy follows
+		}
+		if !hasAnyPrefix(path, ignore) {
+			dirty = append(dirty, path)
+		}
+	}
+	return dirty, nil
+}
+
+// CheckNewBranch returns an error if name isn't a valid branch name or the
+// branch already exists.
+func (r *Repo) CheckNewBranch(ctx context.Context, name string) error {
+	if _, err := run(ctx, r.Dir, nil, "check-ref-format", "--branch", name); err != nil {
+		return fmt.Errorf("invalid branch name %q", name)
+	}
+	_, err := run(ctx, r.Dir, nil, "rev-parse", "--verify", "--quiet", "refs/heads/"+name)
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return fmt.Errorf("branch %s already exists", name)
+	case errors.As(err, &exitErr) && exitErr.ExitCode() == 1:
+		return nil
+	default:
+		return err
+	}
+}
+
+// CurrentBranch returns the name of the checked out branch, or "" if HEAD
+// is detached.
+func (r *Repo) CurrentBranch(ctx context.Context) (string, error) {
+	out, err := run(ctx, r.Dir, nil, "branch", "--show-current")
+	return strings.TrimSpace(out), err
+}
+
+// RemoteURL returns the URL of a remote.
+func (r *Repo) RemoteURL(ctx context.Context, remote string) (string, error) {
+	out, err := run(ctx, r.Dir, nil, "remote", "get-url", remote)
+	return strings.TrimSpace(out), err
+}
+
+// HooksDir returns the directory git runs hooks from, which core.hooksPath
+// may move out of .git.
+func (r *Repo) HooksDir(ctx context.Context) (string, error) {
+	out, err := run(ctx, r.Dir, nil, "rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", err
+	}
+	dir := strings.TrimSpace(out)
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(r.Dir, dir)
+	}
+	return dir, nil
+}
+
+// Push pushes a branch to a remote, setting it as the branch's upstream.
+func (r *Repo) Push(ctx context.Context, remote, branch string) error {
+	_, err := run(ctx, r.Dir, nil, "push", "--quiet", "--set-upstream", remote, branch)
+	return err
+}
+
+// CreateBranch creates a branch at HEAD and switches to it, carrying over
+// uncommitted changes.
+func (r *Repo) CreateBranch(ctx context.Context, name string) error {
+	_, err := run(ctx, r.Dir, nil, "switch", "--quiet", "--create", name)
+	return err
+}
+
+// Commit commits the given files, and only them, with message, even if
+// other changes are staged. It returns the abbreviated commit hash.
+func (r *Repo) Commit(ctx context.Context, paths []string, message string) (string, error) {
+	absPaths := make([]string, 0, len(paths))
+	for _, path := range paths {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return "", err
+		}
+		absPaths = append(absPaths, abs)
+	}
+
+	if _, err := run(ctx, r.Dir, nil, append([]string{"add", "--"}, absPaths...)...); err != nil {
+		return "", err
+	}
+	args := append([]string{"commit", "--quiet", "--file=-", "--only", "--"}, absPaths...)
+	if _, err := run(ctx, r.Dir, strings.NewReader(message), args...); err != nil {
+		return "", err
+	}
+	out, err := run(ctx, r.Dir, nil, "rev-parse", "--short", "HEAD")
+	return strings.TrimSpace(out), err
+}
+
+// run runs git in dir and returns its output. Errors include what git
+// printed to stderr. Git never prompts for credentials.
+func run(ctx context.Context, dir string, stdin *strings.Reader, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	if stdin != nil {
+		cmd.Stdin = stdin
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %w: %s", args[0], err, msg)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return stdout.String(), nil
+}
+
+// hasAnyPrefix reports whether path starts with any of prefixes.
+func hasAnyPrefix(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,144 @@
+package gitrepo
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRepo creates a repository with one committed file, README.md.
+func newRepo(t *testing.T) (string, func(args ...string) string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	repo := t.TempDir()
+	git := func(args ...string) string {
+		cmd := exec.Command("git", append([]string{"-C", repo}, args...)...)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+		return string(out)
+	}
+	git("init", "--quiet", "--initial-branch=main")
+	git("config", "user.name", "gone")
+	git("config", "user.email", "gone@example.com")
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "README.md"), []byte("[Docs](http://old.com)\n"), 0o600))
+	git("add", "README.md")
+	git("commit", "--quiet", "-m", "Add README")
+	return repo, git
+}
+
+func TestOpen(t *testing.T) {
+	t.Parallel()
+
+	repo, _ := newRepo(t)
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, "docs"), 0o750))
+
+	for _, path := range []string{repo, filepath.Join(repo, "docs"), filepath.Join(repo, "README.md")} {
+		r, err := Open(context.Background(), path)
+		require.NoError(t, err, path)
+		wantDir, err := filepath.EvalSymlinks(repo)
+		require.NoError(t, err)
+		gotDir, err := filepath.EvalSymlinks(r.Dir)
+		require.NoError(t, err)
+		assert.Equal(t, wantDir, gotDir, path)
+	}
+
+	_, err := Open(context.Background(), t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not in a git repository")
+}
+
+func TestRepo_HooksDir(t *testing.T) {
+	t.Parallel()
+
+	repo, git := newRepo(t)
+	r, err := Open(context.Background(), repo)
+	require.NoError(t, err)
+
+	dir, err := r.HooksDir(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(r.Dir, ".git", "hooks"), dir)
+
+	git("config", "core.hooksPath", ".githooks")
+	dir, err = r.HooksDir(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(r.Dir, ".githooks"), dir)
+}
+
+func TestRepo_Dirty(t *testing.T) {
+	t.Parallel()
+
+	repo, git := newRepo(t)
+	r := &Repo{Dir: repo}
+	ctx := context.Background()
+
+	dirty, err := r.Dirty(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, dirty)
+
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "README.md"), []byte("edited\n"), 0o600))
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, ".gone", "backup"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(repo, ".gone", "backup", "log.json"), []byte("{}"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "new.md"), []byte("new\n"), 0o600))
+
+	dirty, err = r.Dirty(ctx, ".gone/")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"README.md", "new.md"}, dirty)
+
+	// Renames list the new path
+	git("add", "new.md")
+	git("mv", "new.md", "renamed.md")
+	dirty, err = r.Dirty(ctx, ".gone/")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"README.md", "renamed.md"}, dirty)
+}
+
+func TestRepo_CheckNewBranch(t *testing.T) {
+	t.Parallel()
+
+	repo, git := newRepo(t)
+	r := &Repo{Dir: repo}
+	ctx := context.Background()
+
+	require.NoError(t, r.CheckNewBranch(ctx, "gone/fix-links"))
+
+	err := r.CheckNewBranch(ctx, "main")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already exists")
+
+	err = r.CheckNewBranch(ctx, "bad..name")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid branch name")
+
+	require.NoError(t, r.CreateBranch(ctx, "gone/fix-links"))
+	assert.Equal(t, "gone/fix-links\n", git("branch", "--show-current"))
+}
+
+func TestRepo_Commit(t *testing.T) {
+	t.Parallel()
+
+	repo, git := newRepo(t)
+	r := &Repo{Dir: repo}
+	ctx := context.Background()
+
+	// An unrelated staged change isn't committed
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "other.md"), []byte("other\n"), 0o600))
+	git("add", "other.md")
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "README.md"), []byte("[Docs](https://new.com)\n"), 0o600))
+
+	hash, err := r.Commit(ctx, []string{filepath.Join(repo, "README.md")}, "Fix 1 link(s) in 1 file(s)\n")
+	require.NoError(t, err)
+	assert.NotEmpty(t, hash)
+
+	assert.Equal(t, "Fix 1 link(s) in 1 file(s)\n", git("log", "-1", "--format=%s"))
+	assert.Equal(t, "README.md\n", git("show", "--name-only", "--format=", "HEAD"))
+	assert.True(t, strings.HasPrefix(git("status", "--porcelain"), "A  other.md"))
+}
@@ -0,0 +1,196 @@
+// Package graph builds a link graph of a repository: which files link to
+// which URLs, and which files link to each other through relative links.
+// The graph can be rendered as Graphviz DOT or JSON.
+package graph
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/leonardomso/gone/internal/parser"
+)
+
+// Node types.
+const (
+	NodeFile = "file"
+	NodeURL  = "url"
+)
+
+// Node is a file or external URL in the graph.
+type Node struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+
+	// Scanned is true for files that were scanned for links. Link targets
+	// that weren't scanned (images, source files, missing files) are false.
+	Scanned bool `json:"scanned,omitempty"`
+
+	// Orphan is true for scanned files no other scanned file links to.
+	Orphan bool `json:"orphan,omitempty"`
+}
+
+// Edge is a link from a file to a URL or another file.
+// Several links between the same pair are merged; Count says how many.
+type Edge struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Type  string `json:"type"` // Type of the target node
+	Lines []int  `json:"lines"`
+	Count int    `json:"count"`
+}
+
+// Graph is a link graph. Nodes and edges are sorted by ID.
+type Graph struct {
+	Nodes []Node `json:"nodes"`
+	Edges []Edge `json:"edges"`
+}
+
+// Build creates the graph for the scanned files. links are the external
+// links found in them; relative are links to other files by path, which are
+// resolved against the linking file's directory, or against root when they
+// start with "/".
+func Build(root string, files []string, links, relative []parser.Link) *Graph {
+	nodes := map[string]*Node{}
+	edges := map[[2]string]*Edge{}
+
+	for _, f := range files {
+		id := filepath.ToSlash(filepath.Clean(f))
+		nodes[id] = &Node{ID: id, Type: NodeFile, Scanned: true}
+	}
+
+	addEdge := func(link parser.Link, to, nodeType string) {
+		from := filepath.ToSlash(filepath.Clean(link.FilePath))
+		if _, ok := nodes[to]; !ok {
+			nodes[to] = &Node{ID: to, Type: nodeType}
+		}
+		key := [2]string{from, to}
+		e, ok := edges[key]
+		if !ok {
+			e = &Edge{From: from, To: to, Type: nodeType}
+			edges[key] = e
+		}
+		e.Count++
+		e.Lines = append(e.Lines, link.Line)
+	}
+
+	for _, link := range links {
+		addEdge(link, link.URL, NodeURL)
+	}
+
+	linkedTo := map[string]bool{}
+	for _, link := range relative {
+		target, ok := resolve(root, link)
+		if !ok {
+			continue
+		}
+		addEdge(link, target, NodeFile)
+		if target != filepath.ToSlash(filepath.Clean(link.FilePath)) {
+			linkedTo[target] = true
+		}
+	}
+
+	g := &Graph{
+		Nodes: make([]Node, 0, len(nodes)),
+		Edges: make([]Edge, 0, len(edges)),
+	}
+	for _, n := range nodes {
+		n.Orphan = n.Scanned && !linkedTo[n.ID]
+		g.Nodes = append(g.Nodes, *n)
+	}
+	for _, e := range edges {
+		slices.Sort(e.Lines)
+		g.Edges = append(g.Edges, *e)
+	}
+	slices.SortFunc(g.Nodes, func(a, b Node) int { return cmp.Compare(a.ID, b.ID) })
+	slices.SortFunc(g.Edges, func(a, b Edge) int {
+		return cmp.Or(cmp.Compare(a.From, b.From), cmp.Compare(a.To, b.To))
+	})
+	return g
+}
+
+// resolve turns a relative link into the slash-separated path of its target.
+// The fragment and query are dropped.
+func resolve(root string, link parser.Link) (string, bool) {
+	u, err := url.Parse(link.URL)
+	if err != nil || u.Path == "" {
+		return "", false
+	}
+
+	var target string
+	if strings.HasPrefix(u.Path, "/") {
+		target = filepath.Join(root, filepath.FromSlash(u.Path))
+	} else {
+		target = filepath.Join(filepath.Dir(link.FilePath), filepath.FromSlash(u.Path))
+	}
+	return filepath.ToSlash(target), true
+}
+
+// Orphans returns the IDs of scanned files no other scanned file links to.
+func (g *Graph) Orphans() []string {
+	var orphans []string
+	for _, n := range g.Nodes {
+		if n.Orphan {
+			orphans = append(orphans, n.ID)
+		}
+	}
+	return orphans
+}
+
+// JSON renders the graph as indented JSON.
+func (g *Graph) JSON() ([]byte, error) {
+	data, err := json.MarshalIndent(g, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshaling graph: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// DOT renders the graph in Graphviz DOT format. Files are boxes, URLs are
+// ellipses, orphaned files are dashed, and unscanned targets are gray.
+func (g *Graph) DOT() []byte {
+	var b bytes.Buffer
+
+	b.WriteString("digraph links {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [fontname=\"Helvetica\", fontsize=10];\n\n")
+
+	for _, n := range g.Nodes {
+		attrs := []string{"shape=box"}
+		if n.Type == NodeURL {
+			attrs = []string{"shape=ellipse", "color=\"#4078c0\""}
+		}
+		if n.Orphan {
+			attrs = append(attrs, "style=dashed")
+		}
+		if n.Type == NodeFile && !n.Scanned {
+			attrs = append(attrs, "color=gray", "fontcolor=gray")
+		}
+		fmt.Fprintf(&b, "  %s [%s];\n", quote(n.ID), strings.Join(attrs, ", "))
+	}
+
+	if len(g.Edges) > 0 {
+		b.WriteString("\n")
+	}
+	for _, e := range g.Edges {
+		if e.Count > 1 {
+			fmt.Fprintf(&b, "  %s -> %s [label=\"%d\"];\n", quote(e.From), quote(e.To), e.Count)
+			continue
+		}
+		fmt.Fprintf(&b, "  %s -> %s;\n", quote(e.From), quote(e.To))
+	}
+
+	b.WriteString("}\n")
+	return b.Bytes()
+}
+
+// quote returns s as a DOT quoted string.
+func quote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
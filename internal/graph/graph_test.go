@@ -0,0 +1,98 @@
+package graph
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/leonardomso/gone/internal/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testGraph() *Graph {
+	files := []string{"README.md", "./docs/setup.md", "docs/lonely.md"}
+	links := []parser.Link{
+		{URL: "https://go.dev", FilePath: "README.md", Line: 2},
+		{URL: "https://go.dev", FilePath: "docs/setup.md", Line: 1},
+		{URL: "https://go.dev", FilePath: "docs/setup.md", Line: 5},
+	}
+	relative := []parser.Link{
+		{URL: "docs/setup.md", FilePath: "README.md", Line: 3},
+		{URL: "../README.md#install", FilePath: "docs/setup.md", Line: 2},
+		{URL: "./api.md", FilePath: "docs/setup.md", Line: 3},
+		{URL: "/docs/setup.md", FilePath: "docs/setup.md", Line: 4}, // Links to itself
+	}
+	return Build(".", files, links, relative)
+}
+
+func TestBuild(t *testing.T) {
+	t.Parallel()
+
+	g := testGraph()
+
+	ids := make([]string, 0, len(g.Nodes))
+	for _, n := range g.Nodes {
+		ids = append(ids, n.ID)
+	}
+	assert.Equal(t, []string{"README.md", "docs/api.md", "docs/lonely.md", "docs/setup.md", "https://go.dev"}, ids)
+
+	nodes := map[string]Node{}
+	for _, n := range g.Nodes {
+		nodes[n.ID] = n
+	}
+	assert.Equal(t, NodeURL, nodes["https://go.dev"].Type)
+	assert.False(t, nodes["docs/api.md"].Scanned)
+	assert.True(t, nodes["docs/setup.md"].Scanned)
+
+	// Self-links don't keep a page from being an orphan, but setup.md is linked from README
+	assert.Equal(t, []string{"docs/lonely.md"}, g.Orphans())
+
+	edges := map[[2]string]Edge{}
+	for _, e := range g.Edges {
+		edges[[2]string{e.From, e.To}] = e
+	}
+	require.Len(t, edges, 6)
+	dup := edges[[2]string{"docs/setup.md", "https://go.dev"}]
+	assert.Equal(t, 2, dup.Count)
+	assert.Equal(t, []int{1, 5}, dup.Lines)
+	assert.Equal(t, NodeFile, edges[[2]string{"docs/setup.md", "README.md"}].Type)
+}
+
+func TestBuild_Orphans(t *testing.T) {
+	t.Parallel()
+
+	// A page only linking to itself is still an orphan
+	g := Build(".", []string{"a.md"}, nil, []parser.Link{{URL: "a.md#top", FilePath: "a.md"}})
+	assert.Equal(t, []string{"a.md"}, g.Orphans())
+}
+
+func TestGraph_DOT(t *testing.T) {
+	t.Parallel()
+
+	dot := string(testGraph().DOT())
+
+	assert.Contains(t, dot, "digraph links {")
+	assert.Contains(t, dot, `"https://go.dev" [shape=ellipse`)
+	assert.Contains(t, dot, `"docs/lonely.md" [shape=box, style=dashed];`)
+	assert.Contains(t, dot, `"docs/api.md" [shape=box, color=gray, fontcolor=gray];`)
+	assert.Contains(t, dot, `"docs/setup.md" -> "https://go.dev" [label="2"];`)
+	assert.Contains(t, dot, `"README.md" -> "docs/setup.md";`)
+}
+
+func TestGraph_JSON(t *testing.T) {
+	t.Parallel()
+
+	data, err := testGraph().JSON()
+	require.NoError(t, err)
+
+	var decoded Graph
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Len(t, decoded.Nodes, 5)
+	assert.Len(t, decoded.Edges, 6)
+}
+
+func TestQuote(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `"a\"b\\c"`, quote(`a"b\c`))
+}
@@ -0,0 +1,92 @@
+// Package lint provides opt-in static checks on extracted links that don't
+// require network access.
+package lint
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/leonardomso/gone/internal/checker"
+)
+
+// RuleTextMismatch flags links whose visible text is a URL other than the target.
+const RuleTextMismatch = "text-url-mismatch"
+
+// Finding is a single lint issue for a link.
+type Finding struct {
+	Rule    string
+	Message string
+	Link    checker.Link
+}
+
+// Run applies all lint rules to links and returns the findings in link order.
+func Run(links []checker.Link) []Finding {
+	return TextMismatch(links)
+}
+
+// TextMismatch flags links whose visible text is itself a URL that differs
+// from the link target, e.g. [https://docs.example.com](https://evil.example.net).
+// This catches copy-paste mistakes and phishing-style links.
+func TextMismatch(links []checker.Link) []Finding {
+	var findings []Finding
+	for _, link := range links {
+		text, ok := textURL(link.Text)
+		if !ok || sameURL(text, link.URL) {
+			continue
+		}
+		findings = append(findings, Finding{
+			Rule:    RuleTextMismatch,
+			Message: "link text shows " + text + " but points to " + link.URL,
+			Link:    link,
+		})
+	}
+	return findings
+}
+
+// textURL returns the link text if it looks like a URL (http(s):// or www.).
+func textURL(text string) (string, bool) {
+	text = strings.Trim(strings.TrimSpace(text), "<>")
+	if strings.ContainsAny(text, " \t\n") {
+		return "", false
+	}
+
+	lower := strings.ToLower(text)
+	switch {
+	case strings.HasPrefix(lower, "http://"), strings.HasPrefix(lower, "https://"):
+		return text, true
+	case strings.HasPrefix(lower, "www."):
+		return text, true
+	default:
+		return "", false
+	}
+}
+
+// sameURL reports whether the visible text refers to the same URL as the target.
+// Scheme-less text (www.example.com) matches either scheme, and differences in
+// host case or a trailing slash are ignored.
+func sameURL(text, target string) bool {
+	shown, err := parseLoose(text)
+	if err != nil {
+		return false
+	}
+	actual, err := url.Parse(target)
+	if err != nil {
+		return false
+	}
+
+	if shown.Scheme != "" && !strings.EqualFold(shown.Scheme, actual.Scheme) {
+		return false
+	}
+
+	return strings.EqualFold(shown.Host, actual.Host) &&
+		strings.TrimSuffix(shown.Path, "/") == strings.TrimSuffix(actual.Path, "/") &&
+		shown.RawQuery == actual.RawQuery
+}
+
+// parseLoose parses a URL, treating scheme-less text as a host-first URL.
+func parseLoose(raw string) (*url.URL, error) {
+	if !strings.Contains(raw, "://") {
+		return url.Parse("//" + raw)
+	}
+	return url.Parse(raw)
+}
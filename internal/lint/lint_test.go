@@ -0,0 +1,63 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/leonardomso/gone/internal/checker"
+)
+
+func TestTextMismatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		text     string
+		url      string
+		mismatch bool
+	}{
+		{"DifferentHost", "https://docs.example.com", "https://evil.example.net", true},
+		{"DifferentPath", "https://example.com/a", "https://example.com/b", true},
+		{"DifferentScheme", "http://example.com", "https://example.com", true},
+		{"WWWText", "www.example.com", "https://example.org", true},
+		{"Same", "https://example.com/a", "https://example.com/a", false},
+		{"TrailingSlash", "https://example.com/", "https://example.com", false},
+		{"HostCase", "https://Example.com/a", "https://example.com/a", false},
+		{"WWWSameHost", "www.example.com/docs", "https://www.example.com/docs", false},
+		{"AngleBrackets", "<https://example.com>", "https://example.com", false},
+		{"PlainText", "Example docs", "https://example.com", false},
+		{"Empty", "", "https://example.com", false},
+		{"SentenceWithURL", "see https://a.com here", "https://b.com", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			links := []checker.Link{{URL: tt.url, Text: tt.text, FilePath: "a.md", Line: 3}}
+			findings := TextMismatch(links)
+			if !tt.mismatch {
+				assert.Empty(t, findings)
+				return
+			}
+			require.Len(t, findings, 1)
+			assert.Equal(t, RuleTextMismatch, findings[0].Rule)
+			assert.Equal(t, 3, findings[0].Link.Line)
+			assert.Contains(t, findings[0].Message, tt.url)
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	links := []checker.Link{
+		{URL: "https://a.com", Text: "https://b.com"},
+		{URL: "https://c.com", Text: "docs"},
+	}
+
+	findings := Run(links)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "https://a.com", findings[0].Link.URL)
+}
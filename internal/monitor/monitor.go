@@ -0,0 +1,241 @@
+// Package monitor periodically re-validates known links and reports status changes.
+// It is the building block for long-running modes (watch, serve) that turn gone
+// into a lightweight continuous link monitor.
+package monitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/leonardomso/gone/internal/checker"
+)
+
+// Checker is the subset of checker.Checker used by the monitor.
+type Checker interface {
+	Check(ctx context.Context, links []checker.Link) <-chan checker.Result
+}
+
+// LinkSource returns the current set of known links.
+// It is called before every recheck so newly discovered links are picked up.
+// An error skips the recheck, and is reported to the error handler.
+type LinkSource func() ([]checker.Link, error)
+
+// Sink receives status changes after each recheck (webhooks, metrics, logs).
+type Sink interface {
+	Notify(ctx context.Context, deltas []Delta) error
+}
+
+// SinkFunc adapts a function to the Sink interface.
+type SinkFunc func(ctx context.Context, deltas []Delta) error
+
+// Notify implements Sink.
+func (f SinkFunc) Notify(ctx context.Context, deltas []Delta) error {
+	return f(ctx, deltas)
+}
+
+// DeltaKind describes how a link's state changed between rechecks.
+type DeltaKind string
+
+const (
+	// DeltaAdded indicates a URL that was not known before.
+	DeltaAdded DeltaKind = "added"
+	// DeltaChanged indicates a URL whose status changed.
+	DeltaChanged DeltaKind = "changed"
+	// DeltaRemoved indicates a URL that is no longer referenced.
+	DeltaRemoved DeltaKind = "removed"
+)
+
+// Delta is a single status change detected by a recheck.
+type Delta struct {
+	Kind     DeltaKind
+	URL      string
+	Previous checker.LinkStatus // Zero value when Kind == DeltaAdded
+	Current  checker.Result     // Zero value when Kind == DeltaRemoved
+}
+
+// Monitor keeps the last known result per URL and diffs new checks against it.
+type Monitor struct {
+	checker   Checker
+	onError   func(error)
+	onRecheck func([]checker.Result)
+	last      map[string]checker.Result
+	interval  time.Duration
+	mu        sync.Mutex
+}
+
+// New creates a Monitor that rechecks links every interval.
+func New(c Checker, interval time.Duration) *Monitor {
+	return &Monitor{
+		checker:  c,
+		interval: interval,
+		last:     map[string]checker.Result{},
+	}
+}
+
+// OnError sets a handler for link source and sink errors. Errors never stop
+// the monitor.
+func (m *Monitor) OnError(fn func(error)) {
+	m.onError = fn
+}
+
+// OnRecheck sets a function Run calls with the results of every complete
+// recheck, duplicates included, before pushing its deltas to the sinks.
+// Long-running modes use it to refresh what they show.
+func (m *Monitor) OnRecheck(fn func(results []checker.Result)) {
+	m.onRecheck = fn
+}
+
+// Seed sets the last known results, such as those of the check a
+// long-running mode runs when it starts, so the first recheck only reports
+// what changed since.
+func (m *Monitor) Seed(results []checker.Result) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.last = make(map[string]checker.Result, len(results))
+	for _, r := range results {
+		if !r.IsDuplicate() {
+			m.last[r.Link.URL] = r
+		}
+	}
+}
+
+// Interval returns the recheck interval.
+func (m *Monitor) Interval() time.Duration {
+	return m.interval
+}
+
+// Recheck checks all links once and returns the changes since the previous check.
+// Only primary results are tracked; duplicates share their primary's status.
+func (m *Monitor) Recheck(ctx context.Context, links []checker.Link) []Delta {
+	_, deltas := m.recheck(ctx, links)
+	return deltas
+}
+
+// recheck checks links and returns all their results and the changes since
+// the previous check, or no results if ctx was canceled.
+func (m *Monitor) recheck(ctx context.Context, links []checker.Link) ([]checker.Result, []Delta) {
+	var results []checker.Result
+	current := make(map[string]checker.Result, len(links))
+	for r := range m.checker.Check(ctx, links) {
+		results = append(results, r)
+		if r.IsDuplicate() {
+			continue
+		}
+		current[r.Link.URL] = r
+	}
+
+	// A canceled run is incomplete; don't treat missing URLs as removed.
+	if ctx.Err() != nil {
+		return nil, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	deltas := diff(m.last, current)
+	m.last = current
+	return results, deltas
+}
+
+// Snapshot returns the last known result for every tracked URL, sorted by URL.
+func (m *Monitor) Snapshot() []checker.Result {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	results := make([]checker.Result, 0, len(m.last))
+	for _, r := range m.last {
+		results = append(results, r)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Link.URL < results[j].Link.URL
+	})
+	return results
+}
+
+// Run rechecks links every interval until ctx is canceled, pushing non-empty
+// deltas to all sinks. The first recheck runs one interval after Run is
+// called, since long-running modes check their links when they start; Seed
+// the monitor with those results.
+func (m *Monitor) Run(ctx context.Context, source LinkSource, sinks ...Sink) error {
+	if m.interval <= 0 {
+		return fmt.Errorf("recheck interval must be positive, got %s", m.interval)
+	}
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		links, err := source()
+		if err != nil {
+			m.report(err)
+			continue
+		}
+		results, deltas := m.recheck(ctx, links)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if m.onRecheck != nil {
+			m.onRecheck(results)
+		}
+		if len(deltas) > 0 {
+			m.notify(ctx, deltas, sinks)
+		}
+	}
+}
+
+// notify delivers deltas to every sink, reporting failures via the error handler.
+func (m *Monitor) notify(ctx context.Context, deltas []Delta, sinks []Sink) {
+	var errs []error
+	for _, s := range sinks {
+		if err := s.Notify(ctx, deltas); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		m.report(errors.Join(errs...))
+	}
+}
+
+// report passes err to the error handler, if any.
+func (m *Monitor) report(err error) {
+	if m.onError != nil {
+		m.onError(err)
+	}
+}
+
+// diff compares two result sets keyed by URL and returns deltas sorted by URL.
+func diff(previous, current map[string]checker.Result) []Delta {
+	var deltas []Delta
+
+	for u, r := range current {
+		prev, ok := previous[u]
+		switch {
+		case !ok:
+			deltas = append(deltas, Delta{Kind: DeltaAdded, URL: u, Current: r})
+		case prev.Status != r.Status:
+			deltas = append(deltas, Delta{Kind: DeltaChanged, URL: u, Previous: prev.Status, Current: r})
+		}
+	}
+
+	for u, prev := range previous {
+		if _, ok := current[u]; !ok {
+			deltas = append(deltas, Delta{Kind: DeltaRemoved, URL: u, Previous: prev.Status})
+		}
+	}
+
+	sort.Slice(deltas, func(i, j int) bool {
+		return deltas[i].URL < deltas[j].URL
+	})
+	return deltas
+}
@@ -0,0 +1,210 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/leonardomso/gone/internal/checker"
+)
+
+// fakeChecker returns the status configured for each URL.
+type fakeChecker struct {
+	statuses map[string]checker.LinkStatus
+	calls    atomic.Int32
+	mu       sync.Mutex
+}
+
+func (f *fakeChecker) set(url string, status checker.LinkStatus) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.statuses[url] = status
+}
+
+func (f *fakeChecker) Check(_ context.Context, links []checker.Link) <-chan checker.Result {
+	f.calls.Add(1)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	out := make(chan checker.Result, len(links))
+	seen := map[string]bool{}
+	for _, l := range links {
+		status := f.statuses[l.URL]
+		if seen[l.URL] {
+			status = checker.StatusDuplicate
+		}
+		seen[l.URL] = true
+		out <- checker.Result{Link: l, Status: status}
+	}
+	close(out)
+	return out
+}
+
+func newFake() *fakeChecker {
+	return &fakeChecker{statuses: map[string]checker.LinkStatus{}}
+}
+
+func links(urls ...string) []checker.Link {
+	result := make([]checker.Link, len(urls))
+	for i, u := range urls {
+		result[i] = checker.Link{URL: u}
+	}
+	return result
+}
+
+func TestMonitor_Recheck(t *testing.T) {
+	t.Parallel()
+
+	fc := newFake()
+	fc.set("https://a.com", checker.StatusAlive)
+	fc.set("https://b.com", checker.StatusAlive)
+	m := New(fc, time.Minute)
+	ctx := context.Background()
+
+	// First pass reports everything as added, ignoring duplicates.
+	deltas := m.Recheck(ctx, links("https://b.com", "https://a.com", "https://a.com"))
+	require.Len(t, deltas, 2)
+	assert.Equal(t, DeltaAdded, deltas[0].Kind)
+	assert.Equal(t, "https://a.com", deltas[0].URL)
+	assert.Equal(t, "https://b.com", deltas[1].URL)
+
+	// Nothing changed.
+	assert.Empty(t, m.Recheck(ctx, links("https://a.com", "https://b.com")))
+
+	// Status change and removal.
+	fc.set("https://a.com", checker.StatusDead)
+	deltas = m.Recheck(ctx, links("https://a.com"))
+	require.Len(t, deltas, 2)
+	assert.Equal(t, DeltaChanged, deltas[0].Kind)
+	assert.Equal(t, checker.StatusAlive, deltas[0].Previous)
+	assert.Equal(t, checker.StatusDead, deltas[0].Current.Status)
+	assert.Equal(t, DeltaRemoved, deltas[1].Kind)
+	assert.Equal(t, "https://b.com", deltas[1].URL)
+
+	snapshot := m.Snapshot()
+	require.Len(t, snapshot, 1)
+	assert.Equal(t, "https://a.com", snapshot[0].Link.URL)
+}
+
+func TestMonitor_Recheck_CanceledKeepsState(t *testing.T) {
+	t.Parallel()
+
+	fc := newFake()
+	m := New(fc, time.Minute)
+	m.Recheck(context.Background(), links("https://a.com"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Nil(t, m.Recheck(ctx, nil))
+	assert.Len(t, m.Snapshot(), 1)
+}
+
+func TestMonitor_Run(t *testing.T) {
+	t.Parallel()
+
+	fc := newFake()
+	fc.set("https://a.com", checker.StatusAlive)
+	m := New(fc, 10*time.Millisecond)
+
+	var sinkErr atomic.Value
+	m.OnError(func(err error) { sinkErr.Store(err) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	received := make(chan []Delta, 10)
+	sink := SinkFunc(func(_ context.Context, deltas []Delta) error {
+		received <- deltas
+		if deltas[0].Kind == DeltaChanged {
+			cancel()
+		}
+		return errors.New("webhook down")
+	})
+
+	done := make(chan error, 1)
+	go func() {
+		done <- m.Run(ctx, func() ([]checker.Link, error) { return links("https://a.com"), nil }, sink)
+	}()
+
+	first := <-received
+	assert.Equal(t, DeltaAdded, first[0].Kind)
+
+	fc.set("https://a.com", checker.StatusDead)
+	second := <-received
+	assert.Equal(t, DeltaChanged, second[0].Kind)
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Run did not stop after context cancellation")
+	}
+
+	assert.GreaterOrEqual(t, fc.calls.Load(), int32(2))
+	err, _ := sinkErr.Load().(error)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "webhook down")
+}
+
+func TestMonitor_Run_InvalidInterval(t *testing.T) {
+	t.Parallel()
+
+	m := New(newFake(), 0)
+	assert.Error(t, m.Run(context.Background(), func() ([]checker.Link, error) { return nil, nil }))
+}
+
+func TestMonitor_Run_SeedAndOnRecheck(t *testing.T) {
+	t.Parallel()
+
+	fc := newFake()
+	fc.set("https://a.com", checker.StatusAlive)
+	fc.set("https://b.com", checker.StatusDead)
+	m := New(fc, 10*time.Millisecond)
+	m.Seed([]checker.Result{
+		{Link: checker.Link{URL: "https://a.com"}, Status: checker.StatusAlive},
+		{Link: checker.Link{URL: "https://b.com"}, Status: checker.StatusAlive},
+	})
+
+	var sourceErr atomic.Value
+	m.OnError(func(err error) { sourceErr.Store(err) })
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rechecked := make(chan []checker.Result, 10)
+	m.OnRecheck(func(results []checker.Result) { rechecked <- results })
+	received := make(chan []Delta, 10)
+	sink := SinkFunc(func(_ context.Context, deltas []Delta) error {
+		received <- deltas
+		return nil
+	})
+
+	var calls atomic.Int32
+	source := func() ([]checker.Link, error) {
+		if calls.Add(1) == 1 {
+			return nil, errors.New("scan failed")
+		}
+		return links("https://a.com", "https://b.com", "https://a.com"), nil
+	}
+	done := make(chan error, 1)
+	go func() { done <- m.Run(ctx, source, sink) }()
+
+	// Only b.com changed since the seeded results
+	results := <-rechecked
+	assert.Len(t, results, 3, "duplicates are passed to OnRecheck")
+	deltas := <-received
+	require.Len(t, deltas, 1)
+	assert.Equal(t, DeltaChanged, deltas[0].Kind)
+	assert.Equal(t, "https://b.com", deltas[0].URL)
+	cancel()
+	require.NoError(t, <-done)
+
+	err, _ := sourceErr.Load().(error)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "scan failed")
+}
@@ -0,0 +1,66 @@
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// webhookDelta is a delta as webhooks receive it.
+type webhookDelta struct {
+	Kind       DeltaKind `json:"kind"`
+	URL        string    `json:"url"`
+	Previous   string    `json:"previous,omitempty"` // Status before, unless added
+	Status     string    `json:"status,omitempty"`   // Status now, unless removed
+	StatusCode int       `json:"status_code,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	File       string    `json:"file,omitempty"`
+	Line       int       `json:"line,omitempty"`
+}
+
+// Webhook returns a Sink posting each recheck's deltas to url as a JSON
+// object, {"deltas": [...]}, with client. Answers other than 2xx are errors.
+func Webhook(url string, client *http.Client) Sink {
+	return SinkFunc(func(ctx context.Context, deltas []Delta) error {
+		payload := struct {
+			Deltas []webhookDelta `json:"deltas"`
+		}{Deltas: make([]webhookDelta, 0, len(deltas))}
+		for _, d := range deltas {
+			wd := webhookDelta{Kind: d.Kind, URL: d.URL}
+			if d.Kind != DeltaAdded {
+				wd.Previous = d.Previous.String()
+			}
+			if d.Kind != DeltaRemoved {
+				wd.Status = d.Current.Status.String()
+				wd.StatusCode = d.Current.StatusCode
+				wd.Error = d.Current.Error
+				wd.File = d.Current.Link.FilePath
+				wd.Line = d.Current.Link.Line
+			}
+			payload.Deltas = append(payload.Deltas, wd)
+		}
+		body, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("encoding webhook payload: %w", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("webhook: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := client.Do(req)
+		if err != nil {
+			return fmt.Errorf("webhook: %w", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("webhook: %s answered %s", url, resp.Status)
+		}
+		return nil
+	})
+}
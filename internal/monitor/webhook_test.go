@@ -0,0 +1,60 @@
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/leonardomso/gone/internal/checker"
+)
+
+func TestWebhook(t *testing.T) {
+	t.Parallel()
+
+	var got map[string][]map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	deltas := []Delta{
+		{Kind: DeltaAdded, URL: "https://a.com", Current: checker.Result{
+			Link: checker.Link{URL: "https://a.com", FilePath: "README.md", Line: 3}, Status: checker.StatusAlive,
+		}},
+		{Kind: DeltaChanged, URL: "https://b.com", Previous: checker.StatusAlive, Current: checker.Result{
+			Link: checker.Link{URL: "https://b.com"}, Status: checker.StatusDead, StatusCode: 404,
+		}},
+		{Kind: DeltaRemoved, URL: "https://c.com", Previous: checker.StatusDead},
+	}
+	require.NoError(t, Webhook(srv.URL, srv.Client()).Notify(context.Background(), deltas))
+
+	require.Len(t, got["deltas"], 3)
+	assert.Equal(t, map[string]any{
+		"kind": "added", "url": "https://a.com", "status": "alive", "file": "README.md", "line": float64(3),
+	}, got["deltas"][0])
+	assert.Equal(t, map[string]any{
+		"kind": "changed", "url": "https://b.com", "previous": "alive", "status": "dead", "status_code": float64(404),
+	}, got["deltas"][1])
+	assert.Equal(t, map[string]any{"kind": "removed", "url": "https://c.com", "previous": "dead"}, got["deltas"][2])
+}
+
+func TestWebhook_ErrorStatus(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	err := Webhook(srv.URL, srv.Client()).Notify(context.Background(), []Delta{{Kind: DeltaRemoved, URL: "https://a.com"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "502")
+}
@@ -0,0 +1,84 @@
+package output
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/leonardomso/gone/internal/checker"
+)
+
+// Baseline holds the dead links of an earlier run's JSON report, so a run
+// can report only what changed since: links that broke and links that were
+// fixed. Links are matched by URL and file, not line, so edits that move a
+// link don't make it new.
+type Baseline struct {
+	dead []checker.Result
+}
+
+// LoadBaseline reads a JSON report written by JSONFormatter as a baseline.
+func LoadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // Path comes from the user
+	if err != nil {
+		return nil, fmt.Errorf("reading baseline: %w", err)
+	}
+	report, err := ReadJSONReport(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Duplicates read back from JSON only know their primary's URL, so
+	// they're dead if a checked occurrence of the URL is
+	deadURLs := map[string]bool{}
+	for _, r := range report.Results {
+		if !r.IsDuplicate() && r.IsDead() {
+			deadURLs[r.Link.URL] = true
+		}
+	}
+	b := &Baseline{}
+	for _, r := range report.Results {
+		if deadURLs[r.Link.URL] && (r.IsDuplicate() || r.IsDead()) {
+			b.dead = append(b.dead, r)
+		}
+	}
+	return b, nil
+}
+
+// baselineKey identifies a link across runs.
+func baselineKey(l checker.Link) string {
+	return l.FilePath + "\x00" + l.URL
+}
+
+// Compare returns the dead results that weren't dead in the baseline, with
+// duplicates resolved to their primary's status, and the baseline's dead
+// links that no longer are, because they work now or were removed. A link
+// found dead more often than in the baseline counts as newly broken.
+func (b *Baseline) Compare(results []checker.Result) (broken, fixed []checker.Result) {
+	known := map[string]int{}
+	for _, r := range b.dead {
+		known[baselineKey(r.Link)]++
+	}
+	current := map[string]int{}
+	for _, r := range results {
+		r = r.Resolved()
+		if !r.IsDead() {
+			continue
+		}
+		key := baselineKey(r.Link)
+		current[key]++
+		if known[key] > 0 {
+			known[key]--
+			continue
+		}
+		broken = append(broken, r)
+	}
+
+	for _, r := range b.dead {
+		key := baselineKey(r.Link)
+		if current[key] > 0 {
+			current[key]--
+			continue
+		}
+		fixed = append(fixed, r)
+	}
+	return broken, fixed
+}
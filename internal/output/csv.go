@@ -0,0 +1,74 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+
+	"github.com/leonardomso/gone/internal/checker"
+)
+
+// CSVFormatter formats reports as CSV, with a header row and one row per
+// result, duplicates included, for loading into a spreadsheet. Ignored
+// URLs, findings, and the summary aren't included.
+type CSVFormatter struct{}
+
+// csvHeader names the columns of a CSV report.
+var csvHeader = []string{
+	"url", "file_path", "line", "column", "status", "status_code", "error",
+	"final_url", "duplicate_of", "suggestion", "text", "parser", "link_type", "duration_ms",
+}
+
+// Format implements Formatter.
+func (*CSVFormatter) Format(report *Report) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(csvHeader); err != nil {
+		return nil, err
+	}
+	for _, r := range report.Results {
+		if err := w.Write(csvRecord(r)); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// csvRecord returns the row of a result.
+func csvRecord(r checker.Result) []string {
+	var duplicateOf, suggestion string
+	if r.DuplicateOf != nil {
+		duplicateOf = r.DuplicateOf.Link.URL
+	}
+	if r.Suggestion != nil {
+		suggestion = r.Suggestion.URL
+	}
+	return []string{
+		r.Link.URL,
+		r.Link.FilePath,
+		optionalInt(r.Link.Line),
+		optionalInt(r.Link.Column),
+		r.Status.String(),
+		optionalInt(r.StatusCode),
+		r.Error,
+		r.FinalURL,
+		duplicateOf,
+		suggestion,
+		r.Link.Text,
+		r.Link.Parser,
+		r.Link.Type,
+		strconv.FormatInt(r.Duration.Milliseconds(), 10),
+	}
+}
+
+// optionalInt formats n, leaving the cell empty when it's 0 (unknown).
+func optionalInt(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
@@ -0,0 +1,106 @@
+package output
+
+import (
+	"cmp"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/leonardomso/gone/internal/checker"
+)
+
+// DomainSummary aggregates the links pointing at one destination domain.
+type DomainSummary struct {
+	Domain   string
+	Statuses map[string]int // Checked link occurrences per status (alive, dead, ...)
+	Rules    []string       // Ignore and deny rules that matched links on this domain
+	Links    int            // Checked link occurrences
+	Ignored  int            // Link occurrences skipped by ignore rules
+	Denied   int            // Link occurrences matching deny rules
+	Duration time.Duration  // Total time spent checking this domain's URLs
+}
+
+// SummarizeDomains groups results, ignored URLs, and policy violations by
+// destination domain. Duplicates count toward the status of the link they
+// repeat. Domains with the most links come first.
+func SummarizeDomains(results []checker.Result, ignored []IgnoredURL, violations []PolicyViolation) []DomainSummary {
+	byDomain := map[string]*DomainSummary{}
+	get := func(rawURL string) *DomainSummary {
+		domain := domainOf(rawURL)
+		d, ok := byDomain[domain]
+		if !ok {
+			d = &DomainSummary{Domain: domain, Statuses: map[string]int{}}
+			byDomain[domain] = d
+		}
+		return d
+	}
+	addRule := func(d *DomainSummary, reason, rule string) {
+		r := reason + ": " + rule
+		if !slices.Contains(d.Rules, r) {
+			d.Rules = append(d.Rules, r)
+		}
+	}
+
+	for _, r := range results {
+		d := get(r.Link.URL)
+		d.Links++
+		d.Duration += r.Duration
+
+		status := r.Status
+		if r.DuplicateOf != nil {
+			status = r.DuplicateOf.Status
+		}
+		d.Statuses[status.String()]++
+	}
+	for _, ig := range ignored {
+		d := get(ig.URL)
+		d.Ignored++
+		addRule(d, "ignore "+ig.Reason, ig.Rule)
+	}
+	for _, v := range violations {
+		d := get(v.URL)
+		d.Denied++
+		addRule(d, "deny "+v.Reason, v.Rule)
+	}
+
+	summaries := make([]DomainSummary, 0, len(byDomain))
+	for _, d := range byDomain {
+		slices.Sort(d.Rules)
+		summaries = append(summaries, *d)
+	}
+	slices.SortFunc(summaries, func(a, b DomainSummary) int {
+		if c := cmp.Compare(b.Links+b.Ignored, a.Links+a.Ignored); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Domain, b.Domain)
+	})
+	return summaries
+}
+
+// StatusList returns the status counts as "3 alive, 1 dead", in status order.
+func (d DomainSummary) StatusList() string {
+	statuses := []checker.LinkStatus{
+		checker.StatusAlive, checker.StatusRedirect, checker.StatusLongRedirect,
+		checker.StatusLoginRequired, checker.StatusBrokenAnchor, checker.StatusInsecure, checker.StatusSuspect,
+		checker.StatusBlocked,
+		checker.StatusDead, checker.StatusError, checker.StatusTLSError, checker.StatusSkipped,
+	}
+	parts := make([]string, 0, len(d.Statuses))
+	for _, s := range statuses {
+		if n := d.Statuses[s.String()]; n > 0 {
+			parts = append(parts, strconv.Itoa(n)+" "+s.String())
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// domainOf returns the lowercase host of a URL, or "(invalid)" if it has none.
+func domainOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Hostname() == "" {
+		return "(invalid)"
+	}
+	return strings.ToLower(u.Hostname())
+}
@@ -0,0 +1,130 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/leonardomso/gone/internal/checker"
+)
+
+// GitHubFormatter formats reports as GitHub Actions workflow commands, which
+// annotate the files of a pull request: ::error for dead links, broken
+// internal links, policy violations, and parse errors, and ::warning for
+// redirects and other warnings. Duplicates are annotated where they appear.
+// See https://docs.github.com/actions/reference/workflow-commands-for-github-actions.
+type GitHubFormatter struct{}
+
+// StepSummaryEnv names the file that GitHub Actions renders as a job's summary.
+const StepSummaryEnv = "GITHUB_STEP_SUMMARY"
+
+// Format implements Formatter.
+func (*GitHubFormatter) Format(report *Report) ([]byte, error) {
+	var b strings.Builder
+
+	for _, pe := range report.ParseErrors {
+		writeCommand(&b, "error", pe.File, pe.Line, 0, "Malformed file", pe.Error)
+	}
+	for _, r := range report.Results {
+		r = r.Resolved()
+		switch {
+		case r.IsDead():
+			writeCommand(&b, "error", r.Link.FilePath, r.Link.Line, r.Link.Column,
+				"Dead link", r.Link.URL+deadReason(r))
+		case r.IsWarning():
+			label := strings.ToLower(r.Status.Label())
+			writeCommand(&b, "warning", r.Link.FilePath, r.Link.Line, r.Link.Column,
+				strings.ToUpper(label[:1])+label[1:], r.Link.URL+warningReason(r))
+		}
+	}
+	for _, v := range report.Violations {
+		writeCommand(&b, "error", v.File, v.Line, 0, "Policy violation",
+			fmt.Sprintf("%s matches deny %s %q", v.URL, v.Reason, v.Rule))
+	}
+	for _, il := range report.Internal {
+		writeCommand(&b, "error", il.File, il.Line, 0, "Broken internal link", il.URL+": "+il.Message)
+	}
+
+	s := report.Summary
+	fmt.Fprintf(&b, "::notice title=gone::%d link(s) checked: %d alive, %d warning(s), %d dead\n",
+		report.TotalLinks, s.Alive, s.WarningsCount(), s.Dead+s.Errors)
+	return []byte(b.String()), nil
+}
+
+// deadReason describes why a link is dead.
+func deadReason(r checker.Result) string {
+	switch {
+	case r.Error != "":
+		return ": " + r.Error
+	case r.StatusCode > 0:
+		return " returned HTTP " + strconv.Itoa(r.StatusCode)
+	default:
+		return " is dead"
+	}
+}
+
+// warningReason describes a warning, with the URL to use instead if known.
+func warningReason(r checker.Result) string {
+	reason := ": " + r.Status.Description()
+	if r.IsRedirect() && r.FinalURL != "" {
+		reason = " redirects to " + r.FinalURL
+	}
+	if r.Suggestion != nil && r.Suggestion.URL != r.FinalURL {
+		reason += "; suggested fix: " + r.Suggestion.URL
+	}
+	return reason
+}
+
+// writeCommand writes a workflow command annotating a file's line and column.
+// A zero line or column is left out.
+func writeCommand(b *strings.Builder, command, file string, line, col int, title, message string) {
+	b.WriteString("::" + command)
+	sep := " "
+	if file != "" {
+		b.WriteString(sep + "file=" + escapeProperty(file))
+		sep = ","
+	}
+	if line > 0 {
+		b.WriteString(sep + "line=" + strconv.Itoa(line))
+		sep = ","
+		if col > 0 {
+			b.WriteString(",col=" + strconv.Itoa(col))
+		}
+	}
+	b.WriteString(sep + "title=" + escapeProperty(title))
+	b.WriteString("::" + escapeData(message) + "\n")
+}
+
+// escapeData escapes a workflow command's message.
+func escapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeProperty escapes a workflow command's property value.
+func escapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+// WriteStepSummary appends the report in Markdown to the job summary file
+// named by $GITHUB_STEP_SUMMARY. It does nothing outside GitHub Actions.
+func WriteStepSummary(report *Report) error {
+	path := os.Getenv(StepSummaryEnv)
+	if path == "" {
+		return nil
+	}
+
+	data, err := (&MarkdownFormatter{}).Format(report)
+	if err != nil {
+		return fmt.Errorf("formatting step summary: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600) //nolint:gosec // Path comes from the runner
+	if err != nil {
+		return fmt.Errorf("writing step summary: %w", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("writing step summary: %w", err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,155 @@
+package output
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/leonardomso/gone/internal/checker"
+)
+
+// GroupBy selects how text, Markdown, and HTML reports group results.
+type GroupBy string
+
+const (
+	// GroupByStatus groups results into sections per status: dead links,
+	// warnings, duplicates, and so on. It's the default.
+	GroupByStatus GroupBy = "status"
+	// GroupByFile groups results by the file they were found in.
+	GroupByFile GroupBy = "file"
+	// GroupByDomain groups results by destination domain, which shows a
+	// single dead host behind many failures at a glance.
+	GroupByDomain GroupBy = "domain"
+)
+
+// ParseGroupBy returns the grouping named s, or GroupByStatus if s is empty.
+func ParseGroupBy(s string) (GroupBy, bool) {
+	switch g := GroupBy(strings.ToLower(s)); g {
+	case "":
+		return GroupByStatus, true
+	case GroupByStatus, GroupByFile, GroupByDomain:
+		return g, true
+	default:
+		return GroupByStatus, false
+	}
+}
+
+// GroupByNames returns the names of all groupings.
+func GroupByNames() []string {
+	return []string{string(GroupByStatus), string(GroupByFile), string(GroupByDomain)}
+}
+
+// ParseJUnitSuitePer returns the grouping of JUnit test suites named s:
+// GroupByFile, also for an empty s, or GroupByDomain.
+func ParseJUnitSuitePer(s string) (GroupBy, bool) {
+	switch g := GroupBy(strings.ToLower(s)); g {
+	case "", GroupByFile:
+		return GroupByFile, true
+	case GroupByDomain:
+		return g, true
+	default:
+		return GroupByFile, false
+	}
+}
+
+// ResultGroup holds the results that share a file or domain.
+type ResultGroup struct {
+	Key     string
+	Results []checker.Result
+	Dead    int // Dead results, duplicates of dead links included
+}
+
+// GroupResults groups results by file or domain, keeping their order within
+// each group. Groups with the most dead links come first, then the largest,
+// then by name. With GroupByStatus, it returns nil.
+func GroupResults(results []checker.Result, by GroupBy) []ResultGroup {
+	var key func(checker.Result) string
+	switch by {
+	case GroupByFile:
+		key = func(r checker.Result) string { return r.Link.FilePath }
+	case GroupByDomain:
+		key = func(r checker.Result) string { return domainOf(r.Link.URL) }
+	default:
+		return nil
+	}
+
+	index := map[string]int{}
+	var groups []ResultGroup
+	for _, r := range results {
+		k := key(r)
+		i, ok := index[k]
+		if !ok {
+			i = len(groups)
+			index[k] = i
+			groups = append(groups, ResultGroup{Key: k})
+		}
+		groups[i].Results = append(groups[i].Results, r)
+		if r.Resolved().IsDead() {
+			groups[i].Dead++
+		}
+	}
+	slices.SortStableFunc(groups, func(a, b ResultGroup) int {
+		if c := cmp.Compare(b.Dead, a.Dead); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(len(b.Results), len(a.Results)); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Key, b.Key)
+	})
+	return groups
+}
+
+// FileInventory groups results by file, with files sorted by path and
+// results by their position in the file.
+func FileInventory(results []checker.Result) []ResultGroup {
+	var groups []ResultGroup
+	for _, r := range OrderResults(results, SortFile, 0) {
+		if len(groups) == 0 || groups[len(groups)-1].Key != r.Link.FilePath {
+			groups = append(groups, ResultGroup{Key: r.Link.FilePath})
+		}
+		g := &groups[len(groups)-1]
+		g.Results = append(g.Results, r)
+		if r.Resolved().IsDead() {
+			g.Dead++
+		}
+	}
+	return groups
+}
+
+// Title returns the group's key with its counts, as "example.com (12 links, 10 dead)".
+func (g ResultGroup) Title() string {
+	links := "links"
+	if len(g.Results) == 1 {
+		links = "link"
+	}
+	if g.Dead == 0 {
+		return fmt.Sprintf("%s (%d %s)", g.Key, len(g.Results), links)
+	}
+	return fmt.Sprintf("%s (%d %s, %d dead)", g.Key, len(g.Results), links, g.Dead)
+}
+
+// resultDetails returns a one-line explanation of a result's status for
+// grouped tables: the error, the redirect target, the missing anchor, or
+// the link a duplicate repeats.
+func resultDetails(r checker.Result) string {
+	var parts []string
+	switch {
+	case r.IsDuplicate() && r.DuplicateOf != nil:
+		parts = append(parts, fmt.Sprintf("same as %s (%s)",
+			location(r.DuplicateOf.Link.FilePath, r.DuplicateOf.Link.Line), r.DuplicateOf.Status.Label()))
+	case r.Error != "":
+		parts = append(parts, r.Error)
+	case r.IsRedirect():
+		parts = append(parts, "redirects to "+r.FinalURL)
+	}
+	if r.Anchor != "" {
+		parts = append(parts, "missing anchor #"+r.Anchor)
+	}
+	if r.TLS != nil {
+		parts = append(parts, fmt.Sprintf("certificate %s for %s", r.TLS.Problem, r.TLS.Host))
+	}
+	parts = append(parts, r.Hints...)
+	return strings.Join(parts, "; ")
+}
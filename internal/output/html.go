@@ -0,0 +1,205 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+
+	"github.com/leonardomso/gone/internal/checker"
+	"github.com/leonardomso/gone/internal/stats"
+)
+
+// HTMLFormatter formats reports as a standalone HTML page.
+type HTMLFormatter struct{}
+
+// htmlPage is the data passed to htmlTemplate.
+type htmlPage struct {
+	Report    *Report
+	Dead      []checker.Result
+	Warnings  []checker.Result
+	Groups    []ResultGroup // Set instead of Dead and Warnings with --group-by
+	ByFile    bool
+	DeadCount int
+}
+
+// htmlTemplate renders the page. Styles are inlined so the file can be
+// published as a single CI artifact.
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"status":      formatStatusForHTML,
+	"statusClass": statusClassForHTML,
+	"details":     resultDetails,
+	"latency":     stats.FormatDuration,
+}).Parse(`
+{{- define "hops"}}
+{{- if .RedirectChain}}
+<ol class="hops">
+{{- range .RedirectChain}}
+<li><code>{{.StatusCode}}</code> {{.URL}}{{with .Duration}} <span class="latency">{{latency .}}</span>{{end}}</li>
+{{- end}}
+<li><code>{{or .FinalStatus "—"}}</code> {{.FinalURL}} <span class="latency">final</span></li>
+</ol>
+{{- end}}
+{{- end -}}
+<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Gone Link Check Report</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2rem; color: #24292f; }
+table { border-collapse: collapse; margin-bottom: 2rem; }
+th, td { border: 1px solid #d0d7de; padding: 0.3rem 0.6rem; text-align: left; vertical-align: top; }
+th { background: #f6f8fa; }
+td.url { word-break: break-all; }
+.dead { color: #cf222e; font-weight: 600; }
+.warning { color: #9a6700; font-weight: 600; }
+ol.hops { margin: 0.3rem 0 0; padding-left: 1.5rem; }
+ol.hops li { word-break: break-all; }
+.latency { color: #57606a; }
+</style>
+</head>
+<body>
+<h1>Gone Link Check Report</h1>
+<p>
+<strong>Generated:</strong> {{.Report.GeneratedAt.Format "2006-01-02 15:04:05"}}<br>
+<strong>Files Scanned:</strong> {{.Report.FilesScanned}}<br>
+<strong>Total Links:</strong> {{.Report.TotalLinks}}<br>
+<strong>Unique URLs:</strong> {{.Report.UniqueURLs}}
+</p>
+
+<h2>Summary</h2>
+<table>
+<tr><th>Status</th><th>Count</th></tr>
+<tr><td>Alive</td><td>{{.Report.Summary.Alive}}</td></tr>
+<tr><td>Warnings</td><td>{{.Report.Summary.WarningsCount}}</td></tr>
+<tr><td>Dead</td><td>{{.DeadCount}}</td></tr>
+<tr><td>Duplicates</td><td>{{.Report.Summary.Duplicates}}</td></tr>
+{{- if .Report.Summary.Skipped}}
+<tr><td>Skipped</td><td>{{.Report.Summary.Skipped}}</td></tr>
+{{- end}}
+{{- if .Report.Summary.Retries}}
+<tr><td>Retries</td><td>{{.Report.Summary.Retries}} ({{.Report.Summary.RetriedURLs}} URLs)</td></tr>
+{{- end}}
+{{- if .Report.ParseErrors}}
+<tr><td>Parse Errors</td><td>{{len .Report.ParseErrors}}</td></tr>
+{{- end}}
+{{- if .Report.Violations}}
+<tr><td>Policy Violations</td><td>{{len .Report.Violations}}</td></tr>
+{{- end}}
+{{- if .Report.Internal}}
+<tr><td>Broken Internal Links</td><td>{{len .Report.Internal}}</td></tr>
+{{- end}}
+</table>
+{{- range .Groups}}
+
+<h2>{{.Title}}</h2>
+<table>
+<tr><th>Status</th><th>URL</th><th>Text</th><th>{{if $.ByFile}}Line{{else}}File{{end}}</th><th>Details</th></tr>
+{{- range .Results}}
+<tr><td class="{{statusClass .}}">{{status .}}</td><td class="url"><a href="{{.Link.URL}}">{{.Link.URL}}</a></td>
+<td>{{.Link.Text}}</td><td>{{if $.ByFile}}{{.Link.Line}}{{else}}{{.Link.FilePath}}:{{.Link.Line}}{{end}}</td>
+<td>{{details .}}{{template "hops" .}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Dead}}
+
+<h2>Dead Links ({{len .Dead}})</h2>
+<table>
+<tr><th>Status</th><th>URL</th><th>Text</th><th>Context</th><th>File</th><th>Line</th><th>Error</th></tr>
+{{- range .Dead}}
+<tr><td class="dead">{{status .}}</td><td class="url"><a href="{{.Link.URL}}">{{.Link.URL}}</a></td>
+<td>{{.Link.Text}}</td><td>{{with .Link.Context}}<code>{{.}}</code>{{end}}</td><td>{{.Link.FilePath}}</td><td>{{.Link.Line}}</td><td>{{.Error}}{{template "hops" .}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Warnings}}
+
+<h2>Warnings ({{len .Warnings}})</h2>
+<table>
+<tr><th>Issue</th><th>URL</th><th>Final URL</th><th>File</th><th>Line</th><th>Details</th></tr>
+{{- range .Warnings}}
+<tr><td class="warning">{{.Status.Label}}</td><td class="url"><a href="{{.Link.URL}}">{{.Link.URL}}</a></td>
+<td class="url">{{.FinalURL}}</td><td>{{.Link.FilePath}}</td><td>{{.Link.Line}}</td>
+<td>{{if .Anchor}}Missing anchor #{{.Anchor}}{{end}}
+{{- if .TLS}}Certificate {{.TLS.Problem}}: {{.TLS.Detail}}{{end}}{{template "hops" .}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Report.ParseErrors}}
+
+<h2>Parse Errors ({{len .Report.ParseErrors}})</h2>
+<table>
+<tr><th>File</th><th>Line</th><th>Error</th></tr>
+{{- range .Report.ParseErrors}}
+<tr><td>{{.File}}</td><td>{{.Line}}</td><td>{{.Error}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Report.Violations}}
+
+<h2>Policy Violations ({{len .Report.Violations}})</h2>
+<table>
+<tr><th>URL</th><th>File</th><th>Line</th><th>Reason</th><th>Rule</th></tr>
+{{- range .Report.Violations}}
+<tr><td class="url">{{.URL}}</td><td>{{.File}}</td><td>{{.Line}}</td>
+<td>{{.Reason}}</td><td><code>{{.Rule}}</code></td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Report.Internal}}
+
+<h2>Broken Internal Links ({{len .Report.Internal}})</h2>
+<table>
+<tr><th>Problem</th><th>Link</th><th>File</th><th>Line</th><th>Details</th></tr>
+{{- range .Report.Internal}}
+<tr><td class="dead">{{.Problem}}</td><td class="url">{{.URL}}</td><td>{{.File}}</td><td>{{.Line}}</td>
+<td>{{.Message}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+</body>
+</html>
+`))
+
+// Format implements Formatter.
+func (*HTMLFormatter) Format(report *Report) ([]byte, error) {
+	page := htmlPage{
+		Report:    report,
+		Groups:    GroupResults(report.Results, report.GroupBy),
+		ByFile:    report.GroupBy == GroupByFile,
+		DeadCount: report.Summary.Dead + report.Summary.Errors,
+	}
+	if page.Groups == nil {
+		page.Dead = filterByStatus(report.Results, checker.StatusDead, checker.StatusError, checker.StatusTLSError)
+		page.Warnings = filterByStatus(report.Results, checker.StatusRedirect, checker.StatusLongRedirect,
+			checker.StatusLoginRequired, checker.StatusBrokenAnchor, checker.StatusInsecure, checker.StatusSuspect,
+			checker.StatusBlocked)
+	}
+
+	var b bytes.Buffer
+	if err := htmlTemplate.Execute(&b, page); err != nil {
+		return nil, fmt.Errorf("rendering HTML report: %w", err)
+	}
+	return b.Bytes(), nil
+}
+
+// statusClassForHTML returns the CSS class of a result's status cell.
+func statusClassForHTML(r checker.Result) string {
+	switch r = r.Resolved(); {
+	case r.IsDead():
+		return "dead"
+	case r.IsWarning():
+		return "warning"
+	default:
+		return ""
+	}
+}
+
+// formatStatusForHTML formats a dead result's status code or label.
+func formatStatusForHTML(r checker.Result) string {
+	if r.Status == checker.StatusDead && r.StatusCode > 0 {
+		return fmt.Sprint(r.StatusCode)
+	}
+	return r.Status.Label()
+}
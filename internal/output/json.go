@@ -2,6 +2,8 @@ package output
 
 import (
 	"encoding/json"
+	"fmt"
+	"time"
 
 	"github.com/leonardomso/gone/internal/checker"
 )
@@ -11,42 +13,164 @@ type JSONFormatter struct{}
 
 // jsonOutput is the JSON structure for output.
 type jsonOutput struct {
-	GeneratedAt string        `json:"generated_at"`
-	Results     []jsonResult  `json:"results"`
-	Ignored     []jsonIgnored `json:"ignored,omitempty"`
-	Summary     jsonSummary   `json:"summary"`
-	TotalFiles  int           `json:"total_files"`
-	TotalLinks  int           `json:"total_links"`
-	UniqueURLs  int           `json:"unique_urls"`
+	SchemaVersion int              `json:"schema_version"`
+	GeneratedAt   string           `json:"generated_at"`
+	Results       []jsonResult     `json:"results"`
+	Ignored       []jsonIgnored    `json:"ignored,omitempty"`
+	Skipped       []jsonSkipped    `json:"skipped_files,omitempty"`
+	ParseErrors   []jsonParseError `json:"parse_errors,omitempty"`
+	Lint          []jsonLint       `json:"lint,omitempty"`
+	Violations    []jsonIgnored    `json:"policy_violations,omitempty"`
+	Internal      []jsonInternal   `json:"internal_links,omitempty"`
+	UniqueDead    []jsonUnique     `json:"unique_dead,omitempty"`
+	Fixed         []jsonResult     `json:"fixed,omitempty"`
+	Summary       jsonSummary      `json:"summary"`
+	TotalFiles    int              `json:"total_files"`
+	TotalLinks    int              `json:"total_links"`
+	UniqueURLs    int              `json:"unique_urls"`
 }
 
 type jsonSummary struct {
-	Alive      int `json:"alive"`
-	Redirects  int `json:"redirects"`
-	Blocked    int `json:"blocked"`
-	Dead       int `json:"dead"`
-	Errors     int `json:"errors"`
-	Duplicates int `json:"duplicates"`
-	Ignored    int `json:"ignored,omitempty"`
+	Alive         int   `json:"alive"`
+	Redirects     int   `json:"redirects"`
+	LongRedirects int   `json:"long_redirects,omitempty"`
+	LoginRequired int   `json:"login_required,omitempty"`
+	BrokenAnchors int   `json:"broken_anchors,omitempty"`
+	Insecure      int   `json:"insecure,omitempty"`
+	Suspect       int   `json:"suspect,omitempty"`
+	Blocked       int   `json:"blocked"`
+	Dead          int   `json:"dead"`
+	Errors        int   `json:"errors"`
+	TLSErrors     int   `json:"tls_errors,omitempty"`
+	Duplicates    int   `json:"duplicates"`
+	Skipped       int   `json:"skipped,omitempty"`
+	Retries       int   `json:"retries,omitempty"`
+	RetriedURLs   int   `json:"retried_urls,omitempty"`
+	BackoffMs     int64 `json:"backoff_ms,omitempty"`
+	NoIndex       int   `json:"noindex,omitempty"`
+	Stale         int   `json:"stale,omitempty"`
+	Ignored       int   `json:"ignored,omitempty"`
+	Violations    int   `json:"policy_violations,omitempty"`
+	Internal      int   `json:"internal_links,omitempty"`
+	ParseErrors   int   `json:"parse_errors,omitempty"`
+
+	ByParser   map[string]int `json:"by_parser,omitempty"`
+	ByLinkType map[string]int `json:"by_link_type,omitempty"`
 }
 
 type jsonResult struct {
 	URL           string         `json:"url"`
 	FilePath      string         `json:"file_path"`
 	Text          string         `json:"text,omitempty"`
+	Context       string         `json:"context,omitempty"`
+	Parser        string         `json:"parser,omitempty"`
+	LinkType      string         `json:"link_type,omitempty"`
 	Status        string         `json:"status"`
 	Error         string         `json:"error,omitempty"`
+	Protocol      string         `json:"protocol,omitempty"`
+	Resolver      string         `json:"resolver,omitempty"`
+	Profile       string         `json:"profile,omitempty"`
+	LastModified  string         `json:"last_modified,omitempty"`
 	FinalURL      string         `json:"final_url,omitempty"`
+	Anchor        string         `json:"anchor,omitempty"`
 	DuplicateOf   string         `json:"duplicate_of,omitempty"`
 	RedirectChain []jsonRedirect `json:"redirect_chain,omitempty"`
+	Suggestion    *jsonSuggest   `json:"suggestion,omitempty"`
+	TLS           *jsonTLS       `json:"tls,omitempty"`
+	Timing        *jsonTiming    `json:"timing,omitempty"`
+	Robots        []string       `json:"robots,omitempty"`
+	Hints         []string       `json:"hints,omitempty"`
 	Line          int            `json:"line,omitempty"`
+	Column        int            `json:"column,omitempty"`
+	Offset        int            `json:"offset,omitempty"`
 	StatusCode    int            `json:"status_code"`
 	FinalStatus   int            `json:"final_status,omitempty"`
+	ChainLength   int            `json:"chain_length,omitempty"`
+	AgeDays       int            `json:"age_days,omitempty"`
+	DurationMs    int64          `json:"duration_ms,omitempty"`
+	Retries       int            `json:"retries,omitempty"`
+	BackoffMs     int64          `json:"backoff_ms,omitempty"`
+	Stale         bool           `json:"stale,omitempty"`
+}
+
+type jsonTiming struct {
+	DNSMs     int64 `json:"dns_ms,omitempty"`
+	ConnectMs int64 `json:"connect_ms,omitempty"`
+	TLSMs     int64 `json:"tls_ms,omitempty"`
+	TTFBMs    int64 `json:"ttfb_ms,omitempty"`
+	TotalMs   int64 `json:"total_ms"`
+}
+
+// newJSONTiming converts a request timing, or returns nil if none was recorded.
+func newJSONTiming(t checker.Timing) *jsonTiming {
+	if t.IsZero() {
+		return nil
+	}
+	return &jsonTiming{
+		DNSMs:     t.DNS.Milliseconds(),
+		ConnectMs: t.Connect.Milliseconds(),
+		TLSMs:     t.TLS.Milliseconds(),
+		TTFBMs:    t.TTFB.Milliseconds(),
+		TotalMs:   t.Total.Milliseconds(),
+	}
+}
+
+type jsonTLS struct {
+	Host    string `json:"host"`
+	Problem string `json:"problem"`
+	Detail  string `json:"detail,omitempty"`
+}
+
+type jsonSuggest struct {
+	URL    string `json:"url"`
+	Source string `json:"source"`
 }
 
 type jsonRedirect struct {
 	URL        string `json:"url"`
 	StatusCode int    `json:"status_code"`
+	DurationMs int64  `json:"duration_ms,omitempty"`
+}
+
+type jsonUnique struct {
+	URL        string         `json:"url"`
+	Status     string         `json:"status"`
+	Error      string         `json:"error,omitempty"`
+	Locations  []jsonLocation `json:"locations"`
+	StatusCode int            `json:"status_code"`
+	Count      int            `json:"count"`
+}
+
+type jsonLocation struct {
+	FilePath string `json:"file_path"`
+	Line     int    `json:"line,omitempty"`
+}
+
+type jsonLint struct {
+	URL     string `json:"url"`
+	File    string `json:"file"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+	Line    int    `json:"line,omitempty"`
+}
+
+type jsonInternal struct {
+	URL     string `json:"url"`
+	File    string `json:"file"`
+	Problem string `json:"problem"`
+	Message string `json:"message"`
+	Line    int    `json:"line,omitempty"`
+}
+
+type jsonParseError struct {
+	File  string `json:"file"`
+	Error string `json:"error"`
+	Line  int    `json:"line,omitempty"`
+}
+
+type jsonSkipped struct {
+	File   string `json:"file"`
+	Reason string `json:"reason"`
 }
 
 type jsonIgnored struct {
@@ -60,52 +184,27 @@ type jsonIgnored struct {
 // Format implements Formatter.
 func (*JSONFormatter) Format(report *Report) ([]byte, error) {
 	output := jsonOutput{
-		GeneratedAt: report.GeneratedAt.Format("2006-01-02T15:04:05Z07:00"),
-		TotalFiles:  len(report.Files),
-		TotalLinks:  report.TotalLinks,
-		UniqueURLs:  report.UniqueURLs,
-		Summary: jsonSummary{
-			Alive:      report.Summary.Alive,
-			Redirects:  report.Summary.Redirects,
-			Blocked:    report.Summary.Blocked,
-			Dead:       report.Summary.Dead,
-			Errors:     report.Summary.Errors,
-			Duplicates: report.Summary.Duplicates,
-			Ignored:    len(report.Ignored),
-		},
-		Results: make([]jsonResult, 0, len(report.Results)),
+		SchemaVersion: SchemaVersion,
+		GeneratedAt:   report.GeneratedAt.Format("2006-01-02T15:04:05Z07:00"),
+		TotalFiles:    report.FilesScanned(),
+		TotalLinks:    report.TotalLinks,
+		UniqueURLs:    report.UniqueURLs,
+		Summary:       newJSONSummary(report),
+		Results:       make([]jsonResult, 0, len(report.Results)),
 	}
 
 	for _, r := range report.Results {
-		jr := jsonResult{
-			URL:        r.Link.URL,
-			FilePath:   r.Link.FilePath,
-			Line:       r.Link.Line,
-			Text:       r.Link.Text,
-			StatusCode: r.StatusCode,
-			Status:     r.Status.String(),
-			Error:      r.Error,
-		}
-
-		// Add redirect chain if present
-		if len(r.RedirectChain) > 0 {
-			jr.RedirectChain = make([]jsonRedirect, len(r.RedirectChain))
-			for i, red := range r.RedirectChain {
-				jr.RedirectChain[i] = jsonRedirect{
-					URL:        red.URL,
-					StatusCode: red.StatusCode,
-				}
-			}
-			jr.FinalURL = r.FinalURL
-			jr.FinalStatus = r.FinalStatus
-		}
+		output.Results = append(output.Results, newJSONResult(r))
+	}
 
-		// Add duplicate reference if present
-		if r.DuplicateOf != nil {
-			jr.DuplicateOf = r.DuplicateOf.Link.URL
-		}
+	// Add unique dead URLs if requested
+	for _, u := range report.UniqueDead {
+		output.UniqueDead = append(output.UniqueDead, newJSONUnique(u))
+	}
 
-		output.Results = append(output.Results, jr)
+	// Add links fixed since the baseline if present
+	for _, r := range report.Fixed {
+		output.Fixed = append(output.Fixed, newJSONResult(r))
 	}
 
 	// Add ignored URLs if present
@@ -113,9 +212,332 @@ func (*JSONFormatter) Format(report *Report) ([]byte, error) {
 		output.Ignored = append(output.Ignored, jsonIgnored(ig))
 	}
 
+	// Add files skipped for exceeding the parse budget
+	for _, sk := range report.Skipped {
+		output.Skipped = append(output.Skipped, jsonSkipped(sk))
+	}
+
+	// Add files that failed to parse in strict mode
+	for _, pe := range report.ParseErrors {
+		output.ParseErrors = append(output.ParseErrors, jsonParseError(pe))
+	}
+
+	// Add lint findings if present
+	for _, lf := range report.Lint {
+		output.Lint = append(output.Lint, jsonLint(lf))
+	}
+
+	// Add policy violations if present
+	for _, v := range report.Violations {
+		output.Violations = append(output.Violations, jsonIgnored(v))
+	}
+
+	// Add broken internal links if present
+	for _, il := range report.Internal {
+		output.Internal = append(output.Internal, jsonInternal(il))
+	}
+
 	return json.MarshalIndent(output, "", "  ")
 }
 
+// newJSONSummary converts a report's summary.
+func newJSONSummary(report *Report) jsonSummary {
+	return jsonSummary{
+		Alive:         report.Summary.Alive,
+		Redirects:     report.Summary.Redirects,
+		LongRedirects: report.Summary.LongRedirects,
+		LoginRequired: report.Summary.LoginRequired,
+		BrokenAnchors: report.Summary.BrokenAnchors,
+		Insecure:      report.Summary.Insecure,
+		Suspect:       report.Summary.Suspect,
+		Blocked:       report.Summary.Blocked,
+		Dead:          report.Summary.Dead,
+		Errors:        report.Summary.Errors,
+		TLSErrors:     report.Summary.TLSErrors,
+		Duplicates:    report.Summary.Duplicates,
+		Skipped:       report.Summary.Skipped,
+		Retries:       report.Summary.Retries,
+		RetriedURLs:   report.Summary.RetriedURLs,
+		BackoffMs:     report.Summary.Backoff.Milliseconds(),
+		NoIndex:       report.Summary.NoIndex,
+		Stale:         report.Summary.Stale,
+		Ignored:       len(report.Ignored),
+		Violations:    len(report.Violations),
+		Internal:      len(report.Internal),
+		ParseErrors:   len(report.ParseErrors),
+		ByParser:      report.Summary.ByParser,
+		ByLinkType:    report.Summary.ByLinkType,
+	}
+}
+
+// newJSONResult converts a check result.
+func newJSONResult(r checker.Result) jsonResult {
+	jr := jsonResult{
+		URL:          r.Link.URL,
+		FilePath:     r.Link.FilePath,
+		Line:         r.Link.Line,
+		Column:       r.Link.Column,
+		Offset:       r.Link.Offset,
+		Text:         r.Link.Text,
+		Context:      deadContext(r),
+		Parser:       r.Link.Parser,
+		LinkType:     r.Link.Type,
+		StatusCode:   r.StatusCode,
+		Status:       r.Status.String(),
+		Error:        r.Error,
+		Protocol:     r.Protocol,
+		Resolver:     r.Resolver,
+		Profile:      r.Profile,
+		Anchor:       r.Anchor,
+		LastModified: formatLastModified(r),
+		AgeDays:      ageDays(r),
+		Stale:        r.Stale,
+		DurationMs:   r.Duration.Milliseconds(),
+		Retries:      retries(r),
+		BackoffMs:    r.Backoff.Milliseconds(),
+		Timing:       newJSONTiming(r.Timing),
+		Robots:       r.Robots,
+		Hints:        r.Hints,
+	}
+
+	// Add redirect chain if present
+	if len(r.RedirectChain) > 0 {
+		jr.RedirectChain = make([]jsonRedirect, len(r.RedirectChain))
+		for i, red := range r.RedirectChain {
+			jr.RedirectChain[i] = jsonRedirect{
+				URL:        red.URL,
+				StatusCode: red.StatusCode,
+				DurationMs: red.Duration.Milliseconds(),
+			}
+		}
+		jr.FinalURL = r.FinalURL
+		jr.FinalStatus = r.FinalStatus
+		jr.ChainLength = r.ChainLength()
+	}
+
+	// Add duplicate reference if present
+	if r.DuplicateOf != nil {
+		jr.DuplicateOf = r.DuplicateOf.Link.URL
+	}
+
+	// Add fix suggestion if present
+	if r.Suggestion != nil {
+		jr.Suggestion = &jsonSuggest{URL: r.Suggestion.URL, Source: r.Suggestion.Source}
+	}
+
+	// Add certificate problem if present
+	if r.TLS != nil {
+		jr.TLS = &jsonTLS{Host: r.TLS.Host, Problem: r.TLS.Problem, Detail: r.TLS.Detail}
+	}
+
+	return jr
+}
+
+// newJSONUnique converts a dead URL and its locations.
+func newJSONUnique(u checker.UniqueURL) jsonUnique {
+	ju := jsonUnique{
+		URL:        u.Result.Link.URL,
+		Status:     u.Result.Status.String(),
+		Error:      u.Result.Error,
+		StatusCode: u.Result.StatusCode,
+		Count:      u.Count(),
+		Locations:  make([]jsonLocation, len(u.Locations)),
+	}
+	for i, l := range u.Locations {
+		ju.Locations[i] = jsonLocation{FilePath: l.FilePath, Line: l.Line}
+	}
+	return ju
+}
+
+// ReadJSONLinks returns the links listed in a JSON report written by JSONFormatter.
+// Every occurrence is returned, including duplicates.
+func ReadJSONLinks(data []byte) ([]checker.Link, error) {
+	var report jsonOutput
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("parsing JSON report: %w", err)
+	}
+
+	links := make([]checker.Link, 0, len(report.Results))
+	for _, r := range report.Results {
+		if r.URL == "" {
+			continue
+		}
+		links = append(links, checker.Link{
+			URL:      r.URL,
+			FilePath: r.FilePath,
+			Text:     r.Text,
+			Parser:   r.Parser,
+			Type:     r.LinkType,
+			Line:     r.Line,
+			Column:   r.Column,
+			Offset:   r.Offset,
+		})
+	}
+	return links, nil
+}
+
+// ReadJSONReport reads a JSON report written by JSONFormatter back into a Report.
+// File paths and performance statistics aren't part of the JSON output, so
+// FileCount is set instead of Files, and Stats is left empty. Duplicates
+// point at a primary result that only carries the URL.
+func ReadJSONReport(data []byte) (*Report, error) {
+	var in jsonOutput
+	if err := json.Unmarshal(data, &in); err != nil {
+		return nil, fmt.Errorf("parsing JSON report: %w", err)
+	}
+	// Reports written before schema_version was added have none
+	if in.SchemaVersion > SchemaVersion {
+		return nil, fmt.Errorf("JSON report has schema version %d, but this version of gone reads up to %d; "+
+			"upgrade gone", in.SchemaVersion, SchemaVersion)
+	}
+
+	report := &Report{
+		FileCount:  in.TotalFiles,
+		TotalLinks: in.TotalLinks,
+		UniqueURLs: in.UniqueURLs,
+		Summary: checker.Summary{
+			Total:         in.TotalLinks,
+			UniqueURLs:    in.UniqueURLs,
+			Alive:         in.Summary.Alive,
+			Redirects:     in.Summary.Redirects,
+			LongRedirects: in.Summary.LongRedirects,
+			LoginRequired: in.Summary.LoginRequired,
+			BrokenAnchors: in.Summary.BrokenAnchors,
+			Insecure:      in.Summary.Insecure,
+			Suspect:       in.Summary.Suspect,
+			Blocked:       in.Summary.Blocked,
+			Dead:          in.Summary.Dead,
+			Errors:        in.Summary.Errors,
+			TLSErrors:     in.Summary.TLSErrors,
+			Duplicates:    in.Summary.Duplicates,
+			Skipped:       in.Summary.Skipped,
+			Retries:       in.Summary.Retries,
+			RetriedURLs:   in.Summary.RetriedURLs,
+			Backoff:       time.Duration(in.Summary.BackoffMs) * time.Millisecond,
+			NoIndex:       in.Summary.NoIndex,
+			Stale:         in.Summary.Stale,
+			ByParser:      in.Summary.ByParser,
+			ByLinkType:    in.Summary.ByLinkType,
+		},
+		Results: make([]checker.Result, 0, len(in.Results)),
+	}
+	if generated, err := time.Parse(time.RFC3339, in.GeneratedAt); err == nil {
+		report.GeneratedAt = generated
+	}
+
+	for _, jr := range in.Results {
+		r, err := jr.result()
+		if err != nil {
+			return nil, err
+		}
+		report.Results = append(report.Results, r)
+	}
+
+	for _, ju := range in.UniqueDead {
+		status, _ := checker.ParseLinkStatus(ju.Status)
+		u := checker.UniqueURL{Result: checker.Result{
+			Link:       checker.Link{URL: ju.URL},
+			Status:     status,
+			StatusCode: ju.StatusCode,
+			Error:      ju.Error,
+		}}
+		for _, l := range ju.Locations {
+			u.Locations = append(u.Locations, checker.Link{URL: ju.URL, FilePath: l.FilePath, Line: l.Line})
+		}
+		report.UniqueDead = append(report.UniqueDead, u)
+	}
+
+	for _, ig := range in.Ignored {
+		report.Ignored = append(report.Ignored, IgnoredURL(ig))
+	}
+	for _, sk := range in.Skipped {
+		report.Skipped = append(report.Skipped, SkippedFile(sk))
+	}
+	for _, pe := range in.ParseErrors {
+		report.ParseErrors = append(report.ParseErrors, ParseError(pe))
+	}
+	for _, lf := range in.Lint {
+		report.Lint = append(report.Lint, LintFinding(lf))
+	}
+	for _, v := range in.Violations {
+		report.Violations = append(report.Violations, PolicyViolation(v))
+	}
+	for _, il := range in.Internal {
+		report.Internal = append(report.Internal, InternalLink(il))
+	}
+
+	return report, nil
+}
+
+// result converts a JSON result back into a checker.Result.
+func (jr jsonResult) result() (checker.Result, error) {
+	status, ok := checker.ParseLinkStatus(jr.Status)
+	if !ok {
+		return checker.Result{}, fmt.Errorf("parsing JSON report: unknown status %q for %s", jr.Status, jr.URL)
+	}
+
+	r := checker.Result{
+		Link: checker.Link{
+			URL:      jr.URL,
+			FilePath: jr.FilePath,
+			Text:     jr.Text,
+			Parser:   jr.Parser,
+			Type:     jr.LinkType,
+			Line:     jr.Line,
+			Column:   jr.Column,
+			Offset:   jr.Offset,
+			Context:  jr.Context,
+		},
+		Status:      status,
+		StatusCode:  jr.StatusCode,
+		Error:       jr.Error,
+		Protocol:    jr.Protocol,
+		Resolver:    jr.Resolver,
+		Profile:     jr.Profile,
+		FinalURL:    jr.FinalURL,
+		FinalStatus: jr.FinalStatus,
+		Anchor:      jr.Anchor,
+		Duration:    time.Duration(jr.DurationMs) * time.Millisecond,
+		Backoff:     time.Duration(jr.BackoffMs) * time.Millisecond,
+		Robots:      jr.Robots,
+		Hints:       jr.Hints,
+		Age:         time.Duration(jr.AgeDays) * 24 * time.Hour,
+		Stale:       jr.Stale,
+	}
+	if jr.Retries > 0 {
+		r.Attempts = jr.Retries + 1
+	}
+	if lastModified, err := time.Parse(time.RFC3339, jr.LastModified); err == nil {
+		r.LastModified = lastModified
+	}
+	for _, red := range jr.RedirectChain {
+		r.RedirectChain = append(r.RedirectChain, checker.Redirect{
+			URL:        red.URL,
+			StatusCode: red.StatusCode,
+			Duration:   time.Duration(red.DurationMs) * time.Millisecond,
+		})
+	}
+	if jr.DuplicateOf != "" {
+		r.DuplicateOf = &checker.Result{Link: checker.Link{URL: jr.DuplicateOf}}
+	}
+	if jr.Suggestion != nil {
+		r.Suggestion = &checker.Suggestion{URL: jr.Suggestion.URL, Source: jr.Suggestion.Source}
+	}
+	if jr.TLS != nil {
+		r.TLS = &checker.TLSInfo{Host: jr.TLS.Host, Problem: jr.TLS.Problem, Detail: jr.TLS.Detail}
+	}
+	if jr.Timing != nil {
+		r.Timing = checker.Timing{
+			DNS:     time.Duration(jr.Timing.DNSMs) * time.Millisecond,
+			Connect: time.Duration(jr.Timing.ConnectMs) * time.Millisecond,
+			TLS:     time.Duration(jr.Timing.TLSMs) * time.Millisecond,
+			TTFB:    time.Duration(jr.Timing.TTFBMs) * time.Millisecond,
+			Total:   time.Duration(jr.Timing.TotalMs) * time.Millisecond,
+		}
+	}
+	return r, nil
+}
+
 // filterResults returns results based on status.
 func filterByStatus(results []checker.Result, statuses ...checker.LinkStatus) []checker.Result {
 	statusSet := map[checker.LinkStatus]bool{}
@@ -3,37 +3,60 @@ package output
 import (
 	"encoding/xml"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/leonardomso/gone/internal/checker"
 )
 
 // JUnitFormatter formats reports as JUnit XML for CI/CD integration.
-// Only failed/error links are included as test cases.
+// Only failed/error links are included as test cases, unless the report
+// sets JUnitIncludePassed.
 type JUnitFormatter struct{}
 
 // junitTestSuites is the root element for JUnit XML.
 type junitTestSuites struct {
-	XMLName   xml.Name         `xml:"testsuites"`
-	Name      string           `xml:"name,attr"`
-	TestSuite []junitTestSuite `xml:"testsuite"`
-	Tests     int              `xml:"tests,attr"`
-	Failures  int              `xml:"failures,attr"`
-	Errors    int              `xml:"errors,attr"`
+	Properties *junitProperties `xml:"properties,omitempty"`
+	XMLName    xml.Name         `xml:"testsuites"`
+	Name       string           `xml:"name,attr"`
+	Time       string           `xml:"time,attr"`
+	TestSuite  []junitTestSuite `xml:"testsuite"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	Errors     int              `xml:"errors,attr"`
+	Skipped    int              `xml:"skipped,attr,omitempty"`
+}
+
+// junitProperties carries the link breakdown by parser and link type.
+type junitProperties struct {
+	Properties []junitProperty `xml:"property"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
 }
 
 type junitTestSuite struct {
 	Name      string          `xml:"name,attr"`
+	Time      string          `xml:"time,attr"`
 	TestCases []junitTestCase `xml:"testcase"`
 	Tests     int             `xml:"tests,attr"`
 	Failures  int             `xml:"failures,attr"`
 	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr,omitempty"`
+	elapsed   time.Duration
 }
 
 type junitTestCase struct {
 	Failure   *junitFailure `xml:"failure,omitempty"`
 	Error     *junitError   `xml:"error,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
 	Name      string        `xml:"name,attr"`
 	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
 }
 
 type junitFailure struct {
@@ -48,81 +71,60 @@ type junitError struct {
 	Content string `xml:",chardata"`
 }
 
+type junitSkipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
 // Format implements Formatter.
 func (*JUnitFormatter) Format(report *Report) ([]byte, error) {
-	// Group results by file
-	fileResults := map[string][]checker.Result{}
-	for _, r := range report.Results {
-		// Only include dead and error results
-		if r.Status == checker.StatusDead || r.Status == checker.StatusError {
-			fileResults[r.Link.FilePath] = append(fileResults[r.Link.FilePath], r)
-		}
-	}
-
-	// Count totals
-	totalTests := 0
-	totalFailures := 0
-	totalErrors := 0
-
-	for _, results := range fileResults {
-		for _, r := range results {
-			totalTests++
-			if r.Status == checker.StatusError {
-				totalErrors++
-			} else {
-				totalFailures++
-			}
-		}
-	}
-
-	// Build test suites
 	suites := junitTestSuites{
-		Name:     "gone-link-check",
-		Tests:    totalTests,
-		Failures: totalFailures,
-		Errors:   totalErrors,
+		Name:       "gone-link-check",
+		Properties: buildBreakdownProperties(report.Summary),
 	}
 
-	for file, results := range fileResults {
-		suite := junitTestSuite{
-			Name: file,
+	// One test suite per file or domain, one test case per location
+	index := map[string]int{}
+	for _, r := range report.Results {
+		// A duplicate took no time of its own to check
+		elapsed := r.Duration
+		r = r.Resolved()
+		if !r.IsDead() && !report.JUnitIncludePassed {
+			continue
 		}
-
-		for _, r := range results {
-			suite.Tests++
-
-			tc := junitTestCase{
-				Name:      r.Link.URL,
-				ClassName: fmt.Sprintf("%s:%d", r.Link.FilePath, r.Link.Line),
-			}
-
-			if r.Status == checker.StatusError {
-				suite.Errors++
-				tc.Error = &junitError{
-					Message: truncateForXML(r.Error, 200),
-					Type:    "error",
-					Content: buildErrorContent(r),
-				}
-			} else {
-				suite.Failures++
-				tc.Failure = &junitFailure{
-					Message: buildFailureMessage(r),
-					Type:    "dead",
-					Content: buildFailureContent(r),
-				}
-			}
-
-			suite.TestCases = append(suite.TestCases, tc)
+		name := r.Link.FilePath
+		if report.JUnitSuitePer == GroupByDomain {
+			name = domainOf(r.Link.URL)
 		}
-
-		suites.TestSuite = append(suites.TestSuite, suite)
+		i, ok := index[name]
+		if !ok {
+			i = len(suites.TestSuite)
+			index[name] = i
+			suites.TestSuite = append(suites.TestSuite, junitTestSuite{Name: name})
+		}
+		suites.TestSuite[i].add(r, elapsed)
 	}
+	slices.SortFunc(suites.TestSuite, func(a, b junitTestSuite) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	var elapsed time.Duration
+	for i := range suites.TestSuite {
+		suite := &suites.TestSuite[i]
+		suite.Time = junitTime(suite.elapsed)
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Errors += suite.Errors
+		suites.Skipped += suite.Skipped
+		elapsed += suite.elapsed
+	}
+	suites.Time = junitTime(elapsed)
 
 	// If no failures/errors, create an empty test suite to indicate success
 	if len(suites.TestSuite) == 0 {
 		suites.TestSuite = append(suites.TestSuite, junitTestSuite{
 			Name:  "all-links",
 			Tests: 0,
+			Time:  junitTime(0),
 		})
 	}
 
@@ -134,6 +136,62 @@ func (*JUnitFormatter) Format(report *Report) ([]byte, error) {
 	return append([]byte(xml.Header), data...), nil
 }
 
+// add adds a test case for a result that took elapsed to check: a failure
+// for a dead link, an error for a link that couldn't be checked, skipped for
+// a skipped link, and passing otherwise.
+func (s *junitTestSuite) add(r checker.Result, elapsed time.Duration) {
+	tc := junitTestCase{
+		Name:      r.Link.URL,
+		ClassName: fmt.Sprintf("%s:%d", r.Link.FilePath, r.Link.Line),
+		Time:      junitTime(elapsed),
+	}
+
+	switch {
+	case r.Status == checker.StatusDead:
+		s.Failures++
+		tc.Failure = &junitFailure{
+			Message: buildFailureMessage(r),
+			Type:    "dead",
+			Content: buildFailureContent(r),
+		}
+	case r.IsDead():
+		s.Errors++
+		tc.Error = &junitError{
+			Message: truncateForXML(r.Error, 200),
+			Type:    r.Status.String(),
+			Content: buildErrorContent(r),
+		}
+	case r.Status == checker.StatusSkipped:
+		s.Skipped++
+		tc.Skipped = &junitSkipped{Message: r.Error}
+	}
+
+	s.Tests++
+	s.elapsed += elapsed
+	s.TestCases = append(s.TestCases, tc)
+}
+
+// junitTime formats a duration in seconds, as JUnit's time attributes are.
+func junitTime(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', 3, 64)
+}
+
+// buildBreakdownProperties converts the summary breakdowns into JUnit properties
+// named "parser.<type>" and "link_type.<type>". Returns nil if there are none.
+func buildBreakdownProperties(s checker.Summary) *junitProperties {
+	var props []junitProperty
+	for _, name := range sortedKeys(s.ByParser) {
+		props = append(props, junitProperty{Name: "parser." + name, Value: strconv.Itoa(s.ByParser[name])})
+	}
+	for _, name := range sortedKeys(s.ByLinkType) {
+		props = append(props, junitProperty{Name: "link_type." + name, Value: strconv.Itoa(s.ByLinkType[name])})
+	}
+	if len(props) == 0 {
+		return nil
+	}
+	return &junitProperties{Properties: props}
+}
+
 // buildFailureMessage creates a short failure message.
 func buildFailureMessage(r checker.Result) string {
 	if r.StatusCode > 0 {
@@ -148,6 +206,9 @@ func buildFailureContent(r checker.Result) string {
 	if r.Link.Text != "" {
 		content += fmt.Sprintf("Link text: %q\n", truncateForXML(r.Link.Text, 100))
 	}
+	if r.Link.Context != "" {
+		content += fmt.Sprintf("Context: %s\n", r.Link.Context)
+	}
 	if r.StatusCode > 0 {
 		content += fmt.Sprintf("Status: %d\n", r.StatusCode)
 	}
@@ -155,6 +216,9 @@ func buildFailureContent(r checker.Result) string {
 		content += fmt.Sprintf("Final URL: %s\n", r.FinalURL)
 		content += fmt.Sprintf("Final Status: %d\n", r.FinalStatus)
 	}
+	if r.Suggestion != nil {
+		content += fmt.Sprintf("Suggested fix: %s (%s)\n", r.Suggestion.URL, r.Suggestion.Source)
+	}
 	return content
 }
 
@@ -164,7 +228,16 @@ func buildErrorContent(r checker.Result) string {
 	if r.Link.Text != "" {
 		content += fmt.Sprintf("Link text: %q\n", truncateForXML(r.Link.Text, 100))
 	}
+	if r.Link.Context != "" {
+		content += fmt.Sprintf("Context: %s\n", r.Link.Context)
+	}
 	content += fmt.Sprintf("Error: %s\n", r.Error)
+	if r.TLS != nil {
+		content += fmt.Sprintf("Certificate: %s for %s\n", r.TLS.Problem, r.TLS.Host)
+	}
+	if r.Suggestion != nil {
+		content += fmt.Sprintf("Suggested fix: %s (%s)\n", r.Suggestion.URL, r.Suggestion.Source)
+	}
 	return content
 }
 
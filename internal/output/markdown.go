@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/leonardomso/gone/internal/checker"
+	"github.com/leonardomso/gone/internal/stats"
 )
 
 // MarkdownFormatter formats reports as Markdown.
@@ -17,10 +18,26 @@ func (m *MarkdownFormatter) Format(report *Report) ([]byte, error) {
 
 	m.writeHeader(&b, report)
 	m.writeSummaryTable(&b, report)
-	m.writeDeadLinksSection(&b, report.Results)
-	m.writeWarningsSection(&b, report.Results)
-	m.writeDuplicatesSection(&b, report.Results)
+	m.writeBreakdownSection(&b, report.Summary)
+	m.writeUniqueDeadSection(&b, report.UniqueDead)
+	m.writeDomainsSection(&b, report.Domains)
+	m.writeParseErrorsSection(&b, report.ParseErrors)
+	m.writeViolationsSection(&b, report.Violations)
+	m.writeInternalSection(&b, report.Internal)
+	if groups := GroupResults(report.Results, report.GroupBy); groups != nil {
+		m.writeGroupedSections(&b, groups, report.GroupBy)
+		m.writeFixedSection(&b, report.Fixed)
+	} else {
+		m.writeDeadLinksSection(&b, report.Results)
+		m.writeFixedSection(&b, report.Fixed)
+		m.writeWarningsSection(&b, report.Results)
+		m.writeHintsSection(&b, report.Results)
+		m.writeDuplicatesSection(&b, report.Results)
+	}
+	m.writeLintSection(&b, report.Lint)
 	m.writeIgnoredSection(&b, report.Ignored)
+	m.writeSkippedSection(&b, report.Skipped)
+	m.writeInventorySection(&b, report.Inventory)
 
 	return []byte(b.String()), nil
 }
@@ -29,7 +46,7 @@ func (m *MarkdownFormatter) Format(report *Report) ([]byte, error) {
 func (*MarkdownFormatter) writeHeader(b *strings.Builder, report *Report) {
 	b.WriteString("# Gone Link Check Report\n\n")
 	fmt.Fprintf(b, "**Generated:** %s  \n", report.GeneratedAt.Format("2006-01-02 15:04:05"))
-	fmt.Fprintf(b, "**Files Scanned:** %d  \n", len(report.Files))
+	fmt.Fprintf(b, "**Files Scanned:** %d  \n", report.FilesScanned())
 	fmt.Fprintf(b, "**Total Links:** %d  \n", report.TotalLinks)
 	fmt.Fprintf(b, "**Unique URLs:** %d\n\n", report.UniqueURLs)
 }
@@ -41,17 +58,133 @@ func (*MarkdownFormatter) writeSummaryTable(b *strings.Builder, report *Report)
 	b.WriteString("|--------|-------|\n")
 	fmt.Fprintf(b, "| Alive | %d |\n", report.Summary.Alive)
 	fmt.Fprintf(b, "| Warnings | %d |\n", report.Summary.WarningsCount())
+	if report.Summary.LongRedirects > 0 {
+		fmt.Fprintf(b, "| Long Redirects | %d |\n", report.Summary.LongRedirects)
+	}
+	if report.Summary.LoginRequired > 0 {
+		fmt.Fprintf(b, "| Login Required | %d |\n", report.Summary.LoginRequired)
+	}
+	if report.Summary.BrokenAnchors > 0 {
+		fmt.Fprintf(b, "| Broken Anchors | %d |\n", report.Summary.BrokenAnchors)
+	}
+	if report.Summary.Insecure > 0 {
+		fmt.Fprintf(b, "| Insecure | %d |\n", report.Summary.Insecure)
+	}
+	if report.Summary.Suspect > 0 {
+		fmt.Fprintf(b, "| Suspect | %d |\n", report.Summary.Suspect)
+	}
 	fmt.Fprintf(b, "| Dead | %d |\n", report.Summary.Dead+report.Summary.Errors)
+	if report.Summary.TLSErrors > 0 {
+		fmt.Fprintf(b, "| TLS Errors | %d |\n", report.Summary.TLSErrors)
+	}
 	fmt.Fprintf(b, "| Duplicates | %d |\n", report.Summary.Duplicates)
+	if report.Summary.Skipped > 0 {
+		fmt.Fprintf(b, "| Skipped | %d |\n", report.Summary.Skipped)
+	}
+	if report.Summary.Retries > 0 {
+		fmt.Fprintf(b, "| Retries | %d (%d URLs) |\n", report.Summary.Retries, report.Summary.RetriedURLs)
+	}
+	if report.Summary.NoIndex > 0 {
+		fmt.Fprintf(b, "| Noindex | %d |\n", report.Summary.NoIndex)
+	}
+	if report.Summary.Stale > 0 {
+		fmt.Fprintf(b, "| Stale | %d |\n", report.Summary.Stale)
+	}
 	if len(report.Ignored) > 0 {
 		fmt.Fprintf(b, "| Ignored | %d |\n", len(report.Ignored))
 	}
+	if len(report.Violations) > 0 {
+		fmt.Fprintf(b, "| Policy Violations | %d |\n", len(report.Violations))
+	}
+	if len(report.Internal) > 0 {
+		fmt.Fprintf(b, "| Broken Internal Links | %d |\n", len(report.Internal))
+	}
+	if len(report.ParseErrors) > 0 {
+		fmt.Fprintf(b, "| Parse Errors | %d |\n", len(report.ParseErrors))
+	}
+	b.WriteString("\n")
+}
+
+// writeBreakdownSection writes link counts by parser and link type, if known.
+func (*MarkdownFormatter) writeBreakdownSection(b *strings.Builder, s checker.Summary) {
+	if len(s.ByParser) == 0 && len(s.ByLinkType) == 0 {
+		return
+	}
+
+	b.WriteString("## Link Sources\n\n")
+	writeCountTable(b, "Parser", s.ByParser)
+	writeCountTable(b, "Link Type", s.ByLinkType)
+}
+
+// writeCountTable writes a two-column count table sorted by name.
+func writeCountTable(b *strings.Builder, title string, counts map[string]int) {
+	if len(counts) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "| %s | Count |\n", title)
+	fmt.Fprintf(b, "|%s|-------|\n", strings.Repeat("-", len(title)+2))
+	for _, name := range sortedKeys(counts) {
+		fmt.Fprintf(b, "| %s | %d |\n", name, counts[name])
+	}
+	b.WriteString("\n")
+}
+
+// maxMarkdownLocations caps the locations listed per unique dead URL.
+const maxMarkdownLocations = 5
+
+// writeUniqueDeadSection lists each dead URL once with its locations, if requested.
+func (*MarkdownFormatter) writeUniqueDeadSection(b *strings.Builder, unique []checker.UniqueURL) {
+	if len(unique) == 0 {
+		return
+	}
+
+	fmt.Fprintf(b, "## Unique Dead URLs (%d)\n\n", len(unique))
+	b.WriteString("| Status | URL | Occurrences | Locations |\n")
+	b.WriteString("|--------|-----|-------------|-----------|\n")
+	for _, u := range unique {
+		locations := make([]string, 0, maxMarkdownLocations+1)
+		for i, l := range u.Locations {
+			if i == maxMarkdownLocations {
+				locations = append(locations, fmt.Sprintf("and %d more", u.Count()-i))
+				break
+			}
+			locations = append(locations, fmt.Sprintf("`%s:%d`", l.FilePath, l.Line))
+		}
+		fmt.Fprintf(b, "| %s | %s | %d | %s |\n",
+			formatStatusForMarkdown(u.Result), escapeMarkdown(truncateText(u.Result.Link.URL, 60)),
+			u.Count(), strings.Join(locations, ", "))
+	}
 	b.WriteString("\n")
 }
 
+// writeGroupedSections writes a section per file or domain (--group-by),
+// listing its results whatever their status.
+func (*MarkdownFormatter) writeGroupedSections(b *strings.Builder, groups []ResultGroup, by GroupBy) {
+	for _, g := range groups {
+		fmt.Fprintf(b, "## %s\n\n", escapeMarkdown(g.Title()))
+		if by == GroupByFile {
+			b.WriteString("| Status | URL | Text | Line | Details |\n")
+			b.WriteString("|--------|-----|------|------|---------|\n")
+		} else {
+			b.WriteString("| Status | URL | Text | File | Details |\n")
+			b.WriteString("|--------|-----|------|------|---------|\n")
+		}
+		for _, r := range g.Results {
+			where := fmt.Sprintf("`%s:%d`", r.Link.FilePath, r.Link.Line)
+			if by == GroupByFile {
+				where = fmt.Sprint(r.Link.Line)
+			}
+			fmt.Fprintf(b, "| %s | %s | %s | %s | %s |\n",
+				formatStatusForMarkdown(r), escapeMarkdown(truncateText(r.Link.URL, 60)),
+				escapeMarkdown(truncateText(r.Link.Text, 40)), where, escapeMarkdown(resultDetails(r)))
+		}
+		b.WriteString("\n")
+	}
+}
+
 // writeDeadLinksSection writes the dead links section if any exist.
 func (m *MarkdownFormatter) writeDeadLinksSection(b *strings.Builder, results []checker.Result) {
-	deadLinks := filterByStatus(results, checker.StatusDead, checker.StatusError)
+	deadLinks := filterByStatus(results, checker.StatusDead, checker.StatusError, checker.StatusTLSError)
 	if len(deadLinks) == 0 {
 		return
 	}
@@ -84,11 +217,20 @@ func (*MarkdownFormatter) writeDeadLinksDetails(b *strings.Builder, deadLinks []
 			fmt.Fprintf(b, "- **Text:** %q\n", r.Link.Text)
 		}
 		fmt.Fprintf(b, "- **File:** `%s:%d`\n", r.Link.FilePath, r.Link.Line)
+		if r.Link.Context != "" {
+			fmt.Fprintf(b, "- **Context:** %s\n", codeSpan(r.Link.Context))
+		}
 		fmt.Fprintf(b, "- **Status:** %s\n", formatStatusForMarkdown(r))
 		writeRedirectChain(b, r)
 		if r.Error != "" {
 			fmt.Fprintf(b, "- **Error:** %s\n", r.Error)
 		}
+		if r.TLS != nil {
+			fmt.Fprintf(b, "- **Certificate:** %s for %s\n", r.TLS.Problem, r.TLS.Host)
+		}
+		if r.Suggestion != nil {
+			fmt.Fprintf(b, "- **Suggested fix:** %s (%s)\n", r.Suggestion.URL, r.Suggestion.Source)
+		}
 		b.WriteString("\n")
 	}
 }
@@ -98,16 +240,37 @@ func writeRedirectChain(b *strings.Builder, r checker.Result) {
 	if len(r.RedirectChain) == 0 {
 		return
 	}
-	b.WriteString("- **Redirect Chain:**\n")
+	fmt.Fprintf(b, "- **Redirect Chain:** %d hop(s)\n", r.ChainLength())
+	writeHops(b, r, "  ")
+}
+
+// writeHops writes a redirect chain hop by hop as a numbered list at indent:
+// each hop's status code, URL, and latency, then the final destination.
+func writeHops(b *strings.Builder, r checker.Result, indent string) {
 	for i, red := range r.RedirectChain {
-		fmt.Fprintf(b, "  %d. `%d` → %s\n", i+1, red.StatusCode, red.URL)
+		fmt.Fprintf(b, "%s%d. `%d` %s%s\n", indent, i+1, red.StatusCode, red.URL, hopLatency(red))
+	}
+	if r.FinalStatus > 0 {
+		fmt.Fprintf(b, "%s%d. Final: `%d` %s\n", indent, len(r.RedirectChain)+1, r.FinalStatus, r.FinalURL)
+	} else {
+		fmt.Fprintf(b, "%s%d. Final: %s\n", indent, len(r.RedirectChain)+1, r.FinalURL)
 	}
-	fmt.Fprintf(b, "  Final: `%d` → %s\n", r.FinalStatus, r.FinalURL)
+}
+
+// hopLatency formats how long a redirect hop took as " (120ms)", or "" if
+// unknown, as for cached results.
+func hopLatency(red checker.Redirect) string {
+	if red.Duration == 0 {
+		return ""
+	}
+	return " (" + stats.FormatDuration(red.Duration) + ")"
 }
 
 // writeWarningsSection writes the warnings section if any exist.
 func (m *MarkdownFormatter) writeWarningsSection(b *strings.Builder, results []checker.Result) {
-	warnings := filterByStatus(results, checker.StatusRedirect, checker.StatusBlocked)
+	warnings := filterByStatus(results, checker.StatusRedirect, checker.StatusLongRedirect,
+		checker.StatusLoginRequired, checker.StatusBrokenAnchor, checker.StatusInsecure, checker.StatusSuspect,
+		checker.StatusBlocked)
 	if len(warnings) == 0 {
 		return
 	}
@@ -115,6 +278,8 @@ func (m *MarkdownFormatter) writeWarningsSection(b *strings.Builder, results []c
 	fmt.Fprintf(b, "## Warnings (%d)\n\n", len(warnings))
 	m.writeWarningsTable(b, warnings)
 	m.writeRedirectDetails(b, results)
+	m.writeBrokenAnchorDetails(b, results)
+	m.writeInsecureDetails(b, results)
 }
 
 // writeWarningsTable writes the warnings summary table.
@@ -126,7 +291,7 @@ func (*MarkdownFormatter) writeWarningsTable(b *strings.Builder, warnings []chec
 		text := escapeMarkdown(truncateText(r.Link.Text, 30))
 		url := escapeMarkdown(truncateText(r.Link.URL, 50))
 		finalURL := ""
-		if r.Status == checker.StatusRedirect {
+		if len(r.RedirectChain) > 0 {
 			finalURL = escapeMarkdown(truncateText(r.FinalURL, 50))
 		}
 		fmt.Fprintf(b, "| %s | %s | %s | %s | %s | %d |\n",
@@ -137,7 +302,7 @@ func (*MarkdownFormatter) writeWarningsTable(b *strings.Builder, warnings []chec
 
 // writeRedirectDetails writes detailed redirect chain info.
 func (*MarkdownFormatter) writeRedirectDetails(b *strings.Builder, results []checker.Result) {
-	redirects := filterByStatus(results, checker.StatusRedirect)
+	redirects := filterByStatus(results, checker.StatusRedirect, checker.StatusLongRedirect)
 	if len(redirects) == 0 {
 		return
 	}
@@ -149,22 +314,70 @@ func (*MarkdownFormatter) writeRedirectDetails(b *strings.Builder, results []che
 			fmt.Fprintf(b, "  - Text: %q\n", truncateText(r.Link.Text, 60))
 		}
 		fmt.Fprintf(b, "  - File: `%s:%d`\n", r.Link.FilePath, r.Link.Line)
-		b.WriteString("  - Chain: ")
-		b.WriteString(formatChainCodes(r))
-		b.WriteString("\n")
-		fmt.Fprintf(b, "  - Final: %s\n", r.FinalURL)
+		fmt.Fprintf(b, "  - Chain (%d hop(s)):\n", r.ChainLength())
+		writeHops(b, r, "    ")
+		if r.Suggestion != nil && r.Suggestion.URL != r.FinalURL {
+			fmt.Fprintf(b, "  - Suggested fix: %s (%s)\n", r.Suggestion.URL, r.Suggestion.Source)
+		}
+	}
+	b.WriteString("\n")
+}
+
+// writeBrokenAnchorDetails lists the fragments missing from their target pages.
+func (*MarkdownFormatter) writeBrokenAnchorDetails(b *strings.Builder, results []checker.Result) {
+	broken := filterByStatus(results, checker.StatusBrokenAnchor)
+	if len(broken) == 0 {
+		return
+	}
+
+	b.WriteString("### Broken Anchors\n\n")
+	for _, r := range broken {
+		fmt.Fprintf(b, "- **%s**\n", escapeMarkdown(r.Link.URL))
+		fmt.Fprintf(b, "  - File: `%s:%d`\n", r.Link.FilePath, r.Link.Line)
+		fmt.Fprintf(b, "  - Missing anchor: `#%s`\n", r.Anchor)
+	}
+	b.WriteString("\n")
+}
+
+// writeInsecureDetails lists the certificate problems skipped with --insecure.
+func (*MarkdownFormatter) writeInsecureDetails(b *strings.Builder, results []checker.Result) {
+	insecure := filterByStatus(results, checker.StatusInsecure)
+	if len(insecure) == 0 {
+		return
+	}
+
+	b.WriteString("### Insecure Certificates\n\n")
+	for _, r := range insecure {
+		fmt.Fprintf(b, "- **%s**\n", escapeMarkdown(r.Link.URL))
+		fmt.Fprintf(b, "  - File: `%s:%d`\n", r.Link.FilePath, r.Link.Line)
+		if r.TLS != nil {
+			fmt.Fprintf(b, "  - Certificate: %s for %s (%s)\n", r.TLS.Problem, r.TLS.Host, r.TLS.Detail)
+		}
 	}
 	b.WriteString("\n")
 }
 
-// formatChainCodes formats redirect chain status codes as a string.
-func formatChainCodes(r checker.Result) string {
-	chain := make([]string, 0, len(r.RedirectChain)+1)
-	for _, red := range r.RedirectChain {
-		chain = append(chain, fmt.Sprintf("`%d`", red.StatusCode))
+// writeHintsSection writes links whose target page produced content hints.
+func (*MarkdownFormatter) writeHintsSection(b *strings.Builder, results []checker.Result) {
+	var hinted []checker.Result
+	for _, r := range results {
+		if len(r.Hints) > 0 {
+			hinted = append(hinted, r)
+		}
+	}
+	if len(hinted) == 0 {
+		return
 	}
-	chain = append(chain, fmt.Sprintf("`%d`", r.FinalStatus))
-	return strings.Join(chain, " → ")
+
+	fmt.Fprintf(b, "## Hints (%d)\n\n", len(hinted))
+	b.WriteString("| URL | Hint | File | Line |\n")
+	b.WriteString("|-----|------|------|------|\n")
+	for _, r := range hinted {
+		url := escapeMarkdown(truncateText(r.Link.URL, 60))
+		hint := escapeMarkdown(strings.Join(r.Hints, "; "))
+		fmt.Fprintf(b, "| %s | %s | %s | %d |\n", url, hint, r.Link.FilePath, r.Link.Line)
+	}
+	b.WriteString("\n")
 }
 
 // writeDuplicatesSection writes the duplicates section if any exist.
@@ -189,6 +402,107 @@ func (*MarkdownFormatter) writeDuplicatesSection(b *strings.Builder, results []c
 	b.WriteString("\n")
 }
 
+// writeDomainsSection writes the per-domain breakdown if one was requested.
+func (*MarkdownFormatter) writeDomainsSection(b *strings.Builder, domains []DomainSummary) {
+	if len(domains) == 0 {
+		return
+	}
+
+	fmt.Fprintf(b, "## Domains (%d)\n\n", len(domains))
+	b.WriteString("| Domain | Links | Statuses | Ignored | Denied | Rules | Time |\n")
+	b.WriteString("|--------|-------|----------|---------|--------|-------|------|\n")
+	for _, d := range domains {
+		rules := make([]string, len(d.Rules))
+		for i, r := range d.Rules {
+			rules[i] = "`" + r + "`"
+		}
+		fmt.Fprintf(b, "| %s | %d | %s | %d | %d | %s | %s |\n",
+			escapeMarkdown(d.Domain), d.Links, d.StatusList(), d.Ignored, d.Denied,
+			strings.Join(rules, ", "), stats.FormatDuration(d.Duration))
+	}
+	b.WriteString("\n")
+}
+
+// writeParseErrorsSection writes files that failed to parse in strict mode.
+func (*MarkdownFormatter) writeParseErrorsSection(b *strings.Builder, parseErrors []ParseError) {
+	if len(parseErrors) == 0 {
+		return
+	}
+
+	fmt.Fprintf(b, "## Parse Errors (%d)\n\n", len(parseErrors))
+	b.WriteString("| File | Line | Error |\n")
+	b.WriteString("|------|------|-------|\n")
+	for _, pe := range parseErrors {
+		fmt.Fprintf(b, "| %s | %d | %s |\n", pe.File, pe.Line, escapeMarkdown(pe.Error))
+	}
+	b.WriteString("\n")
+}
+
+// writeViolationsSection writes links matching deny rules if any exist.
+func (*MarkdownFormatter) writeViolationsSection(b *strings.Builder, violations []PolicyViolation) {
+	if len(violations) == 0 {
+		return
+	}
+
+	fmt.Fprintf(b, "## Policy Violations (%d)\n\n", len(violations))
+	b.WriteString("| URL | File | Line | Reason | Rule |\n")
+	b.WriteString("|-----|------|------|--------|------|\n")
+	for _, v := range violations {
+		url := escapeMarkdown(truncateText(v.URL, 60))
+		fmt.Fprintf(b, "| %s | %s | %d | %s | `%s` |\n",
+			url, v.File, v.Line, v.Reason, v.Rule)
+	}
+	b.WriteString("\n")
+}
+
+// writeInternalSection writes relative links to missing files or anchors if any exist.
+func (*MarkdownFormatter) writeInternalSection(b *strings.Builder, links []InternalLink) {
+	if len(links) == 0 {
+		return
+	}
+
+	fmt.Fprintf(b, "## Broken Internal Links (%d)\n\n", len(links))
+	b.WriteString("| Problem | Link | File | Line | Details |\n")
+	b.WriteString("|---------|------|------|------|---------|\n")
+	for _, il := range links {
+		fmt.Fprintf(b, "| %s | %s | %s | %d | %s |\n",
+			il.Problem, escapeMarkdown(truncateText(il.URL, 60)), il.File, il.Line, escapeMarkdown(il.Message))
+	}
+	b.WriteString("\n")
+}
+
+// writeFixedSection writes the baseline's dead links that were fixed if any exist.
+func (*MarkdownFormatter) writeFixedSection(b *strings.Builder, fixed []checker.Result) {
+	if len(fixed) == 0 {
+		return
+	}
+
+	fmt.Fprintf(b, "## Fixed Since Baseline (%d)\n\n", len(fixed))
+	b.WriteString("| URL | File | Line |\n")
+	b.WriteString("|-----|------|------|\n")
+	for _, r := range fixed {
+		fmt.Fprintf(b, "| %s | %s | %d |\n", escapeMarkdown(truncateText(r.Link.URL, 60)), r.Link.FilePath, r.Link.Line)
+	}
+	b.WriteString("\n")
+}
+
+// writeLintSection writes lint findings if any exist.
+func (*MarkdownFormatter) writeLintSection(b *strings.Builder, findings []LintFinding) {
+	if len(findings) == 0 {
+		return
+	}
+
+	fmt.Fprintf(b, "## Lint (%d)\n\n", len(findings))
+	b.WriteString("| Rule | URL | File | Line | Message |\n")
+	b.WriteString("|------|-----|------|------|---------|\n")
+	for _, lf := range findings {
+		url := escapeMarkdown(truncateText(lf.URL, 60))
+		fmt.Fprintf(b, "| %s | %s | %s | %d | %s |\n",
+			lf.Rule, url, lf.File, lf.Line, escapeMarkdown(lf.Message))
+	}
+	b.WriteString("\n")
+}
+
 // writeIgnoredSection writes the ignored URLs section if any exist.
 func (*MarkdownFormatter) writeIgnoredSection(b *strings.Builder, ignored []IgnoredURL) {
 	if len(ignored) == 0 {
@@ -206,6 +520,42 @@ func (*MarkdownFormatter) writeIgnoredSection(b *strings.Builder, ignored []Igno
 	b.WriteString("\n")
 }
 
+// writeSkippedSection writes the files skipped for exceeding the parse budget.
+func (*MarkdownFormatter) writeSkippedSection(b *strings.Builder, skipped []SkippedFile) {
+	if len(skipped) == 0 {
+		return
+	}
+
+	fmt.Fprintf(b, "## Skipped Files (%d)\n\n", len(skipped))
+	b.WriteString("| File | Reason |\n")
+	b.WriteString("|------|--------|\n")
+	for _, sk := range skipped {
+		fmt.Fprintf(b, "| %s | %s |\n", sk.File, escapeMarkdown(sk.Reason))
+	}
+	b.WriteString("\n")
+}
+
+// writeInventorySection lists every link by file with its status, if
+// requested (--report-all), so the report doubles as a link inventory.
+func (*MarkdownFormatter) writeInventorySection(b *strings.Builder, inventory []checker.Result) {
+	if len(inventory) == 0 {
+		return
+	}
+
+	fmt.Fprintf(b, "## Full Inventory (%d)\n\n", len(inventory))
+	for _, g := range FileInventory(inventory) {
+		fmt.Fprintf(b, "### %s\n\n", escapeMarkdown(g.Title()))
+		b.WriteString("| Line | Status | URL | Text | Details |\n")
+		b.WriteString("|------|--------|-----|------|---------|\n")
+		for _, r := range g.Results {
+			fmt.Fprintf(b, "| %d | %s | %s | %s | %s |\n",
+				r.Link.Line, formatStatusForMarkdown(r), escapeMarkdown(truncateText(r.Link.URL, 60)),
+				escapeMarkdown(truncateText(r.Link.Text, 40)), escapeMarkdown(resultDetails(r)))
+		}
+		b.WriteString("\n")
+	}
+}
+
 // formatStatusForMarkdown formats a result status for markdown display.
 func formatStatusForMarkdown(r checker.Result) string {
 	switch r.Status {
@@ -216,6 +566,8 @@ func formatStatusForMarkdown(r checker.Result) string {
 		return "DEAD"
 	case checker.StatusError:
 		return "ERROR"
+	case checker.StatusTLSError:
+		return "TLS ERROR"
 	default:
 		return r.Status.Label()
 	}
@@ -230,6 +582,19 @@ func escapeMarkdown(s string) string {
 	return s
 }
 
+// codeSpan wraps s in a Markdown code span, fenced with more backticks than
+// any run of backticks in s.
+func codeSpan(s string) string {
+	fence := "`"
+	for strings.Contains(s, fence) {
+		fence += "`"
+	}
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") {
+		s = " " + s + " "
+	}
+	return fence + s + fence
+}
+
 // truncateText shortens text to maxLen characters, adding "..." if truncated.
 func truncateText(text string, maxLen int) string {
 	text = strings.TrimSpace(text)
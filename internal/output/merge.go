@@ -0,0 +1,136 @@
+package output
+
+import (
+	"slices"
+	"time"
+
+	"github.com/leonardomso/gone/internal/checker"
+)
+
+// resultKey identifies one occurrence of a link.
+type resultKey struct {
+	URL  string
+	File string
+	Line int
+}
+
+// Merge combines reports from separate runs, such as sharded CI jobs that
+// each scan part of a repository, into one report.
+//
+// Occurrences listed by more than one input (same URL, file, and line) are
+// kept once. A URL checked by several inputs keeps the first result; later
+// occurrences become duplicates of it, as in a single run. Reports usually
+// list only dead links and warnings, so counts for links that aren't listed
+// (alive links, by default) are summed as is. Ignored URLs, skipped files,
+// parse errors, lint findings, policy violations, and broken internal links
+// are deduplicated.
+func Merge(reports ...*Report) *Report {
+	merged := &Report{
+		Summary: checker.Summary{ByParser: map[string]int{}, ByLinkType: map[string]int{}},
+	}
+
+	seen := map[resultKey]bool{}
+	primaries := map[string]*checker.Result{}
+	unique := false
+
+	for _, r := range reports {
+		if r.GeneratedAt.After(merged.GeneratedAt) {
+			merged.GeneratedAt = r.GeneratedAt
+		}
+		merged.FileCount += r.FilesScanned()
+		merged.TotalLinks += r.TotalLinks - len(r.Results)
+		addSummary(&merged.Summary, r.Summary, 1)
+		addSummary(&merged.Summary, checker.Summarize(r.Results), -1)
+		unique = unique || len(r.UniqueDead) > 0
+
+		for _, res := range r.Results {
+			key := resultKey{URL: res.Link.URL, File: res.Link.FilePath, Line: res.Link.Line}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			if !res.IsDuplicate() {
+				if _, ok := primaries[res.Link.URL]; ok {
+					res = checker.Result{Link: res.Link, StatusCode: res.StatusCode, Status: checker.StatusDuplicate}
+				} else {
+					primaries[res.Link.URL] = &res
+				}
+			}
+			merged.Results = append(merged.Results, res)
+		}
+
+		merged.Ignored = appendUnique(merged.Ignored, r.Ignored)
+		merged.Skipped = appendUnique(merged.Skipped, r.Skipped)
+		merged.ParseErrors = appendUnique(merged.ParseErrors, r.ParseErrors)
+		merged.Lint = appendUnique(merged.Lint, r.Lint)
+		merged.Violations = appendUnique(merged.Violations, r.Violations)
+		merged.Internal = appendUnique(merged.Internal, r.Internal)
+	}
+
+	// Point duplicates at the primary they now belong to
+	for i := range merged.Results {
+		res := &merged.Results[i]
+		if !res.IsDuplicate() {
+			continue
+		}
+		if primary, ok := primaries[res.Link.URL]; ok {
+			res.DuplicateOf = primary
+		}
+	}
+
+	merged.TotalLinks += len(merged.Results)
+	addSummary(&merged.Summary, checker.Summarize(merged.Results), 1)
+	merged.UniqueURLs = merged.Summary.UniqueURLs
+
+	if unique {
+		merged.UniqueDead = checker.UniqueDead(merged.Results)
+	}
+	return merged
+}
+
+// addSummary adds s to dst, or subtracts it when sign is -1.
+func addSummary(dst *checker.Summary, s checker.Summary, sign int) {
+	dst.Total += sign * s.Total
+	dst.UniqueURLs += sign * s.UniqueURLs
+	dst.Alive += sign * s.Alive
+	dst.Redirects += sign * s.Redirects
+	dst.LongRedirects += sign * s.LongRedirects
+	dst.LoginRequired += sign * s.LoginRequired
+	dst.BrokenAnchors += sign * s.BrokenAnchors
+	dst.Insecure += sign * s.Insecure
+	dst.Suspect += sign * s.Suspect
+	dst.Blocked += sign * s.Blocked
+	dst.Dead += sign * s.Dead
+	dst.Errors += sign * s.Errors
+	dst.TLSErrors += sign * s.TLSErrors
+	dst.Duplicates += sign * s.Duplicates
+	dst.Skipped += sign * s.Skipped
+	dst.Retries += sign * s.Retries
+	dst.RetriedURLs += sign * s.RetriedURLs
+	dst.Backoff += time.Duration(sign) * s.Backoff
+	dst.NoIndex += sign * s.NoIndex
+	dst.Stale += sign * s.Stale
+	addCounts(dst.ByParser, s.ByParser, sign)
+	addCounts(dst.ByLinkType, s.ByLinkType, sign)
+}
+
+// addCounts adds the counts in src to dst, dropping names that reach zero.
+func addCounts(dst, src map[string]int, sign int) {
+	for name, n := range src {
+		dst[name] += sign * n
+		if dst[name] == 0 {
+			delete(dst, name)
+		}
+	}
+}
+
+// appendUnique appends the items of src that dst doesn't contain yet.
+func appendUnique[T comparable](dst, src []T) []T {
+	for _, item := range src {
+		if !slices.Contains(dst, item) {
+			dst = append(dst, item)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,145 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/leonardomso/gone/internal/checker"
+)
+
+// NDJSONFormatter formats reports as JSON Lines (newline-delimited JSON):
+// one JSON object per line, each with a "type" naming the record. Results
+// come first, then ignored URLs, skipped files, parse errors, lint findings,
+// policy violations, broken internal links, and unique dead URLs, and the
+// summary is always the last line. Fields match those of the JSON format.
+type NDJSONFormatter struct{}
+
+type ndjsonResult struct {
+	Type string `json:"type"`
+	jsonResult
+}
+
+type ndjsonIgnored struct {
+	Type string `json:"type"`
+	jsonIgnored
+}
+
+type ndjsonSkipped struct {
+	Type string `json:"type"`
+	jsonSkipped
+}
+
+type ndjsonParseError struct {
+	Type string `json:"type"`
+	jsonParseError
+}
+
+type ndjsonLint struct {
+	Type string `json:"type"`
+	jsonLint
+}
+
+type ndjsonInternal struct {
+	Type string `json:"type"`
+	jsonInternal
+}
+
+type ndjsonUnique struct {
+	Type string `json:"type"`
+	jsonUnique
+}
+
+type ndjsonSummary struct {
+	Type          string `json:"type"`
+	SchemaVersion int    `json:"schema_version"`
+	GeneratedAt   string `json:"generated_at"`
+	jsonSummary
+	TotalFiles int `json:"total_files"`
+	TotalLinks int `json:"total_links"`
+	UniqueURLs int `json:"unique_urls"`
+}
+
+// Format implements Formatter.
+func (*NDJSONFormatter) Format(report *Report) ([]byte, error) {
+	var buf bytes.Buffer
+	w := NewNDJSONWriter(&buf)
+	for _, r := range report.Results {
+		if err := w.WriteResult(r); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.WriteTrailer(report); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// NDJSONWriter writes a report as JSON Lines while it's being built: each
+// result as soon as it's checked, and the rest of the report once the
+// check is done. It's safe for concurrent use.
+type NDJSONWriter struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewNDJSONWriter returns a writer of JSON Lines to w.
+func NewNDJSONWriter(w io.Writer) *NDJSONWriter {
+	return &NDJSONWriter{enc: json.NewEncoder(w)}
+}
+
+// WriteResult writes a result line.
+func (w *NDJSONWriter) WriteResult(r checker.Result) error {
+	return w.write(ndjsonResult{Type: "result", jsonResult: newJSONResult(r)})
+}
+
+// WriteTrailer writes everything in the report except its results, which
+// have already been written, ending with the summary line.
+func (w *NDJSONWriter) WriteTrailer(report *Report) error {
+	var records []any
+	for _, ig := range report.Ignored {
+		records = append(records, ndjsonIgnored{Type: "ignored", jsonIgnored: jsonIgnored(ig)})
+	}
+	for _, sk := range report.Skipped {
+		records = append(records, ndjsonSkipped{Type: "skipped_file", jsonSkipped: jsonSkipped(sk)})
+	}
+	for _, pe := range report.ParseErrors {
+		records = append(records, ndjsonParseError{Type: "parse_error", jsonParseError: jsonParseError(pe)})
+	}
+	for _, lf := range report.Lint {
+		records = append(records, ndjsonLint{Type: "lint", jsonLint: jsonLint(lf)})
+	}
+	for _, v := range report.Violations {
+		records = append(records, ndjsonIgnored{Type: "policy_violation", jsonIgnored: jsonIgnored(v)})
+	}
+	for _, il := range report.Internal {
+		records = append(records, ndjsonInternal{Type: "internal_link", jsonInternal: jsonInternal(il)})
+	}
+	for _, u := range report.UniqueDead {
+		records = append(records, ndjsonUnique{Type: "unique_dead", jsonUnique: newJSONUnique(u)})
+	}
+	records = append(records, ndjsonSummary{
+		Type:          "summary",
+		SchemaVersion: SchemaVersion,
+		GeneratedAt:   report.GeneratedAt.Format("2006-01-02T15:04:05Z07:00"),
+		jsonSummary:   newJSONSummary(report),
+		TotalFiles:    report.FilesScanned(),
+		TotalLinks:    report.TotalLinks,
+		UniqueURLs:    report.UniqueURLs,
+	})
+
+	for _, record := range records {
+		if err := w.write(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// write encodes a record as one line.
+func (w *NDJSONWriter) write(record any) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.enc.Encode(record)
+}
@@ -3,9 +3,12 @@ package output
 
 import (
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/leonardomso/gone/internal/checker"
@@ -25,6 +28,22 @@ const (
 	FormatJUnit Format = "junit"
 	// FormatMarkdown outputs as a Markdown report.
 	FormatMarkdown Format = "markdown"
+	// FormatShieldJSON outputs a shields.io endpoint badge.
+	FormatShieldJSON Format = "shield-json"
+	// FormatHTML outputs a standalone HTML page.
+	FormatHTML Format = "html"
+	// FormatGitHub outputs GitHub Actions workflow commands (annotations).
+	FormatGitHub Format = "github"
+	// FormatCSV outputs one CSV row per result.
+	FormatCSV Format = "csv"
+	// FormatNDJSON outputs JSON Lines, one record per line.
+	FormatNDJSON Format = "ndjson"
+	// FormatTAP outputs TAP version 13, one test point per unique URL.
+	FormatTAP Format = "tap"
+	// FormatPrometheus outputs Prometheus text-format metrics.
+	FormatPrometheus Format = "prometheus"
+	// FormatTemplate renders the report's Template.
+	FormatTemplate Format = "template"
 )
 
 // ValidFormats returns all valid format strings.
@@ -35,13 +54,22 @@ func ValidFormats() []string {
 		string(FormatXML),
 		string(FormatJUnit),
 		string(FormatMarkdown),
+		string(FormatShieldJSON),
+		string(FormatHTML),
+		string(FormatGitHub),
+		string(FormatCSV),
+		string(FormatNDJSON),
+		string(FormatTAP),
+		string(FormatPrometheus),
+		string(FormatTemplate),
 	}
 }
 
 // IsValidFormat checks if a format string is valid.
 func IsValidFormat(s string) bool {
 	switch Format(strings.ToLower(s)) {
-	case FormatJSON, FormatYAML, FormatXML, FormatJUnit, FormatMarkdown:
+	case FormatJSON, FormatYAML, FormatXML, FormatJUnit, FormatMarkdown, FormatShieldJSON, FormatHTML, FormatGitHub,
+		FormatCSV, FormatNDJSON, FormatTAP, FormatPrometheus, FormatTemplate:
 		return true
 	default:
 		return false
@@ -57,21 +85,112 @@ type IgnoredURL struct {
 	Line   int
 }
 
+// SkippedFile represents a file that was not parsed because it exceeded the parse budget.
+type SkippedFile struct {
+	File   string
+	Reason string
+}
+
+// ParseError represents a file that failed to parse in strict mode.
+// Line is 0 when the parser doesn't report one.
+type ParseError struct {
+	File  string
+	Error string
+	Line  int
+}
+
+// PolicyViolation represents a link matching a deny rule.
+type PolicyViolation struct {
+	URL    string
+	File   string
+	Reason string // "domain", "pattern", or "regex"
+	Rule   string // The rule that matched
+	Line   int
+}
+
+// LintFinding represents a lint issue found on a link (--lint).
+type LintFinding struct {
+	URL     string
+	File    string
+	Rule    string
+	Message string
+	Line    int
+}
+
+// InternalLink represents a relative link to a missing file or heading
+// anchor (--check-internal).
+type InternalLink struct {
+	URL     string
+	File    string
+	Problem string // "missing-file" or "missing-anchor"
+	Message string
+	Line    int
+}
+
 // Report contains all data needed for output formatting.
 type Report struct {
 	GeneratedAt time.Time
 	Files       []string
 	Results     []checker.Result
 	Ignored     []IgnoredURL
+	Skipped     []SkippedFile
+	ParseErrors []ParseError
+	Lint        []LintFinding
+	Violations  []PolicyViolation
+	Internal    []InternalLink
 	Summary     checker.Summary
 	TotalLinks  int
 	UniqueURLs  int
 
+	// FileCount is the number of files scanned when Files isn't known,
+	// as for reports read back from JSON.
+	FileCount int
+
+	// Duration is how long the run took, or 0 if unknown.
+	Duration time.Duration
+
+	// UniqueDead lists each dead URL once with all its locations (--unique).
+	UniqueDead []checker.UniqueURL
+
+	// Fixed lists the baseline's dead links that no longer are (--baseline).
+	// Results then holds only the newly broken links.
+	Fixed []checker.Result
+
+	// Domains groups links by destination domain (--by-domain).
+	Domains []DomainSummary
+
+	// Inventory lists every checked link, whatever the --show flags select,
+	// for the Markdown report's Full Inventory section (--report-all).
+	Inventory []checker.Result
+
+	// GroupBy selects how Markdown and HTML reports group Results (--group-by).
+	// Empty means GroupByStatus.
+	GroupBy GroupBy
+
+	// Template renders the report with FormatTemplate (--template).
+	Template *template.Template
+
+	// JUnitSuitePer groups JUnit test cases into a suite per file (the
+	// default, also for an empty value) or per domain (--junit-suite-per).
+	JUnitSuitePer GroupBy
+
+	// JUnitIncludePassed adds links that didn't fail to JUnit reports as
+	// passing or skipped test cases (--junit-include-passed).
+	JUnitIncludePassed bool
+
 	// Stats contains performance statistics when --stats flag is used.
 	// This is a map to allow flexible serialization to JSON/YAML.
 	Stats map[string]any `json:"stats,omitempty" yaml:"stats,omitempty"`
 }
 
+// FilesScanned returns the number of files scanned.
+func (r *Report) FilesScanned() int {
+	if len(r.Files) > 0 {
+		return len(r.Files)
+	}
+	return r.FileCount
+}
+
 // Formatter is the interface that output formatters implement.
 type Formatter interface {
 	Format(report *Report) ([]byte, error)
@@ -90,6 +209,22 @@ func GetFormatter(format Format) (Formatter, error) {
 		return &JUnitFormatter{}, nil
 	case FormatMarkdown:
 		return &MarkdownFormatter{}, nil
+	case FormatShieldJSON:
+		return &ShieldJSONFormatter{}, nil
+	case FormatHTML:
+		return &HTMLFormatter{}, nil
+	case FormatGitHub:
+		return &GitHubFormatter{}, nil
+	case FormatCSV:
+		return &CSVFormatter{}, nil
+	case FormatNDJSON:
+		return &NDJSONFormatter{}, nil
+	case FormatTAP:
+		return &TAPFormatter{}, nil
+	case FormatPrometheus:
+		return &PrometheusFormatter{}, nil
+	case FormatTemplate:
+		return &TemplateFormatter{}, nil
 	default:
 		return nil, fmt.Errorf("unknown format: %s", format)
 	}
@@ -106,10 +241,14 @@ func FormatReport(report *Report, format Format) ([]byte, error) {
 
 // InferFormat determines the output format from a filename extension.
 func InferFormat(filename string) (Format, error) {
-	// Handle special case for JUnit
-	if strings.HasSuffix(strings.ToLower(filename), ".junit.xml") {
+	// Handle special cases for JUnit and shields.io badges
+	lower := strings.ToLower(filename)
+	if strings.HasSuffix(lower, ".junit.xml") {
 		return FormatJUnit, nil
 	}
+	if strings.HasSuffix(lower, ".shield.json") {
+		return FormatShieldJSON, nil
+	}
 
 	ext := strings.ToLower(filepath.Ext(filename))
 	switch ext {
@@ -121,9 +260,21 @@ func InferFormat(filename string) (Format, error) {
 		return FormatXML, nil
 	case ".md", ".markdown":
 		return FormatMarkdown, nil
+	case ".html", ".htm":
+		return FormatHTML, nil
+	case ".csv":
+		return FormatCSV, nil
+	case ".ndjson", ".jsonl":
+		return FormatNDJSON, nil
+	case ".tap":
+		return FormatTAP, nil
+	case ".prom":
+		return FormatPrometheus, nil
 	default:
 		return "", fmt.Errorf(
-			"cannot infer format from extension %q (supported: .json, .yaml, .yml, .xml, .junit.xml, .md, .markdown)",
+			"cannot infer format from extension %q "+
+				"(supported: .json, .shield.json, .yaml, .yml, .xml, .junit.xml, .md, .markdown, .html, .htm, "+
+				".csv, .ndjson, .jsonl, .tap, .prom)",
 			ext,
 		)
 	}
@@ -131,19 +282,70 @@ func InferFormat(filename string) (Format, error) {
 
 // WriteToFile writes a formatted report to a file.
 func WriteToFile(report *Report, filename string) error {
-	format, err := InferFormat(filename)
-	if err != nil {
-		return err
-	}
+	return WriteToFiles(report, []string{filename})
+}
 
-	data, err := FormatReport(report, format)
-	if err != nil {
-		return fmt.Errorf("formatting report: %w", err)
+// WriteToFiles writes a report to several files, each in the format
+// inferred from its extension. Every extension is checked before anything
+// is written, and the report is formatted once per format.
+func WriteToFiles(report *Report, filenames []string) error {
+	formats := make([]Format, len(filenames))
+	for i, filename := range filenames {
+		format, err := InferFormat(filename)
+		if err != nil {
+			return err
+		}
+		formats[i] = format
 	}
 
-	if err := os.WriteFile(filename, data, 0o600); err != nil {
-		return fmt.Errorf("writing file: %w", err)
+	formatted := make(map[Format][]byte, len(formats))
+	for i, filename := range filenames {
+		data, ok := formatted[formats[i]]
+		if !ok {
+			var err error
+			data, err = FormatReport(report, formats[i])
+			if err != nil {
+				return fmt.Errorf("formatting report: %w", err)
+			}
+			formatted[formats[i]] = data
+		}
+
+		if err := os.WriteFile(filename, data, 0o600); err != nil {
+			return fmt.Errorf("writing file: %w", err)
+		}
 	}
 
 	return nil
 }
+
+// deadContext returns the source line of a dead link, or "" for links
+// that aren't dead, which reports leave without context.
+func deadContext(r checker.Result) string {
+	if !r.Resolved().IsDead() {
+		return ""
+	}
+	return r.Link.Context
+}
+
+// sortedKeys returns the keys of a count map in alphabetical order.
+func sortedKeys(m map[string]int) []string {
+	return slices.Sorted(maps.Keys(m))
+}
+
+// formatLastModified returns a result's Last-Modified time as RFC 3339, or "" if unknown.
+func formatLastModified(r checker.Result) string {
+	if r.LastModified.IsZero() {
+		return ""
+	}
+	return r.LastModified.Format(time.RFC3339)
+}
+
+// ageDays returns a result's age in whole days, or 0 if unknown.
+func ageDays(r checker.Result) int {
+	return int(r.Age / (24 * time.Hour))
+}
+
+// retries returns how many times a result's check was retried.
+func retries(r checker.Result) int {
+	return max(r.Attempts-1, 0)
+}
@@ -3,8 +3,10 @@ package output
 import (
 	"encoding/json"
 	"encoding/xml"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -40,7 +42,9 @@ func newTestReport() *Report {
 		},
 		Results: []checker.Result{
 			{
-				Link:       checker.Link{URL: "https://example.com", FilePath: "README.md", Line: 10, Text: "Example"},
+				Link: checker.Link{
+					URL: "https://example.com", FilePath: "README.md", Line: 10, Column: 7, Offset: 160, Text: "Example",
+				},
 				StatusCode: 200,
 				Status:     checker.StatusAlive,
 			},
@@ -103,12 +107,20 @@ func TestValidFormats(t *testing.T) {
 
 	formats := ValidFormats()
 
-	assert.Len(t, formats, 5)
+	assert.Len(t, formats, 13)
 	assert.Contains(t, formats, "json")
 	assert.Contains(t, formats, "yaml")
 	assert.Contains(t, formats, "xml")
 	assert.Contains(t, formats, "junit")
 	assert.Contains(t, formats, "markdown")
+	assert.Contains(t, formats, "shield-json")
+	assert.Contains(t, formats, "html")
+	assert.Contains(t, formats, "github")
+	assert.Contains(t, formats, "csv")
+	assert.Contains(t, formats, "ndjson")
+	assert.Contains(t, formats, "tap")
+	assert.Contains(t, formats, "prometheus")
+	assert.Contains(t, formats, "template")
 }
 
 func TestIsValidFormat(t *testing.T) {
@@ -126,9 +138,16 @@ func TestIsValidFormat(t *testing.T) {
 		{"xml", true},
 		{"junit", true},
 		{"markdown", true},
+		{"shield-json", true},
 		{"md", false},
+		{"html", true},
+		{"csv", true},
+		{"ndjson", true},
+		{"jsonl", false},
+		{"tap", true},
+		{"prometheus", true},
+		{"template", true},
 		{"txt", false},
-		{"html", false},
 		{"", false},
 	}
 
@@ -157,6 +176,14 @@ func TestGetFormatter(t *testing.T) {
 		{FormatXML, "*output.XMLFormatter", false},
 		{FormatJUnit, "*output.JUnitFormatter", false},
 		{FormatMarkdown, "*output.MarkdownFormatter", false},
+		{FormatShieldJSON, "*output.ShieldJSONFormatter", false},
+		{FormatHTML, "*output.HTMLFormatter", false},
+		{FormatGitHub, "*output.GitHubFormatter", false},
+		{FormatCSV, "*output.CSVFormatter", false},
+		{FormatNDJSON, "*output.NDJSONFormatter", false},
+		{FormatTAP, "*output.TAPFormatter", false},
+		{FormatPrometheus, "*output.PrometheusFormatter", false},
+		{FormatTemplate, "*output.TemplateFormatter", false},
 		{"unknown", "", true},
 	}
 
@@ -208,14 +235,28 @@ func TestInferFormat(t *testing.T) {
 		{"REPORT.JUNIT.XML", FormatJUnit, false},
 		{"path/to/report.junit.xml", FormatJUnit, false},
 
+		// shields.io endpoint (special case)
+		{"links.shield.json", FormatShieldJSON, false},
+		{"LINKS.SHIELD.JSON", FormatShieldJSON, false},
+
 		// Markdown
 		{"report.md", FormatMarkdown, false},
 		{"report.markdown", FormatMarkdown, false},
 		{"REPORT.MD", FormatMarkdown, false},
 
+		// HTML
+		{"report.html", FormatHTML, false},
+		{"report.htm", FormatHTML, false},
+
+		// CSV and JSON Lines
+		{"report.csv", FormatCSV, false},
+		{"report.ndjson", FormatNDJSON, false},
+		{"report.jsonl", FormatNDJSON, false},
+		{"links.tap", FormatTAP, false},
+		{"gone.prom", FormatPrometheus, false},
+
 		// Errors
 		{"report.txt", "", true},
-		{"report.html", "", true},
 		{"report", "", true},
 		{".json", FormatJSON, false}, // Edge case: just extension
 	}
@@ -358,6 +399,45 @@ func TestWriteToFile_InvalidFormat(t *testing.T) {
 	assert.Contains(t, err.Error(), "cannot infer format")
 }
 
+func TestWriteToFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	paths := []string{
+		filepath.Join(dir, "report.json"),
+		filepath.Join(dir, "report.md"),
+		filepath.Join(dir, "report.junit.xml"),
+		filepath.Join(dir, "copy.json"),
+	}
+	require.NoError(t, WriteToFiles(newTestReport(), paths))
+
+	jsonData, err := os.ReadFile(paths[0])
+	require.NoError(t, err)
+	assert.True(t, json.Valid(jsonData))
+	copyData, err := os.ReadFile(paths[3])
+	require.NoError(t, err)
+	assert.Equal(t, jsonData, copyData)
+
+	markdown, err := os.ReadFile(paths[1])
+	require.NoError(t, err)
+	assert.Contains(t, string(markdown), "# Gone Link Check Report")
+	junit, err := os.ReadFile(paths[2])
+	require.NoError(t, err)
+	assert.Contains(t, string(junit), "<testsuites")
+}
+
+func TestWriteToFiles_InvalidFormat(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	err := WriteToFiles(newTestReport(), []string{filepath.Join(dir, "report.json"), filepath.Join(dir, "report.txt")})
+	require.Error(t, err)
+
+	// Nothing is written when any extension is unknown
+	_, err = os.Stat(filepath.Join(dir, "report.json"))
+	assert.True(t, os.IsNotExist(err))
+}
+
 func TestWriteToFile_InvalidPath(t *testing.T) {
 	t.Parallel()
 
@@ -414,6 +494,7 @@ func TestJSONFormatter_Format(t *testing.T) {
 	assert.Len(t, redirectResult.RedirectChain, 1)
 	assert.Equal(t, "https://new.example.com", redirectResult.FinalURL)
 	assert.Equal(t, 200, redirectResult.FinalStatus)
+	assert.Equal(t, 1, redirectResult.ChainLength)
 
 	// Check ignored
 	assert.Len(t, output.Ignored, 1)
@@ -859,6 +940,324 @@ func TestMarkdownFormatter_Format_Duplicates(t *testing.T) {
 // Helper Function Tests
 // =============================================================================
 
+func TestGitHubFormatter_Format(t *testing.T) {
+	t.Parallel()
+
+	report := newTestReport()
+	report.Results = append(report.Results, checker.Result{
+		Link:   checker.Link{URL: "https://dead.example.com", FilePath: "docs/a,b.md", Line: 9},
+		Status: checker.StatusDuplicate, DuplicateOf: &report.Results[2],
+	})
+	report.Internal = []InternalLink{{URL: "guide.md", File: "README.md", Line: 3, Message: "file not found"}}
+
+	data, err := (&GitHubFormatter{}).Format(report)
+	require.NoError(t, err)
+
+	assert.Equal(t, strings.Join([]string{
+		"::warning file=README.md,line=20,title=Redirect::https://old.example.com redirects to https://new.example.com",
+		"::error file=docs/guide.md,line=5,title=Dead link::https://dead.example.com returned HTTP 404",
+		"::error file=docs/guide.md,line=15,title=Dead link::https://error.example.com: connection refused",
+		"::error file=docs/a%2Cb.md,line=9,title=Dead link::https://dead.example.com returned HTTP 404",
+		"::error file=README.md,line=3,title=Broken internal link::guide.md: file not found",
+		"::notice title=gone::10 link(s) checked: 5 alive, 3 warning(s), 2 dead",
+	}, "\n")+"\n", string(data))
+}
+
+func TestWriteStepSummary(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "summary.md")
+	require.NoError(t, os.WriteFile(path, []byte("Earlier step\n"), 0o600))
+	t.Setenv(StepSummaryEnv, path)
+
+	require.NoError(t, WriteStepSummary(newTestReport()))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), "Earlier step\n# Gone Link Check Report"))
+
+	t.Setenv(StepSummaryEnv, "")
+	assert.NoError(t, WriteStepSummary(newTestReport()))
+}
+
+func TestCSVFormatter_Format(t *testing.T) {
+	t.Parallel()
+
+	report := newTestReport()
+	report.Results[0].Link.Text = `Say "hi", world`
+
+	data, err := (&CSVFormatter{}).Format(report)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	require.Len(t, lines, 5)
+	assert.Equal(t, "url,file_path,line,column,status,status_code,error,final_url,duplicate_of,"+
+		"suggestion,text,parser,link_type,duration_ms", lines[0])
+	assert.Equal(t, `https://example.com,README.md,10,7,alive,200,,,,,"Say ""hi"", world",,,0`, lines[1])
+	assert.Equal(t, "https://old.example.com,README.md,20,,redirect,301,,https://new.example.com,,,Old Link,,,0",
+		lines[2])
+	assert.Equal(t, "https://error.example.com,docs/guide.md,15,,error,,connection refused,,,,Error Link,,,0",
+		lines[4])
+}
+
+func TestNDJSONFormatter_Format(t *testing.T) {
+	t.Parallel()
+
+	report := newTestReport()
+	report.Violations = []PolicyViolation{{URL: "http://bad.example.com", File: "README.md", Reason: "domain"}}
+
+	data, err := (&NDJSONFormatter{}).Format(report)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	types := make([]string, len(lines))
+	for i, line := range lines {
+		var record map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &record), line)
+		types[i], _ = record["type"].(string)
+	}
+	assert.Equal(t, []string{"result", "result", "result", "result", "ignored", "policy_violation", "summary"}, types)
+
+	var first jsonResult
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	assert.Equal(t, "https://example.com", first.URL)
+	assert.Equal(t, 7, first.Column)
+
+	var summary ndjsonSummary
+	require.NoError(t, json.Unmarshal([]byte(lines[len(lines)-1]), &summary))
+	assert.Equal(t, 5, summary.Alive)
+	assert.Equal(t, 10, summary.TotalLinks)
+	assert.Equal(t, 1, summary.Violations)
+}
+
+func TestNDJSONWriter_Streaming(t *testing.T) {
+	t.Parallel()
+
+	report := newTestReport()
+	var buf strings.Builder
+	w := NewNDJSONWriter(&buf)
+
+	require.NoError(t, w.WriteResult(report.Results[2]))
+	assert.Equal(t, 1, strings.Count(buf.String(), "\n"), "results are written as they arrive")
+	assert.Contains(t, buf.String(), `"url":"https://dead.example.com"`)
+
+	require.NoError(t, w.WriteTrailer(report))
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 3)
+	assert.Contains(t, lines[1], `"type":"ignored"`)
+	assert.Contains(t, lines[2], `"type":"summary"`)
+}
+
+func TestTAPFormatter_Format(t *testing.T) {
+	t.Parallel()
+
+	report := newTestReport()
+	report.Results = append(report.Results, checker.Result{
+		Link:   checker.Link{URL: "https://dead.example.com", FilePath: "README.md", Line: 40},
+		Status: checker.StatusDuplicate, DuplicateOf: &report.Results[2],
+	})
+	report.Results[0].Link.URL = "https://example.com/#install"
+	report.Internal = []InternalLink{
+		{URL: "guide.md", File: "README.md", Line: 3, Problem: "missing-file", Message: "file not found"},
+	}
+
+	data, err := (&TAPFormatter{}).Format(report)
+	require.NoError(t, err)
+
+	assert.Equal(t, `TAP version 13
+1..5
+ok 1 - https://example.com/\#install
+  ---
+  severity: pass
+  status: alive
+  status_code: 200
+  locations:
+    - README.md:10
+  ...
+ok 2 - https://old.example.com
+  ---
+  severity: warning
+  status: redirect
+  status_code: 301
+  message: URL redirected but final destination works. Consider updating the URL.
+  final_url: https://new.example.com
+  locations:
+    - README.md:20
+  ...
+not ok 3 - https://dead.example.com
+  ---
+  severity: fail
+  status: dead
+  status_code: 404
+  locations:
+    - docs/guide.md:5
+    - README.md:40
+  ...
+not ok 4 - https://error.example.com
+  ---
+  severity: fail
+  status: error
+  message: connection refused
+  locations:
+    - docs/guide.md:15
+  ...
+not ok 5 - guide.md
+  ---
+  severity: fail
+  status: missing-file
+  message: file not found
+  locations:
+    - README.md:3
+  ...
+`, string(data))
+}
+
+func TestPrometheusFormatter_Format(t *testing.T) {
+	t.Parallel()
+
+	report := newTestReport()
+	report.Results = append(report.Results, checker.Result{
+		Link:   checker.Link{URL: "https://dead.example.com", FilePath: `docs/"quoted".md`, Line: 2},
+		Status: checker.StatusDuplicate, DuplicateOf: &report.Results[2],
+	})
+	report.Duration = 1500 * time.Millisecond
+
+	data, err := (&PrometheusFormatter{}).Format(report)
+	require.NoError(t, err)
+	out := string(data)
+
+	assert.Contains(t, out, "# TYPE gone_links_total gauge\n")
+	assert.Contains(t, out, "gone_links_total{file=\"README.md\"} 2\n")
+	assert.Contains(t, out, "gone_links_alive{file=\"README.md\"} 1\n")
+	assert.Contains(t, out, "gone_links_warnings{file=\"README.md\"} 1\n")
+	assert.Contains(t, out, "gone_links_dead{file=\"docs/guide.md\"} 2\n")
+	assert.Contains(t, out, `gone_links_dead{file="docs/\"quoted\".md"} 1`+"\n")
+	assert.Contains(t, out, "gone_files_scanned 2\n")
+	assert.Contains(t, out, "gone_check_duration_seconds 1.500\n")
+	assert.Contains(t, out, "gone_last_run_timestamp_seconds 1705314600\n")
+}
+
+func TestTemplateFormatter_Format(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "slack.tmpl")
+	require.NoError(t, os.WriteFile(path, []byte(
+		`{{len .Results}} links, {{len (.Results | dead)}} dead in {{duration .Duration}}
+{{range groupBy "file" (.Results | warnings)}}{{.Title}}:{{range .Results}} {{.Link.URL}}{{end}}
+{{end}}{{range .Results | dead | sortBy "url" | first 1}}{{json .Link.URL}} {{.StatusCode}}{{end}}
+{{range .Results | dead}}{{details .}}{{end}}
+`), 0o600))
+	tmpl, err := ParseTemplate(path)
+	require.NoError(t, err)
+
+	report := newTestReport()
+	report.Duration = 1500 * time.Millisecond
+	report.Template = tmpl
+
+	data, err := (&TemplateFormatter{}).Format(report)
+	require.NoError(t, err)
+	assert.Equal(t, `4 links, 2 dead in 1.5s
+README.md (1 link): https://old.example.com
+"https://dead.example.com" 404
+connection refused
+`, string(data))
+}
+
+func TestTemplateFormatter_Format_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := (&TemplateFormatter{}).Format(newTestReport())
+	require.ErrorContains(t, err, "needs a template file")
+
+	path := filepath.Join(t.TempDir(), "bad.tmpl")
+	require.NoError(t, os.WriteFile(path, []byte(`{{range groupBy "status" .Results}}{{end}}`), 0o600))
+	tmpl, err := ParseTemplate(path)
+	require.NoError(t, err)
+	report := newTestReport()
+	report.Template = tmpl
+	_, err = (&TemplateFormatter{}).Format(report)
+	require.ErrorContains(t, err, `invalid grouping "status"`)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{{.Results | nosuchfunc}}`), 0o600))
+	_, err = ParseTemplate(path)
+	require.ErrorContains(t, err, "parsing template")
+}
+
+func TestWriteMetricsFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "gone.prom")
+	require.NoError(t, os.WriteFile(path, []byte("stale\n"), 0o600))
+
+	require.NoError(t, WriteMetricsFile(newTestReport(), path))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), "# HELP gone_links_total"))
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "no temporary file is left behind")
+}
+
+func TestBaseline_Compare(t *testing.T) {
+	t.Parallel()
+
+	previous := newTestReport()
+	previous.Results = append(previous.Results, checker.Result{
+		Link:   checker.Link{URL: "https://dead.example.com", FilePath: "README.md", Line: 40},
+		Status: checker.StatusDuplicate, DuplicateOf: &previous.Results[2],
+	})
+	data, err := (&JSONFormatter{}).Format(previous)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	baseline, err := LoadBaseline(path)
+	require.NoError(t, err)
+
+	// The dead link moved to another line, the errored link works now, the
+	// duplicate in README.md is gone, and a new dead link and a second dead
+	// occurrence in docs/guide.md appear
+	current := []checker.Result{
+		{
+			Link:   checker.Link{URL: "https://dead.example.com", FilePath: "docs/guide.md", Line: 8},
+			Status: checker.StatusDead,
+		},
+		{
+			Link:   checker.Link{URL: "https://error.example.com", FilePath: "docs/guide.md", Line: 15},
+			Status: checker.StatusAlive,
+		},
+		{
+			Link:   checker.Link{URL: "https://new.example.com", FilePath: "README.md", Line: 3},
+			Status: checker.StatusDead,
+		},
+	}
+	current = append(current, checker.Result{
+		Link:   checker.Link{URL: "https://dead.example.com", FilePath: "docs/guide.md", Line: 30},
+		Status: checker.StatusDuplicate, DuplicateOf: &current[0],
+	})
+
+	broken, fixed := baseline.Compare(current)
+
+	require.Len(t, broken, 2)
+	assert.Equal(t, "https://new.example.com", broken[0].Link.URL)
+	assert.Equal(t, 30, broken[1].Link.Line)
+	assert.Equal(t, checker.StatusDead, broken[1].Status, "duplicates are resolved")
+
+	require.Len(t, fixed, 2)
+	assert.Equal(t, "https://error.example.com", fixed[0].Link.URL)
+	assert.Equal(t, "README.md", fixed[1].Link.FilePath)
+}
+
+func TestLoadBaseline_Invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := LoadBaseline(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	require.NoError(t, os.WriteFile(path, []byte("not json"), 0o600))
+	_, err = LoadBaseline(path)
+	assert.Error(t, err)
+}
+
 func TestTruncateText(t *testing.T) {
 	t.Parallel()
 
@@ -1443,3 +1842,1337 @@ func TestBuildErrorContent_NoLinkText(t *testing.T) {
 	assert.NotContains(t, content, "Link text")
 	assert.Contains(t, content, "timeout")
 }
+
+func TestFormatters_LongRedirect(t *testing.T) {
+	t.Parallel()
+
+	report := newMinimalReport()
+	report.Summary = checker.Summary{Total: 1, UniqueURLs: 1, LongRedirects: 1}
+	report.Results = []checker.Result{{
+		Link:       checker.Link{URL: "https://a.example.com", FilePath: "README.md", Line: 3},
+		StatusCode: 301,
+		Status:     checker.StatusLongRedirect,
+		RedirectChain: []checker.Redirect{
+			{URL: "https://a.example.com", StatusCode: 301, Duration: 120 * time.Millisecond},
+			{URL: "https://b.example.com", StatusCode: 302, Duration: 45 * time.Millisecond},
+			{URL: "https://c.example.com", StatusCode: 302},
+		},
+		FinalURL:    "https://d.example.com",
+		FinalStatus: 200,
+	}}
+
+	data, err := (&JSONFormatter{}).Format(report)
+	require.NoError(t, err)
+	var jo jsonOutput
+	require.NoError(t, json.Unmarshal(data, &jo))
+	assert.Equal(t, 1, jo.Summary.LongRedirects)
+	require.Len(t, jo.Results, 1)
+	assert.Equal(t, "long-redirect", jo.Results[0].Status)
+	assert.Equal(t, 3, jo.Results[0].ChainLength)
+	assert.Equal(t, int64(120), jo.Results[0].RedirectChain[0].DurationMs)
+	read, err := ReadJSONReport(data)
+	require.NoError(t, err)
+	assert.Equal(t, report.Results[0].RedirectChain, read.Results[0].RedirectChain)
+
+	data, err = (&XMLFormatter{}).Format(report)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `length="3"`)
+	assert.Contains(t, string(data), "<long_redirects>1</long_redirects>")
+
+	data, err = (&MarkdownFormatter{}).Format(report)
+	require.NoError(t, err)
+	md := string(data)
+	assert.Contains(t, md, "| Long Redirects | 1 |")
+	assert.Contains(t, md, "LONG REDIRECT")
+	assert.Contains(t, md, "  - Chain (3 hop(s)):\n"+
+		"    1. `301` https://a.example.com (120ms)\n"+
+		"    2. `302` https://b.example.com (45ms)\n"+
+		"    3. `302` https://c.example.com\n"+
+		"    4. Final: `200` https://d.example.com\n")
+
+	data, err = (&HTMLFormatter{}).Format(report)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `<li><code>301</code> https://a.example.com <span class="latency">120ms</span></li>`)
+	assert.Contains(t, string(data), `<li><code>200</code> https://d.example.com <span class="latency">final</span></li>`)
+}
+
+func TestFormatters_Context(t *testing.T) {
+	t.Parallel()
+
+	report := newTestReport()
+	report.Results[0].Link.Context = "See [Example](https://example.com)."
+	report.Results[2].Link.Context = "Read the `guide` [Dead Link](https://dead.example.com) first."
+
+	data, err := (&JSONFormatter{}).Format(report)
+	require.NoError(t, err)
+	var jo jsonOutput
+	require.NoError(t, json.Unmarshal(data, &jo))
+	assert.Empty(t, jo.Results[0].Context, "only dead links get context")
+	assert.Equal(t, report.Results[2].Link.Context, jo.Results[2].Context)
+	read, err := ReadJSONReport(data)
+	require.NoError(t, err)
+	assert.Equal(t, report.Results[2].Link.Context, read.Results[2].Link.Context)
+
+	data, err = (&MarkdownFormatter{}).Format(report)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "- **Context:** ``Read the `guide` [Dead Link](https://dead.example.com) first.``\n")
+	assert.NotContains(t, string(data), "See [Example]")
+
+	data, err = (&HTMLFormatter{}).Format(report)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "<code>Read the `guide` [Dead Link](https://dead.example.com) first.</code>")
+
+	data, err = (&JUnitFormatter{}).Format(report)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "Context: Read the `guide`")
+}
+
+func TestJSONSchema(t *testing.T) {
+	t.Parallel()
+
+	data, err := JSONSchema()
+	require.NoError(t, err)
+	published, err := os.ReadFile(filepath.Join("..", "..", "schema", "report.schema.json"))
+	require.NoError(t, err)
+	assert.Equal(t, string(published), string(data),
+		"schema/report.schema.json is out of date; regenerate it with 'go run . schema > schema/report.schema.json'")
+
+	var schema jsonSchema
+	require.NoError(t, json.Unmarshal(data, &schema))
+	assert.Equal(t, SchemaVersion, int(schema.Properties["schema_version"].Const.(float64)))
+
+	// Every field of a report is described, and every required one is there
+	report := newTestReport()
+	report.UniqueDead = checker.UniqueDead(report.Results)
+	data, err = (&JSONFormatter{}).Format(report)
+	require.NoError(t, err)
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(data, &doc))
+	assertMatchesSchema(t, &schema, schema.Defs, doc, "report")
+}
+
+// assertMatchesSchema checks that the properties of an object are the
+// ones its schema describes, recursing into objects and arrays of objects.
+func assertMatchesSchema(t *testing.T, s *jsonSchema, defs map[string]*jsonSchema, v any, path string) {
+	t.Helper()
+	if s.Ref != "" {
+		s = defs[strings.TrimPrefix(s.Ref, "#/$defs/")]
+	}
+	switch v := v.(type) {
+	case map[string]any:
+		if s.Properties == nil {
+			return // A map, such as by_parser
+		}
+		for _, name := range s.Required {
+			assert.Contains(t, v, name, "%s is missing required %s", path, name)
+		}
+		for name, value := range v {
+			prop, ok := s.Properties[name]
+			if assert.True(t, ok, "%s.%s isn't in the schema", path, name) {
+				assertMatchesSchema(t, prop, defs, value, path+"."+name)
+			}
+		}
+	case []any:
+		for i, item := range v {
+			assertMatchesSchema(t, s.Items, defs, item, fmt.Sprintf("%s[%d]", path, i))
+		}
+	}
+}
+
+func TestReadJSONReport_NewerSchema(t *testing.T) {
+	t.Parallel()
+
+	_, err := ReadJSONReport([]byte(`{"schema_version": 99, "results": []}`))
+	require.ErrorContains(t, err, "schema version 99")
+
+	report, err := ReadJSONReport([]byte(`{"results": []}`))
+	require.NoError(t, err, "reports from before schema_version are read")
+	assert.Empty(t, report.Results)
+}
+
+func TestFormatters_BrokenAnchor(t *testing.T) {
+	t.Parallel()
+
+	report := newMinimalReport()
+	report.Summary = checker.Summary{Total: 1, UniqueURLs: 1, BrokenAnchors: 1}
+	report.Results = []checker.Result{{
+		Link:       checker.Link{URL: "https://a.example.com/docs#setup", FilePath: "README.md", Line: 3},
+		StatusCode: 200,
+		Status:     checker.StatusBrokenAnchor,
+		Anchor:     "setup",
+	}}
+
+	data, err := (&JSONFormatter{}).Format(report)
+	require.NoError(t, err)
+	var jo jsonOutput
+	require.NoError(t, json.Unmarshal(data, &jo))
+	assert.Equal(t, 1, jo.Summary.BrokenAnchors)
+	require.Len(t, jo.Results, 1)
+	assert.Equal(t, "broken-anchor", jo.Results[0].Status)
+	assert.Equal(t, "setup", jo.Results[0].Anchor)
+
+	data, err = (&YAMLFormatter{}).Format(report)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "anchor: setup")
+
+	data, err = (&XMLFormatter{}).Format(report)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "<anchor>setup</anchor>")
+	assert.Contains(t, string(data), "<broken_anchors>1</broken_anchors>")
+
+	data, err = (&MarkdownFormatter{}).Format(report)
+	require.NoError(t, err)
+	md := string(data)
+	assert.Contains(t, md, "| Broken Anchors | 1 |")
+	assert.Contains(t, md, "BROKEN ANCHOR")
+	assert.Contains(t, md, "Missing anchor: `#setup`")
+
+	data, err = (&HTMLFormatter{}).Format(report)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "Missing anchor #setup")
+}
+
+func TestFormatters_InternalLinks(t *testing.T) {
+	t.Parallel()
+
+	report := newMinimalReport()
+	report.Internal = []InternalLink{{
+		URL:     "./docs/setup.md",
+		File:    "README.md",
+		Problem: "missing-file",
+		Message: "docs/setup.md does not exist",
+		Line:    7,
+	}}
+
+	data, err := (&JSONFormatter{}).Format(report)
+	require.NoError(t, err)
+	var jo jsonOutput
+	require.NoError(t, json.Unmarshal(data, &jo))
+	assert.Equal(t, 1, jo.Summary.Internal)
+	require.Len(t, jo.Internal, 1)
+	assert.Equal(t, "missing-file", jo.Internal[0].Problem)
+	assert.Equal(t, 7, jo.Internal[0].Line)
+
+	back, err := ReadJSONReport(data)
+	require.NoError(t, err)
+	assert.Equal(t, report.Internal, back.Internal)
+
+	data, err = (&YAMLFormatter{}).Format(report)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "problem: missing-file")
+
+	data, err = (&XMLFormatter{}).Format(report)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `<link problem="missing-file">`)
+
+	data, err = (&MarkdownFormatter{}).Format(report)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "## Broken Internal Links (1)")
+
+	data, err = (&HTMLFormatter{}).Format(report)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "docs/setup.md does not exist")
+}
+
+func TestFormatters_TLS(t *testing.T) {
+	t.Parallel()
+
+	results := []checker.Result{
+		{
+			Link:   checker.Link{URL: "https://expired.example.com", FilePath: "README.md", Line: 3},
+			Status: checker.StatusTLSError,
+			Error:  "tls: failed to verify certificate: x509: certificate has expired or is not yet valid",
+			TLS:    &checker.TLSInfo{Host: "expired.example.com", Problem: checker.TLSExpired, Detail: "expired"},
+		},
+		{
+			Link:       checker.Link{URL: "https://self-signed.example.com", FilePath: "README.md", Line: 5},
+			Status:     checker.StatusInsecure,
+			StatusCode: 200,
+			TLS: &checker.TLSInfo{
+				Host: "self-signed.example.com", Problem: checker.TLSUnknownAuthority, Detail: "unknown authority",
+			},
+		},
+	}
+	report := &Report{
+		GeneratedAt: time.Now(),
+		TotalLinks:  len(results),
+		UniqueURLs:  len(results),
+		Results:     results,
+		Summary:     checker.Summarize(results),
+	}
+	assert.Equal(t, 1, report.Summary.TLSErrors)
+	assert.Equal(t, 1, report.Summary.Errors)
+	assert.Equal(t, 1, report.Summary.Insecure)
+
+	data, err := (&JSONFormatter{}).Format(report)
+	require.NoError(t, err)
+	var jo jsonOutput
+	require.NoError(t, json.Unmarshal(data, &jo))
+	assert.Equal(t, 1, jo.Summary.TLSErrors)
+	assert.Equal(t, 1, jo.Summary.Insecure)
+	require.Len(t, jo.Results, 2)
+	assert.Equal(t, "tls-error", jo.Results[0].Status)
+	require.NotNil(t, jo.Results[0].TLS)
+	assert.Equal(t, "expired", jo.Results[0].TLS.Problem)
+
+	back, err := ReadJSONReport(data)
+	require.NoError(t, err)
+	assert.Equal(t, results[1].TLS, back.Results[1].TLS)
+	assert.Equal(t, report.Summary.Insecure, back.Summary.Insecure)
+
+	data, err = (&YAMLFormatter{}).Format(report)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "problem: unknown-authority")
+
+	data, err = (&XMLFormatter{}).Format(report)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `<tls host="expired.example.com" problem="expired">`)
+
+	data, err = (&MarkdownFormatter{}).Format(report)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "| TLS Errors | 1 |")
+	assert.Contains(t, string(data), "### Insecure Certificates")
+
+	data, err = (&JUnitFormatter{}).Format(report)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `type="tls-error"`)
+}
+
+// =============================================================================
+// Shield JSON Formatter Tests
+// =============================================================================
+
+func TestShieldJSONFormatter_Format(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		summary checker.Summary
+		message string
+		color   string
+	}{
+		{
+			name:    "DeadLinks",
+			summary: checker.Summary{Alive: 5, Redirects: 2, Dead: 1, Errors: 1},
+			message: "2 dead",
+			color:   "red",
+		},
+		{
+			name:    "WarningsOnly",
+			summary: checker.Summary{Alive: 5, Redirects: 2, Blocked: 1},
+			message: "3 warnings",
+			color:   "yellow",
+		},
+		{
+			name:    "AllAlive",
+			summary: checker.Summary{Alive: 7},
+			message: "7 alive",
+			color:   "brightgreen",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			report := newMinimalReport()
+			report.Summary = tt.summary
+
+			data, err := FormatReport(report, FormatShieldJSON)
+			require.NoError(t, err)
+
+			var badge map[string]any
+			require.NoError(t, json.Unmarshal(data, &badge))
+			assert.InDelta(t, 1, badge["schemaVersion"], 0)
+			assert.Equal(t, "links", badge["label"])
+			assert.Equal(t, tt.message, badge["message"])
+			assert.Equal(t, tt.color, badge["color"])
+		})
+	}
+}
+
+// =============================================================================
+// Link Breakdown Tests
+// =============================================================================
+
+func TestFormatters_Breakdown(t *testing.T) {
+	t.Parallel()
+
+	report := newTestReport()
+	report.Summary.ByParser = map[string]int{"md": 3, "json": 1}
+	report.Summary.ByLinkType = map[string]int{"inline": 3, "autolink": 1}
+	report.Results[0].Link.Parser = "md"
+	report.Results[0].Link.Type = "inline"
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+		data, err := FormatReport(report, FormatJSON)
+		require.NoError(t, err)
+
+		var out jsonOutput
+		require.NoError(t, json.Unmarshal(data, &out))
+		assert.Equal(t, report.Summary.ByParser, out.Summary.ByParser)
+		assert.Equal(t, report.Summary.ByLinkType, out.Summary.ByLinkType)
+		assert.Equal(t, "md", out.Results[0].Parser)
+		assert.Equal(t, "inline", out.Results[0].LinkType)
+	})
+
+	t.Run("YAML", func(t *testing.T) {
+		t.Parallel()
+		data, err := FormatReport(report, FormatYAML)
+		require.NoError(t, err)
+
+		var out yamlOutput
+		require.NoError(t, yaml.Unmarshal(data, &out))
+		assert.Equal(t, report.Summary.ByParser, out.Summary.ByParser)
+		assert.Equal(t, report.Summary.ByLinkType, out.Summary.ByLinkType)
+	})
+
+	t.Run("XML", func(t *testing.T) {
+		t.Parallel()
+		data, err := FormatReport(report, FormatXML)
+		require.NoError(t, err)
+
+		content := string(data)
+		assert.Contains(t, content, `<count name="json">1</count>`)
+		assert.Contains(t, content, `<count name="md">3</count>`)
+		assert.Contains(t, content, `parser="md" link_type="inline"`)
+	})
+
+	t.Run("JUnit", func(t *testing.T) {
+		t.Parallel()
+		data, err := FormatReport(report, FormatJUnit)
+		require.NoError(t, err)
+
+		content := string(data)
+		assert.Contains(t, content, `<property name="parser.md" value="3"></property>`)
+		assert.Contains(t, content, `<property name="link_type.autolink" value="1"></property>`)
+	})
+
+	t.Run("Markdown", func(t *testing.T) {
+		t.Parallel()
+		data, err := FormatReport(report, FormatMarkdown)
+		require.NoError(t, err)
+
+		content := string(data)
+		assert.Contains(t, content, "## Link Sources")
+		assert.Contains(t, content, "| Parser | Count |")
+		assert.Contains(t, content, "| json | 1 |")
+		assert.Contains(t, content, "| Link Type | Count |")
+	})
+
+	t.Run("EmptyBreakdownOmitted", func(t *testing.T) {
+		t.Parallel()
+		data, err := FormatReport(newMinimalReport(), FormatJSON)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "by_parser")
+
+		data, err = FormatReport(newMinimalReport(), FormatMarkdown)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "Link Sources")
+	})
+}
+
+func TestFormatters_Hints(t *testing.T) {
+	t.Parallel()
+
+	report := newTestReport()
+	report.Summary.NoIndex = 1
+	report.Results[0].Robots = []string{"noindex", "nofollow"}
+	report.Results[0].Hints = []string{"target is marked noindex; it may be a deprecation stub"}
+
+	data, err := FormatReport(report, FormatJSON)
+	require.NoError(t, err)
+	var out jsonOutput
+	require.NoError(t, json.Unmarshal(data, &out))
+	assert.Equal(t, 1, out.Summary.NoIndex)
+	assert.Equal(t, []string{"noindex", "nofollow"}, out.Results[0].Robots)
+	assert.Len(t, out.Results[0].Hints, 1)
+
+	data, err = FormatReport(report, FormatXML)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "<robots>noindex, nofollow</robots>")
+	assert.Contains(t, string(data), "<hint>target is marked noindex")
+
+	data, err = FormatReport(report, FormatMarkdown)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "## Hints (1)")
+	assert.Contains(t, string(data), "| Noindex | 1 |")
+}
+
+func TestFormatters_UniqueDead(t *testing.T) {
+	t.Parallel()
+
+	dead := checker.Result{
+		Link:       checker.Link{URL: "https://dead.com", FilePath: "a.md", Line: 1},
+		Status:     checker.StatusDead,
+		StatusCode: 404,
+	}
+	report := newTestReport()
+	report.UniqueDead = []checker.UniqueURL{{
+		Result:    dead,
+		Locations: []checker.Link{dead.Link, {URL: "https://dead.com", FilePath: "b.md", Line: 9}},
+	}}
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+		data, err := FormatReport(report, FormatJSON)
+		require.NoError(t, err)
+
+		var out jsonOutput
+		require.NoError(t, json.Unmarshal(data, &out))
+		require.Len(t, out.UniqueDead, 1)
+		assert.Equal(t, 2, out.UniqueDead[0].Count)
+		assert.Equal(t, "dead", out.UniqueDead[0].Status)
+		assert.Equal(t, "b.md", out.UniqueDead[0].Locations[1].FilePath)
+	})
+
+	t.Run("YAML", func(t *testing.T) {
+		t.Parallel()
+		data, err := FormatReport(report, FormatYAML)
+		require.NoError(t, err)
+
+		var out yamlOutput
+		require.NoError(t, yaml.Unmarshal(data, &out))
+		require.Len(t, out.UniqueDead, 1)
+		assert.Equal(t, 9, out.UniqueDead[0].Locations[1].Line)
+	})
+
+	t.Run("XML", func(t *testing.T) {
+		t.Parallel()
+		data, err := FormatReport(report, FormatXML)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `<url href="https://dead.com" status="dead" status_code="404" count="2">`)
+		assert.Contains(t, string(data), `<location file="b.md" line="9"></location>`)
+	})
+
+	t.Run("Markdown", func(t *testing.T) {
+		t.Parallel()
+		data, err := FormatReport(report, FormatMarkdown)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "## Unique Dead URLs (1)")
+		assert.Contains(t, string(data), "| 2 | `a.md:1`, `b.md:9` |")
+	})
+
+	t.Run("OmittedByDefault", func(t *testing.T) {
+		t.Parallel()
+		data, err := FormatReport(newTestReport(), FormatJSON)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "unique_dead")
+	})
+}
+
+func TestFormatters_SkippedFiles(t *testing.T) {
+	t.Parallel()
+
+	report := newTestReport()
+	report.Skipped = []SkippedFile{{File: "huge.md", Reason: "parsing took longer than 10s"}}
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+		data, err := FormatReport(report, FormatJSON)
+		require.NoError(t, err)
+
+		var out jsonOutput
+		require.NoError(t, json.Unmarshal(data, &out))
+		require.Len(t, out.Skipped, 1)
+		assert.Equal(t, "huge.md", out.Skipped[0].File)
+	})
+
+	t.Run("YAML", func(t *testing.T) {
+		t.Parallel()
+		data, err := FormatReport(report, FormatYAML)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "skipped_files:")
+	})
+
+	t.Run("XML", func(t *testing.T) {
+		t.Parallel()
+		data, err := FormatReport(report, FormatXML)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `<file path="huge.md">`)
+	})
+
+	t.Run("Markdown", func(t *testing.T) {
+		t.Parallel()
+		data, err := FormatReport(report, FormatMarkdown)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "## Skipped Files (1)")
+		assert.Contains(t, string(data), "| huge.md | parsing took longer than 10s |")
+	})
+}
+
+func TestFormatters_ParseErrors(t *testing.T) {
+	t.Parallel()
+
+	report := newTestReport()
+	report.ParseErrors = []ParseError{{File: "config.json", Line: 3, Error: "invalid JSON: unexpected end"}}
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+		data, err := FormatReport(report, FormatJSON)
+		require.NoError(t, err)
+
+		var out jsonOutput
+		require.NoError(t, json.Unmarshal(data, &out))
+		require.Len(t, out.ParseErrors, 1)
+		assert.Equal(t, jsonParseError{File: "config.json", Error: "invalid JSON: unexpected end", Line: 3}, out.ParseErrors[0])
+		assert.Equal(t, 1, out.Summary.ParseErrors)
+	})
+
+	t.Run("YAML", func(t *testing.T) {
+		t.Parallel()
+		data, err := FormatReport(report, FormatYAML)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "parse_errors:")
+	})
+
+	t.Run("XML", func(t *testing.T) {
+		t.Parallel()
+		data, err := FormatReport(report, FormatXML)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `<file path="config.json" line="3">`)
+	})
+
+	t.Run("Markdown", func(t *testing.T) {
+		t.Parallel()
+		data, err := FormatReport(report, FormatMarkdown)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "| Parse Errors | 1 |")
+		assert.Contains(t, string(data), "## Parse Errors (1)")
+		assert.Contains(t, string(data), "| config.json | 3 | invalid JSON: unexpected end |")
+	})
+}
+
+func TestFormatters_Lint(t *testing.T) {
+	t.Parallel()
+
+	report := newTestReport()
+	report.Lint = []LintFinding{{
+		URL:     "https://evil.example.net",
+		File:    "README.md",
+		Line:    4,
+		Rule:    "text-url-mismatch",
+		Message: "link text shows https://docs.example.com but points to https://evil.example.net",
+	}}
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+		data, err := FormatReport(report, FormatJSON)
+		require.NoError(t, err)
+
+		var out jsonOutput
+		require.NoError(t, json.Unmarshal(data, &out))
+		require.Len(t, out.Lint, 1)
+		assert.Equal(t, "text-url-mismatch", out.Lint[0].Rule)
+		assert.Equal(t, 4, out.Lint[0].Line)
+	})
+
+	t.Run("YAML", func(t *testing.T) {
+		t.Parallel()
+		data, err := FormatReport(report, FormatYAML)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "rule: text-url-mismatch")
+	})
+
+	t.Run("XML", func(t *testing.T) {
+		t.Parallel()
+		data, err := FormatReport(report, FormatXML)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `<finding rule="text-url-mismatch">`)
+	})
+
+	t.Run("Markdown", func(t *testing.T) {
+		t.Parallel()
+		data, err := FormatReport(report, FormatMarkdown)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "## Lint (1)")
+	})
+}
+
+func TestFormatters_PolicyViolations(t *testing.T) {
+	t.Parallel()
+
+	report := newTestReport()
+	report.Violations = []PolicyViolation{{
+		URL:    "https://wiki.intranet.corp/page",
+		File:   "docs/guide.md",
+		Line:   12,
+		Reason: "domain",
+		Rule:   "intranet.corp",
+	}}
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+		data, err := FormatReport(report, FormatJSON)
+		require.NoError(t, err)
+
+		var out jsonOutput
+		require.NoError(t, json.Unmarshal(data, &out))
+		require.Len(t, out.Violations, 1)
+		assert.Equal(t, "intranet.corp", out.Violations[0].Rule)
+		assert.Equal(t, 1, out.Summary.Violations)
+	})
+
+	t.Run("YAML", func(t *testing.T) {
+		t.Parallel()
+		data, err := FormatReport(report, FormatYAML)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "policy_violations:")
+	})
+
+	t.Run("XML", func(t *testing.T) {
+		t.Parallel()
+		data, err := FormatReport(report, FormatXML)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "<policy_violations>")
+		assert.Contains(t, string(data), "<rule>intranet.corp</rule>")
+	})
+
+	t.Run("Markdown", func(t *testing.T) {
+		t.Parallel()
+		data, err := FormatReport(report, FormatMarkdown)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "## Policy Violations (1)")
+		assert.Contains(t, string(data), "| Policy Violations | 1 |")
+	})
+}
+
+func TestReadJSONLinks(t *testing.T) {
+	t.Parallel()
+
+	report := newTestReport()
+	data, err := FormatReport(report, FormatJSON)
+	require.NoError(t, err)
+
+	links, err := ReadJSONLinks(data)
+	require.NoError(t, err)
+	require.NotEmpty(t, links)
+	assert.Equal(t, "https://example.com", links[0].URL)
+	assert.Equal(t, "README.md", links[0].FilePath)
+	assert.Equal(t, 10, links[0].Line)
+	assert.Equal(t, 7, links[0].Column)
+	assert.Equal(t, 160, links[0].Offset)
+
+	yamlData, err := FormatReport(report, FormatYAML)
+	require.NoError(t, err)
+	assert.Contains(t, string(yamlData), "column: 7")
+
+	_, err = ReadJSONLinks([]byte("not json"))
+	require.Error(t, err)
+}
+
+func TestFormatters_Protocol(t *testing.T) {
+	t.Parallel()
+
+	report := newTestReport()
+	report.Results[0].Protocol = "HTTP/3.0"
+
+	data, err := FormatReport(report, FormatJSON)
+	require.NoError(t, err)
+	var out jsonOutput
+	require.NoError(t, json.Unmarshal(data, &out))
+	assert.Equal(t, "HTTP/3.0", out.Results[0].Protocol)
+
+	data, err = FormatReport(report, FormatYAML)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "protocol: HTTP/3.0")
+
+	data, err = FormatReport(report, FormatXML)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `protocol="HTTP/3.0"`)
+}
+
+func TestFormatters_Suggestion(t *testing.T) {
+	t.Parallel()
+
+	report := newTestReport()
+	report.Results[1].Suggestion = &checker.Suggestion{URL: "https://new.example.com", Source: "redirect"}
+	report.Results[2].Suggestion = &checker.Suggestion{URL: "https://alive.example.com", Source: "rewrite"}
+
+	data, err := FormatReport(report, FormatJSON)
+	require.NoError(t, err)
+	var out jsonOutput
+	require.NoError(t, json.Unmarshal(data, &out))
+	require.NotNil(t, out.Results[1].Suggestion)
+	assert.Equal(t, "https://new.example.com", out.Results[1].Suggestion.URL)
+	assert.Equal(t, "redirect", out.Results[1].Suggestion.Source)
+	assert.Nil(t, out.Results[0].Suggestion)
+
+	data, err = FormatReport(report, FormatYAML)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "url: https://alive.example.com")
+
+	data, err = FormatReport(report, FormatXML)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `<suggestion source="rewrite">https://alive.example.com</suggestion>`)
+
+	data, err = FormatReport(report, FormatMarkdown)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "**Suggested fix:** https://alive.example.com (rewrite)")
+
+	data, err = FormatReport(report, FormatJUnit)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "Suggested fix: https://alive.example.com (rewrite)")
+}
+
+func TestFormatters_LastModified(t *testing.T) {
+	t.Parallel()
+
+	report := newTestReport()
+	report.Results[0].LastModified = time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)
+	report.Results[0].Age = 3000 * 24 * time.Hour
+	report.Results[0].Stale = true
+	report.Summary.Stale = 1
+
+	data, err := FormatReport(report, FormatJSON)
+	require.NoError(t, err)
+	var out jsonOutput
+	require.NoError(t, json.Unmarshal(data, &out))
+	assert.Equal(t, "2015-01-01T00:00:00Z", out.Results[0].LastModified)
+	assert.Equal(t, 3000, out.Results[0].AgeDays)
+	assert.True(t, out.Results[0].Stale)
+	assert.Equal(t, 1, out.Summary.Stale)
+	assert.Empty(t, out.Results[1].LastModified)
+
+	data, err = FormatReport(report, FormatYAML)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "age_days: 3000")
+
+	data, err = FormatReport(report, FormatXML)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `last_modified="2015-01-01T00:00:00Z"`)
+	assert.Contains(t, string(data), `stale="true"`)
+
+	data, err = FormatReport(report, FormatMarkdown)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "| Stale | 1 |")
+}
+
+func TestSummarizeDomains(t *testing.T) {
+	t.Parallel()
+
+	primary := checker.Result{
+		Link:     checker.Link{URL: "https://docs.example.com/a"},
+		Status:   checker.StatusDead,
+		Duration: 200 * time.Millisecond,
+	}
+	results := []checker.Result{
+		primary,
+		{Link: checker.Link{URL: "https://docs.example.com/a"}, Status: checker.StatusDuplicate, DuplicateOf: &primary},
+		{Link: checker.Link{URL: "https://DOCS.example.com/b"}, Status: checker.StatusAlive, Duration: 100 * time.Millisecond},
+		{Link: checker.Link{URL: "https://other.com"}, Status: checker.StatusAlive},
+	}
+	ignored := []IgnoredURL{
+		{URL: "http://localhost:3000", Reason: "domain", Rule: "localhost"},
+		{URL: "http://localhost:8080", Reason: "domain", Rule: "localhost"},
+	}
+	violations := []PolicyViolation{{URL: "https://other.com/x", Reason: "domain", Rule: "other.com"}}
+
+	domains := SummarizeDomains(results, ignored, violations)
+	require.Len(t, domains, 3)
+
+	assert.Equal(t, "docs.example.com", domains[0].Domain)
+	assert.Equal(t, 3, domains[0].Links)
+	assert.Equal(t, 300*time.Millisecond, domains[0].Duration)
+	assert.Equal(t, "1 alive, 2 dead", domains[0].StatusList())
+
+	assert.Equal(t, "localhost", domains[1].Domain)
+	assert.Equal(t, 0, domains[1].Links)
+	assert.Equal(t, 2, domains[1].Ignored)
+	assert.Equal(t, []string{"ignore domain: localhost"}, domains[1].Rules)
+
+	assert.Equal(t, "other.com", domains[2].Domain)
+	assert.Equal(t, 1, domains[2].Denied)
+	assert.Equal(t, []string{"deny domain: other.com"}, domains[2].Rules)
+
+	report := newTestReport()
+	report.Domains = domains
+	data, err := FormatReport(report, FormatMarkdown)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "## Domains (3)")
+	assert.Contains(t, string(data), "| docs.example.com | 3 | 1 alive, 2 dead | 0 | 0 |  | 300ms |")
+	assert.Contains(t, string(data), "`ignore domain: localhost`")
+}
+
+func TestGroupResults(t *testing.T) {
+	t.Parallel()
+
+	primary := checker.Result{
+		Link:   checker.Link{URL: "https://down.example.com/a", FilePath: "README.md", Line: 3},
+		Status: checker.StatusDead, StatusCode: 404,
+	}
+	results := []checker.Result{
+		{Link: checker.Link{URL: "https://ok.com", FilePath: "README.md", Line: 1}, Status: checker.StatusAlive},
+		primary,
+		{
+			Link:   checker.Link{URL: "https://down.example.com/a", FilePath: "docs/guide.md", Line: 8},
+			Status: checker.StatusDuplicate, DuplicateOf: &primary,
+		},
+		{
+			Link:   checker.Link{URL: "https://DOWN.example.com/b", FilePath: "docs/guide.md", Line: 9},
+			Status: checker.StatusError, Error: "timeout",
+		},
+	}
+
+	assert.Nil(t, GroupResults(results, GroupByStatus))
+
+	byDomain := GroupResults(results, GroupByDomain)
+	require.Len(t, byDomain, 2)
+	assert.Equal(t, "down.example.com", byDomain[0].Key)
+	assert.Equal(t, 3, byDomain[0].Dead)
+	assert.Equal(t, "down.example.com (3 links, 3 dead)", byDomain[0].Title())
+	assert.Equal(t, "ok.com (1 link)", byDomain[1].Title())
+
+	byFile := GroupResults(results, GroupByFile)
+	require.Len(t, byFile, 2)
+	assert.Equal(t, "docs/guide.md", byFile[0].Key)
+	assert.Equal(t, "README.md", byFile[1].Key)
+	assert.Equal(t, results[:2], byFile[1].Results)
+
+	report := newTestReport()
+	report.Results = results
+	report.GroupBy = GroupByFile
+	data, err := FormatReport(report, FormatMarkdown)
+	require.NoError(t, err)
+	md := string(data)
+	assert.Contains(t, md, "## docs/guide.md (2 links, 2 dead)")
+	assert.Contains(t, md, "| DUPLICATE | https://down.example.com/a |  | 8 | same as README.md:3 (DEAD) |")
+	assert.Contains(t, md, "| ERROR | https://DOWN.example.com/b |  | 9 | timeout |")
+	assert.NotContains(t, md, "## Dead Links")
+
+	report.GroupBy = GroupByDomain
+	data, err = FormatReport(report, FormatHTML)
+	require.NoError(t, err)
+	html := string(data)
+	assert.Contains(t, html, "<h2>down.example.com (3 links, 3 dead)</h2>")
+	assert.Contains(t, html, "<td>docs/guide.md:9</td>")
+	assert.NotContains(t, html, "Dead Links (")
+
+	g, ok := ParseGroupBy("")
+	assert.True(t, ok)
+	assert.Equal(t, GroupByStatus, g)
+	_, ok = ParseGroupBy("host")
+	assert.False(t, ok)
+}
+
+func TestMarkdownFormatter_Format_Inventory(t *testing.T) {
+	t.Parallel()
+
+	report := newTestReport()
+	report.Inventory = []checker.Result{
+		{Link: checker.Link{URL: "https://b.com", FilePath: "docs/guide.md", Line: 7}, Status: checker.StatusDead,
+			StatusCode: 404},
+		{Link: checker.Link{URL: "https://a.com", FilePath: "README.md", Line: 4, Text: "A"},
+			Status: checker.StatusAlive, StatusCode: 200},
+		{Link: checker.Link{URL: "https://c.com", FilePath: "README.md", Line: 2}, Status: checker.StatusRedirect,
+			FinalURL: "https://c.com/", RedirectChain: []checker.Redirect{{URL: "https://c.com", StatusCode: 301}}},
+	}
+
+	data, err := FormatReport(report, FormatMarkdown)
+	require.NoError(t, err)
+	md := string(data)
+	assert.Contains(t, md, "## Full Inventory (3)")
+	assert.Less(t, strings.Index(md, "### README.md (2 links)"), strings.Index(md, "### docs/guide.md (1 link, 1 dead)"))
+	assert.Less(t, strings.Index(md, "| 2 | REDIRECT | https://c.com |  | redirects to https://c.com/ |"),
+		strings.Index(md, "| 4 | OK | https://a.com | A |  |"))
+	assert.Contains(t, md, "| 7 | `404` | https://b.com |  |  |")
+
+	report.Inventory = nil
+	data, err = FormatReport(report, FormatMarkdown)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "Full Inventory")
+}
+
+func TestOrderResults(t *testing.T) {
+	t.Parallel()
+
+	primary := checker.Result{
+		Link:   checker.Link{URL: "https://c.example.com", FilePath: "b.md", Line: 2},
+		Status: checker.StatusDead, Duration: 300 * time.Millisecond,
+	}
+	results := []checker.Result{
+		{Link: checker.Link{URL: "https://b.example.com", FilePath: "b.md", Line: 1}, Status: checker.StatusAlive,
+			Duration: 100 * time.Millisecond},
+		{Link: checker.Link{URL: "https://a.example.com", FilePath: "a.md", Line: 9}, Status: checker.StatusRedirect,
+			Duration: 500 * time.Millisecond},
+		primary,
+		{Link: checker.Link{URL: "https://c.example.com", FilePath: "a.md", Line: 3}, Status: checker.StatusDuplicate,
+			DuplicateOf: &primary},
+	}
+	locations := func(results []checker.Result) []string {
+		var out []string
+		for _, r := range results {
+			out = append(out, r.Link.FilePath+":"+strconv.Itoa(r.Link.Line))
+		}
+		return out
+	}
+
+	assert.Equal(t, locations(results), locations(OrderResults(results, "", 0)))
+	assert.Equal(t, []string{"a.md:9", "b.md:1", "b.md:2", "a.md:3"}, locations(OrderResults(results, SortURL, 0)))
+	assert.Equal(t, []string{"b.md:2", "a.md:3", "a.md:9", "b.md:1"}, locations(OrderResults(results, SortStatus, 0)))
+	assert.Equal(t, []string{"a.md:3", "a.md:9", "b.md:1", "b.md:2"}, locations(OrderResults(results, SortFile, 0)))
+	assert.Equal(t, []string{"a.md:9", "b.md:2"}, locations(OrderResults(results, SortLatency, 2)))
+	assert.Len(t, OrderResults(results, "", 10), 4)
+	assert.Equal(t, "b.md:1", locations(results)[0], "results must not be modified")
+
+	_, ok := ParseSortKey("speed")
+	assert.False(t, ok)
+}
+
+func TestJUnitFormatter_Format_DuplicateOfDead(t *testing.T) {
+	t.Parallel()
+
+	primary := checker.Result{
+		Link:       checker.Link{URL: "https://dead.example.com", FilePath: "a.md", Line: 1},
+		Status:     checker.StatusDead,
+		StatusCode: 404,
+	}
+	report := &Report{
+		GeneratedAt: time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC),
+		Results: []checker.Result{
+			primary,
+			{
+				Link:        checker.Link{URL: "https://dead.example.com", FilePath: "b.md", Line: 7},
+				Status:      checker.StatusDuplicate,
+				StatusCode:  404,
+				DuplicateOf: &primary,
+			},
+		},
+	}
+
+	data, err := FormatReport(report, FormatJUnit)
+	require.NoError(t, err)
+	content := string(data)
+	assert.Contains(t, content, `failures="2"`)
+	assert.Contains(t, content, `classname="a.md:1"`)
+	assert.Contains(t, content, `classname="b.md:7"`)
+}
+
+func TestJUnitFormatter_Format_IncludePassed(t *testing.T) {
+	t.Parallel()
+
+	primary := checker.Result{
+		Link:   checker.Link{URL: "https://a.example.com/x", FilePath: "b.md", Line: 1},
+		Status: checker.StatusDead, StatusCode: 404, Duration: 1500 * time.Millisecond,
+	}
+	report := &Report{
+		GeneratedAt: time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC),
+		Results: []checker.Result{
+			primary,
+			{
+				Link:   checker.Link{URL: "https://ok.com", FilePath: "a.md", Line: 2},
+				Status: checker.StatusAlive, StatusCode: 200, Duration: 250 * time.Millisecond,
+			},
+			{
+				Link:   checker.Link{URL: "https://a.example.com/x", FilePath: "a.md", Line: 3},
+				Status: checker.StatusDuplicate, DuplicateOf: &primary,
+			},
+			{
+				Link:   checker.Link{URL: "https://ok.com/later", FilePath: "a.md", Line: 4},
+				Status: checker.StatusSkipped, Error: "max requests reached",
+			},
+		},
+	}
+
+	parse := func() junitTestSuites {
+		data, err := FormatReport(report, FormatJUnit)
+		require.NoError(t, err)
+		var suites junitTestSuites
+		require.NoError(t, xml.Unmarshal([]byte(strings.TrimPrefix(string(data), xml.Header)), &suites))
+		return suites
+	}
+
+	suites := parse()
+	assert.Equal(t, 2, suites.Tests)
+	assert.Equal(t, "1.500", suites.Time)
+	require.Len(t, suites.TestSuite, 2)
+	assert.Equal(t, "a.md", suites.TestSuite[0].Name)
+	assert.Equal(t, "0.000", suites.TestSuite[0].TestCases[0].Time)
+	assert.Equal(t, "1.500", suites.TestSuite[1].TestCases[0].Time)
+
+	report.JUnitIncludePassed = true
+	suites = parse()
+	assert.Equal(t, 4, suites.Tests)
+	assert.Equal(t, 2, suites.Failures)
+	assert.Equal(t, 1, suites.Skipped)
+	assert.Equal(t, "1.750", suites.Time)
+	require.Len(t, suites.TestSuite, 2)
+	assert.Equal(t, 3, suites.TestSuite[0].Tests)
+	assert.Nil(t, suites.TestSuite[0].TestCases[0].Failure)
+	require.NotNil(t, suites.TestSuite[0].TestCases[2].Skipped)
+	assert.Equal(t, "max requests reached", suites.TestSuite[0].TestCases[2].Skipped.Message)
+
+	report.JUnitSuitePer = GroupByDomain
+	suites = parse()
+	require.Len(t, suites.TestSuite, 2)
+	assert.Equal(t, "a.example.com", suites.TestSuite[0].Name)
+	assert.Equal(t, 2, suites.TestSuite[0].Failures)
+	assert.Equal(t, "ok.com", suites.TestSuite[1].Name)
+
+	g, ok := ParseJUnitSuitePer("")
+	assert.True(t, ok)
+	assert.Equal(t, GroupByFile, g)
+	_, ok = ParseJUnitSuitePer("status")
+	assert.False(t, ok)
+}
+
+// =============================================================================
+// HTML Formatter Tests
+// =============================================================================
+
+func TestHTMLFormatter_Format(t *testing.T) {
+	t.Parallel()
+
+	report := newTestReport()
+	report.Results = append(report.Results, checker.Result{
+		Link:   checker.Link{URL: "https://example.com/?q=<script>", FilePath: "README.md", Line: 40},
+		Status: checker.StatusError,
+		Error:  "timeout",
+	})
+	report.ParseErrors = []ParseError{{File: "broken.json", Line: 3, Error: "invalid character"}}
+
+	data, err := (&HTMLFormatter{}).Format(report)
+	require.NoError(t, err)
+	html := string(data)
+
+	assert.True(t, strings.HasPrefix(html, "<!DOCTYPE html>"))
+	assert.Contains(t, html, "<strong>Files Scanned:</strong> 2")
+	assert.Contains(t, html, "<tr><td>Dead</td><td>2</td></tr>")
+	assert.Contains(t, html, "Dead Links (3)")
+	assert.Contains(t, html, `<td class="dead">404</td>`)
+	assert.Contains(t, html, "https://new.example.com")
+	assert.Contains(t, html, "Parse Errors (1)")
+	assert.Contains(t, html, "&lt;script&gt;")
+	assert.NotContains(t, html, "<script>")
+	assert.NotContains(t, html, "Policy Violations")
+}
+
+// =============================================================================
+// Report Merge Tests
+// =============================================================================
+
+func TestReadJSONReport(t *testing.T) {
+	t.Parallel()
+
+	report := newTestReport()
+	report.Results[2].Suggestion = &checker.Suggestion{URL: "https://new.example.com/dead", Source: "rewrite"}
+	report.Results = append(report.Results, checker.Result{
+		Link:        checker.Link{URL: "https://dead.example.com", FilePath: "README.md", Line: 50},
+		Status:      checker.StatusDuplicate,
+		StatusCode:  404,
+		DuplicateOf: &report.Results[2],
+	})
+	report.ParseErrors = []ParseError{{File: "broken.json", Line: 3, Error: "invalid character"}}
+	report.Violations = []PolicyViolation{
+		{URL: "http://bad.example", File: "README.md", Line: 7, Reason: "domain", Rule: "bad.example"},
+	}
+
+	data, err := (&JSONFormatter{}).Format(report)
+	require.NoError(t, err)
+
+	got, err := ReadJSONReport(data)
+	require.NoError(t, err)
+
+	assert.True(t, report.GeneratedAt.Equal(got.GeneratedAt))
+	assert.Empty(t, got.Files)
+	assert.Equal(t, 2, got.FilesScanned())
+	assert.Equal(t, report.TotalLinks, got.TotalLinks)
+	assert.Equal(t, report.Summary.Dead, got.Summary.Dead)
+	assert.Equal(t, report.Summary.Errors, got.Summary.Errors)
+	require.Len(t, got.Results, 5)
+
+	redirect := got.Results[1]
+	assert.Equal(t, checker.StatusRedirect, redirect.Status)
+	assert.Equal(t, "https://new.example.com", redirect.FinalURL)
+	require.Len(t, redirect.RedirectChain, 1)
+	assert.Equal(t, 301, redirect.RedirectChain[0].StatusCode)
+
+	dead := got.Results[2]
+	assert.Equal(t, checker.Link{
+		URL: "https://dead.example.com", FilePath: "docs/guide.md", Line: 5, Text: "Dead Link",
+	}, dead.Link)
+	require.NotNil(t, dead.Suggestion)
+	assert.Equal(t, "rewrite", dead.Suggestion.Source)
+
+	dup := got.Results[4]
+	assert.Equal(t, checker.StatusDuplicate, dup.Status)
+	require.NotNil(t, dup.DuplicateOf)
+	assert.Equal(t, "https://dead.example.com", dup.DuplicateOf.Link.URL)
+
+	assert.Equal(t, report.Ignored, got.Ignored)
+	assert.Equal(t, report.ParseErrors, got.ParseErrors)
+	assert.Equal(t, report.Violations, got.Violations)
+}
+
+func TestReadJSONReport_Invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := ReadJSONReport([]byte("not json"))
+	require.Error(t, err)
+
+	_, err = ReadJSONReport([]byte(`{"results":[{"url":"https://example.com","status":"bogus"}]}`))
+	require.ErrorContains(t, err, "bogus")
+}
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	dead := checker.Result{
+		Link:       checker.Link{URL: "https://dead.example.com", FilePath: "docs/a.md", Line: 1, Parser: "md"},
+		Status:     checker.StatusDead,
+		StatusCode: 404,
+	}
+	shared := checker.Result{
+		Link:       checker.Link{URL: "https://moved.example.com", FilePath: "README.md", Line: 3, Parser: "md"},
+		Status:     checker.StatusRedirect,
+		StatusCode: 301,
+	}
+
+	// Shard A scanned docs/ and README.md; 3 alive links aren't listed
+	a := &Report{
+		GeneratedAt: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
+		FileCount:   2,
+		TotalLinks:  5,
+		UniqueURLs:  5,
+		Summary: checker.Summary{
+			Total: 5, UniqueURLs: 5, Alive: 3, Redirects: 1, Dead: 1,
+			ByParser: map[string]int{"md": 5},
+		},
+		Results:     []checker.Result{dead, shared},
+		ParseErrors: []ParseError{{File: "docs/broken.json", Error: "invalid character"}},
+	}
+
+	// Shard B scanned api/ and README.md again, and links to the same dead URL
+	deadAgain := dead
+	deadAgain.Link.FilePath = "api/b.md"
+	b := &Report{
+		GeneratedAt: time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC),
+		Files:       []string{"api/b.md", "README.md", "api/c.md"},
+		TotalLinks:  4,
+		UniqueURLs:  4,
+		Summary: checker.Summary{
+			Total: 4, UniqueURLs: 4, Alive: 2, Redirects: 1, Dead: 1,
+			ByParser: map[string]int{"md": 4},
+		},
+		Results:     []checker.Result{deadAgain, shared},
+		ParseErrors: []ParseError{{File: "docs/broken.json", Error: "invalid character"}},
+		UniqueDead:  checker.UniqueDead([]checker.Result{deadAgain}),
+	}
+
+	merged := Merge(a, b)
+
+	assert.True(t, b.GeneratedAt.Equal(merged.GeneratedAt))
+	assert.Equal(t, 5, merged.FilesScanned())
+	require.Len(t, merged.Results, 3)
+	assert.Equal(t, 8, merged.TotalLinks)
+	assert.Equal(t, 7, merged.UniqueURLs)
+
+	assert.Equal(t, checker.StatusDead, merged.Results[0].Status)
+	dup := merged.Results[2]
+	assert.Equal(t, "api/b.md", dup.Link.FilePath)
+	assert.Equal(t, checker.StatusDuplicate, dup.Status)
+	require.NotNil(t, dup.DuplicateOf)
+	assert.Equal(t, "docs/a.md", dup.DuplicateOf.Link.FilePath)
+
+	s := merged.Summary
+	assert.Equal(t, 8, s.Total)
+	assert.Equal(t, 5, s.Alive)
+	assert.Equal(t, 1, s.Redirects)
+	assert.Equal(t, 1, s.Dead)
+	assert.Equal(t, 1, s.Duplicates)
+	assert.Equal(t, map[string]int{"md": 8}, s.ByParser)
+
+	assert.Len(t, merged.ParseErrors, 1)
+	require.Len(t, merged.UniqueDead, 1)
+	assert.Equal(t, 2, merged.UniqueDead[0].Count())
+}
+
+func TestFormatters_Timing(t *testing.T) {
+	t.Parallel()
+
+	timing := checker.Timing{
+		DNS:     12 * time.Millisecond,
+		Connect: 30 * time.Millisecond,
+		TLS:     45 * time.Millisecond,
+		TTFB:    120 * time.Millisecond,
+		Total:   210 * time.Millisecond,
+	}
+	results := []checker.Result{
+		{
+			Link:   checker.Link{URL: "https://slow.example.com", FilePath: "README.md", Line: 1},
+			Status: checker.StatusError,
+			Error:  "context deadline exceeded",
+			Timing: timing,
+		},
+		{
+			Link:       checker.Link{URL: "https://cached.example.com", FilePath: "README.md", Line: 2},
+			Status:     checker.StatusAlive,
+			StatusCode: 200,
+		},
+	}
+	report := &Report{GeneratedAt: time.Now(), Results: results, Summary: checker.Summarize(results)}
+
+	data, err := (&JSONFormatter{}).Format(report)
+	require.NoError(t, err)
+	var jo jsonOutput
+	require.NoError(t, json.Unmarshal(data, &jo))
+	require.Len(t, jo.Results, 2)
+	require.NotNil(t, jo.Results[0].Timing)
+	assert.Equal(t, int64(45), jo.Results[0].Timing.TLSMs)
+	assert.Equal(t, int64(210), jo.Results[0].Timing.TotalMs)
+	assert.Nil(t, jo.Results[1].Timing)
+
+	back, err := ReadJSONReport(data)
+	require.NoError(t, err)
+	assert.Equal(t, timing, back.Results[0].Timing)
+	assert.True(t, back.Results[1].Timing.IsZero())
+
+	data, err = (&YAMLFormatter{}).Format(report)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "ttfb_ms: 120")
+	assert.Equal(t, 1, strings.Count(string(data), "timing:"))
+}
+
+func TestFormatters_Retries(t *testing.T) {
+	t.Parallel()
+
+	results := []checker.Result{
+		{
+			Link:       checker.Link{URL: "https://flaky.example.com", FilePath: "README.md", Line: 1},
+			Status:     checker.StatusAlive,
+			StatusCode: 200,
+			Attempts:   3,
+			Backoff:    3200 * time.Millisecond,
+		},
+		{
+			Link:       checker.Link{URL: "https://ok.example.com", FilePath: "README.md", Line: 2},
+			Status:     checker.StatusAlive,
+			StatusCode: 200,
+			Attempts:   1,
+		},
+	}
+	report := &Report{GeneratedAt: time.Now(), Results: results, Summary: checker.Summarize(results)}
+
+	data, err := (&JSONFormatter{}).Format(report)
+	require.NoError(t, err)
+	var jo jsonOutput
+	require.NoError(t, json.Unmarshal(data, &jo))
+	assert.Equal(t, 2, jo.Summary.Retries)
+	assert.Equal(t, 1, jo.Summary.RetriedURLs)
+	assert.Equal(t, int64(3200), jo.Summary.BackoffMs)
+	require.Len(t, jo.Results, 2)
+	assert.Equal(t, 2, jo.Results[0].Retries)
+	assert.Zero(t, jo.Results[1].Retries)
+
+	back, err := ReadJSONReport(data)
+	require.NoError(t, err)
+	assert.Equal(t, report.Summary.Retries, back.Summary.Retries)
+	assert.Equal(t, report.Summary.Backoff, back.Summary.Backoff)
+	assert.Equal(t, 3, back.Results[0].Attempts)
+	assert.Equal(t, 3200*time.Millisecond, back.Results[0].Backoff)
+
+	data, err = (&MarkdownFormatter{}).Format(report)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "| Retries | 2 (1 URLs) |")
+}
@@ -0,0 +1,122 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// PrometheusFormatter formats reports as gauges in the Prometheus text
+// format, such as gone_links_dead{file="README.md"} 2, for node_exporter's
+// textfile collector or a Pushgateway. Per-file gauges count the results in
+// the report, so reports meant for metrics should include every result, not
+// only the failures.
+type PrometheusFormatter struct{}
+
+// fileCounts holds per-file link counts.
+type fileCounts struct {
+	total, alive, warnings, dead int
+}
+
+// Format implements Formatter.
+func (*PrometheusFormatter) Format(report *Report) ([]byte, error) {
+	byFile := map[string]*fileCounts{}
+	for _, r := range report.Results {
+		c, ok := byFile[r.Link.FilePath]
+		if !ok {
+			c = &fileCounts{}
+			byFile[r.Link.FilePath] = c
+		}
+		c.total++
+		switch r = r.Resolved(); {
+		case r.IsDead():
+			c.dead++
+		case r.IsWarning():
+			c.warnings++
+		case r.IsAlive():
+			c.alive++
+		}
+	}
+	files := make([]string, 0, len(byFile))
+	for file := range byFile {
+		files = append(files, file)
+	}
+	slices.Sort(files)
+
+	var b strings.Builder
+	perFile := func(name, help string, count func(*fileCounts) int) {
+		writeMetricHeader(&b, name, help)
+		for _, file := range files {
+			fmt.Fprintf(&b, "%s{file=\"%s\"} %d\n", name, escapeLabel(file), count(byFile[file]))
+		}
+	}
+	perFile("gone_links_total", "Links found, by file.", func(c *fileCounts) int { return c.total })
+	perFile("gone_links_alive", "Working links, by file.", func(c *fileCounts) int { return c.alive })
+	perFile("gone_links_warnings", "Links with warnings, such as redirects, by file.",
+		func(c *fileCounts) int { return c.warnings })
+	perFile("gone_links_dead", "Dead links and errors, by file.", func(c *fileCounts) int { return c.dead })
+
+	s := report.Summary
+	writeGauge(&b, "gone_files_scanned", "Files scanned.", strconv.Itoa(report.FilesScanned()))
+	writeGauge(&b, "gone_unique_urls", "Unique URLs checked.", strconv.Itoa(report.UniqueURLs))
+	writeGauge(&b, "gone_duplicates", "Links to a URL found earlier.", strconv.Itoa(s.Duplicates))
+	writeGauge(&b, "gone_skipped", "Links left unchecked when the budget ran out.", strconv.Itoa(s.Skipped))
+	writeGauge(&b, "gone_policy_violations", "Links matching a deny rule.", strconv.Itoa(len(report.Violations)))
+	writeGauge(&b, "gone_internal_links_broken", "Relative links to missing files or anchors.",
+		strconv.Itoa(len(report.Internal)))
+	writeGauge(&b, "gone_parse_errors", "Files that failed to parse.", strconv.Itoa(len(report.ParseErrors)))
+	if report.Duration > 0 {
+		writeGauge(&b, "gone_check_duration_seconds", "How long the run took.",
+			strconv.FormatFloat(report.Duration.Seconds(), 'f', 3, 64))
+	}
+	if !report.GeneratedAt.IsZero() {
+		writeGauge(&b, "gone_last_run_timestamp_seconds", "When the report was generated, as a Unix timestamp.",
+			strconv.FormatInt(report.GeneratedAt.Unix(), 10))
+	}
+	return []byte(b.String()), nil
+}
+
+// writeMetricHeader writes the HELP and TYPE lines of a gauge.
+func writeMetricHeader(b *strings.Builder, name, help string) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+}
+
+// writeGauge writes a gauge without labels.
+func writeGauge(b *strings.Builder, name, help, value string) {
+	writeMetricHeader(b, name, help)
+	fmt.Fprintf(b, "%s %s\n", name, value)
+}
+
+// escapeLabel escapes a label value: backslashes, double quotes, and newlines.
+func escapeLabel(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+// WriteMetricsFile writes a report's metrics to a file, replacing it
+// atomically so a collector never reads a partly written file.
+func WriteMetricsFile(report *Report, path string) error {
+	data, err := (&PrometheusFormatter{}).Format(report)
+	if err != nil {
+		return fmt.Errorf("formatting metrics: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("writing metrics: %w", err)
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("writing metrics: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package output
+
+import (
+	"cmp"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SchemaVersion is the version of the JSON report format, written to every
+// JSON report as schema_version. It's bumped when a field is removed,
+// renamed, or changes type; new fields don't bump it.
+const SchemaVersion = 1
+
+// schemaID identifies the JSON Schema of the report format.
+const schemaID = "https://raw.githubusercontent.com/leonardomso/gone/main/schema/report.schema.json"
+
+// jsonSchema is a JSON Schema (draft 2020-12) document or subschema.
+type jsonSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	ID                   string                 `json:"$id,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Const                any                    `json:"const,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+	AdditionalProperties *jsonSchema            `json:"additionalProperties,omitempty"`
+	Defs                 map[string]*jsonSchema `json:"$defs,omitempty"`
+}
+
+// JSONSchema returns the JSON Schema of the JSON report format, generated
+// from the structs JSONFormatter writes.
+func JSONSchema() ([]byte, error) {
+	defs := map[string]*jsonSchema{}
+	root := schemaForStruct(reflect.TypeFor[jsonOutput](), defs)
+	root.Schema = "https://json-schema.org/draft/2020-12/schema"
+	root.ID = schemaID
+	root.Title = "gone link check report"
+	root.Properties["schema_version"].Const = SchemaVersion
+	root.Defs = defs
+
+	data, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// schemaFor returns the schema of a Go type, adding the structs it refers
+// to to defs.
+func schemaFor(t reflect.Type, defs map[string]*jsonSchema) *jsonSchema {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return schemaFor(t.Elem(), defs)
+	case reflect.Struct:
+		name := defName(t)
+		if _, ok := defs[name]; !ok {
+			defs[name] = nil // Reserve the name before recursing
+			defs[name] = schemaForStruct(t, defs)
+		}
+		return &jsonSchema{Ref: "#/$defs/" + name}
+	case reflect.Slice, reflect.Array:
+		return &jsonSchema{Type: "array", Items: schemaFor(t.Elem(), defs)}
+	case reflect.Map:
+		return &jsonSchema{Type: "object", AdditionalProperties: schemaFor(t.Elem(), defs)}
+	case reflect.String:
+		return &jsonSchema{Type: "string"}
+	case reflect.Bool:
+		return &jsonSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &jsonSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &jsonSchema{Type: "number"}
+	default:
+		return &jsonSchema{}
+	}
+}
+
+// schemaForStruct returns the object schema of a struct, in which fields
+// without omitempty are required. Other properties are allowed, as fields
+// added without a SchemaVersion bump must not fail validation.
+func schemaForStruct(t reflect.Type, defs map[string]*jsonSchema) *jsonSchema {
+	s := &jsonSchema{Type: "object", Properties: map[string]*jsonSchema{}}
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		name = cmp.Or(name, field.Name)
+		s.Properties[name] = schemaFor(field.Type, defs)
+		if !strings.Contains(opts, "omitempty") && !strings.Contains(opts, "omitzero") {
+			s.Required = append(s.Required, name)
+		}
+	}
+	return s
+}
+
+// defName names a struct's definition after its type, without the json
+// prefix: jsonResult is "Result".
+func defName(t reflect.Type) string {
+	name := strings.TrimPrefix(t.Name(), "json")
+	r, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToUpper(r)) + name[size:]
+}
@@ -0,0 +1,49 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ShieldJSONFormatter formats reports as a shields.io endpoint badge.
+// See https://shields.io/badges/endpoint-badge for the schema.
+type ShieldJSONFormatter struct{}
+
+// shieldLabel is the left-hand text of the badge.
+const shieldLabel = "links"
+
+// shieldOutput is the shields.io endpoint JSON structure.
+type shieldOutput struct {
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         string `json:"color"`
+	SchemaVersion int    `json:"schemaVersion"`
+}
+
+// Format implements Formatter.
+func (*ShieldJSONFormatter) Format(report *Report) ([]byte, error) {
+	message, color := shieldMessage(report)
+	output := shieldOutput{
+		SchemaVersion: 1,
+		Label:         shieldLabel,
+		Message:       message,
+		Color:         color,
+	}
+	return json.MarshalIndent(output, "", "  ")
+}
+
+// shieldMessage picks the badge message and color from the report summary.
+// Dead links take precedence over warnings.
+//
+//nolint:gocritic // Named returns would make this function harder to read
+func shieldMessage(report *Report) (string, string) {
+	s := report.Summary
+	switch {
+	case s.HasDeadLinks():
+		return fmt.Sprintf("%d dead", s.Dead+s.Errors), "red"
+	case s.WarningsCount() > 0:
+		return fmt.Sprintf("%d warnings", s.WarningsCount()), "yellow"
+	default:
+		return fmt.Sprintf("%d alive", s.Alive), "brightgreen"
+	}
+}
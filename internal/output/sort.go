@@ -0,0 +1,87 @@
+package output
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/leonardomso/gone/internal/checker"
+)
+
+// SortKey selects the order results are listed in.
+type SortKey string
+
+const (
+	// SortURL orders results by URL.
+	SortURL SortKey = "url"
+	// SortStatus lists dead links first, then warnings, skipped links, and
+	// working links. Duplicates sort with the link they repeat.
+	SortStatus SortKey = "status"
+	// SortFile orders results by file, then position in the file.
+	SortFile SortKey = "file"
+	// SortLatency lists the slowest URLs first.
+	SortLatency SortKey = "latency"
+)
+
+// ParseSortKey returns the sort key named s. An empty s is valid and keeps
+// results in the order they were checked.
+func ParseSortKey(s string) (SortKey, bool) {
+	switch k := SortKey(strings.ToLower(s)); k {
+	case "", SortURL, SortStatus, SortFile, SortLatency:
+		return k, true
+	default:
+		return "", false
+	}
+}
+
+// SortKeyNames returns the names of all sort keys.
+func SortKeyNames() []string {
+	return []string{string(SortURL), string(SortStatus), string(SortFile), string(SortLatency)}
+}
+
+// OrderResults returns results sorted by key, keeping their order among
+// equal results, and cut to the first top results if top is positive.
+// results isn't modified. An empty key keeps the checked order.
+func OrderResults(results []checker.Result, key SortKey, top int) []checker.Result {
+	ordered := slices.Clone(results)
+	switch key {
+	case SortURL:
+		slices.SortStableFunc(ordered, func(a, b checker.Result) int {
+			return strings.Compare(a.Link.URL, b.Link.URL)
+		})
+	case SortStatus:
+		slices.SortStableFunc(ordered, func(a, b checker.Result) int {
+			return cmp.Compare(statusRank(a), statusRank(b))
+		})
+	case SortFile:
+		slices.SortStableFunc(ordered, func(a, b checker.Result) int {
+			return cmp.Or(
+				strings.Compare(a.Link.FilePath, b.Link.FilePath),
+				cmp.Compare(a.Link.Line, b.Link.Line),
+				cmp.Compare(a.Link.Column, b.Link.Column),
+			)
+		})
+	case SortLatency:
+		slices.SortStableFunc(ordered, func(a, b checker.Result) int {
+			return cmp.Compare(b.Resolved().Duration, a.Resolved().Duration)
+		})
+	}
+	if top > 0 && len(ordered) > top {
+		ordered = ordered[:top]
+	}
+	return ordered
+}
+
+// statusRank returns where a result sorts with SortStatus.
+func statusRank(r checker.Result) int {
+	switch r = r.Resolved(); {
+	case r.IsDead():
+		return 0
+	case r.IsWarning():
+		return 1
+	case r.Status == checker.StatusSkipped:
+		return 2
+	default:
+		return 3
+	}
+}
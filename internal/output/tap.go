@@ -0,0 +1,161 @@
+package output
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/leonardomso/gone/internal/checker"
+)
+
+// TAPFormatter formats reports as TAP version 13 (Test Anything Protocol),
+// for harnesses such as prove. Each unique URL is a test point, with a YAML
+// diagnostic block giving its status, status code, and every location it
+// appears at. Dead URLs are "not ok"; warnings pass, as they don't fail the
+// run. Policy violations, broken internal links, and malformed files are
+// failing test points of their own. See https://testanything.org/tap-version-13-specification.html.
+type TAPFormatter struct{}
+
+// tapPoint is a test point and its diagnostics.
+type tapPoint struct {
+	Description string
+	OK          bool
+	Diagnostic  tapDiagnostics
+}
+
+// tapDiagnostics is the YAML block under a test point.
+type tapDiagnostics struct {
+	Severity   string   `yaml:"severity"`
+	Status     string   `yaml:"status,omitempty"`
+	StatusCode int      `yaml:"status_code,omitempty"`
+	Message    string   `yaml:"message,omitempty"`
+	FinalURL   string   `yaml:"final_url,omitempty"`
+	Suggestion string   `yaml:"suggestion,omitempty"`
+	Locations  []string `yaml:"locations,omitempty"`
+}
+
+// Format implements Formatter.
+func (*TAPFormatter) Format(report *Report) ([]byte, error) {
+	points := tapResults(report.Results)
+	for _, v := range report.Violations {
+		points = append(points, tapPoint{
+			Description: v.URL,
+			Diagnostic: tapDiagnostics{
+				Severity:  "fail",
+				Status:    "policy_violation",
+				Message:   fmt.Sprintf("matches deny %s %q", v.Reason, v.Rule),
+				Locations: []string{location(v.File, v.Line)},
+			},
+		})
+	}
+	for _, il := range report.Internal {
+		points = append(points, tapPoint{
+			Description: il.URL,
+			Diagnostic: tapDiagnostics{
+				Severity:  "fail",
+				Status:    il.Problem,
+				Message:   il.Message,
+				Locations: []string{location(il.File, il.Line)},
+			},
+		})
+	}
+	for _, pe := range report.ParseErrors {
+		points = append(points, tapPoint{
+			Description: pe.File,
+			Diagnostic: tapDiagnostics{
+				Severity:  "fail",
+				Status:    "parse_error",
+				Message:   pe.Error,
+				Locations: []string{location(pe.File, pe.Line)},
+			},
+		})
+	}
+
+	var b strings.Builder
+	b.WriteString("TAP version 13\n")
+	fmt.Fprintf(&b, "1..%d\n", len(points))
+	for i, p := range points {
+		if !p.OK {
+			b.WriteString("not ")
+		}
+		fmt.Fprintf(&b, "ok %d - %s\n", i+1, tapDescription(p.Description))
+
+		var diagnostics strings.Builder
+		enc := yaml.NewEncoder(&diagnostics)
+		enc.SetIndent(2)
+		if err := enc.Encode(p.Diagnostic); err != nil {
+			return nil, fmt.Errorf("marshaling TAP diagnostics: %w", err)
+		}
+		b.WriteString("  ---\n")
+		for line := range strings.Lines(diagnostics.String()) {
+			b.WriteString("  " + line)
+		}
+		b.WriteString("  ...\n")
+	}
+	return []byte(b.String()), nil
+}
+
+// tapResults returns a test point for each unique URL among results, in
+// order of first appearance, with duplicates folded into their locations.
+func tapResults(results []checker.Result) []tapPoint {
+	index := map[string]int{}
+	var points []tapPoint
+	for _, r := range results {
+		i, ok := index[r.Link.URL]
+		if !ok {
+			i = len(points)
+			index[r.Link.URL] = i
+			points = append(points, newTAPPoint(r.Resolved()))
+		}
+		points[i].Diagnostic.Locations = append(points[i].Diagnostic.Locations,
+			location(r.Link.FilePath, r.Link.Line))
+	}
+	return points
+}
+
+// newTAPPoint returns the test point of a checked URL, without locations.
+func newTAPPoint(r checker.Result) tapPoint {
+	p := tapPoint{
+		Description: r.Link.URL,
+		OK:          !r.IsDead(),
+		Diagnostic: tapDiagnostics{
+			Severity:   "pass",
+			Status:     r.Status.String(),
+			StatusCode: r.StatusCode,
+			Message:    r.Error,
+		},
+	}
+	switch {
+	case r.IsDead():
+		p.Diagnostic.Severity = "fail"
+	case r.IsWarning():
+		p.Diagnostic.Severity = "warning"
+		if p.Diagnostic.Message == "" {
+			p.Diagnostic.Message = r.Status.Description()
+		}
+	}
+	if r.IsRedirect() {
+		p.Diagnostic.FinalURL = r.FinalURL
+	}
+	if r.Suggestion != nil {
+		p.Diagnostic.Suggestion = r.Suggestion.URL
+	}
+	return p
+}
+
+// location formats a file and line as file:line, or just the file if the line is unknown.
+func location(file string, line int) string {
+	if line == 0 {
+		return file
+	}
+	return file + ":" + strconv.Itoa(line)
+}
+
+// tapDescription escapes the characters that have a meaning in a test
+// point's description: # starts a directive.
+func tapDescription(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return strings.ReplaceAll(s, "#", `\#`)
+}
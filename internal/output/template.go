@@ -0,0 +1,103 @@
+package output
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/leonardomso/gone/internal/checker"
+	"github.com/leonardomso/gone/internal/stats"
+)
+
+// TemplateFormatter formats reports with the report's Template, for Slack
+// messages, wiki pages, or e-mail bodies no built-in format covers.
+// The template is executed with the *Report as its data.
+type TemplateFormatter struct{}
+
+// templateFuncs are the helper functions available to report templates.
+// Functions taking results take them last, so they work in pipelines:
+//
+//	{{range .Results | dead | sortBy "file" | first 10}}...{{end}}
+var templateFuncs = template.FuncMap{
+	"dead":       checker.FilterDead,
+	"warnings":   checker.FilterWarnings,
+	"alive":      checker.FilterAlive,
+	"duplicates": byStatus(checker.StatusDuplicate),
+	"skipped":    byStatus(checker.StatusSkipped),
+	"uniqueDead": checker.UniqueDead,
+	"groupBy":    groupByForTemplate,
+	"sortBy":     sortByForTemplate,
+	"first":      firstForTemplate,
+	"domain":     domainOf,
+	"details":    resultDetails,
+	"duration":   stats.FormatDuration,
+	"truncate":   func(n int, s string) string { return truncateText(s, n) },
+	"join":       func(sep string, elems []string) string { return strings.Join(elems, sep) },
+	"json":       jsonForTemplate,
+}
+
+// ParseTemplate parses the report template in the file at path, with the
+// helper functions available.
+func ParseTemplate(path string) (*template.Template, error) {
+	tmpl, err := template.New(filepath.Base(path)).Funcs(templateFuncs).ParseFiles(path)
+	if err != nil {
+		return nil, fmt.Errorf("parsing template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// Format implements Formatter.
+func (*TemplateFormatter) Format(report *Report) ([]byte, error) {
+	if report.Template == nil {
+		return nil, errors.New("the template format needs a template file")
+	}
+	var b strings.Builder
+	if err := report.Template.Execute(&b, report); err != nil {
+		return nil, fmt.Errorf("rendering template: %w", err)
+	}
+	return []byte(b.String()), nil
+}
+
+// byStatus returns a template function keeping the results with status.
+func byStatus(status checker.LinkStatus) func([]checker.Result) []checker.Result {
+	return func(results []checker.Result) []checker.Result {
+		return checker.FilterByStatus(results, status)
+	}
+}
+
+// groupByForTemplate groups results by "file" or "domain".
+func groupByForTemplate(by string, results []checker.Result) ([]ResultGroup, error) {
+	switch g, _ := ParseGroupBy(by); g {
+	case GroupByFile, GroupByDomain:
+		return GroupResults(results, g), nil
+	default:
+		return nil, fmt.Errorf("invalid grouping %q; valid groupings: file, domain", by)
+	}
+}
+
+// sortByForTemplate orders results by one of the SortKeyNames.
+func sortByForTemplate(key string, results []checker.Result) ([]checker.Result, error) {
+	k, ok := ParseSortKey(key)
+	if !ok || k == "" {
+		return nil, fmt.Errorf("invalid key %q; valid keys: %s", key, strings.Join(SortKeyNames(), ", "))
+	}
+	return OrderResults(results, k, 0), nil
+}
+
+// firstForTemplate returns the first n results.
+func firstForTemplate(n int, results []checker.Result) []checker.Result {
+	return OrderResults(results, "", n)
+}
+
+// jsonForTemplate encodes v as JSON, such as a string to embed in a JSON
+// payload for a webhook.
+func jsonForTemplate(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -2,6 +2,7 @@ package output
 
 import (
 	"encoding/xml"
+	"strings"
 )
 
 // XMLFormatter formats reports as generic XML.
@@ -9,24 +10,57 @@ type XMLFormatter struct{}
 
 // xmlOutput is the XML structure for output.
 type xmlOutput struct {
-	Ignored     *xmlIgnored `xml:"ignored,omitempty"`
-	XMLName     xml.Name    `xml:"report"`
-	GeneratedAt string      `xml:"generated_at,attr"`
-	Results     xmlResults  `xml:"results"`
-	Summary     xmlSummary  `xml:"summary"`
-	TotalFiles  int         `xml:"total_files,attr"`
-	TotalLinks  int         `xml:"total_links,attr"`
-	UniqueURLs  int         `xml:"unique_urls,attr"`
+	Ignored     *xmlIgnored     `xml:"ignored,omitempty"`
+	Skipped     *xmlSkipped     `xml:"skipped_files,omitempty"`
+	ParseErrors *xmlParseErrors `xml:"parse_errors,omitempty"`
+	Lint        *xmlLint        `xml:"lint,omitempty"`
+	Violations  *xmlIgnored     `xml:"policy_violations,omitempty"`
+	Internal    *xmlInternal    `xml:"internal_links,omitempty"`
+	UniqueDead  *xmlUniques     `xml:"unique_dead,omitempty"`
+	XMLName     xml.Name        `xml:"report"`
+	GeneratedAt string          `xml:"generated_at,attr"`
+	Results     xmlResults      `xml:"results"`
+	Summary     xmlSummary      `xml:"summary"`
+	TotalFiles  int             `xml:"total_files,attr"`
+	TotalLinks  int             `xml:"total_links,attr"`
+	UniqueURLs  int             `xml:"unique_urls,attr"`
 }
 
 type xmlSummary struct {
-	Alive      int `xml:"alive"`
-	Redirects  int `xml:"redirects"`
-	Blocked    int `xml:"blocked"`
-	Dead       int `xml:"dead"`
-	Errors     int `xml:"errors"`
-	Duplicates int `xml:"duplicates"`
-	Ignored    int `xml:"ignored,omitempty"`
+	Alive         int   `xml:"alive"`
+	Redirects     int   `xml:"redirects"`
+	LongRedirects int   `xml:"long_redirects,omitempty"`
+	LoginRequired int   `xml:"login_required,omitempty"`
+	BrokenAnchors int   `xml:"broken_anchors,omitempty"`
+	Insecure      int   `xml:"insecure,omitempty"`
+	Suspect       int   `xml:"suspect,omitempty"`
+	Blocked       int   `xml:"blocked"`
+	Dead          int   `xml:"dead"`
+	Errors        int   `xml:"errors"`
+	TLSErrors     int   `xml:"tls_errors,omitempty"`
+	Duplicates    int   `xml:"duplicates"`
+	Skipped       int   `xml:"skipped,omitempty"`
+	Retries       int   `xml:"retries,omitempty"`
+	RetriedURLs   int   `xml:"retried_urls,omitempty"`
+	BackoffMs     int64 `xml:"backoff_ms,omitempty"`
+	NoIndex       int   `xml:"noindex,omitempty"`
+	Stale         int   `xml:"stale,omitempty"`
+	Ignored       int   `xml:"ignored,omitempty"`
+	Violations    int   `xml:"policy_violations,omitempty"`
+	Internal      int   `xml:"internal_links,omitempty"`
+	ParseErrors   int   `xml:"parse_errors,omitempty"`
+
+	ByParser   *xmlCounts `xml:"by_parser,omitempty"`
+	ByLinkType *xmlCounts `xml:"by_link_type,omitempty"`
+}
+
+type xmlCounts struct {
+	Counts []xmlCount `xml:"count"`
+}
+
+type xmlCount struct {
+	Name  string `xml:"name,attr"`
+	Value int    `xml:",chardata"`
 }
 
 type xmlResults struct {
@@ -35,25 +69,116 @@ type xmlResults struct {
 
 type xmlResult struct {
 	RedirectChain *xmlRedirectChain `xml:"redirect_chain,omitempty"`
+	Suggestion    *xmlSuggest       `xml:"suggestion,omitempty"`
+	TLS           *xmlTLS           `xml:"tls,omitempty"`
 	Status        string            `xml:"status,attr"`
+	Parser        string            `xml:"parser,attr,omitempty"`
+	LinkType      string            `xml:"link_type,attr,omitempty"`
+	Protocol      string            `xml:"protocol,attr,omitempty"`
+	Resolver      string            `xml:"resolver,attr,omitempty"`
+	Profile       string            `xml:"profile,attr,omitempty"`
+	LastModified  string            `xml:"last_modified,attr,omitempty"`
 	URL           string            `xml:"url"`
 	FilePath      string            `xml:"file"`
 	Text          string            `xml:"text,omitempty"`
+	Context       string            `xml:"context,omitempty"`
 	Error         string            `xml:"error,omitempty"`
 	FinalURL      string            `xml:"final_url,omitempty"`
+	Anchor        string            `xml:"anchor,omitempty"`
 	DuplicateOf   string            `xml:"duplicate_of,omitempty"`
 	StatusCode    int               `xml:"status_code,attr"`
 	Line          int               `xml:"line,omitempty"`
 	FinalStatus   int               `xml:"final_status,omitempty"`
+	AgeDays       int               `xml:"age_days,attr,omitempty"`
+	DurationMs    int64             `xml:"duration_ms,attr,omitempty"`
+	Retries       int               `xml:"retries,attr,omitempty"`
+	BackoffMs     int64             `xml:"backoff_ms,attr,omitempty"`
+	Stale         bool              `xml:"stale,attr,omitempty"`
+	Robots        string            `xml:"robots,omitempty"`
+	Hints         []string          `xml:"hint,omitempty"`
+}
+
+type xmlTLS struct {
+	Host    string `xml:"host,attr"`
+	Problem string `xml:"problem,attr"`
+	Detail  string `xml:",chardata"`
+}
+
+type xmlSuggest struct {
+	Source string `xml:"source,attr"`
+	URL    string `xml:",chardata"`
 }
 
 type xmlRedirectChain struct {
 	Redirects []xmlRedirect `xml:"redirect"`
+	Length    int           `xml:"length,attr"`
 }
 
 type xmlRedirect struct {
 	URL        string `xml:"url,attr"`
 	StatusCode int    `xml:"status_code,attr"`
+	DurationMs int64  `xml:"duration_ms,attr,omitempty"`
+}
+
+type xmlUniques struct {
+	URLs []xmlUnique `xml:"url"`
+}
+
+type xmlUnique struct {
+	URL        string        `xml:"href,attr"`
+	Status     string        `xml:"status,attr"`
+	Error      string        `xml:"error,omitempty"`
+	Locations  []xmlLocation `xml:"location"`
+	StatusCode int           `xml:"status_code,attr"`
+	Count      int           `xml:"count,attr"`
+}
+
+type xmlLocation struct {
+	FilePath string `xml:"file,attr"`
+	Line     int    `xml:"line,attr,omitempty"`
+}
+
+type xmlLint struct {
+	Findings []xmlLintFinding `xml:"finding"`
+}
+
+type xmlLintFinding struct {
+	URL     string `xml:"url"`
+	File    string `xml:"file"`
+	Rule    string `xml:"rule,attr"`
+	Message string `xml:"message"`
+	Line    int    `xml:"line,omitempty"`
+}
+
+type xmlInternal struct {
+	Links []xmlInternalLink `xml:"link"`
+}
+
+type xmlInternalLink struct {
+	URL     string `xml:"url"`
+	File    string `xml:"file"`
+	Problem string `xml:"problem,attr"`
+	Message string `xml:"message"`
+	Line    int    `xml:"line,omitempty"`
+}
+
+type xmlParseErrors struct {
+	Files []xmlParseError `xml:"file"`
+}
+
+type xmlParseError struct {
+	File  string `xml:"path,attr"`
+	Error string `xml:"error"`
+	Line  int    `xml:"line,attr,omitempty"`
+}
+
+type xmlSkipped struct {
+	Files []xmlSkippedFile `xml:"file"`
+}
+
+type xmlSkippedFile struct {
+	File   string `xml:"path,attr"`
+	Reason string `xml:"reason"`
 }
 
 type xmlIgnored struct {
@@ -72,17 +197,34 @@ type xmlIgnoredItem struct {
 func (*XMLFormatter) Format(report *Report) ([]byte, error) {
 	output := xmlOutput{
 		GeneratedAt: report.GeneratedAt.Format("2006-01-02T15:04:05Z07:00"),
-		TotalFiles:  len(report.Files),
+		TotalFiles:  report.FilesScanned(),
 		TotalLinks:  report.TotalLinks,
 		UniqueURLs:  report.UniqueURLs,
 		Summary: xmlSummary{
-			Alive:      report.Summary.Alive,
-			Redirects:  report.Summary.Redirects,
-			Blocked:    report.Summary.Blocked,
-			Dead:       report.Summary.Dead,
-			Errors:     report.Summary.Errors,
-			Duplicates: report.Summary.Duplicates,
-			Ignored:    len(report.Ignored),
+			Alive:         report.Summary.Alive,
+			Redirects:     report.Summary.Redirects,
+			LongRedirects: report.Summary.LongRedirects,
+			LoginRequired: report.Summary.LoginRequired,
+			BrokenAnchors: report.Summary.BrokenAnchors,
+			Insecure:      report.Summary.Insecure,
+			Suspect:       report.Summary.Suspect,
+			Blocked:       report.Summary.Blocked,
+			Dead:          report.Summary.Dead,
+			Errors:        report.Summary.Errors,
+			TLSErrors:     report.Summary.TLSErrors,
+			Duplicates:    report.Summary.Duplicates,
+			Skipped:       report.Summary.Skipped,
+			Retries:       report.Summary.Retries,
+			RetriedURLs:   report.Summary.RetriedURLs,
+			BackoffMs:     report.Summary.Backoff.Milliseconds(),
+			NoIndex:       report.Summary.NoIndex,
+			Stale:         report.Summary.Stale,
+			Ignored:       len(report.Ignored),
+			Violations:    len(report.Violations),
+			Internal:      len(report.Internal),
+			ParseErrors:   len(report.ParseErrors),
+			ByParser:      newXMLCounts(report.Summary.ByParser),
+			ByLinkType:    newXMLCounts(report.Summary.ByLinkType),
 		},
 		Results: xmlResults{
 			Results: make([]xmlResult, 0, len(report.Results)),
@@ -91,24 +233,41 @@ func (*XMLFormatter) Format(report *Report) ([]byte, error) {
 
 	for _, r := range report.Results {
 		xr := xmlResult{
-			Status:     r.Status.String(),
-			StatusCode: r.StatusCode,
-			URL:        r.Link.URL,
-			FilePath:   r.Link.FilePath,
-			Line:       r.Link.Line,
-			Text:       r.Link.Text,
-			Error:      r.Error,
+			Status:       r.Status.String(),
+			Parser:       r.Link.Parser,
+			LinkType:     r.Link.Type,
+			StatusCode:   r.StatusCode,
+			URL:          r.Link.URL,
+			FilePath:     r.Link.FilePath,
+			Line:         r.Link.Line,
+			Text:         r.Link.Text,
+			Context:      deadContext(r),
+			Error:        r.Error,
+			Protocol:     r.Protocol,
+			Resolver:     r.Resolver,
+			Profile:      r.Profile,
+			Anchor:       r.Anchor,
+			LastModified: formatLastModified(r),
+			AgeDays:      ageDays(r),
+			Stale:        r.Stale,
+			DurationMs:   r.Duration.Milliseconds(),
+			Retries:      retries(r),
+			BackoffMs:    r.Backoff.Milliseconds(),
+			Robots:       strings.Join(r.Robots, ", "),
+			Hints:        r.Hints,
 		}
 
 		// Add redirect chain if present
 		if len(r.RedirectChain) > 0 {
 			xr.RedirectChain = &xmlRedirectChain{
 				Redirects: make([]xmlRedirect, len(r.RedirectChain)),
+				Length:    r.ChainLength(),
 			}
 			for i, red := range r.RedirectChain {
 				xr.RedirectChain.Redirects[i] = xmlRedirect{
 					URL:        red.URL,
 					StatusCode: red.StatusCode,
+					DurationMs: red.Duration.Milliseconds(),
 				}
 			}
 			xr.FinalURL = r.FinalURL
@@ -120,9 +279,38 @@ func (*XMLFormatter) Format(report *Report) ([]byte, error) {
 			xr.DuplicateOf = r.DuplicateOf.Link.URL
 		}
 
+		// Add fix suggestion if present
+		if r.Suggestion != nil {
+			xr.Suggestion = &xmlSuggest{URL: r.Suggestion.URL, Source: r.Suggestion.Source}
+		}
+
+		// Add certificate problem if present
+		if r.TLS != nil {
+			xr.TLS = &xmlTLS{Host: r.TLS.Host, Problem: r.TLS.Problem, Detail: r.TLS.Detail}
+		}
+
 		output.Results.Results = append(output.Results.Results, xr)
 	}
 
+	// Add unique dead URLs if requested
+	if len(report.UniqueDead) > 0 {
+		output.UniqueDead = &xmlUniques{URLs: make([]xmlUnique, len(report.UniqueDead))}
+		for i, u := range report.UniqueDead {
+			xu := xmlUnique{
+				URL:        u.Result.Link.URL,
+				Status:     u.Result.Status.String(),
+				Error:      u.Result.Error,
+				StatusCode: u.Result.StatusCode,
+				Count:      u.Count(),
+				Locations:  make([]xmlLocation, len(u.Locations)),
+			}
+			for j, l := range u.Locations {
+				xu.Locations[j] = xmlLocation{FilePath: l.FilePath, Line: l.Line}
+			}
+			output.UniqueDead.URLs[i] = xu
+		}
+	}
+
 	// Add ignored URLs if present
 	if len(report.Ignored) > 0 {
 		output.Ignored = &xmlIgnored{
@@ -133,6 +321,48 @@ func (*XMLFormatter) Format(report *Report) ([]byte, error) {
 		}
 	}
 
+	// Add files skipped for exceeding the parse budget
+	if len(report.Skipped) > 0 {
+		output.Skipped = &xmlSkipped{Files: make([]xmlSkippedFile, len(report.Skipped))}
+		for i, sk := range report.Skipped {
+			output.Skipped.Files[i] = xmlSkippedFile(sk)
+		}
+	}
+
+	// Add files that failed to parse in strict mode
+	if len(report.ParseErrors) > 0 {
+		output.ParseErrors = &xmlParseErrors{Files: make([]xmlParseError, len(report.ParseErrors))}
+		for i, pe := range report.ParseErrors {
+			output.ParseErrors.Files[i] = xmlParseError(pe)
+		}
+	}
+
+	// Add policy violations if present
+	if len(report.Violations) > 0 {
+		output.Violations = &xmlIgnored{
+			Items: make([]xmlIgnoredItem, len(report.Violations)),
+		}
+		for i, v := range report.Violations {
+			output.Violations.Items[i] = xmlIgnoredItem(v)
+		}
+	}
+
+	// Add lint findings if present
+	if len(report.Lint) > 0 {
+		output.Lint = &xmlLint{Findings: make([]xmlLintFinding, len(report.Lint))}
+		for i, lf := range report.Lint {
+			output.Lint.Findings[i] = xmlLintFinding(lf)
+		}
+	}
+
+	// Add broken internal links if present
+	if len(report.Internal) > 0 {
+		output.Internal = &xmlInternal{Links: make([]xmlInternalLink, len(report.Internal))}
+		for i, il := range report.Internal {
+			output.Internal.Links[i] = xmlInternalLink(il)
+		}
+	}
+
 	// Add XML header and marshal with indentation
 	data, err := xml.MarshalIndent(output, "", "  ")
 	if err != nil {
@@ -141,3 +371,15 @@ func (*XMLFormatter) Format(report *Report) ([]byte, error) {
 
 	return append([]byte(xml.Header), data...), nil
 }
+
+// newXMLCounts converts a count map to sorted XML elements, or nil if empty.
+func newXMLCounts(m map[string]int) *xmlCounts {
+	if len(m) == 0 {
+		return nil
+	}
+	counts := &xmlCounts{Counts: make([]xmlCount, 0, len(m))}
+	for _, name := range sortedKeys(m) {
+		counts.Counts = append(counts.Counts, xmlCount{Name: name, Value: m[name]})
+	}
+	return counts
+}
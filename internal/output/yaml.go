@@ -2,6 +2,8 @@ package output
 
 import (
 	"gopkg.in/yaml.v3"
+
+	"github.com/leonardomso/gone/internal/checker"
 )
 
 // YAMLFormatter formats reports as YAML.
@@ -9,42 +11,162 @@ type YAMLFormatter struct{}
 
 // yamlOutput is the YAML structure for output.
 type yamlOutput struct {
-	GeneratedAt string        `yaml:"generated_at"`
-	Results     []yamlResult  `yaml:"results"`
-	Ignored     []yamlIgnored `yaml:"ignored,omitempty"`
-	Summary     yamlSummary   `yaml:"summary"`
-	TotalFiles  int           `yaml:"total_files"`
-	TotalLinks  int           `yaml:"total_links"`
-	UniqueURLs  int           `yaml:"unique_urls"`
+	GeneratedAt string           `yaml:"generated_at"`
+	Results     []yamlResult     `yaml:"results"`
+	Ignored     []yamlIgnored    `yaml:"ignored,omitempty"`
+	Skipped     []yamlSkipped    `yaml:"skipped_files,omitempty"`
+	ParseErrors []yamlParseError `yaml:"parse_errors,omitempty"`
+	Lint        []yamlLint       `yaml:"lint,omitempty"`
+	Violations  []yamlIgnored    `yaml:"policy_violations,omitempty"`
+	Internal    []yamlInternal   `yaml:"internal_links,omitempty"`
+	UniqueDead  []yamlUnique     `yaml:"unique_dead,omitempty"`
+	Summary     yamlSummary      `yaml:"summary"`
+	TotalFiles  int              `yaml:"total_files"`
+	TotalLinks  int              `yaml:"total_links"`
+	UniqueURLs  int              `yaml:"unique_urls"`
 }
 
 type yamlSummary struct {
-	Alive      int `yaml:"alive"`
-	Redirects  int `yaml:"redirects"`
-	Blocked    int `yaml:"blocked"`
-	Dead       int `yaml:"dead"`
-	Errors     int `yaml:"errors"`
-	Duplicates int `yaml:"duplicates"`
-	Ignored    int `yaml:"ignored,omitempty"`
+	Alive         int   `yaml:"alive"`
+	Redirects     int   `yaml:"redirects"`
+	LongRedirects int   `yaml:"long_redirects,omitempty"`
+	LoginRequired int   `yaml:"login_required,omitempty"`
+	BrokenAnchors int   `yaml:"broken_anchors,omitempty"`
+	Insecure      int   `yaml:"insecure,omitempty"`
+	Suspect       int   `yaml:"suspect,omitempty"`
+	Blocked       int   `yaml:"blocked"`
+	Dead          int   `yaml:"dead"`
+	Errors        int   `yaml:"errors"`
+	TLSErrors     int   `yaml:"tls_errors,omitempty"`
+	Duplicates    int   `yaml:"duplicates"`
+	Skipped       int   `yaml:"skipped,omitempty"`
+	Retries       int   `yaml:"retries,omitempty"`
+	RetriedURLs   int   `yaml:"retried_urls,omitempty"`
+	BackoffMs     int64 `yaml:"backoff_ms,omitempty"`
+	NoIndex       int   `yaml:"noindex,omitempty"`
+	Stale         int   `yaml:"stale,omitempty"`
+	Ignored       int   `yaml:"ignored,omitempty"`
+	Violations    int   `yaml:"policy_violations,omitempty"`
+	Internal      int   `yaml:"internal_links,omitempty"`
+	ParseErrors   int   `yaml:"parse_errors,omitempty"`
+
+	ByParser   map[string]int `yaml:"by_parser,omitempty"`
+	ByLinkType map[string]int `yaml:"by_link_type,omitempty"`
 }
 
 type yamlResult struct {
 	URL           string         `yaml:"url"`
 	FilePath      string         `yaml:"file_path"`
 	Text          string         `yaml:"text,omitempty"`
+	Context       string         `yaml:"context,omitempty"`
+	Parser        string         `yaml:"parser,omitempty"`
+	LinkType      string         `yaml:"link_type,omitempty"`
 	Status        string         `yaml:"status"`
 	Error         string         `yaml:"error,omitempty"`
+	Protocol      string         `yaml:"protocol,omitempty"`
+	Resolver      string         `yaml:"resolver,omitempty"`
+	Profile       string         `yaml:"profile,omitempty"`
+	LastModified  string         `yaml:"last_modified,omitempty"`
 	FinalURL      string         `yaml:"final_url,omitempty"`
+	Anchor        string         `yaml:"anchor,omitempty"`
 	DuplicateOf   string         `yaml:"duplicate_of,omitempty"`
 	RedirectChain []yamlRedirect `yaml:"redirect_chain,omitempty"`
+	Suggestion    *yamlSuggest   `yaml:"suggestion,omitempty"`
+	TLS           *yamlTLS       `yaml:"tls,omitempty"`
+	Timing        *yamlTiming    `yaml:"timing,omitempty"`
+	Robots        []string       `yaml:"robots,omitempty"`
+	Hints         []string       `yaml:"hints,omitempty"`
 	Line          int            `yaml:"line,omitempty"`
+	Column        int            `yaml:"column,omitempty"`
+	Offset        int            `yaml:"offset,omitempty"`
 	StatusCode    int            `yaml:"status_code"`
 	FinalStatus   int            `yaml:"final_status,omitempty"`
+	ChainLength   int            `yaml:"chain_length,omitempty"`
+	AgeDays       int            `yaml:"age_days,omitempty"`
+	DurationMs    int64          `yaml:"duration_ms,omitempty"`
+	Retries       int            `yaml:"retries,omitempty"`
+	BackoffMs     int64          `yaml:"backoff_ms,omitempty"`
+	Stale         bool           `yaml:"stale,omitempty"`
+}
+
+type yamlTiming struct {
+	DNSMs     int64 `yaml:"dns_ms,omitempty"`
+	ConnectMs int64 `yaml:"connect_ms,omitempty"`
+	TLSMs     int64 `yaml:"tls_ms,omitempty"`
+	TTFBMs    int64 `yaml:"ttfb_ms,omitempty"`
+	TotalMs   int64 `yaml:"total_ms"`
+}
+
+// newYAMLTiming converts a request timing, or returns nil if none was recorded.
+func newYAMLTiming(t checker.Timing) *yamlTiming {
+	if t.IsZero() {
+		return nil
+	}
+	return &yamlTiming{
+		DNSMs:     t.DNS.Milliseconds(),
+		ConnectMs: t.Connect.Milliseconds(),
+		TLSMs:     t.TLS.Milliseconds(),
+		TTFBMs:    t.TTFB.Milliseconds(),
+		TotalMs:   t.Total.Milliseconds(),
+	}
+}
+
+type yamlTLS struct {
+	Host    string `yaml:"host"`
+	Problem string `yaml:"problem"`
+	Detail  string `yaml:"detail,omitempty"`
+}
+
+type yamlSuggest struct {
+	URL    string `yaml:"url"`
+	Source string `yaml:"source"`
 }
 
 type yamlRedirect struct {
 	URL        string `yaml:"url"`
 	StatusCode int    `yaml:"status_code"`
+	DurationMs int64  `yaml:"duration_ms,omitempty"`
+}
+
+type yamlUnique struct {
+	URL        string         `yaml:"url"`
+	Status     string         `yaml:"status"`
+	Error      string         `yaml:"error,omitempty"`
+	Locations  []yamlLocation `yaml:"locations"`
+	StatusCode int            `yaml:"status_code"`
+	Count      int            `yaml:"count"`
+}
+
+type yamlLocation struct {
+	FilePath string `yaml:"file_path"`
+	Line     int    `yaml:"line,omitempty"`
+}
+
+type yamlLint struct {
+	URL     string `yaml:"url"`
+	File    string `yaml:"file"`
+	Rule    string `yaml:"rule"`
+	Message string `yaml:"message"`
+	Line    int    `yaml:"line,omitempty"`
+}
+
+type yamlInternal struct {
+	URL     string `yaml:"url"`
+	File    string `yaml:"file"`
+	Problem string `yaml:"problem"`
+	Message string `yaml:"message"`
+	Line    int    `yaml:"line,omitempty"`
+}
+
+type yamlParseError struct {
+	File  string `yaml:"file"`
+	Error string `yaml:"error"`
+	Line  int    `yaml:"line,omitempty"`
+}
+
+type yamlSkipped struct {
+	File   string `yaml:"file"`
+	Reason string `yaml:"reason"`
 }
 
 type yamlIgnored struct {
@@ -59,30 +181,65 @@ type yamlIgnored struct {
 func (*YAMLFormatter) Format(report *Report) ([]byte, error) {
 	output := yamlOutput{
 		GeneratedAt: report.GeneratedAt.Format("2006-01-02T15:04:05Z07:00"),
-		TotalFiles:  len(report.Files),
+		TotalFiles:  report.FilesScanned(),
 		TotalLinks:  report.TotalLinks,
 		UniqueURLs:  report.UniqueURLs,
 		Summary: yamlSummary{
-			Alive:      report.Summary.Alive,
-			Redirects:  report.Summary.Redirects,
-			Blocked:    report.Summary.Blocked,
-			Dead:       report.Summary.Dead,
-			Errors:     report.Summary.Errors,
-			Duplicates: report.Summary.Duplicates,
-			Ignored:    len(report.Ignored),
+			Alive:         report.Summary.Alive,
+			Redirects:     report.Summary.Redirects,
+			LongRedirects: report.Summary.LongRedirects,
+			LoginRequired: report.Summary.LoginRequired,
+			BrokenAnchors: report.Summary.BrokenAnchors,
+			Insecure:      report.Summary.Insecure,
+			Suspect:       report.Summary.Suspect,
+			Blocked:       report.Summary.Blocked,
+			Dead:          report.Summary.Dead,
+			Errors:        report.Summary.Errors,
+			TLSErrors:     report.Summary.TLSErrors,
+			Duplicates:    report.Summary.Duplicates,
+			Skipped:       report.Summary.Skipped,
+			Retries:       report.Summary.Retries,
+			RetriedURLs:   report.Summary.RetriedURLs,
+			BackoffMs:     report.Summary.Backoff.Milliseconds(),
+			NoIndex:       report.Summary.NoIndex,
+			Stale:         report.Summary.Stale,
+			Ignored:       len(report.Ignored),
+			Violations:    len(report.Violations),
+			Internal:      len(report.Internal),
+			ParseErrors:   len(report.ParseErrors),
+			ByParser:      report.Summary.ByParser,
+			ByLinkType:    report.Summary.ByLinkType,
 		},
 		Results: make([]yamlResult, 0, len(report.Results)),
 	}
 
 	for _, r := range report.Results {
 		yr := yamlResult{
-			URL:        r.Link.URL,
-			FilePath:   r.Link.FilePath,
-			Line:       r.Link.Line,
-			Text:       r.Link.Text,
-			StatusCode: r.StatusCode,
-			Status:     r.Status.String(),
-			Error:      r.Error,
+			URL:          r.Link.URL,
+			FilePath:     r.Link.FilePath,
+			Line:         r.Link.Line,
+			Column:       r.Link.Column,
+			Offset:       r.Link.Offset,
+			Text:         r.Link.Text,
+			Context:      deadContext(r),
+			Parser:       r.Link.Parser,
+			LinkType:     r.Link.Type,
+			StatusCode:   r.StatusCode,
+			Status:       r.Status.String(),
+			Error:        r.Error,
+			Protocol:     r.Protocol,
+			Resolver:     r.Resolver,
+			Profile:      r.Profile,
+			Anchor:       r.Anchor,
+			LastModified: formatLastModified(r),
+			AgeDays:      ageDays(r),
+			Stale:        r.Stale,
+			DurationMs:   r.Duration.Milliseconds(),
+			Retries:      retries(r),
+			BackoffMs:    r.Backoff.Milliseconds(),
+			Timing:       newYAMLTiming(r.Timing),
+			Robots:       r.Robots,
+			Hints:        r.Hints,
 		}
 
 		// Add redirect chain if present
@@ -92,10 +249,12 @@ func (*YAMLFormatter) Format(report *Report) ([]byte, error) {
 				yr.RedirectChain[i] = yamlRedirect{
 					URL:        red.URL,
 					StatusCode: red.StatusCode,
+					DurationMs: red.Duration.Milliseconds(),
 				}
 			}
 			yr.FinalURL = r.FinalURL
 			yr.FinalStatus = r.FinalStatus
+			yr.ChainLength = r.ChainLength()
 		}
 
 		// Add duplicate reference if present
@@ -103,13 +262,62 @@ func (*YAMLFormatter) Format(report *Report) ([]byte, error) {
 			yr.DuplicateOf = r.DuplicateOf.Link.URL
 		}
 
+		// Add fix suggestion if present
+		if r.Suggestion != nil {
+			yr.Suggestion = &yamlSuggest{URL: r.Suggestion.URL, Source: r.Suggestion.Source}
+		}
+
+		// Add certificate problem if present
+		if r.TLS != nil {
+			yr.TLS = &yamlTLS{Host: r.TLS.Host, Problem: r.TLS.Problem, Detail: r.TLS.Detail}
+		}
+
 		output.Results = append(output.Results, yr)
 	}
 
+	// Add unique dead URLs if requested
+	for _, u := range report.UniqueDead {
+		yu := yamlUnique{
+			URL:        u.Result.Link.URL,
+			Status:     u.Result.Status.String(),
+			Error:      u.Result.Error,
+			StatusCode: u.Result.StatusCode,
+			Count:      u.Count(),
+			Locations:  make([]yamlLocation, len(u.Locations)),
+		}
+		for i, l := range u.Locations {
+			yu.Locations[i] = yamlLocation{FilePath: l.FilePath, Line: l.Line}
+		}
+		output.UniqueDead = append(output.UniqueDead, yu)
+	}
+
 	// Add ignored URLs if present
 	for _, ig := range report.Ignored {
 		output.Ignored = append(output.Ignored, yamlIgnored(ig))
 	}
 
+	// Add files skipped for exceeding the parse budget
+	for _, sk := range report.Skipped {
+		output.Skipped = append(output.Skipped, yamlSkipped(sk))
+	}
+
+	// Add files that failed to parse in strict mode
+	for _, pe := range report.ParseErrors {
+		output.ParseErrors = append(output.ParseErrors, yamlParseError(pe))
+	}
+
+	// Add lint findings if present
+	for _, lf := range report.Lint {
+		output.Lint = append(output.Lint, yamlLint(lf))
+	}
+
+	// Add policy violations if present
+	for _, v := range report.Violations {
+		output.Violations = append(output.Violations, yamlIgnored(v))
+	}
+	for _, il := range report.Internal {
+		output.Internal = append(output.Internal, yamlInternal(il))
+	}
+
 	return yaml.Marshal(output)
 }
@@ -0,0 +1,57 @@
+package parser
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// badgeHosts serve nothing but badges.
+var badgeHosts = map[string]bool{
+	"img.shields.io":  true,
+	"badgen.net":      true,
+	"flat.badgen.net": true,
+	"badge.fury.io":   true,
+	"forthebadge.com": true,
+}
+
+// badgeImageHosts are CI and coverage services whose .svg and .png URLs are
+// status badges, e.g. https://travis-ci.com/owner/repo.svg?branch=main.
+var badgeImageHosts = map[string]bool{
+	"travis-ci.org":     true,
+	"travis-ci.com":     true,
+	"app.travis-ci.com": true,
+	"circleci.com":      true,
+	"dl.circleci.com":   true,
+	"codecov.io":        true,
+	"coveralls.io":      true,
+}
+
+// badgePathRegex matches the badge endpoints of other services: GitHub
+// Actions (/workflows/ci.yml/badge.svg), GitLab (/badges/main/pipeline.svg),
+// Go Report Card and pkg.go.dev (/badge/...), SonarCloud, AppVeyor, Azure
+// Pipelines, and Netlify.
+var badgePathRegex = regexp.MustCompile(
+	`(?i)(/badge(\.svg|\.png)?$|^/badge/|/badges/.+\.svg$|/project_badges/|` +
+		`/api/projects/status/|/_apis/build/status/|/deploy-status$)`)
+
+// IsBadgeURL reports whether a URL points to a badge image, such as a
+// shields.io badge or a CI status badge. Badges are generated on every
+// request and often rate limited, so they can be reported and skipped apart
+// from other links.
+// Exported for use by subpackage parsers.
+func IsBadgeURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	if badgeHosts[host] {
+		return true
+	}
+	path := strings.ToLower(u.Path)
+	if badgeImageHosts[host] && (strings.HasSuffix(path, ".svg") || strings.HasSuffix(path, ".png")) {
+		return true
+	}
+	return badgePathRegex.MatchString(u.Path)
+}
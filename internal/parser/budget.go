@@ -0,0 +1,68 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Budget limits the work spent parsing a single file, so one pathological
+// (e.g. huge generated) file can't stall the whole run. Zero values disable a limit.
+type Budget struct {
+	// MaxBytes skips files larger than this without reading them.
+	MaxBytes int64
+
+	// Timeout abandons parsing a file that takes longer than this.
+	Timeout time.Duration
+
+	// Exempt files are always parsed in full, whatever the limits.
+	Exempt map[string]bool
+}
+
+// SkippedFile records a file that was not parsed because it exceeded the budget.
+type SkippedFile struct {
+	FilePath string
+	Reason   string
+}
+
+// errParseTimeout is returned by parseWithBudget when parsing runs over time.
+var errParseTimeout = errors.New("parse budget exceeded")
+
+// parseWithBudget runs the parser, giving up after timeout (if positive).
+// A ContextParser is stopped when the time is up; other parsers don't
+// support cancellation, so an abandoned parse finishes in the background
+// and its result is discarded.
+func parseWithBudget(p FileParser, filePath string, content []byte, timeout time.Duration) ([]Link, error) {
+	if timeout <= 0 {
+		return p.ValidateAndParse(filePath, content)
+	}
+
+	type parseResult struct {
+		err   error
+		links []Link
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	done := make(chan parseResult, 1)
+	go func() {
+		var r parseResult
+		if cp, ok := p.(ContextParser); ok {
+			r.links, r.err = cp.ParseContext(ctx, filePath, content)
+		} else {
+			r.links, r.err = p.ValidateAndParse(filePath, content)
+		}
+		done <- r
+	}()
+
+	select {
+	case r := <-done:
+		if ctx.Err() != nil {
+			return nil, errParseTimeout
+		}
+		return r.links, r.err
+	case <-ctx.Done():
+		return nil, errParseTimeout
+	}
+}
@@ -0,0 +1,116 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// budgetParser is a FileParser that emits one link and can be made slow.
+type budgetParser struct {
+	delay time.Duration
+	ext   string
+}
+
+func (p *budgetParser) Extensions() []string { return []string{p.ext} }
+
+func (p *budgetParser) ValidateAndParse(filename string, _ []byte) ([]Link, error) {
+	time.Sleep(p.delay)
+	return []Link{{URL: "https://example.com", FilePath: filename, Line: 1}}, nil
+}
+
+func TestParseWithBudget(t *testing.T) {
+	t.Parallel()
+
+	fast := &budgetParser{ext: ".fast"}
+	links, err := parseWithBudget(fast, "a.fast", nil, time.Second)
+	require.NoError(t, err)
+	assert.Len(t, links, 1)
+
+	slow := &budgetParser{ext: ".slow", delay: 200 * time.Millisecond}
+	_, err = parseWithBudget(slow, "a.slow", nil, 10*time.Millisecond)
+	require.ErrorIs(t, err, errParseTimeout)
+
+	// No timeout means no limit
+	links, err = parseWithBudget(slow, "a.slow", nil, 0)
+	require.NoError(t, err)
+	assert.Len(t, links, 1)
+}
+
+// stoppableParser is a ContextParser that parses until ctx is done.
+type stoppableParser struct {
+	stopped chan struct{}
+}
+
+func (*stoppableParser) Extensions() []string { return []string{".stoppable"} }
+
+func (*stoppableParser) ValidateAndParse(string, []byte) ([]Link, error) {
+	return nil, errors.New("ParseContext must be used")
+}
+
+func (p *stoppableParser) ParseContext(ctx context.Context, _ string, _ []byte) ([]Link, error) {
+	<-ctx.Done()
+	close(p.stopped)
+	return nil, ctx.Err()
+}
+
+func TestParseWithBudget_StopsContextParsers(t *testing.T) {
+	t.Parallel()
+
+	p := &stoppableParser{stopped: make(chan struct{})}
+	_, err := parseWithBudget(p, "a.stoppable", nil, 10*time.Millisecond)
+	require.ErrorIs(t, err, errParseTimeout)
+
+	select {
+	case <-p.stopped:
+	case <-time.After(time.Second):
+		t.Fatal("the parse wasn't stopped when the budget ran out")
+	}
+}
+
+func TestExtractLinksWithBudget(t *testing.T) {
+	t.Parallel()
+
+	RegisterParser(&budgetParser{ext: ".budgetfast"})
+	RegisterParser(&budgetParser{ext: ".budgetslow", delay: 200 * time.Millisecond})
+
+	dir := t.TempDir()
+	small := filepath.Join(dir, "small.budgetfast")
+	large := filepath.Join(dir, "large.budgetfast")
+	slow := filepath.Join(dir, "slow.budgetslow")
+	require.NoError(t, os.WriteFile(small, []byte("x"), 0o600))
+	require.NoError(t, os.WriteFile(large, []byte(strings.Repeat("x", 100)), 0o600))
+	require.NoError(t, os.WriteFile(slow, []byte("x"), 0o600))
+
+	budget := Budget{MaxBytes: 10, Timeout: 20 * time.Millisecond}
+	links, skipped, err := ExtractLinksWithBudget([]string{small, large, slow}, true, budget)
+	require.NoError(t, err)
+	require.Len(t, links, 1)
+	assert.Equal(t, small, links[0].FilePath)
+
+	require.Len(t, skipped, 2)
+	assert.Equal(t, large, skipped[0].FilePath)
+	assert.Contains(t, skipped[0].Reason, "exceeds parse budget")
+	assert.Equal(t, slow, skipped[1].FilePath)
+	assert.Contains(t, skipped[1].Reason, "longer than 20ms")
+
+	// Without a budget every file is parsed
+	links, skipped, err = ExtractLinksWithBudget([]string{small, large}, true, Budget{})
+	require.NoError(t, err)
+	assert.Len(t, links, 2)
+	assert.Empty(t, skipped)
+
+	// Exempt files ignore the budget
+	budget.Exempt = map[string]bool{large: true}
+	links, skipped, err = ExtractLinksWithBudget([]string{small, large}, true, budget)
+	require.NoError(t, err)
+	assert.Len(t, links, 2)
+	assert.Empty(t, skipped)
+}
@@ -0,0 +1,285 @@
+// Package code implements a URL extractor for comments in source files.
+// Each language is lexed with its own comment and string syntax, so URLs
+// in string literals are left alone and "//" inside a string doesn't start
+// a comment.
+package code
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/leonardomso/gone/internal/parser"
+)
+
+// GroupName is the file type that selects every language of this package.
+const GroupName = "code"
+
+// Language describes the comment and string syntax of a programming language.
+type Language struct {
+	Name       string   // Language name, e.g. "go"
+	Extensions []string // File extensions, the first one naming the file type
+
+	lineComment  string // Line comment opener, e.g. "//" or "#"
+	blockComment bool   // /* ... */ comments
+	nestedBlocks bool   // Block comments nest (Rust)
+	charLiterals bool   // ' starts a character literal instead of a string
+	rawBacktick  bool   // `...` strings without escapes (Go)
+	template     bool   // `...` strings with escapes (JavaScript, TypeScript)
+	tripleQuotes bool   // """...""" strings, and '''...''' unless charLiterals
+	rustRaw      bool   // r"..." and r#"..."# raw strings
+}
+
+// Languages supported by the code parser.
+var (
+	Go = Language{
+		Name: "go", Extensions: []string{".go"},
+		lineComment: "//", blockComment: true, charLiterals: true, rawBacktick: true,
+	}
+	Python = Language{
+		Name: "python", Extensions: []string{".py"},
+		lineComment: "#", tripleQuotes: true,
+	}
+	JavaScript = Language{
+		Name: "javascript", Extensions: []string{".js", ".mjs", ".cjs", ".jsx"},
+		lineComment: "//", blockComment: true, template: true,
+	}
+	TypeScript = Language{
+		Name: "typescript", Extensions: []string{".ts", ".tsx"},
+		lineComment: "//", blockComment: true, template: true,
+	}
+	Rust = Language{
+		Name: "rust", Extensions: []string{".rs"},
+		lineComment: "//", blockComment: true, nestedBlocks: true, charLiterals: true, rustRaw: true,
+	}
+	Java = Language{
+		Name: "java", Extensions: []string{".java"},
+		lineComment: "//", blockComment: true, charLiterals: true, tripleQuotes: true,
+	}
+)
+
+// Languages returns the languages supported by the code parser.
+func Languages() []Language {
+	return []Language{Go, Python, JavaScript, TypeScript, Rust, Java}
+}
+
+// Parser implements parser.FileParser for the source files of one language.
+type Parser struct {
+	lang Language
+}
+
+// New creates a new parser for comments in lang's source files.
+func New(lang Language) *Parser {
+	return &Parser{lang: lang}
+}
+
+// Extensions returns the file extensions this parser handles.
+func (p *Parser) Extensions() []string {
+	return p.lang.Extensions
+}
+
+// ValidateAndParse extracts the URLs in the comments of a source file.
+// Source files are never rejected: an unterminated string or comment
+// runs to the end of the file.
+func (p *Parser) ValidateAndParse(filename string, content []byte) ([]parser.Link, error) {
+	if len(content) == 0 || !bytes.Contains(content, []byte("http")) {
+		return nil, nil
+	}
+
+	l := &lexer{
+		lang:     p.lang,
+		content:  content,
+		filePath: filename,
+		lines:    parser.BuildLineIndex(content),
+	}
+	l.run()
+	return l.links, nil
+}
+
+// lexer walks a source file, skipping strings and collecting links from comments.
+type lexer struct {
+	content  []byte
+	filePath string
+	lines    []int
+	links    []parser.Link
+	lang     Language
+}
+
+// run scans the whole file.
+func (l *lexer) run() {
+	src := l.content
+	for i := 0; i < len(src); {
+		rest := src[i:]
+		switch {
+		case bytes.HasPrefix(rest, []byte(l.lang.lineComment)):
+			start := i + len(l.lang.lineComment)
+			end := indexFrom(src, start, "\n")
+			l.comment(start, end)
+			i = end
+		case l.lang.blockComment && bytes.HasPrefix(rest, []byte("/*")):
+			i = l.blockComment(i)
+		case l.lang.rustRaw && src[i] == 'r' && l.rawStringStart(i):
+			i = l.rustRawString(i)
+		case src[i] == '"', src[i] == '`', src[i] == '\'':
+			i = l.literal(i)
+		default:
+			i++
+		}
+	}
+}
+
+// blockComment records the links in the block comment at i and returns
+// the offset after it.
+func (l *lexer) blockComment(i int) int {
+	src := l.content
+	depth := 0
+	for j := i; j < len(src)-1; j++ {
+		switch {
+		case src[j] == '/' && src[j+1] == '*':
+			if depth > 0 && !l.lang.nestedBlocks {
+				continue
+			}
+			depth++
+			j++
+		case src[j] == '*' && src[j+1] == '/':
+			depth--
+			j++
+			if depth == 0 {
+				l.comment(i+2, j-1)
+				return j + 1
+			}
+		}
+	}
+	l.comment(i+2, len(src))
+	return len(src)
+}
+
+// literal skips the string or character literal starting at i and returns
+// the offset after it.
+func (l *lexer) literal(i int) int {
+	src := l.content
+	quote := src[i]
+
+	switch {
+	case quote == '\'' && l.lang.charLiterals:
+		return l.charLiteral(i)
+	case quote == '`' && l.lang.rawBacktick:
+		return indexFrom(src, i+1, "`") + 1
+	case quote == '`' && !l.lang.template:
+		return i + 1
+	case l.lang.tripleQuotes && (quote == '"' || !l.lang.charLiterals) &&
+		bytes.HasPrefix(src[i:], []byte{quote, quote, quote}):
+		return l.skipQuoted(i+3, []byte{quote, quote, quote}, true)
+	}
+	// Template literals span lines; other strings end at the line
+	return l.skipQuoted(i+1, []byte{quote}, quote == '`')
+}
+
+// skipQuoted returns the offset after the closing delimiter of a string
+// whose content starts at i, honoring backslash escapes.
+func (l *lexer) skipQuoted(i int, closing []byte, multiline bool) int {
+	src := l.content
+	for j := i; j < len(src); j++ {
+		switch {
+		case src[j] == '\\':
+			j++
+		case src[j] == '\n' && !multiline:
+			return j
+		case bytes.HasPrefix(src[j:], closing):
+			return j + len(closing)
+		}
+	}
+	return len(src)
+}
+
+// charLiteral skips a character literal such as 'a' or '\n' at i. A quote
+// that doesn't start one, such as a Rust lifetime ('a), is skipped alone.
+func (l *lexer) charLiteral(i int) int {
+	src := l.content
+	if i+1 < len(src) && src[i+1] == '\\' {
+		return l.skipQuoted(i+1, []byte{'\''}, false)
+	}
+	_, size := utf8.DecodeRune(src[i+1:])
+	if end := i + 1 + size; end < len(src) && src[end] == '\'' {
+		return end + 1
+	}
+	return i + 1
+}
+
+// rawStringStart reports whether the r at i starts a Rust raw string
+// (r"...", r#"..."#, or br"...") rather than ending an identifier.
+func (l *lexer) rawStringStart(i int) bool {
+	src := l.content
+	if i > 0 && isIdent(src[i-1]) && (src[i-1] != 'b' || i > 1 && isIdent(src[i-2])) {
+		return false
+	}
+	j := i + 1
+	for j < len(src) && src[j] == '#' {
+		j++
+	}
+	return j < len(src) && src[j] == '"'
+}
+
+// rustRawString skips the raw string at i, which rawStringStart accepted.
+func (l *lexer) rustRawString(i int) int {
+	src := l.content
+	j := i + 1
+	for src[j] == '#' {
+		j++
+	}
+	closing := "\"" + strings.Repeat("#", j-i-1)
+	return indexFrom(src, j+1, closing) + len(closing)
+}
+
+// comment records the links in the comment text src[start:end].
+func (l *lexer) comment(start, end int) {
+	text := l.content[start:min(end, len(l.content))]
+	if !bytes.Contains(text, []byte("http")) {
+		return
+	}
+	for _, m := range parser.URLRegex.FindAllIndex(text, -1) {
+		url := parser.CleanURLTrailing(string(text[m[0]:m[1]]))
+		if !parser.IsHTTPURL(url) {
+			continue
+		}
+		line, col := parser.OffsetToLineCol(l.lines, start+m[0])
+		l.links = append(l.links, parser.Link{
+			URL:      url,
+			FilePath: l.filePath,
+			Line:     line,
+			Column:   col,
+			Offset:   start + m[0],
+			Type:     parser.LinkTypeAutolink,
+		})
+	}
+}
+
+// indexFrom returns the offset of the first sep in src at or after i, or
+// len(src) if there is none.
+func indexFrom(src []byte, i int, sep string) int {
+	if i >= len(src) {
+		return len(src)
+	}
+	if n := bytes.Index(src[i:], []byte(sep)); n >= 0 {
+		return i + n
+	}
+	return len(src)
+}
+
+// isIdent reports whether b can be part of an identifier.
+func isIdent(b byte) bool {
+	return b == '_' || b >= '0' && b <= '9' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z'
+}
+
+// init registers a parser per language with the default registry, and the
+// "code" file type that selects all of them.
+func init() {
+	types := make([]string, 0, 16)
+	for _, lang := range Languages() {
+		parser.RegisterParser(New(lang))
+		for _, ext := range lang.Extensions {
+			types = append(types, strings.TrimPrefix(ext, "."))
+		}
+	}
+	parser.RegisterGroup(GroupName, types...)
+}
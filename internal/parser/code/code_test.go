@@ -0,0 +1,167 @@
+package code
+
+import (
+	"testing"
+
+	"github.com/leonardomso/gone/internal/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// urls returns the URLs of links.
+func urls(links []parser.Link) []string {
+	out := make([]string, 0, len(links))
+	for _, l := range links {
+		out = append(out, l.URL)
+	}
+	return out
+}
+
+func TestParser_Extensions(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{".go"}, New(Go).Extensions())
+	assert.Contains(t, New(JavaScript).Extensions(), ".mjs")
+	assert.Contains(t, New(TypeScript).Extensions(), ".tsx")
+}
+
+func TestParser_ValidateAndParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		lang     Language
+		file     string
+		content  string
+		expected []string
+	}{
+		{
+			name: "GoLineAndBlockComments",
+			lang: Go,
+			file: "main.go",
+			content: `package main
+
+// See https://go.dev/doc/effective_go for details.
+/*
+ * Spec: https://go.dev/ref/spec.
+ */
+func main() {
+	url := "https://example.com/in-a-string" // https://example.com/trailing
+	raw := ` + "`// https://example.com/raw`" + `
+	r := '"' // https://example.com/after-rune
+}
+`,
+			expected: []string{
+				"https://go.dev/doc/effective_go",
+				"https://go.dev/ref/spec",
+				"https://example.com/trailing",
+				"https://example.com/after-rune",
+			},
+		},
+		{
+			name: "PythonHashComments",
+			lang: Python,
+			file: "app.py",
+			content: `# Docs: https://docs.python.org/3/
+URL = "https://example.com/#not-a-comment"
+DOC = """
+# https://example.com/in-docstring
+"""
+x = 'it''s' # https://example.com/after-string
+`,
+			expected: []string{"https://docs.python.org/3/", "https://example.com/after-string"},
+		},
+		{
+			name: "JavaScriptTemplateLiterals",
+			lang: JavaScript,
+			file: "app.js",
+			content: "const u = `multi\n// https://example.com/in-template\n`;\n" +
+				"const s = 'https://example.com/single'; // https://developer.mozilla.org/\n" +
+				"/** @see https://example.com/jsdoc */\n",
+			expected: []string{"https://developer.mozilla.org/", "https://example.com/jsdoc"},
+		},
+		{
+			name: "RustNestedCommentsAndRawStrings",
+			lang: Rust,
+			file: "lib.rs",
+			content: `/// Docs at https://doc.rust-lang.org/book/
+fn f<'a>(s: &'a str) -> &'a str {
+    let r = r#"// https://example.com/raw "quoted""#;
+    /* outer /* inner https://example.com/nested */ still https://example.com/outer */
+    s // https://example.com/after-lifetime
+}
+`,
+			expected: []string{
+				"https://doc.rust-lang.org/book/",
+				"https://example.com/nested",
+				"https://example.com/outer",
+				"https://example.com/after-lifetime",
+			},
+		},
+		{
+			name: "JavaTextBlocks",
+			lang: Java,
+			file: "App.java",
+			content: `/** Javadoc: https://docs.oracle.com/javase/8/ */
+class App {
+    String block = """
+        // https://example.com/in-text-block
+        """;
+    char c = '\''; // https://example.com/after-char
+}
+`,
+			expected: []string{"https://docs.oracle.com/javase/8/", "https://example.com/after-char"},
+		},
+		{
+			name:     "UnterminatedComment",
+			lang:     TypeScript,
+			file:     "app.ts",
+			content:  "let x = 1; /* https://example.com/unterminated",
+			expected: []string{"https://example.com/unterminated"},
+		},
+		{
+			name:     "NoComments",
+			lang:     Go,
+			file:     "main.go",
+			content:  `var u = "https://example.com"`,
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			links, err := New(tt.lang).ValidateAndParse(tt.file, []byte(tt.content))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, urls(links))
+			for _, l := range links {
+				assert.Equal(t, tt.file, l.FilePath)
+				assert.Equal(t, parser.LinkTypeAutolink, l.Type)
+			}
+		})
+	}
+}
+
+func TestParser_Positions(t *testing.T) {
+	t.Parallel()
+
+	content := []byte("package main\n\n\t// see https://go.dev/\n")
+	links, err := New(Go).ValidateAndParse("main.go", content)
+	require.NoError(t, err)
+	require.Len(t, links, 1)
+	assert.Equal(t, 3, links[0].Line)
+	assert.Equal(t, 9, links[0].Column)
+}
+
+func TestRegistration(t *testing.T) {
+	t.Parallel()
+
+	p, ok := parser.GetParserForFile("src/app.tsx")
+	require.True(t, ok)
+	assert.Equal(t, []string{".ts", ".tsx"}, p.Extensions())
+	assert.Equal(t, "ts", parser.FileTypeForFile("src/app.tsx"))
+	assert.Contains(t, parser.SupportedFileTypes(), GroupName)
+
+	expanded := parser.ExpandFileTypes([]string{"md", GroupName, "go"})
+	assert.Equal(t, []string{"md", "go", "py", "js", "mjs", "cjs", "jsx", "ts", "tsx", "rs", "java"}, expanded)
+}
@@ -0,0 +1,284 @@
+// Package docker implements URL extractors for Dockerfiles and Docker
+// Compose files. Dockerfiles are read by instruction: LABEL values, ADD
+// sources, and the URLs that curl and wget fetch in RUN commands. Compose
+// files are parsed as YAML, plus the comments next to image: keys, which
+// often link to the image's documentation.
+package docker
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+
+	"github.com/leonardomso/gone/internal/parser"
+	yamlparser "github.com/leonardomso/gone/internal/parser/yaml"
+)
+
+// File types of the parsers in this package, and their group.
+const (
+	TypeDockerfile = "dockerfile"
+	TypeCompose    = "compose"
+	GroupName      = "docker"
+)
+
+// DockerfileNames are the base name patterns of Dockerfiles.
+var DockerfileNames = []string{"dockerfile", "dockerfile.*", "*.dockerfile", "containerfile", "containerfile.*"}
+
+// ComposeNames are the base name patterns of Docker Compose files.
+var ComposeNames = []string{
+	"docker-compose.yml", "docker-compose.yaml", "docker-compose.*.yml", "docker-compose.*.yaml",
+	"compose.yml", "compose.yaml", "compose.*.yml", "compose.*.yaml",
+}
+
+// Dockerfile implements parser.FileParser for Dockerfiles.
+type Dockerfile struct{}
+
+// NewDockerfile creates a new Dockerfile parser.
+func NewDockerfile() *Dockerfile {
+	return &Dockerfile{}
+}
+
+// Extensions returns the file extensions this parser handles. Files named
+// Dockerfile are matched by name; see DockerfileNames.
+func (*Dockerfile) Extensions() []string {
+	return []string{".dockerfile"}
+}
+
+// ValidateAndParse extracts the URLs of a Dockerfile's LABEL, ADD, and
+// RUN curl/wget instructions. Dockerfiles are never rejected.
+func (*Dockerfile) ValidateAndParse(filename string, content []byte) ([]parser.Link, error) {
+	if len(content) == 0 || !bytes.Contains(content, []byte("http")) {
+		return nil, nil
+	}
+
+	e := &extractor{filePath: filename, content: content, lines: parser.BuildLineIndex(content)}
+	for start := 0; start < len(content); {
+		end := instructionEnd(content, start)
+		e.instruction(start, string(content[start:end]))
+		start = end + 1
+	}
+	return e.links, nil
+}
+
+// instructionEnd returns the offset of the newline ending the instruction
+// at start, following backslash line continuations. Comment lines inside
+// a continued instruction don't end it.
+func instructionEnd(src []byte, start int) int {
+	for {
+		end := start + bytes.IndexByte(src[start:], '\n')
+		if end < start {
+			return len(src)
+		}
+		line := strings.TrimSpace(string(src[start:end]))
+		if !strings.HasSuffix(line, "\\") {
+			return end
+		}
+		start = end + 1
+	}
+}
+
+// extractor collects the links of a Dockerfile.
+type extractor struct {
+	filePath string
+	content  []byte
+	lines    []int
+	links    []parser.Link
+}
+
+// instruction extracts the links of the instruction text starting at offset start.
+func (e *extractor) instruction(start int, text string) {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" || trimmed[0] == '#' || !strings.Contains(trimmed, "http") {
+		return
+	}
+	keyword, args := trimmed, ""
+	if i := strings.IndexAny(trimmed, " \t"); i >= 0 {
+		keyword, args = trimmed[:i], joinContinuations(trimmed[i+1:])
+	}
+
+	switch strings.ToUpper(keyword) {
+	case "LABEL":
+		for _, label := range labelPairs(args) {
+			e.add(start, text, label.value, "LABEL "+label.key)
+		}
+	case "ADD":
+		for _, arg := range strings.Fields(args) {
+			if !strings.HasPrefix(arg, "--") {
+				e.add(start, text, strings.Trim(arg, `"[],`), "ADD")
+			}
+		}
+	case "RUN":
+		for _, cmd := range commands(args) {
+			fields := strings.Fields(cmd)
+			for i, f := range fields {
+				name := strings.Trim(f, `"[],`)
+				if name != "curl" && name != "wget" {
+					continue
+				}
+				for _, arg := range fields[i+1:] {
+					e.add(start, text, strings.Trim(arg, `"'[],`), "RUN "+name)
+				}
+				break
+			}
+		}
+	}
+}
+
+// joinContinuations removes backslash line continuations and the comment
+// lines between them.
+func joinContinuations(args string) string {
+	if !strings.Contains(args, "\n") {
+		return args
+	}
+	var b strings.Builder
+	for line := range strings.Lines(args) {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		b.WriteString(strings.TrimSuffix(line, "\\"))
+		b.WriteByte(' ')
+	}
+	return b.String()
+}
+
+// commandSeparator splits a shell command line into simple commands.
+var commandSeparator = regexp.MustCompile(`&&|\|\||[;|]`)
+
+// commands returns the simple commands of a RUN instruction.
+func commands(args string) []string {
+	return commandSeparator.Split(args, -1)
+}
+
+// label is a key and value of a LABEL instruction.
+type label struct {
+	key   string
+	value string
+}
+
+// labelPair matches key=value pairs, with double-quoted, single-quoted, or bare values.
+var labelPair = regexp.MustCompile(`([^\s=]+)=("(?:[^"\\]|\\.)*"|'[^']*'|\S+)`)
+
+// labelPairs returns the key=value pairs of a LABEL instruction.
+func labelPairs(args string) []label {
+	var labels []label
+	for _, m := range labelPair.FindAllStringSubmatch(args, -1) {
+		key := strings.Trim(m[1], `"`)
+		labels = append(labels, label{key: key, value: strings.Trim(m[2], `"'`)})
+	}
+	return labels
+}
+
+// add records value as a link if it's an http(s) URL. URLs built from
+// build arguments, such as https://example.com/v${VERSION}.tar.gz, are
+// skipped since they can't be requested as written.
+func (e *extractor) add(start int, text, value, label string) {
+	url := parser.CleanURLTrailing(value)
+	if !parser.IsHTTPURL(url) || strings.Contains(url, "$") {
+		return
+	}
+	offset := start
+	if i := strings.Index(text, url); i >= 0 {
+		offset += i
+	}
+	line, col := parser.OffsetToLineCol(e.lines, offset)
+	e.links = append(e.links, parser.Link{
+		URL:      url,
+		FilePath: e.filePath,
+		Text:     label,
+		Line:     line,
+		Column:   col,
+		Offset:   offset,
+		Type:     parser.LinkTypeAutolink,
+	})
+}
+
+// Compose implements parser.FileParser for Docker Compose files.
+type Compose struct {
+	yaml *yamlparser.Parser
+}
+
+// NewCompose creates a new Docker Compose parser.
+func NewCompose() *Compose {
+	return &Compose{yaml: yamlparser.New()}
+}
+
+// Extensions returns no extensions: Compose files are YAML files matched
+// by name; see ComposeNames.
+func (*Compose) Extensions() []string {
+	return nil
+}
+
+// ValidateAndParse extracts the URLs of a Compose file's values, like the
+// YAML parser, and of the comments above and beside its image: keys.
+func (c *Compose) ValidateAndParse(filename string, content []byte) ([]parser.Link, error) {
+	links, err := c.yaml.ValidateAndParse(filename, content)
+	if err != nil {
+		return nil, err
+	}
+	return append(links, imageComments(filename, content)...), nil
+}
+
+// imageKey matches an image: line of a Compose file and captures the image
+// and a trailing comment.
+var imageKey = regexp.MustCompile(`^\s*(?:-\s+)?image:\s*([^#\s]*)\s*(?:#(.*))?$`)
+
+// imageComments returns the URLs in the comment lines directly above each
+// image: key and in the comment at the end of its line, labeled with the image.
+func imageComments(filename string, content []byte) []parser.Link {
+	if !bytes.Contains(content, []byte("http")) {
+		return nil
+	}
+
+	lines := strings.Split(string(content), "\n")
+	starts := parser.BuildLineIndex(content)
+	var links []parser.Link
+	for i, line := range lines {
+		m := imageKey.FindStringSubmatch(strings.TrimRight(line, "\r"))
+		if m == nil {
+			continue
+		}
+		text := "image " + strings.Trim(m[1], `"'`)
+
+		first := i
+		for first > 0 && strings.HasPrefix(strings.TrimSpace(lines[first-1]), "#") {
+			first--
+		}
+		for j := first; j <= i; j++ {
+			comment := lines[j]
+			if j == i {
+				if m[2] == "" {
+					continue
+				}
+				comment = line[strings.LastIndex(line, "#"+m[2]):]
+			}
+			col := len(lines[j]) - len(comment) + 1
+			for _, loc := range parser.URLRegex.FindAllStringIndex(comment, -1) {
+				url := parser.CleanURLTrailing(comment[loc[0]:loc[1]])
+				if !parser.IsHTTPURL(url) {
+					continue
+				}
+				links = append(links, parser.Link{
+					URL:      url,
+					FilePath: filename,
+					Text:     text,
+					Line:     j + 1,
+					Column:   col + loc[0],
+					Offset:   starts[j] + col - 1 + loc[0],
+					Type:     parser.LinkTypeAutolink,
+				})
+			}
+		}
+	}
+	return links
+}
+
+// init registers the Dockerfile and Compose parsers with the default
+// registry, and the "docker" file type that selects both.
+func init() {
+	dockerfile := NewDockerfile()
+	parser.RegisterParser(dockerfile)
+	parser.RegisterNamedParser(TypeDockerfile, dockerfile, DockerfileNames...)
+	parser.RegisterNamedParser(TypeCompose, NewCompose(), ComposeNames...)
+	parser.RegisterGroup(GroupName, TypeDockerfile, TypeCompose)
+}
@@ -0,0 +1,105 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/leonardomso/gone/internal/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDockerfile_ValidateAndParse(t *testing.T) {
+	t.Parallel()
+
+	content := []byte(`# syntax=docker/dockerfile:1
+# Base image docs: https://example.com/commented
+FROM golang:1.22 AS build
+LABEL org.opencontainers.image.source="https://github.com/acme/app" \
+      org.opencontainers.image.documentation=https://docs.acme.dev maintainer="ops@acme.dev"
+ARG VERSION=1.2.3
+ADD --chmod=644 https://example.com/config.tar.gz /tmp/
+RUN apt-get update && \
+    curl -fsSL https://deb.nodesource.com/setup_20.x | bash - && \
+    wget -q -O /usr/local/bin/tool "https://example.com/tool-${VERSION}" && \
+    echo https://example.com/not-fetched
+RUN ["wget", "-O", "/x", "https://example.com/exec-form"]
+COPY . /app
+`)
+	links, err := NewDockerfile().ValidateAndParse("Dockerfile", content)
+	require.NoError(t, err)
+
+	type found struct{ text, url string }
+	got := make([]found, 0, len(links))
+	for _, l := range links {
+		got = append(got, found{l.Text, l.URL})
+	}
+	assert.Equal(t, []found{
+		{"LABEL org.opencontainers.image.source", "https://github.com/acme/app"},
+		{"LABEL org.opencontainers.image.documentation", "https://docs.acme.dev"},
+		{"ADD", "https://example.com/config.tar.gz"},
+		{"RUN curl", "https://deb.nodesource.com/setup_20.x"},
+		{"RUN wget", "https://example.com/exec-form"},
+	}, got)
+
+	assert.Equal(t, 4, links[0].Line)
+	assert.Equal(t, 5, links[1].Line)
+	assert.Equal(t, 9, links[3].Line)
+	assert.Equal(t, 16, links[3].Column)
+}
+
+func TestCompose_ValidateAndParse(t *testing.T) {
+	t.Parallel()
+
+	content := []byte(`services:
+  web:
+    # Image docs: https://hub.docker.com/_/nginx
+    # Config reference https://nginx.org/en/docs/
+    image: nginx:1.25 # changelog https://nginx.org/en/CHANGES
+    environment:
+      API_URL: https://api.example.com
+  # Unrelated comment https://example.com/unrelated
+
+  db:
+    image: postgres:16
+`)
+	links, err := NewCompose().ValidateAndParse("docker-compose.yml", content)
+	require.NoError(t, err)
+
+	texts := map[string]string{}
+	for _, l := range links {
+		texts[l.URL] = l.Text
+	}
+	assert.Equal(t, map[string]string{
+		"https://api.example.com":        "services.web.environment.API_URL",
+		"https://hub.docker.com/_/nginx": "image nginx:1.25",
+		"https://nginx.org/en/docs/":     "image nginx:1.25",
+		"https://nginx.org/en/CHANGES":   "image nginx:1.25",
+	}, texts)
+
+	for _, l := range links {
+		if l.URL == "https://nginx.org/en/CHANGES" {
+			assert.Equal(t, 5, l.Line)
+			assert.Equal(t, 35, l.Column)
+		}
+	}
+
+	_, err = NewCompose().ValidateAndParse("compose.yml", []byte("services: [\n"))
+	require.Error(t, err)
+}
+
+func TestRegistration(t *testing.T) {
+	t.Parallel()
+
+	for file, typ := range map[string]string{
+		"Dockerfile":                   TypeDockerfile,
+		"build/Dockerfile.prod":        TypeDockerfile,
+		"api.dockerfile":               TypeDockerfile,
+		"Containerfile":                TypeDockerfile,
+		"docker-compose.yml":           TypeCompose,
+		"deploy/compose.override.yaml": TypeCompose,
+		"config.yml":                   "yaml",
+	} {
+		assert.Equal(t, typ, parser.FileTypeForFile(file), file)
+	}
+	assert.Equal(t, []string{TypeDockerfile, TypeCompose}, parser.ExpandFileTypes([]string{GroupName}))
+}
@@ -0,0 +1,102 @@
+package parser
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// externalWaitDelay is how long an external parser's output is still read
+// after it's killed, in case a child process it started holds it open.
+const externalWaitDelay = time.Second
+
+// External implements FileParser by running a user-provided command, so
+// formats gone doesn't know can be checked without changing gone.
+//
+// The protocol: the command is run with the file path as its last argument
+// and the file's content on stdin, and prints a JSON array of links on
+// stdout, such as [{"url": "https://example.com", "line": 3, "column": 7,
+// "text": "docs"}], and optionally the byte "offset" of the same position.
+// Only url is required. A non-zero exit status marks the
+// file as malformed, with stderr as the reason.
+type External struct {
+	ext     string
+	command []string
+}
+
+// externalLink is a link as printed by an external parser.
+type externalLink struct {
+	URL    string `json:"url"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+	Offset int    `json:"offset"`
+	Text   string `json:"text"`
+}
+
+// NewExternal creates a parser for files with extension ext that runs
+// command. The command is split on spaces; it isn't run through a shell.
+func NewExternal(ext, command string) *External {
+	return &External{ext: normalizeExtension(ext), command: strings.Fields(command)}
+}
+
+// Extensions returns the extension the external parser was registered for.
+func (p *External) Extensions() []string {
+	return []string{p.ext}
+}
+
+// ValidateAndParse runs the external command on the file and decodes its links.
+// Non-HTTP URLs are dropped, as with the built-in parsers.
+func (p *External) ValidateAndParse(filename string, content []byte) ([]Link, error) {
+	return p.ParseContext(context.Background(), filename, content)
+}
+
+// ParseContext is ValidateAndParse, killing the command once ctx is done,
+// such as when the file runs over its parse budget.
+func (p *External) ParseContext(ctx context.Context, filename string, content []byte) ([]Link, error) {
+	if len(p.command) == 0 {
+		return nil, fmt.Errorf("no command for external %s parser", p.ext)
+	}
+
+	//nolint:gosec // Running the configured command is the point
+	cmd := exec.CommandContext(ctx, p.command[0], append(p.command[1:], filename)...)
+	cmd.WaitDelay = externalWaitDelay
+	cmd.Stdin = bytes.NewReader(content)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
+	}
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s: %w: %s", p.command[0], err, msg)
+		}
+		return nil, fmt.Errorf("%s: %w", p.command[0], err)
+	}
+
+	var found []externalLink
+	if err := json.Unmarshal(out, &found); err != nil {
+		return nil, fmt.Errorf("%s: invalid output: %w", p.command[0], err)
+	}
+
+	links := make([]Link, 0, len(found))
+	for _, l := range found {
+		if !IsHTTPURL(l.URL) {
+			continue
+		}
+		links = append(links, Link{
+			URL:      l.URL,
+			FilePath: filename,
+			Line:     l.Line,
+			Column:   l.Column,
+			Offset:   l.Offset,
+			Text:     l.Text,
+			Type:     LinkTypeAutolink,
+		})
+	}
+	return links, nil
+}
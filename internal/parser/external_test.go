@@ -0,0 +1,85 @@
+package parser
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeScript writes an executable shell script and returns its path.
+func writeScript(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "parser.sh")
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+body), 0o700))
+	return path
+}
+
+func TestExternal_ValidateAndParse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("DecodesLinks", func(t *testing.T) {
+		t.Parallel()
+		// Echo the argument and stdin back, to check both are passed
+		script := writeScript(t, `read -r line
+printf '[{"url":"https://example.com/%s","line":2,"column":5,"text":"%s"},{"url":"mailto:a@b.c"}]' "$line" "$2"`)
+		p := NewExternal("adoc", script+" --json")
+
+		assert.Equal(t, []string{".adoc"}, p.Extensions())
+		links, err := p.ValidateAndParse("docs/guide.adoc", []byte("page\n"))
+		require.NoError(t, err)
+		require.Len(t, links, 1)
+		assert.Equal(t, Link{
+			URL:      "https://example.com/page",
+			FilePath: "docs/guide.adoc",
+			Line:     2,
+			Column:   5,
+			Text:     "docs/guide.adoc",
+			Type:     LinkTypeAutolink,
+		}, links[0])
+	})
+
+	t.Run("FailureIsMalformed", func(t *testing.T) {
+		t.Parallel()
+		p := NewExternal(".adoc", writeScript(t, "echo 'unterminated block' >&2\nexit 2\n"))
+		_, err := p.ValidateAndParse("guide.adoc", nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unterminated block")
+	})
+
+	t.Run("InvalidOutput", func(t *testing.T) {
+		t.Parallel()
+		p := NewExternal(".adoc", writeScript(t, "echo not json\n"))
+		_, err := p.ValidateAndParse("guide.adoc", nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid output")
+	})
+}
+
+func TestExternal_ParseContext(t *testing.T) {
+	t.Parallel()
+
+	p := NewExternal(".adoc", writeScript(t, "exec sleep 10\n"))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := p.ParseContext(ctx, "guide.adoc", nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second, "the command is killed")
+}
+
+func TestRegistry_External(t *testing.T) {
+	t.Parallel()
+
+	r := NewRegistry()
+	r.Register(NewExternal("ADOC", "asciidoc-links"))
+	assert.Equal(t, "adoc", r.TypeForFile("guide.adoc"))
+	exts, err := r.ExtensionsForTypes([]string{"adoc"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{".adoc"}, exts)
+}
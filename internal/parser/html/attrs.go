@@ -0,0 +1,110 @@
+package html //nolint:revive // package name matches file type being parsed
+
+import "strings"
+
+// urlAttributes lists HTML attributes whose value is a URL.
+var urlAttributes = map[string]bool{
+	"href":       true,
+	"src":        true,
+	"poster":     true,
+	"cite":       true,
+	"action":     true,
+	"formaction": true,
+	"data":       true,
+	"srcset":     true,
+}
+
+// IsURLAttribute reports whether an attribute (case-insensitive) holds a URL.
+func IsURLAttribute(key string) bool {
+	return urlAttributes[strings.ToLower(key)]
+}
+
+// AttrSpan locates an attribute value inside a raw start tag.
+// Start and End are byte offsets of the value, excluding quotes.
+type AttrSpan struct {
+	Key   string // Attribute name, lowercased
+	Start int
+	End   int
+	Quote byte // Quote character, or 0 for unquoted values
+}
+
+// ScanAttrs returns the value spans of all attributes in a raw start tag such as
+// `<a class="x" href='https://example.com'>`. Attributes without values are skipped.
+// It mirrors the HTML tokenizer closely enough to rewrite values in place.
+func ScanAttrs(raw string) []AttrSpan {
+	var spans []AttrSpan
+
+	// Skip "<" and the tag name
+	i := 1
+	for i < len(raw) && !isSpace(raw[i]) && raw[i] != '>' && raw[i] != '/' {
+		i++
+	}
+
+	for i < len(raw) {
+		// Skip whitespace and stray slashes between attributes
+		for i < len(raw) && (isSpace(raw[i]) || raw[i] == '/') {
+			i++
+		}
+		if i >= len(raw) || raw[i] == '>' {
+			break
+		}
+
+		keyStart := i
+		for i < len(raw) && !isSpace(raw[i]) && raw[i] != '=' && raw[i] != '>' && raw[i] != '/' {
+			i++
+		}
+		key := strings.ToLower(raw[keyStart:i])
+
+		for i < len(raw) && isSpace(raw[i]) {
+			i++
+		}
+		if i >= len(raw) || raw[i] != '=' {
+			continue // Attribute without a value
+		}
+		i++
+		for i < len(raw) && isSpace(raw[i]) {
+			i++
+		}
+		if i >= len(raw) {
+			break
+		}
+
+		span := AttrSpan{Key: key}
+		if q := raw[i]; q == '"' || q == '\'' {
+			span.Quote = q
+			span.Start = i + 1
+			end := strings.IndexByte(raw[span.Start:], q)
+			if end < 0 {
+				end = len(raw) - span.Start
+			}
+			span.End = span.Start + end
+			i = span.End + 1
+		} else {
+			span.Start = i
+			for i < len(raw) && !isSpace(raw[i]) && raw[i] != '>' {
+				i++
+			}
+			span.End = i
+		}
+		spans = append(spans, span)
+	}
+
+	return spans
+}
+
+// SrcsetURLs splits a srcset value ("a.png 1x, b.png 2x") into its URLs.
+func SrcsetURLs(value string) []string {
+	var urls []string
+	for candidate := range strings.SplitSeq(value, ",") {
+		fields := strings.Fields(candidate)
+		if len(fields) > 0 {
+			urls = append(urls, fields[0])
+		}
+	}
+	return urls
+}
+
+// isSpace reports whether b is HTML whitespace.
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r' || b == '\f'
+}
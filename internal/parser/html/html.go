@@ -0,0 +1,175 @@
+// Package html implements a URL extractor for HTML files.
+package html //nolint:revive // package name matches file type being parsed
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	"github.com/leonardomso/gone/internal/parser"
+)
+
+// Parser implements parser.FileParser for HTML files.
+type Parser struct{}
+
+// New creates a new HTML parser.
+func New() *Parser {
+	return &Parser{}
+}
+
+// Extensions returns the file extensions this parser handles.
+func (*Parser) Extensions() []string {
+	return []string{".html", ".htm"}
+}
+
+// ValidateAndParse extracts links from URL attributes (href, src, ...).
+// HTML parsing is lenient, so only read errors are reported.
+func (*Parser) ValidateAndParse(filename string, content []byte) ([]parser.Link, error) {
+	if len(content) == 0 {
+		return nil, nil
+	}
+
+	e := &linkExtractor{
+		filePath: filename,
+		lines:    parser.BuildLineIndex(content),
+		links:    make([]parser.Link, 0, 32),
+		anchor:   -1,
+	}
+
+	z := html.NewTokenizer(bytes.NewReader(content))
+	offset := 0
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			if err := z.Err(); !errors.Is(err, io.EOF) {
+				return nil, err
+			}
+			e.closeAnchor()
+			return e.links, nil
+		}
+
+		raw := string(z.Raw())
+		e.processToken(tt, z.Token(), raw, offset)
+		offset += len(raw)
+	}
+}
+
+// linkExtractor collects links from HTML tokens.
+type linkExtractor struct {
+	filePath   string
+	anchorText strings.Builder
+	lines      []int
+	links      []parser.Link
+	anchor     int // Index of the open <a> link collecting text, or -1
+}
+
+// processToken handles a single token at the given byte offset.
+func (e *linkExtractor) processToken(tt html.TokenType, tok html.Token, raw string, offset int) {
+	switch tt {
+	case html.StartTagToken, html.SelfClosingTagToken:
+		if tok.DataAtom == atom.A {
+			e.closeAnchor()
+		}
+		e.extractFromTag(tok, raw, offset)
+	case html.EndTagToken:
+		if tok.DataAtom == atom.A {
+			e.closeAnchor()
+		}
+	case html.TextToken:
+		if e.anchor >= 0 {
+			e.anchorText.WriteString(tok.Data)
+		}
+	}
+}
+
+// extractFromTag adds a link for every URL attribute of a start tag.
+func (e *linkExtractor) extractFromTag(tok html.Token, raw string, offset int) {
+	values := make(map[string]string, len(tok.Attr))
+	for _, a := range tok.Attr {
+		values[a.Key] = a.Val
+	}
+
+	for _, span := range ScanAttrs(raw) {
+		if !IsURLAttribute(span.Key) {
+			continue
+		}
+
+		urls := []string{strings.TrimSpace(values[span.Key])}
+		if span.Key == "srcset" {
+			urls = SrcsetURLs(values[span.Key])
+		}
+
+		for _, url := range urls {
+			if !parser.IsHTTPURL(url) {
+				continue
+			}
+			e.addLink(tok, span, url, raw, offset)
+		}
+	}
+}
+
+// addLink records a link, locating it within the raw tag for line/column info.
+func (e *linkExtractor) addLink(tok html.Token, span AttrSpan, url, raw string, offset int) {
+	pos := offset + span.Start
+	if idx := strings.Index(raw[span.Start:span.End], url); idx >= 0 {
+		pos += idx
+	}
+	line, col := parser.OffsetToLineCol(e.lines, pos)
+
+	link := parser.Link{
+		URL:      url,
+		FilePath: e.filePath,
+		Line:     line,
+		Column:   col,
+		Offset:   pos,
+		Type:     parser.LinkTypeHTML,
+		Text:     tok.Data + "." + span.Key,
+	}
+
+	switch {
+	case tok.DataAtom == atom.Img:
+		link.Type = parser.LinkTypeImage
+		if parser.IsBadgeURL(url) {
+			link.Type = parser.LinkTypeBadge
+		}
+		if alt := attrValue(tok, "alt"); alt != "" {
+			link.Text = alt
+		}
+	case tok.DataAtom == atom.A && span.Key == "href":
+		e.anchor = len(e.links)
+		e.anchorText.Reset()
+	}
+
+	e.links = append(e.links, link)
+}
+
+// closeAnchor assigns collected text to the open <a> link, if any.
+func (e *linkExtractor) closeAnchor() {
+	if e.anchor < 0 {
+		return
+	}
+	if text := strings.Join(strings.Fields(e.anchorText.String()), " "); text != "" {
+		e.links[e.anchor].Text = text
+	}
+	e.anchor = -1
+	e.anchorText.Reset()
+}
+
+// attrValue returns the value of a token attribute, or "" if missing.
+func attrValue(tok html.Token, key string) string {
+	for _, a := range tok.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// init registers the HTML parser with the default registry.
+func init() {
+	parser.RegisterParser(New())
+}
@@ -0,0 +1,113 @@
+package html //nolint:revive // package name matches file type being parsed
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/leonardomso/gone/internal/parser"
+)
+
+func TestParser_Extensions(t *testing.T) {
+	t.Parallel()
+
+	exts := New().Extensions()
+
+	assert.Contains(t, exts, ".html")
+	assert.Contains(t, exts, ".htm")
+}
+
+func TestParser_ValidateAndParse(t *testing.T) {
+	t.Parallel()
+	p := New()
+
+	t.Run("Empty", func(t *testing.T) {
+		t.Parallel()
+		links, err := p.ValidateAndParse("test.html", nil)
+		require.NoError(t, err)
+		assert.Empty(t, links)
+	})
+
+	t.Run("AnchorWithText", func(t *testing.T) {
+		t.Parallel()
+		content := []byte("<p>\n  <a href=\"https://example.com\">Example <b>site</b></a>\n</p>")
+		links, err := p.ValidateAndParse("test.html", content)
+		require.NoError(t, err)
+		require.Len(t, links, 1)
+		assert.Equal(t, "https://example.com", links[0].URL)
+		assert.Equal(t, "Example site", links[0].Text)
+		assert.Equal(t, parser.LinkTypeHTML, links[0].Type)
+		assert.Equal(t, 2, links[0].Line)
+		assert.Equal(t, 12, links[0].Column)
+	})
+
+	t.Run("ImageAltText", func(t *testing.T) {
+		t.Parallel()
+		content := []byte(`<img alt="Logo" src="https://example.com/logo.png">`)
+		links, err := p.ValidateAndParse("test.html", content)
+		require.NoError(t, err)
+		require.Len(t, links, 1)
+		assert.Equal(t, parser.LinkTypeImage, links[0].Type)
+		assert.Equal(t, "Logo", links[0].Text)
+	})
+
+	t.Run("Srcset", func(t *testing.T) {
+		t.Parallel()
+		content := []byte(`<img srcset="https://a.com/1x.png 1x, https://a.com/2x.png 2x">`)
+		links, err := p.ValidateAndParse("test.html", content)
+		require.NoError(t, err)
+		require.Len(t, links, 2)
+		assert.Equal(t, "https://a.com/1x.png", links[0].URL)
+		assert.Equal(t, "https://a.com/2x.png", links[1].URL)
+	})
+
+	t.Run("EscapedEntities", func(t *testing.T) {
+		t.Parallel()
+		content := []byte(`<a href="https://example.com/?a=1&amp;b=2">x</a>`)
+		links, err := p.ValidateAndParse("test.html", content)
+		require.NoError(t, err)
+		require.Len(t, links, 1)
+		assert.Equal(t, "https://example.com/?a=1&b=2", links[0].URL)
+	})
+
+	t.Run("IgnoresTextAndRelativeURLs", func(t *testing.T) {
+		t.Parallel()
+		content := []byte(`<!-- <a href="https://comment.com"> --><p>https://text.com</p><a href="/local">x</a>`)
+		links, err := p.ValidateAndParse("test.html", content)
+		require.NoError(t, err)
+		assert.Empty(t, links)
+	})
+
+	t.Run("OtherURLAttributes", func(t *testing.T) {
+		t.Parallel()
+		content := []byte(`<link rel="stylesheet" href="https://cdn.com/a.css"><script src='https://cdn.com/a.js'></script>`)
+		links, err := p.ValidateAndParse("test.html", content)
+		require.NoError(t, err)
+		require.Len(t, links, 2)
+		assert.Equal(t, "link.href", links[0].Text)
+		assert.Equal(t, "script.src", links[1].Text)
+	})
+}
+
+func TestScanAttrs(t *testing.T) {
+	t.Parallel()
+
+	raw := `<a HREF="https://x.com" data-id=7 title='t' disabled>`
+	spans := ScanAttrs(raw)
+
+	require.Len(t, spans, 3) // Valueless "disabled" is skipped
+	assert.Equal(t, "href", spans[0].Key)
+	assert.Equal(t, "https://x.com", raw[spans[0].Start:spans[0].End])
+	assert.Equal(t, byte('"'), spans[0].Quote)
+	assert.Equal(t, "7", raw[spans[1].Start:spans[1].End])
+	assert.Equal(t, byte(0), spans[1].Quote)
+	assert.Equal(t, "t", raw[spans[2].Start:spans[2].End])
+}
+
+func TestSrcsetURLs(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"a.png", "b.png"}, SrcsetURLs(" a.png 1x , b.png 2x"))
+	assert.Empty(t, SrcsetURLs(""))
+}
@@ -0,0 +1,302 @@
+// Package ini implements a URL extractor for key-value configuration files:
+// INI and .conf files, .env files, and Java .properties files. URLs are
+// taken from values, reported with their key (and INI section) as the link
+// text, and commented-out lines are skipped.
+package ini
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/leonardomso/gone/internal/parser"
+)
+
+// Format describes the syntax of a key-value file format.
+type Format struct {
+	Name       string   // Format name, e.g. "ini"
+	Extensions []string // File extensions, the first one naming the file type
+
+	comments     string // Characters that start a comment line
+	sections     bool   // [section] headers prefix keys
+	export       bool   // Keys may be prefixed with "export " (.env)
+	quotes       bool   // Values may be quoted (.env)
+	escapes      bool   // Backslash escapes and line continuations (.properties)
+	inlineMarker string // Characters that start an inline comment after whitespace
+}
+
+// Formats supported by the key-value parser.
+var (
+	INI = Format{
+		Name: "ini", Extensions: []string{".ini", ".conf"},
+		comments: ";#", sections: true, inlineMarker: ";#",
+	}
+	Env = Format{
+		Name: "env", Extensions: []string{".env"},
+		comments: "#", export: true, quotes: true, inlineMarker: "#",
+	}
+	Properties = Format{
+		Name: "properties", Extensions: []string{".properties"},
+		comments: "#!", escapes: true,
+	}
+)
+
+// Formats returns the formats supported by the key-value parser.
+func Formats() []Format {
+	return []Format{INI, Env, Properties}
+}
+
+// Parser implements parser.FileParser for the files of one format.
+type Parser struct {
+	format   Format
+	comments bool
+}
+
+// New creates a new parser for format's files.
+func New(format Format) *Parser {
+	return &Parser{format: format}
+}
+
+// WithComments returns a copy of the parser that also extracts URLs from
+// commented-out lines.
+func (p *Parser) WithComments(enabled bool) *Parser {
+	c := *p
+	c.comments = enabled
+	return &c
+}
+
+// Extensions returns the file extensions this parser handles.
+func (p *Parser) Extensions() []string {
+	return p.format.Extensions
+}
+
+// ValidateAndParse extracts the URLs in the values of a key-value file.
+// Files are never rejected: lines that aren't key-value pairs, such as
+// directives in a .conf file, are searched for URLs as a whole.
+func (p *Parser) ValidateAndParse(filename string, content []byte) ([]parser.Link, error) {
+	if len(content) == 0 || !bytes.Contains(content, []byte("http")) {
+		return nil, nil
+	}
+
+	e := &extractor{
+		format:   p.format,
+		comments: p.comments,
+		filePath: filename,
+		content:  content,
+		lines:    parser.BuildLineIndex(content),
+	}
+	e.run()
+	return e.links, nil
+}
+
+// extractor walks a key-value file line by line.
+type extractor struct {
+	filePath string
+	content  []byte
+	lines    []int
+	links    []parser.Link
+	section  string
+	format   Format
+	comments bool
+}
+
+// run scans the whole file.
+func (e *extractor) run() {
+	src := e.content
+	for start := 0; start < len(src); {
+		end := e.lineEnd(start)
+		e.line(start, string(src[start:end]))
+		start = end + 1
+	}
+}
+
+// lineEnd returns the offset of the newline ending the logical line at
+// start, following .properties continuations (a line ending in an odd
+// number of backslashes).
+func (e *extractor) lineEnd(start int) int {
+	src := e.content
+	for {
+		end := start + bytes.IndexByte(src[start:], '\n')
+		if end < start {
+			return len(src)
+		}
+		if !e.format.escapes || !continues(strings.TrimRight(string(src[start:end]), "\r")) {
+			return end
+		}
+		start = end + 1
+	}
+}
+
+// continues reports whether a .properties line ends in an unescaped backslash.
+func continues(line string) bool {
+	n := len(line) - len(strings.TrimRight(line, "\\"))
+	return n%2 == 1
+}
+
+// line extracts the links of the logical line starting at offset start.
+func (e *extractor) line(start int, raw string) {
+	text := strings.TrimSpace(raw)
+	if text == "" {
+		return
+	}
+
+	if strings.ContainsRune(e.format.comments, rune(text[0])) {
+		if e.comments {
+			e.extract(start, raw, text[1:], e.key(""))
+		}
+		return
+	}
+	if e.format.sections && text[0] == '[' {
+		if name, _, ok := strings.Cut(text[1:], "]"); ok {
+			e.section = strings.TrimSpace(name)
+			return
+		}
+	}
+	if !strings.Contains(text, "http") {
+		return
+	}
+
+	key, value, ok := e.split(text)
+	if !ok {
+		e.extract(start, raw, text, e.key(""))
+		return
+	}
+	e.extract(start, raw, value, e.key(key))
+}
+
+// split splits a key-value line into its key and its value, with quotes,
+// escapes, and inline comments removed.
+func (e *extractor) split(text string) (key, value string, ok bool) {
+	if e.format.escapes {
+		return splitProperty(text)
+	}
+	if e.format.export {
+		text = strings.TrimPrefix(text, "export ")
+	}
+
+	i := strings.IndexAny(text, "=:")
+	if e.format.export {
+		i = strings.IndexByte(text, '=')
+	}
+	// The colon of a URL scheme, as in "proxy_pass http://backend;", isn't a separator
+	if i <= 0 || strings.HasPrefix(text[i:], "://") {
+		return "", "", false
+	}
+	key = strings.TrimSpace(text[:i])
+	value = strings.TrimSpace(text[i+1:])
+
+	if e.format.quotes && len(value) > 1 && (value[0] == '"' || value[0] == '\'') {
+		if end := strings.IndexByte(value[1:], value[0]); end >= 0 {
+			return key, value[1 : end+1], true
+		}
+	}
+	return key, stripInlineComment(value, e.format.inlineMarker), true
+}
+
+// splitProperty splits a .properties line at the first unescaped '=', ':',
+// or whitespace, and unescapes the value.
+func splitProperty(text string) (key, value string, ok bool) {
+	i := 0
+	for ; i < len(text); i++ {
+		if text[i] == '\\' {
+			i++
+			continue
+		}
+		if text[i] == '=' || text[i] == ':' || text[i] == ' ' || text[i] == '\t' {
+			break
+		}
+	}
+	if i == 0 || i >= len(text) {
+		return "", "", false
+	}
+	key = unescape(text[:i])
+	value = strings.TrimLeft(text[i:], " \t")
+	if value != "" && (value[0] == '=' || value[0] == ':') {
+		value = strings.TrimLeft(value[1:], " \t")
+	}
+	return key, unescape(value), true
+}
+
+// unescape resolves .properties escapes and joins continuation lines.
+func unescape(s string) string {
+	if !strings.Contains(s, "\\") {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case '\r', '\n':
+			// Continuation: drop the newline and the next line's indentation
+			for i+1 < len(s) && (s[i+1] == '\n' || s[i+1] == ' ' || s[i+1] == '\t') {
+				i++
+			}
+		case 't':
+			b.WriteByte('\t')
+		case 'n':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+// stripInlineComment removes a comment that starts with one of markers
+// after whitespace, so "https://a.com/#top" keeps its fragment.
+func stripInlineComment(value, markers string) string {
+	for i := 1; i < len(value); i++ {
+		if strings.IndexByte(markers, value[i]) >= 0 && (value[i-1] == ' ' || value[i-1] == '\t') {
+			return strings.TrimSpace(value[:i])
+		}
+	}
+	return value
+}
+
+// key returns the link text of a key: section.key in INI files.
+func (e *extractor) key(key string) string {
+	switch {
+	case e.section == "":
+		return key
+	case key == "":
+		return e.section
+	default:
+		return e.section + "." + key
+	}
+}
+
+// extract records the URLs in value, found on the logical line raw that
+// starts at offset start, with text as the link text.
+func (e *extractor) extract(start int, raw, value, text string) {
+	for _, m := range parser.URLRegex.FindAllStringIndex(value, -1) {
+		url := parser.CleanURLTrailing(value[m[0]:m[1]])
+		if !parser.IsHTTPURL(url) {
+			continue
+		}
+		// Escapes can make the URL differ from the raw text; fall back to the line start
+		offset := start + len(raw) - len(strings.TrimLeft(raw, " \t"))
+		if i := strings.Index(raw, url); i >= 0 {
+			offset = start + i
+		}
+		line, col := parser.OffsetToLineCol(e.lines, offset)
+		e.links = append(e.links, parser.Link{
+			URL:      url,
+			FilePath: e.filePath,
+			Text:     text,
+			Line:     line,
+			Column:   col,
+			Offset:   offset,
+			Type:     parser.LinkTypeAutolink,
+		})
+	}
+}
+
+// init registers a parser per format with the default registry.
+func init() {
+	for _, format := range Formats() {
+		parser.RegisterParser(New(format))
+	}
+}
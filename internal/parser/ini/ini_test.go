@@ -0,0 +1,144 @@
+package ini
+
+import (
+	"testing"
+
+	"github.com/leonardomso/gone/internal/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// texts maps each link's text to its URL.
+func texts(links []parser.Link) map[string]string {
+	out := make(map[string]string, len(links))
+	for _, l := range links {
+		out[l.Text] = l.URL
+	}
+	return out
+}
+
+func TestParser_ValidateAndParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		format   Format
+		file     string
+		content  string
+		expected map[string]string
+	}{
+		{
+			name:   "INISections",
+			format: INI,
+			file:   "app.ini",
+			content: `; https://example.com/commented
+base = https://example.com/root
+
+[oauth]
+callback_url = https://example.com/callback ; registered with the provider
+docs: https://example.com/docs#setup
+# docs_old = https://example.com/old
+`,
+			expected: map[string]string{
+				"base":               "https://example.com/root",
+				"oauth.callback_url": "https://example.com/callback",
+				"oauth.docs":         "https://example.com/docs#setup",
+			},
+		},
+		{
+			name:   "ConfDirectives",
+			format: INI,
+			file:   "nginx.conf",
+			content: `location / {
+    proxy_pass http://127.0.0.1:8080/;
+}
+`,
+			expected: map[string]string{"": "http://127.0.0.1:8080/"},
+		},
+		{
+			name:   "EnvQuotesAndExport",
+			format: Env,
+			file:   ".env",
+			content: `# API_URL=https://example.com/old
+API_URL=https://api.example.com/v1
+export WEBHOOK_URL="https://example.com/hook?a=1 #2"
+DOCS_URL=https://docs.example.com # see onboarding
+TOKEN=abc
+`,
+			expected: map[string]string{
+				"API_URL":     "https://api.example.com/v1",
+				"WEBHOOK_URL": "https://example.com/hook?a=1",
+				"DOCS_URL":    "https://docs.example.com",
+			},
+		},
+		{
+			name:   "PropertiesEscapesAndContinuations",
+			format: Properties,
+			file:   "app.properties",
+			content: `! https://example.com/commented
+site.url=https\://example.com/site
+api.endpoint : https://api.example.com/v2
+help.urls = https://example.com/a, \
+    https://example.com/b
+`,
+			expected: map[string]string{
+				"site.url":     "https://example.com/site",
+				"api.endpoint": "https://api.example.com/v2",
+				"help.urls":    "https://example.com/b",
+			},
+		},
+		{
+			name:     "NoURLs",
+			format:   INI,
+			file:     "app.ini",
+			content:  "[server]\nport = 8080\n",
+			expected: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			links, err := New(tt.format).ValidateAndParse(tt.file, []byte(tt.content))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, texts(links))
+		})
+	}
+}
+
+func TestParser_Positions(t *testing.T) {
+	t.Parallel()
+
+	content := []byte("[auth]\n  login = https://example.com/login\n")
+	links, err := New(INI).ValidateAndParse("app.ini", content)
+	require.NoError(t, err)
+	require.Len(t, links, 1)
+	assert.Equal(t, 2, links[0].Line)
+	assert.Equal(t, 11, links[0].Column)
+	assert.Equal(t, parser.LinkTypeAutolink, links[0].Type)
+}
+
+func TestParser_WithComments(t *testing.T) {
+	t.Parallel()
+
+	content := []byte("# OLD_URL=https://example.com/old\nURL=https://example.com/new\n")
+	p := New(Env)
+
+	links, err := p.WithComments(true).ValidateAndParse(".env", content)
+	require.NoError(t, err)
+	assert.Len(t, links, 2)
+
+	links, err = p.ValidateAndParse(".env", content)
+	require.NoError(t, err)
+	assert.Len(t, links, 1)
+}
+
+func TestRegistration(t *testing.T) {
+	t.Parallel()
+
+	for _, file := range []string{"app.ini", "site.conf", ".env", "prod.env", "app.properties"} {
+		_, ok := parser.GetParserForFile(file)
+		assert.True(t, ok, file)
+	}
+	assert.Equal(t, "ini", parser.FileTypeForFile("site.conf"))
+}
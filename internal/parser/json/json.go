@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/leonardomso/gone/internal/parser"
+	"github.com/leonardomso/gone/internal/parser/openapi"
 )
 
 // Parser implements parser.FileParser for JSON files.
@@ -45,6 +46,10 @@ func (*Parser) ValidateAndParse(filename string, content []byte) ([]parser.Link,
 		content:  content,
 		lines:    lines,
 		links:    make([]parser.Link, 0, 32),
+		next:     map[string]int{},
+	}
+	if obj, ok := v.(map[string]any); ok {
+		extractor.openapi = openapi.IsDocument(obj)
 	}
 
 	extractor.extractFromValue(v, "")
@@ -58,6 +63,10 @@ type linkExtractor struct {
 	content  []byte
 	lines    []int
 	links    []parser.Link
+	openapi  bool // Only check the link fields of an OpenAPI document
+
+	// Offset where the next occurrence of each URL is searched
+	next map[string]int
 }
 
 // extractFromValue recursively extracts URLs from a JSON value.
@@ -78,6 +87,9 @@ func (e *linkExtractor) extractFromString(s, path string) {
 	if !strings.Contains(s, "http") {
 		return
 	}
+	if e.openapi && !openapi.IsLink(path, s) {
+		return
+	}
 
 	// Find all URLs in the string
 	matches := parser.URLRegex.FindAllString(s, -1)
@@ -89,13 +101,14 @@ func (e *linkExtractor) extractFromString(s, path string) {
 		}
 
 		// Find the position of this URL in the original content
-		line, col := e.findURLPosition(url)
+		line, col, offset := e.findURLPosition(url)
 
 		e.links = append(e.links, parser.Link{
 			URL:      url,
 			FilePath: e.filePath,
 			Line:     line,
 			Column:   col,
+			Offset:   offset,
 			Text:     path,
 			Type:     parser.LinkTypeAutolink,
 		})
@@ -106,13 +119,14 @@ func (e *linkExtractor) extractFromString(s, path string) {
 func (e *linkExtractor) extractFromObject(obj map[string]any, path string) {
 	for key, value := range obj {
 		// Check if the key itself is a URL
-		if parser.IsHTTPURL(key) {
-			line, col := e.findURLPosition(key)
+		if !e.openapi && parser.IsHTTPURL(key) {
+			line, col, offset := e.findURLPosition(key)
 			e.links = append(e.links, parser.Link{
 				URL:      key,
 				FilePath: e.filePath,
 				Line:     line,
 				Column:   col,
+				Offset:   offset,
 				Text:     path + ".<key>",
 				Type:     parser.LinkTypeAutolink,
 			})
@@ -138,15 +152,22 @@ func (e *linkExtractor) extractFromArray(arr []any, path string) {
 	}
 }
 
-// findURLPosition finds the line and column of a URL in the content.
-// This is a best-effort approach since JSON doesn't preserve positions after parsing.
-func (e *linkExtractor) findURLPosition(url string) (line, col int) {
-	idx := bytes.Index(e.content, []byte(url))
+// findURLPosition finds the line, column, and byte offset of a URL in the content.
+// JSON doesn't preserve positions after parsing, so a URL's occurrences are
+// handed out in order: each call for a URL gets its next occurrence. URLs
+// that aren't written verbatim (e.g. with escaped slashes) get the first.
+func (e *linkExtractor) findURLPosition(url string) (line, col, offset int) {
+	idx := parser.IndexURL(e.content, url, e.next[url])
+	if idx == -1 {
+		idx = bytes.Index(e.content, []byte(url))
+	}
 	if idx == -1 {
-		return 1, 1
+		return 1, 1, 0
 	}
+	e.next[url] = idx + len(url)
 
-	return parser.OffsetToLineCol(e.lines, idx)
+	line, col = parser.OffsetToLineCol(e.lines, idx)
+	return line, col, idx
 }
 
 // init registers the JSON parser with the default registry.
@@ -163,6 +163,30 @@ func TestParser_ValidateAndParse(t *testing.T) {
 		assert.Contains(t, urls, "https://example.com")
 		assert.Contains(t, urls, "https://github.com")
 	})
+
+	t.Run("OpenAPIChecksLinkFields", func(t *testing.T) {
+		t.Parallel()
+		content := []byte(`{
+			"swagger": "2.0",
+			"info": {"license": {"url": "https://opensource.org/licenses/MIT"}},
+			"paths": {"/pets": {"get": {
+				"externalDocs": {"url": "https://docs.example.com/pets"},
+				"responses": {"200": {"examples": {"application/json": {"link": "https://example.com/pet"}}}}
+			}}},
+			"https://example.com/key": true
+		}`)
+		links, err := p.ValidateAndParse("swagger.json", content)
+		require.NoError(t, err)
+
+		paths := map[string]string{}
+		for _, l := range links {
+			paths[l.Text] = l.URL
+		}
+		assert.Equal(t, map[string]string{
+			"info.license.url":                 "https://opensource.org/licenses/MIT",
+			"paths./pets.get.externalDocs.url": "https://docs.example.com/pets",
+		}, paths)
+	})
 }
 
 func TestParser_ParseFromFile(t *testing.T) {
@@ -250,6 +274,35 @@ func TestParser_LineNumbers(t *testing.T) {
 			assert.Greater(t, link.Line, 0)
 		}
 	})
+
+	t.Run("TracksColumnsAndOffsets", func(t *testing.T) {
+		t.Parallel()
+		content := []byte(`{"home": "https://example.com/home"}`)
+		links, err := p.ValidateAndParse("test.json", content)
+		require.NoError(t, err)
+		require.Len(t, links, 1)
+		assert.Equal(t, 11, links[0].Column)
+		assert.Equal(t, 10, links[0].Offset)
+	})
+
+	t.Run("RepeatedURLsGetTheirOwnPositions", func(t *testing.T) {
+		t.Parallel()
+		content := []byte(`{
+	"docs": "https://example.com/docs",
+	"home": "https://example.com",
+	"links": ["https://example.com"]
+}`)
+		links, err := p.ValidateAndParse("test.json", content)
+		require.NoError(t, err)
+
+		var lines []int
+		for _, link := range links {
+			if link.URL == "https://example.com" {
+				lines = append(lines, link.Line)
+			}
+		}
+		assert.ElementsMatch(t, []int{3, 4}, lines)
+	})
 }
 
 func TestCleanURLTrailing(t *testing.T) {
@@ -0,0 +1,60 @@
+package markdown
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+// htmlAnchorAttr matches id and name attributes in raw HTML, such as
+// <a name="install"></a> or <h2 id="setup">.
+var htmlAnchorAttr = regexp.MustCompile(`<[a-zA-Z][^>]*?\s(?:id|name)\s*=\s*["']([^"']+)["']`)
+
+// HeadingAnchors returns the anchors a fragment can point at in markdown
+// content: the GitHub-style ids of its headings and the id and name
+// attributes of HTML elements in it. Repeated headings get "-1", "-2", and so
+// on appended, as GitHub does.
+func HeadingAnchors(content []byte) map[string]bool {
+	doc := mdParser.Parser().Parse(text.NewReader(content))
+	e := &linkExtractor{source: content}
+	anchors := map[string]bool{}
+	seen := map[string]int{}
+
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering || n.Kind() != ast.KindHeading {
+			return ast.WalkContinue, nil
+		}
+		slug := Slugify(e.getNodeText(n))
+		if count := seen[slug]; count > 0 {
+			anchors[slug+"-"+strconv.Itoa(count)] = true
+		} else {
+			anchors[slug] = true
+		}
+		seen[slug]++
+		return ast.WalkSkipChildren, nil
+	})
+
+	for _, m := range htmlAnchorAttr.FindAllSubmatch(content, -1) {
+		anchors[string(m[1])] = true
+	}
+	return anchors
+}
+
+// Slugify turns heading text into the id GitHub gives the heading: lowercase,
+// spaces replaced by hyphens, and punctuation other than "-" and "_" dropped.
+func Slugify(heading string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(heading)) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsNumber(r), r == '-', r == '_':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteByte('-')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,44 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeadingAnchors(t *testing.T) {
+	t.Parallel()
+
+	content := []byte(`# Getting Started
+
+## Install ` + "`gone`" + `
+
+## FAQ
+
+## FAQ
+
+<a name="legacy-link"></a>
+<h3 id="custom">Custom</h3>
+`)
+
+	anchors := HeadingAnchors(content)
+	for _, want := range []string{"getting-started", "install-gone", "faq", "faq-1", "legacy-link", "custom"} {
+		assert.True(t, anchors[want], want)
+	}
+	assert.False(t, anchors["faq-2"])
+}
+
+func TestSlugify(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"Getting Started":           "getting-started",
+		"What's new in v2.0?":       "whats-new-in-v20",
+		"snake_case and kebab-case": "snake_case-and-kebab-case",
+		"  Padded  ":                "padded",
+		"Café & Crème":              "café--crème",
+	}
+	for heading, want := range tests {
+		assert.Equal(t, want, Slugify(heading), heading)
+	}
+}
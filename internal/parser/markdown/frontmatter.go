@@ -0,0 +1,86 @@
+package markdown
+
+import (
+	"bytes"
+
+	"github.com/leonardomso/gone/internal/parser"
+	tomlparser "github.com/leonardomso/gone/internal/parser/toml"
+	yamlparser "github.com/leonardomso/gone/internal/parser/yaml"
+)
+
+// frontmatter is a YAML (---) or TOML (+++) block at the top of a markdown file.
+type frontmatter struct {
+	parser parser.FileParser
+	body   []byte // The block's content, without its delimiters, starting on line 2
+	start  int    // Offset of body
+	end    int    // Offset just past the closing delimiter line
+}
+
+// findFrontmatter returns the frontmatter block that opens content, if any.
+func findFrontmatter(content []byte) (frontmatter, bool) {
+	var fm frontmatter
+	var delim []byte
+	switch {
+	case bytes.HasPrefix(content, []byte("---")):
+		delim, fm.parser = []byte("---"), yamlparser.New()
+	case bytes.HasPrefix(content, []byte("+++")):
+		delim, fm.parser = []byte("+++"), tomlparser.New()
+	default:
+		return fm, false
+	}
+
+	// The opening delimiter must be alone on the first line
+	first := bytes.IndexByte(content, '\n')
+	if first < 0 || len(bytes.TrimSpace(content[:first])) != len(delim) {
+		return fm, false
+	}
+
+	start := first + 1
+	for offset := start; offset < len(content); {
+		end := bytes.IndexByte(content[offset:], '\n')
+		next := len(content)
+		if end >= 0 {
+			end += offset
+			next = end + 1
+		} else {
+			end = len(content)
+		}
+		if bytes.Equal(bytes.TrimSpace(content[offset:end]), delim) {
+			fm.body = content[start:offset]
+			fm.start = start
+			fm.end = next
+			return fm, true
+		}
+		offset = next
+	}
+	return fm, false
+}
+
+// extractFrontmatter returns the links in a file's frontmatter, labeled
+// with their key path, and the content with the block blanked out so the
+// markdown parser doesn't see it again. Line and byte offsets are kept.
+// Frontmatter that isn't valid YAML or TOML is left to the markdown parser.
+func extractFrontmatter(content []byte, filePath string) ([]parser.Link, []byte) {
+	fm, ok := findFrontmatter(content)
+	if !ok || !bytes.Contains(fm.body, []byte("http")) {
+		return nil, content
+	}
+
+	links, err := fm.parser.ValidateAndParse(filePath, fm.body)
+	if err != nil {
+		return nil, content
+	}
+	for i := range links {
+		// The body starts after the opening delimiter
+		links[i].Line++
+		links[i].Offset += fm.start
+	}
+
+	blanked := bytes.Clone(content)
+	for i := range fm.end {
+		if blanked[i] != '\n' {
+			blanked[i] = ' '
+		}
+	}
+	return links, blanked
+}
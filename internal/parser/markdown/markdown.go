@@ -5,6 +5,8 @@ package markdown
 
 import (
 	"bytes"
+	"context"
+	"path/filepath"
 	"regexp"
 	"strings"
 
@@ -12,6 +14,7 @@ import (
 	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark/ast"
 	"github.com/yuin/goldmark/extension"
+	extast "github.com/yuin/goldmark/extension/ast"
 	gmparser "github.com/yuin/goldmark/parser"
 	"github.com/yuin/goldmark/text"
 )
@@ -35,6 +38,12 @@ func (*Parser) ValidateAndParse(filename string, content []byte) ([]parser.Link,
 	return ExtractLinksFromContent(content, filename)
 }
 
+// ParseContext is ValidateAndParse, stopping once ctx is done, so a huge
+// generated file over its parse budget doesn't keep parsing.
+func (*Parser) ParseContext(ctx context.Context, filename string, content []byte) ([]parser.Link, error) {
+	return extractLinks(ctx, content, filename)
+}
+
 // init registers the markdown parser with the default registry.
 func init() {
 	parser.RegisterParser(New())
@@ -42,6 +51,8 @@ func init() {
 
 // linkExtractor walks the AST and extracts links.
 type linkExtractor struct {
+	ctx context.Context // Stops the walk once done
+
 	// Track reference definitions: name -> (url, line)
 	refDefs  map[string]refDef
 	filePath string
@@ -51,6 +62,16 @@ type linkExtractor struct {
 
 	// Track if we're inside a code block
 	inCodeBlock bool
+
+	// Label of the footnote definition being walked, e.g. "^1"
+	footnote string
+
+	// Offset just past the last URL located in the source
+	cursor int
+
+	// MDX only: byte ranges of code blocks and spans
+	mdx        bool
+	codeRanges [][2]int
 }
 
 // refDef holds reference definition info.
@@ -71,7 +92,9 @@ var refDefRegex = regexp.MustCompile(`^\s*\[([^\]]+)\]:\s*(\S+)`)
 // to avoid the overhead of creating a new parser for each file.
 var mdParser = goldmark.New(
 	goldmark.WithExtensions(
-		extension.Linkify, // Auto-link bare URLs
+		extension.Linkify,  // Auto-link bare URLs
+		extension.Footnote, // [^1]: definitions, whose links would otherwise read as reference definitions
+		extension.TaskList, // - [x] items
 	),
 	goldmark.WithParserOptions(
 		gmparser.WithAutoHeadingID(),
@@ -80,9 +103,24 @@ var mdParser = goldmark.New(
 
 // ExtractLinksFromContent extracts links from markdown content.
 func ExtractLinksFromContent(content []byte, filePath string) ([]parser.Link, error) {
+	return extractLinks(context.Background(), content, filePath)
+}
+
+// extractLinks extracts links from markdown content, stopping with ctx's
+// error once ctx is done.
+func extractLinks(ctx context.Context, content []byte, filePath string) ([]parser.Link, error) {
+	// Take links from YAML or TOML frontmatter first, hiding it from goldmark
+	frontmatterLinks, content := extractFrontmatter(content, filePath)
+
 	// Parse the markdown into AST using the package-level parser
-	reader := text.NewReader(content)
+	var reader text.Reader = text.NewReader(content)
+	if ctx.Done() != nil {
+		reader = &cancelReader{Reader: reader, ctx: ctx}
+	}
 	doc := mdParser.Parser().Parse(reader)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
 	// Build line offset index for position calculation
 	lines := parser.BuildLineIndex(content)
@@ -93,40 +131,85 @@ func ExtractLinksFromContent(content []byte, filePath string) ([]parser.Link, er
 	// Create extractor and walk the AST
 	// Pre-allocate links slice - typical markdown files have ~10-30 links
 	extractor := &linkExtractor{
-		links:    make([]parser.Link, 0, 32),
+		ctx:      ctx,
+		links:    append(make([]parser.Link, 0, 32), frontmatterLinks...),
 		source:   content,
 		filePath: filePath,
 		lines:    lines,
 		refDefs:  refDefs,
+		mdx:      strings.EqualFold(filepath.Ext(filePath), ".mdx"),
 	}
-
 	// Walk the AST
-	_ = ast.Walk(doc, extractor.walk)
+	if err := ast.Walk(doc, extractor.walk); err != nil {
+		return nil, err
+	}
 
 	// Also extract HTML links (goldmark doesn't parse these as links)
 	extractor.extractHTMLLinks(content)
 
+	// MDX files also link from JSX components and ESM imports
+	if extractor.mdx {
+		extractor.extractJSX(content)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	return extractor.links, nil
 }
 
+// cancelReader is a goldmark reader that runs out of lines once ctx is
+// done, which ends parsing.
+type cancelReader struct {
+	text.Reader
+	ctx context.Context
+}
+
+// PeekLine returns the current line, or nil once ctx is done.
+func (r *cancelReader) PeekLine() ([]byte, text.Segment) {
+	if r.ctx.Err() != nil {
+		return nil, text.Segment{}
+	}
+	return r.Reader.PeekLine()
+}
+
 // walk is the AST walker function.
 func (e *linkExtractor) walk(n ast.Node, entering bool) (ast.WalkStatus, error) {
+	if err := e.ctx.Err(); err != nil {
+		return ast.WalkStop, err
+	}
+
 	// Track code block state
 	if n.Kind() == ast.KindCodeBlock || n.Kind() == ast.KindFencedCodeBlock {
 		e.inCodeBlock = entering
+		if entering && e.mdx {
+			e.recordCode(n)
+		}
 		return ast.WalkContinue, nil
 	}
+	if n.Kind() == ast.KindCodeSpan && entering && e.mdx {
+		e.recordCode(n)
+	}
 
 	// Skip if inside code block
 	if e.inCodeBlock {
 		return ast.WalkContinue, nil
 	}
 
+	if footnote, ok := n.(*extast.Footnote); ok {
+		e.footnote = ""
+		if entering {
+			e.footnote = "^" + string(footnote.Ref)
+		}
+		return ast.WalkContinue, nil
+	}
+
 	// Only process on enter
 	if !entering {
 		return ast.WalkContinue, nil
 	}
 
+	found := len(e.links)
 	switch node := n.(type) {
 	case *ast.Link:
 		e.handleLink(node)
@@ -136,6 +219,13 @@ func (e *linkExtractor) walk(n ast.Node, entering bool) (ast.WalkStatus, error)
 		e.handleAutoLink(node)
 	}
 
+	// Links in a footnote definition carry its label
+	for i := found; i < len(e.links) && e.footnote != ""; i++ {
+		if e.links[i].RefName == "" {
+			e.links[i].RefName = e.footnote
+		}
+	}
+
 	return ast.WalkContinue, nil
 }
 
@@ -159,23 +249,104 @@ func (e *linkExtractor) handleLink(node *ast.Link) {
 		FilePath: e.filePath,
 		Line:     line,
 		Column:   col,
+		Offset:   e.inlineStart(node),
 		Text:     linkText,
 		Type:     parser.LinkTypeInline,
 	}
 
-	// Check reference definitions for this URL
-	for refName, refDef := range e.refDefs {
-		if refDef.url == linkURL && refDef.line != line {
+	// Reference links are told apart by their syntax; when it can't be
+	// read, by matching the URL against the definitions
+	switch label, syntax := e.linkSyntax(node); syntax {
+	case syntaxReference:
+		if def, ok := e.refDefs[label]; ok && def.url == linkURL {
 			link.Type = parser.LinkTypeReference
-			link.RefName = refName
-			link.RefDefLine = refDef.line
-			break
+			link.RefName = label
+			link.RefDefLine = def.line
 		}
+	case syntaxUnknown:
+		e.matchRefDef(&link)
+	case syntaxInline:
+		// Written with its URL
+	}
+
+	// Inline links point at their URL; reference links, whose URL is
+	// defined on RefDefLine, at their text
+	if link.Type == parser.LinkTypeInline {
+		link.Offset = e.urlOffset(node, linkURL)
+		link.Line, link.Column = parser.OffsetToLineCol(e.lines, link.Offset)
 	}
 
 	e.links = append(e.links, link)
 }
 
+// Link syntaxes, as told by linkSyntax.
+const (
+	syntaxUnknown = iota
+	syntaxInline
+	syntaxReference
+)
+
+// linkSyntax tells whether a link is written inline, [text](url), or as a
+// reference, [text][label], [label][] or [label]. For references, it also
+// returns the normalized label.
+func (e *linkExtractor) linkSyntax(node *ast.Link) (string, int) {
+	textEnd, ok := lastTextStop(node)
+	if !ok {
+		return "", syntaxUnknown
+	}
+	// The text may end inside emphasis or a code span
+	closing := bytes.IndexByte(e.source[textEnd:], ']')
+	if closing < 0 {
+		return "", syntaxUnknown
+	}
+	rest := e.source[textEnd+closing+1:]
+
+	switch {
+	case bytes.HasPrefix(rest, []byte("(")):
+		return "", syntaxInline
+	case bytes.HasPrefix(rest, []byte("[")):
+		if end := bytes.IndexByte(rest, ']'); end > 1 {
+			return normalizeLabel(string(rest[1:end])), syntaxReference
+		}
+	}
+	// Collapsed and shortcut references use the text as their label
+	return normalizeLabel(e.getNodeText(node)), syntaxReference
+}
+
+// matchRefDef marks a link as a reference if a definition elsewhere has its
+// URL, picking the first such definition in the file.
+func (e *linkExtractor) matchRefDef(link *parser.Link) {
+	for name, def := range e.refDefs {
+		if def.url != link.URL || def.line == link.Line {
+			continue
+		}
+		if link.RefDefLine == 0 || def.line < link.RefDefLine {
+			link.Type = parser.LinkTypeReference
+			link.RefName = name
+			link.RefDefLine = def.line
+		}
+	}
+}
+
+// lastTextStop returns the offset just past the last text inside n.
+func lastTextStop(n ast.Node) (int, bool) {
+	for c := n.LastChild(); c != nil; c = c.PreviousSibling() {
+		if t, ok := c.(*ast.Text); ok {
+			return t.Segment.Stop, true
+		}
+		if stop, ok := lastTextStop(c); ok {
+			return stop, true
+		}
+	}
+	return 0, false
+}
+
+// normalizeLabel normalizes a reference label for matching: labels are
+// case-insensitive and runs of whitespace count as one space.
+func normalizeLabel(label string) string {
+	return strings.ToLower(strings.Join(strings.Fields(label), " "))
+}
+
 // handleImage processes an image link.
 func (e *linkExtractor) handleImage(node *ast.Image) {
 	imageURL := string(node.Destination)
@@ -188,15 +359,22 @@ func (e *linkExtractor) handleImage(node *ast.Image) {
 	// Get alt text from node children
 	altText := e.getNodeText(node)
 
-	line, col := e.getPosition(node)
+	offset := e.urlOffset(node, imageURL)
+	line, col := parser.OffsetToLineCol(e.lines, offset)
+
+	linkType := parser.LinkTypeImage
+	if parser.IsBadgeURL(imageURL) {
+		linkType = parser.LinkTypeBadge
+	}
 
 	e.links = append(e.links, parser.Link{
 		URL:      imageURL,
 		FilePath: e.filePath,
 		Line:     line,
 		Column:   col,
+		Offset:   offset,
 		Text:     altText,
-		Type:     parser.LinkTypeImage,
+		Type:     linkType,
 	})
 }
 
@@ -209,13 +387,15 @@ func (e *linkExtractor) handleAutoLink(node *ast.AutoLink) {
 		return
 	}
 
-	line, col := e.getPosition(node)
+	offset := e.urlOffset(node, url)
+	line, col := parser.OffsetToLineCol(e.lines, offset)
 
 	e.links = append(e.links, parser.Link{
 		URL:      url,
 		FilePath: e.filePath,
 		Line:     line,
 		Column:   col,
+		Offset:   offset,
 		Text:     "", // Auto-links don't have separate text
 		Type:     parser.LinkTypeAutolink,
 	})
@@ -238,14 +418,15 @@ func (e *linkExtractor) extractHTMLLinks(content []byte) {
 			continue
 		}
 
-		// Calculate line and column from byte offset
-		line, col := parser.OffsetToLineCol(e.lines, match[0])
+		// Calculate line and column from the href value's byte offset
+		line, col := parser.OffsetToLineCol(e.lines, match[2])
 
 		e.links = append(e.links, parser.Link{
 			URL:      url,
 			FilePath: e.filePath,
 			Line:     line,
 			Column:   col,
+			Offset:   match[2],
 			Text:     linkText,
 			Type:     parser.LinkTypeHTML,
 		})
@@ -297,6 +478,58 @@ func (e *linkExtractor) getPosition(n ast.Node) (line, col int) {
 	return 1, 1 // Default if we can't determine position
 }
 
+// urlOffset returns the byte offset of url in the source of the inline
+// node n, searched for from the node's start to the end of its block, or
+// the node's start if the URL isn't written verbatim there (e.g. escaped).
+func (e *linkExtractor) urlOffset(n ast.Node, url string) int {
+	start, end := e.inlineStart(n), len(e.source)
+	if block := blockOf(n); block != nil {
+		lines := block.Lines()
+		end = lines.At(lines.Len() - 1).Stop
+	}
+	// Links earlier in the block were already located
+	from := start
+	if e.cursor > from && e.cursor < end {
+		from = e.cursor
+	}
+	if from < end {
+		if i := bytes.Index(e.source[from:end], []byte(url)); i >= 0 {
+			e.cursor = from + i + len(url)
+			return from + i
+		}
+	}
+	return start
+}
+
+// inlineStart returns the byte offset where the inline node n starts, or
+// about: its first text, the end of the text before it, or its block's start.
+func (e *linkExtractor) inlineStart(n ast.Node) int {
+	for c := n.FirstChild(); c != nil; c = c.FirstChild() {
+		if t, ok := c.(*ast.Text); ok {
+			return t.Segment.Start
+		}
+	}
+	for p := n.PreviousSibling(); p != nil; p = p.PreviousSibling() {
+		if t, ok := p.(*ast.Text); ok {
+			return t.Segment.Stop
+		}
+	}
+	if block := blockOf(n); block != nil {
+		return block.Lines().At(0).Start
+	}
+	return 0
+}
+
+// blockOf returns the nearest block ancestor of n with source lines, or nil.
+func blockOf(n ast.Node) ast.Node {
+	for p := n.Parent(); p != nil; p = p.Parent() {
+		if p.Type() == ast.TypeBlock && p.Lines().Len() > 0 {
+			return p
+		}
+	}
+	return nil
+}
+
 // extractRefDefs extracts reference-style link definitions from markdown content.
 // These are lines in the format: [refname]: url
 // Reference names are normalized to lowercase for case-insensitive matching.
@@ -318,13 +551,16 @@ func extractRefDefs(content []byte) map[string]refDef {
 			start = start + end + 1
 		}
 
+		// Footnote definitions ([^1]: ...) aren't link references
 		match := refDefRegex.FindSubmatch(line)
-		if match != nil {
-			name := strings.ToLower(string(match[1]))
-			url := string(match[2])
-			defs[name] = refDef{
-				url:  url,
-				line: lineNum,
+		// The first definition of a label wins, as in CommonMark
+		if match != nil && match[1][0] != '^' {
+			name := normalizeLabel(string(match[1]))
+			if _, ok := defs[name]; !ok {
+				defs[name] = refDef{
+					url:  string(match[2]),
+					line: lineNum,
+				}
 			}
 		}
 		lineNum++
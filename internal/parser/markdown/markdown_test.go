@@ -2,6 +2,7 @@ package markdown
 
 import (
 	"bytes"
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
@@ -69,6 +70,21 @@ Some paragraph without any links.
 	})
 }
 
+func TestParser_ParseContext(t *testing.T) {
+	t.Parallel()
+
+	content := bytes.Repeat([]byte("See [docs](https://example.com/docs).\n\n"), 1000)
+	links, err := New().ParseContext(context.Background(), "big.md", content)
+	require.NoError(t, err)
+	assert.Len(t, links, 1000)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	links, err = New().ParseContext(ctx, "big.md", content)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, links)
+}
+
 func TestExtractLinks_LinkTypes(t *testing.T) {
 	t.Parallel()
 
@@ -415,6 +431,59 @@ func TestExtractLinks_ReferenceDefinitions(t *testing.T) {
 		require.NoError(t, err)
 		require.Len(t, links, 1)
 		assert.Equal(t, "http://example.com", links[0].URL)
+		assert.Equal(t, parser.LinkTypeReference, links[0].Type)
+		assert.Equal(t, "ref", links[0].RefName)
+	})
+
+	t.Run("InlineLinkWithSameURL", func(t *testing.T) {
+		t.Parallel()
+		content := []byte("[Docs][docs], [*inline*](http://example.com/docs), [`code]`](http://example.com/docs)\n\n" +
+			"[docs]: http://example.com/docs\n")
+		links, err := ExtractLinksFromContent(content, "test.md")
+		require.NoError(t, err)
+		require.Len(t, links, 3)
+
+		assert.Equal(t, parser.LinkTypeReference, links[0].Type)
+		assert.Equal(t, 3, links[0].RefDefLine)
+		for _, l := range links[1:] {
+			assert.Equal(t, parser.LinkTypeInline, l.Type, l.Text)
+			assert.Empty(t, l.RefName)
+			assert.Zero(t, l.RefDefLine)
+		}
+	})
+
+	t.Run("LabelsSharingURL", func(t *testing.T) {
+		t.Parallel()
+		content := []byte(`[One][docs], [Two][Guide], [guide][], and [docs].
+
+[docs]: http://example.com/docs
+[guide]: http://example.com/docs
+`)
+		links, err := ExtractLinksFromContent(content, "test.md")
+		require.NoError(t, err)
+		require.Len(t, links, 4)
+
+		names := make([]string, 0, len(links))
+		for _, l := range links {
+			names = append(names, l.RefName)
+		}
+		assert.Equal(t, []string{"docs", "guide", "guide", "docs"}, names)
+		assert.Equal(t, 4, links[1].RefDefLine)
+	})
+
+	t.Run("FirstDefinitionWins", func(t *testing.T) {
+		t.Parallel()
+		content := []byte(`[Docs][docs]
+
+[docs]: http://example.com/first
+[DOCS]: http://example.com/second
+`)
+		links, err := ExtractLinksFromContent(content, "test.md")
+		require.NoError(t, err)
+		require.Len(t, links, 1)
+		assert.Equal(t, "http://example.com/first", links[0].URL)
+		assert.Equal(t, parser.LinkTypeReference, links[0].Type)
+		assert.Equal(t, 3, links[0].RefDefLine)
 	})
 }
 
@@ -1200,3 +1269,213 @@ func TestExtractHTMLLinks_EdgeCases(t *testing.T) {
 		require.Len(t, links, 1)
 	})
 }
+
+func TestExtractLinks_MDX(t *testing.T) {
+	t.Parallel()
+
+	content := []byte(`import { Chart } from "https://esm.sh/chart@1"
+import Local from './local'
+export { theme } from 'https://cdn.example.com/theme.js'
+
+# Title
+
+<Card url="https://card.example.com" title="Card">
+  body
+</Card>
+
+<Card
+  href={"https://multi.example.com"}
+  other='https://other.example.com'
+/>
+
+Text <Link to="https://inline.example.com">x</Link> and <span data-x="https://lower.example.com">y</span>
+
+` + "```jsx\n<Card url=\"https://code.example.com\" />\nimport x from \"https://code-import.example.com\"\n```\n\n" +
+		"`<Card url=\"https://span.example.com\" />`\n")
+
+	t.Run("MDX", func(t *testing.T) {
+		t.Parallel()
+		links, err := ExtractLinksFromContent(content, "page.mdx")
+		require.NoError(t, err)
+
+		got := map[string]string{}
+		for _, l := range links {
+			got[l.URL] = l.Text
+		}
+		assert.Equal(t, map[string]string{
+			"https://card.example.com":         "Card url",
+			"https://multi.example.com":        "Card href",
+			"https://other.example.com":        "Card other",
+			"https://inline.example.com":       "Link to",
+			"https://esm.sh/chart@1":           "import",
+			"https://cdn.example.com/theme.js": "import",
+		}, got)
+
+		for _, l := range links {
+			if l.URL == "https://card.example.com" {
+				assert.Equal(t, 7, l.Line)
+				assert.Equal(t, 12, l.Column)
+				assert.Equal(t, parser.LinkTypeHTML, l.Type)
+			}
+		}
+	})
+
+	t.Run("MarkdownIgnoresJSX", func(t *testing.T) {
+		t.Parallel()
+		links, err := ExtractLinksFromContent(content, "page.md")
+		require.NoError(t, err)
+		for _, l := range links {
+			assert.NotEqual(t, "Card url", l.Text)
+		}
+	})
+}
+
+func TestExtractLinks_Frontmatter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("YAML", func(t *testing.T) {
+		t.Parallel()
+		content := []byte(`---
+title: Post
+canonical: https://example.com/post
+og:
+  image: https://cdn.example.com/og.png
+---
+
+# Post
+
+See https://example.com/body.
+`)
+		links, err := ExtractLinksFromContent(content, "post.md")
+		require.NoError(t, err)
+		require.Len(t, links, 3)
+
+		assert.Equal(t, "canonical", links[0].Text)
+		assert.Equal(t, "https://example.com/post", links[0].URL)
+		assert.Equal(t, 3, links[0].Line)
+		assert.Equal(t, "og.image", links[1].Text)
+		assert.Equal(t, 5, links[1].Line)
+		assert.Equal(t, "https://example.com/body", links[2].URL)
+	})
+
+	t.Run("TOML", func(t *testing.T) {
+		t.Parallel()
+		content := []byte("+++\ntitle = \"Post\"\nog_url = \"https://example.com/og\"\n+++\n\nBody\n")
+		links, err := ExtractLinksFromContent(content, "post.md")
+		require.NoError(t, err)
+		require.Len(t, links, 1)
+		assert.Equal(t, "og_url", links[0].Text)
+		assert.Equal(t, 3, links[0].Line)
+	})
+
+	t.Run("InvalidFrontmatterIsText", func(t *testing.T) {
+		t.Parallel()
+		content := []byte("---\nurl: https://example.com\nkey: [unclosed\n---\n")
+		links, err := ExtractLinksFromContent(content, "post.md")
+		require.NoError(t, err)
+		require.Len(t, links, 1)
+		assert.Empty(t, links[0].Text)
+	})
+
+	t.Run("NoClosingDelimiter", func(t *testing.T) {
+		t.Parallel()
+		_, ok := findFrontmatter([]byte("---\nurl: https://example.com\n"))
+		assert.False(t, ok)
+	})
+}
+
+func TestExtractLinks_FootnotesAndTaskLists(t *testing.T) {
+	t.Parallel()
+
+	content := []byte(`# Notes
+
+Text[^1] and [^note].
+
+- [x] Done: https://example.com/task
+- [ ] [Todo](https://example.com/todo)
+
+[^1]: https://example.com/fn1
+[^note]: See [docs](https://example.com/docs).
+    Continued at https://example.com/cont
+
+Use [the ref][ref].
+
+[ref]: https://example.com/ref
+`)
+	links, err := ExtractLinksFromContent(content, "notes.md")
+	require.NoError(t, err)
+
+	// Footnote definitions are walked after the document body
+	type found struct {
+		url     string
+		refName string
+	}
+	got := make([]found, 0, len(links))
+	for _, l := range links {
+		got = append(got, found{l.URL, l.RefName})
+	}
+	assert.Equal(t, []found{
+		{"https://example.com/task", ""},
+		{"https://example.com/todo", ""},
+		{"https://example.com/ref", "ref"},
+		{"https://example.com/fn1", "^1"},
+		{"https://example.com/docs", "^note"},
+		{"https://example.com/cont", "^note"},
+	}, got)
+	assert.Equal(t, 9, links[4].Line)
+
+	// Footnote definitions aren't mistaken for reference definitions
+	refs := extractRefDefs(content)
+	assert.NotContains(t, refs, "^1")
+	assert.Contains(t, refs, "ref")
+}
+
+func TestExtractLinks_Badges(t *testing.T) {
+	t.Parallel()
+
+	content := []byte(`# Project
+
+[![CI](https://github.com/owner/repo/actions/workflows/ci.yml/badge.svg)](https://github.com/owner/repo/actions)
+![Stars](https://img.shields.io/github/stars/owner/repo)
+![Logo](https://example.com/logo.png)
+`)
+	links, err := ExtractLinksFromContent(content, "README.md")
+	require.NoError(t, err)
+
+	types := map[string]parser.LinkType{}
+	for _, l := range links {
+		types[l.URL] = l.Type
+	}
+	assert.Equal(t, map[string]parser.LinkType{
+		"https://github.com/owner/repo/actions/workflows/ci.yml/badge.svg": parser.LinkTypeBadge,
+		"https://github.com/owner/repo/actions":                            parser.LinkTypeInline,
+		"https://img.shields.io/github/stars/owner/repo":                   parser.LinkTypeBadge,
+		"https://example.com/logo.png":                                     parser.LinkTypeImage,
+	}, types)
+}
+
+func TestExtractLinks_Positions(t *testing.T) {
+	t.Parallel()
+
+	content := []byte(`# Title
+
+See [the **docs**](https://example.com/docs) and https://example.com/bare,
+then ![logo](https://example.com/logo.png) or <a href="https://example.com/html">x</a>.
+
+[Same](https://example.com/same) and [same again](https://example.com/same).
+`)
+	links, err := ExtractLinksFromContent(content, "doc.md")
+	require.NoError(t, err)
+	require.Len(t, links, 6)
+
+	// Every link points at its URL
+	for _, l := range links {
+		assert.Equal(t, l.URL, string(content[l.Offset:l.Offset+len(l.URL)]), l.URL)
+		line, col := parser.OffsetToLineCol(parser.BuildLineIndex(content), l.Offset)
+		assert.Equal(t, line, l.Line, l.URL)
+		assert.Equal(t, col, l.Column, l.URL)
+	}
+	assert.Equal(t, 3, links[0].Line)
+	assert.Equal(t, 20, links[0].Column)
+	assert.NotEqual(t, links[3].Offset, links[4].Offset, "repeated URLs get their own offset")
+}
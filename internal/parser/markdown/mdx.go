@@ -0,0 +1,103 @@
+package markdown
+
+import (
+	"regexp"
+
+	"github.com/leonardomso/gone/internal/parser"
+	"github.com/yuin/goldmark/ast"
+)
+
+// jsxTagRegex matches the opening tag of a JSX component, whose name starts
+// with an upper-case letter (<Card ...>, <Docs.Link ...>). Lower-case HTML
+// elements are left to htmlLinkRegex.
+var jsxTagRegex = regexp.MustCompile(`<([A-Z][\w.]*)((?:[^>"'{]|"[^"]*"|'[^']*'|\{[^}]*\})*)>`)
+
+// jsxAttrRegex matches a JSX attribute with a string value: name="v",
+// name='v', or an expression holding a single string literal, name={"v"}.
+var jsxAttrRegex = regexp.MustCompile(
+	"([\\w:-]+)\\s*=\\s*(?:\"([^\"]*)\"|'([^']*)'|\\{\\s*[\"'`]([^\"'`]*)[\"'`]\\s*\\})")
+
+// esmImportRegex matches the specifier of an ESM import or export at the
+// start of a line: import x from "url", import "url", export { x } from "url".
+var esmImportRegex = regexp.MustCompile(
+	`(?m)^(?:import|export)\b(?:[^;"'` + "`" + `]*?\bfrom)?\s*["'](https?://[^"'\s]+)["']`)
+
+// extractJSX finds URLs in the JSX component attributes and ESM import
+// specifiers of an MDX file, outside code blocks and code spans.
+func (e *linkExtractor) extractJSX(content []byte) {
+	for _, tag := range jsxTagRegex.FindAllSubmatchIndex(content, -1) {
+		if e.inCode(tag[0]) {
+			continue
+		}
+		name := string(content[tag[2]:tag[3]])
+		attrs := content[tag[4]:tag[5]]
+		for _, attr := range jsxAttrRegex.FindAllSubmatchIndex(attrs, -1) {
+			// One of the three value groups matched
+			for g := 4; g < len(attr); g += 2 {
+				if attr[g] < 0 {
+					continue
+				}
+				url := string(attrs[attr[g]:attr[g+1]])
+				if !parser.IsHTTPURL(url) {
+					break
+				}
+				offset := tag[4] + attr[g]
+				line, col := parser.OffsetToLineCol(e.lines, offset)
+				e.links = append(e.links, parser.Link{
+					URL:      url,
+					FilePath: e.filePath,
+					Line:     line,
+					Column:   col,
+					Offset:   offset,
+					Text:     name + " " + string(attrs[attr[2]:attr[3]]),
+					Type:     parser.LinkTypeHTML,
+				})
+				break
+			}
+		}
+	}
+
+	for _, m := range esmImportRegex.FindAllSubmatchIndex(content, -1) {
+		if e.inCode(m[0]) {
+			continue
+		}
+		line, col := parser.OffsetToLineCol(e.lines, m[2])
+		e.links = append(e.links, parser.Link{
+			URL:      string(content[m[2]:m[3]]),
+			FilePath: e.filePath,
+			Line:     line,
+			Column:   col,
+			Offset:   m[2],
+			Text:     "import",
+			Type:     parser.LinkTypeAutolink,
+		})
+	}
+}
+
+// inCode reports whether the byte offset is inside a code block or code span.
+func (e *linkExtractor) inCode(offset int) bool {
+	for _, r := range e.codeRanges {
+		if offset >= r[0] && offset < r[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// recordCode records the byte range of a code block or code span.
+func (e *linkExtractor) recordCode(n ast.Node) {
+	if n.Type() == ast.TypeBlock {
+		lines := n.Lines()
+		if lines.Len() == 0 {
+			return
+		}
+		e.codeRanges = append(e.codeRanges, [2]int{lines.At(0).Start, lines.At(lines.Len() - 1).Stop})
+		return
+	}
+	first, last := n.FirstChild(), n.LastChild()
+	start, ok1 := first.(*ast.Text)
+	stop, ok2 := last.(*ast.Text)
+	if ok1 && ok2 {
+		e.codeRanges = append(e.codeRanges, [2]int{start.Segment.Start, stop.Segment.Stop})
+	}
+}
@@ -0,0 +1,85 @@
+package markdown
+
+import (
+	"net/url"
+
+	"github.com/leonardomso/gone/internal/parser"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+// ExtractRelativeLinks returns the links in markdown content that point at
+// other files by path ("./setup.md", "../README.md#install", "/docs/a.md").
+// Links with a scheme, fragment-only links ("#section"), and links inside
+// code blocks are skipped. URLs are returned as written.
+func ExtractRelativeLinks(content []byte, filePath string) []parser.Link {
+	return extractLocalLinks(content, filePath, IsRelativePath)
+}
+
+// ExtractInternalLinks returns the links in markdown content that point at
+// other files by path, like ExtractRelativeLinks, along with fragment-only
+// links ("#section") to headings in the same file.
+func ExtractInternalLinks(content []byte, filePath string) []parser.Link {
+	return extractLocalLinks(content, filePath, func(dest string) bool {
+		return IsRelativePath(dest) || (len(dest) > 1 && dest[0] == '#')
+	})
+}
+
+// extractLocalLinks returns the inline links and images outside code blocks
+// whose destination is accepted by keep.
+func extractLocalLinks(content []byte, filePath string, keep func(dest string) bool) []parser.Link {
+	doc := mdParser.Parser().Parse(text.NewReader(content))
+	e := &linkExtractor{
+		source:   content,
+		filePath: filePath,
+		lines:    parser.BuildLineIndex(content),
+	}
+
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if n.Kind() == ast.KindCodeBlock || n.Kind() == ast.KindFencedCodeBlock {
+			return ast.WalkSkipChildren, nil
+		}
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		var (
+			dest     string
+			linkType parser.LinkType
+		)
+		switch node := n.(type) {
+		case *ast.Link:
+			dest, linkType = string(node.Destination), parser.LinkTypeInline
+		case *ast.Image:
+			dest, linkType = string(node.Destination), parser.LinkTypeImage
+		default:
+			return ast.WalkContinue, nil
+		}
+
+		if !keep(dest) {
+			return ast.WalkContinue, nil
+		}
+		line, col := e.getPosition(n)
+		e.links = append(e.links, parser.Link{
+			URL:      dest,
+			FilePath: filePath,
+			Line:     line,
+			Column:   col,
+			Text:     e.getNodeText(n),
+			Type:     linkType,
+		})
+		return ast.WalkContinue, nil
+	})
+
+	return e.links
+}
+
+// IsRelativePath reports whether a link destination is a path to a file
+// rather than a URL with a scheme or a fragment within the same page.
+func IsRelativePath(dest string) bool {
+	u, err := url.Parse(dest)
+	if err != nil {
+		return false
+	}
+	return u.Scheme == "" && u.Host == "" && u.Path != ""
+}
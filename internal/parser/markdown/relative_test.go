@@ -0,0 +1,76 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/leonardomso/gone/internal/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractRelativeLinks(t *testing.T) {
+	t.Parallel()
+
+	content := []byte(`# Docs
+
+See [setup](./setup.md), [install](../README.md#install) and [api](/docs/api.md).
+![diagram](img/flow.png)
+
+Skipped: [site](https://example.com), [top](#docs), [mail](mailto:a@b.com).
+
+` + "```" + `
+[in code](code.md)
+` + "```" + `
+`)
+
+	links := ExtractRelativeLinks(content, "docs/guide.md")
+	require.Len(t, links, 4)
+
+	assert.Equal(t, "./setup.md", links[0].URL)
+	assert.Equal(t, "setup", links[0].Text)
+	assert.Equal(t, "docs/guide.md", links[0].FilePath)
+	assert.Equal(t, 3, links[0].Line)
+	assert.Equal(t, parser.LinkTypeInline, links[0].Type)
+
+	assert.Equal(t, "../README.md#install", links[1].URL)
+	assert.Equal(t, "/docs/api.md", links[2].URL)
+
+	assert.Equal(t, "img/flow.png", links[3].URL)
+	assert.Equal(t, parser.LinkTypeImage, links[3].Type)
+	assert.Equal(t, 4, links[3].Line)
+}
+
+func TestIsRelativePath(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]bool{
+		"./setup.md":           true,
+		"../README.md#install": true,
+		"/docs/api.md":         true,
+		"setup.md?raw=1":       true,
+		"#section":             false,
+		"https://example.com":  false,
+		"//cdn.example.com/x":  false,
+		"mailto:a@b.com":       false,
+		"":                     false,
+	}
+	for dest, want := range tests {
+		assert.Equal(t, want, IsRelativePath(dest), dest)
+	}
+}
+
+func TestExtractInternalLinks(t *testing.T) {
+	t.Parallel()
+
+	content := []byte(`# Docs
+
+See [setup](./setup.md#install), [below](#usage), and [site](https://example.com).
+Skipped: [empty](#).
+`)
+
+	links := ExtractInternalLinks(content, "docs/guide.md")
+	require.Len(t, links, 2)
+	assert.Equal(t, "./setup.md#install", links[0].URL)
+	assert.Equal(t, "#usage", links[1].URL)
+	assert.Equal(t, 3, links[1].Line)
+}
@@ -0,0 +1,64 @@
+// Package openapi recognizes OpenAPI and Swagger documents and the fields
+// in them that hold links. The YAML and JSON parsers use it to check only
+// those fields, instead of every URL-looking value such as examples and
+// schema defaults.
+package openapi
+
+import (
+	"regexp"
+	"strings"
+)
+
+// IsVersionField reports whether key and value are the version field that
+// opens an OpenAPI 3 ("openapi: 3.x") or Swagger 2 ("swagger: 2.0") document.
+func IsVersionField(key, value string) bool {
+	switch key {
+	case "openapi":
+		return strings.HasPrefix(value, "3.")
+	case "swagger":
+		return strings.HasPrefix(value, "2.")
+	default:
+		return false
+	}
+}
+
+// IsDocument reports whether a decoded top-level object is an OpenAPI or
+// Swagger document.
+func IsDocument(doc map[string]any) bool {
+	for _, key := range []string{"openapi", "swagger"} {
+		if v, ok := doc[key].(string); ok && IsVersionField(key, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// serverURL matches the path of a server URL, which servers lists may hold
+// at the top level, in path items, and in operations.
+var serverURL = regexp.MustCompile(`(^|\.)servers\[\d+\]\.url$`)
+
+// IsLink reports whether value, found at path, should be checked. The path
+// is the dotted path the parsers report as link text, e.g.
+// "paths./pets.get.externalDocs.url" or "servers[0].url".
+//
+// Link fields are externalDocs.url anywhere, info.contact.url,
+// info.license.url, info.termsOfService, and servers[].url; server URLs with
+// {variables} are skipped since they can't be requested as written. The prose
+// of description and summary fields is kept too, so links written in the
+// documentation are still found.
+func IsLink(path, value string) bool {
+	last := path[strings.LastIndexByte(path, '.')+1:]
+	if last == "description" || last == "summary" {
+		return true
+	}
+	return isLinkField(path) && !strings.Contains(value, "{")
+}
+
+// isLinkField reports whether path is one of the fields that hold a single URL.
+func isLinkField(path string) bool {
+	switch path {
+	case "info.contact.url", "info.license.url", "info.termsOfService", "externalDocs.url":
+		return true
+	}
+	return strings.HasSuffix(path, ".externalDocs.url") || serverURL.MatchString(path)
+}
@@ -0,0 +1,57 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsVersionField(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsVersionField("openapi", "3.1.0"))
+	assert.True(t, IsVersionField("swagger", "2.0"))
+	assert.False(t, IsVersionField("openapi", "2.0"))
+	assert.False(t, IsVersionField("version", "3.0.0"))
+}
+
+func TestIsDocument(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsDocument(map[string]any{"openapi": "3.0.3"}))
+	assert.True(t, IsDocument(map[string]any{"swagger": "2.0"}))
+	assert.False(t, IsDocument(map[string]any{"swagger": 2.0}))
+	assert.False(t, IsDocument(map[string]any{"name": "app"}))
+}
+
+func TestIsLink(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path     string
+		value    string
+		expected bool
+	}{
+		{"info.contact.url", "https://example.com", true},
+		{"info.license.url", "https://opensource.org/licenses/MIT", true},
+		{"info.termsOfService", "https://example.com/terms", true},
+		{"externalDocs.url", "https://docs.example.com", true},
+		{"paths./pets.get.externalDocs.url", "https://docs.example.com/pets", true},
+		{"tags[0].externalDocs.url", "https://docs.example.com/tags", true},
+		{"servers[0].url", "https://api.example.com/v1", true},
+		{"paths./pets.servers[1].url", "https://eu.api.example.com", true},
+		{"servers[0].url", "https://{region}.api.example.com", false},
+		{"info.description", "See https://example.com/guide", true},
+		{"paths./pets.get.summary", "Mirrors https://example.com", true},
+		{"components.schemas.Pet.properties.homepage.example", "https://example.com", false},
+		{"paths./pets.get.parameters[0].schema.default", "https://example.com", false},
+		{"info.contact.email", "https://example.com", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, IsLink(tt.path, tt.value))
+		})
+	}
+}
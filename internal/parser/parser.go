@@ -3,13 +3,21 @@
 package parser
 
 import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
 	"fmt"
-	"os"
+	"log/slog"
 	"regexp"
 	"runtime"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"unicode"
+
+	"github.com/leonardomso/gone/internal/archive"
 )
 
 // LinkType represents the type of link found in a file.
@@ -26,6 +34,8 @@ const (
 	LinkTypeAutolink
 	// LinkTypeHTML represents a link in HTML: <a href="url">.
 	LinkTypeHTML
+	// LinkTypeBadge represents a badge image, such as a shields.io or CI status badge.
+	LinkTypeBadge
 )
 
 // String returns the string representation of a LinkType.
@@ -41,6 +51,8 @@ func (t LinkType) String() string {
 		return "autolink"
 	case LinkTypeHTML:
 		return "html"
+	case LinkTypeBadge:
+		return "badge"
 	default:
 		return "unknown"
 	}
@@ -53,22 +65,31 @@ type Link struct {
 	Text     string // Link text or alt text for images
 
 	// For reference links.
-	RefName string   // Reference name (e.g., "myref" in [text][myref])
+	RefName string   // Reference name (e.g., "myref" in [text][myref]), or footnote label (e.g., "^1")
 	Line    int      // Line number (1-indexed)
-	Column  int      // Column position (1-indexed)
+	Column  int      // Column position (1-indexed, in bytes)
+	Offset  int      // Byte offset of the same position from the start of the file (0-indexed)
 	Type    LinkType // Type of link
 
 	RefDefLine int // Line where [ref]: url is defined (0 if not reference)
+
+	// Context is the source line the link is on, trimmed, for reports.
+	Context string
 }
 
 // ParseError represents an error that occurred during file parsing.
-// It includes the file path and the underlying error.
+// It includes the file path, the line of the error when the parser
+// reports one (0 otherwise), and the underlying error.
 type ParseError struct {
 	FilePath string
 	Err      error
+	Line     int
 }
 
 func (e *ParseError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s", e.FilePath, e.Line, e.Err.Error())
+	}
 	return e.FilePath + ": " + e.Err.Error()
 }
 
@@ -76,10 +97,41 @@ func (e *ParseError) Unwrap() error {
 	return e.Err
 }
 
+// errLineRegex finds the line number in messages like "yaml: line 3: ..." or "toml: line 7 ...".
+var errLineRegex = regexp.MustCompile(`\bline (\d+)`)
+
+// errorLine returns the line a parser error points at, or 0 if it has none.
+// JSON errors carry a byte offset, XML errors a line, and the YAML and TOML
+// decoders mention the line in their messages.
+func errorLine(err error, content []byte) int {
+	var (
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+		xmlErr    *xml.SyntaxError
+	)
+	switch {
+	case errors.As(err, &syntaxErr):
+		line, _ := OffsetToLineCol(BuildLineIndex(content), int(syntaxErr.Offset)-1)
+		return line
+	case errors.As(err, &typeErr):
+		line, _ := OffsetToLineCol(BuildLineIndex(content), int(typeErr.Offset)-1)
+		return line
+	case errors.As(err, &xmlErr):
+		return xmlErr.Line
+	}
+
+	if m := errLineRegex.FindStringSubmatch(err.Error()); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		return line
+	}
+	return 0
+}
+
 // fileResult holds the result of parsing a single file.
 type fileResult struct {
-	links []Link
-	err   error
+	err     error
+	skipped *SkippedFile
+	links   []Link
 }
 
 // =============================================================================
@@ -113,6 +165,29 @@ func CleanURLTrailing(url string) string {
 	return url
 }
 
+// IndexURL returns the byte offset of the first occurrence of url in
+// content at or after from, skipping occurrences that are only the start
+// of a longer URL (https://a.com in https://a.com/page), or -1.
+// Exported for use by subpackage parsers and the fixer.
+func IndexURL(content []byte, url string, from int) int {
+	for from >= 0 && from <= len(content) {
+		i := bytes.Index(content[from:], []byte(url))
+		if i < 0 {
+			return -1
+		}
+		start := from + i
+		// The URL ends here if the one URLRegex matches from here, once
+		// cleaned, isn't longer. A window past the URL is enough to tell.
+		window := content[start:min(len(content), start+len(url)+64)]
+		loc := URLRegex.FindIndex(window)
+		if loc == nil || loc[0] != 0 || len(CleanURLTrailing(string(window[:loc[1]]))) <= len(url) {
+			return start
+		}
+		from = start + 1
+	}
+	return -1
+}
+
 // BuildLineIndex creates an index of byte offsets for the start of each line.
 // This index enables O(log n) line/column lookups from byte offsets,
 // which is more efficient than scanning from the start for each lookup.
@@ -132,6 +207,52 @@ func BuildLineIndex(content []byte) []int {
 	return lines
 }
 
+// maxContextLen caps a link's Context, so links in minified files don't
+// carry the whole file along.
+const maxContextLen = 200
+
+// setContext sets each link's Context to its source line in content.
+func setContext(links []Link, content []byte) {
+	if len(links) == 0 {
+		return
+	}
+	lines := BuildLineIndex(content)
+	for i := range links {
+		links[i].Context = lineContext(content, lines, links[i].Line, links[i].Column)
+	}
+}
+
+// lineContext returns a line of content with surrounding whitespace trimmed.
+// Lines longer than maxContextLen are cut down to the bytes around column,
+// marked with "…" where they were cut.
+func lineContext(content []byte, lines []int, line, column int) string {
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+	end := len(content)
+	if line < len(lines) {
+		end = lines[line] - 1
+	}
+	text := content[lines[line-1]:end]
+	trimmed := bytes.TrimLeftFunc(text, unicode.IsSpace)
+	column -= len(text) - len(trimmed) + 1
+	text = bytes.TrimRightFunc(trimmed, unicode.IsSpace)
+	if len(text) <= maxContextLen {
+		return string(text)
+	}
+
+	from := min(max(column-maxContextLen/2, 0), len(text)-maxContextLen)
+	to := from + maxContextLen
+	snippet := strings.ToValidUTF8(string(text[from:to]), "")
+	if from > 0 {
+		snippet = "…" + snippet
+	}
+	if to < len(text) {
+		snippet += "…"
+	}
+	return snippet
+}
+
 // OffsetToLineCol converts a byte offset to 1-indexed line and column numbers
 // using binary search for O(log n) performance.
 // The lines parameter should be created by BuildLineIndex.
@@ -163,32 +284,65 @@ func OffsetToLineCol(lines []int, offset int) (lineNum, colNum int) {
 // using the appropriate parser from the registry based on file extension.
 // If strict is true, validation errors will cause the function to return an error.
 func ExtractLinksWithRegistry(filePath string, strict bool) ([]Link, error) {
+	links, _, err := extractFile(filePath, strict, Budget{})
+	return links, err
+}
+
+// extractFile parses a single file within the given budget.
+// Files over budget are reported as skipped rather than as errors.
+// Read failures are returned as a *ParseError, and so are validation
+// failures if strict is true; otherwise malformed files yield no links.
+func extractFile(filePath string, strict bool, budget Budget) ([]Link, *SkippedFile, error) {
 	// Get parser for this file type
 	p, ok := GetParserForFile(filePath)
 	if !ok {
-		return nil, &ParseError{
+		return nil, nil, &ParseError{
 			FilePath: filePath,
 			Err:      fmt.Errorf("no parser registered for file extension"),
 		}
 	}
 
-	// Read file content
-	content, err := os.ReadFile(filePath)
+	if budget.Exempt[filePath] {
+		budget = Budget{}
+	}
+
+	// Skip oversized files before reading them
+	if budget.MaxBytes > 0 {
+		if size, err := archive.Size(filePath); err == nil && size > budget.MaxBytes {
+			return nil, skipFile(filePath, fmt.Sprintf("file size %d bytes exceeds parse budget of %d bytes",
+				size, budget.MaxBytes)), nil
+		}
+	}
+
+	// Read file content, which may be in an archive
+	content, err := archive.ReadFile(filePath)
 	if err != nil {
-		return nil, &ParseError{FilePath: filePath, Err: err}
+		return nil, nil, &ParseError{FilePath: filePath, Err: err}
 	}
 
 	// Validate and parse in a single pass for better performance
-	links, err := p.ValidateAndParse(filePath, content)
+	links, err := parseWithBudget(p, filePath, content, budget.Timeout)
+	if errors.Is(err, errParseTimeout) {
+		return nil, skipFile(filePath, fmt.Sprintf("parsing took longer than %s", budget.Timeout)), nil
+	}
 	if err != nil {
+		slog.Debug("failed to parse file", "file", filePath, "error", err)
 		if strict {
-			return nil, &ParseError{FilePath: filePath, Err: err}
+			return nil, nil, &ParseError{FilePath: filePath, Line: errorLine(err, content), Err: err}
 		}
 		// In non-strict mode, skip files with errors
-		return nil, nil
+		return nil, nil, nil
 	}
 
-	return links, nil
+	setContext(links, content)
+	slog.Debug("parsed file", "file", filePath, "links", len(links))
+	return links, nil, nil
+}
+
+// skipFile logs and returns a file skipped for exceeding the parse budget.
+func skipFile(filePath, reason string) *SkippedFile {
+	slog.Debug("skipping file", "file", filePath, "reason", reason)
+	return &SkippedFile{FilePath: filePath, Reason: reason}
 }
 
 // ExtractLinksFromMultipleFilesWithRegistry processes multiple files concurrently
@@ -196,10 +350,33 @@ func ExtractLinksWithRegistry(filePath string, strict bool) ([]Link, error) {
 // If strict is true, validation errors will cause the function to return an error.
 // Files with unsupported extensions are silently skipped.
 func ExtractLinksFromMultipleFilesWithRegistry(filePaths []string, strict bool) ([]Link, error) {
-	if len(filePaths) == 0 {
-		return nil, nil
+	links, _, err := ExtractLinksWithBudget(filePaths, strict, Budget{})
+	return links, err
+}
+
+// ExtractLinksWithBudget is like ExtractLinksFromMultipleFilesWithRegistry, but
+// files exceeding the parse budget are skipped and returned, sorted by path.
+// Skipped files never cause an error, even in strict mode.
+func ExtractLinksWithBudget(filePaths []string, strict bool, budget Budget) ([]Link, []SkippedFile, error) {
+	ex := Extract(filePaths, budget)
+	if strict && len(ex.Errors) > 0 {
+		return nil, nil, &ex.Errors[0]
 	}
+	return ex.Links, ex.Skipped, nil
+}
 
+// Extraction is the outcome of parsing a set of files.
+type Extraction struct {
+	Links   []Link
+	Skipped []SkippedFile // Files over the parse budget, sorted by path
+	Errors  []ParseError  // Files that failed to parse, sorted by path
+}
+
+// Extract parses every file within the budget. Unlike the strict mode of
+// ExtractLinksWithBudget, it doesn't stop at the first malformed file:
+// every parse failure is collected, so all of them can be reported at once.
+// Files with unsupported extensions are silently skipped.
+func Extract(filePaths []string, budget Budget) Extraction {
 	// Filter to only supported files
 	supportedFiles := make([]string, 0, len(filePaths))
 	for _, path := range filePaths {
@@ -209,64 +386,108 @@ func ExtractLinksFromMultipleFilesWithRegistry(filePaths []string, strict bool)
 	}
 
 	if len(supportedFiles) == 0 {
-		return nil, nil
+		return Extraction{}
 	}
 
+	// Read the files to parse from each archive at once, and only those
+	for archivePath, keep := range archiveFiles(supportedFiles, budget) {
+		if err := archive.Load(archivePath, keep); err != nil {
+			// Reading each file reports the error
+			slog.Debug("failed to load archive", "archive", archivePath, "error", err)
+			continue
+		}
+		defer archive.Release(archivePath)
+	}
+
+	var ex Extraction
+
 	// For small number of files, use sequential processing
 	if len(supportedFiles) <= 2 {
-		return extractLinksSequentialWithRegistry(supportedFiles, strict)
+		ex = extractLinksSequentialWithRegistry(supportedFiles, budget)
+	} else {
+		ex = extractLinksParallelWithRegistry(supportedFiles, budget)
 	}
 
-	return extractLinksParallelWithRegistry(supportedFiles, strict)
-}
+	sort.Slice(ex.Skipped, func(i, j int) bool {
+		return ex.Skipped[i].FilePath < ex.Skipped[j].FilePath
+	})
+	sort.Slice(ex.Errors, func(i, j int) bool {
+		return ex.Errors[i].FilePath < ex.Errors[j].FilePath
+	})
 
-// extractLinksSequentialWithRegistry processes files one at a time using the registry.
-func extractLinksSequentialWithRegistry(filePaths []string, strict bool) ([]Link, error) {
-	allLinks := make([]Link, 0, len(filePaths)*30)
+	return ex
+}
 
+// archiveFiles groups the files inside archives by archive, as a function
+// reporting whether a file of the archive is one of them and within the
+// budget's size limit.
+func archiveFiles(filePaths []string, budget Budget) map[string]func(string, int64) bool {
+	members := map[string]map[string]bool{}
 	for _, path := range filePaths {
-		links, err := ExtractLinksWithRegistry(path, strict)
-		if err != nil {
-			if strict {
-				return nil, err
+		if archivePath, name, ok := archive.Split(path); ok {
+			if members[archivePath] == nil {
+				members[archivePath] = map[string]bool{}
 			}
-			// In non-strict mode, skip files with errors
-			continue
+			members[archivePath][name] = true
 		}
-		if links != nil {
-			allLinks = append(allLinks, links...)
+	}
+
+	keep := make(map[string]func(string, int64) bool, len(members))
+	for archivePath, names := range members {
+		keep[archivePath] = func(name string, size int64) bool {
+			return names[name] && (budget.MaxBytes <= 0 || size <= budget.MaxBytes ||
+				budget.Exempt[archive.Join(archivePath, name)])
 		}
 	}
+	return keep
+}
 
-	return allLinks, nil
+// add records the outcome of parsing one file.
+func (ex *Extraction) add(r fileResult) {
+	var parseErr *ParseError
+	switch {
+	case errors.As(r.err, &parseErr):
+		ex.Errors = append(ex.Errors, *parseErr)
+	case r.skipped != nil:
+		ex.Skipped = append(ex.Skipped, *r.skipped)
+	default:
+		ex.Links = append(ex.Links, r.links...)
+	}
 }
 
-// extractLinksParallelWithRegistry processes files concurrently using the registry.
-func extractLinksParallelWithRegistry(filePaths []string, strict bool) ([]Link, error) {
-	numWorkers := min(runtime.NumCPU(), len(filePaths))
+// extractLinksSequentialWithRegistry processes files one at a time using the registry.
+func extractLinksSequentialWithRegistry(filePaths []string, budget Budget) Extraction {
+	ex := Extraction{Links: make([]Link, 0, len(filePaths)*30)}
 
-	type job struct {
-		path   string
-		strict bool
+	for _, path := range filePaths {
+		links, skip, err := extractFile(path, true, budget)
+		ex.add(fileResult{links: links, skipped: skip, err: err})
 	}
 
-	jobs := make(chan job, len(filePaths))
+	return ex
+}
+
+// extractLinksParallelWithRegistry processes files concurrently using the registry.
+func extractLinksParallelWithRegistry(filePaths []string, budget Budget) Extraction {
+	numWorkers := min(runtime.NumCPU(), len(filePaths))
+
+	jobs := make(chan string, len(filePaths))
 	results := make(chan fileResult, len(filePaths))
 
 	// Start workers
 	var wg sync.WaitGroup
 	for range numWorkers {
 		wg.Go(func() {
-			for j := range jobs {
-				links, err := ExtractLinksWithRegistry(j.path, j.strict)
-				results <- fileResult{links: links, err: err}
+			for path := range jobs {
+				links, skip, err := extractFile(path, true, budget)
+				results <- fileResult{links: links, skipped: skip, err: err}
 			}
 		})
 	}
 
 	// Send jobs
 	for _, path := range filePaths {
-		jobs <- job{path: path, strict: strict}
+		jobs <- path
 	}
 	close(jobs)
 
@@ -277,19 +498,10 @@ func extractLinksParallelWithRegistry(filePaths []string, strict bool) ([]Link,
 	}()
 
 	// Collect results
-	allLinks := make([]Link, 0, len(filePaths)*30)
+	ex := Extraction{Links: make([]Link, 0, len(filePaths)*30)}
 	for result := range results {
-		if result.err != nil {
-			if strict {
-				return nil, result.err
-			}
-			// In non-strict mode, skip files with errors
-			continue
-		}
-		if result.links != nil {
-			allLinks = append(allLinks, result.links...)
-		}
+		ex.add(result)
 	}
 
-	return allLinks, nil
+	return ex
 }
@@ -1,9 +1,18 @@
 package parser
 
 import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestLinkType_String(t *testing.T) {
@@ -18,6 +27,7 @@ func TestLinkType_String(t *testing.T) {
 		{LinkTypeImage, "image"},
 		{LinkTypeAutolink, "autolink"},
 		{LinkTypeHTML, "html"},
+		{LinkTypeBadge, "badge"},
 		{LinkType(99), "unknown"},
 	}
 
@@ -56,6 +66,44 @@ func TestIsHTTPURL(t *testing.T) {
 	}
 }
 
+func TestIsBadgeURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		url      string
+		expected bool
+	}{
+		{"https://img.shields.io/github/stars/owner/repo?style=social", true},
+		{"https://badgen.net/npm/v/express", true},
+		{"https://badge.fury.io/js/express.svg", true},
+		{"https://github.com/owner/repo/actions/workflows/ci.yml/badge.svg?branch=main", true},
+		{"https://github.com/owner/repo/workflows/CI/badge.svg", true},
+		{"https://gitlab.com/owner/repo/badges/main/pipeline.svg", true},
+		{"https://goreportcard.com/badge/github.com/owner/repo", true},
+		{"https://pkg.go.dev/badge/github.com/owner/repo.svg", true},
+		{"https://travis-ci.com/owner/repo.svg?branch=main", true},
+		{"https://codecov.io/gh/owner/repo/branch/main/graph/badge.svg", true},
+		{"https://sonarcloud.io/api/project_badges/measure?project=x&metric=coverage", true},
+		{"https://ci.appveyor.com/api/projects/status/abc123", true},
+		{"https://dev.azure.com/org/project/_apis/build/status/pipeline", true},
+		{"https://api.netlify.com/api/v1/badges/abc/deploy-status", true},
+
+		{"https://shields.io", false},
+		{"https://github.com/badges/shields", false},
+		{"https://github.com/owner/repo/actions", false},
+		{"https://travis-ci.com/owner/repo", false},
+		{"https://example.com/logo.svg", false},
+		{"https://goreportcard.com/report/github.com/owner/repo", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, IsBadgeURL(tt.url))
+		})
+	}
+}
+
 func TestCleanURLTrailing(t *testing.T) {
 	t.Parallel()
 
@@ -168,6 +216,21 @@ func TestParseError(t *testing.T) {
 	})
 }
 
+func TestIndexURL(t *testing.T) {
+	t.Parallel()
+
+	content := []byte(`{"a": "https://old.com/page", "b": "see https://old.com.", "c": "https://old.com"}`)
+
+	first := IndexURL(content, "https://old.com", 0)
+	assert.Equal(t, bytes.Index(content, []byte("https://old.com.")), first)
+	second := IndexURL(content, "https://old.com", first+1)
+	assert.Equal(t, bytes.LastIndex(content, []byte("https://old.com")), second)
+	assert.Equal(t, -1, IndexURL(content, "https://old.com", second+1))
+
+	assert.Equal(t, 7, IndexURL(content, "https://old.com/page", 0))
+	assert.Equal(t, -1, IndexURL(content, "https://missing.com", 0))
+}
+
 func TestOffsetToLineCol(t *testing.T) {
 	t.Parallel()
 
@@ -233,3 +296,88 @@ func TestOffsetToLineCol(t *testing.T) {
 		assert.Equal(t, 1, col)
 	})
 }
+
+func TestLineContext(t *testing.T) {
+	t.Parallel()
+
+	long := strings.Repeat("a", 150) + " [link](https://example.com) " + strings.Repeat("b", 150)
+	content := []byte("# Title\n\n  See [the docs](https://example.com) for more.  \r\n" + long + "\n")
+	lines := BuildLineIndex(content)
+
+	assert.Equal(t, "# Title", lineContext(content, lines, 1, 1))
+	assert.Empty(t, lineContext(content, lines, 2, 1))
+	assert.Equal(t, "See [the docs](https://example.com) for more.", lineContext(content, lines, 3, 7))
+	assert.Empty(t, lineContext(content, lines, 0, 0), "unknown line")
+	assert.Empty(t, lineContext(content, lines, 9, 1), "line past the end")
+
+	snippet := lineContext(content, lines, 4, 152)
+	assert.True(t, strings.HasPrefix(snippet, "…a"))
+	assert.True(t, strings.HasSuffix(snippet, "b…"))
+	assert.Contains(t, snippet, "[link](https://example.com)")
+	assert.Len(t, strings.Trim(snippet, "…"), maxContextLen)
+
+	assert.Equal(t, strings.Repeat("a", maxContextLen)+"…",
+		lineContext([]byte(strings.Repeat("a", 300)), []int{0}, 1, 0), "unknown column keeps the start")
+}
+
+// failingParser is a FileParser that rejects every file.
+type failingParser struct {
+	ext string
+}
+
+func (p *failingParser) Extensions() []string { return []string{p.ext} }
+
+func (*failingParser) ValidateAndParse(string, []byte) ([]Link, error) {
+	return nil, errors.New("yaml: line 4: mapping values are not allowed here")
+}
+
+func TestExtract(t *testing.T) {
+	t.Parallel()
+
+	RegisterParser(&budgetParser{ext: ".extractok"})
+	RegisterParser(&failingParser{ext: ".extractbad"})
+
+	dir := t.TempDir()
+	var files []string
+	for _, name := range []string{"b.extractbad", "ok1.extractok", "a.extractbad", "ok2.extractok"} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte("x"), 0o600))
+		files = append(files, path)
+	}
+
+	// Every failure is collected and the other files are still parsed
+	ex := Extract(files, Budget{})
+	assert.Len(t, ex.Links, 2)
+	require.Len(t, ex.Errors, 2)
+	assert.Equal(t, files[2], ex.Errors[0].FilePath)
+	assert.Equal(t, files[0], ex.Errors[1].FilePath)
+	assert.Equal(t, 4, ex.Errors[0].Line)
+	assert.Equal(t, files[2]+":4: yaml: line 4: mapping values are not allowed here", ex.Errors[0].Error())
+
+	// Strict mode still returns the first failure as an error
+	_, _, err := ExtractLinksWithBudget(files, true, Budget{})
+	var parseErr *ParseError
+	require.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, files[2], parseErr.FilePath)
+
+	links, _, err := ExtractLinksWithBudget(files, false, Budget{})
+	require.NoError(t, err)
+	assert.Len(t, links, 2)
+}
+
+func TestErrorLine(t *testing.T) {
+	t.Parallel()
+
+	content := []byte("{\n  \"a\": 1,\n  \"b\": oops\n}")
+	var v any
+	err := json.Unmarshal(content, &v)
+	require.Error(t, err)
+	assert.Equal(t, 3, errorLine(fmt.Errorf("invalid JSON: %w", err), content))
+
+	err = xml.Unmarshal([]byte("<a>\n<b></b>\n</c>"), &v)
+	require.Error(t, err)
+	assert.Equal(t, 3, errorLine(err, nil))
+
+	assert.Equal(t, 7, errorLine(errors.New(`toml: line 7 (last key "b"): expected value`), nil))
+	assert.Equal(t, 0, errorLine(errors.New("unexpected EOF"), nil))
+}
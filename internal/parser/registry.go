@@ -3,6 +3,7 @@
 package parser
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 	"sort"
@@ -22,18 +23,123 @@ type FileParser interface {
 	ValidateAndParse(filename string, content []byte) ([]Link, error)
 }
 
-// Registry manages file parsers by extension.
+// ContextParser is a FileParser that stops parsing once ctx is done, such as
+// when a file runs over its parse budget, and returns ctx's error.
+type ContextParser interface {
+	FileParser
+
+	// ParseContext is ValidateAndParse, stopping early once ctx is done.
+	ParseContext(ctx context.Context, filename string, content []byte) ([]Link, error)
+}
+
+// Registry manages file parsers by extension, and by file name for files
+// such as Dockerfile that an extension doesn't identify.
 // It provides thread-safe registration and lookup of parsers.
 type Registry struct {
 	mu      sync.RWMutex
 	parsers map[string]FileParser // extension -> parser
+	groups  map[string][]string   // group type name -> member type names
+	named   []namedParser         // checked before extensions, in registration order
+}
+
+// namedParser is a parser selected by file name patterns.
+type namedParser struct {
+	typeName string
+	patterns []string // Lowercase filepath.Match patterns for base names
+	parser   FileParser
 }
 
 // NewRegistry creates a new empty parser registry.
 func NewRegistry() *Registry {
 	return &Registry{
 		parsers: map[string]FileParser{},
+		groups:  map[string][]string{},
+	}
+}
+
+// RegisterGroup adds a file type name that stands for several others, such
+// as "code" for every source language. Members are type names without the
+// leading dot.
+func (r *Registry) RegisterGroup(name string, types ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.groups[strings.ToLower(name)] = types
+}
+
+// ExpandTypes replaces group names in types with their members, keeping
+// the order and dropping repeats.
+func (r *Registry) ExpandTypes(types []string) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	expanded := make([]string, 0, len(types))
+	seen := make(map[string]bool, len(types))
+	add := func(t string) {
+		if !seen[t] {
+			seen[t] = true
+			expanded = append(expanded, t)
+		}
+	}
+	for _, t := range types {
+		members, ok := r.groups[strings.ToLower(t)]
+		if !ok {
+			add(t)
+			continue
+		}
+		for _, m := range members {
+			add(m)
+		}
+	}
+	return expanded
+}
+
+// RegisterNamed adds a parser for files whose base name matches one of
+// patterns (filepath.Match syntax, case-insensitive), under the file type
+// typeName. Name matches take precedence over extensions, so a parser can
+// claim docker-compose.yml from the YAML parser.
+func (r *Registry) RegisterNamed(typeName string, p FileParser, patterns ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	lower := make([]string, len(patterns))
+	for i, pattern := range patterns {
+		lower[i] = strings.ToLower(pattern)
+	}
+	r.named = append(r.named, namedParser{typeName: strings.ToLower(typeName), patterns: lower, parser: p})
+}
+
+// namedFor returns the name-matched parser for filename, if any.
+func (r *Registry) namedFor(filename string) (namedParser, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	base := strings.ToLower(filepath.Base(filename))
+	for _, n := range r.named {
+		for _, pattern := range n.patterns {
+			if ok, _ := filepath.Match(pattern, base); ok {
+				return n, true
+			}
+		}
 	}
+	return namedParser{}, false
+}
+
+// FilenamesForTypes returns the file name patterns of the name-matched
+// parsers among types, for scanning files an extension doesn't identify.
+func (r *Registry) FilenamesForTypes(types []string) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var patterns []string
+	for _, t := range types {
+		for _, n := range r.named {
+			if n.typeName == strings.ToLower(t) {
+				patterns = append(patterns, n.patterns...)
+			}
+		}
+	}
+	return patterns
 }
 
 // Register adds a parser to the registry for all its supported extensions.
@@ -60,13 +166,36 @@ func (r *Registry) Get(ext string) (FileParser, bool) {
 	return p, ok
 }
 
-// GetForFile returns the parser for the given filename based on its extension.
-// Returns nil, false if no parser is registered for the file's extension.
+// GetForFile returns the parser for the given filename based on its name
+// patterns or, failing those, its extension.
+// Returns nil, false if no parser is registered for the file.
 func (r *Registry) GetForFile(filename string) (FileParser, bool) {
+	if n, ok := r.namedFor(filename); ok {
+		return n.parser, true
+	}
 	ext := filepath.Ext(filename)
 	return r.Get(ext)
 }
 
+// TypeForFile returns the file type name of the parser that handles filename,
+// using the parser's primary (first) extension, e.g. "md" for README.markdown.
+// Name-matched files report the type they were registered under.
+// Returns "" if no parser is registered for the file.
+func (r *Registry) TypeForFile(filename string) string {
+	if n, ok := r.namedFor(filename); ok {
+		return n.typeName
+	}
+	p, ok := r.GetForFile(filename)
+	if !ok {
+		return ""
+	}
+	exts := p.Extensions()
+	if len(exts) == 0 {
+		return ""
+	}
+	return strings.TrimPrefix(normalizeExtension(exts[0]), ".")
+}
+
 // SupportedTypes returns a sorted list of registered file type names.
 // For display purposes in CLI help text.
 func (r *Registry) SupportedTypes() []string {
@@ -80,6 +209,12 @@ func (r *Registry) SupportedTypes() []string {
 		typeName := strings.TrimPrefix(ext, ".")
 		typeNames[typeName] = struct{}{}
 	}
+	for name := range r.groups {
+		typeNames[name] = struct{}{}
+	}
+	for _, n := range r.named {
+		typeNames[n.typeName] = struct{}{}
+	}
 
 	// Convert to slice
 	result := make([]string, 0, len(typeNames))
@@ -118,8 +253,18 @@ func (r *Registry) ExtensionsForTypes(types []string) ([]string, error) {
 
 	extensions := make([]string, 0, len(types))
 	for _, typeName := range types {
+		if members, ok := r.groups[strings.ToLower(typeName)]; ok {
+			for _, m := range members {
+				extensions = append(extensions, normalizeExtension(m))
+			}
+			continue
+		}
 		ext := normalizeExtension(typeName)
 		if _, ok := r.parsers[ext]; !ok {
+			if r.isNamedType(typeName) {
+				// Selected by file name only; see FilenamesForTypes
+				continue
+			}
 			return nil, fmt.Errorf("unsupported file type: %s", typeName)
 		}
 		extensions = append(extensions, ext)
@@ -127,6 +272,17 @@ func (r *Registry) ExtensionsForTypes(types []string) ([]string, error) {
 	return extensions, nil
 }
 
+// isNamedType reports whether typeName belongs to a name-matched parser.
+// The caller must hold r.mu.
+func (r *Registry) isNamedType(typeName string) bool {
+	for _, n := range r.named {
+		if n.typeName == strings.ToLower(typeName) {
+			return true
+		}
+	}
+	return false
+}
+
 // normalizeExtension ensures the extension is lowercase and has a leading dot.
 func normalizeExtension(ext string) string {
 	ext = strings.ToLower(ext)
@@ -149,6 +305,33 @@ func RegisterParser(p FileParser) {
 	defaultRegistry.Register(p)
 }
 
+// RegisterGroup registers a group file type with the default registry.
+func RegisterGroup(name string, types ...string) {
+	defaultRegistry.RegisterGroup(name, types...)
+}
+
+// RegisterNamedParser registers a name-matched parser with the default registry.
+func RegisterNamedParser(typeName string, p FileParser, patterns ...string) {
+	defaultRegistry.RegisterNamed(typeName, p, patterns...)
+}
+
+// RegisterExternal registers an external command as the parser for files
+// with extension ext in the default registry, replacing any built-in parser.
+// See External for the protocol.
+func RegisterExternal(ext, command string) {
+	defaultRegistry.Register(NewExternal(ext, command))
+}
+
+// FilenamesForFileTypes returns the file name patterns of types in the default registry.
+func FilenamesForFileTypes(types []string) []string {
+	return defaultRegistry.FilenamesForTypes(types)
+}
+
+// ExpandFileTypes replaces group names in types using the default registry.
+func ExpandFileTypes(types []string) []string {
+	return defaultRegistry.ExpandTypes(types)
+}
+
 // GetParser returns a parser from the default registry for the given extension.
 func GetParser(ext string) (FileParser, bool) {
 	return defaultRegistry.Get(ext)
@@ -159,6 +342,11 @@ func GetParserForFile(filename string) (FileParser, bool) {
 	return defaultRegistry.GetForFile(filename)
 }
 
+// FileTypeForFile returns the file type name of the default registry's parser for filename.
+func FileTypeForFile(filename string) string {
+	return defaultRegistry.TypeForFile(filename)
+}
+
 // SupportedFileTypes returns all supported file types from the default registry.
 func SupportedFileTypes() []string {
 	return defaultRegistry.SupportedTypes()
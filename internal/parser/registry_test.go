@@ -148,6 +148,19 @@ func TestRegistry_GetForFile(t *testing.T) {
 	})
 }
 
+func TestRegistry_TypeForFile(t *testing.T) {
+	t.Parallel()
+
+	r := NewRegistry()
+	r.Register(newMockParser(".md", ".mdx", ".markdown"))
+	r.Register(newMockParser("YAML", ".yml"))
+
+	assert.Equal(t, "md", r.TypeForFile("README.md"))
+	assert.Equal(t, "md", r.TypeForFile("docs/guide.markdown"))
+	assert.Equal(t, "yaml", r.TypeForFile("config.yml"))
+	assert.Empty(t, r.TypeForFile("main.go"))
+}
+
 func TestRegistry_SupportedTypes(t *testing.T) {
 	t.Parallel()
 
@@ -227,6 +240,57 @@ func TestRegistry_ExtensionsForTypes(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "unsupported file type")
 	})
+
+	t.Run("ExpandsGroups", func(t *testing.T) {
+		t.Parallel()
+		r := NewRegistry()
+		r.Register(newMockParser(".go"))
+		r.Register(newMockParser(".py"))
+		r.RegisterGroup("code", "go", "py")
+
+		exts, err := r.ExtensionsForTypes([]string{"code"})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{".go", ".py"}, exts)
+	})
+}
+
+func TestRegistry_RegisterNamed(t *testing.T) {
+	t.Parallel()
+
+	r := NewRegistry()
+	yaml := newMockParser(".yml", ".yaml")
+	compose := newMockParser()
+	r.Register(yaml)
+	r.RegisterNamed("compose", compose, "docker-compose.yml", "compose.*.yml")
+
+	p, ok := r.GetForFile("deploy/Docker-Compose.yml")
+	require.True(t, ok)
+	assert.Same(t, compose, p)
+	assert.Equal(t, "compose", r.TypeForFile("compose.prod.yml"))
+
+	p, ok = r.GetForFile("config.yml")
+	require.True(t, ok)
+	assert.Same(t, yaml, p)
+
+	assert.Contains(t, r.SupportedTypes(), "compose")
+	assert.Equal(t, []string{"docker-compose.yml", "compose.*.yml"}, r.FilenamesForTypes([]string{"yml", "compose"}))
+
+	exts, err := r.ExtensionsForTypes([]string{"yml", "compose"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{".yml"}, exts)
+}
+
+func TestRegistry_ExpandTypes(t *testing.T) {
+	t.Parallel()
+
+	r := NewRegistry()
+	r.Register(newMockParser(".go"))
+	r.Register(newMockParser(".py"))
+	r.RegisterGroup("code", "go", "py")
+
+	assert.Equal(t, []string{"md", "go", "py"}, r.ExpandTypes([]string{"md", "CODE", "go"}))
+	assert.Equal(t, []string{"json"}, r.ExpandTypes([]string{"json"}))
+	assert.Contains(t, r.SupportedTypes(), "code")
 }
 
 func TestDefaultRegistry(t *testing.T) {
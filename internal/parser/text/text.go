@@ -0,0 +1,69 @@
+// Package text implements a fallback URL extractor for plain text files,
+// such as CHANGELOG, LICENSE, and NOTICE files and .txt documents. Every
+// http(s) URL in the text is extracted; nothing is known of its structure.
+package text
+
+import (
+	"bytes"
+
+	"github.com/leonardomso/gone/internal/parser"
+)
+
+// TypeName is the file type of the plain text parser.
+const TypeName = "txt"
+
+// Names are the base name patterns of extensionless text files picked up
+// with the txt file type. Files like CHANGELOG.md keep their own parser.
+var Names = []string{
+	"authors", "changelog", "changes", "contributors", "copying",
+	"history", "licence", "license", "notice", "readme",
+}
+
+// Parser implements parser.FileParser for plain text files.
+type Parser struct{}
+
+// New creates a new plain text parser.
+func New() *Parser {
+	return &Parser{}
+}
+
+// Extensions returns the file extensions this parser handles. Extensionless
+// files such as LICENSE are matched by name; see Names.
+func (*Parser) Extensions() []string {
+	return []string{".txt"}
+}
+
+// ValidateAndParse extracts every http(s) URL in the content, trimming
+// trailing punctuation. Text files are never rejected.
+func (*Parser) ValidateAndParse(filename string, content []byte) ([]parser.Link, error) {
+	if !bytes.Contains(content, []byte("http")) {
+		return nil, nil
+	}
+
+	lines := parser.BuildLineIndex(content)
+	var links []parser.Link
+	for _, m := range parser.URLRegex.FindAllIndex(content, -1) {
+		url := parser.CleanURLTrailing(string(content[m[0]:m[1]]))
+		if !parser.IsHTTPURL(url) {
+			continue
+		}
+		line, col := parser.OffsetToLineCol(lines, m[0])
+		links = append(links, parser.Link{
+			URL:      url,
+			FilePath: filename,
+			Line:     line,
+			Column:   col,
+			Offset:   m[0],
+			Type:     parser.LinkTypeAutolink,
+		})
+	}
+	return links, nil
+}
+
+// init registers the plain text parser with the default registry, by
+// extension and by name.
+func init() {
+	p := New()
+	parser.RegisterParser(p)
+	parser.RegisterNamedParser(TypeName, p, Names...)
+}
@@ -0,0 +1,65 @@
+package text
+
+import (
+	"testing"
+
+	"github.com/leonardomso/gone/internal/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_ValidateAndParse(t *testing.T) {
+	t.Parallel()
+
+	content := []byte(`Changelog
+
+1.2.0 (see https://example.com/releases/1.2.0).
+  - Fixed https://github.com/owner/repo/issues/1, thanks!
+  - Docs moved to <https://docs.example.com/>.
+
+Licensed under "https://www.apache.org/licenses/LICENSE-2.0"; ftp://example.com is not checked.
+`)
+	links, err := New().ValidateAndParse("CHANGELOG", content)
+	require.NoError(t, err)
+
+	urls := make([]string, 0, len(links))
+	for _, l := range links {
+		urls = append(urls, l.URL)
+		assert.Equal(t, "CHANGELOG", l.FilePath)
+		assert.Equal(t, parser.LinkTypeAutolink, l.Type)
+	}
+	assert.Equal(t, []string{
+		"https://example.com/releases/1.2.0",
+		"https://github.com/owner/repo/issues/1",
+		"https://docs.example.com/",
+		"https://www.apache.org/licenses/LICENSE-2.0",
+	}, urls)
+
+	assert.Equal(t, 3, links[0].Line)
+	assert.Equal(t, 12, links[0].Column)
+	assert.Equal(t, 4, links[1].Line)
+	assert.Equal(t, 11, links[1].Column)
+}
+
+func TestParser_NoURLs(t *testing.T) {
+	t.Parallel()
+
+	links, err := New().ValidateAndParse("notes.txt", []byte("nothing to see here\n"))
+	require.NoError(t, err)
+	assert.Empty(t, links)
+}
+
+func TestParser_Registration(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"notes.txt", "LICENSE", "CHANGELOG", "docs/NOTICE"} {
+		p, ok := parser.DefaultRegistry().GetForFile(name)
+		require.True(t, ok, name)
+		assert.IsType(t, &Parser{}, p, name)
+		assert.Equal(t, TypeName, parser.FileTypeForFile(name), name)
+	}
+
+	// Names only match extensionless files, so CHANGELOG.md stays markdown
+	assert.NotEqual(t, TypeName, parser.FileTypeForFile("CHANGELOG.md"))
+	assert.Contains(t, parser.FilenamesForFileTypes([]string{TypeName}), "license")
+}
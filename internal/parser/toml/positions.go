@@ -0,0 +1,317 @@
+package toml
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// bareKeyRegex matches keys that can be written without quotes.
+var bareKeyRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// formatKey returns a key as it's written in a path: bare if it can be,
+// quoted otherwise, so a key containing a dot isn't read as two.
+func formatKey(key string) string {
+	if bareKeyRegex.MatchString(key) {
+		return key
+	}
+	return strconv.Quote(key)
+}
+
+// joinPath appends a key to a dotted path.
+func joinPath(path, key string) string {
+	if path == "" {
+		return formatKey(key)
+	}
+	return path + "." + formatKey(key)
+}
+
+// position is where a value or key is written in the source.
+type position struct {
+	offset int    // Byte offset of the value (after its opening quotes) or key
+	end    int    // Byte offset just past a string value
+	label  string // Path as written: the table header in brackets, then the key, e.g. [tool.links].docs
+}
+
+// scanner reads the structure of a TOML document, which has already been
+// validated by the decoder, to locate each value and key. The decoder
+// doesn't expose positions, and searching for a URL's first occurrence puts
+// repeated URLs, and URLs also found in comments, on the wrong line.
+type scanner struct {
+	src    []byte
+	i      int
+	values map[string]position // By path, e.g. tool.links.docs or servers[1].url
+	keys   map[string]position // By path, for the key's own position
+	arrays map[string]int      // Element count of each array of tables, by path
+	header string              // Current table header as written, e.g. [tool.links] or [[servers]][1]
+}
+
+// scanPositions returns the positions of the values and keys of a TOML document.
+func scanPositions(src []byte) *scanner {
+	s := &scanner{
+		src:    src,
+		values: make(map[string]position),
+		keys:   make(map[string]position),
+		arrays: make(map[string]int),
+	}
+	table := ""
+	for {
+		s.skipSpace(true)
+		if s.i >= len(s.src) {
+			return s
+		}
+		start := s.i
+		if s.src[s.i] == '[' {
+			table = s.tableHeader()
+		} else {
+			s.keyValue(table)
+		}
+		if s.i == start {
+			s.skipLine()
+		}
+	}
+}
+
+// tableHeader reads a [table] or [[array]] header and returns its path.
+func (s *scanner) tableHeader() string {
+	array := s.i+1 < len(s.src) && s.src[s.i+1] == '['
+	s.i++
+	if array {
+		s.i++
+	}
+	keys, _ := s.key()
+	s.skipLine()
+
+	path, index := "", ""
+	for j, key := range keys {
+		path = joinPath(path, key)
+		if array && j == len(keys)-1 {
+			n := s.arrays[path]
+			s.arrays[path] = n + 1
+			index = "[" + strconv.Itoa(n) + "]"
+			path += index
+		} else if n, ok := s.arrays[path]; ok {
+			path += "[" + strconv.Itoa(n-1) + "]"
+		}
+	}
+
+	if array {
+		s.header = "[[" + strings.TrimSuffix(path, index) + "]]" + index
+	} else {
+		s.header = "[" + path + "]"
+	}
+	return path
+}
+
+// keyValue reads a key = value pair inside the table at path.
+func (s *scanner) keyValue(table string) {
+	s.readPair(table, s.header, "")
+}
+
+// readPair reads a key = value pair. path is the pair's table, and header
+// and prefix make up its label: the table header and the keys written
+// between it and this pair, such as those of an enclosing inline table.
+func (s *scanner) readPair(path, header, prefix string) {
+	keys, keyStart := s.key()
+	if len(keys) == 0 {
+		return
+	}
+	for _, key := range keys {
+		path = joinPath(path, key)
+		prefix = joinPath(prefix, key)
+	}
+	s.keys[path] = position{offset: keyStart, label: label(header, prefix)}
+
+	s.skipSpace(false)
+	if s.i >= len(s.src) || s.src[s.i] != '=' {
+		return
+	}
+	s.i++
+	s.skipSpace(false)
+	s.value(path, header, prefix)
+}
+
+// value reads the value at path.
+func (s *scanner) value(path, header, prefix string) {
+	if s.i >= len(s.src) {
+		return
+	}
+	switch c := s.src[s.i]; c {
+	case '"', '\'':
+		quotes := 1
+		if s.hasQuotes(c, 3) {
+			quotes = 3
+		}
+		s.i += quotes
+		start := s.i
+		s.skipString(c, quotes)
+		s.values[path] = position{offset: start, end: s.i, label: label(header, prefix)}
+	case '[':
+		s.i++
+		for n := 0; ; {
+			s.skipSpace(true)
+			if s.i >= len(s.src) {
+				return
+			}
+			switch s.src[s.i] {
+			case ']':
+				s.i++
+				return
+			case ',':
+				s.i++
+			default:
+				index := "[" + strconv.Itoa(n) + "]"
+				start := s.i
+				s.value(path+index, header, prefix+index)
+				if s.i == start {
+					s.i++
+				}
+				n++
+			}
+		}
+	case '{':
+		s.i++
+		for {
+			s.skipSpace(true)
+			if s.i >= len(s.src) {
+				return
+			}
+			switch s.src[s.i] {
+			case '}':
+				s.i++
+				return
+			case ',':
+				s.i++
+			default:
+				start := s.i
+				s.readPair(path, header, prefix)
+				if s.i == start {
+					s.i++
+				}
+			}
+		}
+	default:
+		// Numbers, booleans, and dates end at a delimiter
+		for s.i < len(s.src) && !strings.ContainsRune(",]}#\r\n", rune(s.src[s.i])) {
+			s.i++
+		}
+	}
+}
+
+// skipString moves past the string whose opening quotes have been read.
+func (s *scanner) skipString(quote byte, quotes int) {
+	for s.i < len(s.src) {
+		c := s.src[s.i]
+		switch {
+		case c == '\\' && quote == '"':
+			s.i += 2
+		case c == quote && (quotes == 1 || s.hasQuotes(quote, 3)):
+			s.i += quotes
+			// A multi-line string may end with up to two more quotes
+			for extra := 0; quotes == 3 && extra < 2 && s.i < len(s.src) && s.src[s.i] == quote; extra++ {
+				s.i++
+			}
+			return
+		default:
+			s.i++
+		}
+	}
+}
+
+// hasQuotes reports whether n quote characters start at the current offset.
+func (s *scanner) hasQuotes(quote byte, n int) bool {
+	if s.i+n > len(s.src) {
+		return false
+	}
+	for _, c := range s.src[s.i : s.i+n] {
+		if c != quote {
+			return false
+		}
+	}
+	return true
+}
+
+// key reads a dotted key and returns its parts and the offset of the last one.
+func (s *scanner) key() (keys []string, offset int) {
+	for {
+		s.skipSpace(false)
+		if s.i >= len(s.src) {
+			return keys, offset
+		}
+		offset = s.i
+		switch c := s.src[s.i]; c {
+		case '"', '\'':
+			s.i++
+			start := s.i
+			s.skipString(c, 1)
+			raw := string(s.src[start : s.i-1])
+			key := raw
+			if c == '"' {
+				if unquoted, err := strconv.Unquote(`"` + raw + `"`); err == nil {
+					key = unquoted
+				}
+			}
+			keys = append(keys, key)
+			offset++
+		default:
+			start := s.i
+			for s.i < len(s.src) && isBareKeyChar(s.src[s.i]) {
+				s.i++
+			}
+			if s.i == start {
+				return keys, offset
+			}
+			keys = append(keys, string(s.src[start:s.i]))
+		}
+		s.skipSpace(false)
+		if s.i >= len(s.src) || s.src[s.i] != '.' {
+			return keys, offset
+		}
+		s.i++
+	}
+}
+
+// skipSpace moves past spaces and tabs, and newlines and comments if multiline is set.
+func (s *scanner) skipSpace(multiline bool) {
+	for s.i < len(s.src) {
+		switch s.src[s.i] {
+		case ' ', '\t':
+			s.i++
+		case '\r', '\n':
+			if !multiline {
+				return
+			}
+			s.i++
+		case '#':
+			if !multiline {
+				return
+			}
+			s.skipLine()
+		default:
+			return
+		}
+	}
+}
+
+// skipLine moves to the start of the next line.
+func (s *scanner) skipLine() {
+	for s.i < len(s.src) && s.src[s.i] != '\n' {
+		s.i++
+	}
+	if s.i < len(s.src) {
+		s.i++
+	}
+}
+
+// label joins a table header and the keys written under it.
+func label(header, keys string) string {
+	if header == "" {
+		return keys
+	}
+	return header + "." + keys
+}
+
+// isBareKeyChar reports whether c can appear in a bare key.
+func isBareKeyChar(c byte) bool {
+	return c == '_' || c == '-' || ('0' <= c && c <= '9') || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
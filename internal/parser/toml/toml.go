@@ -1,9 +1,13 @@
-// Package toml implements a URL extractor for TOML files.
+// Package toml implements a URL extractor for TOML files. Links are
+// labeled with their key path as written, table header first, such as
+// [tool.links].docs, and positioned at the value they were found in.
 package toml
 
 import (
 	"bytes"
+	"cmp"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -41,23 +45,31 @@ func (*Parser) ValidateAndParse(filename string, content []byte) ([]parser.Link,
 
 	// Extract links from the parsed TOML
 	extractor := &linkExtractor{
-		filePath: filename,
-		content:  content,
-		lines:    lines,
-		links:    make([]parser.Link, 0, 32),
+		filePath:  filename,
+		content:   content,
+		lines:     lines,
+		positions: scanPositions(content),
+		next:      make(map[string]int),
+		links:     make([]parser.Link, 0, 32),
 	}
 
 	extractor.extractFromValue(v, "")
 
+	// Tables are maps, so report links in document order
+	slices.SortStableFunc(extractor.links, func(a, b parser.Link) int {
+		return cmp.Compare(a.Offset, b.Offset)
+	})
 	return extractor.links, nil
 }
 
 // linkExtractor extracts URLs from TOML values.
 type linkExtractor struct {
-	filePath string
-	content  []byte
-	lines    []int
-	links    []parser.Link
+	filePath  string
+	content   []byte
+	lines     []int
+	positions *scanner
+	next      map[string]int // Search offset for the next URL of each value, by path
+	links     []parser.Link
 }
 
 // extractFromValue recursively extracts URLs from a TOML value.
@@ -96,14 +108,19 @@ func (e *linkExtractor) extractFromString(s, path string) {
 		}
 
 		// Find the position of this URL in the original content
-		line, col := e.findURLPosition(url)
+		line, col, offset := e.findValueURL(url, path)
 
+		text := path
+		if pos, ok := e.positions.values[path]; ok {
+			text = pos.label
+		}
 		e.links = append(e.links, parser.Link{
 			URL:      url,
 			FilePath: e.filePath,
 			Line:     line,
 			Column:   col,
-			Text:     path,
+			Offset:   offset,
+			Text:     text,
 			Type:     parser.LinkTypeAutolink,
 		})
 	}
@@ -112,25 +129,29 @@ func (e *linkExtractor) extractFromString(s, path string) {
 // extractFromTable extracts URLs from a TOML table (map).
 func (e *linkExtractor) extractFromTable(table map[string]any, path string) {
 	for key, value := range table {
+		// Build path for this value
+		childPath := joinPath(path, key)
+
 		// Check if the key itself is a URL
 		if parser.IsHTTPURL(key) {
-			line, col := e.findURLPosition(key)
+			line, col, offset := e.findURLPosition(key)
+			text := path + ".<key>"
+			if pos, ok := e.positions.keys[childPath]; ok {
+				line, col = parser.OffsetToLineCol(e.lines, pos.offset)
+				offset = pos.offset
+				text = strings.TrimSuffix(strings.TrimSuffix(pos.label, formatKey(key)), ".") + ".<key>"
+			}
 			e.links = append(e.links, parser.Link{
 				URL:      key,
 				FilePath: e.filePath,
 				Line:     line,
 				Column:   col,
-				Text:     path + ".<key>",
+				Offset:   offset,
+				Text:     text,
 				Type:     parser.LinkTypeAutolink,
 			})
 		}
 
-		// Build path for this value
-		childPath := key
-		if path != "" {
-			childPath = path + "." + key
-		}
-
 		// Recurse into value
 		e.extractFromValue(value, childPath)
 	}
@@ -145,15 +166,36 @@ func (e *linkExtractor) extractFromArray(arr []any, path string) {
 	}
 }
 
-// findURLPosition finds the line and column of a URL in the content.
+// findValueURL finds the line, column, and byte offset of a URL in the
+// value at path, after the value's previous URLs. It falls back to
+// findURLPosition when the value wasn't located or holds the URL escaped.
+func (e *linkExtractor) findValueURL(url, path string) (line, col, offset int) {
+	pos, ok := e.positions.values[path]
+	if !ok {
+		return e.findURLPosition(url)
+	}
+	from := max(pos.offset, e.next[path])
+	idx := bytes.Index(e.content[from:pos.end], []byte(url))
+	if idx == -1 {
+		return e.findURLPosition(url)
+	}
+
+	offset = from + idx
+	e.next[path] = offset + len(url)
+	line, col = parser.OffsetToLineCol(e.lines, offset)
+	return line, col, offset
+}
+
+// findURLPosition finds the line, column, and byte offset of a URL in the content.
 // This is a best-effort approach since TOML doesn't preserve positions after parsing.
-func (e *linkExtractor) findURLPosition(url string) (line, col int) {
+func (e *linkExtractor) findURLPosition(url string) (line, col, offset int) {
 	idx := bytes.Index(e.content, []byte(url))
 	if idx == -1 {
-		return 1, 1
+		return 1, 1, 0
 	}
 
-	return parser.OffsetToLineCol(e.lines, idx)
+	line, col = parser.OffsetToLineCol(e.lines, idx)
+	return line, col, idx
 }
 
 // init registers the TOML parser with the default registry.
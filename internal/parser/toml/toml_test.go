@@ -365,3 +365,68 @@ url = "https://example.com"
 		assert.Empty(t, links)
 	})
 }
+
+func TestParser_KeyPathsAndPositions(t *testing.T) {
+	t.Parallel()
+	p := New()
+
+	content := []byte(`# Docs moved from https://docs.example.com
+homepage = "https://example.com"
+
+[tool.links]
+docs = "https://docs.example.com"
+mirror = "https://docs.example.com"
+"docs.v2" = "https://v2.example.com"
+urls = ["https://one.example.com", { url = "https://two.example.com" }]
+
+[[servers]]
+url = "https://server1.example.com"
+
+[[servers]]
+url = "https://server2.example.com"
+`)
+	links, err := p.ValidateAndParse("pyproject.toml", content)
+	require.NoError(t, err)
+	require.Len(t, links, 8)
+
+	want := []struct {
+		text   string
+		line   int
+		column int
+	}{
+		{"homepage", 2, 13},
+		{"[tool.links].docs", 5, 9},
+		{"[tool.links].mirror", 6, 11},
+		{`[tool.links]."docs.v2"`, 7, 14},
+		{"[tool.links].urls[0]", 8, 10},
+		{"[tool.links].urls[1].url", 8, 45},
+		{"[[servers]][0].url", 11, 8},
+		{"[[servers]][1].url", 14, 8},
+	}
+	for i, w := range want {
+		assert.Equal(t, w.text, links[i].Text, "link %d", i)
+		assert.Equal(t, w.line, links[i].Line, "link %d", i)
+		assert.Equal(t, w.column, links[i].Column, "link %d", i)
+		assert.Equal(t, links[i].URL, string(content[links[i].Offset:links[i].Offset+len(links[i].URL)]), "link %d", i)
+	}
+}
+
+func TestParser_MultiLineStringPositions(t *testing.T) {
+	t.Parallel()
+	p := New()
+
+	content := []byte(`[package]
+description = """
+See https://example.com and
+https://example.com/docs for details."""
+`)
+	links, err := p.ValidateAndParse("Cargo.toml", content)
+	require.NoError(t, err)
+	require.Len(t, links, 2)
+
+	assert.Equal(t, "[package].description", links[0].Text)
+	assert.Equal(t, 3, links[0].Line)
+	assert.Equal(t, 5, links[0].Column)
+	assert.Equal(t, 4, links[1].Line)
+	assert.Equal(t, 1, links[1].Column)
+}
@@ -0,0 +1,154 @@
+package xml //nolint:revive // package name matches file type being parsed
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+
+	"github.com/leonardomso/gone/internal/parser"
+)
+
+// feedRoots are the root elements of sitemaps (urlset, sitemapindex) and
+// feeds (RSS 2.0, RSS 1.0, Atom), which are read by element instead of
+// searched for URLs.
+var feedRoots = map[string]bool{
+	"urlset":       true,
+	"sitemapindex": true,
+	"rss":          true,
+	"RDF":          true,
+	"feed":         true,
+}
+
+// feedTextElements are the elements whose text is a link: sitemap <loc>
+// (including image and video extensions), RSS <link>, <comments>, <docs>,
+// <guid>, and image <url>, and Atom <icon>, <logo>, and author <uri>.
+var feedTextElements = map[string]bool{
+	"loc":         true,
+	"player_loc":  true,
+	"content_loc": true,
+	"link":        true,
+	"comments":    true,
+	"docs":        true,
+	"guid":        true,
+	"url":         true,
+	"icon":        true,
+	"logo":        true,
+	"uri":         true,
+}
+
+// feedAttributes maps elements to their link attribute: RSS <enclosure url>
+// and <source url>, Media RSS <content url> and <thumbnail url>, Atom
+// <link href> and <content src>, and RSS 1.0 <item rdf:about>.
+var feedAttributes = map[string][]string{
+	"enclosure": {"url"},
+	"source":    {"url"},
+	"content":   {"url", "src"},
+	"thumbnail": {"url"},
+	"link":      {"href"},
+	"item":      {"about"},
+}
+
+// feedExtractor extracts the links of a sitemap or feed by element.
+type feedExtractor struct {
+	*linkExtractor
+	stack []xml.StartElement // Open elements, innermost last
+}
+
+// process handles the token found at content[start:end].
+func (e *feedExtractor) process(token xml.Token, start, end int) {
+	switch t := token.(type) {
+	case xml.StartElement:
+		e.stack = append(e.stack, t)
+		e.attributes(t, start, end)
+	case xml.EndElement:
+		if len(e.stack) > 0 {
+			e.stack = e.stack[:len(e.stack)-1]
+		}
+	case xml.CharData:
+		e.text(string(t), start, end)
+	}
+}
+
+// attributes records the link attributes of elem, whose raw start tag is content[start:end].
+func (e *feedExtractor) attributes(elem xml.StartElement, start, end int) {
+	names := feedAttributes[elem.Name.Local]
+	if len(names) == 0 {
+		return
+	}
+	for _, attr := range elem.Attr {
+		for _, name := range names {
+			url := strings.TrimSpace(attr.Value)
+			if attr.Name.Local != name || !parser.IsHTTPURL(url) {
+				continue
+			}
+			offset := start + attrValueOffset(e.content[start:end], attr.Name.Local)
+			e.addLink(url, offset, e.label(elem)+"."+name)
+		}
+	}
+}
+
+// text records the text of a link element, found raw at content[start:end].
+func (e *feedExtractor) text(text string, start, end int) {
+	if len(e.stack) == 0 {
+		return
+	}
+	elem := e.stack[len(e.stack)-1]
+	url := strings.TrimSpace(text)
+	if !feedTextElements[elem.Name.Local] || !parser.IsHTTPURL(url) {
+		return
+	}
+	for _, attr := range elem.Attr {
+		// A guid that isn't a permalink is only an identifier
+		if attr.Name.Local == "isPermaLink" && attr.Value == "false" {
+			return
+		}
+	}
+
+	raw := bytes.TrimPrefix(e.content[start:end], []byte("<![CDATA["))
+	offset := end - len(bytes.TrimLeft(raw, " \t\r\n"))
+	e.addLink(url, offset, e.label(elem))
+}
+
+// label names an element with its parent, e.g. "item.link" or "url.loc".
+func (e *feedExtractor) label(elem xml.StartElement) string {
+	name := elem.Name.Local
+	for _, attr := range elem.Attr {
+		// Atom links differ by relation: alternate, enclosure, self, ...
+		if name == "link" && attr.Name.Local == "rel" {
+			name += "[" + attr.Value + "]"
+		}
+	}
+	// elem is the innermost open element
+	if len(e.stack) < 2 {
+		return name
+	}
+	return e.stack[len(e.stack)-2].Name.Local + "." + name
+}
+
+// attrValueOffset returns the offset of an attribute's value in a raw
+// start tag, or 0 if it isn't found.
+func attrValueOffset(tag []byte, name string) int {
+	for i := 0; ; {
+		j := bytes.Index(tag[i:], []byte(name))
+		if j < 0 {
+			return 0
+		}
+		// Skip matches inside longer names, such as "url" in "imageurl"
+		before := byte(' ')
+		if i+j > 0 {
+			before = tag[i+j-1]
+		}
+		i += j + len(name)
+		if before != ' ' && before != '\t' && before != '\n' && before != '\r' && before != ':' {
+			continue
+		}
+		rest := bytes.TrimLeft(tag[i:], " \t\r\n")
+		if len(rest) == 0 || rest[0] != '=' {
+			continue
+		}
+		value := bytes.TrimLeft(rest[1:], " \t\r\n")
+		if len(value) > 0 && (value[0] == '"' || value[0] == '\'') {
+			return len(tag) - len(value) + 1
+		}
+	}
+}
@@ -7,33 +7,46 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
+	"strconv"
 	"strings"
 
 	"github.com/leonardomso/gone/internal/parser"
 )
 
-// urlAttributes lists common XML/HTML attributes that typically contain URLs.
-var urlAttributes = map[string]bool{
-	"href":       true,
-	"src":        true,
-	"url":        true,
-	"link":       true,
-	"action":     true,
-	"data":       true,
-	"poster":     true,
-	"srcset":     true,
-	"formaction": true,
-	"cite":       true,
-	"background": true,
-	"xlink:href": true,
+// DefaultAttributes are the attributes whose values are read as links,
+// by qualified name, unless replaced with WithAttributes.
+var DefaultAttributes = []string{
+	"href", "src", "url", "link", "action", "data", "poster", "srcset",
+	"formaction", "cite", "background", "xlink:href",
+}
+
+// knownPrefixes are the conventional prefixes of well-known namespaces,
+// which attribute names are matched with and which a document may use
+// without declaring them.
+var knownPrefixes = map[string]string{
+	"http://www.w3.org/1999/xlink":         "xlink",
+	"http://www.w3.org/XML/1998/namespace": "xml",
 }
 
 // Parser implements parser.FileParser for XML files.
-type Parser struct{}
+type Parser struct {
+	attributes map[string]bool // Lower-cased qualified names
+}
 
-// New creates a new XML parser.
+// New creates a new XML parser that reads DefaultAttributes.
 func New() *Parser {
-	return &Parser{}
+	return (&Parser{}).WithAttributes(DefaultAttributes)
+}
+
+// WithAttributes sets the attributes whose values are read as links, by
+// qualified name (href, xlink:href), matched case-insensitively.
+func (p *Parser) WithAttributes(names []string) *Parser {
+	p.attributes = make(map[string]bool, len(names))
+	for _, name := range names {
+		p.attributes[strings.ToLower(name)] = true
+	}
+	return p
 }
 
 // Extensions returns the file extensions this parser handles.
@@ -41,8 +54,11 @@ func (*Parser) Extensions() []string {
 	return []string{".xml"}
 }
 
-// ValidateAndParse validates the content and extracts links in a single pass.
-func (*Parser) ValidateAndParse(filename string, content []byte) ([]parser.Link, error) {
+// ValidateAndParse validates the content and extracts links in a single
+// pass: the values of the parser's attributes and URLs in element text,
+// labeled with the element's XPath, such as /project/url or
+// /svg/a[2]/@xlink:href.
+func (p *Parser) ValidateAndParse(filename string, content []byte) ([]parser.Link, error) {
 	if len(content) == 0 {
 		return nil, nil
 	}
@@ -52,15 +68,23 @@ func (*Parser) ValidateAndParse(filename string, content []byte) ([]parser.Link,
 
 	// Extract links (single pass - validates and parses)
 	extractor := &linkExtractor{
-		filePath: filename,
-		content:  content,
-		lines:    lines,
-		links:    make([]parser.Link, 0, 32),
-		seen:     map[string]bool{},
+		filePath:   filename,
+		content:    content,
+		lines:      lines,
+		links:      make([]parser.Link, 0, 32),
+		seen:       map[string]bool{},
+		attributes: p.attributes,
+		prefixes:   maps.Clone(knownPrefixes),
+		stack:      []element{{children: map[string]int{}}},
 	}
 
+	// Sitemaps and feeds, recognized by their root element, are read by element
+	var feed *feedExtractor
+	rootSeen := false
+
 	decoder := xml.NewDecoder(bytes.NewReader(content))
 	for {
+		start := decoder.InputOffset()
 		token, err := decoder.Token()
 		if errors.Is(err, io.EOF) {
 			break
@@ -69,7 +93,17 @@ func (*Parser) ValidateAndParse(filename string, content []byte) ([]parser.Link,
 			return nil, fmt.Errorf("invalid XML: %w", err)
 		}
 
-		extractor.processToken(token, decoder.InputOffset())
+		if elem, ok := token.(xml.StartElement); ok && !rootSeen {
+			rootSeen = true
+			if feedRoots[elem.Name.Local] {
+				feed = &feedExtractor{linkExtractor: extractor}
+			}
+		}
+		if feed != nil {
+			feed.process(token, int(start), int(decoder.InputOffset()))
+			continue
+		}
+		extractor.processToken(token, int(start), int(decoder.InputOffset()))
 	}
 
 	return extractor.links, nil
@@ -77,98 +111,171 @@ func (*Parser) ValidateAndParse(filename string, content []byte) ([]parser.Link,
 
 // linkExtractor extracts URLs from XML tokens.
 type linkExtractor struct {
-	filePath string
-	content  []byte
-	lines    []int
-	links    []parser.Link
-	seen     map[string]bool // Track seen URLs to avoid duplicates from same position
+	filePath   string
+	content    []byte
+	lines      []int
+	links      []parser.Link
+	seen       map[string]bool   // Track seen URLs to avoid duplicates from same position
+	attributes map[string]bool   // Link attributes, by lower-cased qualified name
+	prefixes   map[string]string // Namespace prefixes, by namespace URI
+	stack      []element         // Open elements, innermost last, below a document node
+}
+
+// element is an open element and the names of its children so far.
+type element struct {
+	path     string         // XPath, e.g. /svg/a[2]
+	children map[string]int // Count of child elements, by qualified name
 }
 
-// processToken processes a single XML token.
-func (e *linkExtractor) processToken(token xml.Token, offset int64) {
+// processToken processes the XML token found at content[start:end].
+func (e *linkExtractor) processToken(token xml.Token, start, end int) {
 	switch t := token.(type) {
 	case xml.StartElement:
-		e.extractFromElement(t, offset)
+		e.open(t)
+		e.extractFromElement(t, start, end)
+	case xml.EndElement:
+		if len(e.stack) > 1 {
+			e.stack = e.stack[:len(e.stack)-1]
+		}
 	case xml.CharData:
-		e.extractFromText(string(t), offset)
+		e.extractFromText(string(t), start, end)
 	}
 }
 
-// extractFromElement extracts URLs from element attributes.
-func (e *linkExtractor) extractFromElement(elem xml.StartElement, offset int64) {
+// open records the namespaces an element declares and pushes it on the stack.
+func (e *linkExtractor) open(elem xml.StartElement) {
 	for _, attr := range elem.Attr {
-		// Check if this is a URL attribute
-		attrName := strings.ToLower(attr.Name.Local)
-		if attr.Name.Space != "" {
-			attrName = attr.Name.Space + ":" + attrName
+		switch {
+		case attr.Name.Space == "xmlns":
+			e.prefixes[attr.Value] = attr.Name.Local
+		case attr.Name.Space == "" && attr.Name.Local == "xmlns":
+			e.prefixes[attr.Value] = ""
 		}
+	}
 
-		if urlAttributes[attrName] {
-			url := strings.TrimSpace(attr.Value)
-			if parser.IsHTTPURL(url) {
-				line, col := e.findURLPosition(url)
-				e.addLink(url, line, col, elem.Name.Local+"."+attrName)
-			}
-		}
+	parent := e.stack[len(e.stack)-1]
+	name := e.qualify(elem.Name)
+	parent.children[name]++
+	step := name
+	if n := parent.children[name]; n > 1 {
+		step += "[" + strconv.Itoa(n) + "]"
+	}
+	e.stack = append(e.stack, element{path: parent.path + "/" + step, children: map[string]int{}})
+}
 
-		// Also check for URLs embedded in attribute values
-		e.extractEmbeddedURLs(attr.Value, offset, elem.Name.Local+"."+attrName)
+// qualify returns a name as written, with its namespace's prefix. The
+// decoder resolves prefixes to namespace URIs.
+func (e *linkExtractor) qualify(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
 	}
+	prefix, ok := e.prefixes[name.Space]
+	switch {
+	case !ok:
+		// An undeclared prefix is left as is
+		prefix = name.Space
+	case prefix == "":
+		return name.Local
+	}
+	return prefix + ":" + name.Local
 }
 
-// extractFromText extracts URLs from text content.
-func (e *linkExtractor) extractFromText(text string, offset int64) {
-	e.extractEmbeddedURLs(text, offset, "text")
+// canonical returns a name with its namespace's conventional prefix if it
+// has one, so xlink:href matches whatever prefix a document binds XLink to.
+func (e *linkExtractor) canonical(name xml.Name) string {
+	if prefix, ok := knownPrefixes[name.Space]; ok {
+		return prefix + ":" + name.Local
+	}
+	return e.qualify(name)
+}
+
+// extractFromElement extracts the URLs of an element's link attributes,
+// whose raw start tag is content[start:end].
+func (e *linkExtractor) extractFromElement(elem xml.StartElement, start, end int) {
+	path := e.stack[len(e.stack)-1].path
+	for _, attr := range elem.Attr {
+		if attr.Name.Space == "xmlns" || !e.attributes[strings.ToLower(e.canonical(attr.Name))] {
+			continue
+		}
+		url := strings.TrimSpace(attr.Value)
+		if !parser.IsHTTPURL(url) {
+			continue
+		}
+
+		// Search from the attribute's value, since the URL may also appear
+		// earlier in the tag
+		from := start + attrValueOffset(e.content[start:end], e.qualify(attr.Name))
+		e.addLink(url, e.findURLOffset(url, from, end), path+"/@"+e.qualify(attr.Name))
+	}
 }
 
-// extractEmbeddedURLs finds URLs embedded in a string value.
-func (e *linkExtractor) extractEmbeddedURLs(s string, _ int64, context string) {
+// extractFromText extracts URLs from text content, found raw at content[start:end].
+func (e *linkExtractor) extractFromText(text string, start, end int) {
 	// Quick check: skip if no "http" substring (covers both http:// and https://)
-	if !strings.Contains(s, "http") {
+	if !strings.Contains(text, "http") {
 		return
 	}
 
-	matches := parser.URLRegex.FindAllString(s, -1)
-	for _, url := range matches {
+	path := e.stack[len(e.stack)-1].path
+	if path == "" {
+		path = "/"
+	}
+	from := start
+	for _, url := range parser.URLRegex.FindAllString(text, -1) {
 		url = parser.CleanURLTrailing(url)
 		if !parser.IsHTTPURL(url) {
 			continue
 		}
 
-		line, col := e.findURLPosition(url)
-		e.addLink(url, line, col, context)
+		offset := e.findURLOffset(url, from, end)
+		if offset >= from {
+			from = offset + len(url)
+		}
+		e.addLink(url, offset, path)
 	}
 }
 
-// addLink adds a link if not already seen at this position.
-func (e *linkExtractor) addLink(url string, line, col int, context string) {
+// addLink adds a link at the byte offset if not already seen at this position.
+func (e *linkExtractor) addLink(url string, offset int, context string) {
 	// Create a unique key for this URL at this position
-	key := fmt.Sprintf("%s:%d:%d", url, line, col)
+	key := url + ":" + strconv.Itoa(offset)
 	if e.seen[key] {
 		return
 	}
 	e.seen[key] = true
 
+	line, col := parser.OffsetToLineCol(e.lines, offset)
 	e.links = append(e.links, parser.Link{
 		URL:      url,
 		FilePath: e.filePath,
 		Line:     line,
 		Column:   col,
+		Offset:   offset,
 		Text:     context,
 		Type:     parser.LinkTypeAutolink,
 	})
 }
 
-// findURLPosition finds the line and column of a URL in the content.
-func (e *linkExtractor) findURLPosition(url string) (line, col int) {
-	idx := bytes.Index(e.content, []byte(url))
-	if idx == -1 {
-		return 1, 1
+// findURLOffset finds the byte offset of a URL in content[from:end], written
+// verbatim or with its &, <, and > escaped as &amp;, &lt;, and &gt;. If it's
+// written otherwise (e.g. with numeric entities), it's the offset of its
+// first occurrence in the content, or 0.
+func (e *linkExtractor) findURLOffset(url string, from, end int) int {
+	if idx := bytes.Index(e.content[from:end], []byte(url)); idx >= 0 {
+		return from + idx
 	}
-
-	return parser.OffsetToLineCol(e.lines, idx)
+	if escaped := xmlEscaper.Replace(url); escaped != url {
+		if idx := bytes.Index(e.content[from:end], []byte(escaped)); idx >= 0 {
+			return from + idx
+		}
+	}
+	return max(bytes.Index(e.content, []byte(url)), 0)
 }
 
+// xmlEscaper escapes the characters XML requires to be escaped in text and
+// attribute values.
+var xmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
 // init registers the XML parser with the default registry.
 func init() {
 	parser.RegisterParser(New())
@@ -219,6 +219,21 @@ func TestParser_LineNumbers(t *testing.T) {
 			assert.Greater(t, link.Line, 0)
 		}
 	})
+
+	t.Run("URLsWithEntities", func(t *testing.T) {
+		t.Parallel()
+		content := []byte(`<root>
+<a href="https://example.com/?a=1&amp;b=2">One</a>
+<a href="https://example.com/?a=1&amp;b=2">Two</a>
+</root>`)
+		links, err := p.ValidateAndParse("test.xml", content)
+		require.NoError(t, err)
+		require.Len(t, links, 2)
+		assert.Equal(t, "https://example.com/?a=1&b=2", links[0].URL)
+		assert.Equal(t, 2, links[0].Line)
+		assert.Equal(t, 3, links[1].Line)
+		assert.Equal(t, "https://example.com/?a=1&amp;b=2", string(content[links[1].Offset:links[1].Offset+32]))
+	})
 }
 
 // TestParser_EdgeCases tests edge cases for the XML parser.
@@ -360,3 +375,149 @@ func TestParser_EdgeCases(t *testing.T) {
 		assert.Len(t, links, 4)
 	})
 }
+
+func TestParser_AttributesAndXPaths(t *testing.T) {
+	t.Parallel()
+
+	content := []byte(`<svg xmlns="http://www.w3.org/2000/svg" xmlns:x="http://www.w3.org/1999/xlink">
+<a x:href="https://one.example.com"><text>https://one.example.com</text></a>
+<a x:href="https://two.example.com" title="https://title.example.com"/>
+<image src="https://img.example.com/a.png" resource="https://res.example.com"/>
+</svg>`)
+
+	t.Run("Default", func(t *testing.T) {
+		t.Parallel()
+		links, err := New().ValidateAndParse("test.xml", content)
+		require.NoError(t, err)
+		require.Len(t, links, 4)
+
+		// Namespace declarations and other attributes aren't links
+		assert.Equal(t, "https://one.example.com", links[0].URL)
+		assert.Equal(t, "/svg/a/@x:href", links[0].Text)
+		assert.Equal(t, 12, links[0].Column)
+		assert.Equal(t, "/svg/a/text", links[1].Text)
+		assert.Equal(t, 43, links[1].Column)
+		assert.Equal(t, "/svg/a[2]/@x:href", links[2].Text)
+		assert.Equal(t, "/svg/image/@src", links[3].Text)
+		for _, link := range links {
+			assert.Equal(t, link.URL, string(content[link.Offset:link.Offset+len(link.URL)]))
+		}
+	})
+
+	t.Run("WithAttributes", func(t *testing.T) {
+		t.Parallel()
+		p := New().WithAttributes([]string{"Resource", "title"})
+		links, err := p.ValidateAndParse("test.xml", content)
+		require.NoError(t, err)
+
+		texts := make([]string, 0, len(links))
+		for _, link := range links {
+			texts = append(texts, link.Text)
+		}
+		assert.Equal(t, []string{"/svg/a/text", "/svg/a[2]/@title", "/svg/image/@resource"}, texts)
+	})
+}
+
+func TestParser_Feeds(t *testing.T) {
+	t.Parallel()
+	p := New()
+
+	// labels maps each link's text to its URL.
+	labels := func(links []parser.Link) map[string]string {
+		out := map[string]string{}
+		for _, l := range links {
+			out[l.Text] = l.URL
+		}
+		return out
+	}
+
+	t.Run("Sitemap", func(t *testing.T) {
+		t.Parallel()
+		content := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"
+        xmlns:image="http://www.google.com/schemas/sitemap-image/1.1">
+  <url>
+    <loc>
+      https://example.com/?page=1&amp;sort=new
+    </loc>
+    <lastmod>2024-01-01</lastmod>
+    <image:image><image:loc>https://example.com/a.png</image:loc></image:image>
+  </url>
+</urlset>`)
+		links, err := p.ValidateAndParse("sitemap.xml", content)
+		require.NoError(t, err)
+		require.Len(t, links, 2)
+
+		assert.Equal(t, "https://example.com/?page=1&sort=new", links[0].URL)
+		assert.Equal(t, "url.loc", links[0].Text)
+		assert.Equal(t, 6, links[0].Line)
+		assert.Equal(t, 7, links[0].Column)
+		assert.Equal(t, "image.loc", links[1].Text)
+	})
+
+	t.Run("SitemapIndex", func(t *testing.T) {
+		t.Parallel()
+		content := []byte(`<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <sitemap><loc>https://example.com/sitemap-1.xml</loc></sitemap>
+</sitemapindex>`)
+		links, err := p.ValidateAndParse("sitemap_index.xml", content)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"sitemap.loc": "https://example.com/sitemap-1.xml"}, labels(links))
+	})
+
+	t.Run("RSS", func(t *testing.T) {
+		t.Parallel()
+		content := []byte(`<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom">
+<channel>
+  <link>https://example.com/</link>
+  <atom:link href="https://example.com/feed.xml" rel="self"/>
+  <description>Posts about https://example.com/not-checked</description>
+  <item>
+    <link><![CDATA[https://example.com/post]]></link>
+    <guid isPermaLink="false">https://example.com/?p=1</guid>
+    <comments>https://example.com/post#comments</comments>
+    <enclosure url="https://example.com/episode.mp3" length="1" type="audio/mpeg"/>
+  </item>
+</channel>
+</rss>`)
+		links, err := p.ValidateAndParse("feed.xml", content)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"channel.link":            "https://example.com/",
+			"channel.link[self].href": "https://example.com/feed.xml",
+			"item.link":               "https://example.com/post",
+			"item.comments":           "https://example.com/post#comments",
+			"item.enclosure.url":      "https://example.com/episode.mp3",
+		}, labels(links))
+
+		for _, l := range links {
+			switch l.Text {
+			case "item.link":
+				assert.Equal(t, 7, l.Line)
+				assert.Equal(t, 20, l.Column)
+			case "item.enclosure.url":
+				assert.Equal(t, 10, l.Line)
+				assert.Equal(t, 21, l.Column)
+			}
+		}
+	})
+
+	t.Run("Atom", func(t *testing.T) {
+		t.Parallel()
+		content := []byte(`<feed xmlns="http://www.w3.org/2005/Atom">
+  <id>https://example.com/id-not-checked</id>
+  <link href="https://example.com/"/>
+  <entry>
+    <link rel="alternate" href="https://example.com/entry"/>
+    <author><uri>https://example.com/me</uri></author>
+  </entry>
+</feed>`)
+		links, err := p.ValidateAndParse("atom.xml", content)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"feed.link.href":             "https://example.com/",
+			"entry.link[alternate].href": "https://example.com/entry",
+			"author.uri":                 "https://example.com/me",
+		}, labels(links))
+	})
+}
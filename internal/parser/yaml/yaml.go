@@ -7,8 +7,10 @@ import (
 	"fmt"
 	"io"
 	"strconv"
+	"unicode/utf8"
 
 	"github.com/leonardomso/gone/internal/parser"
+	"github.com/leonardomso/gone/internal/parser/openapi"
 	"gopkg.in/yaml.v3"
 )
 
@@ -33,6 +35,8 @@ func (*Parser) ValidateAndParse(filename string, content []byte) ([]parser.Link,
 
 	extractor := &linkExtractor{
 		filePath: filename,
+		content:  content,
+		lines:    parser.BuildLineIndex(content),
 		links:    make([]parser.Link, 0, 32),
 	}
 
@@ -46,16 +50,34 @@ func (*Parser) ValidateAndParse(filename string, content []byte) ([]parser.Link,
 			}
 			return nil, fmt.Errorf("invalid YAML: %w", err)
 		}
+		extractor.openapi = isOpenAPI(&node)
 		extractor.extractFromNode(&node, "")
 	}
 
 	return extractor.links, nil
 }
 
+// isOpenAPI reports whether a YAML document is an OpenAPI or Swagger document.
+func isOpenAPI(doc *yaml.Node) bool {
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return false
+	}
+	root := doc.Content[0]
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if openapi.IsVersionField(root.Content[i].Value, root.Content[i+1].Value) {
+			return true
+		}
+	}
+	return false
+}
+
 // linkExtractor extracts URLs from YAML nodes.
 type linkExtractor struct {
 	filePath string
+	content  []byte
+	lines    []int
 	links    []parser.Link
+	openapi  bool // Only check the link fields of an OpenAPI document
 }
 
 // extractFromNode recursively extracts URLs from a YAML node.
@@ -78,15 +100,8 @@ func (e *linkExtractor) extractFromNode(node *yaml.Node, path string) {
 			valueNode := node.Content[i+1]
 
 			// Check if key is a URL
-			if keyNode.Kind == yaml.ScalarNode && parser.IsHTTPURL(keyNode.Value) {
-				e.links = append(e.links, parser.Link{
-					URL:      keyNode.Value,
-					FilePath: e.filePath,
-					Line:     keyNode.Line,
-					Column:   keyNode.Column,
-					Text:     path + ".<key>",
-					Type:     parser.LinkTypeAutolink,
-				})
+			if !e.openapi && keyNode.Kind == yaml.ScalarNode && parser.IsHTTPURL(keyNode.Value) {
+				e.addLink(keyNode, keyNode.Value, path+".<key>", e.nodeOffset(keyNode))
 			}
 
 			// Build path for value
@@ -126,17 +141,14 @@ func (e *linkExtractor) extractFromNode(node *yaml.Node, path string) {
 // extractURLsFromScalar extracts URLs from a scalar (string) node.
 func (e *linkExtractor) extractURLsFromScalar(node *yaml.Node, path string) {
 	value := node.Value
+	if e.openapi && !openapi.IsLink(path, value) {
+		return
+	}
 
 	// Check if the entire value is a URL
+	from := e.nodeOffset(node)
 	if parser.IsHTTPURL(value) {
-		e.links = append(e.links, parser.Link{
-			URL:      value,
-			FilePath: e.filePath,
-			Line:     node.Line,
-			Column:   node.Column,
-			Text:     path,
-			Type:     parser.LinkTypeAutolink,
-		})
+		e.addLink(node, value, path, from)
 		return
 	}
 
@@ -149,15 +161,49 @@ func (e *linkExtractor) extractURLsFromScalar(node *yaml.Node, path string) {
 			continue
 		}
 
-		e.links = append(e.links, parser.Link{
-			URL:      url,
-			FilePath: e.filePath,
-			Line:     node.Line,
-			Column:   node.Column,
-			Text:     path,
-			Type:     parser.LinkTypeAutolink,
-		})
+		from = e.addLink(node, url, path, from)
+	}
+}
+
+// addLink records url, found in node at or after the byte offset from, and
+// returns the offset just past it, where the node's next URL is searched.
+// URLs that aren't written verbatim, such as in a folded scalar, get the
+// node's own position.
+func (e *linkExtractor) addLink(node *yaml.Node, url, path string, from int) int {
+	link := parser.Link{
+		URL:      url,
+		FilePath: e.filePath,
+		Line:     node.Line,
+		Column:   node.Column,
+		Offset:   from,
+		Text:     path,
+		Type:     parser.LinkTypeAutolink,
+	}
+	next := from
+	if i := bytes.Index(e.content[from:], []byte(url)); i >= 0 {
+		link.Offset = from + i
+		link.Line, link.Column = parser.OffsetToLineCol(e.lines, link.Offset)
+		next = link.Offset + len(url)
+	}
+	e.links = append(e.links, link)
+	return next
+}
+
+// nodeOffset returns the byte offset of a node, whose column yaml.v3
+// counts in characters rather than bytes.
+func (e *linkExtractor) nodeOffset(node *yaml.Node) int {
+	if node.Line < 1 || node.Line > len(e.lines) {
+		return 0
+	}
+	offset := e.lines[node.Line-1]
+	for range node.Column - 1 {
+		if offset >= len(e.content) {
+			break
+		}
+		_, size := utf8.DecodeRune(e.content[offset:])
+		offset += size
 	}
+	return offset
 }
 
 // init registers the YAML parser with the default registry.
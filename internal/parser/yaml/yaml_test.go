@@ -265,6 +265,25 @@ url2: https://line3.example.com
 		require.Len(t, links, 1)
 		assert.Equal(t, 3, links[0].Line)
 	})
+
+	t.Run("ColumnsAndOffsets", func(t *testing.T) {
+		t.Parallel()
+		content := []byte(`title: "Café"
+home: "https://example.com/"
+about: "Ünïcode, see https://example.com/a and https://example.com/a"
+`)
+		links, err := p.ValidateAndParse("test.yaml", content)
+		require.NoError(t, err)
+		require.Len(t, links, 3)
+
+		// Quoted values point past the quote, at the URL itself
+		for _, l := range links {
+			assert.Equal(t, l.URL, string(content[l.Offset:l.Offset+len(l.URL)]))
+		}
+		assert.Equal(t, 2, links[0].Line)
+		assert.Equal(t, 8, links[0].Column)
+		assert.Less(t, links[1].Offset, links[2].Offset, "repeated URLs get their own offset")
+	})
 }
 
 func TestYAMLParser_PathTracking(t *testing.T) {
@@ -299,6 +318,81 @@ urls:
 	})
 }
 
+func TestYAMLParser_OpenAPI(t *testing.T) {
+	t.Parallel()
+	p := New()
+
+	t.Run("ChecksLinkFields", func(t *testing.T) {
+		t.Parallel()
+		content := []byte(`openapi: 3.0.3
+info:
+  title: Pets
+  description: Guide at https://example.com/guide
+  contact:
+    url: https://example.com/support
+  license:
+    url: https://opensource.org/licenses/MIT
+servers:
+  - url: https://api.example.com/v1
+  - url: https://{region}.example.com
+externalDocs:
+  url: https://docs.example.com
+paths:
+  /pets:
+    get:
+      externalDocs:
+        url: https://docs.example.com/pets
+      parameters:
+        - name: callback
+          schema:
+            default: https://example.com/default
+          example: https://example.com/example
+`)
+		links, err := p.ValidateAndParse("openapi.yaml", content)
+		require.NoError(t, err)
+
+		paths := map[string]string{}
+		for _, l := range links {
+			paths[l.Text] = l.URL
+		}
+		assert.Equal(t, map[string]string{
+			"info.description":                 "https://example.com/guide",
+			"info.contact.url":                 "https://example.com/support",
+			"info.license.url":                 "https://opensource.org/licenses/MIT",
+			"servers[0].url":                   "https://api.example.com/v1",
+			"externalDocs.url":                 "https://docs.example.com",
+			"paths./pets.get.externalDocs.url": "https://docs.example.com/pets",
+		}, paths)
+	})
+
+	t.Run("Swagger", func(t *testing.T) {
+		t.Parallel()
+		content := []byte(`swagger: "2.0"
+info:
+  termsOfService: https://example.com/terms
+definitions:
+  Pet:
+    example: https://example.com/example
+`)
+		links, err := p.ValidateAndParse("swagger.yaml", content)
+		require.NoError(t, err)
+		require.Len(t, links, 1)
+		assert.Equal(t, "info.termsOfService", links[0].Text)
+	})
+
+	t.Run("OtherDocumentsScanFully", func(t *testing.T) {
+		t.Parallel()
+		content := []byte(`openapi: 3.0.0
+---
+example: https://example.com/example
+`)
+		links, err := p.ValidateAndParse("multi.yaml", content)
+		require.NoError(t, err)
+		require.Len(t, links, 1)
+		assert.Equal(t, "example", links[0].Text)
+	})
+}
+
 // TestYAMLParser_EdgeCases tests edge cases for the YAML parser.
 func TestYAMLParser_EdgeCases(t *testing.T) {
 	t.Parallel()
@@ -0,0 +1,108 @@
+// Package relative checks links between files in the scanned tree, such as
+// "./docs/setup.md" or "#install", without network access.
+package relative
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/leonardomso/gone/internal/anchor"
+	"github.com/leonardomso/gone/internal/parser"
+	"github.com/leonardomso/gone/internal/parser/markdown"
+)
+
+// Problems reported for relative links.
+const (
+	ProblemMissingFile   = "missing-file"
+	ProblemMissingAnchor = "missing-anchor"
+)
+
+// Finding is a relative link whose target file or heading anchor doesn't exist.
+type Finding struct {
+	Problem string
+	Message string
+	Target  string // Resolved path of the target file
+	Anchor  string // Missing fragment, for ProblemMissingAnchor
+	Closest string // Anchor in the target closest to Anchor, if one is close enough
+	Link    parser.Link
+}
+
+// Check resolves relative links against the file system and returns the
+// links that point at missing files or at fragments that match no heading
+// or HTML anchor in the target. Paths starting with "/" are resolved against
+// root, other paths against the directory of the linking file, and
+// fragment-only links against the linking file itself. Anchors are only
+// verified in markdown targets, and a missing one comes with the closest
+// anchor in the target, if any is close enough.
+func Check(root string, links []parser.Link) []Finding {
+	var findings []Finding
+	anchors := map[string]map[string]bool{}
+
+	for _, link := range links {
+		target, fragment, ok := resolve(root, link)
+		if !ok {
+			continue
+		}
+
+		info, err := os.Stat(target)
+		if err != nil {
+			findings = append(findings, Finding{
+				Problem: ProblemMissingFile,
+				Message: filepath.ToSlash(target) + " does not exist",
+				Target:  target,
+				Link:    link,
+			})
+			continue
+		}
+		if fragment == "" || info.IsDir() || parser.FileTypeForFile(target) != "md" {
+			continue
+		}
+
+		set, ok := anchors[target]
+		if !ok {
+			content, err := os.ReadFile(target) //nolint:gosec // Path is resolved from a scanned link
+			if err != nil {
+				continue
+			}
+			set = markdown.HeadingAnchors(content)
+			anchors[target] = set
+		}
+		if set[fragment] || set[strings.ToLower(fragment)] {
+			continue
+		}
+		finding := Finding{
+			Problem: ProblemMissingAnchor,
+			Message: "no heading or anchor #" + fragment + " in " + filepath.ToSlash(target),
+			Target:  target,
+			Anchor:  fragment,
+			Link:    link,
+		}
+		if closest, ok := anchor.Closest(fragment, set); ok {
+			finding.Closest = closest
+			finding.Message += " (closest: #" + closest + ")"
+		}
+		findings = append(findings, finding)
+	}
+	return findings
+}
+
+// resolve returns the path of the file a link points at and its decoded
+// fragment. The query is dropped.
+func resolve(root string, link parser.Link) (target, fragment string, ok bool) {
+	u, err := url.Parse(link.URL)
+	if err != nil || u.Scheme != "" || u.Host != "" {
+		return "", "", false
+	}
+
+	switch {
+	case u.Path == "":
+		target = link.FilePath
+	case strings.HasPrefix(u.Path, "/"):
+		target = filepath.Join(root, filepath.FromSlash(u.Path))
+	default:
+		target = filepath.Join(filepath.Dir(link.FilePath), filepath.FromSlash(u.Path))
+	}
+	return target, u.Fragment, true
+}
@@ -0,0 +1,58 @@
+package relative
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/leonardomso/gone/internal/parser"
+)
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "docs", "img"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "README.md"), []byte("# Project\n\n## Usage\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "docs", "setup.md"), []byte("# Setup\n\n## Install\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "docs", "data.txt"), []byte("data"), 0o600))
+
+	readme := filepath.Join(root, "README.md")
+	links := []parser.Link{
+		{URL: "./docs/setup.md", FilePath: readme, Line: 1},
+		{URL: "docs/setup.md#install", FilePath: readme, Line: 2},
+		{URL: "docs/setup.md#Install", FilePath: readme, Line: 3},
+		{URL: "#usage", FilePath: readme, Line: 4},
+		{URL: "/docs/img", FilePath: readme, Line: 5},
+		{URL: "docs/data.txt#L1", FilePath: readme, Line: 6},
+		{URL: "docs/missing.md", FilePath: readme, Line: 7},
+		{URL: "docs/setup.md#instal", FilePath: readme, Line: 8},
+		{URL: "#nowhere", FilePath: readme, Line: 9},
+		{URL: "/docs/gone.md?raw=1", FilePath: readme, Line: 10},
+		{URL: "https://example.com/missing.md", FilePath: readme, Line: 11},
+	}
+
+	findings := Check(root, links)
+	require.Len(t, findings, 4)
+
+	assert.Equal(t, ProblemMissingFile, findings[0].Problem)
+	assert.Equal(t, 7, findings[0].Link.Line)
+	assert.Equal(t, filepath.Join(root, "docs", "missing.md"), findings[0].Target)
+	assert.Contains(t, findings[0].Message, "does not exist")
+
+	assert.Equal(t, ProblemMissingAnchor, findings[1].Problem)
+	assert.Equal(t, "instal", findings[1].Anchor)
+	assert.Equal(t, "install", findings[1].Closest)
+	assert.Contains(t, findings[1].Message, "(closest: #install)")
+	assert.Equal(t, 8, findings[1].Link.Line)
+
+	assert.Equal(t, ProblemMissingAnchor, findings[2].Problem)
+	assert.Equal(t, readme, findings[2].Target)
+	assert.Equal(t, "nowhere", findings[2].Anchor)
+	assert.Empty(t, findings[2].Closest)
+
+	assert.Equal(t, ProblemMissingFile, findings[3].Problem)
+	assert.Equal(t, filepath.Join(root, "docs", "gone.md"), findings[3].Target)
+}
@@ -1,20 +1,30 @@
-// Package scanner finds files in a directory based on their extensions.
+// Package scanner finds files in a directory, or in a zip or tar archive,
+// based on their extensions or names.
 package scanner
 
 import (
+	"log/slog"
 	"os"
 	"path/filepath"
 	"slices"
 	"strings"
 
 	"github.com/gobwas/glob"
+	"github.com/leonardomso/gone/internal/archive"
 )
 
 // FindFiles walks a directory and returns all files matching the given extensions.
 // Extensions should include the leading dot (e.g., ".md", ".json").
 // It skips hidden directories (starting with .) like .git.
 func FindFiles(root string, extensions []string) ([]string, error) {
-	if len(extensions) == 0 {
+	return findFiles(root, extensions, nil)
+}
+
+// findFiles walks a directory and returns all files matching the given
+// extensions or whose base name matches one of the lowercase filepath.Match
+// patterns in names, such as "dockerfile".
+func findFiles(root string, extensions, names []string) ([]string, error) {
+	if len(extensions) == 0 && len(names) == 0 {
 		return nil, nil
 	}
 
@@ -24,6 +34,13 @@ func FindFiles(root string, extensions []string) ([]string, error) {
 		normalizedExts[strings.ToLower(ext)] = true
 	}
 
+	// Archives are scanned like directories, by the paths of their files
+	if archive.IsArchive(root) {
+		if info, err := os.Stat(root); err == nil && !info.IsDir() {
+			return findArchiveFiles(root, normalizedExts, names)
+		}
+	}
+
 	var files []string
 
 	// filepath.WalkDir traverses a directory tree
@@ -37,6 +54,7 @@ func FindFiles(root string, extensions []string) ([]string, error) {
 		// Skip hidden directories (like .git, .github, etc.)
 		// d.IsDir() returns true if this entry is a directory
 		if d.IsDir() && strings.HasPrefix(d.Name(), ".") && path != root {
+			slog.Debug("skipping hidden directory", "dir", path)
 			// filepath.SkipDir tells WalkDir to skip this entire directory
 			return filepath.SkipDir
 		}
@@ -44,7 +62,8 @@ func FindFiles(root string, extensions []string) ([]string, error) {
 		// Check if this file has a matching extension
 		if !d.IsDir() {
 			ext := strings.ToLower(filepath.Ext(d.Name()))
-			if normalizedExts[ext] {
+			if normalizedExts[ext] || matchesName(d.Name(), names) {
+				slog.Debug("found file", "file", path)
 				files = append(files, path)
 			}
 		}
@@ -60,12 +79,43 @@ func FindFiles(root string, extensions []string) ([]string, error) {
 	return files, nil
 }
 
+// findArchiveFiles returns the files in an archive matching the given
+// extensions or name patterns, skipping hidden directories like findFiles.
+func findArchiveFiles(root string, extensions map[string]bool, names []string) ([]string, error) {
+	members, err := archive.Files(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, member := range members {
+		_, name, _ := archive.Split(member)
+		dirs := strings.Split(name, "/")
+		base := dirs[len(dirs)-1]
+		hidden := slices.ContainsFunc(dirs[:len(dirs)-1], func(dir string) bool {
+			return strings.HasPrefix(dir, ".")
+		})
+		if hidden {
+			continue
+		}
+		if extensions[strings.ToLower(filepath.Ext(base))] || matchesName(base, names) {
+			files = append(files, member)
+		}
+	}
+	return files, nil
+}
+
 // FindFilesByTypes walks a directory and returns all files matching the given type names.
 // Type names are without the leading dot (e.g., "md", "json", "yaml").
 // It skips hidden directories (starting with .) like .git.
 func FindFilesByTypes(root string, types []string) ([]string, error) {
+	return FindFiles(root, typeExtensions(types))
+}
+
+// typeExtensions converts type names to the extensions they match.
+func typeExtensions(types []string) []string {
 	if len(types) == 0 {
-		return nil, nil
+		return nil
 	}
 
 	// Convert type names to extensions
@@ -91,8 +141,21 @@ func FindFilesByTypes(root string, types []string) ([]string, error) {
 	if slices.Contains(types, "md") {
 		extensions = append(extensions, ".mdx", ".markdown")
 	}
+	if slices.Contains(types, "html") {
+		extensions = append(extensions, ".htm")
+	}
+	return extensions
+}
 
-	return FindFiles(root, extensions)
+// matchesName reports whether a file's base name matches one of patterns.
+func matchesName(name string, patterns []string) bool {
+	name = strings.ToLower(name)
+	for _, pattern := range patterns {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
 }
 
 // ScanOptions holds options for scanning files with filtering.
@@ -103,6 +166,10 @@ type ScanOptions struct {
 	// Types are the file types to include (e.g., "md", "json", "yaml").
 	Types []string
 
+	// Filenames are lowercase base name patterns (filepath.Match syntax) of
+	// files to include regardless of extension, e.g. "dockerfile".
+	Filenames []string
+
 	// Include patterns (glob) - if set, only matching files are included.
 	Include []string
 
@@ -114,7 +181,7 @@ type ScanOptions struct {
 // This is the recommended function for scanning with full configuration support.
 func FindFilesWithOptions(opts ScanOptions) ([]string, error) {
 	// Get base files by type
-	files, err := FindFilesByTypes(opts.Root, opts.Types)
+	files, err := findFiles(opts.Root, typeExtensions(opts.Types), opts.Filenames)
 	if err != nil {
 		return nil, err
 	}
@@ -138,6 +205,68 @@ func FindFilesWithOptions(opts ScanOptions) ([]string, error) {
 	return files, nil
 }
 
+// Matches reports whether FindFilesWithOptions would find the file at path:
+// it has one of the types or file names, isn't in a hidden directory under
+// Root, and passes the include and exclude patterns. The file itself doesn't
+// have to exist, so removed files can be matched too.
+func (opts ScanOptions) Matches(path string) bool {
+	if path != opts.Root {
+		rel, err := filepath.Rel(opts.Root, path)
+		if err != nil || !filepath.IsLocal(rel) {
+			return false
+		}
+		dirs := strings.Split(filepath.ToSlash(rel), "/")
+		if slices.ContainsFunc(dirs[:len(dirs)-1], func(dir string) bool {
+			return strings.HasPrefix(dir, ".")
+		}) {
+			return false
+		}
+	}
+
+	name := filepath.Base(path)
+	ext := strings.ToLower(filepath.Ext(name))
+	if !slices.ContainsFunc(typeExtensions(opts.Types), func(e string) bool {
+		return strings.ToLower(e) == ext
+	}) && !matchesName(name, opts.Filenames) {
+		return false
+	}
+
+	files, err := filterByGlobPatterns([]string{path}, opts.Root, opts.Include, true)
+	if err != nil {
+		return false
+	}
+	files, err = filterByGlobPatterns(files, opts.Root, opts.Exclude, false)
+	return err == nil && len(files) == 1
+}
+
+// Prioritize moves files matching any of the glob patterns to the front,
+// keeping the scan order within both groups. Patterns match paths relative
+// to root, as with ScanOptions.Include. It also returns the set of matching files.
+func Prioritize(files []string, root string, patterns []string) ([]string, map[string]bool, error) {
+	if len(patterns) == 0 {
+		return files, nil, nil
+	}
+
+	matched, err := filterByGlobPatterns(files, root, patterns, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	priority := make(map[string]bool, len(matched))
+	for _, f := range matched {
+		priority[f] = true
+	}
+
+	ordered := make([]string, 0, len(files))
+	ordered = append(ordered, matched...)
+	for _, f := range files {
+		if !priority[f] {
+			ordered = append(ordered, f)
+		}
+	}
+	return ordered, priority, nil
+}
+
 // filterByGlobPatterns filters files by glob patterns.
 // If include=true, keeps only files matching any pattern.
 // If include=false, removes files matching any pattern.
@@ -165,15 +294,22 @@ func filterByGlobPatterns(files []string, root string, patterns []string, includ
 		}
 		// Normalize path separators for cross-platform glob matching
 		relPath = filepath.ToSlash(relPath)
+		// Files in an archive are relative to it
+		if archivePath, member, ok := archive.Split(f); ok && archivePath == root {
+			relPath = member
+		}
 
 		matches := matchesAnyGlob(relPath, compiled)
 
 		// For include mode: keep files that match any pattern
 		// For exclude mode: keep files that don't match any pattern
-		if include && matches {
-			result = append(result, f)
-		} else if !include && !matches {
+		switch {
+		case include == matches:
 			result = append(result, f)
+		case include:
+			slog.Debug("skipping file not matching include patterns", "file", f)
+		default:
+			slog.Debug("skipping file matching exclude patterns", "file", f)
 		}
 	}
 
@@ -1,6 +1,7 @@
 package scanner
 
 import (
+	"archive/zip"
 	"os"
 	"path/filepath"
 	"sort"
@@ -184,6 +185,19 @@ func TestFindFilesByTypes(t *testing.T) {
 		assert.Equal(t, []string{".yaml", ".yml"}, extensions)
 	})
 
+	t.Run("HTMLTypeIncludesHTM", func(t *testing.T) {
+		t.Parallel()
+		tmpDir := t.TempDir()
+		err := os.WriteFile(filepath.Join(tmpDir, "index.html"), []byte("<p></p>"), 0o644)
+		require.NoError(t, err)
+		err = os.WriteFile(filepath.Join(tmpDir, "legacy.htm"), []byte("<p></p>"), 0o644)
+		require.NoError(t, err)
+
+		files, err := FindFilesByTypes(tmpDir, []string{"html"})
+		require.NoError(t, err)
+		assert.Len(t, files, 2)
+	})
+
 	t.Run("MultipleTypes", func(t *testing.T) {
 		t.Parallel()
 		// Create temp directory with various file types
@@ -250,6 +264,26 @@ func TestFindFilesWithOptions(t *testing.T) {
 		assert.Len(t, files, 2)
 	})
 
+	t.Run("WithFilenames", func(t *testing.T) {
+		t.Parallel()
+		tmpDir := t.TempDir()
+		for _, name := range []string{"Dockerfile", "Dockerfile.dev", "notes.md", "main.go"} {
+			require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name), []byte("x"), 0o644))
+		}
+
+		files, err := FindFilesWithOptions(ScanOptions{
+			Root:      tmpDir,
+			Types:     []string{"md"},
+			Filenames: []string{"dockerfile", "dockerfile.*"},
+		})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{
+			filepath.Join(tmpDir, "Dockerfile"),
+			filepath.Join(tmpDir, "Dockerfile.dev"),
+			filepath.Join(tmpDir, "notes.md"),
+		}, files)
+	})
+
 	t.Run("WithIncludePattern", func(t *testing.T) {
 		t.Parallel()
 		// Create temp structure: docs/readme.md, src/code.md
@@ -390,3 +424,90 @@ func TestFindFilesWithOptions(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestFindFilesWithOptions_Archive(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "docs.zip")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	w := zip.NewWriter(f)
+	for _, name := range []string{"README.md", "guide/intro.md", "guide/api.json", ".github/notes.md", "drafts/wip.md"} {
+		_, err := w.Create(name)
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+
+	// Hidden directories are skipped, and patterns match paths in the archive
+	files, err := FindFilesWithOptions(ScanOptions{Root: path, Types: []string{"md"}, Exclude: []string{"drafts/**"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{path + "!/README.md", path + "!/guide/intro.md"}, files)
+}
+
+func TestPrioritize(t *testing.T) {
+	t.Parallel()
+
+	root := "repo"
+	files := []string{
+		filepath.Join(root, "CHANGELOG.md"),
+		filepath.Join(root, "README.md"),
+		filepath.Join(root, "blog", "post.md"),
+		filepath.Join(root, "docs", "a.md"),
+		filepath.Join(root, "docs", "guide", "b.md"),
+	}
+
+	ordered, priority, err := Prioritize(files, root, []string{"README.md", "docs/**"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{files[1], files[3], files[4], files[0], files[2]}, ordered)
+	assert.Len(t, priority, 3)
+	assert.True(t, priority[files[1]])
+	assert.False(t, priority[files[0]])
+
+	// No patterns leaves the order alone
+	ordered, priority, err = Prioritize(files, root, nil)
+	require.NoError(t, err)
+	assert.Equal(t, files, ordered)
+	assert.Empty(t, priority)
+
+	_, _, err = Prioritize(files, root, []string{"[invalid"})
+	require.Error(t, err)
+}
+
+func TestScanOptions_Matches(t *testing.T) {
+	t.Parallel()
+
+	root := filepath.Join("testdata", "docs")
+	opts := ScanOptions{
+		Root:      root,
+		Types:     []string{"md", "yaml"},
+		Filenames: []string{"dockerfile"},
+		Exclude:   []string{"vendor/**"},
+	}
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{filepath.Join(root, "README.md"), true},
+		{filepath.Join(root, "guide", "Intro.MARKDOWN"), true},
+		{filepath.Join(root, "config.yml"), true},
+		{filepath.Join(root, "Dockerfile"), true},
+		{filepath.Join(root, "main.go"), false},
+		{filepath.Join(root, ".github", "README.md"), false},
+		{filepath.Join(root, "vendor", "lib", "README.md"), false},
+		{filepath.Join("testdata", "other.md"), false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, opts.Matches(tt.path), tt.path)
+	}
+
+	opts.Include = []string{"guide/**"}
+	assert.False(t, opts.Matches(filepath.Join(root, "README.md")))
+	assert.True(t, opts.Matches(filepath.Join(root, "guide", "setup.md")))
+
+	// A single file root matches itself
+	single := ScanOptions{Root: "README.md", Types: []string{"md"}}
+	assert.True(t, single.Matches("README.md"))
+	assert.False(t, single.Matches("CHANGELOG.md"))
+}
@@ -0,0 +1,284 @@
+// Package server serves link checks over HTTP, for gone serve. POST /check
+// runs a check, GET /reports/latest returns the report of the last one, and
+// GET / shows it as a dashboard. Refresh replaces that report with the one
+// of a recheck.
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/leonardomso/gone/internal/output"
+)
+
+// maxRequestBody caps the body of POST /check.
+const maxRequestBody = 1 << 20
+
+// maxChecks caps the checks of POST /check running at once. Each already
+// checks its links concurrently; more are answered with 503.
+const maxChecks = 1
+
+// ErrInvalidRequest marks errors caused by the request, such as a path
+// that doesn't exist, which are answered with 400 Bad Request.
+var ErrInvalidRequest = errors.New("invalid request")
+
+// Request is what POST /check checks: either URLs, or the files under Path.
+type Request struct {
+	URLs []string `json:"urls,omitempty"`
+	Path string   `json:"path,omitempty"`
+}
+
+// Runner runs the check of a request and returns its report.
+type Runner func(ctx context.Context, req Request) (*output.Report, error)
+
+// Server keeps the request and the report of the last check.
+type Server struct {
+	run    Runner
+	slots  chan struct{} // Checks of POST /check running
+	mu     sync.Mutex
+	last   *Request
+	latest *output.Report
+}
+
+// New creates a Server running checks with run.
+func New(run Runner) *Server {
+	return &Server{run: run, slots: make(chan struct{}, maxChecks)}
+}
+
+// Handler returns the HTTP handler of the server's endpoints.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /check", s.handleCheck)
+	mux.HandleFunc("GET /reports/latest", s.handleLatest)
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = io.WriteString(w, "ok\n")
+	})
+	mux.HandleFunc("GET /{$}", s.handleDashboard)
+	return mux
+}
+
+// Check runs a check and keeps its report as the latest.
+func (s *Server) Check(ctx context.Context, req Request) (*output.Report, error) {
+	report, err := s.run(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.last, s.latest = &req, report
+	s.mu.Unlock()
+	return report, nil
+}
+
+// Last returns the request of the last check, or nil before the first.
+func (s *Server) Last() *Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.last
+}
+
+// Refresh makes report, of a new check of req as returned by Last, the
+// latest, unless another check ran since, and reports whether it did.
+func (s *Server) Refresh(req *Request, report *output.Report) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if req == nil || req != s.last {
+		return false
+	}
+	s.latest = report
+	return true
+}
+
+// Latest returns the report of the last check, or nil before the first.
+func (s *Server) Latest() *output.Report {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.latest
+}
+
+// handleCheck runs the check of the request body and answers with its
+// report, as JSON or in the format named by the format query parameter.
+func (s *Server) handleCheck(w http.ResponseWriter, r *http.Request) {
+	format, err := queryFormat(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	req, err := parseRequest(http.MaxBytesReader(w, r.Body, maxRequestBody))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	select {
+	case s.slots <- struct{}{}:
+		defer func() { <-s.slots }()
+	default:
+		writeError(w, http.StatusServiceUnavailable, errors.New("a check is already running; try again later"))
+		return
+	}
+
+	report, err := s.Check(r.Context(), req)
+	switch {
+	case errors.Is(err, ErrInvalidRequest):
+		writeError(w, http.StatusBadRequest, err)
+		return
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeReport(w, report, format)
+}
+
+// handleLatest answers with the report of the last check, as JSON or in
+// the format named by the format query parameter.
+func (s *Server) handleLatest(w http.ResponseWriter, r *http.Request) {
+	format, err := queryFormat(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	report := s.Latest()
+	if report == nil {
+		writeError(w, http.StatusNotFound, errors.New("no check has run yet"))
+		return
+	}
+	writeReport(w, report, format)
+}
+
+// handleDashboard shows the HTML report of the last check.
+func (s *Server) handleDashboard(w http.ResponseWriter, r *http.Request) {
+	report := s.Latest()
+	if report == nil {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_ = emptyDashboard.Execute(w, r.Host)
+		return
+	}
+	writeReport(w, report, output.FormatHTML)
+}
+
+// emptyDashboard is shown before the first check.
+var emptyDashboard = template.Must(template.New("empty").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>gone</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2rem; color: #222; }
+code { background: #f4f4f4; padding: 0.1rem 0.3rem; }
+</style>
+</head>
+<body>
+<h1>gone</h1>
+<p>No check has run yet. Start one with:</p>
+<pre><code>curl -X POST -d '{"path": "."}' http://{{.}}/check</code></pre>
+</body>
+</html>
+`))
+
+// parseRequest reads a check request: a JSON object with "urls" or "path",
+// a JSON array of URLs, or plain text with a URL per line. Paths must be
+// relative and stay inside the served directory.
+func parseRequest(body io.Reader) (Request, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return Request{}, fmt.Errorf("reading request: %w", err)
+	}
+	data = bytes.TrimSpace(data)
+
+	var req Request
+	switch {
+	case len(data) == 0:
+		return Request{}, errors.New(`empty request; send {"urls": [...]} or {"path": "..."}`)
+	case data[0] == '{':
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&req); err != nil {
+			return Request{}, fmt.Errorf("decoding request: %w", err)
+		}
+	case data[0] == '[':
+		if err := json.Unmarshal(data, &req.URLs); err != nil {
+			return Request{}, fmt.Errorf("decoding request: %w", err)
+		}
+	default:
+		for line := range strings.Lines(string(data)) {
+			if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+				req.URLs = append(req.URLs, line)
+			}
+		}
+	}
+
+	switch {
+	case len(req.URLs) > 0 && req.Path != "":
+		return Request{}, errors.New(`send either "urls" or "path", not both`)
+	case len(req.URLs) == 0 && req.Path == "":
+		return Request{}, errors.New(`nothing to check; send {"urls": [...]} or {"path": "..."}`)
+	case req.Path != "" && !filepath.IsLocal(filepath.FromSlash(req.Path)):
+		return Request{}, fmt.Errorf("path %q must be relative and inside the served directory", req.Path)
+	}
+	for _, u := range req.URLs {
+		if !strings.HasPrefix(u, "http://") && !strings.HasPrefix(u, "https://") {
+			return Request{}, fmt.Errorf("%q is not an http:// or https:// URL", u)
+		}
+	}
+	return req, nil
+}
+
+// queryFormat returns the report format named by the format query
+// parameter, JSON by default.
+func queryFormat(r *http.Request) (output.Format, error) {
+	name := r.URL.Query().Get("format")
+	if name == "" {
+		return output.FormatJSON, nil
+	}
+	format := output.Format(strings.ToLower(name))
+	if !output.IsValidFormat(name) || format == output.FormatTemplate {
+		return "", fmt.Errorf("unsupported format %q", name)
+	}
+	return format, nil
+}
+
+// contentTypes are the media types of the report formats other than plain
+// text.
+var contentTypes = map[output.Format]string{
+	output.FormatJSON:       "application/json",
+	output.FormatShieldJSON: "application/json",
+	output.FormatYAML:       "application/yaml",
+	output.FormatXML:        "application/xml",
+	output.FormatJUnit:      "application/xml",
+	output.FormatHTML:       "text/html; charset=utf-8",
+	output.FormatMarkdown:   "text/markdown; charset=utf-8",
+	output.FormatCSV:        "text/csv; charset=utf-8",
+	output.FormatNDJSON:     "application/x-ndjson",
+}
+
+// writeReport writes a report in format.
+func writeReport(w http.ResponseWriter, report *output.Report, format output.Format) {
+	data, err := output.FormatReport(report, format)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	contentType, ok := contentTypes[format]
+	if !ok {
+		contentType = "text/plain; charset=utf-8"
+	}
+	w.Header().Set("Content-Type", contentType)
+	_, _ = w.Write(data)
+}
+
+// writeError answers with status and a JSON error message.
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,209 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/leonardomso/gone/internal/checker"
+	"github.com/leonardomso/gone/internal/output"
+)
+
+// fakeRun reports every requested URL as alive, and fails for a path
+// named "missing".
+func fakeRun(_ context.Context, req Request) (*output.Report, error) {
+	if req.Path == "missing" {
+		return nil, fmt.Errorf("%w: no such file or directory: %s", ErrInvalidRequest, req.Path)
+	}
+	var results []checker.Result
+	for i, u := range req.URLs {
+		results = append(results, checker.Result{
+			Link:   checker.Link{URL: u, FilePath: "request", Line: i + 1},
+			Status: checker.StatusAlive,
+		})
+	}
+	return &output.Report{Results: results, Summary: checker.Summarize(results)}, nil
+}
+
+// do sends a request to the server's handler.
+func do(t *testing.T, s *Server, method, target, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, httptest.NewRequest(method, target, strings.NewReader(body)))
+	return rec
+}
+
+func TestParseRequest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		body    string
+		want    Request
+		wantErr string
+	}{
+		{
+			name: "object with urls",
+			body: `{"urls": ["https://a.com", "http://b.com"]}`,
+			want: Request{URLs: []string{"https://a.com", "http://b.com"}},
+		},
+		{name: "object with path", body: `{"path": "docs/guide"}`, want: Request{Path: "docs/guide"}},
+		{name: "array", body: ` ["https://a.com"]`, want: Request{URLs: []string{"https://a.com"}}},
+		{
+			name: "plain text",
+			body: "# links\nhttps://a.com\n\n  https://b.com  \n",
+			want: Request{URLs: []string{"https://a.com", "https://b.com"}},
+		},
+		{name: "empty", body: " \n", wantErr: "empty request"},
+		{name: "unknown field", body: `{"url": "https://a.com"}`, wantErr: "unknown field"},
+		{name: "both", body: `{"urls": ["https://a.com"], "path": "."}`, wantErr: "not both"},
+		{name: "neither", body: `{}`, wantErr: "nothing to check"},
+		{name: "path outside", body: `{"path": "../etc"}`, wantErr: "inside the served directory"},
+		{name: "absolute path", body: `{"path": "/etc"}`, wantErr: "inside the served directory"},
+		{name: "not http", body: "ftp://a.com", wantErr: "not an http"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req, err := parseRequest(strings.NewReader(tt.body))
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, req)
+		})
+	}
+}
+
+func TestServer_Check(t *testing.T) {
+	t.Parallel()
+
+	s := New(fakeRun)
+
+	rec := do(t, s, http.MethodGet, "/reports/latest", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	rec = do(t, s, http.MethodGet, "/", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "No check has run yet")
+
+	rec = do(t, s, http.MethodPost, "/check", "https://a.com\nhttps://b.com\n")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var report struct {
+		Results []struct {
+			URL string `json:"url"`
+		} `json:"results"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+	require.Len(t, report.Results, 2)
+	assert.Equal(t, "https://a.com", report.Results[0].URL)
+
+	// The report is kept as the latest, and shown on the dashboard
+	require.NotNil(t, s.Latest())
+	assert.Len(t, s.Latest().Results, 2)
+	rec = do(t, s, http.MethodGet, "/reports/latest?format=markdown", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/markdown")
+	rec = do(t, s, http.MethodGet, "/", "")
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/html")
+	assert.Contains(t, rec.Body.String(), "<html")
+	assert.NotContains(t, rec.Body.String(), "No check has run yet")
+}
+
+func TestServer_Errors(t *testing.T) {
+	t.Parallel()
+
+	s := New(fakeRun)
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		want   int
+	}{
+		{"bad request", http.MethodPost, "/check", `{"path": "../x"}`, http.StatusBadRequest},
+		{"invalid path", http.MethodPost, "/check", `{"path": "missing"}`, http.StatusBadRequest},
+		{"unknown format", http.MethodPost, "/check?format=pdf", `["https://a.com"]`, http.StatusBadRequest},
+		{"template format", http.MethodPost, "/check?format=template", `["https://a.com"]`, http.StatusBadRequest},
+		{"wrong method", http.MethodGet, "/check", "", http.StatusMethodNotAllowed},
+		{"unknown path", http.MethodGet, "/nope", "", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := do(t, s, tt.method, tt.target, tt.body)
+			assert.Equal(t, tt.want, rec.Code)
+		})
+	}
+}
+
+func TestServer_RunnerError(t *testing.T) {
+	t.Parallel()
+
+	s := New(func(context.Context, Request) (*output.Report, error) {
+		return nil, errors.New("boom")
+	})
+	rec := do(t, s, http.MethodPost, "/check", "https://a.com")
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.JSONEq(t, `{"error": "boom"}`, rec.Body.String())
+}
+
+func TestServer_Busy(t *testing.T) {
+	t.Parallel()
+
+	started, release := make(chan struct{}), make(chan struct{})
+	s := New(func(ctx context.Context, req Request) (*output.Report, error) {
+		close(started)
+		<-release
+		return fakeRun(ctx, req)
+	})
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- do(t, s, http.MethodPost, "/check", "https://a.com") }()
+	<-started
+
+	rec := do(t, s, http.MethodPost, "/check", "https://b.com")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Contains(t, rec.Body.String(), "already running")
+
+	close(release)
+	assert.Equal(t, http.StatusOK, (<-done).Code)
+}
+
+func TestServer_Refresh(t *testing.T) {
+	t.Parallel()
+
+	s := New(fakeRun)
+	assert.Nil(t, s.Last())
+	assert.False(t, s.Refresh(nil, &output.Report{}))
+
+	_, err := s.Check(context.Background(), Request{URLs: []string{"https://a.com"}})
+	require.NoError(t, err)
+	last := s.Last()
+	require.NotNil(t, last)
+	assert.Equal(t, []string{"https://a.com"}, last.URLs)
+
+	rechecked := &output.Report{TotalLinks: 1}
+	assert.True(t, s.Refresh(last, rechecked))
+	assert.Same(t, rechecked, s.Latest())
+
+	// A recheck finishing after a newer check doesn't replace its report
+	_, err = s.Check(context.Background(), Request{URLs: []string{"https://b.com"}})
+	require.NoError(t, err)
+	assert.False(t, s.Refresh(last, rechecked))
+	assert.NotSame(t, rechecked, s.Latest())
+}
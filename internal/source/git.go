@@ -0,0 +1,47 @@
+package source
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// repoURLPrefixes are the prefixes of repository URLs that git clones.
+var repoURLPrefixes = []string{"https://", "http://", "ssh://", "git://", "file://", "git@"}
+
+// IsRepoURL reports whether arg names a remote git repository rather than
+// a local path, such as https://github.com/org/repo or git@host:org/repo.
+func IsRepoURL(arg string) bool {
+	for _, prefix := range repoURLPrefixes {
+		if strings.HasPrefix(arg, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Clone shallow-clones the default branch of the repository at repoURL
+// into a new temporary directory and returns the directory. The caller
+// removes it. Git must be installed; it never prompts for credentials.
+func Clone(ctx context.Context, repoURL string) (string, error) {
+	dir, err := os.MkdirTemp("", "gone-repo-")
+	if err != nil {
+		return "", fmt.Errorf("cloning %s: %w", repoURL, err)
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "clone", "--depth=1", "--quiet", "--", repoURL, dir)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		_ = os.RemoveAll(dir)
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("cloning %s: %w: %s", repoURL, err, msg)
+		}
+		return "", fmt.Errorf("cloning %s: %w", repoURL, err)
+	}
+	return dir, nil
+}
@@ -0,0 +1,60 @@
+package source
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRepoURL(t *testing.T) {
+	t.Parallel()
+
+	for _, arg := range []string{"https://github.com/org/repo", "git@github.com:org/repo.git", "ssh://host/repo"} {
+		assert.True(t, IsRepoURL(arg), arg)
+	}
+	for _, arg := range []string{".", "docs", "/tmp/repo", "docs.zip"} {
+		assert.False(t, IsRepoURL(arg), arg)
+	}
+}
+
+func TestClone(t *testing.T) {
+	t.Parallel()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	repo := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", repo, "-c", "user.name=gone", "-c", "user.email=gone@example.com"},
+			args...)...)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	git("init", "--quiet")
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "README.md"), []byte("[Docs](https://example.com)\n"), 0o600))
+	git("add", "README.md")
+	git("commit", "--quiet", "-m", "Add README")
+
+	t.Run("Clones", func(t *testing.T) {
+		t.Parallel()
+		dir, err := Clone(context.Background(), "file://"+repo)
+		require.NoError(t, err)
+		defer func() { _ = os.RemoveAll(dir) }()
+
+		content, err := os.ReadFile(filepath.Join(dir, "README.md"))
+		require.NoError(t, err)
+		assert.Equal(t, "[Docs](https://example.com)\n", string(content))
+	})
+
+	t.Run("Fails", func(t *testing.T) {
+		t.Parallel()
+		_, err := Clone(context.Background(), "file://"+filepath.Join(repo, "missing"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cloning")
+	})
+}
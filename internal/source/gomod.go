@@ -0,0 +1,140 @@
+package source
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// DefaultGoProxy is the module proxy go.mod repositories are looked up in
+// when GOPROXY doesn't name one.
+const DefaultGoProxy = "https://proxy.golang.org"
+
+// maxProxyBytes caps how much of a module proxy answer is read.
+const maxProxyBytes = 1 << 20
+
+// goModFields returns the repository and documentation URLs of the module
+// a go.mod declares. The repository comes from the module proxy's @latest
+// metadata, or from the module path on GitHub, GitLab, and Bitbucket.
+func goModFields(ctx context.Context, client *http.Client, data []byte) ([]field, error) {
+	module, err := modulePath(data)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := ""
+	if proxy := goProxy(); client != nil && proxy != "" {
+		repo = proxyRepository(ctx, client, proxy, module)
+	}
+	if repo == "" {
+		repo = hostedRepository(module)
+	}
+	return nonEmpty([]field{
+		{name: "repository", value: repo, at: " " + module},
+		{name: "documentation", value: "https://pkg.go.dev/" + module, at: " " + module},
+	}), nil
+}
+
+// modulePath returns the path of a go.mod's module directive.
+func modulePath(data []byte) (string, error) {
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		rest, ok := strings.CutPrefix(line, "module")
+		if !ok || rest == "" || (rest[0] != ' ' && rest[0] != '\t') {
+			continue
+		}
+		rest, _, _ = strings.Cut(rest, "//")
+		path := strings.TrimSpace(rest)
+		if unquoted, err := strconv.Unquote(path); err == nil {
+			path = unquoted
+		}
+		if path != "" {
+			return path, nil
+		}
+	}
+	return "", errors.New("no module directive")
+}
+
+// proxyRepository returns the VCS URL the module proxy reports for the
+// latest version of module, or "" if the proxy doesn't know it.
+func proxyRepository(ctx context.Context, client *http.Client, proxy, module string) string {
+	url := proxy + "/" + escapeModulePath(module) + "/@latest"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return ""
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return ""
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return ""
+	}
+
+	var info struct {
+		Origin struct {
+			URL string `json:"URL"`
+		} `json:"Origin"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxProxyBytes)).Decode(&info); err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(info.Origin.URL, ".git")
+}
+
+// goProxy returns the first http(s) proxy in GOPROXY, or DefaultGoProxy.
+// It returns "" if GOPROXY is "off".
+func goProxy() string {
+	if os.Getenv("GOPROXY") == "off" {
+		return ""
+	}
+	for _, entry := range strings.FieldsFunc(os.Getenv("GOPROXY"), func(r rune) bool {
+		return r == ',' || r == '|'
+	}) {
+		if strings.HasPrefix(entry, "http://") || strings.HasPrefix(entry, "https://") {
+			return strings.TrimSuffix(entry, "/")
+		}
+	}
+	return DefaultGoProxy
+}
+
+// escapeModulePath escapes a module path for proxy URLs, which write each
+// upper-case letter as '!' and its lower-case form.
+func escapeModulePath(module string) string {
+	var b strings.Builder
+	for _, r := range module {
+		if r >= 'A' && r <= 'Z' {
+			b.WriteByte('!')
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// hostedRepository returns the repository URL of a module hosted on GitHub,
+// GitLab, or Bitbucket, whose path starts with host/owner/repo.
+func hostedRepository(module string) string {
+	parts := strings.Split(module, "/")
+	if len(parts) < 3 {
+		return ""
+	}
+	switch parts[0] {
+	case "github.com", "gitlab.com", "bitbucket.org":
+		return fmt.Sprintf("https://%s/%s/%s", parts[0], parts[1], parts[2])
+	default:
+		return ""
+	}
+}
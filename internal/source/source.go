@@ -0,0 +1,306 @@
+// Package source loads links from places other than a scanned directory:
+// a remote link list or the metadata URLs of a package manifest. It also
+// clones remote repositories so they can be scanned like a directory.
+package source
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/leonardomso/gone/internal/parser"
+)
+
+// maxListBytes caps how much of a remote link list is read.
+const maxListBytes = 10 << 20
+
+// FromURL fetches a plain-text link list: one URL per line. Blank lines,
+// lines starting with '#', and lines that aren't http(s) URLs are skipped.
+// Links point back at listURL and their line in the list.
+func FromURL(ctx context.Context, client *http.Client, listURL string) ([]parser.Link, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, listURL, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("fetching link list: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching link list: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("fetching link list %s: HTTP %d", listURL, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxListBytes))
+	if err != nil {
+		return nil, fmt.Errorf("reading link list: %w", err)
+	}
+	return ParseList(listURL, data), nil
+}
+
+// ParseList extracts links from a plain-text link list read from origin.
+func ParseList(origin string, data []byte) []parser.Link {
+	var links []parser.Link
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 0, 64*1024), 1<<20)
+
+	line := 0
+	for sc.Scan() {
+		line++
+		text := strings.TrimSpace(sc.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		// Allow trailing notes after the URL ("https://x.com  main site")
+		url := strings.Fields(text)[0]
+		if !parser.IsHTTPURL(url) {
+			continue
+		}
+		links = append(links, parser.Link{
+			URL:      url,
+			FilePath: origin,
+			Line:     line,
+			Type:     parser.LinkTypeAutolink,
+		})
+	}
+	return links
+}
+
+// Manifests lists the file names FromManifest understands.
+var Manifests = []string{"package.json", "pyproject.toml", "Cargo.toml", "composer.json", "go.mod"}
+
+// FromManifest reads the project URLs (homepage, repository, docs, ...) from a
+// package manifest, or from every manifest in path if it's a directory. Each
+// link's text is the manifest field it came from.
+//
+// A go.mod has no URL fields: its repository is looked up in the module
+// proxy's metadata with client, falling back to the module path for the
+// well-known code hosts. A nil client skips the lookup.
+func FromManifest(ctx context.Context, client *http.Client, path string) ([]parser.Link, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+	if !info.IsDir() {
+		return manifestLinks(ctx, client, path)
+	}
+
+	var links []parser.Link
+	found := false
+	for _, name := range Manifests {
+		manifest := filepath.Join(path, name)
+		if _, err := os.Stat(manifest); err != nil {
+			continue
+		}
+		found = true
+		manifestLinks, err := manifestLinks(ctx, client, manifest)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, manifestLinks...)
+	}
+	if !found {
+		return nil, fmt.Errorf("no manifest in %s: looked for %s", path, strings.Join(Manifests, ", "))
+	}
+	return links, nil
+}
+
+// manifestLinks reads the project URLs of the manifest file at path.
+func manifestLinks(ctx context.Context, client *http.Client, path string) ([]parser.Link, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // Path comes from the user
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+
+	var fields []field
+	switch filepath.Base(path) {
+	case "package.json":
+		fields, err = packageJSONFields(data)
+	case "pyproject.toml":
+		fields, err = pyprojectFields(data)
+	case "Cargo.toml":
+		fields, err = cargoFields(data)
+	case "composer.json":
+		fields, err = composerFields(data)
+	case "go.mod":
+		fields, err = goModFields(ctx, client, data)
+	default:
+		return nil, fmt.Errorf("unsupported manifest %s: use one of %s", path, strings.Join(Manifests, ", "))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing manifest %s: %w", path, err)
+	}
+
+	lines := parser.BuildLineIndex(data)
+	links := make([]parser.Link, 0, len(fields))
+	for _, f := range fields {
+		url := normalizeRepoURL(f.value)
+		if !parser.IsHTTPURL(url) {
+			continue
+		}
+		link := parser.Link{
+			URL:      url,
+			FilePath: path,
+			Text:     f.name,
+			Type:     parser.LinkTypeAutolink,
+		}
+		// JSON and TOML quote strings, which avoids matching a longer URL's prefix
+		if i := bytes.Index(data, []byte(f.locate())); i >= 0 {
+			link.Offset = i + 1
+			link.Line, link.Column = parser.OffsetToLineCol(lines, link.Offset)
+		}
+		links = append(links, link)
+	}
+	return links, nil
+}
+
+// field is a named URL found in a manifest.
+type field struct {
+	name  string
+	value string
+	at    string // Text marking the field's position, if not the quoted value
+}
+
+// locate returns the text whose first occurrence, after its first byte,
+// is the field's position in the manifest.
+func (f field) locate() string {
+	if f.at != "" {
+		return f.at
+	}
+	return `"` + f.value + `"`
+}
+
+// packageJSONFields returns the URL fields of a package.json.
+// repository, bugs, and funding may be strings or objects with a url key.
+func packageJSONFields(data []byte) ([]field, error) {
+	var pkg map[string]any
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil, err
+	}
+
+	var fields []field
+	for _, name := range []string{"homepage", "repository", "bugs", "funding"} {
+		switch v := pkg[name].(type) {
+		case string:
+			fields = append(fields, field{name: name, value: v})
+		case map[string]any:
+			if url, ok := v["url"].(string); ok {
+				fields = append(fields, field{name: name, value: url})
+			}
+		}
+	}
+	return fields, nil
+}
+
+// pyprojectFields returns the [project.urls] table of a pyproject.toml,
+// plus the homepage, repository, and documentation keys of [tool.poetry].
+func pyprojectFields(data []byte) ([]field, error) {
+	var doc struct {
+		Project struct {
+			URLs map[string]string `toml:"urls"`
+		} `toml:"project"`
+		Tool struct {
+			Poetry struct {
+				Homepage      string `toml:"homepage"`
+				Repository    string `toml:"repository"`
+				Documentation string `toml:"documentation"`
+			} `toml:"poetry"`
+		} `toml:"tool"`
+	}
+	if err := toml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	var fields []field
+	for _, name := range slices.Sorted(maps.Keys(doc.Project.URLs)) {
+		fields = append(fields, field{name: name, value: doc.Project.URLs[name]})
+	}
+	poetry := doc.Tool.Poetry
+	for _, f := range []field{
+		{name: "homepage", value: poetry.Homepage},
+		{name: "repository", value: poetry.Repository},
+		{name: "documentation", value: poetry.Documentation},
+	} {
+		if f.value != "" {
+			fields = append(fields, f)
+		}
+	}
+	return fields, nil
+}
+
+// cargoFields returns the homepage, repository, and documentation keys of
+// a Cargo.toml's [package] table.
+func cargoFields(data []byte) ([]field, error) {
+	var doc struct {
+		Package struct {
+			Homepage      string `toml:"homepage"`
+			Repository    string `toml:"repository"`
+			Documentation string `toml:"documentation"`
+		} `toml:"package"`
+	}
+	if err := toml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	pkg := doc.Package
+	return nonEmpty([]field{
+		{name: "homepage", value: pkg.Homepage},
+		{name: "repository", value: pkg.Repository},
+		{name: "documentation", value: pkg.Documentation},
+	}), nil
+}
+
+// composerFields returns the homepage, the support URLs (as support.issues,
+// support.source, ...), and the funding URLs of a composer.json.
+func composerFields(data []byte) ([]field, error) {
+	var doc struct {
+		Homepage string            `json:"homepage"`
+		Support  map[string]string `json:"support"`
+		Funding  []struct {
+			URL string `json:"url"`
+		} `json:"funding"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	fields := []field{{name: "homepage", value: doc.Homepage}}
+	for _, key := range slices.Sorted(maps.Keys(doc.Support)) {
+		fields = append(fields, field{name: "support." + key, value: doc.Support[key]})
+	}
+	for i, f := range doc.Funding {
+		fields = append(fields, field{name: "funding[" + strconv.Itoa(i) + "]", value: f.URL})
+	}
+	return nonEmpty(fields), nil
+}
+
+// nonEmpty drops the fields without a value.
+func nonEmpty(fields []field) []field {
+	return slices.DeleteFunc(fields, func(f field) bool { return f.value == "" })
+}
+
+// normalizeRepoURL turns VCS-style repository URLs ("git+https://github.com/a/b.git")
+// into their browsable https form. Other URLs are returned unchanged.
+func normalizeRepoURL(url string) string {
+	url = strings.TrimSpace(url)
+	if rest, ok := strings.CutPrefix(url, "git+"); ok {
+		url = strings.TrimSuffix(rest, ".git")
+	}
+	return url
+}
@@ -0,0 +1,232 @@
+package source
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseList(t *testing.T) {
+	t.Parallel()
+
+	data := []byte("# Partner sites\n\nhttps://a.com\n  https://b.com/docs\tdocs\nnot a url\nmailto:x@y.z\n")
+	links := ParseList("https://example.com/links.txt", data)
+
+	require.Len(t, links, 2)
+	assert.Equal(t, "https://a.com", links[0].URL)
+	assert.Equal(t, 3, links[0].Line)
+	assert.Equal(t, "https://example.com/links.txt", links[0].FilePath)
+	assert.Equal(t, "https://b.com/docs", links[1].URL)
+	assert.Equal(t, 4, links[1].Line)
+}
+
+func TestFromURL(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/links.txt" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("https://a.com\nhttps://b.com\n"))
+	}))
+	defer server.Close()
+
+	links, err := FromURL(context.Background(), server.Client(), server.URL+"/links.txt")
+	require.NoError(t, err)
+	assert.Len(t, links, 2)
+
+	_, err = FromURL(context.Background(), server.Client(), server.URL+"/missing.txt")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "HTTP 404")
+}
+
+func TestFromManifest_PackageJSON(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "package.json")
+	content := `{
+  "name": "demo",
+  "homepage": "https://demo.dev",
+  "repository": {"type": "git", "url": "git+https://github.com/acme/demo.git"},
+  "bugs": "https://github.com/acme/demo/issues",
+  "license": "MIT"
+}`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	links, err := FromManifest(context.Background(), nil, path)
+	require.NoError(t, err)
+	require.Len(t, links, 3)
+
+	assert.Equal(t, "https://demo.dev", links[0].URL)
+	assert.Equal(t, "homepage", links[0].Text)
+	assert.Equal(t, 3, links[0].Line)
+
+	assert.Equal(t, "https://github.com/acme/demo", links[1].URL)
+	assert.Equal(t, "repository", links[1].Text)
+	assert.Equal(t, 4, links[1].Line)
+
+	assert.Equal(t, "bugs", links[2].Text)
+}
+
+func TestFromManifest_Pyproject(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "pyproject.toml")
+	content := `[project]
+name = "demo"
+
+[project.urls]
+Homepage = "https://demo.dev"
+Documentation = "https://docs.demo.dev"
+
+[tool.poetry]
+repository = "https://github.com/acme/demo"
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	links, err := FromManifest(context.Background(), nil, path)
+	require.NoError(t, err)
+	require.Len(t, links, 3)
+
+	assert.Equal(t, "Documentation", links[0].Text)
+	assert.Equal(t, "https://docs.demo.dev", links[0].URL)
+	assert.Equal(t, 6, links[0].Line)
+	assert.Equal(t, "Homepage", links[1].Text)
+	assert.Equal(t, "repository", links[2].Text)
+}
+
+func TestFromManifest_Cargo(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "Cargo.toml")
+	content := `[package]
+name = "demo"
+homepage = "https://demo.dev"
+repository = "https://github.com/acme/demo"
+
+[dependencies]
+serde = "1"
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	links, err := FromManifest(context.Background(), nil, path)
+	require.NoError(t, err)
+	require.Len(t, links, 2)
+	assert.Equal(t, "homepage", links[0].Text)
+	assert.Equal(t, 3, links[0].Line)
+	assert.Equal(t, "repository", links[1].Text)
+}
+
+func TestFromManifest_Composer(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "composer.json")
+	content := `{
+  "name": "acme/demo",
+  "homepage": "https://demo.dev",
+  "support": {"issues": "https://github.com/acme/demo/issues", "email": "help@demo.dev"},
+  "funding": [{"type": "github", "url": "https://github.com/sponsors/acme"}]
+}`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	links, err := FromManifest(context.Background(), nil, path)
+	require.NoError(t, err)
+	require.Len(t, links, 3)
+	assert.Equal(t, "homepage", links[0].Text)
+	assert.Equal(t, "support.issues", links[1].Text)
+	assert.Equal(t, 4, links[1].Line)
+	assert.Equal(t, "funding[0]", links[2].Text)
+	assert.Equal(t, "https://github.com/sponsors/acme", links[2].URL)
+}
+
+func TestFromManifest_GoMod(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/example.com/!acme/tool/@latest" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"Version":"v1.2.0","Origin":{"VCS":"git","URL":"https://git.example.com/acme/tool.git"}}`))
+	}))
+	defer proxy.Close()
+	t.Setenv("GOPROXY", proxy.URL+",direct")
+
+	dir := t.TempDir()
+	write := func(module string) string {
+		path := filepath.Join(dir, "go.mod")
+		content := "// Tool module.\nmodule " + module + "\n\ngo 1.22\n"
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	t.Run("Proxy", func(t *testing.T) {
+		links, err := FromManifest(context.Background(), proxy.Client(), write("example.com/Acme/tool"))
+		require.NoError(t, err)
+		require.Len(t, links, 2)
+		assert.Equal(t, "repository", links[0].Text)
+		assert.Equal(t, "https://git.example.com/acme/tool", links[0].URL)
+		assert.Equal(t, 2, links[0].Line)
+		assert.Equal(t, 8, links[0].Column)
+		assert.Equal(t, "documentation", links[1].Text)
+		assert.Equal(t, "https://pkg.go.dev/example.com/Acme/tool", links[1].URL)
+	})
+
+	t.Run("HostedFallback", func(t *testing.T) {
+		links, err := FromManifest(context.Background(), proxy.Client(), write("github.com/acme/tool/v2"))
+		require.NoError(t, err)
+		require.Len(t, links, 2)
+		assert.Equal(t, "https://github.com/acme/tool", links[0].URL)
+	})
+
+	t.Run("UnknownHost", func(t *testing.T) {
+		links, err := FromManifest(context.Background(), nil, write("example.com/Acme/tool"))
+		require.NoError(t, err)
+		require.Len(t, links, 1)
+		assert.Equal(t, "documentation", links[0].Text)
+	})
+
+	t.Run("NoModule", func(t *testing.T) {
+		path := filepath.Join(dir, "go.mod")
+		require.NoError(t, os.WriteFile(path, []byte("go 1.22\n"), 0o600))
+		_, err := FromManifest(context.Background(), nil, path)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no module directive")
+	})
+}
+
+func TestFromManifest_Directory(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "package.json"),
+		[]byte(`{"homepage": "https://demo.dev"}`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Cargo.toml"),
+		[]byte("[package]\nrepository = \"https://github.com/acme/demo\"\n"), 0o600))
+
+	links, err := FromManifest(context.Background(), nil, dir)
+	require.NoError(t, err)
+	require.Len(t, links, 2)
+	assert.Equal(t, filepath.Join(dir, "package.json"), links[0].FilePath)
+	assert.Equal(t, filepath.Join(dir, "Cargo.toml"), links[1].FilePath)
+
+	_, err = FromManifest(context.Background(), nil, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no manifest")
+}
+
+func TestFromManifest_Unsupported(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "Gemfile")
+	require.NoError(t, os.WriteFile(path, []byte("source 'https://rubygems.org'\n"), 0o600))
+
+	_, err := FromManifest(context.Background(), nil, path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported manifest")
+}
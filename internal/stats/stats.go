@@ -5,11 +5,34 @@ package stats
 
 import (
 	"fmt"
+	"math"
 	"runtime"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// TimingPhase is a phase of a link's request, as recorded by RecordTiming.
+type TimingPhase int
+
+// Request phases, in the order they happen.
+const (
+	PhaseDNS TimingPhase = iota
+	PhaseConnect
+	PhaseTLS
+	PhaseTTFB
+	PhaseTotal
+	numTimingPhases
+)
+
+// timingPhaseNames are the keys of the phases in ToJSON.
+var timingPhaseNames = [numTimingPhases]string{"dns", "connect", "tls", "ttfb", "total"}
+
+// timingPhaseLabels are the labels of the phases in String.
+var timingPhaseLabels = [numTimingPhases]string{"DNS", "Connect", "TLS", "TTFB", "Total"}
+
 // Stats holds performance metrics for a link checking session.
 type Stats struct {
 	// Timing for each phase
@@ -27,6 +50,31 @@ type Stats struct {
 	Duplicates   int
 	Ignored      int
 
+	// HTTP traffic, recorded through checker hooks while checking: requests
+	// sent, retries, URLs retried at least once, and the total backoff
+	// waited before retries (in nanoseconds)
+	Requests    int64
+	Retries     int64
+	RetriedURLs int64
+	BackoffTime int64
+
+	// DNS lookups made while connecting: queries sent, answers served from
+	// the cache, and the total time spent waiting for both (in nanoseconds)
+	DNSLookups   int64
+	DNSCacheHits int64
+	DNSTime      int64
+
+	// Request timing samples per phase, recorded with RecordTiming
+	timing   [numTimingPhases][]time.Duration
+	timingMu sync.Mutex
+
+	// Pre-flight host probe (only set when the probe ran)
+	ProbeDuration    time.Duration
+	ProbedHosts      int
+	UnreachableHosts int
+	RateLimitedHosts int
+	Probed           bool
+
 	// Memory stats (captured at end)
 	HeapAlloc    uint64
 	TotalAlloc   uint64
@@ -75,6 +123,80 @@ func (s *Stats) EndCheck() {
 	s.captureMemoryStats()
 }
 
+// RecordProbe stores the outcome of the pre-flight host probe.
+func (s *Stats) RecordProbe(d time.Duration, hosts, unreachable, rateLimited int) {
+	s.Probed = true
+	s.ProbeDuration = d
+	s.ProbedHosts = hosts
+	s.UnreachableHosts = unreachable
+	s.RateLimitedHosts = rateLimited
+}
+
+// RecordRequest counts an HTTP request. Safe for concurrent use.
+func (s *Stats) RecordRequest() {
+	atomic.AddInt64(&s.Requests, 1)
+}
+
+// RecordRetry counts retry number attempt of a check, made after waiting
+// delay. Safe for concurrent use.
+func (s *Stats) RecordRetry(attempt int, delay time.Duration) {
+	atomic.AddInt64(&s.Retries, 1)
+	if attempt == 1 {
+		atomic.AddInt64(&s.RetriedURLs, 1)
+	}
+	atomic.AddInt64(&s.BackoffTime, int64(delay))
+}
+
+// BackoffDuration returns the total time waited before retries.
+func (s *Stats) BackoffDuration() time.Duration {
+	return time.Duration(atomic.LoadInt64(&s.BackoffTime))
+}
+
+// RecordDNS counts a host name lookup and the time spent waiting for it.
+// Safe for concurrent use.
+func (s *Stats) RecordDNS(d time.Duration, cached bool) {
+	if cached {
+		atomic.AddInt64(&s.DNSCacheHits, 1)
+	} else {
+		atomic.AddInt64(&s.DNSLookups, 1)
+	}
+	atomic.AddInt64(&s.DNSTime, int64(d))
+}
+
+// DNSDuration returns the total time spent resolving host names. Lookups
+// run concurrently, so it can exceed the check duration.
+func (s *Stats) DNSDuration() time.Duration {
+	return time.Duration(atomic.LoadInt64(&s.DNSTime))
+}
+
+// RecordTiming records the phases of a link's request. Phases that didn't
+// happen (zero), such as DNS on a reused connection, aren't sampled, so
+// their percentiles describe the requests that went through them.
+// Safe for concurrent use.
+func (s *Stats) RecordTiming(dns, connect, tls, ttfb, total time.Duration) {
+	s.timingMu.Lock()
+	defer s.timingMu.Unlock()
+	for phase, d := range [numTimingPhases]time.Duration{dns, connect, tls, ttfb, total} {
+		if d > 0 {
+			s.timing[phase] = append(s.timing[phase], d)
+		}
+	}
+}
+
+// TimingPercentile returns the p-th percentile (0-100, nearest rank) of a
+// request phase, and the number of samples. It returns 0, 0 without samples.
+func (s *Stats) TimingPercentile(phase TimingPhase, p float64) (time.Duration, int) {
+	s.timingMu.Lock()
+	samples := slices.Clone(s.timing[phase])
+	s.timingMu.Unlock()
+	if len(samples) == 0 {
+		return 0, 0
+	}
+	slices.Sort(samples)
+	rank := max(int(math.Ceil(p/100*float64(len(samples)))), 1)
+	return samples[min(rank, len(samples))-1], len(samples)
+}
+
 // captureMemoryStats reads current memory statistics from runtime.
 func (s *Stats) captureMemoryStats() {
 	var m runtime.MemStats
@@ -211,9 +333,45 @@ func (s *Stats) String() string {
 	if s.Ignored > 0 {
 		b.WriteString(fmt.Sprintf("  Ignored:           %5d\n", s.Ignored))
 	}
+	if s.Requests > 0 {
+		b.WriteString(fmt.Sprintf("  HTTP requests:     %5d\n", s.Requests))
+	}
+	if s.Retries > 0 {
+		b.WriteString(fmt.Sprintf("  Retries:           %5d\n", s.Retries))
+		b.WriteString(fmt.Sprintf("  Retried URLs:      %5d\n", s.RetriedURLs))
+		b.WriteString(fmt.Sprintf("  Backoff time:    %7s\n", FormatDuration(s.BackoffDuration())))
+	}
+	if s.DNSLookups > 0 || s.DNSCacheHits > 0 {
+		b.WriteString(fmt.Sprintf("  DNS lookups:       %5d\n", s.DNSLookups))
+		b.WriteString(fmt.Sprintf("  DNS cache hits:    %5d\n", s.DNSCacheHits))
+		b.WriteString(fmt.Sprintf("  DNS time:        %7s\n", FormatDuration(s.DNSDuration())))
+	}
 	b.WriteString(fmt.Sprintf("  URLs/second:       %5.1f\n", s.URLsPerSecond()))
 	b.WriteString(fmt.Sprintf("  Avg response:    %7s\n", FormatDuration(s.AvgResponseTime())))
 
+	// Request timing
+	if _, n := s.TimingPercentile(PhaseTotal, 50); n > 0 {
+		b.WriteString("\nRequest timing:       p50      p95\n")
+		for phase := range numTimingPhases {
+			p50, n := s.TimingPercentile(phase, 50)
+			if n == 0 {
+				continue
+			}
+			p95, _ := s.TimingPercentile(phase, 95)
+			b.WriteString(fmt.Sprintf("  %-10s    %8s %8s\n", timingPhaseLabels[phase]+":",
+				FormatDuration(p50), FormatDuration(p95)))
+		}
+	}
+
+	// Pre-flight probe
+	if s.Probed {
+		b.WriteString("\nPre-flight probe:\n")
+		b.WriteString(fmt.Sprintf("  Hosts probed:      %5d\n", s.ProbedHosts))
+		b.WriteString(fmt.Sprintf("  Unreachable:       %5d\n", s.UnreachableHosts))
+		b.WriteString(fmt.Sprintf("  Rate limited:      %5d\n", s.RateLimitedHosts))
+		b.WriteString(fmt.Sprintf("  Probe time:      %7s\n", FormatDuration(s.ProbeDuration)))
+	}
+
 	// Memory
 	b.WriteString("\nMemory:\n")
 	b.WriteString(fmt.Sprintf("  Heap in use:   %8s\n", FormatBytes(s.HeapAlloc)))
@@ -226,7 +384,7 @@ func (s *Stats) String() string {
 
 // ToJSON returns a map suitable for JSON serialization.
 func (s *Stats) ToJSON() map[string]any {
-	return map[string]any{
+	result := map[string]any{
 		"timing": map[string]any{
 			"scan_ms":  s.ScanDuration().Milliseconds(),
 			"parse_ms": s.ParseDuration().Milliseconds(),
@@ -239,6 +397,13 @@ func (s *Stats) ToJSON() map[string]any {
 			"unique_urls":     s.UniqueURLs,
 			"duplicates":      s.Duplicates,
 			"ignored":         s.Ignored,
+			"requests":        s.Requests,
+			"retries":         s.Retries,
+			"retried_urls":    s.RetriedURLs,
+			"backoff_ms":      s.BackoffDuration().Milliseconds(),
+			"dns_lookups":     s.DNSLookups,
+			"dns_cache_hits":  s.DNSCacheHits,
+			"dns_ms":          s.DNSDuration().Milliseconds(),
 			"urls_per_second": s.URLsPerSecond(),
 			"avg_response_ms": s.AvgResponseTime().Milliseconds(),
 		},
@@ -249,4 +414,32 @@ func (s *Stats) ToJSON() map[string]any {
 			"goroutines":  s.NumGoroutine,
 		},
 	}
+
+	timing := map[string]any{}
+	for phase := range numTimingPhases {
+		p50, n := s.TimingPercentile(phase, 50)
+		if n == 0 {
+			continue
+		}
+		p95, _ := s.TimingPercentile(phase, 95)
+		timing[timingPhaseNames[phase]] = map[string]any{
+			"p50_ms":  p50.Milliseconds(),
+			"p95_ms":  p95.Milliseconds(),
+			"samples": n,
+		}
+	}
+	if len(timing) > 0 {
+		result["request_timing"] = timing
+	}
+
+	if s.Probed {
+		result["probe"] = map[string]any{
+			"hosts":        s.ProbedHosts,
+			"unreachable":  s.UnreachableHosts,
+			"rate_limited": s.RateLimitedHosts,
+			"duration_ms":  s.ProbeDuration.Milliseconds(),
+		}
+	}
+
+	return result
 }
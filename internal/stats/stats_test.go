@@ -1,6 +1,7 @@
 package stats
 
 import (
+	"sync"
 	"testing"
 	"time"
 
@@ -492,5 +493,97 @@ func TestToJSON(t *testing.T) {
 		assert.Equal(t, 80, throughput["unique_urls"])
 		assert.Equal(t, 15, throughput["duplicates"])
 		assert.Equal(t, 5, throughput["ignored"])
+		assert.NotContains(t, result, "probe")
+	})
+
+	t.Run("IncludesProbe", func(t *testing.T) {
+		t.Parallel()
+		s := New()
+		s.RecordProbe(1500*time.Millisecond, 12, 2, 1)
+
+		result := s.ToJSON()
+
+		probe, ok := result["probe"].(map[string]any)
+		require.True(t, ok)
+		assert.Equal(t, 12, probe["hosts"])
+		assert.Equal(t, 2, probe["unreachable"])
+		assert.Equal(t, 1, probe["rate_limited"])
+		assert.Equal(t, int64(1500), probe["duration_ms"])
+		assert.Contains(t, s.String(), "Pre-flight probe:")
+	})
+
+	t.Run("IncludesRequests", func(t *testing.T) {
+		t.Parallel()
+		s := New()
+
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Go(s.RecordRequest)
+		}
+		wg.Wait()
+		s.RecordRetry(1, time.Second)
+		s.RecordRetry(2, 2*time.Second)
+
+		result := s.ToJSON()
+
+		throughput, ok := result["throughput"].(map[string]any)
+		require.True(t, ok)
+		assert.Equal(t, int64(10), throughput["requests"])
+		assert.Equal(t, int64(2), throughput["retries"])
+		assert.Equal(t, int64(1), throughput["retried_urls"])
+		assert.Equal(t, int64(3000), throughput["backoff_ms"])
+		assert.Contains(t, s.String(), "HTTP requests:")
+		assert.Contains(t, s.String(), "Retries:")
+		assert.Contains(t, s.String(), "Retried URLs:")
+	})
+	t.Run("IncludesDNS", func(t *testing.T) {
+		t.Parallel()
+		s := New()
+		assert.NotContains(t, s.String(), "DNS lookups:")
+
+		s.RecordDNS(30*time.Millisecond, false)
+		s.RecordDNS(2*time.Millisecond, true)
+		s.RecordDNS(time.Millisecond, true)
+
+		throughput, ok := s.ToJSON()["throughput"].(map[string]any)
+		require.True(t, ok)
+		assert.Equal(t, int64(1), throughput["dns_lookups"])
+		assert.Equal(t, int64(2), throughput["dns_cache_hits"])
+		assert.Equal(t, int64(33), throughput["dns_ms"])
+		assert.Contains(t, s.String(), "DNS cache hits:")
+	})
+	t.Run("IncludesRequestTiming", func(t *testing.T) {
+		t.Parallel()
+		s := New()
+		assert.NotContains(t, s.String(), "Request timing:")
+
+		for i := 1; i <= 20; i++ {
+			ms := time.Duration(i) * time.Millisecond
+			var dns time.Duration
+			if i%2 == 0 {
+				dns = ms // Odd requests reused a connection
+			}
+			s.RecordTiming(dns, 0, 0, ms, 10*ms)
+		}
+
+		p50, n := s.TimingPercentile(PhaseTotal, 50)
+		assert.Equal(t, 20, n)
+		assert.Equal(t, 100*time.Millisecond, p50)
+		p95, _ := s.TimingPercentile(PhaseTotal, 95)
+		assert.Equal(t, 190*time.Millisecond, p95)
+		dnsP50, n := s.TimingPercentile(PhaseDNS, 50)
+		assert.Equal(t, 10, n)
+		assert.Equal(t, 10*time.Millisecond, dnsP50)
+		_, n = s.TimingPercentile(PhaseTLS, 50)
+		assert.Zero(t, n)
+
+		timing, ok := s.ToJSON()["request_timing"].(map[string]any)
+		require.True(t, ok)
+		assert.NotContains(t, timing, "tls")
+		ttfb, ok := timing["ttfb"].(map[string]any)
+		require.True(t, ok)
+		assert.Equal(t, int64(10), ttfb["p50_ms"])
+		assert.Equal(t, int64(19), ttfb["p95_ms"])
+		assert.Contains(t, s.String(), "Request timing:")
 	})
 }
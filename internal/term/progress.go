@@ -0,0 +1,116 @@
+package term
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/leonardomso/gone/internal/checker"
+)
+
+// progressBarWidth is the number of cells in the progress bar.
+const progressBarWidth = 24
+
+// progressInterval is how often the progress line is redrawn while no
+// results come in, so the elapsed time and ETA stay current.
+const progressInterval = 250 * time.Millisecond
+
+// Progress draws a progress line on a terminal while links are checked:
+// a bar, checked and total links, dead links and warnings found so far,
+// and the estimated time left. The line is redrawn in place and cleared
+// when the check is done, so it never ends up in the output.
+type Progress struct {
+	w     io.Writer
+	total int
+	start time.Time
+	now   func() time.Time
+
+	mu                   sync.Mutex
+	done, dead, warnings int
+
+	stop    chan struct{}
+	stopped sync.WaitGroup
+}
+
+// NewProgress returns a progress line for a check of total links, drawn on w.
+func NewProgress(w io.Writer, total int) *Progress {
+	return &Progress{w: w, total: total, now: time.Now, stop: make(chan struct{})}
+}
+
+// IsTerminal reports whether f is an interactive terminal that can redraw
+// a line in place.
+func IsTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0 && os.Getenv("TERM") != "dumb"
+}
+
+// Start draws the progress line and keeps it current until Stop is called.
+func (p *Progress) Start() {
+	p.start = p.now()
+	p.draw()
+	p.stopped.Add(1)
+	go func() {
+		defer p.stopped.Done()
+		ticker := time.NewTicker(progressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.stop:
+				return
+			case <-ticker.C:
+				p.draw()
+			}
+		}
+	}()
+}
+
+// Record counts a result and redraws the line. It's meant for the
+// checker's OnResult hook.
+func (p *Progress) Record(r checker.Result) {
+	p.mu.Lock()
+	p.done++
+	switch {
+	case r.IsDead():
+		p.dead++
+	case r.IsWarning():
+		p.warnings++
+	}
+	p.mu.Unlock()
+	p.draw()
+}
+
+// Stop stops redrawing and clears the progress line.
+func (p *Progress) Stop() {
+	close(p.stop)
+	p.stopped.Wait()
+	fmt.Fprint(p.w, "\r\x1b[K")
+}
+
+// draw redraws the progress line.
+func (p *Progress) draw() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	fmt.Fprint(p.w, "\r"+p.line()+"\x1b[K")
+}
+
+// line returns the progress line, without the escape sequences that redraw it.
+func (p *Progress) line() string {
+	filled := progressBarWidth
+	if p.total > 0 {
+		filled = min(progressBarWidth, p.done*progressBarWidth/p.total)
+	}
+	bar := strings.Repeat("=", filled) + strings.Repeat(" ", progressBarWidth-filled)
+
+	line := fmt.Sprintf("[%s] %d/%d checked  %d dead  %d warnings", bar, p.done, p.total, p.dead, p.warnings)
+	elapsed := p.now().Sub(p.start)
+	if p.done > 0 && p.done < p.total {
+		eta := elapsed * time.Duration(p.total-p.done) / time.Duration(p.done)
+		line += "  ETA " + eta.Round(time.Second).String()
+	} else {
+		line += "  " + elapsed.Round(time.Second).String()
+	}
+	return line
+}
@@ -0,0 +1,201 @@
+// Package term renders the text output of a check for a terminal: status
+// tags colored by a theme, entries aligned in columns, and clickable links
+// on terminals that support OSC 8 hyperlinks. Colors are left out when the
+// output isn't a terminal, NO_COLOR is set, or they're turned off.
+package term
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Entry is an item of a section: a status tag and a URL, with labeled
+// details printed under the URL.
+type Entry struct {
+	Tag    string
+	Kind   Kind
+	URL    string
+	Fields []Field
+}
+
+// Field is a labeled detail of an entry. If Link is set, Value is a link to
+// it; Suffix follows Value outside the link. Fields without a label are
+// printed as notes in the label column.
+type Field struct {
+	Label  string
+	Value  string
+	Link   string
+	Suffix string
+}
+
+// FileField returns a field for a location in a file, as file:line or just
+// the file if the line is unknown, linking to the file.
+func FileField(label, path string, line int) Field {
+	value := path
+	if line > 0 {
+		value += ":" + strconv.Itoa(line)
+	}
+	return Field{Label: label, Value: value, Link: fileURL(path)}
+}
+
+// Printer writes sections of entries to a terminal.
+type Printer struct {
+	w          io.Writer
+	renderer   *lipgloss.Renderer
+	theme      Theme
+	hyperlinks bool
+}
+
+// NewPrinter returns a printer writing to w with the default theme. Colors
+// follow what w supports and the NO_COLOR and CLICOLOR_FORCE variables.
+// Links are clickable on terminals known to support hyperlinks, or wherever
+// FORCE_HYPERLINK is set to anything but 0.
+func NewPrinter(w io.Writer) *Printer {
+	p := &Printer{w: w, renderer: lipgloss.NewRenderer(w), theme: themes["default"]}
+	p.hyperlinks = hyperlinksEnabled(p.renderer.ColorProfile() != termenv.Ascii, os.Getenv)
+	return p
+}
+
+// WithTheme returns a copy of the printer that uses theme.
+func (p *Printer) WithTheme(theme Theme) *Printer {
+	c := *p
+	c.theme = theme
+	return &c
+}
+
+// WithColor returns a copy of the printer with colors and hyperlinks turned
+// off if enabled is false. It can't turn them on where w doesn't support them.
+func (p *Printer) WithColor(enabled bool) *Printer {
+	if enabled {
+		return p
+	}
+	c := *p
+	c.renderer = lipgloss.NewRenderer(p.w)
+	c.renderer.SetColorProfile(termenv.Ascii)
+	c.hyperlinks = false
+	return &c
+}
+
+// Heading writes a section heading, such as "=== Dead Links (2) ===".
+func (p *Printer) Heading(title string) {
+	style := p.renderer.NewStyle().Bold(true).Foreground(p.theme.Title)
+	fmt.Fprintf(p.w, "%s\n\n", style.Render("=== "+title+" ==="))
+}
+
+// Section writes a heading and its entries, followed by a blank line.
+func (p *Printer) Section(title string, entries []Entry) {
+	p.Heading(title)
+	p.Entries(entries)
+	fmt.Fprintln(p.w)
+}
+
+// Entries writes entries, each followed by a blank line. URLs line up after
+// the widest tag, and field values after the widest label.
+func (p *Printer) Entries(entries []Entry) {
+	tagWidth, labelWidth := 0, 0
+	for _, e := range entries {
+		tagWidth = max(tagWidth, utf8.RuneCountInString(e.Tag))
+		for _, f := range e.Fields {
+			labelWidth = max(labelWidth, utf8.RuneCountInString(f.Label)+1)
+		}
+	}
+	indent := strings.Repeat(" ", 2+tagWidth+1)
+	labelStyle := p.renderer.NewStyle().Foreground(p.theme.Label)
+
+	var b strings.Builder
+	for _, e := range entries {
+		b.WriteString("  " + p.Status(e.Kind, e.Tag))
+		b.WriteString(strings.Repeat(" ", tagWidth-utf8.RuneCountInString(e.Tag)+1))
+		b.WriteString(p.link(absoluteURL(e.URL), e.URL) + "\n")
+		for _, f := range e.Fields {
+			b.WriteString(indent)
+			if f.Label == "" {
+				b.WriteString(labelStyle.Render(f.Value) + "\n")
+				continue
+			}
+			label := f.Label + ":"
+			b.WriteString(labelStyle.Render(label))
+			b.WriteString(strings.Repeat(" ", labelWidth-utf8.RuneCountInString(label)+1))
+			b.WriteString(p.link(f.Link, f.Value) + f.Suffix + "\n")
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprint(p.w, b.String())
+}
+
+// Diff writes a line before and after a change, as "- before" in the dead
+// color and "+ after" in the alive color.
+func (p *Printer) Diff(before, after string) {
+	removed := p.renderer.NewStyle().Foreground(p.theme.Dead)
+	added := p.renderer.NewStyle().Foreground(p.theme.Alive)
+	fmt.Fprintf(p.w, "  %s\n  %s\n", removed.Render("- "+before), added.Render("+ "+after))
+}
+
+// Status returns text styled as a tag of the given kind, for use inline.
+func (p *Printer) Status(kind Kind, text string) string {
+	if text == "" {
+		return ""
+	}
+	return p.renderer.NewStyle().Bold(true).Foreground(p.theme.color(kind)).Render(text)
+}
+
+// link returns text as a hyperlink to target, if hyperlinks are enabled.
+func (p *Printer) link(target, text string) string {
+	if !p.hyperlinks || target == "" {
+		return text
+	}
+	return p.renderer.Output().Hyperlink(target, text)
+}
+
+// hyperlinksEnabled reports whether links should be clickable, given
+// whether colors are enabled and the environment.
+func hyperlinksEnabled(colored bool, getenv func(string) string) bool {
+	if force := getenv("FORCE_HYPERLINK"); force != "" {
+		return force != "0"
+	}
+	if !colored {
+		return false
+	}
+	switch getenv("TERM_PROGRAM") {
+	case "iTerm.app", "WezTerm", "vscode", "ghostty", "Hyper":
+		return true
+	}
+	if getenv("WT_SESSION") != "" || getenv("KITTY_WINDOW_ID") != "" || getenv("DOMTERM") != "" {
+		return true
+	}
+	// VTE-based terminals, such as GNOME Terminal, support them since 0.50
+	vte, err := strconv.Atoi(getenv("VTE_VERSION"))
+	return err == nil && vte >= 5000
+}
+
+// absoluteURL returns u if it's an absolute URL, which a hyperlink can
+// point to, or "" for relative links.
+func absoluteURL(u string) string {
+	parsed, err := url.Parse(u)
+	if err != nil || !parsed.IsAbs() {
+		return ""
+	}
+	return u
+}
+
+// fileURL returns the file:// URL of a path, or "" if it can't be made absolute.
+func fileURL(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return ""
+	}
+	abs = filepath.ToSlash(abs)
+	if !strings.HasPrefix(abs, "/") {
+		abs = "/" + abs // Windows drive letters
+	}
+	return (&url.URL{Scheme: "file", Path: abs}).String()
+}
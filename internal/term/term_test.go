@@ -0,0 +1,169 @@
+package term
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/leonardomso/gone/internal/checker"
+)
+
+func TestPrinter_Section(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	NewPrinter(&buf).Section("Dead Links (2)", []Entry{
+		{Tag: "[404]", Kind: KindDead, URL: "https://example.com/a", Fields: []Field{
+			{Label: "Text", Value: `"a"`},
+			FileField("File", "README.md", 3),
+		}},
+		{Tag: "[ERROR]", Kind: KindDead, URL: "https://example.com/b", Fields: []Field{
+			FileField("File", "docs/guide.md", 0),
+			{Label: "Error", Value: "timeout"},
+			{Value: "... and 2 more"},
+		}},
+	})
+
+	want := `=== Dead Links (2) ===
+
+  [404]   https://example.com/a
+          Text:  "a"
+          File:  README.md:3
+
+  [ERROR] https://example.com/b
+          File:  docs/guide.md
+          Error: timeout
+          ... and 2 more
+
+
+`
+	assert.Equal(t, want, buf.String())
+}
+
+func TestPrinter_Color(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	p := NewPrinter(&buf)
+	p.renderer.SetColorProfile(termenv.ANSI256)
+	p.hyperlinks = true
+
+	p.Entries([]Entry{{Tag: "[404]", Kind: KindDead, URL: "https://example.com/a", Fields: []Field{
+		{Label: "Fix", Value: "https://example.com/b", Link: "https://example.com/b", Suffix: " (redirect)"},
+	}}})
+	out := buf.String()
+	assert.Contains(t, out, "\x1b[1;38;5;196m[404]\x1b[0m")
+	assert.Contains(t, out, "\x1b]8;;https://example.com/a\x1b\\https://example.com/a\x1b]8;;\x1b\\")
+	assert.Contains(t, out, "\x1b]8;;https://example.com/b\x1b\\https://example.com/b\x1b]8;;\x1b\\ (redirect)")
+
+	buf.Reset()
+	p.WithColor(false).Entries([]Entry{{Tag: "[404]", Kind: KindDead, URL: "https://example.com/a"}})
+	assert.Equal(t, "  [404] https://example.com/a\n\n", buf.String())
+
+	mono, ok := ThemeByName("mono")
+	require.True(t, ok)
+	assert.Equal(t, "\x1b[1m[404]\x1b[0m", p.WithTheme(mono).Status(KindDead, "[404]"))
+}
+
+func TestPrinter_Diff(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	p := NewPrinter(&buf)
+	p.Diff("see http://a.com", "see https://b.com")
+	assert.Equal(t, "  - see http://a.com\n  + see https://b.com\n", buf.String())
+
+	buf.Reset()
+	p.renderer.SetColorProfile(termenv.ANSI256)
+	p.Diff("old", "new")
+	assert.Equal(t, "  \x1b[38;5;196m- old\x1b[0m\n  \x1b[38;5;82m+ new\x1b[0m\n", buf.String())
+}
+
+func TestPrinter_RelativeURL(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	p := NewPrinter(&buf)
+	p.hyperlinks = true
+	p.Entries([]Entry{{Tag: "[missing-file]", URL: "./docs/missing.md"}})
+	assert.Equal(t, "  [missing-file] ./docs/missing.md\n\n", buf.String())
+}
+
+func TestFileField(t *testing.T) {
+	t.Parallel()
+
+	f := FileField("File", "README.md", 12)
+	assert.Equal(t, "README.md:12", f.Value)
+	assert.True(t, strings.HasPrefix(f.Link, "file:///"), f.Link)
+	assert.True(t, strings.HasSuffix(f.Link, "/README.md"), f.Link)
+}
+
+func TestHyperlinksEnabled(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		colored bool
+		env     map[string]string
+		want    bool
+	}{
+		{"unknown terminal", true, nil, false},
+		{"iTerm", true, map[string]string{"TERM_PROGRAM": "iTerm.app"}, true},
+		{"Windows Terminal", true, map[string]string{"WT_SESSION": "1"}, true},
+		{"new VTE", true, map[string]string{"VTE_VERSION": "6003"}, true},
+		{"old VTE", true, map[string]string{"VTE_VERSION": "4601"}, false},
+		{"no colors", false, map[string]string{"TERM_PROGRAM": "WezTerm"}, false},
+		{"forced", false, map[string]string{"FORCE_HYPERLINK": "1"}, true},
+		{"forced off", true, map[string]string{"FORCE_HYPERLINK": "0", "TERM_PROGRAM": "vscode"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			getenv := func(key string) string { return tt.env[key] }
+			assert.Equal(t, tt.want, hyperlinksEnabled(tt.colored, getenv))
+		})
+	}
+}
+
+func TestThemeByName(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range ThemeNames() {
+		_, ok := ThemeByName(name)
+		assert.True(t, ok, name)
+	}
+	def, ok := ThemeByName("")
+	require.True(t, ok)
+	assert.Equal(t, themes["default"], def)
+
+	_, ok = ThemeByName("neon")
+	assert.False(t, ok)
+}
+
+func TestProgress(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	p := NewProgress(&buf, 4)
+	start := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	now := start
+	p.now = func() time.Time { return now }
+	p.start = start
+
+	assert.Equal(t, "[                        ] 0/4 checked  0 dead  0 warnings  0s", p.line())
+
+	now = start.Add(10 * time.Second)
+	p.Record(checker.Result{Status: checker.StatusDead})
+	assert.Equal(t, "[======                  ] 1/4 checked  1 dead  0 warnings  ETA 30s", p.line())
+	assert.True(t, strings.HasSuffix(buf.String(), "\r"+p.line()+"\x1b[K"))
+
+	p.Record(checker.Result{Status: checker.StatusRedirect})
+	p.Record(checker.Result{Status: checker.StatusAlive})
+	p.Record(checker.Result{Status: checker.StatusDuplicate})
+	assert.Equal(t, "[========================] 4/4 checked  1 dead  1 warnings  10s", p.line())
+}
@@ -0,0 +1,97 @@
+package term
+
+import "github.com/charmbracelet/lipgloss"
+
+// Kind is the kind of status a tag shows, which decides its color.
+type Kind int
+
+// Tag kinds.
+const (
+	KindNeutral Kind = iota
+	KindAlive
+	KindWarning
+	KindDead
+	KindDuplicate
+	KindSkipped
+)
+
+// Theme holds the colors of the text output. Tags and titles are bold in
+// every theme, so they stand out even without colors.
+type Theme struct {
+	Title     lipgloss.TerminalColor
+	Alive     lipgloss.TerminalColor
+	Warning   lipgloss.TerminalColor
+	Dead      lipgloss.TerminalColor
+	Duplicate lipgloss.TerminalColor
+	Skipped   lipgloss.TerminalColor
+	Label     lipgloss.TerminalColor
+}
+
+// color returns the color of a tag kind.
+func (t Theme) color(kind Kind) lipgloss.TerminalColor {
+	switch kind {
+	case KindAlive:
+		return t.Alive
+	case KindWarning:
+		return t.Warning
+	case KindDead:
+		return t.Dead
+	case KindDuplicate:
+		return t.Duplicate
+	case KindSkipped:
+		return t.Skipped
+	default:
+		return lipgloss.NoColor{}
+	}
+}
+
+// themeNames lists the themes in the order ThemeNames returns them.
+var themeNames = []string{"default", "ansi", "mono"}
+
+// themes maps theme names to themes.
+var themes = map[string]Theme{
+	// default matches the interactive UI's 256-color palette.
+	"default": {
+		Title:     lipgloss.Color("205"),
+		Alive:     lipgloss.Color("82"),
+		Warning:   lipgloss.Color("214"),
+		Dead:      lipgloss.Color("196"),
+		Duplicate: lipgloss.Color("139"),
+		Skipped:   lipgloss.Color("245"),
+		Label:     lipgloss.Color("245"),
+	},
+	// ansi uses the 16 basic colors, which follow the terminal's color scheme.
+	"ansi": {
+		Title:     lipgloss.Color("5"),
+		Alive:     lipgloss.Color("2"),
+		Warning:   lipgloss.Color("3"),
+		Dead:      lipgloss.Color("1"),
+		Duplicate: lipgloss.Color("6"),
+		Skipped:   lipgloss.Color("8"),
+		Label:     lipgloss.Color("8"),
+	},
+	// mono has no colors, only bold tags and titles.
+	"mono": {
+		Title:     lipgloss.NoColor{},
+		Alive:     lipgloss.NoColor{},
+		Warning:   lipgloss.NoColor{},
+		Dead:      lipgloss.NoColor{},
+		Duplicate: lipgloss.NoColor{},
+		Skipped:   lipgloss.NoColor{},
+		Label:     lipgloss.NoColor{},
+	},
+}
+
+// ThemeByName returns the theme called name, or the default theme if name is empty.
+func ThemeByName(name string) (Theme, bool) {
+	if name == "" {
+		name = "default"
+	}
+	t, ok := themes[name]
+	return t, ok
+}
+
+// ThemeNames returns the names of all themes.
+func ThemeNames() []string {
+	return themeNames
+}
@@ -322,9 +322,10 @@ func (m *Model) handleLinkChecked(msg LinkCheckedMsg) (tea.Model, tea.Cmd) {
 	switch msg.Result.Status {
 	case checker.StatusAlive:
 		m.aliveLinks = append(m.aliveLinks, msg.Result)
-	case checker.StatusRedirect, checker.StatusBlocked:
+	case checker.StatusRedirect, checker.StatusLongRedirect, checker.StatusLoginRequired,
+		checker.StatusBrokenAnchor, checker.StatusInsecure, checker.StatusSuspect, checker.StatusBlocked:
 		m.warningLinks = append(m.warningLinks, msg.Result)
-	case checker.StatusDead, checker.StatusError:
+	case checker.StatusDead, checker.StatusError, checker.StatusTLSError:
 		m.deadLinks = append(m.deadLinks, msg.Result)
 	case checker.StatusDuplicate:
 		m.duplicateLinks = append(m.duplicateLinks, msg.Result)
@@ -41,6 +41,8 @@ func ExtractLinksCmdWithRegistry(files []string, strictMode bool) tea.Cmd {
 				FilePath: pl.FilePath,
 				Line:     pl.Line,
 				Text:     pl.Text,
+				Parser:   parser.FileTypeForFile(pl.FilePath),
+				Type:     pl.Type.String(),
 			}
 		}
 
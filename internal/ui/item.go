@@ -55,13 +55,25 @@ func (i ResultItem) Description() string {
 	case checker.StatusAlive:
 		return fmt.Sprintf("%s[%d] %s", url, r.StatusCode, r.Link.FilePath)
 
-	case checker.StatusRedirect:
+	case checker.StatusRedirect, checker.StatusLongRedirect, checker.StatusLoginRequired:
 		finalURL := r.FinalURL
 		if len(finalURL) > 40 {
 			finalURL = finalURL[:37] + "..."
 		}
 		return fmt.Sprintf("%s→ %s | %s", url, finalURL, r.Link.FilePath)
 
+	case checker.StatusBrokenAnchor:
+		return fmt.Sprintf("%sNo #%s on page | %s", url, r.Anchor, r.Link.FilePath)
+
+	case checker.StatusSuspect:
+		return fmt.Sprintf("%sLooks like a soft 404 | %s", url, r.Link.FilePath)
+
+	case checker.StatusInsecure, checker.StatusTLSError:
+		if r.TLS != nil {
+			return fmt.Sprintf("%sCertificate %s | %s", url, r.TLS.Problem, r.Link.FilePath)
+		}
+		return fmt.Sprintf("%sCertificate problem | %s", url, r.Link.FilePath)
+
 	case checker.StatusBlocked:
 		return fmt.Sprintf("%s403 Forbidden | %s", url, r.Link.FilePath)
 
@@ -84,6 +96,9 @@ func (i ResultItem) Description() string {
 		}
 		return fmt.Sprintf("%sDuplicate | %s", url, r.Link.FilePath)
 
+	case checker.StatusSkipped:
+		return fmt.Sprintf("%sNot checked (budget) | %s", url, r.Link.FilePath)
+
 	default:
 		return url + r.Link.FilePath
 	}
@@ -104,7 +119,7 @@ func (i ResultItem) DetailView() string {
 	case checker.StatusAlive:
 		b.WriteString(fmt.Sprintf("│ %s  %d\n", DetailLabelStyle.Render("HTTP Code:"), r.StatusCode))
 
-	case checker.StatusRedirect:
+	case checker.StatusRedirect, checker.StatusLongRedirect, checker.StatusLoginRequired:
 		b.WriteString(fmt.Sprintf("│ %s  %d\n", DetailLabelStyle.Render("Original:"), r.StatusCode))
 		b.WriteString(fmt.Sprintf("│ %s  %s\n", DetailLabelStyle.Render("Chain:"), formatRedirectChain(r)))
 		b.WriteString(fmt.Sprintf("│ %s  %s\n", DetailLabelStyle.Render("Final URL:"), r.FinalURL))
@@ -112,6 +127,32 @@ func (i ResultItem) DetailView() string {
 		b.WriteString("│\n")
 		b.WriteString(fmt.Sprintf("│ %s\n", DetailNoteStyle.Render("Note: "+r.Status.Description())))
 
+	case checker.StatusBrokenAnchor:
+		b.WriteString(fmt.Sprintf("│ %s  %d\n", DetailLabelStyle.Render("HTTP Code:"), r.StatusCode))
+		b.WriteString(fmt.Sprintf("│ %s  #%s\n", DetailLabelStyle.Render("Missing Anchor:"), r.Anchor))
+		b.WriteString("│\n")
+		b.WriteString(fmt.Sprintf("│ %s\n", DetailNoteStyle.Render("Note: "+r.Status.Description())))
+
+	case checker.StatusInsecure, checker.StatusTLSError:
+		if r.StatusCode > 0 {
+			b.WriteString(fmt.Sprintf("│ %s  %d\n", DetailLabelStyle.Render("HTTP Code:"), r.StatusCode))
+		}
+		if r.TLS != nil {
+			b.WriteString(fmt.Sprintf("│ %s  %s for %s\n",
+				DetailLabelStyle.Render("Certificate:"), r.TLS.Problem, r.TLS.Host))
+			b.WriteString(fmt.Sprintf("│ %s  %s\n", DetailLabelStyle.Render("Detail:"), r.TLS.Detail))
+		}
+		b.WriteString("│\n")
+		b.WriteString(fmt.Sprintf("│ %s\n", DetailNoteStyle.Render("Note: "+r.Status.Description())))
+
+	case checker.StatusSuspect:
+		b.WriteString(fmt.Sprintf("│ %s  %d\n", DetailLabelStyle.Render("HTTP Code:"), r.StatusCode))
+		for _, hint := range r.Hints {
+			b.WriteString(fmt.Sprintf("│ %s  %s\n", DetailLabelStyle.Render("Hint:"), hint))
+		}
+		b.WriteString("│\n")
+		b.WriteString(fmt.Sprintf("│ %s\n", DetailNoteStyle.Render("Note: "+r.Status.Description())))
+
 	case checker.StatusBlocked:
 		b.WriteString(fmt.Sprintf("│ %s  %d\n", DetailLabelStyle.Render("HTTP Code:"), r.StatusCode))
 		b.WriteString("│\n")
@@ -145,6 +186,13 @@ func (i ResultItem) DetailView() string {
 		}
 		b.WriteString("│\n")
 		b.WriteString(fmt.Sprintf("│ %s\n", DetailNoteStyle.Render("Note: "+r.Status.Description())))
+
+	case checker.StatusSkipped:
+		b.WriteString(fmt.Sprintf("│ %s  %s\n", DetailLabelStyle.Render("Reason:"), r.Error))
+	}
+
+	if r.Profile != "" {
+		b.WriteString(fmt.Sprintf("│ %s  %s\n", DetailLabelStyle.Render("Profile:"), r.Profile))
 	}
 
 	// Link text if available
@@ -177,7 +225,7 @@ func formatRedirectChain(r checker.Result) string {
 		parts = append(parts, fmt.Sprintf("%d", red.StatusCode))
 	}
 	parts = append(parts, fmt.Sprintf("%d", r.FinalStatus))
-	return strings.Join(parts, " → ")
+	return fmt.Sprintf("%s (%d hop(s))", strings.Join(parts, " → "), r.ChainLength())
 }
 
 // ResultsToItems converts a slice of checker.Result to ResultItems.
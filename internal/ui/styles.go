@@ -127,14 +127,28 @@ func StatusBadge(status checker.LinkStatus) string {
 		return BadgeAlive.Render("OK")
 	case checker.StatusRedirect:
 		return BadgeRedirect.Render("REDIRECT")
+	case checker.StatusLongRedirect:
+		return BadgeRedirect.Render("LONG REDIRECT")
+	case checker.StatusLoginRequired:
+		return BadgeBlocked.Render("LOGIN REQUIRED")
+	case checker.StatusBrokenAnchor:
+		return BadgeRedirect.Render("BROKEN ANCHOR")
+	case checker.StatusInsecure:
+		return BadgeRedirect.Render("INSECURE")
+	case checker.StatusSuspect:
+		return BadgeRedirect.Render("SUSPECT")
 	case checker.StatusBlocked:
 		return BadgeBlocked.Render("BLOCKED")
 	case checker.StatusDead:
 		return BadgeDead.Render("DEAD")
 	case checker.StatusError:
 		return BadgeError.Render("ERROR")
+	case checker.StatusTLSError:
+		return BadgeError.Render("TLS ERROR")
 	case checker.StatusDuplicate:
 		return BadgeDuplicate.Render("DUPLICATE")
+	case checker.StatusSkipped:
+		return BadgeDuplicate.Render("SKIPPED")
 	default:
 		return BadgeError.Render("???")
 	}
@@ -145,13 +159,14 @@ func StatusTextStyle(status checker.LinkStatus) lipgloss.Style {
 	switch status {
 	case checker.StatusAlive:
 		return SuccessStyle
-	case checker.StatusRedirect:
+	case checker.StatusRedirect, checker.StatusLongRedirect, checker.StatusLoginRequired, checker.StatusBrokenAnchor,
+		checker.StatusInsecure, checker.StatusSuspect:
 		return WarningStyle
 	case checker.StatusBlocked:
 		return BlockedStyle
-	case checker.StatusDead, checker.StatusError:
+	case checker.StatusDead, checker.StatusError, checker.StatusTLSError:
 		return ErrorStyle
-	case checker.StatusDuplicate:
+	case checker.StatusDuplicate, checker.StatusSkipped:
 		return DuplicateStyle
 	default:
 		return NormalStyle
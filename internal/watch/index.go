@@ -0,0 +1,206 @@
+// Package watch re-checks the links of files as they change, for gone
+// watch. A Watcher reports which files changed, and an Index checks only
+// the links new to them, reusing the results of URLs already checked.
+package watch
+
+import (
+	"context"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/leonardomso/gone/internal/checker"
+	"github.com/leonardomso/gone/internal/monitor"
+)
+
+// Change is what an update did to the links of one file.
+type Change struct {
+	File    string
+	Removed bool             // The file no longer exists
+	Added   []checker.Result // Results of the links new to the file
+	Dropped []string         // URLs the file no longer links to
+}
+
+// Index keeps the links of every watched file and the last result of every
+// URL they link to.
+type Index struct {
+	checker monitor.Checker
+	files   map[string][]checker.Link
+	results map[string]checker.Result
+	refs    map[string]int // Number of files linking to each URL
+}
+
+// NewIndex creates an empty Index checking links with c.
+func NewIndex(c monitor.Checker) *Index {
+	return &Index{
+		checker: c,
+		files:   map[string][]checker.Link{},
+		results: map[string]checker.Result{},
+		refs:    map[string]int{},
+	}
+}
+
+// Update replaces the links of the given files, keyed by path, and returns
+// the changes, sorted by file. Links are new to a file if it didn't link to
+// their URL before; only the URLs no watched file linked to are checked, all
+// in one run. Results of URLs no file links to anymore are forgotten.
+func (ix *Index) Update(ctx context.Context, files map[string][]checker.Link) []Change {
+	var toCheck []checker.Link
+	queued := map[string]bool{}
+	added := map[string][]checker.Link{}
+	var changes []Change
+
+	for _, file := range slices.Sorted(maps.Keys(files)) {
+		links := files[file]
+		before := urlSet(ix.files[file])
+		after := urlSet(links)
+
+		change := Change{File: file}
+		for _, link := range links {
+			if before[link.URL] {
+				continue
+			}
+			added[file] = append(added[file], link)
+			if _, known := ix.results[link.URL]; !known && !queued[link.URL] {
+				queued[link.URL] = true
+				toCheck = append(toCheck, link)
+			}
+		}
+		for url := range before {
+			if !after[url] {
+				change.Dropped = append(change.Dropped, url)
+			}
+		}
+		slices.Sort(change.Dropped)
+		ix.setLinks(file, links, before, after)
+		changes = append(changes, change)
+	}
+
+	if len(toCheck) > 0 {
+		for r := range ix.checker.Check(ctx, toCheck) {
+			if !r.IsDuplicate() {
+				ix.results[r.Link.URL] = r
+			}
+		}
+	}
+
+	for i := range changes {
+		for _, link := range added[changes[i].File] {
+			if r, ok := ix.results[link.URL]; ok {
+				r.Link = link
+				changes[i].Added = append(changes[i].Added, r)
+			}
+		}
+	}
+	ix.prune()
+	return changes
+}
+
+// Remove forgets the files at the given paths, and the files under them if
+// they were directories, and returns a change for each file forgotten.
+func (ix *Index) Remove(paths ...string) []Change {
+	var changes []Change
+	for _, file := range slices.Sorted(maps.Keys(ix.files)) {
+		if !slices.ContainsFunc(paths, func(path string) bool { return within(file, path) }) {
+			continue
+		}
+		before := urlSet(ix.files[file])
+		change := Change{File: file, Removed: true}
+		for url := range before {
+			change.Dropped = append(change.Dropped, url)
+		}
+		slices.Sort(change.Dropped)
+		ix.setLinks(file, nil, before, nil)
+		delete(ix.files, file)
+		changes = append(changes, change)
+	}
+	ix.prune()
+	return changes
+}
+
+// Results returns the last result of every link of every watched file,
+// sorted by file and line.
+func (ix *Index) Results() []checker.Result {
+	var results []checker.Result
+	for _, file := range slices.Sorted(maps.Keys(ix.files)) {
+		for _, link := range ix.files[file] {
+			if r, ok := ix.results[link.URL]; ok {
+				r.Link = link
+				results = append(results, r)
+			}
+		}
+	}
+	return results
+}
+
+// Links returns a link to every URL the watched files link to, the first
+// by file and line, to check them all again.
+func (ix *Index) Links() []checker.Link {
+	var links []checker.Link
+	seen := map[string]bool{}
+	for _, file := range slices.Sorted(maps.Keys(ix.files)) {
+		for _, link := range ix.files[file] {
+			if !seen[link.URL] {
+				seen[link.URL] = true
+				links = append(links, link)
+			}
+		}
+	}
+	return links
+}
+
+// Refresh replaces the results of URLs with those of a new check, such as
+// of Links. Results of URLs no watched file links to anymore are dropped.
+func (ix *Index) Refresh(results []checker.Result) {
+	for _, r := range results {
+		if !r.IsDuplicate() && ix.refs[r.Link.URL] > 0 {
+			ix.results[r.Link.URL] = r
+		}
+	}
+}
+
+// Paths returns the paths of the watched files, sorted.
+func (ix *Index) Paths() []string {
+	return slices.Sorted(maps.Keys(ix.files))
+}
+
+// Files returns the number of watched files.
+func (ix *Index) Files() int {
+	return len(ix.files)
+}
+
+// setLinks replaces the links of file, counting the URLs it links to.
+func (ix *Index) setLinks(file string, links []checker.Link, before, after map[string]bool) {
+	for url := range before {
+		ix.refs[url]--
+	}
+	for url := range after {
+		ix.refs[url]++
+	}
+	ix.files[file] = links
+}
+
+// prune forgets the results of URLs no file links to.
+func (ix *Index) prune() {
+	for url, n := range ix.refs {
+		if n <= 0 {
+			delete(ix.refs, url)
+			delete(ix.results, url)
+		}
+	}
+}
+
+// within reports whether file is path or is under it.
+func within(file, path string) bool {
+	return file == path || strings.HasPrefix(file, path+string(filepath.Separator))
+}
+
+// urlSet returns the URLs of links.
+func urlSet(links []checker.Link) map[string]bool {
+	set := make(map[string]bool, len(links))
+	for _, link := range links {
+		set[link.URL] = true
+	}
+	return set
+}
@@ -0,0 +1,149 @@
+package watch
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/leonardomso/gone/internal/checker"
+)
+
+// fakeChecker reports every URL as dead if listed in dead, and alive
+// otherwise, and records the URLs it checked.
+type fakeChecker struct {
+	dead    map[string]bool
+	checked []string
+}
+
+func (f *fakeChecker) Check(_ context.Context, links []checker.Link) <-chan checker.Result {
+	out := make(chan checker.Result, len(links))
+	for _, l := range links {
+		f.checked = append(f.checked, l.URL)
+		status := checker.StatusAlive
+		if f.dead[l.URL] {
+			status = checker.StatusDead
+		}
+		out <- checker.Result{Link: l, Status: status}
+	}
+	close(out)
+	return out
+}
+
+func link(file, url string, line int) checker.Link {
+	return checker.Link{URL: url, FilePath: file, Line: line}
+}
+
+func TestIndex_Update(t *testing.T) {
+	t.Parallel()
+
+	fc := &fakeChecker{dead: map[string]bool{"https://dead.com": true}}
+	ix := NewIndex(fc)
+	ctx := context.Background()
+
+	changes := ix.Update(ctx, map[string][]checker.Link{
+		"b.md": {link("b.md", "https://a.com", 1)},
+		"a.md": {link("a.md", "https://a.com", 1), link("a.md", "https://dead.com", 2)},
+	})
+	require.Len(t, changes, 2)
+	assert.Equal(t, "a.md", changes[0].File)
+	assert.Len(t, changes[0].Added, 2)
+	assert.Equal(t, "b.md", changes[1].File)
+	require.Len(t, changes[1].Added, 1)
+	assert.Equal(t, "b.md", changes[1].Added[0].Link.FilePath, "results carry the file's own link")
+	assert.Equal(t, []string{"https://a.com", "https://dead.com"}, fc.checked, "each URL is checked once")
+	assert.Equal(t, 2, ix.Files())
+
+	// Only the link new to the file is checked and reported
+	fc.checked = nil
+	changes = ix.Update(ctx, map[string][]checker.Link{
+		"a.md": {link("a.md", "https://a.com", 3), link("a.md", "https://new.com", 4)},
+	})
+	require.Len(t, changes, 1)
+	require.Len(t, changes[0].Added, 1)
+	assert.Equal(t, "https://new.com", changes[0].Added[0].Link.URL)
+	assert.Equal(t, []string{"https://dead.com"}, changes[0].Dropped)
+	assert.Equal(t, []string{"https://new.com"}, fc.checked)
+
+	// A URL another file already links to isn't checked again
+	fc.checked = nil
+	changes = ix.Update(ctx, map[string][]checker.Link{
+		"c.md": {link("c.md", "https://new.com", 1)},
+	})
+	require.Len(t, changes[0].Added, 1)
+	assert.Equal(t, checker.StatusAlive, changes[0].Added[0].Status)
+	assert.Empty(t, fc.checked)
+
+	results := ix.Results()
+	require.Len(t, results, 4)
+	assert.Equal(t, "a.md", results[0].Link.FilePath)
+	assert.Equal(t, 3, results[0].Link.Line)
+}
+
+func TestIndex_Update_ForgetsUnlinkedURLs(t *testing.T) {
+	t.Parallel()
+
+	fc := &fakeChecker{}
+	ix := NewIndex(fc)
+	ctx := context.Background()
+
+	ix.Update(ctx, map[string][]checker.Link{"a.md": {link("a.md", "https://a.com", 1)}})
+	ix.Update(ctx, map[string][]checker.Link{"a.md": nil})
+	fc.checked = nil
+
+	// The URL was forgotten, so adding it back checks it again
+	ix.Update(ctx, map[string][]checker.Link{"a.md": {link("a.md", "https://a.com", 1)}})
+	assert.Equal(t, []string{"https://a.com"}, fc.checked)
+}
+
+func TestIndex_Remove(t *testing.T) {
+	t.Parallel()
+
+	ix := NewIndex(&fakeChecker{})
+	guide := filepath.Join("docs", "guide.md")
+	intro := filepath.Join("docs", "intro.md")
+	ix.Update(context.Background(), map[string][]checker.Link{
+		"README.md":   {link("README.md", "https://a.com", 1)},
+		guide:         {link(guide, "https://b.com", 1), link(guide, "https://a.com", 2)},
+		intro:         {link(intro, "https://c.com", 1)},
+		"docs-old.md": {link("docs-old.md", "https://d.com", 1)},
+	})
+
+	changes := ix.Remove("docs")
+	require.Len(t, changes, 2)
+	assert.Equal(t, Change{File: guide, Removed: true, Dropped: []string{"https://a.com", "https://b.com"}}, changes[0])
+	assert.Equal(t, intro, changes[1].File)
+	assert.Equal(t, 2, ix.Files())
+	assert.Len(t, ix.Results(), 2)
+
+	assert.Empty(t, ix.Remove("missing.md"))
+}
+
+func TestIndex_LinksAndRefresh(t *testing.T) {
+	t.Parallel()
+
+	fc := &fakeChecker{}
+	ix := NewIndex(fc)
+	ix.Update(context.Background(), map[string][]checker.Link{
+		"b.md": {link("b.md", "https://a.com", 1), link("b.md", "https://b.com", 2)},
+		"a.md": {link("a.md", "https://a.com", 4)},
+	})
+	assert.Equal(t, []string{"a.md", "b.md"}, ix.Paths())
+
+	links := ix.Links()
+	assert.Equal(t, []checker.Link{link("a.md", "https://a.com", 4), link("b.md", "https://b.com", 2)}, links)
+
+	ix.Refresh([]checker.Result{
+		{Link: links[0], Status: checker.StatusDead},
+		{Link: link("c.md", "https://gone.com", 1), Status: checker.StatusDead},
+	})
+	results := ix.Results()
+	require.Len(t, results, 3)
+	assert.Equal(t, checker.StatusDead, results[0].Status)
+	assert.Equal(t, checker.StatusDead, results[1].Status, "every link to the URL gets the new result")
+	assert.Equal(t, "b.md", results[1].Link.FilePath)
+	assert.Equal(t, checker.StatusAlive, results[2].Status)
+	assert.NotContains(t, ix.Links(), link("c.md", "https://gone.com", 1), "unlinked URLs aren't added")
+}
@@ -0,0 +1,171 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// DefaultDebounce is how long a Watcher waits for more changes before
+// reporting them, so an editor saving a file in several steps is reported once.
+const DefaultDebounce = 200 * time.Millisecond
+
+// Watcher reports changes to the files under a directory, or to a single
+// file. Hidden directories such as .git are skipped, as by the scanner.
+type Watcher struct {
+	root     string
+	match    func(path string) bool
+	debounce time.Duration
+	onError  func(error)
+	fs       *fsnotify.Watcher
+}
+
+// NewWatcher starts watching root. Only changes to files match accepts are
+// reported.
+func NewWatcher(root string, match func(path string) bool) (*Watcher, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("starting file watcher: %w", err)
+	}
+
+	w := &Watcher{root: root, match: match, debounce: DefaultDebounce, fs: fsw}
+	if info.IsDir() {
+		err = w.addTree(root)
+	} else {
+		// Editors often replace a file instead of writing it, so its
+		// directory is watched
+		err = fsw.Add(filepath.Dir(root))
+	}
+	if err != nil {
+		_ = fsw.Close()
+		return nil, fmt.Errorf("watching %s: %w", root, err)
+	}
+	return w, nil
+}
+
+// SetDebounce sets how long the watcher waits for more changes before
+// reporting them (see DefaultDebounce).
+func (w *Watcher) SetDebounce(d time.Duration) {
+	w.debounce = d
+}
+
+// OnError sets a handler for errors watching files. Errors never stop the
+// watcher.
+func (w *Watcher) OnError(fn func(error)) {
+	w.onError = fn
+}
+
+// Close stops watching files.
+func (w *Watcher) Close() error {
+	return w.fs.Close()
+}
+
+// Run calls onChange with the paths of the files that changed, sorted,
+// once no other change came for the debounce delay, until ctx is canceled
+// or the watcher is closed. Removed paths are reported whether or not they
+// match, since a removed directory can't be told from a file.
+func (w *Watcher) Run(ctx context.Context, onChange func(paths []string)) error {
+	pending := map[string]bool{}
+	timer := time.NewTimer(w.debounce)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err, ok := <-w.fs.Errors:
+			if !ok {
+				return nil
+			}
+			w.reportError(err)
+		case event, ok := <-w.fs.Events:
+			if !ok {
+				return nil
+			}
+			if w.collect(event, pending) {
+				timer.Reset(w.debounce)
+			}
+		case <-timer.C:
+			paths := slices.Sorted(maps.Keys(pending))
+			clear(pending)
+			onChange(paths)
+		}
+	}
+}
+
+// collect adds the paths an event changed to pending, and reports whether
+// it added any. New directories are watched, and the files already in them
+// added. Paths are cleaned, to match those of a scan of the same root.
+func (w *Watcher) collect(event fsnotify.Event, pending map[string]bool) bool {
+	path := filepath.Clean(event.Name)
+	switch {
+	case event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename):
+		pending[path] = true
+		return true
+	case event.Has(fsnotify.Create):
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			return w.collectTree(path, pending)
+		}
+	case !event.Has(fsnotify.Write):
+		return false
+	}
+	if !w.match(path) {
+		return false
+	}
+	pending[path] = true
+	return true
+}
+
+// collectTree watches a new directory and adds its matching files to
+// pending.
+func (w *Watcher) collectTree(dir string, pending map[string]bool) bool {
+	if err := w.addTree(dir); err != nil {
+		w.reportError(fmt.Errorf("watching %s: %w", dir, err))
+	}
+	found := false
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !w.match(path) {
+			return nil
+		}
+		pending[path] = true
+		found = true
+		return nil
+	})
+	return found
+}
+
+// addTree watches dir and the directories under it, skipping hidden ones.
+func (w *Watcher) addTree(dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if strings.HasPrefix(d.Name(), ".") && path != w.root {
+			return filepath.SkipDir
+		}
+		return w.fs.Add(path)
+	})
+}
+
+// reportError passes err to the error handler, if any.
+func (w *Watcher) reportError(err error) {
+	if w.onError != nil {
+		w.onError(err)
+	}
+}
@@ -0,0 +1,112 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// watchDir starts a watcher on dir reporting markdown files, and returns
+// the channel its batches are sent to.
+func watchDir(t *testing.T, dir string) <-chan []string {
+	t.Helper()
+
+	w, err := NewWatcher(dir, func(path string) bool {
+		return strings.HasSuffix(path, ".md") && !strings.Contains(path, string(filepath.Separator)+".")
+	})
+	require.NoError(t, err)
+	w.SetDebounce(50 * time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	batches := make(chan []string, 10)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = w.Run(ctx, func(paths []string) { batches <- paths })
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+		_ = w.Close()
+	})
+	return batches
+}
+
+// nextBatch waits for the next batch of changed paths.
+func nextBatch(t *testing.T, batches <-chan []string) []string {
+	t.Helper()
+	select {
+	case paths := <-batches:
+		return paths
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change reported")
+		return nil
+	}
+}
+
+func TestWatcher_Run(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	readme := filepath.Join(dir, "README.md")
+	require.NoError(t, os.WriteFile(readme, []byte("# Readme\n"), 0o600))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, ".git"), 0o750))
+	batches := watchDir(t, dir)
+
+	// Several writes are reported once; other files and hidden directories aren't
+	require.NoError(t, os.WriteFile(readme, []byte("# Readme\n\nhttps://a.com\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("x"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".git", "HEAD.md"), []byte("x"), 0o600))
+	require.NoError(t, os.WriteFile(readme, []byte("# Readme\n\nhttps://b.com\n"), 0o600))
+	assert.Equal(t, []string{readme}, nextBatch(t, batches))
+
+	// Files in new directories are reported, and the directories watched
+	guide := filepath.Join(dir, "docs", "guide.md")
+	require.NoError(t, os.MkdirAll(filepath.Dir(guide), 0o750))
+	require.NoError(t, os.WriteFile(guide, []byte("# Guide\n"), 0o600))
+	assert.Contains(t, nextBatch(t, batches), guide)
+
+	require.NoError(t, os.WriteFile(guide, []byte("# Guide\n\nhttps://c.com\n"), 0o600))
+	assert.Equal(t, []string{guide}, nextBatch(t, batches))
+
+	// Removed directories are reported as they are
+	require.NoError(t, os.RemoveAll(filepath.Dir(guide)))
+	assert.Contains(t, nextBatch(t, batches), filepath.Dir(guide))
+}
+
+func TestWatcher_SingleFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	readme := filepath.Join(dir, "README.md")
+	require.NoError(t, os.WriteFile(readme, []byte("# Readme\n"), 0o600))
+	batches := watchDir(t, readme)
+
+	require.NoError(t, os.WriteFile(readme, []byte("# Readme\n\nhttps://a.com\n"), 0o600))
+	assert.Equal(t, []string{readme}, nextBatch(t, batches))
+}
+
+func TestNewWatcher_MissingRoot(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewWatcher(filepath.Join(t.TempDir(), "missing"), func(string) bool { return true })
+	assert.Error(t, err)
+}
+
+func TestWatcher_CleansPaths(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	readme := filepath.Join(dir, "README.md")
+	require.NoError(t, os.WriteFile(readme, []byte("# Readme\n"), 0o600))
+	batches := watchDir(t, dir+string(filepath.Separator)+".")
+
+	require.NoError(t, os.WriteFile(readme, []byte("# Readme\n\nhttps://a.com\n"), 0o600))
+	assert.Equal(t, []string{readme}, nextBatch(t, batches))
+}